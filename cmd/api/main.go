@@ -77,16 +77,18 @@ func main() {
 	// リポジトリ
 	userRepository := repository.NewUserRepository()
 	emailVerificationRepository := repository.NewEmailVerificationRepository()
+	pauseRepository := repository.NewPauseRepository()
+	blockedDateRepository := repository.NewBlockedDateRepository()
 	categoryRepository := repository.NewCategoryRepository()
 	boxRepository := repository.NewBoxRepository()
 	patternRepository := repository.NewPatternRepository()
 	itemRepository := repository.NewItemRepository()
 
 	// ユースケース
-	userUsecase := userUsecase.NewUserUsecase(userRepository, emailVerificationRepository, transactionManager, cryptoService, hasher, emailSender, tokenGenerator)
+	userUsecase := userUsecase.NewUserUsecase(userRepository, emailVerificationRepository, pauseRepository, blockedDateRepository, transactionManager, cryptoService, hasher, emailSender, tokenGenerator)
 	categoryUsecase := categoryUsecase.NewCategoryUsecase(categoryRepository)
-	boxUsecase := boxUsecase.NewBoxUsecase(boxRepository)
-	patternUsecase := patternUsecase.NewPatternUsecase(patternRepository, itemRepository, transactionManager)
+	boxUsecase := boxUsecase.NewBoxUsecase(boxRepository, itemRepository, patternRepository, transactionManager, scheduler)
+	patternUsecase := patternUsecase.NewPatternUsecase(patternRepository, itemRepository, transactionManager, scheduler, userRepository, boxRepository)
 	itemUsecase := itemUsecase.NewItemUsecase(categoryRepository, boxRepository, itemRepository, patternRepository, transactionManager, scheduler)
 
 	// コントローラー
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"time"
+
+	BatchDomain "github.com/minminseo/recall-setter/domain/batch"
+	"github.com/minminseo/recall-setter/infrastructure/scheduler"
+	batchUsecase "github.com/minminseo/recall-setter/usecase/batch"
+)
+
+// バッチで定期実行するジョブを登録する。ジョブを追加する場合はここに登録する
+func registerJobs(s *scheduler.Scheduler, uc batchUsecase.IBatchUsecase) error {
+	// IANAのタイムゾーンはUTCからのオフセットが全部15分単位なので、0, 15, 30, 45分のタイミングで実行すれば各タイムゾーンの0時を拾える
+	everyQuarterHour, err := scheduler.ParseCron("*/15 * * * *")
+	if err != nil {
+		return err
+	}
+	return s.Register(scheduler.Job{
+		Name:     BatchDomain.JobNameUpdateOverdueScheduledDates,
+		Schedule: everyQuarterHour,
+		Timeout:  10 * time.Minute,
+		// 停止中に実行されなかった実行枠の分を、次の15分を待たずに起動時の実行枠でまとめて処理する
+		// 起動時の実行枠で実行済みの場合は実行記録から判定して実行しない
+		RunOnStart: true,
+		// 実行枠毎に実行記録を残し、同じ実行枠では2回実行しない
+		Run: uc.RunUpdateOverdueScheduledDates,
+	})
+}
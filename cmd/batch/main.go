@@ -2,20 +2,56 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/minminseo/recall-setter/infrastructure/db"
 	"github.com/minminseo/recall-setter/infrastructure/repository"
+	"github.com/minminseo/recall-setter/infrastructure/scheduler"
 	batchUsecase "github.com/minminseo/recall-setter/usecase/batch"
 )
 
 func main() {
+	once := flag.Bool("once", false, "スケジュール通りに実行せず、登録したジョブを現在時刻（--nowを指定した場合はその時刻）で1回だけ実行して終了する（cronからの実行用）")
+	jobName := flag.String("job", "", "--onceで実行するジョブ名。指定しない場合は登録したジョブを全部実行する")
+	nowFlag := flag.String("now", "", "--onceと--dry-runの基準時刻をRFC3339形式で指定する（例: 2026-01-01T00:00:00Z）。タイムゾーン毎の日付けの跨ぎ方を再現するために使う。--onceでは指定した時刻の実行枠で実行記録を残す")
+	dryRun := flag.Bool("dry-run", false, "ずれる復習日をユーザーと復習物毎に出力して終了する。更新はロールバックし、実行記録も残さない")
+	flag.Parse()
+
 	// ログ収集ツールとの連携想定でJSON形式で出力
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	slog.SetDefault(logger)
 
+	now := time.Now()
+	if *nowFlag != "" {
+		// 定期実行は実際の時刻で実行枠を決めるため、基準時刻を指定できるのは1回だけ実行する場合に限る
+		if !*once && !*dryRun {
+			slog.Error("--nowは--onceか--dry-runと合わせて指定してください。")
+			os.Exit(2)
+		}
+		parsed, err := time.Parse(time.RFC3339, *nowFlag)
+		if err != nil {
+			slog.Error("--nowの形式が不正です。RFC3339形式で指定してください。", "now", *nowFlag, "error", err)
+			os.Exit(2)
+		}
+		// --onceは指定した時刻の実行枠で実行記録を残す。未来の実行枠を記録すると、以降の定期実行でその時刻まで日付けを跨いだタイムゾーンがないと判定されるため、未来の時刻は指定できない
+		if !*dryRun && parsed.After(now) {
+			slog.Error("--onceの--nowに未来の時刻は指定できません。", "now", *nowFlag)
+			os.Exit(2)
+		}
+		now = parsed
+	}
+
+	if *jobName != "" && !*once {
+		slog.Error("--jobは--onceと合わせて指定してください。")
+		os.Exit(2)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -27,53 +63,88 @@ func main() {
 	defer pool.Close()
 
 	batchRepository := repository.NewBatchRepository()
-	batchUsecase := batchUsecase.NewBatchUsecase(batchRepository)
-
-	runAlignedQuarterHourlyScheduler(batchUsecase)
-}
-
-// タイムアウト付きのContextを生成し、バッチ処理の単一の実行をカプセル化
-func executeBatch(uc batchUsecase.IBatchUsecase, t time.Time) {
-	slog.Info("15分間隔バッチ処理を開始します。", "実行時刻", t.Format(time.RFC3339))
-
-	// バッチ処理一回ごとに独立したタイムアウト付きContextを生成
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
-	defer cancel()
-
-	if err := uc.ExecuteUpdateOverdueScheduledDates(ctx); err != nil {
-		slog.Error("バッチ処理中にエラーが発生しました。", "error", err)
+	transactionManager := repository.NewTransactionManager(pool)
+	// 複数のプロセスで動かしても、同じ時刻に実行するのはロックを取得できた1つだけにする
+	locker := repository.NewAdvisoryLocker(pool)
+	batchUsecase := batchUsecase.NewBatchUsecase(batchRepository, transactionManager, locker)
+
+	if *dryRun {
+		if err := executeDryRun(batchUsecase, now); err != nil {
+			pool.Close()
+			os.Exit(1)
+		}
 		return
 	}
-}
 
-// IANAのタイムゾーンはUTCからのオフセットが全部15分単位なので、0, 15, 30, 45分のタイミングで実行
-func runAlignedQuarterHourlyScheduler(uc batchUsecase.IBatchUsecase) {
-	slog.Info("壁時計同期・15分間隔実行バッチスケジューラーを起動しました。")
+	jobScheduler := scheduler.NewScheduler()
+	if err := registerJobs(jobScheduler, batchUsecase); err != nil {
+		slog.Error("ジョブの登録に失敗しました。", "error", err)
+		pool.Close()
+		os.Exit(1)
+	}
 
-	// 初回実行時刻の計算と待機
-	now := time.Now()
-	// 現在時刻の「分」を15で割った余りを計算し、次の15分マークまでの待機時間を算出
-	remainder := now.Minute() % 15
-	waitMinutes := 15 - remainder
+	if *once {
+		if err := executeOnce(jobScheduler, *jobName, now); err != nil {
+			pool.Close()
+			os.Exit(1)
+		}
+		return
+	}
 
-	// 次の実行時刻を算出
-	// 待機時間を足した後、Truncateで秒以下を切り捨てて正確な次の実行時刻を算出
-	nextRun := now.Add(time.Duration(waitMinutes) * time.Minute).Truncate(time.Minute)
+	// 停止のシグナルを受け取ったら新しい実行を始めずに、実行中のジョブが終わるのを待って終了する
+	stopCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	jobScheduler.Start(stopCtx)
+}
 
-	slog.Info("最初のバッチ実行まで待機します。", "初回実行時刻", nextRun.Format(time.RFC3339))
+// jobNameのジョブ（指定しない場合は登録したジョブを全部）を1回ずつ実行する。失敗したジョブがあっても残りのジョブは実行する
+func executeOnce(s *scheduler.Scheduler, jobName string, now time.Time) error {
+	names := s.JobNames()
+	if jobName != "" {
+		names = []string{jobName}
+	}
 
-	// 次の実行時間までゴルーチンを休止（CPUリソースを無駄に消費するビジーループ対策）
-	time.Sleep(time.Until(nextRun))
+	var errs []error
+	for _, name := range names {
+		if err := s.RunOnce(context.Background(), name, now); err != nil {
+			if errors.Is(err, scheduler.ErrJobNotFound) {
+				slog.Error("ジョブが登録されていません。", "job", name, "jobs", s.JobNames())
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
 
-	// 算出した初回実行時刻になったら、最初のバッチを実行（tickerの起動が0秒のタイミングからずれないようにゴルーチン使用）
-	go executeBatch(uc, time.Now())
+// ずれる復習日をユーザーと復習物毎に1件ずつ出力する
+func executeDryRun(uc batchUsecase.IBatchUsecase, now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
 
-	// 初回実行後は、Tickerで15分ごとにバッチを実行するように設定
-	ticker := time.NewTicker(15 * time.Minute)
-	defer ticker.Stop()
+	output, err := uc.DryRunUpdateOverdueScheduledDates(ctx, now)
+	if err != nil {
+		slog.Error("ドライラン中にエラーが発生しました。", "error", err)
+		return err
+	}
 
-	// ticker.Cからの通知を待ち、15分ごとにバッチを実行する無限ループに入る
-	for execTime := range ticker.C {
-		go executeBatch(uc, execTime)
+	itemIDs := make(map[string]struct{})
+	for _, c := range output.Changes {
+		itemIDs[c.ItemID] = struct{}{}
+		slog.Info("ずれる復習日",
+			"user_id", c.UserID,
+			"item_id", c.ItemID,
+			"review_date_id", c.ReviewDateID,
+			"step_number", c.StepNumber,
+			"変更前", c.OldDate.Format(time.DateOnly),
+			"変更後", c.NewDate.Format(time.DateOnly),
+			"日数", c.Days,
+		)
 	}
+	slog.Info("ドライランが完了しました。更新はロールバックしました。",
+		"基準時刻", now.Format(time.RFC3339),
+		"復習物数", len(itemIDs),
+		"復習日数", len(output.Changes),
+		"更新件数", output.RowsAffected,
+	)
+	return nil
 }
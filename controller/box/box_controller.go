@@ -124,6 +124,8 @@ func (bc *boxController) UpdateBox(c echo.Context) error {
 		CategoryID: categoryIDParam,
 		PatternID:  req.PatternID,
 		Name:       req.Name,
+		Today:      req.Today,
+		IsDryRun:   req.DryRun,
 	}
 	boxRes, err := bc.bu.UpdateBox(ctx, input)
 	if err != nil {
@@ -136,6 +138,24 @@ func (bc *boxController) UpdateBox(c echo.Context) error {
 		PatternID:  boxRes.PatternID,
 		Name:       boxRes.Name,
 		EditedAt:   boxRes.EditedAt,
+		IsDryRun:   req.DryRun,
+	}
+	res.ReplannedItems = make([]ReplannedItemResponse, len(boxRes.ReplannedItems))
+	for i, item := range boxRes.ReplannedItems {
+		reviewDates := make([]ReplannedReviewDateResponse, len(item.ReviewDates))
+		for j, rd := range item.ReviewDates {
+			reviewDates[j] = ReplannedReviewDateResponse{
+				StepNumber:    rd.StepNumber,
+				ScheduledDate: rd.ScheduledDate,
+				IsCompleted:   rd.IsCompleted,
+			}
+		}
+		res.ReplannedItems[i] = ReplannedItemResponse{
+			ItemID:      item.ItemID,
+			Name:        item.Name,
+			IsFinished:  item.IsFinished,
+			ReviewDates: reviewDates,
+		}
 	}
 	return c.JSON(http.StatusOK, res)
 }
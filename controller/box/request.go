@@ -8,4 +8,6 @@ type CreateBoxRequest struct {
 type UpdateBoxRequest struct {
 	PatternID string `json:"pattern_id"`
 	Name      string `json:"name"`
+	Today     string `json:"today"`
+	DryRun    bool   `json:"dry_run"`
 }
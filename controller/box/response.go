@@ -12,11 +12,26 @@ type BoxResponse struct {
 	EditedAt     time.Time `json:"edited_at"`
 }
 
+type ReplannedReviewDateResponse struct {
+	StepNumber    int    `json:"step_number"`
+	ScheduledDate string `json:"scheduled_date"`
+	IsCompleted   bool   `json:"is_completed"`
+}
+
+type ReplannedItemResponse struct {
+	ItemID      string                        `json:"item_id"`
+	Name        string                        `json:"name"`
+	IsFinished  bool                          `json:"is_finished"`
+	ReviewDates []ReplannedReviewDateResponse `json:"review_dates"`
+}
+
 type UpdateBoxResponse struct {
-	ID         string    `json:"id"`
-	UserID     string    `json:"user_id"`
-	CategoryID string    `json:"category_id"`
-	PatternID  string    `json:"pattern_id"`
-	Name       string    `json:"name"`
-	EditedAt   time.Time `json:"edited_at"`
+	ID             string                  `json:"id"`
+	UserID         string                  `json:"user_id"`
+	CategoryID     string                  `json:"category_id"`
+	PatternID      string                  `json:"pattern_id"`
+	Name           string                  `json:"name"`
+	EditedAt       time.Time               `json:"edited_at"`
+	ReplannedItems []ReplannedItemResponse `json:"replanned_items"`
+	IsDryRun       bool                    `json:"is_dry_run"`
 }
@@ -3,6 +3,7 @@ package item
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
@@ -10,6 +11,9 @@ import (
 	itemUsecase "github.com/minminseo/recall-setter/usecase/item"
 )
 
+// 復習日数の予測でdaysの指定がない場合の日数
+const defaultForecastDays = 7
+
 type itemController struct {
 	iu itemUsecase.IItemUsecase
 }
@@ -74,6 +78,7 @@ func (ic *itemController) CreateItem(c echo.Context) error {
 			InitialScheduledDate: rd.InitialScheduledDate,
 			ScheduledDate:        rd.ScheduledDate,
 			IsCompleted:          rd.IsCompleted,
+			ScheduledAt:          rd.ScheduledAt,
 		}
 	}
 
@@ -141,6 +146,8 @@ func (ic *itemController) UpdateItem(c echo.Context) error {
 			InitialScheduledDate: rd.InitialScheduledDate,
 			ScheduledDate:        rd.ScheduledDate,
 			IsCompleted:          rd.IsCompleted,
+			ScheduledAt:          rd.ScheduledAt,
+			RecallGrade:          rd.RecallGrade,
 		}
 	}
 
@@ -222,6 +229,8 @@ func (ic *itemController) UpdateReviewDates(c echo.Context) error {
 			InitialScheduledDate: rd.InitialScheduledDate,
 			ScheduledDate:        rd.ScheduledDate,
 			IsCompleted:          rd.IsCompleted,
+			ScheduledAt:          rd.ScheduledAt,
+			RecallGrade:          rd.RecallGrade,
 		}
 	}
 
@@ -279,18 +288,38 @@ func (ic *itemController) UpdateReviewDateAsCompleted(c echo.Context) error {
 		UserID:       userID,
 		ItemID:       itemID,
 		StepNumber:   req.StepNumber,
+		RecallGrade:  req.RecallGrade,
+		Today:        req.Today,
 	}
 
 	out, err := ic.iu.UpdateReviewDateAsCompleted(ctx, input)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "復習日の完了処理に失敗しました: " + err.Error()})
 	}
+	reviewDates := make([]ReviewDateResponse, len(out.ReviewDates))
+	for i, rd := range out.ReviewDates {
+		reviewDates[i] = ReviewDateResponse{
+			ReviewDateID:         rd.ReviewDateID,
+			UserID:               rd.UserID,
+			CategoryID:           rd.CategoryID,
+			BoxID:                rd.BoxID,
+			ItemID:               rd.ItemID,
+			StepNumber:           rd.StepNumber,
+			InitialScheduledDate: rd.InitialScheduledDate,
+			ScheduledDate:        rd.ScheduledDate,
+			IsCompleted:          rd.IsCompleted,
+			ScheduledAt:          rd.ScheduledAt,
+			RecallGrade:          rd.RecallGrade,
+		}
+	}
 	res := UpdateReviewDateAsCompletedResponse{
 		ReviewDateID: out.ReviewDateID,
 		UserID:       out.UserID,
 		IsCompleted:  out.IsCompleted,
 		IsFinished:   out.IsFinished,
+		RecallGrade:  out.RecallGrade,
 		EditedAt:     out.EditedAt,
+		ReviewDates:  reviewDates,
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -371,6 +400,8 @@ func (ic *itemController) UpdateItemAsUnFinishedForce(c echo.Context) error {
 			InitialScheduledDate: rd.InitialScheduledDate,
 			ScheduledDate:        rd.ScheduledDate,
 			IsCompleted:          rd.IsCompleted,
+			ScheduledAt:          rd.ScheduledAt,
+			RecallGrade:          rd.RecallGrade,
 		}
 	}
 
@@ -576,6 +607,60 @@ func (ic *itemController) CountAllDailyReviewDates(c echo.Context) error {
 
 }
 
+// 指定日から指定日数分の復習日数の予測を取得（daysの指定がない場合は7日分）
+func (ic *itemController) GetReviewForecast(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークンにユーザーIDが含まれていません"})
+	}
+	from := c.QueryParam("from")
+	days := defaultForecastDays
+	if rawDays := c.QueryParam("days"); rawDays != "" {
+		days, err = strconv.Atoi(rawDays)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "daysは整数で指定してください"})
+		}
+	}
+
+	out, err := ic.iu.GetReviewForecast(ctx, userID, from, days)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "復習日数の予測の取得に失敗しました: " + err.Error()})
+	}
+
+	res := ReviewForecastResponse{
+		From: out.From,
+		To:   out.To,
+		Days: make([]ForecastDayResponse, len(out.Days)),
+	}
+	for i, d := range out.Days {
+		byBox := make([]DailyCountGroupedByBoxResponse, len(d.ByBox))
+		for j, r := range d.ByBox {
+			byBox[j] = DailyCountGroupedByBoxResponse{
+				CategoryID: r.CategoryID,
+				BoxID:      r.BoxID,
+				Count:      r.Count,
+			}
+		}
+		unclassifiedByCategory := make([]UnclassifiedDailyDatesCountGroupedByCategoryResponse, len(d.UnclassifiedByCategory))
+		for j, r := range d.UnclassifiedByCategory {
+			unclassifiedByCategory[j] = UnclassifiedDailyDatesCountGroupedByCategoryResponse{
+				CategoryID: r.CategoryID,
+				Count:      r.Count,
+			}
+		}
+		res.Days[i] = ForecastDayResponse{
+			Date:                   d.Date,
+			TotalCount:             d.TotalCount,
+			ByBox:                  byBox,
+			UnclassifiedByCategory: unclassifiedByCategory,
+			UnclassifiedCount:      d.UnclassifiedCount,
+		}
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
 // 今日の復習日一覧取得
 func (ic *itemController) GetAllDailyReviewDates(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -608,6 +693,7 @@ func (ic *itemController) GetAllDailyReviewDates(c echo.Context) error {
 					ScheduledDate:        rd.ScheduledDate,
 					NextScheduledDate:    rd.NextScheduledDate,
 					IsCompleted:          rd.IsCompleted,
+					IsOverdue:            rd.IsOverdue,
 					ItemID:               rd.ItemID,
 					ItemName:             rd.ItemName,
 					Detail:               rd.Detail,
@@ -636,6 +722,7 @@ func (ic *itemController) GetAllDailyReviewDates(c echo.Context) error {
 				ScheduledDate:        rd.ScheduledDate,
 				NextScheduledDate:    rd.NextScheduledDate,
 				IsCompleted:          rd.IsCompleted,
+				IsOverdue:            rd.IsOverdue,
 				ItemID:               rd.ItemID,
 				ItemName:             rd.ItemName,
 				Detail:               rd.Detail,
@@ -664,6 +751,7 @@ func (ic *itemController) GetAllDailyReviewDates(c echo.Context) error {
 			ScheduledDate:        rd.ScheduledDate,
 			NextScheduledDate:    rd.NextScheduledDate,
 			IsCompleted:          rd.IsCompleted,
+			IsOverdue:            rd.IsOverdue,
 			ItemID:               rd.ItemID,
 			ItemName:             rd.ItemName,
 			Detail:               rd.Detail,
@@ -677,6 +765,35 @@ func (ic *itemController) GetAllDailyReviewDates(c echo.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (ic *itemController) GetDueNowReviewDates(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークンにユーザーIDが含まれていません"})
+	}
+
+	out, err := ic.iu.GetDueNowReviewDates(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "復習日時を過ぎた復習日の取得に失敗しました: " + err.Error()})
+	}
+	res := make([]DueNowReviewDateResponse, len(out))
+	for i, rd := range out {
+		res[i] = DueNowReviewDateResponse{
+			ReviewDateID:  rd.ReviewDateID,
+			CategoryID:    rd.CategoryID,
+			BoxID:         rd.BoxID,
+			StepNumber:    rd.StepNumber,
+			ScheduledDate: rd.ScheduledDate,
+			ScheduledAt:   rd.ScheduledAt,
+			ItemID:        rd.ItemID,
+			ItemName:      rd.ItemName,
+			Detail:        rd.Detail,
+			LearnedDate:   rd.LearnedDate,
+		}
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
 func (ic *itemController) GetFinishedItemsByBoxID(c echo.Context) error {
 	ctx := c.Request().Context()
 	userID, err := getUserIDFromContext(c)
@@ -25,8 +25,10 @@ type IItemController interface {
 	CountDailyDatesUnclassifiedByUserID(c echo.Context) error
 
 	CountAllDailyReviewDates(c echo.Context) error
+	GetReviewForecast(c echo.Context) error
 
 	GetAllDailyReviewDates(c echo.Context) error
+	GetDueNowReviewDates(c echo.Context) error
 
 	GetFinishedItemsByBoxID(c echo.Context) error
 	GetUnclassfiedFinishedItemsByCategoryID(c echo.Context) error
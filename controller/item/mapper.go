@@ -17,6 +17,8 @@ func mapToItemResponse(items []*itemUsecase.GetItemOutput) []ItemResponse {
 				InitialScheduledDate: rd.InitialScheduledDate,
 				ScheduledDate:        rd.ScheduledDate,
 				IsCompleted:          rd.IsCompleted,
+				ScheduledAt:          rd.ScheduledAt,
+				RecallGrade:          rd.RecallGrade,
 			}
 		}
 		res[i] = ItemResponse{
@@ -44,8 +44,11 @@ type UpdateItemAsUnFinishedForceRequest struct {
 	Today       string  `json:"today"`
 }
 
+// RecallGradeは任意（again/hard/good/easy）。againとeasyの場合はTodayも必要
 type UpdateReviewDateAsCompletedRequest struct {
-	StepNumber int `json:"step_number"`
+	StepNumber  int    `json:"step_number"`
+	RecallGrade string `json:"recall_grade"`
+	Today       string `json:"today"`
 }
 
 type UpdateReviewDateAsInCompletedRequest struct {
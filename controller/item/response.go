@@ -3,15 +3,17 @@ package item
 import "time"
 
 type ReviewDateResponse struct {
-	ReviewDateID         string  `json:"review_date_id"`
-	UserID               string  `json:"user_id"`
-	CategoryID           *string `json:"category_id"`
-	BoxID                *string `json:"box_id"`
-	ItemID               string  `json:"item_id"`
-	StepNumber           int     `json:"step_number"`
-	InitialScheduledDate string  `json:"initial_scheduled_date"`
-	ScheduledDate        string  `json:"scheduled_date"`
-	IsCompleted          bool    `json:"is_completed"`
+	ReviewDateID         string     `json:"review_date_id"`
+	UserID               string     `json:"user_id"`
+	CategoryID           *string    `json:"category_id"`
+	BoxID                *string    `json:"box_id"`
+	ItemID               string     `json:"item_id"`
+	StepNumber           int        `json:"step_number"`
+	InitialScheduledDate string     `json:"initial_scheduled_date"`
+	ScheduledDate        string     `json:"scheduled_date"`
+	IsCompleted          bool       `json:"is_completed"`
+	RecallGrade          *string    `json:"recall_grade"`
+	ScheduledAt          *time.Time `json:"scheduled_at"` // 分・時間単位のステップの復習日時。日単位以上のステップではnull
 }
 
 type ItemResponse struct {
@@ -37,11 +39,13 @@ type UpdateItemAsFinishedForceResponse struct {
 }
 
 type UpdateReviewDateAsCompletedResponse struct {
-	ReviewDateID string    `json:"review_date_id"`
-	UserID       string    `json:"user_id"`
-	IsCompleted  bool      `json:"is_completed"`
-	IsFinished   bool      `json:"is_finished"`
-	EditedAt     time.Time `json:"edited_at"`
+	ReviewDateID string               `json:"review_date_id"`
+	UserID       string               `json:"user_id"`
+	IsCompleted  bool                 `json:"is_completed"`
+	IsFinished   bool                 `json:"is_finished"`
+	RecallGrade  *string              `json:"recall_grade"`
+	EditedAt     time.Time            `json:"edited_at"`
+	ReviewDates  []ReviewDateResponse `json:"review_dates"` // 想起評価によって再計算された後続の復習日
 }
 
 type UpdateReviewDateAsInCompletedResponse struct {
@@ -94,6 +98,20 @@ type UnclassifiedDailyDatesCountGroupedByCategoryResponse struct {
 	Count      int    `json:"count"`
 }
 
+type ForecastDayResponse struct {
+	Date                   string                                                 `json:"date"`
+	TotalCount             int                                                    `json:"total_count"`
+	ByBox                  []DailyCountGroupedByBoxResponse                       `json:"by_box"`
+	UnclassifiedByCategory []UnclassifiedDailyDatesCountGroupedByCategoryResponse `json:"unclassified_by_category"`
+	UnclassifiedCount      int                                                    `json:"unclassified_count"`
+}
+
+type ReviewForecastResponse struct {
+	From string                `json:"from"`
+	To   string                `json:"to"`
+	Days []ForecastDayResponse `json:"days"`
+}
+
 type DailyReviewDatesByBoxResponse struct {
 	ReviewDateID         string    `json:"review_date_id"`
 	CategoryID           string    `json:"category_id"`
@@ -104,6 +122,7 @@ type DailyReviewDatesByBoxResponse struct {
 	ScheduledDate        string    `json:"scheduled_date"`
 	NextScheduledDate    *string   `json:"next_scheduled_date"`
 	IsCompleted          bool      `json:"is_completed"`
+	IsOverdue            bool      `json:"is_overdue"`
 	ItemID               string    `json:"item_id"`
 	ItemName             string    `json:"item_name"`
 	Detail               string    `json:"detail"`
@@ -129,6 +148,7 @@ type UnclassifiedDailyReviewDatesGroupedByCategoryResponse struct {
 	ScheduledDate        string    `json:"scheduled_date"`
 	NextScheduledDate    *string   `json:"next_scheduled_date"`
 	IsCompleted          bool      `json:"is_completed"`
+	IsOverdue            bool      `json:"is_overdue"`
 	ItemID               string    `json:"item_id"`
 	ItemName             string    `json:"item_name"`
 	Detail               string    `json:"detail"`
@@ -152,6 +172,7 @@ type UnclassifiedDailyReviewDatesGroupedByUserResponse struct {
 	ScheduledDate        string    `json:"scheduled_date"`
 	NextScheduledDate    *string   `json:"next_scheduled_date"`
 	IsCompleted          bool      `json:"is_completed"`
+	IsOverdue            bool      `json:"is_overdue"`
 	ItemID               string    `json:"item_id"`
 	ItemName             string    `json:"item_name"`
 	Detail               string    `json:"detail"`
@@ -164,3 +185,16 @@ type GetDailyReviewDatesResponse struct {
 	Categories                    []DailyReviewDatesGroupedByCategoryResponse         `json:"categories"`
 	DailyReviewDatesGroupedByUser []UnclassifiedDailyReviewDatesGroupedByUserResponse `json:"daily_review_dates_grouped_by_user"`
 }
+
+type DueNowReviewDateResponse struct {
+	ReviewDateID  string    `json:"review_date_id"`
+	CategoryID    *string   `json:"category_id"`
+	BoxID         *string   `json:"box_id"`
+	StepNumber    int       `json:"step_number"`
+	ScheduledDate string    `json:"scheduled_date"`
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	ItemID        string    `json:"item_id"`
+	ItemName      string    `json:"item_name"`
+	Detail        string    `json:"detail"`
+	LearnedDate   string    `json:"learned_date"`
+}
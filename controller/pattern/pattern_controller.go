@@ -1,10 +1,13 @@
 package pattern
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	patternDomain "github.com/minminseo/recall-setter/domain/pattern"
 	patternUsecase "github.com/minminseo/recall-setter/usecase/pattern"
 )
 
@@ -40,13 +43,18 @@ func (pc *patternController) CreatePattern(c echo.Context) error {
 		steps[i] = patternUsecase.CreatePatternStepInput{
 			StepNumber:   s.StepNumber,
 			IntervalDays: s.IntervalDays,
+			IntervalUnit: s.IntervalUnit,
 		}
 	}
 	input := patternUsecase.CreatePatternInput{
-		UserID:       userID,
-		Name:         req.Name,
-		TargetWeight: req.TargetWeight,
-		Steps:        steps,
+		UserID:                  userID,
+		Name:                    req.Name,
+		TargetWeight:            req.TargetWeight,
+		SchedulingAlgorithm:     req.SchedulingAlgorithm,
+		IsLoadBalanced:          req.IsLoadBalanced,
+		ExcludedWeekdays:        req.ExcludedWeekdays,
+		MaintenanceIntervalDays: req.MaintenanceIntervalDays,
+		Steps:                   steps,
 	}
 
 	out, err := pc.pu.CreatePattern(ctx, input)
@@ -62,17 +70,22 @@ func (pc *patternController) CreatePattern(c echo.Context) error {
 			PatternID:     s.PatternID,
 			StepNumber:    s.StepNumber,
 			IntervalDays:  s.IntervalDays,
+			IntervalUnit:  s.IntervalUnit,
 		}
 	}
 
 	res := PatternResponse{
-		ID:           out.ID,
-		UserID:       out.UserID,
-		Name:         out.Name,
-		TargetWeight: out.TargetWeight,
-		RegisteredAt: out.RegisteredAt,
-		EditedAt:     out.EditedAt,
-		Steps:        resSteps,
+		ID:                      out.ID,
+		UserID:                  out.UserID,
+		Name:                    out.Name,
+		TargetWeight:            out.TargetWeight,
+		SchedulingAlgorithm:     out.SchedulingAlgorithm,
+		IsLoadBalanced:          out.IsLoadBalanced,
+		ExcludedWeekdays:        out.ExcludedWeekdays,
+		MaintenanceIntervalDays: out.MaintenanceIntervalDays,
+		RegisteredAt:            out.RegisteredAt,
+		EditedAt:                out.EditedAt,
+		Steps:                   resSteps,
 	}
 
 	return c.JSON(http.StatusCreated, res)
@@ -107,16 +120,21 @@ func (pc *patternController) GetPatterns(c echo.Context) error {
 				PatternID:     s.PatternID,
 				StepNumber:    s.StepNumber,
 				IntervalDays:  s.IntervalDays,
+				IntervalUnit:  s.IntervalUnit,
 			}
 		}
 		res = append(res, PatternResponse{
-			ID:           p.PatternID,
-			UserID:       p.UserID,
-			Name:         p.Name,
-			TargetWeight: p.TargetWeight,
-			RegisteredAt: p.RegisteredAt,
-			EditedAt:     p.EditedAt,
-			Steps:        steps,
+			ID:                      p.PatternID,
+			UserID:                  p.UserID,
+			Name:                    p.Name,
+			TargetWeight:            p.TargetWeight,
+			SchedulingAlgorithm:     p.SchedulingAlgorithm,
+			IsLoadBalanced:          p.IsLoadBalanced,
+			ExcludedWeekdays:        p.ExcludedWeekdays,
+			MaintenanceIntervalDays: p.MaintenanceIntervalDays,
+			RegisteredAt:            p.RegisteredAt,
+			EditedAt:                p.EditedAt,
+			Steps:                   steps,
 		})
 	}
 
@@ -154,14 +172,21 @@ func (pc *patternController) UpdatePattern(c echo.Context) error {
 			PatternID:    patternID,
 			StepNumber:   s.StepNumber,
 			IntervalDays: s.IntervalDays,
+			IntervalUnit: s.IntervalUnit,
 		}
 	}
 	input := patternUsecase.UpdatePatternInput{
-		PatternID:    patternID,
-		UserID:       userID,
-		Name:         req.Name,
-		TargetWeight: req.TargetWeight,
-		Steps:        steps,
+		PatternID:               patternID,
+		UserID:                  userID,
+		Name:                    req.Name,
+		TargetWeight:            req.TargetWeight,
+		SchedulingAlgorithm:     req.SchedulingAlgorithm,
+		IsLoadBalanced:          req.IsLoadBalanced,
+		ExcludedWeekdays:        req.ExcludedWeekdays,
+		MaintenanceIntervalDays: req.MaintenanceIntervalDays,
+		Steps:                   steps,
+		StepChangeMode:          req.StepChangeMode,
+		Today:                   req.Today,
 	}
 
 	out, err := pc.pu.UpdatePattern(ctx, input)
@@ -177,17 +202,24 @@ func (pc *patternController) UpdatePattern(c echo.Context) error {
 			PatternID:     s.PatternID,
 			StepNumber:    s.StepNumber,
 			IntervalDays:  s.IntervalDays,
+			IntervalUnit:  s.IntervalUnit,
 		}
 	}
 
-	res := PatternResponse{
-		ID:           out.PatternID,
-		UserID:       out.UserID,
-		Name:         out.Name,
-		TargetWeight: out.TargetWeight,
-		RegisteredAt: out.RegisteredAt,
-		EditedAt:     out.EditedAt,
-		Steps:        resSteps,
+	res := UpdatePatternResponse{
+		ID:                      out.PatternID,
+		UserID:                  out.UserID,
+		Name:                    out.Name,
+		TargetWeight:            out.TargetWeight,
+		SchedulingAlgorithm:     out.SchedulingAlgorithm,
+		IsLoadBalanced:          out.IsLoadBalanced,
+		ExcludedWeekdays:        out.ExcludedWeekdays,
+		MaintenanceIntervalDays: out.MaintenanceIntervalDays,
+		RegisteredAt:            out.RegisteredAt,
+		EditedAt:                out.EditedAt,
+		Steps:                   resSteps,
+		UpdatedItemCount:        out.UpdatedItemCount,
+		UpdatedReviewDateCount:  out.UpdatedReviewDateCount,
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -212,8 +244,426 @@ func (pc *patternController) DeletePattern(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"error": "パスにパターンIDが必要です"})
 	}
 
+	// 付け替え先の指定がある場合は、パターンを使うボックスと復習物を付け替えてから削除する
+	reassignTo := c.QueryParam("reassign_to")
+	if reassignTo != "" {
+		input := patternUsecase.DeletePatternWithReassignInput{
+			PatternID:  patternID,
+			UserID:     userID,
+			ReassignTo: reassignTo,
+			Today:      c.QueryParam("today"),
+		}
+		out, err := pc.pu.DeletePatternWithReassign(ctx, input)
+		if err != nil {
+			if errors.Is(err, patternDomain.ErrReassignToSamePattern) ||
+				errors.Is(err, patternDomain.ErrAdaptivePatternReschedule) {
+				return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+			}
+			return c.JSON(http.StatusInternalServerError, echo.Map{"error": "パターンの削除に失敗しました: " + err.Error()})
+		}
+		res := DeletePatternWithReassignResponse{
+			ReassignedBoxCount:         out.ReassignedBoxCount,
+			ReassignedItemCount:        out.ReassignedItemCount,
+			RescheduledItemCount:       out.RescheduledItemCount,
+			RescheduledReviewDateCount: out.RescheduledReviewDateCount,
+		}
+		return c.JSON(http.StatusOK, res)
+	}
+
 	if err := pc.pu.DeletePattern(ctx, patternID, userID); err != nil {
 		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "パターンの削除に失敗しました: " + err.Error()})
 	}
 	return c.NoContent(http.StatusNoContent)
 }
+
+func (pc *patternController) GetPatternUsage(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークンにユーザーIDが含まれていません"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークン内のユーザーIDが無効です"})
+	}
+
+	patternID := c.Param("id")
+	if patternID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "パスにパターンIDが必要です"})
+	}
+
+	out, err := pc.pu.GetPatternUsage(ctx, patternID, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "パターンの使用箇所の取得に失敗しました: " + err.Error()})
+	}
+
+	res := PatternUsageResponse{
+		Boxes: make([]PatternUsageBoxResponse, len(out.Boxes)),
+		Items: make([]PatternUsageItemResponse, len(out.Items)),
+	}
+	for i, b := range out.Boxes {
+		res.Boxes[i] = PatternUsageBoxResponse{
+			ID:         b.BoxID,
+			CategoryID: b.CategoryID,
+			Name:       b.Name,
+		}
+	}
+	for i, item := range out.Items {
+		res.Items[i] = PatternUsageItemResponse{
+			ID:         item.ItemID,
+			CategoryID: item.CategoryID,
+			BoxID:      item.BoxID,
+			Name:       item.Name,
+			IsFinished: item.IsFinished,
+		}
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// パスにパターンIDがない場合（POST /patterns/preview）は、リクエストボディのステップでプレビューする
+func (pc *patternController) PreviewSchedule(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークンにユーザーIDが含まれていません"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークン内のユーザーIDが無効です"})
+	}
+
+	var patternID *string
+	if id := c.Param("id"); id != "" {
+		patternID = &id
+	}
+
+	var req PreviewScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "リクエストの形式が正しくありません: " + err.Error()})
+	}
+
+	steps := make([]patternUsecase.CreatePatternStepInput, len(req.Steps))
+	for i, s := range req.Steps {
+		steps[i] = patternUsecase.CreatePatternStepInput{
+			StepNumber:   s.StepNumber,
+			IntervalDays: s.IntervalDays,
+			IntervalUnit: s.IntervalUnit,
+		}
+	}
+	input := patternUsecase.PreviewScheduleInput{
+		PatternID:                patternID,
+		UserID:                   userID,
+		SchedulingAlgorithm:      req.SchedulingAlgorithm,
+		ExcludedWeekdays:         req.ExcludedWeekdays,
+		MaintenanceIntervalDays:  req.MaintenanceIntervalDays,
+		Steps:                    steps,
+		LearnedDate:              req.LearnedDate,
+		Today:                    req.Today,
+		IsMarkOverdueAsCompleted: req.IsMarkOverdueAsCompleted,
+	}
+
+	out, err := pc.pu.PreviewSchedule(ctx, input)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "復習スケジュールのプレビューに失敗しました: " + err.Error()})
+	}
+
+	resReviewDates := make([]PreviewReviewDateResponse, len(out.ReviewDates))
+	for i, rd := range out.ReviewDates {
+		resReviewDates[i] = PreviewReviewDateResponse{
+			StepNumber:    rd.StepNumber,
+			ScheduledDate: rd.ScheduledDate,
+			IsCompleted:   rd.IsCompleted,
+			ScheduledAt:   rd.ScheduledAt,
+		}
+	}
+
+	res := PreviewScheduleResponse{
+		SchedulingAlgorithm: out.SchedulingAlgorithm,
+		IsFinished:          out.IsFinished,
+		ReviewDates:         resReviewDates,
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (pc *patternController) GetPatternVersions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークンにユーザーIDが含まれていません"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークン内のユーザーIDが無効です"})
+	}
+
+	patternID := c.Param("id")
+	if patternID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "パスにパターンIDが必要です"})
+	}
+
+	results, err := pc.pu.GetPatternVersions(ctx, patternID, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "パターンの版の取得に失敗しました: " + err.Error()})
+	}
+
+	res := make([]PatternVersionResponse, len(results))
+	for i, v := range results {
+		steps := make([]PatternVersionStepResponse, len(v.Steps))
+		for j, s := range v.Steps {
+			steps[j] = PatternVersionStepResponse{
+				StepNumber:   s.StepNumber,
+				IntervalDays: s.IntervalDays,
+				IntervalUnit: s.IntervalUnit,
+			}
+		}
+		res[i] = PatternVersionResponse{
+			ID:                  v.PatternVersionID,
+			PatternID:           v.PatternID,
+			VersionNumber:       v.VersionNumber,
+			SchedulingAlgorithm: v.SchedulingAlgorithm,
+			IsCurrent:           v.IsCurrent,
+			CreatedAt:           v.CreatedAt,
+			Steps:               steps,
+		}
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (pc *patternController) GetPatternPresets(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークンにユーザーIDが含まれていません"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークン内のユーザーIDが無効です"})
+	}
+
+	results, err := pc.pu.GetPatternPresets(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "プリセットの取得に失敗しました: " + err.Error()})
+	}
+
+	res := make([]PatternPresetResponse, len(results))
+	for i, p := range results {
+		steps := make([]PatternPresetStepResponse, len(p.Steps))
+		for j, s := range p.Steps {
+			steps[j] = PatternPresetStepResponse{
+				StepNumber:   s.StepNumber,
+				IntervalDays: s.IntervalDays,
+				IntervalUnit: s.IntervalUnit,
+			}
+		}
+		res[i] = PatternPresetResponse{
+			Key:                 p.Key,
+			Name:                p.Name,
+			TargetWeight:        p.TargetWeight,
+			SchedulingAlgorithm: p.SchedulingAlgorithm,
+			Steps:               steps,
+		}
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (pc *patternController) CreatePatternFromPreset(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークンにユーザーIDが含まれていません"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークン内のユーザーIDが無効です"})
+	}
+
+	presetKey := c.Param("key")
+	if presetKey == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "パスにプリセットのキーが必要です"})
+	}
+
+	out, err := pc.pu.CreatePatternFromPreset(ctx, userID, presetKey)
+	if err != nil {
+		if errors.Is(err, patternDomain.ErrPatternPresetNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "パターンの作成に失敗しました: " + err.Error()})
+	}
+
+	resSteps := make([]PatternStepResponse, len(out.Steps))
+	for i, s := range out.Steps {
+		resSteps[i] = PatternStepResponse{
+			PatternStepID: s.PatternStepID,
+			UserID:        s.UserID,
+			PatternID:     s.PatternID,
+			StepNumber:    s.StepNumber,
+			IntervalDays:  s.IntervalDays,
+			IntervalUnit:  s.IntervalUnit,
+		}
+	}
+
+	res := PatternResponse{
+		ID:                      out.ID,
+		UserID:                  out.UserID,
+		Name:                    out.Name,
+		TargetWeight:            out.TargetWeight,
+		SchedulingAlgorithm:     out.SchedulingAlgorithm,
+		IsLoadBalanced:          out.IsLoadBalanced,
+		ExcludedWeekdays:        out.ExcludedWeekdays,
+		MaintenanceIntervalDays: out.MaintenanceIntervalDays,
+		RegisteredAt:            out.RegisteredAt,
+		EditedAt:                out.EditedAt,
+		Steps:                   resSteps,
+	}
+
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (pc *patternController) ExportPatterns(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークンにユーザーIDが含まれていません"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークン内のユーザーIDが無効です"})
+	}
+
+	out, err := pc.pu.ExportPatterns(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "パターンのエクスポートに失敗しました: " + err.Error()})
+	}
+
+	patterns := make([]PatternExportField, len(out.Patterns))
+	for i, p := range out.Patterns {
+		steps := make([]CreatePatternStepField, len(p.Steps))
+		for j, s := range p.Steps {
+			steps[j] = CreatePatternStepField{
+				StepNumber:   s.StepNumber,
+				IntervalDays: s.IntervalDays,
+				IntervalUnit: s.IntervalUnit,
+			}
+		}
+		patterns[i] = PatternExportField{
+			Name:                    p.Name,
+			TargetWeight:            p.TargetWeight,
+			SchedulingAlgorithm:     p.SchedulingAlgorithm,
+			IsLoadBalanced:          p.IsLoadBalanced,
+			ExcludedWeekdays:        p.ExcludedWeekdays,
+			MaintenanceIntervalDays: p.MaintenanceIntervalDays,
+			Steps:                   steps,
+		}
+	}
+
+	res := ExportPatternsResponse{
+		FormatVersion: out.FormatVersion,
+		Patterns:      patterns,
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (pc *patternController) ImportPatterns(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークンにユーザーIDが含まれていません"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "トークン内のユーザーIDが無効です"})
+	}
+
+	// 指定がない場合は同じ名前のパターンもそのまま作成する
+	skipDuplicates := false
+	if raw := c.QueryParam("skip_duplicates"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": "skip_duplicatesはtrueまたはfalseで指定してください"})
+		}
+		skipDuplicates = parsed
+	}
+
+	var req ImportPatternsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "リクエストの形式が正しくありません: " + err.Error()})
+	}
+
+	patterns := make([]patternUsecase.PatternExport, len(req.Patterns))
+	for i, p := range req.Patterns {
+		steps := make([]patternUsecase.PatternExportStep, len(p.Steps))
+		for j, s := range p.Steps {
+			steps[j] = patternUsecase.PatternExportStep{
+				StepNumber:   s.StepNumber,
+				IntervalDays: s.IntervalDays,
+				IntervalUnit: s.IntervalUnit,
+			}
+		}
+		patterns[i] = patternUsecase.PatternExport{
+			Name:                    p.Name,
+			TargetWeight:            p.TargetWeight,
+			SchedulingAlgorithm:     p.SchedulingAlgorithm,
+			IsLoadBalanced:          p.IsLoadBalanced,
+			ExcludedWeekdays:        p.ExcludedWeekdays,
+			MaintenanceIntervalDays: p.MaintenanceIntervalDays,
+			Steps:                   steps,
+		}
+	}
+	input := patternUsecase.ImportPatternsInput{
+		UserID:         userID,
+		FormatVersion:  req.FormatVersion,
+		Patterns:       patterns,
+		SkipDuplicates: skipDuplicates,
+	}
+
+	out, err := pc.pu.ImportPatterns(ctx, input)
+	if err != nil {
+		if errors.Is(err, patternDomain.ErrUnsupportedPatternFormatVersion) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": "パターンのインポートに失敗しました: " + err.Error()})
+	}
+
+	results := make([]ImportPatternResultResponse, len(out.Results))
+	for i, r := range out.Results {
+		results[i] = ImportPatternResultResponse{
+			Index:     r.Index,
+			Name:      r.Name,
+			Status:    r.Status,
+			PatternID: r.PatternID,
+			Error:     r.Error,
+		}
+	}
+
+	res := ImportPatternsResponse{
+		CreatedCount: out.CreatedCount,
+		SkippedCount: out.SkippedCount,
+		FailedCount:  out.FailedCount,
+		Results:      results,
+	}
+	return c.JSON(http.StatusOK, res)
+}
@@ -7,4 +7,11 @@ type IPatternController interface {
 	GetPatterns(c echo.Context) error
 	UpdatePattern(c echo.Context) error
 	DeletePattern(c echo.Context) error
+	PreviewSchedule(c echo.Context) error
+	GetPatternVersions(c echo.Context) error
+	GetPatternPresets(c echo.Context) error
+	CreatePatternFromPreset(c echo.Context) error
+	ExportPatterns(c echo.Context) error
+	ImportPatterns(c echo.Context) error
+	GetPatternUsage(c echo.Context) error
 }
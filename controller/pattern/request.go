@@ -1,22 +1,60 @@
 package pattern
 
 type CreatePatternRequest struct {
-	Name         string                   `json:"name"`
-	TargetWeight string                   `json:"target_weight"`
-	Steps        []CreatePatternStepField `json:"steps"`
+	Name                    string                   `json:"name"`
+	TargetWeight            string                   `json:"target_weight"`
+	SchedulingAlgorithm     string                   `json:"scheduling_algorithm"`
+	IsLoadBalanced          bool                     `json:"is_load_balanced"`
+	ExcludedWeekdays        []int                    `json:"excluded_weekdays"`
+	MaintenanceIntervalDays int                      `json:"maintenance_interval_days"`
+	Steps                   []CreatePatternStepField `json:"steps"`
 }
 type CreatePatternStepField struct {
-	StepNumber   int `json:"step_number"`
-	IntervalDays int `json:"interval_days"`
+	StepNumber   int    `json:"step_number"`
+	IntervalDays int    `json:"interval_days"`
+	IntervalUnit string `json:"interval_unit"`
 }
 
 type UpdatePatternRequest struct {
-	Name         string                   `json:"name"`
-	TargetWeight string                   `json:"target_weight"`
-	Steps        []UpdatePatternStepField `json:"steps"`
+	Name                    string                   `json:"name"`
+	TargetWeight            string                   `json:"target_weight"`
+	SchedulingAlgorithm     string                   `json:"scheduling_algorithm"`
+	IsLoadBalanced          *bool                    `json:"is_load_balanced"`
+	ExcludedWeekdays        []int                    `json:"excluded_weekdays"`
+	MaintenanceIntervalDays *int                     `json:"maintenance_interval_days"`
+	Steps                   []UpdatePatternStepField `json:"steps"`
+	StepChangeMode          string                   `json:"step_change_mode"`
+	Today                   string                   `json:"today"`
 }
 type UpdatePatternStepField struct {
 	StepID       string `json:"step_id"`
 	StepNumber   int    `json:"step_number"`
 	IntervalDays int    `json:"interval_days"`
+	IntervalUnit string `json:"interval_unit"`
+}
+
+// パスにパターンIDがある場合はそのパターンで、ない場合はSchedulingAlgorithmとStepsでプレビューする
+type PreviewScheduleRequest struct {
+	SchedulingAlgorithm      string                   `json:"scheduling_algorithm"`
+	ExcludedWeekdays         []int                    `json:"excluded_weekdays"`
+	MaintenanceIntervalDays  int                      `json:"maintenance_interval_days"`
+	Steps                    []CreatePatternStepField `json:"steps"`
+	LearnedDate              string                   `json:"learned_date"`
+	Today                    string                   `json:"today"`
+	IsMarkOverdueAsCompleted bool                     `json:"is_mark_overdue_as_completed"`
+}
+
+// エクスポートのレスポンスと同じ形式なので、エクスポートしたJSONをそのままインポートできる
+type ImportPatternsRequest struct {
+	FormatVersion int                  `json:"format_version"`
+	Patterns      []PatternExportField `json:"patterns"`
+}
+type PatternExportField struct {
+	Name                    string                   `json:"name"`
+	TargetWeight            string                   `json:"target_weight"`
+	SchedulingAlgorithm     string                   `json:"scheduling_algorithm"`
+	IsLoadBalanced          bool                     `json:"is_load_balanced"`
+	ExcludedWeekdays        []int                    `json:"excluded_weekdays"`
+	MaintenanceIntervalDays int                      `json:"maintenance_interval_days"`
+	Steps                   []CreatePatternStepField `json:"steps"`
 }
@@ -8,14 +8,125 @@ type PatternStepResponse struct {
 	PatternID     string `json:"pattern_id"`
 	StepNumber    int    `json:"step_number"`
 	IntervalDays  int    `json:"interval_days"`
+	IntervalUnit  string `json:"interval_unit"`
 }
 
 type PatternResponse struct {
-	ID           string                `json:"id"`
-	UserID       string                `json:"user_id"`
-	Name         string                `json:"name"`
-	TargetWeight string                `json:"target_weight"`
-	RegisteredAt time.Time             `json:"registered_at"`
-	EditedAt     time.Time             `json:"edited_at"`
-	Steps        []PatternStepResponse `json:"steps"`
+	ID                      string                `json:"id"`
+	UserID                  string                `json:"user_id"`
+	Name                    string                `json:"name"`
+	TargetWeight            string                `json:"target_weight"`
+	SchedulingAlgorithm     string                `json:"scheduling_algorithm"`
+	IsLoadBalanced          bool                  `json:"is_load_balanced"`
+	ExcludedWeekdays        []int                 `json:"excluded_weekdays"`
+	MaintenanceIntervalDays int                   `json:"maintenance_interval_days"`
+	RegisteredAt            time.Time             `json:"registered_at"`
+	EditedAt                time.Time             `json:"edited_at"`
+	Steps                   []PatternStepResponse `json:"steps"`
+}
+
+// 復習物に紐づくパターンのステップをrescheduleで変更した場合は、組み直した復習物と復習日の数を返す
+type UpdatePatternResponse struct {
+	ID                      string                `json:"id"`
+	UserID                  string                `json:"user_id"`
+	Name                    string                `json:"name"`
+	TargetWeight            string                `json:"target_weight"`
+	SchedulingAlgorithm     string                `json:"scheduling_algorithm"`
+	IsLoadBalanced          bool                  `json:"is_load_balanced"`
+	ExcludedWeekdays        []int                 `json:"excluded_weekdays"`
+	MaintenanceIntervalDays int                   `json:"maintenance_interval_days"`
+	RegisteredAt            time.Time             `json:"registered_at"`
+	EditedAt                time.Time             `json:"edited_at"`
+	Steps                   []PatternStepResponse `json:"steps"`
+	UpdatedItemCount        int                   `json:"updated_item_count"`
+	UpdatedReviewDateCount  int                   `json:"updated_review_date_count"`
+}
+
+type PreviewReviewDateResponse struct {
+	StepNumber    int        `json:"step_number"`
+	ScheduledDate string     `json:"scheduled_date"`
+	IsCompleted   bool       `json:"is_completed"`
+	ScheduledAt   *time.Time `json:"scheduled_at"`
+}
+
+type PreviewScheduleResponse struct {
+	SchedulingAlgorithm string                      `json:"scheduling_algorithm"`
+	IsFinished          bool                        `json:"is_finished"`
+	ReviewDates         []PreviewReviewDateResponse `json:"review_dates"`
+}
+
+type PatternVersionStepResponse struct {
+	StepNumber   int    `json:"step_number"`
+	IntervalDays int    `json:"interval_days"`
+	IntervalUnit string `json:"interval_unit"`
+}
+
+type PatternVersionResponse struct {
+	ID                  string                       `json:"id"`
+	PatternID           string                       `json:"pattern_id"`
+	VersionNumber       int                          `json:"version_number"`
+	SchedulingAlgorithm string                       `json:"scheduling_algorithm"`
+	IsCurrent           bool                         `json:"is_current"`
+	CreatedAt           time.Time                    `json:"created_at"`
+	Steps               []PatternVersionStepResponse `json:"steps"`
+}
+
+type PatternPresetStepResponse struct {
+	StepNumber   int    `json:"step_number"`
+	IntervalDays int    `json:"interval_days"`
+	IntervalUnit string `json:"interval_unit"`
+}
+
+type PatternPresetResponse struct {
+	Key                 string                      `json:"key"`
+	Name                string                      `json:"name"`
+	TargetWeight        string                      `json:"target_weight"`
+	SchedulingAlgorithm string                      `json:"scheduling_algorithm"`
+	Steps               []PatternPresetStepResponse `json:"steps"`
+}
+
+type ExportPatternsResponse struct {
+	FormatVersion int                  `json:"format_version"`
+	Patterns      []PatternExportField `json:"patterns"`
+}
+
+type ImportPatternResultResponse struct {
+	Index     int    `json:"index"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	PatternID string `json:"pattern_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ImportPatternsResponse struct {
+	CreatedCount int                           `json:"created_count"`
+	SkippedCount int                           `json:"skipped_count"`
+	FailedCount  int                           `json:"failed_count"`
+	Results      []ImportPatternResultResponse `json:"results"`
+}
+
+type PatternUsageBoxResponse struct {
+	ID         string `json:"id"`
+	CategoryID string `json:"category_id"`
+	Name       string `json:"name"`
+}
+
+type PatternUsageItemResponse struct {
+	ID         string  `json:"id"`
+	CategoryID *string `json:"category_id"`
+	BoxID      *string `json:"box_id"`
+	Name       string  `json:"name"`
+	IsFinished bool    `json:"is_finished"`
+}
+
+type PatternUsageResponse struct {
+	Boxes []PatternUsageBoxResponse  `json:"boxes"`
+	Items []PatternUsageItemResponse `json:"items"`
+}
+
+type DeletePatternWithReassignResponse struct {
+	ReassignedBoxCount         int64 `json:"reassigned_box_count"`
+	ReassignedItemCount        int64 `json:"reassigned_item_count"`
+	RescheduledItemCount       int   `json:"rescheduled_item_count"`
+	RescheduledReviewDateCount int   `json:"rescheduled_review_date_count"`
 }
@@ -14,10 +14,13 @@ type logInUserRequest struct {
 }
 
 type updateUserRequest struct {
-	Email      string `json:"email"`
-	Timezone   string `json:"timezone"`
-	ThemeColor string `json:"theme_color"`
-	Language   string `json:"language"`
+	Email            string `json:"email"`
+	Timezone         string `json:"timezone"`
+	ThemeColor       string `json:"theme_color"`
+	Language         string `json:"language"`
+	DailyReviewLimit int    `json:"daily_review_limit"`
+	ExcludedWeekdays []int  `json:"excluded_weekdays"`
+	OverduePolicy    string `json:"overdue_policy"`
 }
 
 type updatePasswordRequest struct {
@@ -39,3 +42,17 @@ type resetPasswordRequest struct {
 	Code     string `json:"code"`
 	Password string `json:"password"`
 }
+
+type createPauseRequest struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type blockedDateRequest struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+type createBlockedDatesRequest struct {
+	Dates []blockedDateRequest `json:"dates"`
+}
@@ -20,15 +20,38 @@ type CsrfTokenResponse struct {
 }
 
 type GetUserSettingResponse struct {
-	Email      string `json:"email"`
-	Timezone   string `json:"timezone"`
-	ThemeColor string `json:"theme_color"`
-	Language   string `json:"language"`
+	Email            string `json:"email"`
+	Timezone         string `json:"timezone"`
+	ThemeColor       string `json:"theme_color"`
+	Language         string `json:"language"`
+	DailyReviewLimit int    `json:"daily_review_limit"`
+	ExcludedWeekdays []int  `json:"excluded_weekdays"`
+	OverduePolicy    string `json:"overdue_policy"`
 }
 
 type UpdateUserSettingResponse struct {
-	Email      string `json:"email"`
-	Timezone   string `json:"timezone"`
-	ThemeColor string `json:"theme_color"`
-	Language   string `json:"language"`
+	Email            string `json:"email"`
+	Timezone         string `json:"timezone"`
+	ThemeColor       string `json:"theme_color"`
+	Language         string `json:"language"`
+	DailyReviewLimit int    `json:"daily_review_limit"`
+	ExcludedWeekdays []int  `json:"excluded_weekdays"`
+	OverduePolicy    string `json:"overdue_policy"`
+}
+
+type CreatePauseResponse struct {
+	ID        string `json:"id"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type BlockedDateResponse struct {
+	ID   string `json:"id"`
+	Date string `json:"date"`
+	Name string `json:"name"`
+}
+
+type CreateBlockedDatesResponse struct {
+	BlockedDates []BlockedDateResponse `json:"blocked_dates"`
+	SkippedCount int                   `json:"skipped_count"`
 }
@@ -1,12 +1,14 @@
 package user
 
 import (
+	"errors"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	userDomain "github.com/minminseo/recall-setter/domain/user"
 	userUsecase "github.com/minminseo/recall-setter/usecase/user"
 )
 
@@ -158,10 +160,13 @@ func (uc *userController) GetUserSetting(c echo.Context) error {
 	}
 
 	res := GetUserSettingResponse{
-		Email:      userRes.Email,
-		Timezone:   userRes.Timezone,
-		ThemeColor: userRes.ThemeColor,
-		Language:   userRes.Language,
+		Email:            userRes.Email,
+		Timezone:         userRes.Timezone,
+		ThemeColor:       userRes.ThemeColor,
+		Language:         userRes.Language,
+		DailyReviewLimit: userRes.DailyReviewLimit,
+		ExcludedWeekdays: userRes.ExcludedWeekdays,
+		OverduePolicy:    userRes.OverduePolicy,
 	}
 	return c.JSON(http.StatusOK, res)
 }
@@ -185,11 +190,14 @@ func (uc *userController) UpdateSetting(c echo.Context) error {
 	}
 
 	input := userUsecase.UpdateUserInput{
-		ID:         userID,
-		Email:      request.Email,
-		Timezone:   request.Timezone,
-		ThemeColor: request.ThemeColor,
-		Language:   request.Language,
+		ID:               userID,
+		Email:            request.Email,
+		Timezone:         request.Timezone,
+		ThemeColor:       request.ThemeColor,
+		Language:         request.Language,
+		DailyReviewLimit: request.DailyReviewLimit,
+		ExcludedWeekdays: request.ExcludedWeekdays,
+		OverduePolicy:    request.OverduePolicy,
 	}
 
 	userRes, err := uc.uu.UpdateSetting(ctx, input)
@@ -198,10 +206,13 @@ func (uc *userController) UpdateSetting(c echo.Context) error {
 	}
 
 	res := UpdateUserSettingResponse{
-		Email:      userRes.Email,
-		Timezone:   userRes.Timezone,
-		ThemeColor: userRes.ThemeColor,
-		Language:   userRes.Language,
+		Email:            userRes.Email,
+		Timezone:         userRes.Timezone,
+		ThemeColor:       userRes.ThemeColor,
+		Language:         userRes.Language,
+		DailyReviewLimit: userRes.DailyReviewLimit,
+		ExcludedWeekdays: userRes.ExcludedWeekdays,
+		OverduePolicy:    userRes.OverduePolicy,
 	}
 	return c.JSON(http.StatusOK, res)
 
@@ -253,3 +264,260 @@ func (uc *userController) ResetPassword(c echo.Context) error {
 	}
 	return c.NoContent(http.StatusOK)
 }
+
+func (uc *userController) CreatePause(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "User ID not found in token"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid user ID in token"})
+	}
+
+	var request createPauseRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	input := userUsecase.CreatePauseInput{
+		UserID:    userID,
+		StartDate: request.StartDate,
+		EndDate:   request.EndDate,
+	}
+
+	pauseRes, err := uc.uu.CreatePause(ctx, input)
+	if err != nil {
+		if errors.Is(err, userDomain.ErrPauseStartDateBeforeToday) ||
+			errors.Is(err, userDomain.ErrPauseEndDateBeforeStart) ||
+			errors.Is(err, userDomain.ErrPauseTooLong) ||
+			errors.Is(err, userDomain.ErrPauseOverlapped) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	res := CreatePauseResponse{
+		ID:        pauseRes.ID,
+		StartDate: pauseRes.StartDate,
+		EndDate:   pauseRes.EndDate,
+	}
+	return c.JSON(http.StatusCreated, res)
+}
+
+func (uc *userController) CreateBlockedDates(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "User ID not found in token"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid user ID in token"})
+	}
+
+	var request createBlockedDatesRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	input := userUsecase.CreateBlockedDatesInput{
+		UserID: userID,
+		Dates:  make([]userUsecase.BlockedDateInput, len(request.Dates)),
+	}
+	for i, d := range request.Dates {
+		input.Dates[i] = userUsecase.BlockedDateInput{
+			Date: d.Date,
+			Name: d.Name,
+		}
+	}
+
+	blockedDatesRes, err := uc.uu.CreateBlockedDates(ctx, input)
+	if err != nil {
+		var parseErr *time.ParseError
+		if errors.As(err, &parseErr) || isBlockedDateValidationError(err) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, toCreateBlockedDatesResponse(blockedDatesRes))
+}
+
+// iCalendarファイルはmultipart/form-dataのfileで受け取る
+func (uc *userController) ImportBlockedDates(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "User ID not found in token"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid user ID in token"})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "iCalendarファイルが必要です"})
+	}
+	if fileHeader.Size > maxICSFileSize {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "iCalendarファイルは1MB以内で指定してください"})
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+	defer file.Close()
+
+	input := userUsecase.ImportBlockedDatesInput{
+		UserID: userID,
+		File:   file,
+	}
+
+	blockedDatesRes, err := uc.uu.ImportBlockedDatesFromICS(ctx, input)
+	if err != nil {
+		if errors.Is(err, userDomain.ErrInvalidICS) ||
+			errors.Is(err, userDomain.ErrICSEventTooLong) ||
+			isBlockedDateValidationError(err) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, toCreateBlockedDatesResponse(blockedDatesRes))
+}
+
+func (uc *userController) GetBlockedDates(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "User ID not found in token"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid user ID in token"})
+	}
+
+	blockedDatesRes, err := uc.uu.GetBlockedDates(ctx, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	res := make([]BlockedDateResponse, len(blockedDatesRes))
+	for i, b := range blockedDatesRes {
+		res[i] = toBlockedDateResponse(b)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (uc *userController) UpdateBlockedDate(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "User ID not found in token"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid user ID in token"})
+	}
+
+	blockedDateID := c.Param("id")
+	if blockedDateID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "パスに復習日を置かない日付のIDが必要です"})
+	}
+
+	var request blockedDateRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	input := userUsecase.UpdateBlockedDateInput{
+		ID:     blockedDateID,
+		UserID: userID,
+		Date:   request.Date,
+		Name:   request.Name,
+	}
+
+	blockedDateRes, err := uc.uu.UpdateBlockedDate(ctx, input)
+	if err != nil {
+		var parseErr *time.ParseError
+		if errors.Is(err, userDomain.ErrBlockedDateNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+		}
+		if errors.As(err, &parseErr) ||
+			errors.Is(err, userDomain.ErrBlockedDateDuplicated) ||
+			isBlockedDateValidationError(err) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, toBlockedDateResponse(blockedDateRes))
+}
+
+func (uc *userController) DeleteBlockedDate(c echo.Context) error {
+	ctx := c.Request().Context()
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	rawID, ok := claims["user_id"]
+	if !ok || rawID == nil {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "User ID not found in token"})
+	}
+	userID, ok := rawID.(string)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "Invalid user ID in token"})
+	}
+
+	blockedDateID := c.Param("id")
+	if blockedDateID == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": "パスに復習日を置かない日付のIDが必要です"})
+	}
+
+	if err := uc.uu.DeleteBlockedDate(ctx, blockedDateID, userID); err != nil {
+		if errors.Is(err, userDomain.ErrBlockedDateNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// 取り込むiCalendarファイルの最大サイズ（1MB）
+const maxICSFileSize = 1 << 20
+
+func isBlockedDateValidationError(err error) bool {
+	return errors.Is(err, userDomain.ErrBlockedDateBeforeToday) ||
+		errors.Is(err, userDomain.ErrBlockedDateNameTooLong) ||
+		errors.Is(err, userDomain.ErrBlockedDatesEmpty) ||
+		errors.Is(err, userDomain.ErrTooManyBlockedDates)
+}
+
+func toBlockedDateResponse(b *userUsecase.BlockedDateOutput) BlockedDateResponse {
+	return BlockedDateResponse{
+		ID:   b.ID,
+		Date: b.Date,
+		Name: b.Name,
+	}
+}
+
+func toCreateBlockedDatesResponse(output *userUsecase.CreateBlockedDatesOutput) CreateBlockedDatesResponse {
+	res := CreateBlockedDatesResponse{
+		BlockedDates: make([]BlockedDateResponse, len(output.BlockedDates)),
+		SkippedCount: output.SkippedCount,
+	}
+	for i, b := range output.BlockedDates {
+		res.BlockedDates[i] = toBlockedDateResponse(b)
+	}
+	return res
+}
@@ -13,4 +13,10 @@ type IUserController interface {
 	VerifyEmail(c echo.Context) error
 	RequestPasswordReset(c echo.Context) error
 	ResetPassword(c echo.Context) error
+	CreatePause(c echo.Context) error
+	CreateBlockedDates(c echo.Context) error
+	ImportBlockedDates(c echo.Context) error
+	GetBlockedDates(c echo.Context) error
+	UpdateBlockedDate(c echo.Context) error
+	DeleteBlockedDate(c echo.Context) error
 }
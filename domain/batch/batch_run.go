@@ -0,0 +1,134 @@
+package batch
+
+import (
+	"errors"
+	"time"
+)
+
+// IANAのタイムゾーンはUTCからのオフセットが全部15分単位なので、15分毎の実行枠で各タイムゾーンの0時を拾える
+const RunInterval = 15 * time.Minute
+
+// 実行中のまま残っている実行記録を失敗扱いにするまでの時間。1回の実行のタイムアウト（10分）より長くする
+const StaleRunTimeout = 30 * time.Minute
+
+const (
+	JobNameUpdateOverdueScheduledDates string = "update_overdue_scheduled_dates"
+)
+
+const (
+	BatchRunStatusRunning   string = "running"
+	BatchRunStatusSucceeded string = "succeeded"
+	BatchRunStatusFailed    string = "failed"
+)
+
+// 実行中のまま残っていた実行記録に残すエラーメッセージ
+const StaleRunErrorMessage = "実行中のまま終了しませんでした"
+
+// バッチ処理の1回の実行記録。15分毎の実行枠につき1件だけ作成し、同じ実行枠を2回実行しない
+type BatchRun struct {
+	id          string
+	jobName     string
+	windowStart time.Time
+	// 停止中などで実行されなかった実行枠がある場合、その最初の実行枠の開始時刻
+	caughtUpFrom *time.Time
+	status       string
+	rowsAffected int64
+	errorMessage *string
+	startedAt    time.Time
+	finishedAt   *time.Time
+}
+
+// 時刻が属する実行枠の開始時刻を返す
+func WindowStart(t time.Time) time.Time {
+	return t.UTC().Truncate(RunInterval)
+}
+
+// lastWindowStartは前回の実行記録の実行枠の開始時刻（実行記録がない場合はnil）
+// 前回の実行枠との間に実行されなかった実行枠がある場合は、この実行でまとめて処理したことを記録する
+func NewBatchRun(
+	id string,
+	jobName string,
+	windowStart time.Time,
+	lastWindowStart *time.Time,
+	startedAt time.Time,
+) (*BatchRun, error) {
+	if id == "" {
+		return nil, errors.New("実行記録IDが空です")
+	}
+	if jobName == "" {
+		return nil, errors.New("バッチ処理名が空です")
+	}
+	windowStart = WindowStart(windowStart)
+	var caughtUpFrom *time.Time
+	if lastWindowStart != nil && windowStart.Sub(*lastWindowStart) > RunInterval {
+		from := lastWindowStart.UTC().Add(RunInterval)
+		caughtUpFrom = &from
+	}
+	return &BatchRun{
+		id:           id,
+		jobName:      jobName,
+		windowStart:  windowStart,
+		caughtUpFrom: caughtUpFrom,
+		status:       BatchRunStatusRunning,
+		startedAt:    startedAt,
+	}, nil
+}
+
+func (b *BatchRun) ID() string {
+	return b.id
+}
+
+func (b *BatchRun) JobName() string {
+	return b.jobName
+}
+
+func (b *BatchRun) WindowStart() time.Time {
+	return b.windowStart
+}
+
+func (b *BatchRun) CaughtUpFrom() *time.Time {
+	return b.caughtUpFrom
+}
+
+func (b *BatchRun) Status() string {
+	return b.status
+}
+
+func (b *BatchRun) RowsAffected() int64 {
+	return b.rowsAffected
+}
+
+func (b *BatchRun) ErrorMessage() *string {
+	return b.errorMessage
+}
+
+func (b *BatchRun) StartedAt() time.Time {
+	return b.startedAt
+}
+
+func (b *BatchRun) FinishedAt() *time.Time {
+	return b.finishedAt
+}
+
+// 実行されなかった実行枠の数
+func (b *BatchRun) MissedWindows() int {
+	if b.caughtUpFrom == nil {
+		return 0
+	}
+	return int(b.windowStart.Sub(*b.caughtUpFrom) / RunInterval)
+}
+
+func (b *BatchRun) Succeed(rowsAffected int64, finishedAt time.Time) {
+	b.status = BatchRunStatusSucceeded
+	b.rowsAffected = rowsAffected
+	b.finishedAt = &finishedAt
+}
+
+// ユーザーを上限件数ずつ別のトランザクションで更新するため、失敗するまでにコミットした影響行数を残す
+func (b *BatchRun) Fail(err error, rowsAffected int64, finishedAt time.Time) {
+	message := err.Error()
+	b.status = BatchRunStatusFailed
+	b.rowsAffected = rowsAffected
+	b.errorMessage = &message
+	b.finishedAt = &finishedAt
+}
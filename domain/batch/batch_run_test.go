@@ -0,0 +1,128 @@
+package batch
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewBatchRun(t *testing.T) {
+	startedAt := time.Date(2024, 1, 10, 15, 0, 3, 0, time.UTC)
+	windowStart := time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		id                string
+		jobName           string
+		windowStart       time.Time
+		lastWindowStart   *time.Time
+		wantWindowStart   time.Time
+		wantCaughtUpFrom  *time.Time
+		wantMissedWindows int
+		wantErr           bool
+	}{
+		{
+			name:            "前回の実行記録がない（正常系）",
+			id:              "run-id",
+			jobName:         JobNameUpdateOverdueScheduledDates,
+			windowStart:     windowStart,
+			wantWindowStart: windowStart,
+		},
+		{
+			name:            "前回の実行枠の次の実行枠（正常系）",
+			id:              "run-id",
+			jobName:         JobNameUpdateOverdueScheduledDates,
+			windowStart:     windowStart,
+			lastWindowStart: ptrTime(windowStart.Add(-RunInterval)),
+			wantWindowStart: windowStart,
+		},
+		{
+			name:              "実行されなかった実行枠がある（正常系）",
+			id:                "run-id",
+			jobName:           JobNameUpdateOverdueScheduledDates,
+			windowStart:       windowStart,
+			lastWindowStart:   ptrTime(windowStart.Add(-time.Hour)),
+			wantWindowStart:   windowStart,
+			wantCaughtUpFrom:  ptrTime(windowStart.Add(-45 * time.Minute)),
+			wantMissedWindows: 3,
+		},
+		{
+			name:            "実行枠の途中の時刻は実行枠の開始時刻にする（正常系）",
+			id:              "run-id",
+			jobName:         JobNameUpdateOverdueScheduledDates,
+			windowStart:     windowStart.Add(7 * time.Minute),
+			wantWindowStart: windowStart,
+		},
+		{
+			name:        "IDが空（異常系）",
+			id:          "",
+			jobName:     JobNameUpdateOverdueScheduledDates,
+			windowStart: windowStart,
+			wantErr:     true,
+		},
+		{
+			name:        "バッチ処理名が空（異常系）",
+			id:          "run-id",
+			jobName:     "",
+			windowStart: windowStart,
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewBatchRun(tc.id, tc.jobName, tc.windowStart, tc.lastWindowStart, startedAt)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("エラーを期待しましたが、nilでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if !got.WindowStart().Equal(tc.wantWindowStart) {
+				t.Errorf("WindowStart() = %v, want %v", got.WindowStart(), tc.wantWindowStart)
+			}
+			if (got.CaughtUpFrom() == nil) != (tc.wantCaughtUpFrom == nil) {
+				t.Fatalf("CaughtUpFrom() = %v, want %v", got.CaughtUpFrom(), tc.wantCaughtUpFrom)
+			}
+			if tc.wantCaughtUpFrom != nil && !got.CaughtUpFrom().Equal(*tc.wantCaughtUpFrom) {
+				t.Errorf("CaughtUpFrom() = %v, want %v", *got.CaughtUpFrom(), *tc.wantCaughtUpFrom)
+			}
+			if got.MissedWindows() != tc.wantMissedWindows {
+				t.Errorf("MissedWindows() = %d, want %d", got.MissedWindows(), tc.wantMissedWindows)
+			}
+			if got.Status() != BatchRunStatusRunning {
+				t.Errorf("Status() = %s, want %s", got.Status(), BatchRunStatusRunning)
+			}
+		})
+	}
+}
+
+func TestBatchRun_SucceedAndFail(t *testing.T) {
+	windowStart := time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC)
+	finishedAt := windowStart.Add(time.Minute)
+
+	succeeded, _ := NewBatchRun("run-id", JobNameUpdateOverdueScheduledDates, windowStart, nil, windowStart)
+	succeeded.Succeed(12, finishedAt)
+	if succeeded.Status() != BatchRunStatusSucceeded || succeeded.RowsAffected() != 12 || succeeded.ErrorMessage() != nil {
+		t.Errorf("成功時の実行記録が不正です: status=%s rows=%d", succeeded.Status(), succeeded.RowsAffected())
+	}
+	if succeeded.FinishedAt() == nil || !succeeded.FinishedAt().Equal(finishedAt) {
+		t.Errorf("FinishedAt() = %v, want %v", succeeded.FinishedAt(), finishedAt)
+	}
+
+	failed, _ := NewBatchRun("run-id", JobNameUpdateOverdueScheduledDates, windowStart, nil, windowStart)
+	failed.Fail(errors.New("database connection failed"), 3, finishedAt)
+	if failed.Status() != BatchRunStatusFailed || failed.RowsAffected() != 3 {
+		t.Errorf("失敗時の実行記録が不正です: status=%s rows=%d", failed.Status(), failed.RowsAffected())
+	}
+	if failed.ErrorMessage() == nil || *failed.ErrorMessage() != "database connection failed" {
+		t.Errorf("ErrorMessage() = %v, want %q", failed.ErrorMessage(), "database connection failed")
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
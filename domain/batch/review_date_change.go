@@ -0,0 +1,49 @@
+package batch
+
+import "time"
+
+// ドライランで、バッチ処理の前後の復習日を比べるために使う
+type ReviewDateSnapshot struct {
+	ReviewDateID  string
+	UserID        string
+	ItemID        string
+	StepNumber    int
+	ScheduledDate time.Time
+}
+
+// バッチ処理でずれる復習日。Daysはずれる日数（前にずれる場合は負の値）
+type ReviewDateChange struct {
+	ReviewDateID string
+	UserID       string
+	ItemID       string
+	StepNumber   int
+	OldDate      time.Time
+	NewDate      time.Time
+	Days         int
+}
+
+// 更新前と更新後の復習日を比べて、ずれた復習日を更新前の並び順で返す
+func DiffReviewDates(before []*ReviewDateSnapshot, after []*ReviewDateSnapshot) []*ReviewDateChange {
+	afterByID := make(map[string]*ReviewDateSnapshot, len(after))
+	for _, a := range after {
+		afterByID[a.ReviewDateID] = a
+	}
+
+	var changes []*ReviewDateChange
+	for _, b := range before {
+		a, ok := afterByID[b.ReviewDateID]
+		if !ok || a.ScheduledDate.Equal(b.ScheduledDate) {
+			continue
+		}
+		changes = append(changes, &ReviewDateChange{
+			ReviewDateID: b.ReviewDateID,
+			UserID:       b.UserID,
+			ItemID:       b.ItemID,
+			StepNumber:   b.StepNumber,
+			OldDate:      b.ScheduledDate,
+			NewDate:      a.ScheduledDate,
+			Days:         int(a.ScheduledDate.Sub(b.ScheduledDate).Hours() / 24),
+		})
+	}
+	return changes
+}
@@ -0,0 +1,68 @@
+package batch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffReviewDates(t *testing.T) {
+	date := func(day int) time.Time {
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		before []*ReviewDateSnapshot
+		after  []*ReviewDateSnapshot
+		want   []*ReviewDateChange
+	}{
+		{
+			name: "ずれた復習日だけを返す",
+			before: []*ReviewDateSnapshot{
+				{ReviewDateID: "rd-1", UserID: "user1", ItemID: "item-1", StepNumber: 1, ScheduledDate: date(2)},
+				{ReviewDateID: "rd-2", UserID: "user1", ItemID: "item-1", StepNumber: 2, ScheduledDate: date(4)},
+				{ReviewDateID: "rd-3", UserID: "user1", ItemID: "item-2", StepNumber: 1, ScheduledDate: date(9)},
+			},
+			after: []*ReviewDateSnapshot{
+				{ReviewDateID: "rd-1", UserID: "user1", ItemID: "item-1", StepNumber: 1, ScheduledDate: date(10)},
+				{ReviewDateID: "rd-2", UserID: "user1", ItemID: "item-1", StepNumber: 2, ScheduledDate: date(12)},
+				{ReviewDateID: "rd-3", UserID: "user1", ItemID: "item-2", StepNumber: 1, ScheduledDate: date(9)},
+			},
+			want: []*ReviewDateChange{
+				{ReviewDateID: "rd-1", UserID: "user1", ItemID: "item-1", StepNumber: 1, OldDate: date(2), NewDate: date(10), Days: 8},
+				{ReviewDateID: "rd-2", UserID: "user1", ItemID: "item-1", StepNumber: 2, OldDate: date(4), NewDate: date(12), Days: 8},
+			},
+		},
+		{
+			name: "前にずれた復習日は負の日数を返す",
+			before: []*ReviewDateSnapshot{
+				{ReviewDateID: "rd-1", UserID: "user1", ItemID: "item-1", StepNumber: 2, ScheduledDate: date(20)},
+			},
+			after: []*ReviewDateSnapshot{
+				{ReviewDateID: "rd-1", UserID: "user1", ItemID: "item-1", StepNumber: 2, ScheduledDate: date(15)},
+			},
+			want: []*ReviewDateChange{
+				{ReviewDateID: "rd-1", UserID: "user1", ItemID: "item-1", StepNumber: 2, OldDate: date(20), NewDate: date(15), Days: -5},
+			},
+		},
+		{
+			name: "更新後にない復習日は無視する",
+			before: []*ReviewDateSnapshot{
+				{ReviewDateID: "rd-1", UserID: "user1", ItemID: "item-1", StepNumber: 1, ScheduledDate: date(2)},
+			},
+			after: []*ReviewDateSnapshot{},
+			want:  nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DiffReviewDates(tc.before, tc.after)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("DiffReviewDates() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package batch
+
+import (
+	"time"
+)
+
+// 1回のトランザクションで更新するユーザー数の上限。復習日の行ロックを持つ時間を短く抑える
+const UserChunkSize = 500
+
+// fromからtoまでの間に日付けを跨いだ（現地の日付けが変わった）タイムゾーンを返す
+// 期限切れの復習日は現地の日付けが変わった時にだけ増えるため、日付けを跨いでいないタイムゾーンのユーザーは処理しなくてよい
+// fromがnil（前回の成功した実行がない）の場合は全部のタイムゾーンを返す
+// 読み込めないタイムゾーンは判定できないため、処理の対象に含める
+func TimezonesCrossedMidnight(timezones []string, from *time.Time, to time.Time) []string {
+	if from == nil {
+		return timezones
+	}
+	crossed := make([]string, 0, len(timezones))
+	for _, tz := range timezones {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			crossed = append(crossed, tz)
+			continue
+		}
+		if localDate(*from, loc) != localDate(to, loc) {
+			crossed = append(crossed, tz)
+		}
+	}
+	return crossed
+}
+
+func localDate(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(time.DateOnly)
+}
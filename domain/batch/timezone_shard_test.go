@@ -0,0 +1,74 @@
+package batch
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimezonesCrossedMidnight(t *testing.T) {
+	timezones := []string{"America/New_York", "Asia/Kolkata", "Asia/Tokyo", "UTC"}
+
+	tests := []struct {
+		name string
+		from *time.Time
+		to   time.Time
+		want []string
+	}{
+		{
+			name: "前回の成功した実行がない場合は全部のタイムゾーン（正常系）",
+			from: nil,
+			to:   time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC),
+			want: timezones,
+		},
+		{
+			name: "日本時間の0時を跨いだ（正常系）",
+			from: ptrTime(time.Date(2024, 1, 10, 14, 45, 0, 0, time.UTC)),
+			to:   time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC),
+			want: []string{"Asia/Tokyo"},
+		},
+		{
+			name: "30分ずれたタイムゾーンの0時を跨いだ（正常系）",
+			from: ptrTime(time.Date(2024, 1, 10, 18, 15, 0, 0, time.UTC)),
+			to:   time.Date(2024, 1, 10, 18, 30, 0, 0, time.UTC),
+			want: []string{"Asia/Kolkata"},
+		},
+		{
+			name: "前回の実行枠の開始時刻が0時ちょうどの場合は跨いでいない（正常系）",
+			from: ptrTime(time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC)),
+			to:   time.Date(2024, 1, 10, 15, 15, 0, 0, time.UTC),
+			want: []string{},
+		},
+		{
+			name: "実行されなかった実行枠の間に跨いだタイムゾーンも含む（正常系）",
+			from: ptrTime(time.Date(2024, 1, 10, 14, 0, 0, 0, time.UTC)),
+			to:   time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+			want: []string{"Asia/Kolkata", "Asia/Tokyo", "UTC"},
+		},
+		{
+			name: "夏時間の期間はオフセットが変わる（正常系）",
+			from: ptrTime(time.Date(2024, 7, 1, 3, 45, 0, 0, time.UTC)),
+			to:   time.Date(2024, 7, 1, 4, 0, 0, 0, time.UTC),
+			want: []string{"America/New_York"},
+		},
+		{
+			name: "読み込めないタイムゾーンは含める（正常系）",
+			from: ptrTime(time.Date(2024, 1, 10, 3, 0, 0, 0, time.UTC)),
+			to:   time.Date(2024, 1, 10, 3, 15, 0, 0, time.UTC),
+			want: []string{"Invalid/Zone"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tzs := timezones
+			if slices.Contains(tc.want, "Invalid/Zone") {
+				tzs = append(slices.Clone(timezones), "Invalid/Zone")
+			}
+			got := TimezonesCrossedMidnight(tzs, tc.from, tc.to)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("TimezonesCrossedMidnight() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
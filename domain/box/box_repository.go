@@ -1,6 +1,9 @@
 package box
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type BoxCountGroupedByCategory struct {
 	CategoryID string
@@ -22,9 +25,14 @@ type IBoxRepository interface {
 	GetByID(ctx context.Context, boxID string, categoryID string, userID string) (*Box, error)
 
 	Update(ctx context.Context, box *Box) error
+	// ボックス内の復習物の復習日の組み直しは呼び出し側で同一トランザクションで行う
 	UpdateWithPatternID(ctx context.Context, box *Box) (int64, error)
 	Delete(ctx context.Context, boxID string, categoryID string, userID string) error
 
 	// item_usecaseで使う。ボックスの名前とパターンIDを一覧取得する
 	GetBoxNamesByBoxIDs(ctx context.Context, boxIDs []string) ([]*BoxName, error)
+
+	// pattern_usecaseで使う。パターンの使用箇所の一覧と、パターン削除時の付け替え用
+	GetAllByPatternID(ctx context.Context, patternID string, userID string) ([]*Box, error)
+	UpdatePatternIDByPatternID(ctx context.Context, patternID string, userID string, newPatternID string, editedAt time.Time) (int64, error)
 }
@@ -3,5 +3,5 @@ package box
 
 import "errors"
 
-// review_itemsが存在する状態パターン変更しようとしたときのエラー
-var ErrPatternConflict = errors.New("復習物がボックス内に存在するため、復習パターンを変更できません")
+// パターン変更の対象のボックスが更新時に存在しなかったときのエラー
+var ErrBoxNotFound = errors.New("ボックスが存在しません")
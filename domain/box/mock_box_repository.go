@@ -12,6 +12,7 @@ package box
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -83,6 +84,21 @@ func (mr *MockIBoxRepositoryMockRecorder) GetAllByCategoryID(ctx, categoryID, us
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByCategoryID", reflect.TypeOf((*MockIBoxRepository)(nil).GetAllByCategoryID), ctx, categoryID, userID)
 }
 
+// GetAllByPatternID mocks base method.
+func (m *MockIBoxRepository) GetAllByPatternID(ctx context.Context, patternID, userID string) ([]*Box, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllByPatternID", ctx, patternID, userID)
+	ret0, _ := ret[0].([]*Box)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllByPatternID indicates an expected call of GetAllByPatternID.
+func (mr *MockIBoxRepositoryMockRecorder) GetAllByPatternID(ctx, patternID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllByPatternID", reflect.TypeOf((*MockIBoxRepository)(nil).GetAllByPatternID), ctx, patternID, userID)
+}
+
 // GetBoxNamesByBoxIDs mocks base method.
 func (m *MockIBoxRepository) GetBoxNamesByBoxIDs(ctx context.Context, boxIDs []string) ([]*BoxName, error) {
 	m.ctrl.T.Helper()
@@ -127,6 +143,21 @@ func (mr *MockIBoxRepositoryMockRecorder) Update(ctx, box any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIBoxRepository)(nil).Update), ctx, box)
 }
 
+// UpdatePatternIDByPatternID mocks base method.
+func (m *MockIBoxRepository) UpdatePatternIDByPatternID(ctx context.Context, patternID, userID, newPatternID string, editedAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePatternIDByPatternID", ctx, patternID, userID, newPatternID, editedAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePatternIDByPatternID indicates an expected call of UpdatePatternIDByPatternID.
+func (mr *MockIBoxRepositoryMockRecorder) UpdatePatternIDByPatternID(ctx, patternID, userID, newPatternID, editedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePatternIDByPatternID", reflect.TypeOf((*MockIBoxRepository)(nil).UpdatePatternIDByPatternID), ctx, patternID, userID, newPatternID, editedAt)
+}
+
 // UpdateWithPatternID mocks base method.
 func (m *MockIBoxRepository) UpdateWithPatternID(ctx context.Context, box *Box) (int64, error) {
 	m.ctrl.T.Helper()
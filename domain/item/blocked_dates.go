@@ -0,0 +1,20 @@
+package item
+
+import "time"
+
+// 復習日を置かない日付（祝日や予定のある日など）の集合。キーはYYYY-MM-DD形式
+type BlockedDates map[string]struct{}
+
+func NewBlockedDates(dates []time.Time) BlockedDates {
+	b := make(BlockedDates, len(dates))
+	for _, d := range dates {
+		b[d.Format("2006-01-02")] = struct{}{}
+	}
+	return b
+}
+
+// 指定日が復習日を置かない日付かどうか
+func (b BlockedDates) Contains(date time.Time) bool {
+	_, ok := b[date.Format("2006-01-02")]
+	return ok
+}
@@ -0,0 +1,44 @@
+package item
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBlockedDates_Contains(t *testing.T) {
+	blockedDates := NewBlockedDates([]time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	tests := []struct {
+		name string
+		date time.Time
+		want bool
+	}{
+		{
+			name: "登録されている日付",
+			date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "時刻やタイムゾーンが異なっても日付が同じなら含む",
+			date: time.Date(2024, 1, 1, 9, 30, 0, 0, time.FixedZone("JST", 9*60*60)),
+			want: true,
+		},
+		{
+			name: "登録されていない日付",
+			date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blockedDates.Contains(tt.date); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if NewBlockedDates(nil).Contains(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Contains() on empty BlockedDates = true, want false")
+	}
+}
@@ -7,4 +7,9 @@ var (
 	ErrHasCompletedReviewDate                     = errors.New("完了済みの復習物があるため、復習パターンを変更できません")
 	ErrNewScheduledDateBeforeInitialScheduledDate = errors.New("新しい復習日は初期復習日より前に設定できません")
 	ErrMismatchedIDsAndSteps                      = errors.New("復習パターンのステップ数と復習日数が一致しません")
+	ErrUnknownSchedulingAlgorithm                 = errors.New("未対応のスケジューリング方式です")
+	ErrAdaptiveReviewDateNotLatest                = errors.New("適応型の復習物は直近の復習日のみ変更できます")
+	ErrMaintenanceReviewDateNotLatest             = errors.New("最後のステップ以降の復習日は直近の復習日のみ変更できます")
+	ErrInvalidForecastDays                        = errors.New("予測日数は1日以上366日以下で指定してください")
+	ErrIntradayReviewDateNotEditable              = errors.New("分・時間単位のステップの復習日は変更できません")
 )
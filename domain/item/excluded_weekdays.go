@@ -0,0 +1,51 @@
+package item
+
+import "time"
+
+// 復習日を置かない曜日の集合。time.Weekdayの値をビット位置とする
+type ExcludedWeekdays uint8
+
+// 全ての曜日を除外した値。この場合は除外しない
+const allExcludedWeekdays ExcludedWeekdays = 1<<7 - 1
+
+// 曜日の一覧（0が日曜日、6が土曜日）をまとめて除外する曜日の集合を作る
+// パターンの設定とユーザーの設定のように、複数の一覧を渡した場合は和集合とする
+func NewExcludedWeekdays(weekdaysList ...[]int) ExcludedWeekdays {
+	var w ExcludedWeekdays
+	for _, weekdays := range weekdaysList {
+		for _, d := range weekdays {
+			if d < int(time.Sunday) || d > int(time.Saturday) {
+				continue
+			}
+			w |= 1 << d
+		}
+	}
+	return w
+}
+
+// 指定日が除外する曜日かどうか
+func (w ExcludedWeekdays) Excludes(date time.Time) bool {
+	if w == allExcludedWeekdays {
+		return false
+	}
+	return w&(1<<date.Weekday()) != 0
+}
+
+// 指定日が除外する曜日の場合、次の除外しない曜日の日付を返す
+func (w ExcludedWeekdays) NextAllowedDate(date time.Time) time.Time {
+	for w.Excludes(date) {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// 除外する曜日の一覧（0が日曜日、6が土曜日）
+func (w ExcludedWeekdays) Weekdays() []int {
+	weekdays := []int{}
+	for d := int(time.Sunday); d <= int(time.Saturday); d++ {
+		if w&(1<<d) != 0 {
+			weekdays = append(weekdays, d)
+		}
+	}
+	return weekdays
+}
@@ -0,0 +1,93 @@
+package item
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestExcludedWeekdays_NextAllowedDate(t *testing.T) {
+	// 2024-01-06は土曜日
+	saturday := time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		weekdaysList [][]int
+		date         time.Time
+		want         time.Time
+	}{
+		{
+			name:         "除外する曜日がない場合はそのまま",
+			weekdaysList: nil,
+			date:         saturday,
+			want:         saturday,
+		},
+		{
+			name:         "除外しない曜日の場合はそのまま",
+			weekdaysList: [][]int{{0}},
+			date:         saturday,
+			want:         saturday,
+		},
+		{
+			name:         "除外する曜日の場合は次の除外しない曜日にずらす",
+			weekdaysList: [][]int{{6}},
+			date:         saturday,
+			want:         saturday.AddDate(0, 0, 1),
+		},
+		{
+			name:         "パターンとユーザーの除外する曜日を合わせて連続して除外する",
+			weekdaysList: [][]int{{6}, {0}},
+			date:         saturday,
+			want:         saturday.AddDate(0, 0, 2),
+		},
+		{
+			name:         "全ての曜日を除外する場合はそのまま",
+			weekdaysList: [][]int{{0, 1, 2, 3}, {4, 5, 6}},
+			date:         saturday,
+			want:         saturday,
+		},
+		{
+			name:         "範囲外の曜日は無視する",
+			weekdaysList: [][]int{{7, -1}},
+			date:         saturday,
+			want:         saturday,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewExcludedWeekdays(tt.weekdaysList...).NextAllowedDate(tt.date)
+			if !got.Equal(tt.want) {
+				t.Errorf("NextAllowedDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcludedWeekdays_Weekdays(t *testing.T) {
+	tests := []struct {
+		name         string
+		weekdaysList [][]int
+		want         []int
+	}{
+		{
+			name:         "除外する曜日がない場合は空",
+			weekdaysList: nil,
+			want:         []int{},
+		},
+		{
+			name:         "重複を除いて曜日順に並べる",
+			weekdaysList: [][]int{{6, 0}, {0, 3}},
+			want:         []int{0, 3, 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewExcludedWeekdays(tt.weekdaysList...).Weekdays()
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Weekdays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
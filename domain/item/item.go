@@ -8,17 +8,19 @@ import (
 )
 
 type Item struct {
-	itemID       string
-	userID       string
-	categoryID   *string
-	boxID        *string
-	patternID    *string
-	name         string
-	detail       string
-	learnedDate  time.Time
-	isFinished   bool
-	registeredAt time.Time
-	editedAt     time.Time
+	itemID     string
+	userID     string
+	categoryID *string
+	boxID      *string
+	patternID  *string
+	// 復習日を算出したパターンの版。パターンのない復習物と、保存前の復習物はnil
+	patternVersionID *string
+	name             string
+	detail           string
+	learnedDate      time.Time
+	isFinished       bool
+	registeredAt     time.Time
+	editedAt         time.Time
 }
 
 func NewItem(
@@ -63,6 +65,7 @@ func ReconstructItem(
 	categoryID *string,
 	boxID *string,
 	patternID *string,
+	patternVersionID *string,
 	name string,
 	detail string,
 	learnedDate time.Time,
@@ -71,17 +74,18 @@ func ReconstructItem(
 	editedAt time.Time,
 ) (*Item, error) {
 	i := &Item{
-		itemID:       itemID,
-		userID:       userID,
-		categoryID:   categoryID,
-		boxID:        boxID,
-		patternID:    patternID,
-		name:         name,
-		detail:       detail,
-		learnedDate:  learnedDate,
-		isFinished:   isFinished,
-		registeredAt: registeredAt,
-		editedAt:     editedAt,
+		itemID:           itemID,
+		userID:           userID,
+		categoryID:       categoryID,
+		boxID:            boxID,
+		patternID:        patternID,
+		patternVersionID: patternVersionID,
+		name:             name,
+		detail:           detail,
+		learnedDate:      learnedDate,
+		isFinished:       isFinished,
+		registeredAt:     registeredAt,
+		editedAt:         editedAt,
 	}
 	return i, nil
 }
@@ -122,6 +126,10 @@ func (i *Item) PatternID() *string {
 	return i.patternID
 }
 
+func (i *Item) PatternVersionID() *string {
+	return i.patternVersionID
+}
+
 func (i *Item) Name() string {
 	return i.name
 }
@@ -183,6 +191,9 @@ type Reviewdate struct {
 	initialScheduledDate time.Time
 	scheduledDate        time.Time
 	isCompleted          bool
+	recallGrade          *string // 未評価の場合はnil
+	// 分・時間単位のステップの復習日時。日単位以上のステップではnil
+	scheduledAt *time.Time
 }
 
 func NewReviewdate(
@@ -213,6 +224,36 @@ func NewReviewdate(
 	return s, nil
 }
 
+// 分・時間単位のステップの復習日を生成する。scheduledDateは復習日時ではなく起点日（学習日）とする
+func NewIntradayReviewdate(
+	reviewdateID string,
+	userID string,
+	categoryID *string,
+	boxID *string,
+	itemID string,
+	stepNumber int,
+	scheduledDate time.Time,
+	scheduledAt time.Time,
+	isCompleted bool,
+) (*Reviewdate, error) {
+	rd, err := NewReviewdate(
+		reviewdateID,
+		userID,
+		categoryID,
+		boxID,
+		itemID,
+		stepNumber,
+		scheduledDate,
+		scheduledDate,
+		isCompleted,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rd.scheduledAt = &scheduledAt
+	return rd, nil
+}
+
 func ReconstructReviewdate(
 	reviewdateID string,
 	userID string,
@@ -223,6 +264,8 @@ func ReconstructReviewdate(
 	initialScheduledDate time.Time,
 	scheduledDate time.Time,
 	isCompleted bool,
+	recallGrade *string,
+	scheduledAt *time.Time,
 ) (*Reviewdate, error) {
 	rd := &Reviewdate{
 		reviewdateID:         reviewdateID,
@@ -234,6 +277,8 @@ func ReconstructReviewdate(
 		initialScheduledDate: initialScheduledDate,
 		scheduledDate:        scheduledDate,
 		isCompleted:          isCompleted,
+		recallGrade:          recallGrade,
+		scheduledAt:          scheduledAt,
 	}
 	return rd, nil
 }
@@ -274,6 +319,20 @@ func (r *Reviewdate) IsCompleted() bool {
 	return r.isCompleted
 }
 
+func (r *Reviewdate) RecallGrade() *string {
+	return r.recallGrade
+}
+
+func (r *Reviewdate) ScheduledAt() *time.Time {
+	return r.scheduledAt
+}
+
+// 分・時間単位のステップの復習日かどうか
+// バッチ処理の期限切れの繰り越しや負荷分散の対象外とする
+func (r *Reviewdate) IsIntraday() bool {
+	return r.scheduledAt != nil
+}
+
 func (r *Reviewdate) Validate() error {
 	return validation.ValidateStruct(r,
 		validation.Field(
@@ -299,7 +358,31 @@ func (r *Reviewdate) UpdateReviewdateIDs(
 	return nil
 }
 
+// 完了済みの復習日のうち、ステップ番号が最も大きいもの。完了済みの復習日がない場合はnil
+// 復習日を組み直す際に、どのステップまで完了しているかを判定するため
+func LastCompletedReviewdate(reviewdates []*Reviewdate) *Reviewdate {
+	var lastCompleted *Reviewdate
+	for _, rd := range reviewdates {
+		if rd.IsCompleted() && (lastCompleted == nil || rd.StepNumber() > lastCompleted.StepNumber()) {
+			lastCompleted = rd
+		}
+	}
+	return lastCompleted
+}
+
 type IScheduler interface {
+	// パターンに設定された方式で復習日を算出するスケジューラを返す
+	WithAlgorithm(schedulingAlgorithm string) (IScheduler, error)
+
+	// 算出した復習日が除外する曜日の場合に、次の除外しない曜日にずらすスケジューラを返す
+	WithExcludedWeekdays(excludedWeekdays ExcludedWeekdays) IScheduler
+
+	// 算出した復習日が復習日を置かない日付の場合に、次の復習日を置ける日にずらすスケジューラを返す
+	WithBlockedDates(blockedDates BlockedDates) IScheduler
+
+	// 学習日から各ステップの復習日までの日数を返す
+	OffsetDays(targetPatternSteps []*PatternDomain.PatternStep, parsedBaseDate time.Time) []int
+
 	FormatWithOverdueMarkedCompleted(
 		targetPatternSteps []*PatternDomain.PatternStep,
 		userID string,
@@ -352,4 +435,59 @@ type IScheduler interface {
 		parsedLearnedDate time.Time,
 		diff time.Duration,
 	) ([]*Reviewdate, error)
+
+	// 想起評価に応じて、完了したステップより後の未完了の復習日を再計算する
+	// 再計算が不要な評価の場合は空のスライスを返す
+	RescheduleByRecallGrade(
+		targetPatternSteps []*PatternDomain.PatternStep,
+		reviewdates []*Reviewdate,
+		completedStepNumber int,
+		recallGrade string,
+		parsedToday time.Time,
+	) ([]*Reviewdate, error)
+
+	// パターンのステップ変更時に、完了したステップより後の復習日を変更後のステップで生成し直す
+	RescheduleForUpdatedSteps(
+		targetPatternSteps []*PatternDomain.PatternStep,
+		userID string,
+		categoryID *string,
+		boxID *string,
+		itemID string,
+		lastCompletedStepNumber int,
+		parsedLearnedDate time.Time,
+		parsedToday time.Time,
+	) ([]*Reviewdate, error)
+
+	// 適応型の方式で、完了した復習日の次の復習日を学習状態から生成する
+	NextAdaptiveReviewdate(
+		completedReviewdate *Reviewdate,
+		state *SM2State,
+		parsedBaseDate time.Time,
+	) (*Reviewdate, error)
+
+	// パターンの最後のステップより後に、繰り返しの間隔日数で次の復習日を生成する
+	NextMaintenanceReviewdate(
+		lastReviewdate *Reviewdate,
+		maintenanceIntervalDays int,
+		parsedBaseDate time.Time,
+		parsedToday time.Time,
+	) (*Reviewdate, error)
+
+	// 未完了の復習日を、許容範囲内で復習予定数が最も少ない日にずらす
+	// dailyCountsはLoadBalanceRangeで求めた期間の日毎の復習予定数
+	LoadBalance(
+		reviewdates []*Reviewdate,
+		dailyCounts []*DailyScheduledCount,
+		parsedLearnedDate time.Time,
+		parsedToday time.Time,
+	) ([]*Reviewdate, error)
+
+	// 1日あたりの復習数の上限に達している日の未完了の復習日を、空きのある次の日にずらす
+	// dailyCountsはDailyLoadRangeで求めた期間の日毎の復習予定数
+	DeferOverflow(
+		reviewdates []*Reviewdate,
+		dailyCounts []*DailyScheduledCount,
+		dailyReviewLimit int,
+		parsedToday time.Time,
+	) ([]*Reviewdate, error)
 }
@@ -26,6 +26,42 @@ type UnclassifiedDailyDatesCountGroupedByCategory struct {
 	Count      int
 }
 
+// 復習量の予測用。ScheduledDate毎の集計結果
+type ForecastCountGroupedByBox struct {
+	ScheduledDate time.Time
+	CategoryID    string
+	BoxID         string
+	Count         int
+}
+
+// CategoryIDがnilの場合はホーム画面の未分類
+type UnclassifiedForecastCountGroupedByCategory struct {
+	ScheduledDate time.Time
+	CategoryID    *string
+	Count         int
+}
+
+// 復習日の負荷分散用。ボックスやカテゴリーを区別しない復習日毎の集計結果
+type DailyScheduledCount struct {
+	ScheduledDate time.Time
+	Count         int
+}
+
+// 1日あたりの復習数の上限があるユーザーの期限切れの復習物。バッチ処理で繰り越し先を決めるために使う
+// OldDateは最も古い未完了の復習日、Todayはユーザーのタイムゾーンでの今日
+type OverdueItem struct {
+	UserID           string
+	ItemID           string
+	OldDate          time.Time
+	Today            time.Time
+	DailyReviewLimit int
+	// ユーザーの期限切れの復習日の扱い（適応型のパターンの復習物は1ステップ目に戻せないため、reset_to_first_stepの場合もslide_all）
+	OverduePolicy string
+	TargetWeight  string
+	// ユーザーとパターンの除外する曜日を合わせたもの
+	ExcludedWeekdays ExcludedWeekdays
+}
+
 type DailyReviewDate struct {
 	ReviewdateID         string
 	CategoryID           *string
@@ -36,12 +72,28 @@ type DailyReviewDate struct {
 	ScheduledDate        time.Time
 	NextScheduledDate    *time.Time
 	IsCompleted          bool
-	ItemID               string
-	Name                 string
-	Detail               string
-	LearnedDate          time.Time
-	RegisteredAt         time.Time
-	EditedAt             time.Time
+	// 期限切れの復習日の扱いがkeep_overdueのユーザーの、今日より前の未完了の復習日
+	IsOverdue    bool
+	ItemID       string
+	Name         string
+	Detail       string
+	LearnedDate  time.Time
+	RegisteredAt time.Time
+	EditedAt     time.Time
+}
+
+// 分・時間単位のステップの、復習日時を過ぎた未完了の復習日
+type DueNowReviewDate struct {
+	ReviewdateID  string
+	CategoryID    *string
+	BoxID         *string
+	StepNumber    int
+	ScheduledDate time.Time
+	ScheduledAt   time.Time
+	ItemID        string
+	Name          string
+	Detail        string
+	LearnedDate   time.Time
 }
 
 type IItemRepository interface {
@@ -69,9 +121,22 @@ type IItemRepository interface {
 
 	UpdateItemAsUnFinished(ctx context.Context, itemID string, userID string, editedAt time.Time) error
 
-	// 復習日を完了済みに更新
-	UpdateReviewDateAsCompleted(ctx context.Context, reviewdateID string, userID string) error
+	// ボックスのパターン変更時に、ボックス内の未完了の復習物のパターンも変更（完了済みの復習物は変更しない）
+	UpdateItemsPatternIDByBoxID(ctx context.Context, boxID string, userID string, patternID string) error
+
+	// ボックスのパターン変更で組み直す間、ボックス内の未完了の復習物とその復習日を更新されないようにロックする（トランザクション内で使う）
+	LockUnFinishedItemsByBoxID(ctx context.Context, boxID string, userID string) error
+
+	// パターンのステップ変更や削除時の付け替えで組み直す間、パターンを使う未完了の復習物とその復習日を更新されないようにロックする（トランザクション内で使う）
+	LockUnFinishedItemsByPatternID(ctx context.Context, patternID string, userID string) error
+
+	// パターンのステップ変更で復習日を組み直した復習物に、変更後のパターンの版を割り当てる
+	UpdateItemPatternVersionID(ctx context.Context, itemID string, userID string, patternVersionID string) error
+
+	// 復習日を完了済みに更新（想起評価が未指定の場合はnil）
+	UpdateReviewDateAsCompleted(ctx context.Context, reviewdateID string, userID string, recallGrade *string) error
 
+	// 復習日を未完了に戻す（想起評価もリセットする）
 	UpdateReviewDateAsInCompleted(ctx context.Context, reviewdateID string, userID string) error
 
 	// 復習日巻き戻し操作時の最新復習スケジュールを取得するため・復習日完了操作対象の復習日が最後の復習日かどうか判別するため
@@ -82,6 +147,15 @@ type IItemRepository interface {
 
 	DeleteReviewDates(ctx context.Context, itemID string, userID string) error
 
+	// 適応型の復習日の完了取り消し時に、指定したステップより後の未完了の復習日（生成済みの次の復習日）を削除
+	DeleteInCompletedReviewDatesAfterStep(ctx context.Context, itemID string, userID string, stepNumber int) error
+
+	// 適応型SM-2方式の学習状態を取得（一度も完了していない場合はnil）
+	FindSM2StateByItemID(ctx context.Context, itemID string, userID string) (*SM2State, error)
+
+	// 適応型SM-2方式の学習状態を保存（既に存在する場合は上書き）
+	UpsertSM2State(ctx context.Context, state *SM2State) error
+
 	/*-------------*/
 	// ここからしたは取得系
 
@@ -92,6 +166,9 @@ type IItemRepository interface {
 	// TODO: rview_datesテーブルにも親の復習物が完了かどうかのフラグを持たせるべきか検討（冗長化）。
 	GetAllReviewDatesByBoxID(ctx context.Context, boxID string, userID string) ([]*Reviewdate, error)
 
+	// パターンAを使う復習物（未完了）を一覧取得。パターンのステップ変更時に復習日を組み直すため
+	GetAllUnFinishedItemsByPatternID(ctx context.Context, patternID string, userID string) ([]*Item, error)
+
 	// ホーム画面の未分類復習物ボックスの復習物（未完了）と復習日を一覧取得
 	GetAllUnFinishedUnclassifiedItemsByUserID(ctx context.Context, userID string) ([]*Item, error)
 	GetAllUnclassifiedReviewDatesByUserID(ctx context.Context, userID string) ([]*Reviewdate, error)
@@ -129,6 +206,26 @@ type IItemRepository interface {
 	// ホーム画面の未分類復習物ボックスの今日の復習物数（復習日）を取得
 	CountDailyDatesUnclassifiedByUserID(ctx context.Context, userID string, targetDate time.Time) (int, error)
 
+	// 復習量の予測系
+	// fromDateからtoDateまでの各日の未完了の復習日数を、ボックス毎に取得
+	CountForecastDatesGroupedByBoxByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*ForecastCountGroupedByBox, error)
+
+	// fromDateからtoDateまでの各日の未分類の未完了の復習日数を、カテゴリー毎に取得
+	CountForecastDatesUnclassifiedGroupedByCategoryByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*UnclassifiedForecastCountGroupedByCategory, error)
+
+	// 復習日の負荷分散用
+	// fromDateからtoDateまでの各日の未完了の復習日数を取得（復習日がない日は含まない）
+	CountScheduledDatesGroupedByDateByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*DailyScheduledCount, error)
+
+	// 1日あたりの復習数の上限を取得（0の場合は上限なし）
+	GetDailyReviewLimitByUserID(ctx context.Context, userID string) (int, error)
+
+	// 復習日を置かない曜日のユーザー設定を取得（0が日曜日、6が土曜日）
+	GetExcludedWeekdaysByUserID(ctx context.Context, userID string) ([]int, error)
+
+	// ユーザーの復習日を置かない日付を取得
+	GetBlockedDatesByUserID(ctx context.Context, userID string) ([]time.Time, error)
+
 	// EditedAtの取得専用
 	GetEditedAtByItemID(ctx context.Context, itemID string, userID string) (time.Time, error)
 
@@ -137,6 +234,9 @@ type IItemRepository interface {
 
 	GetAllDailyReviewDates(ctx context.Context, userID string, parsedToday time.Time) ([]*DailyReviewDate, error)
 
+	// 分・時間単位のステップのうち、復習日時がnow以前の未完了の復習日を取得する（完了済みの復習物は除く）
+	GetDueNowReviewDates(ctx context.Context, userID string, now time.Time) ([]*DueNowReviewDate, error)
+
 	// 完了済み復習物系を取得する系
 	GetFinishedItemsByBoxID(ctx context.Context, boxID string, userID string) ([]*Item, error)
 	GetUnclassfiedFinishedItemsByCategoryID(ctx context.Context, categoryID string, userID string) ([]*Item, error)
@@ -145,4 +245,10 @@ type IItemRepository interface {
 	/*--------------------*/
 	// patternパッケージで使うメソッド
 	IsPatternRelatedToItemByPatternID(ctx context.Context, patternID string, userID string) (bool, error)
+
+	// パターンの使用箇所の一覧用。パターンを使う復習物（完了済みを含む）を一覧取得
+	GetAllItemsByPatternID(ctx context.Context, patternID string, userID string) ([]*Item, error)
+
+	// パターンの削除時に、パターンを使う復習物（完了済みを含む）を別のパターンとその最新の版に付け替える
+	UpdateItemsPatternIDByPatternID(ctx context.Context, patternID string, userID string, newPatternID string) (int64, error)
 }
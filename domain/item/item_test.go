@@ -51,6 +51,7 @@ func TestNewItem(t *testing.T) {
 					&categoryID,
 					&boxID,
 					&patternID,
+					nil,
 					"Apple",
 					"Apple - りんご",
 					learnedDate,
@@ -82,6 +83,7 @@ func TestNewItem(t *testing.T) {
 					nil,
 					nil,
 					nil,
+					nil,
 					"Apple",
 					"Apple - りんご",
 					learnedDate,
@@ -147,6 +149,7 @@ func TestNewItem(t *testing.T) {
 					&categoryID,
 					&boxID,
 					&patternID,
+					nil,
 					"Apple",
 					"",
 					learnedDate,
@@ -247,6 +250,7 @@ func TestItem_UpdateItem(t *testing.T) {
 					&newCategoryID,
 					&newBoxID,
 					&newPatternID,
+					nil,
 					"更新後復習物",
 					"更新後詳細",
 					newLearnedDate,
@@ -274,6 +278,7 @@ func TestItem_UpdateItem(t *testing.T) {
 					nil,
 					nil,
 					nil,
+					nil,
 					"nil項目で更新",
 					"更新後詳細",
 					newLearnedDate,
@@ -301,6 +306,7 @@ func TestItem_UpdateItem(t *testing.T) {
 					&categoryID,
 					&boxID,
 					&patternID,
+					nil,
 					"Original Item",
 					"Original detail",
 					learnedDate,
@@ -329,6 +335,7 @@ func TestItem_UpdateItem(t *testing.T) {
 					&categoryID,
 					&boxID,
 					&patternID,
+					nil,
 					"Original Item",
 					"Original detail",
 					learnedDate,
@@ -419,6 +426,8 @@ func TestNewReviewdate(t *testing.T) {
 					initialDate,
 					scheduledDate,
 					false,
+					nil,
+					nil,
 				)
 				return reviewdate
 			}(),
@@ -446,6 +455,8 @@ func TestNewReviewdate(t *testing.T) {
 					initialDate,
 					scheduledDate,
 					true,
+					nil,
+					nil,
 				)
 				return reviewdate
 			}(),
@@ -550,3 +561,51 @@ func TestNewReviewdate(t *testing.T) {
 		})
 	}
 }
+
+func TestLastCompletedReviewdate(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newReviewdate := func(stepNumber int, isCompleted bool) *Reviewdate {
+		rd, _ := NewReviewdate("rd", "user1", nil, nil, "item1", stepNumber, date, date, isCompleted)
+		return rd
+	}
+	completed1 := newReviewdate(1, true)
+	completed2 := newReviewdate(2, true)
+	inCompleted3 := newReviewdate(3, false)
+	completed4 := newReviewdate(4, true)
+
+	tests := []struct {
+		name        string
+		reviewdates []*Reviewdate
+		want        *Reviewdate
+	}{
+		{
+			name:        "完了済みの復習日のうちステップ番号が最も大きいもの",
+			reviewdates: []*Reviewdate{completed1, completed2, inCompleted3},
+			want:        completed2,
+		},
+		{
+			name:        "未完了の復習日を挟んでいても完了済みのうち最も大きいもの",
+			reviewdates: []*Reviewdate{completed4, completed1, inCompleted3},
+			want:        completed4,
+		},
+		{
+			name:        "完了済みの復習日がない場合はnil",
+			reviewdates: []*Reviewdate{inCompleted3},
+			want:        nil,
+		},
+		{
+			name:        "復習日がない場合はnil",
+			reviewdates: []*Reviewdate{},
+			want:        nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := LastCompletedReviewdate(tc.reviewdates)
+			if got != tc.want {
+				t.Errorf("LastCompletedReviewdate() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
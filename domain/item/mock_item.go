@@ -41,6 +41,21 @@ func (m *MockIScheduler) EXPECT() *MockISchedulerMockRecorder {
 	return m.recorder
 }
 
+// DeferOverflow mocks base method.
+func (m *MockIScheduler) DeferOverflow(reviewdates []*Reviewdate, dailyCounts []*DailyScheduledCount, dailyReviewLimit int, parsedToday time.Time) ([]*Reviewdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeferOverflow", reviewdates, dailyCounts, dailyReviewLimit, parsedToday)
+	ret0, _ := ret[0].([]*Reviewdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeferOverflow indicates an expected call of DeferOverflow.
+func (mr *MockISchedulerMockRecorder) DeferOverflow(reviewdates, dailyCounts, dailyReviewLimit, parsedToday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeferOverflow", reflect.TypeOf((*MockIScheduler)(nil).DeferOverflow), reviewdates, dailyCounts, dailyReviewLimit, parsedToday)
+}
+
 // FormatWithOverdueMarkedCompleted mocks base method.
 func (m *MockIScheduler) FormatWithOverdueMarkedCompleted(targetPatternSteps []*pattern.PatternStep, userID string, categoryID, boxID *string, itemID string, parsedLearnedDate, parsedToday time.Time) ([]*Reviewdate, bool, error) {
 	m.ctrl.T.Helper()
@@ -117,3 +132,135 @@ func (mr *MockISchedulerMockRecorder) FormatWithOverdueMarkedInCompletedWithIDsF
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates", reflect.TypeOf((*MockIScheduler)(nil).FormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates), targetPatternSteps, reviewDateIDs, userID, categoryID, boxID, itemID, parsedLearnedDate, diff)
 }
+
+// LoadBalance mocks base method.
+func (m *MockIScheduler) LoadBalance(reviewdates []*Reviewdate, dailyCounts []*DailyScheduledCount, parsedLearnedDate, parsedToday time.Time) ([]*Reviewdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBalance", reviewdates, dailyCounts, parsedLearnedDate, parsedToday)
+	ret0, _ := ret[0].([]*Reviewdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadBalance indicates an expected call of LoadBalance.
+func (mr *MockISchedulerMockRecorder) LoadBalance(reviewdates, dailyCounts, parsedLearnedDate, parsedToday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBalance", reflect.TypeOf((*MockIScheduler)(nil).LoadBalance), reviewdates, dailyCounts, parsedLearnedDate, parsedToday)
+}
+
+// NextAdaptiveReviewdate mocks base method.
+func (m *MockIScheduler) NextAdaptiveReviewdate(completedReviewdate *Reviewdate, state *SM2State, parsedBaseDate time.Time) (*Reviewdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextAdaptiveReviewdate", completedReviewdate, state, parsedBaseDate)
+	ret0, _ := ret[0].(*Reviewdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextAdaptiveReviewdate indicates an expected call of NextAdaptiveReviewdate.
+func (mr *MockISchedulerMockRecorder) NextAdaptiveReviewdate(completedReviewdate, state, parsedBaseDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextAdaptiveReviewdate", reflect.TypeOf((*MockIScheduler)(nil).NextAdaptiveReviewdate), completedReviewdate, state, parsedBaseDate)
+}
+
+// NextMaintenanceReviewdate mocks base method.
+func (m *MockIScheduler) NextMaintenanceReviewdate(lastReviewdate *Reviewdate, maintenanceIntervalDays int, parsedBaseDate, parsedToday time.Time) (*Reviewdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextMaintenanceReviewdate", lastReviewdate, maintenanceIntervalDays, parsedBaseDate, parsedToday)
+	ret0, _ := ret[0].(*Reviewdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextMaintenanceReviewdate indicates an expected call of NextMaintenanceReviewdate.
+func (mr *MockISchedulerMockRecorder) NextMaintenanceReviewdate(lastReviewdate, maintenanceIntervalDays, parsedBaseDate, parsedToday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextMaintenanceReviewdate", reflect.TypeOf((*MockIScheduler)(nil).NextMaintenanceReviewdate), lastReviewdate, maintenanceIntervalDays, parsedBaseDate, parsedToday)
+}
+
+// OffsetDays mocks base method.
+func (m *MockIScheduler) OffsetDays(targetPatternSteps []*pattern.PatternStep, parsedBaseDate time.Time) []int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OffsetDays", targetPatternSteps, parsedBaseDate)
+	ret0, _ := ret[0].([]int)
+	return ret0
+}
+
+// OffsetDays indicates an expected call of OffsetDays.
+func (mr *MockISchedulerMockRecorder) OffsetDays(targetPatternSteps, parsedBaseDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffsetDays", reflect.TypeOf((*MockIScheduler)(nil).OffsetDays), targetPatternSteps, parsedBaseDate)
+}
+
+// RescheduleByRecallGrade mocks base method.
+func (m *MockIScheduler) RescheduleByRecallGrade(targetPatternSteps []*pattern.PatternStep, reviewdates []*Reviewdate, completedStepNumber int, recallGrade string, parsedToday time.Time) ([]*Reviewdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleByRecallGrade", targetPatternSteps, reviewdates, completedStepNumber, recallGrade, parsedToday)
+	ret0, _ := ret[0].([]*Reviewdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RescheduleByRecallGrade indicates an expected call of RescheduleByRecallGrade.
+func (mr *MockISchedulerMockRecorder) RescheduleByRecallGrade(targetPatternSteps, reviewdates, completedStepNumber, recallGrade, parsedToday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleByRecallGrade", reflect.TypeOf((*MockIScheduler)(nil).RescheduleByRecallGrade), targetPatternSteps, reviewdates, completedStepNumber, recallGrade, parsedToday)
+}
+
+// RescheduleForUpdatedSteps mocks base method.
+func (m *MockIScheduler) RescheduleForUpdatedSteps(targetPatternSteps []*pattern.PatternStep, userID string, categoryID, boxID *string, itemID string, lastCompletedStepNumber int, parsedLearnedDate, parsedToday time.Time) ([]*Reviewdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleForUpdatedSteps", targetPatternSteps, userID, categoryID, boxID, itemID, lastCompletedStepNumber, parsedLearnedDate, parsedToday)
+	ret0, _ := ret[0].([]*Reviewdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RescheduleForUpdatedSteps indicates an expected call of RescheduleForUpdatedSteps.
+func (mr *MockISchedulerMockRecorder) RescheduleForUpdatedSteps(targetPatternSteps, userID, categoryID, boxID, itemID, lastCompletedStepNumber, parsedLearnedDate, parsedToday any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleForUpdatedSteps", reflect.TypeOf((*MockIScheduler)(nil).RescheduleForUpdatedSteps), targetPatternSteps, userID, categoryID, boxID, itemID, lastCompletedStepNumber, parsedLearnedDate, parsedToday)
+}
+
+// WithAlgorithm mocks base method.
+func (m *MockIScheduler) WithAlgorithm(schedulingAlgorithm string) (IScheduler, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithAlgorithm", schedulingAlgorithm)
+	ret0, _ := ret[0].(IScheduler)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithAlgorithm indicates an expected call of WithAlgorithm.
+func (mr *MockISchedulerMockRecorder) WithAlgorithm(schedulingAlgorithm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithAlgorithm", reflect.TypeOf((*MockIScheduler)(nil).WithAlgorithm), schedulingAlgorithm)
+}
+
+// WithBlockedDates mocks base method.
+func (m *MockIScheduler) WithBlockedDates(blockedDates BlockedDates) IScheduler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithBlockedDates", blockedDates)
+	ret0, _ := ret[0].(IScheduler)
+	return ret0
+}
+
+// WithBlockedDates indicates an expected call of WithBlockedDates.
+func (mr *MockISchedulerMockRecorder) WithBlockedDates(blockedDates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithBlockedDates", reflect.TypeOf((*MockIScheduler)(nil).WithBlockedDates), blockedDates)
+}
+
+// WithExcludedWeekdays mocks base method.
+func (m *MockIScheduler) WithExcludedWeekdays(excludedWeekdays ExcludedWeekdays) IScheduler {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithExcludedWeekdays", excludedWeekdays)
+	ret0, _ := ret[0].(IScheduler)
+	return ret0
+}
+
+// WithExcludedWeekdays indicates an expected call of WithExcludedWeekdays.
+func (mr *MockISchedulerMockRecorder) WithExcludedWeekdays(excludedWeekdays any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithExcludedWeekdays", reflect.TypeOf((*MockIScheduler)(nil).WithExcludedWeekdays), excludedWeekdays)
+}
@@ -101,6 +101,36 @@ func (mr *MockIItemRepositoryMockRecorder) CountDailyDatesUnclassifiedGroupedByC
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDailyDatesUnclassifiedGroupedByCategoryByUserID", reflect.TypeOf((*MockIItemRepository)(nil).CountDailyDatesUnclassifiedGroupedByCategoryByUserID), ctx, userID, targetDate)
 }
 
+// CountForecastDatesGroupedByBoxByUserID mocks base method.
+func (m *MockIItemRepository) CountForecastDatesGroupedByBoxByUserID(ctx context.Context, userID string, fromDate, toDate time.Time) ([]*ForecastCountGroupedByBox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountForecastDatesGroupedByBoxByUserID", ctx, userID, fromDate, toDate)
+	ret0, _ := ret[0].([]*ForecastCountGroupedByBox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountForecastDatesGroupedByBoxByUserID indicates an expected call of CountForecastDatesGroupedByBoxByUserID.
+func (mr *MockIItemRepositoryMockRecorder) CountForecastDatesGroupedByBoxByUserID(ctx, userID, fromDate, toDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountForecastDatesGroupedByBoxByUserID", reflect.TypeOf((*MockIItemRepository)(nil).CountForecastDatesGroupedByBoxByUserID), ctx, userID, fromDate, toDate)
+}
+
+// CountForecastDatesUnclassifiedGroupedByCategoryByUserID mocks base method.
+func (m *MockIItemRepository) CountForecastDatesUnclassifiedGroupedByCategoryByUserID(ctx context.Context, userID string, fromDate, toDate time.Time) ([]*UnclassifiedForecastCountGroupedByCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountForecastDatesUnclassifiedGroupedByCategoryByUserID", ctx, userID, fromDate, toDate)
+	ret0, _ := ret[0].([]*UnclassifiedForecastCountGroupedByCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountForecastDatesUnclassifiedGroupedByCategoryByUserID indicates an expected call of CountForecastDatesUnclassifiedGroupedByCategoryByUserID.
+func (mr *MockIItemRepositoryMockRecorder) CountForecastDatesUnclassifiedGroupedByCategoryByUserID(ctx, userID, fromDate, toDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountForecastDatesUnclassifiedGroupedByCategoryByUserID", reflect.TypeOf((*MockIItemRepository)(nil).CountForecastDatesUnclassifiedGroupedByCategoryByUserID), ctx, userID, fromDate, toDate)
+}
+
 // CountItemsGroupedByBoxByUserID mocks base method.
 func (m *MockIItemRepository) CountItemsGroupedByBoxByUserID(ctx context.Context, userID string) ([]*ItemCountGroupedByBox, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +146,21 @@ func (mr *MockIItemRepositoryMockRecorder) CountItemsGroupedByBoxByUserID(ctx, u
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountItemsGroupedByBoxByUserID", reflect.TypeOf((*MockIItemRepository)(nil).CountItemsGroupedByBoxByUserID), ctx, userID)
 }
 
+// CountScheduledDatesGroupedByDateByUserID mocks base method.
+func (m *MockIItemRepository) CountScheduledDatesGroupedByDateByUserID(ctx context.Context, userID string, fromDate, toDate time.Time) ([]*DailyScheduledCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountScheduledDatesGroupedByDateByUserID", ctx, userID, fromDate, toDate)
+	ret0, _ := ret[0].([]*DailyScheduledCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountScheduledDatesGroupedByDateByUserID indicates an expected call of CountScheduledDatesGroupedByDateByUserID.
+func (mr *MockIItemRepositoryMockRecorder) CountScheduledDatesGroupedByDateByUserID(ctx, userID, fromDate, toDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountScheduledDatesGroupedByDateByUserID", reflect.TypeOf((*MockIItemRepository)(nil).CountScheduledDatesGroupedByDateByUserID), ctx, userID, fromDate, toDate)
+}
+
 // CountUnclassifiedItemsByUserID mocks base method.
 func (m *MockIItemRepository) CountUnclassifiedItemsByUserID(ctx context.Context, userID string) (int, error) {
 	m.ctrl.T.Helper()
@@ -175,6 +220,20 @@ func (mr *MockIItemRepositoryMockRecorder) CreateReviewdates(ctx, reviewdates an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReviewdates", reflect.TypeOf((*MockIItemRepository)(nil).CreateReviewdates), ctx, reviewdates)
 }
 
+// DeleteInCompletedReviewDatesAfterStep mocks base method.
+func (m *MockIItemRepository) DeleteInCompletedReviewDatesAfterStep(ctx context.Context, itemID, userID string, stepNumber int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInCompletedReviewDatesAfterStep", ctx, itemID, userID, stepNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInCompletedReviewDatesAfterStep indicates an expected call of DeleteInCompletedReviewDatesAfterStep.
+func (mr *MockIItemRepositoryMockRecorder) DeleteInCompletedReviewDatesAfterStep(ctx, itemID, userID, stepNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInCompletedReviewDatesAfterStep", reflect.TypeOf((*MockIItemRepository)(nil).DeleteInCompletedReviewDatesAfterStep), ctx, itemID, userID, stepNumber)
+}
+
 // DeleteItem mocks base method.
 func (m *MockIItemRepository) DeleteItem(ctx context.Context, itemID, userID string) error {
 	m.ctrl.T.Helper()
@@ -203,6 +262,21 @@ func (mr *MockIItemRepositoryMockRecorder) DeleteReviewDates(ctx, itemID, userID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReviewDates", reflect.TypeOf((*MockIItemRepository)(nil).DeleteReviewDates), ctx, itemID, userID)
 }
 
+// FindSM2StateByItemID mocks base method.
+func (m *MockIItemRepository) FindSM2StateByItemID(ctx context.Context, itemID, userID string) (*SM2State, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSM2StateByItemID", ctx, itemID, userID)
+	ret0, _ := ret[0].(*SM2State)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSM2StateByItemID indicates an expected call of FindSM2StateByItemID.
+func (mr *MockIItemRepositoryMockRecorder) FindSM2StateByItemID(ctx, itemID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSM2StateByItemID", reflect.TypeOf((*MockIItemRepository)(nil).FindSM2StateByItemID), ctx, itemID, userID)
+}
+
 // GetAllDailyReviewDates mocks base method.
 func (m *MockIItemRepository) GetAllDailyReviewDates(ctx context.Context, userID string, parsedToday time.Time) ([]*DailyReviewDate, error) {
 	m.ctrl.T.Helper()
@@ -218,6 +292,21 @@ func (mr *MockIItemRepositoryMockRecorder) GetAllDailyReviewDates(ctx, userID, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllDailyReviewDates", reflect.TypeOf((*MockIItemRepository)(nil).GetAllDailyReviewDates), ctx, userID, parsedToday)
 }
 
+// GetAllItemsByPatternID mocks base method.
+func (m *MockIItemRepository) GetAllItemsByPatternID(ctx context.Context, patternID, userID string) ([]*Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllItemsByPatternID", ctx, patternID, userID)
+	ret0, _ := ret[0].([]*Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllItemsByPatternID indicates an expected call of GetAllItemsByPatternID.
+func (mr *MockIItemRepositoryMockRecorder) GetAllItemsByPatternID(ctx, patternID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllItemsByPatternID", reflect.TypeOf((*MockIItemRepository)(nil).GetAllItemsByPatternID), ctx, patternID, userID)
+}
+
 // GetAllReviewDatesByBoxID mocks base method.
 func (m *MockIItemRepository) GetAllReviewDatesByBoxID(ctx context.Context, boxID, userID string) ([]*Reviewdate, error) {
 	m.ctrl.T.Helper()
@@ -248,6 +337,21 @@ func (mr *MockIItemRepositoryMockRecorder) GetAllUnFinishedItemsByBoxID(ctx, box
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUnFinishedItemsByBoxID", reflect.TypeOf((*MockIItemRepository)(nil).GetAllUnFinishedItemsByBoxID), ctx, boxID, userID)
 }
 
+// GetAllUnFinishedItemsByPatternID mocks base method.
+func (m *MockIItemRepository) GetAllUnFinishedItemsByPatternID(ctx context.Context, patternID, userID string) ([]*Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllUnFinishedItemsByPatternID", ctx, patternID, userID)
+	ret0, _ := ret[0].([]*Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllUnFinishedItemsByPatternID indicates an expected call of GetAllUnFinishedItemsByPatternID.
+func (mr *MockIItemRepositoryMockRecorder) GetAllUnFinishedItemsByPatternID(ctx, patternID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUnFinishedItemsByPatternID", reflect.TypeOf((*MockIItemRepository)(nil).GetAllUnFinishedItemsByPatternID), ctx, patternID, userID)
+}
+
 // GetAllUnFinishedUnclassifiedItemsByCategoryID mocks base method.
 func (m *MockIItemRepository) GetAllUnFinishedUnclassifiedItemsByCategoryID(ctx context.Context, categoryID, userID string) ([]*Item, error) {
 	m.ctrl.T.Helper()
@@ -308,6 +412,51 @@ func (mr *MockIItemRepositoryMockRecorder) GetAllUnclassifiedReviewDatesByUserID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUnclassifiedReviewDatesByUserID", reflect.TypeOf((*MockIItemRepository)(nil).GetAllUnclassifiedReviewDatesByUserID), ctx, userID)
 }
 
+// GetBlockedDatesByUserID mocks base method.
+func (m *MockIItemRepository) GetBlockedDatesByUserID(ctx context.Context, userID string) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedDatesByUserID", ctx, userID)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedDatesByUserID indicates an expected call of GetBlockedDatesByUserID.
+func (mr *MockIItemRepositoryMockRecorder) GetBlockedDatesByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedDatesByUserID", reflect.TypeOf((*MockIItemRepository)(nil).GetBlockedDatesByUserID), ctx, userID)
+}
+
+// GetDailyReviewLimitByUserID mocks base method.
+func (m *MockIItemRepository) GetDailyReviewLimitByUserID(ctx context.Context, userID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyReviewLimitByUserID", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyReviewLimitByUserID indicates an expected call of GetDailyReviewLimitByUserID.
+func (mr *MockIItemRepositoryMockRecorder) GetDailyReviewLimitByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyReviewLimitByUserID", reflect.TypeOf((*MockIItemRepository)(nil).GetDailyReviewLimitByUserID), ctx, userID)
+}
+
+// GetDueNowReviewDates mocks base method.
+func (m *MockIItemRepository) GetDueNowReviewDates(ctx context.Context, userID string, now time.Time) ([]*DueNowReviewDate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueNowReviewDates", ctx, userID, now)
+	ret0, _ := ret[0].([]*DueNowReviewDate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueNowReviewDates indicates an expected call of GetDueNowReviewDates.
+func (mr *MockIItemRepositoryMockRecorder) GetDueNowReviewDates(ctx, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueNowReviewDates", reflect.TypeOf((*MockIItemRepository)(nil).GetDueNowReviewDates), ctx, userID, now)
+}
+
 // GetEditedAtByItemID mocks base method.
 func (m *MockIItemRepository) GetEditedAtByItemID(ctx context.Context, itemID, userID string) (time.Time, error) {
 	m.ctrl.T.Helper()
@@ -323,6 +472,21 @@ func (mr *MockIItemRepositoryMockRecorder) GetEditedAtByItemID(ctx, itemID, user
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEditedAtByItemID", reflect.TypeOf((*MockIItemRepository)(nil).GetEditedAtByItemID), ctx, itemID, userID)
 }
 
+// GetExcludedWeekdaysByUserID mocks base method.
+func (m *MockIItemRepository) GetExcludedWeekdaysByUserID(ctx context.Context, userID string) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExcludedWeekdaysByUserID", ctx, userID)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExcludedWeekdaysByUserID indicates an expected call of GetExcludedWeekdaysByUserID.
+func (mr *MockIItemRepositoryMockRecorder) GetExcludedWeekdaysByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExcludedWeekdaysByUserID", reflect.TypeOf((*MockIItemRepository)(nil).GetExcludedWeekdaysByUserID), ctx, userID)
+}
+
 // GetFinishedItemsByBoxID mocks base method.
 func (m *MockIItemRepository) GetFinishedItemsByBoxID(ctx context.Context, boxID, userID string) ([]*Item, error) {
 	m.ctrl.T.Helper()
@@ -443,6 +607,34 @@ func (mr *MockIItemRepositoryMockRecorder) IsPatternRelatedToItemByPatternID(ctx
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPatternRelatedToItemByPatternID", reflect.TypeOf((*MockIItemRepository)(nil).IsPatternRelatedToItemByPatternID), ctx, patternID, userID)
 }
 
+// LockUnFinishedItemsByBoxID mocks base method.
+func (m *MockIItemRepository) LockUnFinishedItemsByBoxID(ctx context.Context, boxID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUnFinishedItemsByBoxID", ctx, boxID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUnFinishedItemsByBoxID indicates an expected call of LockUnFinishedItemsByBoxID.
+func (mr *MockIItemRepositoryMockRecorder) LockUnFinishedItemsByBoxID(ctx, boxID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUnFinishedItemsByBoxID", reflect.TypeOf((*MockIItemRepository)(nil).LockUnFinishedItemsByBoxID), ctx, boxID, userID)
+}
+
+// LockUnFinishedItemsByPatternID mocks base method.
+func (m *MockIItemRepository) LockUnFinishedItemsByPatternID(ctx context.Context, patternID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUnFinishedItemsByPatternID", ctx, patternID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUnFinishedItemsByPatternID indicates an expected call of LockUnFinishedItemsByPatternID.
+func (mr *MockIItemRepositoryMockRecorder) LockUnFinishedItemsByPatternID(ctx, patternID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUnFinishedItemsByPatternID", reflect.TypeOf((*MockIItemRepository)(nil).LockUnFinishedItemsByPatternID), ctx, patternID, userID)
+}
+
 // UpdateItem mocks base method.
 func (m *MockIItemRepository) UpdateItem(ctx context.Context, item *Item) error {
 	m.ctrl.T.Helper()
@@ -485,18 +677,61 @@ func (mr *MockIItemRepositoryMockRecorder) UpdateItemAsUnFinished(ctx, itemID, u
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemAsUnFinished", reflect.TypeOf((*MockIItemRepository)(nil).UpdateItemAsUnFinished), ctx, itemID, userID, editedAt)
 }
 
+// UpdateItemPatternVersionID mocks base method.
+func (m *MockIItemRepository) UpdateItemPatternVersionID(ctx context.Context, itemID, userID, patternVersionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItemPatternVersionID", ctx, itemID, userID, patternVersionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItemPatternVersionID indicates an expected call of UpdateItemPatternVersionID.
+func (mr *MockIItemRepositoryMockRecorder) UpdateItemPatternVersionID(ctx, itemID, userID, patternVersionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemPatternVersionID", reflect.TypeOf((*MockIItemRepository)(nil).UpdateItemPatternVersionID), ctx, itemID, userID, patternVersionID)
+}
+
+// UpdateItemsPatternIDByBoxID mocks base method.
+func (m *MockIItemRepository) UpdateItemsPatternIDByBoxID(ctx context.Context, boxID, userID, patternID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItemsPatternIDByBoxID", ctx, boxID, userID, patternID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateItemsPatternIDByBoxID indicates an expected call of UpdateItemsPatternIDByBoxID.
+func (mr *MockIItemRepositoryMockRecorder) UpdateItemsPatternIDByBoxID(ctx, boxID, userID, patternID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemsPatternIDByBoxID", reflect.TypeOf((*MockIItemRepository)(nil).UpdateItemsPatternIDByBoxID), ctx, boxID, userID, patternID)
+}
+
+// UpdateItemsPatternIDByPatternID mocks base method.
+func (m *MockIItemRepository) UpdateItemsPatternIDByPatternID(ctx context.Context, patternID, userID, newPatternID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItemsPatternIDByPatternID", ctx, patternID, userID, newPatternID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateItemsPatternIDByPatternID indicates an expected call of UpdateItemsPatternIDByPatternID.
+func (mr *MockIItemRepositoryMockRecorder) UpdateItemsPatternIDByPatternID(ctx, patternID, userID, newPatternID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItemsPatternIDByPatternID", reflect.TypeOf((*MockIItemRepository)(nil).UpdateItemsPatternIDByPatternID), ctx, patternID, userID, newPatternID)
+}
+
 // UpdateReviewDateAsCompleted mocks base method.
-func (m *MockIItemRepository) UpdateReviewDateAsCompleted(ctx context.Context, reviewdateID, userID string) error {
+func (m *MockIItemRepository) UpdateReviewDateAsCompleted(ctx context.Context, reviewdateID, userID string, recallGrade *string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateReviewDateAsCompleted", ctx, reviewdateID, userID)
+	ret := m.ctrl.Call(m, "UpdateReviewDateAsCompleted", ctx, reviewdateID, userID, recallGrade)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateReviewDateAsCompleted indicates an expected call of UpdateReviewDateAsCompleted.
-func (mr *MockIItemRepositoryMockRecorder) UpdateReviewDateAsCompleted(ctx, reviewdateID, userID any) *gomock.Call {
+func (mr *MockIItemRepositoryMockRecorder) UpdateReviewDateAsCompleted(ctx, reviewdateID, userID, recallGrade any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewDateAsCompleted", reflect.TypeOf((*MockIItemRepository)(nil).UpdateReviewDateAsCompleted), ctx, reviewdateID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewDateAsCompleted", reflect.TypeOf((*MockIItemRepository)(nil).UpdateReviewDateAsCompleted), ctx, reviewdateID, userID, recallGrade)
 }
 
 // UpdateReviewDateAsInCompleted mocks base method.
@@ -540,3 +775,17 @@ func (mr *MockIItemRepositoryMockRecorder) UpdateReviewDatesBack(ctx, reviewdate
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewDatesBack", reflect.TypeOf((*MockIItemRepository)(nil).UpdateReviewDatesBack), ctx, reviewdates, userID)
 }
+
+// UpsertSM2State mocks base method.
+func (m *MockIItemRepository) UpsertSM2State(ctx context.Context, state *SM2State) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSM2State", ctx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertSM2State indicates an expected call of UpsertSM2State.
+func (mr *MockIItemRepositoryMockRecorder) UpsertSM2State(ctx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSM2State", reflect.TypeOf((*MockIItemRepository)(nil).UpsertSM2State), ctx, state)
+}
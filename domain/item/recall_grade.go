@@ -0,0 +1,45 @@
+package item
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// 復習日完了時の想起評価
+// again: 思い出せなかった、hard: なんとか思い出せた、good: 思い出せた、easy: 簡単に思い出せた
+const (
+	RecallGradeAgain string = "again"
+	RecallGradeHard  string = "hard"
+	RecallGradeGood  string = "good"
+	RecallGradeEasy  string = "easy"
+)
+
+var allowedRecallGrades = map[string]struct{}{
+	RecallGradeAgain: {},
+	RecallGradeHard:  {},
+	RecallGradeGood:  {},
+	RecallGradeEasy:  {},
+}
+
+// easy評価時に次の復習日までの間隔を伸ばす倍率
+const easyIntervalMultiplier = 1.3
+
+func ValidateRecallGrade(recallGrade string) error {
+	return validation.Validate(
+		recallGrade,
+		validation.Required.Error("想起評価は必須です"),
+		validation.By(func(value interface{}) error {
+			grade, _ := value.(string)
+			if _, ok := allowedRecallGrades[grade]; !ok {
+				return errors.New("想起評価の値が不正です")
+			}
+			return nil
+		}),
+	)
+}
+
+// 想起評価によって後続の復習日の再計算が必要かどうか
+func IsRescheduleRequired(recallGrade string) bool {
+	return recallGrade == RecallGradeAgain || recallGrade == RecallGradeEasy
+}
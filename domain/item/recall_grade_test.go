@@ -0,0 +1,41 @@
+package item
+
+import "testing"
+
+func TestValidateRecallGrade(t *testing.T) {
+	tests := []struct {
+		name        string
+		recallGrade string
+		wantErr     bool
+	}{
+		{
+			name:        "again",
+			recallGrade: RecallGradeAgain,
+			wantErr:     false,
+		},
+		{
+			name:        "easy",
+			recallGrade: RecallGradeEasy,
+			wantErr:     false,
+		},
+		{
+			name:        "空文字",
+			recallGrade: "",
+			wantErr:     true,
+		},
+		{
+			name:        "未対応の値",
+			recallGrade: "perfect",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRecallGrade(tt.recallGrade)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRecallGrade() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
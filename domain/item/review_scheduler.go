@@ -1,6 +1,9 @@
 package item
 
 import (
+	"context"
+	"math"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,10 +11,116 @@ import (
 )
 
 // 復習日の計算を担うドメインサービス
-type scheduler struct{}
+// 復習日の算出方式はstrategyに委譲する（デフォルトは固定ステップ方式）
+// 算出した復習日が除外する曜日や復習日を置かない日付の場合は、次の復習日を置ける日にずらす
+// 分・時間単位のステップは学習日当日の復習日時として算出し、strategyには日単位以上のステップのみ渡す
+type scheduler struct {
+	strategies       map[string]ISchedulingStrategy
+	strategy         ISchedulingStrategy
+	excludedWeekdays ExcludedWeekdays
+	blockedDates     BlockedDates
+	now              func() time.Time
+}
 
 func NewScheduler() IScheduler {
-	return &scheduler{}
+	strategies := defaultStrategies()
+	return &scheduler{
+		strategies: strategies,
+		strategy:   strategies[PatternDomain.SchedulingAlgorithmFixed],
+		now:        time.Now,
+	}
+}
+
+// 指定された方式で復習日を算出するスケジューラを返す
+// 空文字の場合は固定ステップ方式とする
+func (s *scheduler) WithAlgorithm(schedulingAlgorithm string) (IScheduler, error) {
+	if schedulingAlgorithm == "" {
+		schedulingAlgorithm = PatternDomain.SchedulingAlgorithmFixed
+	}
+	strategy, ok := s.strategies[schedulingAlgorithm]
+	if !ok {
+		return nil, ErrUnknownSchedulingAlgorithm
+	}
+	return &scheduler{
+		strategies:       s.strategies,
+		strategy:         strategy,
+		excludedWeekdays: s.excludedWeekdays,
+		blockedDates:     s.blockedDates,
+		now:              s.now,
+	}, nil
+}
+
+// 除外する曜日を指定したスケジューラを返す
+func (s *scheduler) WithExcludedWeekdays(excludedWeekdays ExcludedWeekdays) IScheduler {
+	return &scheduler{
+		strategies:       s.strategies,
+		strategy:         s.strategy,
+		excludedWeekdays: excludedWeekdays,
+		blockedDates:     s.blockedDates,
+		now:              s.now,
+	}
+}
+
+// 復習日を置かない日付を指定したスケジューラを返す
+func (s *scheduler) WithBlockedDates(blockedDates BlockedDates) IScheduler {
+	return &scheduler{
+		strategies:       s.strategies,
+		strategy:         s.strategy,
+		excludedWeekdays: s.excludedWeekdays,
+		blockedDates:     blockedDates,
+		now:              s.now,
+	}
+}
+
+// 指定日が除外する曜日、または復習日を置かない日付かどうか
+func (s *scheduler) excludes(date time.Time) bool {
+	return s.excludedWeekdays.Excludes(date) || s.blockedDates.Contains(date)
+}
+
+// 指定日が復習日を置けない日の場合、次の復習日を置ける日付を返す
+func (s *scheduler) nextAllowedDate(date time.Time) time.Time {
+	for s.excludes(date) {
+		date = date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// 分・時間単位のステップは学習日当日（0日）とし、日単位以上のステップの日数はstrategyで算出する
+func (s *scheduler) OffsetDays(targetPatternSteps []*PatternDomain.PatternStep, parsedBaseDate time.Time) []int {
+	intradaySteps, dailySteps := PatternDomain.SplitIntradaySteps(targetPatternSteps)
+	offsets := make([]int, len(intradaySteps), len(targetPatternSteps))
+	return append(offsets, s.strategy.OffsetDays(dailySteps, parsedBaseDate)...)
+}
+
+// 分・時間単位のステップの復習日時
+// 起点日が今日以降の場合は現在時刻から、今日より前の場合は起点日の0時から間隔を数える
+func (s *scheduler) intradayScheduledAt(step *PatternDomain.PatternStep, parsedAnchorDate time.Time, parsedToday time.Time) time.Time {
+	if parsedAnchorDate.Before(parsedToday) {
+		return step.AddTo(parsedAnchorDate)
+	}
+	return step.AddTo(s.now().UTC())
+}
+
+// 最初の復習日が今日より前になる場合に、全ての復習日を後ろにずらす日数と、ずらした後の学習日からの日数を返す
+// 月単位のステップが同じ日付に揃うように、ずらした学習日を起点に日数を算出し直す
+func (s *scheduler) shiftedOffsetDays(
+	targetPatternSteps []*PatternDomain.PatternStep,
+	parsedLearnedDate time.Time,
+	parsedToday time.Time,
+) (int, []int) {
+	offsets := s.OffsetDays(targetPatternSteps, parsedLearnedDate)
+	// 分・時間単位のステップは学習日当日なので、最初の日単位以上のステップで判定する
+	intradaySteps, _ := PatternDomain.SplitIntradaySteps(targetPatternSteps)
+	if len(targetPatternSteps) == len(intradaySteps) {
+		return 0, offsets
+	}
+	firstScheduled := parsedLearnedDate.AddDate(0, 0, offsets[len(intradaySteps)])
+	if !firstScheduled.Before(parsedToday) {
+		return 0, offsets
+	}
+	addDuration := int(parsedToday.Sub(firstScheduled).Hours() / 24)
+	shiftedOffsets := s.OffsetDays(targetPatternSteps, parsedLearnedDate.AddDate(0, 0, addDuration))
+	return addDuration, shiftedOffsets
 }
 
 // 作成
@@ -26,10 +135,30 @@ func (s *scheduler) FormatWithOverdueMarkedCompleted(
 ) ([]*Reviewdate, bool, error) {
 
 	result := make([]*Reviewdate, len(targetPatternSteps))
+	offsets := s.OffsetDays(targetPatternSteps, parsedLearnedDate)
 
 	for i, step := range targetPatternSteps {
+		// 分・時間単位のステップは学習日当日の復習日時とし、学習日が今日より前なら完了扱い
+		if step.IsIntraday() {
+			reviewdate, err := NewIntradayReviewdate(
+				uuid.NewString(),
+				userID,
+				categoryID,
+				boxID,
+				itemID,
+				step.StepNumber(),
+				parsedLearnedDate,
+				s.intradayScheduledAt(step, parsedLearnedDate, parsedToday),
+				parsedLearnedDate.Before(parsedToday),
+			)
+			if err != nil {
+				return nil, false, err
+			}
+			result[i] = reviewdate
+			continue
+		}
 		reviewDateID := uuid.NewString()
-		calculatedScheduledDate := parsedLearnedDate.AddDate(0, 0, step.IntervalDays())
+		calculatedScheduledDate := s.nextAllowedDate(parsedLearnedDate.AddDate(0, 0, offsets[i]))
 
 		reviewdate, err := NewReviewdate(
 			reviewDateID,
@@ -67,20 +196,31 @@ func (s *scheduler) FormatWithOverdueMarkedInCompleted(
 	parsedToday time.Time,
 ) ([]*Reviewdate, error) {
 	result := make([]*Reviewdate, len(targetPatternSteps))
-
-	var addDuration int
-	if len(targetPatternSteps) > 0 {
-		firstScheduled := parsedLearnedDate.AddDate(0, 0, targetPatternSteps[0].IntervalDays())
-		if firstScheduled.Before(parsedToday) {
-			addDuration = int(parsedToday.Sub(firstScheduled).Hours() / 24)
-		} else {
-			addDuration = 0
-		}
-	}
+	addDuration, offsets := s.shiftedOffsetDays(targetPatternSteps, parsedLearnedDate, parsedToday)
+	// 分・時間単位のステップは、ずらした後の学習日を起点とする
+	parsedAnchorDate := parsedLearnedDate.AddDate(0, 0, addDuration)
 
 	for i, step := range targetPatternSteps {
+		if step.IsIntraday() {
+			reviewdate, err := NewIntradayReviewdate(
+				uuid.NewString(),
+				userID,
+				categoryID,
+				boxID,
+				itemID,
+				step.StepNumber(),
+				parsedAnchorDate,
+				s.intradayScheduledAt(step, parsedAnchorDate, parsedToday),
+				false,
+			)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = reviewdate
+			continue
+		}
 		reviewDateID := uuid.NewString()
-		calculatedScheduledDate := parsedLearnedDate.AddDate(0, 0, step.IntervalDays()+addDuration)
+		calculatedScheduledDate := s.nextAllowedDate(parsedLearnedDate.AddDate(0, 0, offsets[i]+addDuration))
 
 		reviewdate, err := NewReviewdate(
 			reviewDateID,
@@ -119,9 +259,28 @@ func (s *scheduler) FormatWithOverdueMarkedCompletedWithIDs(
 	}
 
 	result := make([]*Reviewdate, len(targetPatternSteps))
+	offsets := s.OffsetDays(targetPatternSteps, parsedLearnedDate)
 
 	for i, step := range targetPatternSteps {
-		calculatedScheduledDate := parsedLearnedDate.AddDate(0, 0, step.IntervalDays())
+		if step.IsIntraday() {
+			reviewdate, err := NewIntradayReviewdate(
+				reviewDateIDs[i],
+				userID,
+				categoryID,
+				boxID,
+				itemID,
+				step.StepNumber(),
+				parsedLearnedDate,
+				s.intradayScheduledAt(step, parsedLearnedDate, parsedToday),
+				parsedLearnedDate.Before(parsedToday),
+			)
+			if err != nil {
+				return nil, false, err
+			}
+			result[i] = reviewdate
+			continue
+		}
+		calculatedScheduledDate := s.nextAllowedDate(parsedLearnedDate.AddDate(0, 0, offsets[i]))
 
 		reviewdate, err := NewReviewdate(
 			reviewDateIDs[i],
@@ -163,19 +322,30 @@ func (s *scheduler) FormatWithOverdueMarkedInCompletedWithIDs(
 	}
 
 	result := make([]*Reviewdate, len(targetPatternSteps))
-
-	var addDuration int
-	if len(targetPatternSteps) > 0 {
-		firstScheduled := parsedLearnedDate.AddDate(0, 0, targetPatternSteps[0].IntervalDays())
-		if firstScheduled.Before(parsedToday) {
-			addDuration = int(parsedToday.Sub(firstScheduled).Hours() / 24)
-		} else {
-			addDuration = 0
-		}
-	}
+	addDuration, offsets := s.shiftedOffsetDays(targetPatternSteps, parsedLearnedDate, parsedToday)
+	// 分・時間単位のステップは、ずらした後の学習日を起点とする
+	parsedAnchorDate := parsedLearnedDate.AddDate(0, 0, addDuration)
 
 	for i, step := range targetPatternSteps {
-		calculatedScheduledDate := parsedLearnedDate.AddDate(0, 0, step.IntervalDays()+addDuration)
+		if step.IsIntraday() {
+			reviewdate, err := NewIntradayReviewdate(
+				reviewDateIDs[i],
+				userID,
+				categoryID,
+				boxID,
+				itemID,
+				step.StepNumber(),
+				parsedAnchorDate,
+				s.intradayScheduledAt(step, parsedAnchorDate, parsedToday),
+				false,
+			)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = reviewdate
+			continue
+		}
+		calculatedScheduledDate := s.nextAllowedDate(parsedLearnedDate.AddDate(0, 0, offsets[i]+addDuration))
 		reviewdate, err := NewReviewdate(
 			reviewDateIDs[i],
 			userID,
@@ -211,9 +381,29 @@ func (s *scheduler) FormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates(
 	}
 
 	result := make([]*Reviewdate, len(targetPatternSteps))
+	offsets := s.OffsetDays(targetPatternSteps, parsedLearnedDate)
 
 	for i, step := range targetPatternSteps {
-		calculatedScheduledDate := parsedLearnedDate.AddDate(0, 0, step.IntervalDays())
+		// 分・時間単位のステップは学習日当日のまま変えない
+		if step.IsIntraday() {
+			reviewdate, err := NewIntradayReviewdate(
+				reviewDateIDs[i],
+				userID,
+				categoryID,
+				boxID,
+				itemID,
+				step.StepNumber(),
+				parsedLearnedDate,
+				step.AddTo(parsedLearnedDate),
+				false,
+			)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = reviewdate
+			continue
+		}
+		calculatedScheduledDate := s.nextAllowedDate(parsedLearnedDate.AddDate(0, 0, offsets[i]))
 
 		reviewdate, err := NewReviewdate(
 			reviewDateIDs[i],
@@ -234,3 +424,445 @@ func (s *scheduler) FormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates(
 
 	return result, nil
 }
+
+// 想起評価による後続の復習日の再計算
+// again: 今日を学習日とみなし、後続の復習日をパターンの最初のステップから組み直す
+// easy: 次の復習日までの間隔をeasyIntervalMultiplier倍に伸ばし、以降の復習日も同じ日数だけ後ろにずらす
+// hard/good: 変更しない
+// 分・時間単位のステップの復習日は再計算しない
+func (s *scheduler) RescheduleByRecallGrade(
+	targetPatternSteps []*PatternDomain.PatternStep,
+	reviewdates []*Reviewdate,
+	completedStepNumber int,
+	recallGrade string,
+	parsedToday time.Time,
+) ([]*Reviewdate, error) {
+	if !IsRescheduleRequired(recallGrade) {
+		return []*Reviewdate{}, nil
+	}
+
+	var completed *Reviewdate
+	following := make([]*Reviewdate, 0, len(reviewdates))
+	for _, rd := range reviewdates {
+		switch {
+		case rd.StepNumber() == completedStepNumber:
+			completed = rd
+		case rd.StepNumber() > completedStepNumber && !rd.IsCompleted() && !rd.IsIntraday():
+			following = append(following, rd)
+		}
+	}
+	if completed == nil || len(following) == 0 {
+		return []*Reviewdate{}, nil
+	}
+
+	newDates := make([]time.Time, len(following))
+	switch recallGrade {
+	case RecallGradeAgain:
+		_, dailySteps := PatternDomain.SplitIntradaySteps(targetPatternSteps)
+		offsets := s.strategy.OffsetDays(dailySteps, parsedToday)
+		if len(following) > len(offsets) {
+			return nil, ErrMismatchedIDsAndSteps
+		}
+		for i := range following {
+			newDates[i] = s.nextAllowedDate(parsedToday.AddDate(0, 0, offsets[i]))
+		}
+	case RecallGradeEasy:
+		gap := int(following[0].ScheduledDate().Sub(completed.ScheduledDate()).Hours() / 24)
+		shift := roundGap(float64(gap)*easyIntervalMultiplier) - gap
+		if shift < 1 {
+			shift = 1
+		}
+		for i, rd := range following {
+			newDates[i] = s.nextAllowedDate(rd.ScheduledDate().AddDate(0, 0, shift))
+		}
+	}
+
+	result := make([]*Reviewdate, len(following))
+	for i, rd := range following {
+		// 再計算後の日付を新たな初期復習日とする（againの場合は初期復習日より前になり得るため）
+		reviewdate, err := NewReviewdate(
+			rd.ReviewdateID(),
+			rd.UserID(),
+			rd.CategoryID(),
+			rd.BoxID(),
+			rd.ItemID(),
+			rd.StepNumber(),
+			newDates[i],
+			newDates[i],
+			false,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = reviewdate
+	}
+	return result, nil
+}
+
+// パターンのステップ変更時の復習日の組み直し
+// 完了済みの復習日は残すため、lastCompletedStepNumberより後のステップの復習日だけを学習日から算出し直す
+// 算出した最初の復習日が今日より前になる場合は、今日になるように以降の復習日も同じ日数だけ後ろにずらす
+// 分・時間単位のステップは学習日当日の復習のため生成しない
+func (s *scheduler) RescheduleForUpdatedSteps(
+	targetPatternSteps []*PatternDomain.PatternStep,
+	userID string,
+	categoryID *string,
+	boxID *string,
+	itemID string,
+	lastCompletedStepNumber int,
+	parsedLearnedDate time.Time,
+	parsedToday time.Time,
+) ([]*Reviewdate, error) {
+	_, dailySteps := PatternDomain.SplitIntradaySteps(targetPatternSteps)
+	offsets := s.strategy.OffsetDays(dailySteps, parsedLearnedDate)
+
+	remainingSteps := make([]*PatternDomain.PatternStep, 0, len(dailySteps))
+	remainingDates := make([]time.Time, 0, len(dailySteps))
+	for i, step := range dailySteps {
+		if step.StepNumber() <= lastCompletedStepNumber {
+			continue
+		}
+		remainingSteps = append(remainingSteps, step)
+		remainingDates = append(remainingDates, parsedLearnedDate.AddDate(0, 0, offsets[i]))
+	}
+	if len(remainingSteps) == 0 {
+		return []*Reviewdate{}, nil
+	}
+
+	shift := 0
+	if remainingDates[0].Before(parsedToday) {
+		shift = int(parsedToday.Sub(remainingDates[0]).Hours() / 24)
+	}
+
+	result := make([]*Reviewdate, len(remainingSteps))
+	for i, step := range remainingSteps {
+		calculatedScheduledDate := s.nextAllowedDate(remainingDates[i].AddDate(0, 0, shift))
+		reviewdate, err := NewReviewdate(
+			uuid.NewString(),
+			userID,
+			categoryID,
+			boxID,
+			itemID,
+			step.StepNumber(),
+			calculatedScheduledDate,
+			calculatedScheduledDate,
+			false,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = reviewdate
+	}
+	return result, nil
+}
+
+// 適応型の方式では復習日を事前に生成しないため、完了した復習日を起点に次のステップの復習日を1件だけ生成する
+// 起点日は実際に復習した日（クライアントの今日）とする
+func (s *scheduler) NextAdaptiveReviewdate(
+	completedReviewdate *Reviewdate,
+	state *SM2State,
+	parsedBaseDate time.Time,
+) (*Reviewdate, error) {
+	calculatedScheduledDate := s.nextAllowedDate(parsedBaseDate.AddDate(0, 0, state.IntervalDays()))
+	return NewReviewdate(
+		uuid.NewString(),
+		completedReviewdate.UserID(),
+		completedReviewdate.CategoryID(),
+		completedReviewdate.BoxID(),
+		completedReviewdate.ItemID(),
+		completedReviewdate.StepNumber()+1,
+		calculatedScheduledDate,
+		calculatedScheduledDate,
+		false,
+	)
+}
+
+// 繰り返しの間隔日数が設定されたパターンでは、最後のステップ以降の復習日を起点日から間隔日数後に1件ずつ生成する
+// ステップ番号は直前の復習日の次の番号とする。算出した復習日が今日より前になる場合は今日にずらす
+func (s *scheduler) NextMaintenanceReviewdate(
+	lastReviewdate *Reviewdate,
+	maintenanceIntervalDays int,
+	parsedBaseDate time.Time,
+	parsedToday time.Time,
+) (*Reviewdate, error) {
+	calculatedScheduledDate := parsedBaseDate.AddDate(0, 0, maintenanceIntervalDays)
+	if calculatedScheduledDate.Before(parsedToday) {
+		calculatedScheduledDate = parsedToday
+	}
+	calculatedScheduledDate = s.nextAllowedDate(calculatedScheduledDate)
+	return NewReviewdate(
+		uuid.NewString(),
+		lastReviewdate.UserID(),
+		lastReviewdate.CategoryID(),
+		lastReviewdate.BoxID(),
+		lastReviewdate.ItemID(),
+		lastReviewdate.StepNumber()+1,
+		calculatedScheduledDate,
+		calculatedScheduledDate,
+		false,
+	)
+}
+
+// 負荷分散で復習日をずらせる範囲。直前の復習日（最初のステップは学習日）からの間隔に対する割合
+const loadBalanceToleranceRatio = 0.1
+
+// 各復習日を前後にずらせる日数
+func loadBalanceTolerances(reviewdates []*Reviewdate, parsedLearnedDate time.Time) []int {
+	tolerances := make([]int, len(reviewdates))
+	prev := parsedLearnedDate
+	for i, rd := range reviewdates {
+		gap := int(rd.ScheduledDate().Sub(prev).Hours() / 24)
+		tolerances[i] = int(math.Round(float64(gap) * loadBalanceToleranceRatio))
+		prev = rd.ScheduledDate()
+	}
+	return tolerances
+}
+
+// 負荷分散に必要な復習予定数の集計期間を返す
+func LoadBalanceRange(reviewdates []*Reviewdate, parsedLearnedDate time.Time) (time.Time, time.Time) {
+	if len(reviewdates) == 0 {
+		return parsedLearnedDate, parsedLearnedDate
+	}
+	tolerances := loadBalanceTolerances(reviewdates, parsedLearnedDate)
+	from := reviewdates[0].ScheduledDate().AddDate(0, 0, -tolerances[0])
+	to := reviewdates[len(reviewdates)-1].ScheduledDate().AddDate(0, 0, tolerances[len(reviewdates)-1])
+	return from, to
+}
+
+// 負荷分散
+// 未完了の各復習日を、許容範囲内でユーザーの復習予定数が最も少ない日にずらす（同数の場合は元の日に近い日、さらに同じなら前の日を優先）
+// ずらした後も復習日の順序は保ち、今日より前にはずらさない。ずらした日を新たな初期復習日とする
+// 分・時間単位のステップの復習日はずらさない
+func (s *scheduler) LoadBalance(
+	reviewdates []*Reviewdate,
+	dailyCounts []*DailyScheduledCount,
+	parsedLearnedDate time.Time,
+	parsedToday time.Time,
+) ([]*Reviewdate, error) {
+	counts := make(map[string]int, len(dailyCounts))
+	for _, dc := range dailyCounts {
+		counts[dc.ScheduledDate.Format("2006-01-02")] = dc.Count
+	}
+	tolerances := loadBalanceTolerances(reviewdates, parsedLearnedDate)
+
+	result := make([]*Reviewdate, len(reviewdates))
+	prevPlaced := parsedLearnedDate
+	for i, rd := range reviewdates {
+		if rd.IsCompleted() || rd.IsIntraday() {
+			result[i] = rd
+			prevPlaced = rd.ScheduledDate()
+			continue
+		}
+
+		original := rd.ScheduledDate()
+		var placed time.Time
+		bestCount := -1
+		// 元の日から近い順（同じ距離なら前の日から）に候補を調べる
+		for d := 0; d <= tolerances[i]; d++ {
+			offsets := []int{-d, d}
+			if d == 0 {
+				offsets = []int{0}
+			}
+			for _, offset := range offsets {
+				candidate := original.AddDate(0, 0, offset)
+				if !candidate.After(prevPlaced) || candidate.Before(parsedToday) || s.excludes(candidate) {
+					continue
+				}
+				count := counts[candidate.Format("2006-01-02")]
+				if bestCount == -1 || count < bestCount {
+					placed = candidate
+					bestCount = count
+				}
+			}
+		}
+		// 直前の復習日をずらした結果、候補が1つもない場合は直前の復習日の翌日（復習日を置けない日の場合はその次の日）にする
+		if bestCount == -1 {
+			placed = s.nextAllowedDate(prevPlaced.AddDate(0, 0, 1))
+		}
+		counts[placed.Format("2006-01-02")]++
+		prevPlaced = placed
+
+		reviewdate, err := NewReviewdate(
+			rd.ReviewdateID(),
+			rd.UserID(),
+			rd.CategoryID(),
+			rd.BoxID(),
+			rd.ItemID(),
+			rd.StepNumber(),
+			placed,
+			placed,
+			false,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = reviewdate
+	}
+	return result, nil
+}
+
+// 1日あたりの復習数の上限を超えた復習日の繰り越し先を探す期間（日数）
+const DailyReviewLimitSearchDays = 366
+
+// 負荷分散と上限を超えた復習日の繰り越しに必要な復習予定数の集計期間を返す
+func DailyLoadRange(reviewdates []*Reviewdate, parsedLearnedDate time.Time, dailyReviewLimit int) (time.Time, time.Time) {
+	from, to := LoadBalanceRange(reviewdates, parsedLearnedDate)
+	if dailyReviewLimit > 0 {
+		to = to.AddDate(0, 0, DailyReviewLimitSearchDays)
+	}
+	return from, to
+}
+
+// 日毎の復習予定数に合わせた調整で使う、ユーザーの上限と日毎の復習予定数の読み取り
+// IItemRepositoryが満たす。読み取りだけなので、保存しないプレビューでも使える
+type DailyLoadReader interface {
+	GetDailyReviewLimitByUserID(ctx context.Context, userID string) (int, error)
+	CountScheduledDatesGroupedByDateByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*DailyScheduledCount, error)
+}
+
+// 生成した復習日をユーザーの日毎の復習予定数に合わせて調整する
+// パターンで負荷分散が有効な場合は復習予定数が少ない日にずらし、1日あたりの復習数の上限がある場合は上限を超える復習日を空きのある日に繰り越す
+// 復習物の作成・更新と復習スケジュールのプレビューで同じ復習日になるように、どちらもこの調整を使う
+func AdjustReviewdatesToDailyLoad(
+	ctx context.Context,
+	reader DailyLoadReader,
+	scheduler IScheduler,
+	isLoadBalanced bool,
+	reviewdates []*Reviewdate,
+	userID string,
+	parsedLearnedDate time.Time,
+	parsedToday time.Time,
+) ([]*Reviewdate, error) {
+	if len(reviewdates) == 0 {
+		return reviewdates, nil
+	}
+	dailyReviewLimit, err := reader.GetDailyReviewLimitByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isLoadBalanced && dailyReviewLimit == 0 {
+		return reviewdates, nil
+	}
+	fromDate, toDate := DailyLoadRange(reviewdates, parsedLearnedDate, dailyReviewLimit)
+	dailyCounts, err := reader.CountScheduledDatesGroupedByDateByUserID(ctx, userID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	if isLoadBalanced {
+		reviewdates, err = scheduler.LoadBalance(reviewdates, dailyCounts, parsedLearnedDate, parsedToday)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dailyReviewLimit > 0 {
+		return scheduler.DeferOverflow(reviewdates, dailyCounts, dailyReviewLimit, parsedToday)
+	}
+	return reviewdates, nil
+}
+
+// 1日あたりの復習数の上限を超える復習日の繰り越し
+// 未完了の各復習日について、その日の復習予定数が上限に達している場合は空きのある次の日（除外する曜日と復習日を置かない日付を除く）にずらす
+// ずらした後も復習日の順序は保ち、ずらした日を新たな初期復習日とする。今日より前の復習日はバッチ処理でずらすのでそのままにする
+// 分・時間単位のステップの復習日は上限の対象外とし、そのままにする
+func (s *scheduler) DeferOverflow(
+	reviewdates []*Reviewdate,
+	dailyCounts []*DailyScheduledCount,
+	dailyReviewLimit int,
+	parsedToday time.Time,
+) ([]*Reviewdate, error) {
+	if dailyReviewLimit <= 0 {
+		return reviewdates, nil
+	}
+	counts := make(map[string]int, len(dailyCounts))
+	for _, dc := range dailyCounts {
+		counts[dc.ScheduledDate.Format("2006-01-02")] = dc.Count
+	}
+
+	result := make([]*Reviewdate, len(reviewdates))
+	var prevPlaced time.Time
+	for i, rd := range reviewdates {
+		if rd.IsCompleted() || rd.IsIntraday() || rd.ScheduledDate().Before(parsedToday) {
+			result[i] = rd
+			prevPlaced = rd.ScheduledDate()
+			continue
+		}
+
+		placed := rd.ScheduledDate()
+		// 直前の復習日を繰り越した結果、順序が逆転する場合は直前の復習日の翌日から探す
+		if !prevPlaced.IsZero() && !placed.After(prevPlaced) {
+			placed = prevPlaced.AddDate(0, 0, 1)
+		}
+		for counts[placed.Format("2006-01-02")] >= dailyReviewLimit || s.excludes(placed) {
+			placed = placed.AddDate(0, 0, 1)
+		}
+		counts[placed.Format("2006-01-02")]++
+		prevPlaced = placed
+
+		if placed.Equal(rd.ScheduledDate()) {
+			result[i] = rd
+			continue
+		}
+		reviewdate, err := NewReviewdate(
+			rd.ReviewdateID(),
+			rd.UserID(),
+			rd.CategoryID(),
+			rd.BoxID(),
+			rd.ItemID(),
+			rd.StepNumber(),
+			placed,
+			placed,
+			false,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = reviewdate
+	}
+	return result, nil
+}
+
+// 期限切れの復習物を繰り越す際の優先順位（値が小さいほど先に今日に近い日を割り当てる）
+var targetWeightPriorities = map[string]int{
+	PatternDomain.TargetWeightHeavy:  0,
+	PatternDomain.TargetWeightNormal: 1,
+	PatternDomain.TargetWeightLight:  2,
+	PatternDomain.TargetWeightUnset:  3,
+}
+
+// 1日あたりの復習数の上限があるユーザーの期限切れの復習物の繰り越し先を決める
+// 重みが大きいパターンの復習物から順に（同じ重みなら期限切れの復習日が古い順に）、今日以降で復習予定数が上限に達していない最も早い日を割り当てる
+// 復習物毎に除外する曜日は異なるため、除外する曜日の日は復習物毎に飛ばす。ユーザーの復習日を置かない日付は全ての復習物で飛ばす
+// 戻り値は復習物ID毎の繰り越し先の日付
+func AssignOverdueItemsWithinDailyLimit(
+	overdueItems []*OverdueItem,
+	dailyCounts []*DailyScheduledCount,
+	dailyReviewLimit int,
+	blockedDates BlockedDates,
+	parsedToday time.Time,
+) map[string]time.Time {
+	counts := make(map[string]int, len(dailyCounts))
+	for _, dc := range dailyCounts {
+		counts[dc.ScheduledDate.Format("2006-01-02")] = dc.Count
+	}
+
+	sorted := make([]*OverdueItem, len(overdueItems))
+	copy(sorted, overdueItems)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		pi, pj := targetWeightPriorities[sorted[i].TargetWeight], targetWeightPriorities[sorted[j].TargetWeight]
+		if pi != pj {
+			return pi < pj
+		}
+		return sorted[i].OldDate.Before(sorted[j].OldDate)
+	})
+
+	result := make(map[string]time.Time, len(sorted))
+	for _, oi := range sorted {
+		placed := parsedToday
+		for (dailyReviewLimit > 0 && counts[placed.Format("2006-01-02")] >= dailyReviewLimit) || oi.ExcludedWeekdays.Excludes(placed) || blockedDates.Contains(placed) {
+			placed = placed.AddDate(0, 0, 1)
+		}
+		counts[placed.Format("2006-01-02")]++
+		result[oi.ItemID] = placed
+	}
+	return result
+}
@@ -27,11 +27,11 @@ func TestFormatWithOverdueMarkedCompleted(t *testing.T) {
 			name: "正常なパターンステップで復習日が今日より前（完了扱い）",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 3)
+					step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -49,11 +49,11 @@ func TestFormatWithOverdueMarkedCompleted(t *testing.T) {
 			name: "正常なパターンステップで復習日が今日以降（未完了扱い）",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 5)
+					step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 5, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 10)
+					step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 10, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -71,7 +71,7 @@ func TestFormatWithOverdueMarkedCompleted(t *testing.T) {
 			name: "単一ステップでの処理",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -176,11 +176,11 @@ func TestFormatWithOverdueMarkedInCompleted(t *testing.T) {
 			name: "最初のステップが今日より前の場合",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step3", "user1", "pattern1", 2, 3)
+					step, _ := PatternDomain.ReconstructPatternStep("step3", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -197,11 +197,11 @@ func TestFormatWithOverdueMarkedInCompleted(t *testing.T) {
 			name: "最初のステップが今日以降の場合",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step4", "user1", "pattern1", 1, 10)
+					step, _ := PatternDomain.ReconstructPatternStep("step4", "user1", "pattern1", 1, 10, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step5", "user1", "pattern1", 2, 15)
+					step, _ := PatternDomain.ReconstructPatternStep("step5", "user1", "pattern1", 2, 15, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -218,7 +218,7 @@ func TestFormatWithOverdueMarkedInCompleted(t *testing.T) {
 			name: "単一ステップでの処理",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step6", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step6", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -328,11 +328,11 @@ func TestFormatWithOverdueMarkedCompletedWithIDs(t *testing.T) {
 			name: "正常なID付きパターンステップでの処理",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step7", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step7", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step8", "user1", "pattern1", 2, 3)
+					step, _ := PatternDomain.ReconstructPatternStep("step8", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -351,11 +351,11 @@ func TestFormatWithOverdueMarkedCompletedWithIDs(t *testing.T) {
 			name: "IDとステップ数の不一致エラー",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step9", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step9", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step10", "user1", "pattern1", 2, 3)
+					step, _ := PatternDomain.ReconstructPatternStep("step10", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -375,11 +375,11 @@ func TestFormatWithOverdueMarkedCompletedWithIDs(t *testing.T) {
 			name: "今日以降の最終ステップ（未完了扱い）",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step11", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step11", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step12", "user1", "pattern1", 2, 10)
+					step, _ := PatternDomain.ReconstructPatternStep("step12", "user1", "pattern1", 2, 10, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -478,11 +478,11 @@ func TestFormatWithOverdueMarkedInCompletedWithIDs(t *testing.T) {
 			name: "正常なID付きパターンステップでの処理",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step13", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step13", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step14", "user1", "pattern1", 2, 3)
+					step, _ := PatternDomain.ReconstructPatternStep("step14", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -500,11 +500,11 @@ func TestFormatWithOverdueMarkedInCompletedWithIDs(t *testing.T) {
 			name: "IDとステップ数の不一致エラー",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step15", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step15", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step16", "user1", "pattern1", 2, 3)
+					step, _ := PatternDomain.ReconstructPatternStep("step16", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -621,11 +621,11 @@ func TestFormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates(t *testing.
 			name: "正常なバック復習日処理",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step17", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step17", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step18", "user1", "pattern1", 2, 3)
+					step, _ := PatternDomain.ReconstructPatternStep("step18", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -643,11 +643,11 @@ func TestFormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates(t *testing.
 			name: "diffが設定されている場合",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step19", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step19", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step20", "user1", "pattern1", 2, 3)
+					step, _ := PatternDomain.ReconstructPatternStep("step20", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -665,11 +665,11 @@ func TestFormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates(t *testing.
 			name: "IDとステップ数の不一致エラー",
 			targetPatternSteps: []*PatternDomain.PatternStep{
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step21", "user1", "pattern1", 1, 1)
+					step, _ := PatternDomain.ReconstructPatternStep("step21", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 				func() *PatternDomain.PatternStep {
-					step, _ := PatternDomain.ReconstructPatternStep("step22", "user1", "pattern1", 2, 3)
+					step, _ := PatternDomain.ReconstructPatternStep("step22", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
 					return step
 				}(),
 			},
@@ -756,6 +756,1260 @@ func TestFormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates(t *testing.
 	}
 }
 
+func TestWithAlgorithm(t *testing.T) {
+	scheduler := NewScheduler()
+	tests := []struct {
+		name                string
+		schedulingAlgorithm string
+		wantErr             error
+	}{
+		{
+			name:                "固定ステップ方式",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmFixed,
+			wantErr:             nil,
+		},
+		{
+			name:                "空文字の場合は固定ステップ方式",
+			schedulingAlgorithm: "",
+			wantErr:             nil,
+		},
+		{
+			name:                "FSRS風方式",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmFSRS,
+			wantErr:             nil,
+		},
+		{
+			name:                "未対応の方式",
+			schedulingAlgorithm: "unknown",
+			wantErr:             ErrUnknownSchedulingAlgorithm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scheduler.WithAlgorithm(tt.schedulingAlgorithm)
+			if err != tt.wantErr {
+				t.Errorf("WithAlgorithm() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got == nil {
+				t.Errorf("WithAlgorithm() returned nil scheduler")
+			}
+		})
+	}
+}
+
+func TestWithExcludedWeekdays(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	// 学習日の2024-01-01は月曜日。ステップは1日後（火曜日）と5日後（土曜日）
+	targetPatternSteps := []*PatternDomain.PatternStep{
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 5, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+	}
+
+	tests := []struct {
+		name             string
+		excludedWeekdays ExcludedWeekdays
+		parsedToday      time.Time
+		wantDates        []time.Time
+	}{
+		{
+			name:             "除外する曜日がない場合はそのまま",
+			excludedWeekdays: NewExcludedWeekdays(),
+			parsedToday:      date(1, 1),
+			wantDates:        []time.Time{date(1, 2), date(1, 6)},
+		},
+		{
+			name:             "土日を除外する場合は月曜日にずらす",
+			excludedWeekdays: NewExcludedWeekdays([]int{0, 6}),
+			parsedToday:      date(1, 1),
+			wantDates:        []time.Time{date(1, 2), date(1, 8)},
+		},
+		{
+			name:             "期限切れで今日にずらした復習日も除外する曜日を避ける",
+			excludedWeekdays: NewExcludedWeekdays([]int{0, 6}),
+			// 今日（土曜日）に1件目をずらすと、1件目は月曜日、2件目は1/10（水曜日）になる
+			parsedToday: date(1, 6),
+			wantDates:   []time.Time{date(1, 8), date(1, 10)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// スケジューリング方式を切り替えても除外する曜日は引き継ぐ
+			scheduler, err := NewScheduler().WithExcludedWeekdays(tt.excludedWeekdays).WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed)
+			if err != nil {
+				t.Fatalf("WithAlgorithm() error = %v", err)
+			}
+			got, err := scheduler.FormatWithOverdueMarkedInCompleted(targetPatternSteps, "user1", nil, nil, "item1", date(1, 1), tt.parsedToday)
+			if err != nil {
+				t.Fatalf("FormatWithOverdueMarkedInCompleted() error = %v", err)
+			}
+			if len(got) != len(tt.wantDates) {
+				t.Fatalf("FormatWithOverdueMarkedInCompleted() returned %d review dates, want %d", len(got), len(tt.wantDates))
+			}
+			for i, rd := range got {
+				if !rd.ScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tt.wantDates[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWithBlockedDates(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	// 学習日の2024-01-01は月曜日。ステップは1日後（火曜日）と5日後（土曜日）
+	targetPatternSteps := []*PatternDomain.PatternStep{
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 5, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+	}
+
+	tests := []struct {
+		name             string
+		excludedWeekdays ExcludedWeekdays
+		blockedDates     BlockedDates
+		wantDates        []time.Time
+	}{
+		{
+			name:         "復習日を置かない日付がない場合はそのまま",
+			blockedDates: NewBlockedDates(nil),
+			wantDates:    []time.Time{date(1, 2), date(1, 6)},
+		},
+		{
+			name:         "復習日を置かない日付が連続する場合はその次の日にずらす",
+			blockedDates: NewBlockedDates([]time.Time{date(1, 2), date(1, 3)}),
+			wantDates:    []time.Time{date(1, 4), date(1, 6)},
+		},
+		{
+			name:             "除外する曜日と合わせて避ける",
+			excludedWeekdays: NewExcludedWeekdays([]int{0}),
+			blockedDates:     NewBlockedDates([]time.Time{date(1, 6), date(1, 8)}),
+			wantDates:        []time.Time{date(1, 2), date(1, 9)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// スケジューリング方式や除外する曜日を切り替えても復習日を置かない日付は引き継ぐ
+			scheduler, err := NewScheduler().WithBlockedDates(tt.blockedDates).WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed)
+			if err != nil {
+				t.Fatalf("WithAlgorithm() error = %v", err)
+			}
+			scheduler = scheduler.WithExcludedWeekdays(tt.excludedWeekdays)
+			got, err := scheduler.FormatWithOverdueMarkedInCompleted(targetPatternSteps, "user1", nil, nil, "item1", date(1, 1), date(1, 1))
+			if err != nil {
+				t.Fatalf("FormatWithOverdueMarkedInCompleted() error = %v", err)
+			}
+			if len(got) != len(tt.wantDates) {
+				t.Fatalf("FormatWithOverdueMarkedInCompleted() returned %d review dates, want %d", len(got), len(tt.wantDates))
+			}
+			for i, rd := range got {
+				if !rd.ScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tt.wantDates[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOffsetDays(t *testing.T) {
+	targetPatternSteps := []*PatternDomain.PatternStep{
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 3, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step3", "user1", "pattern1", 3, 7, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step4", "user1", "pattern1", 4, 14, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+	}
+	// 1か月、2か月、3か月
+	monthPatternSteps := []*PatternDomain.PatternStep{
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitMonth)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 2, PatternDomain.IntervalUnitMonth)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step3", "user1", "pattern1", 3, 3, PatternDomain.IntervalUnitMonth)
+			return step
+		}(),
+	}
+	// 1日、1週、1か月
+	mixedPatternSteps := []*PatternDomain.PatternStep{
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 1, PatternDomain.IntervalUnitWeek)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step3", "user1", "pattern1", 3, 1, PatternDomain.IntervalUnitMonth)
+			return step
+		}(),
+	}
+	parsedBaseDate := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		schedulingAlgorithm string
+		targetPatternSteps  []*PatternDomain.PatternStep
+		parsedBaseDate      time.Time
+		want                []int
+	}{
+		{
+			name:                "固定ステップ方式は間隔日数をそのまま使う",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmFixed,
+			targetPatternSteps:  targetPatternSteps,
+			parsedBaseDate:      parsedBaseDate,
+			want:                []int{1, 3, 7, 14},
+		},
+		{
+			name:                "拡大倍率方式は最初の間隔から倍々に伸ばす",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmExpanding,
+			targetPatternSteps:  targetPatternSteps,
+			parsedBaseDate:      parsedBaseDate,
+			want:                []int{1, 3, 7, 15},
+		},
+		{
+			name:                "SM-2方式は1日、6日、以降は前回間隔×2.5",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmSM2,
+			targetPatternSteps:  targetPatternSteps,
+			parsedBaseDate:      parsedBaseDate,
+			want:                []int{1, 7, 22, 60},
+		},
+		{
+			name:                "FSRS風方式は安定度の成長に従って伸ばす",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmFSRS,
+			targetPatternSteps:  targetPatternSteps,
+			parsedBaseDate:      parsedBaseDate,
+			want:                []int{1, 5, 17, 50},
+		},
+		{
+			name:                "空のパターンステップ",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmSM2,
+			targetPatternSteps:  []*PatternDomain.PatternStep{},
+			parsedBaseDate:      parsedBaseDate,
+			want:                []int{},
+		},
+		{
+			name:                "月単位のステップは学習日と同じ日付までの日数",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmFixed,
+			targetPatternSteps:  monthPatternSteps,
+			parsedBaseDate:      parsedBaseDate,
+			want:                []int{31, 60, 91},
+		},
+		{
+			name:                "月末の学習日は、同じ日付がない月では月末日にする",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmFixed,
+			targetPatternSteps:  monthPatternSteps,
+			parsedBaseDate:      time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			want:                []int{28, 59, 89},
+		},
+		{
+			name:                "日・週・月の混在",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmFixed,
+			targetPatternSteps:  mixedPatternSteps,
+			parsedBaseDate:      time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+			want:                []int{1, 7, 29},
+		},
+		{
+			name:                "拡大倍率方式は月単位の最初の間隔を日数にして倍々に伸ばす",
+			schedulingAlgorithm: PatternDomain.SchedulingAlgorithmExpanding,
+			targetPatternSteps:  monthPatternSteps,
+			parsedBaseDate:      parsedBaseDate,
+			want:                []int{31, 93, 217},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler, err := NewScheduler().WithAlgorithm(tt.schedulingAlgorithm)
+			if err != nil {
+				t.Fatalf("WithAlgorithm() error = %v", err)
+			}
+			got := scheduler.OffsetDays(tt.targetPatternSteps, tt.parsedBaseDate)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OffsetDays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatWithOverdueMarkedInCompletedWithAlgorithm(t *testing.T) {
+	scheduler, err := NewScheduler().WithAlgorithm(PatternDomain.SchedulingAlgorithmSM2)
+	if err != nil {
+		t.Fatalf("WithAlgorithm() error = %v", err)
+	}
+	targetPatternSteps := []*PatternDomain.PatternStep{
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 2, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 4, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+	}
+	parsedLearnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 1つ目の復習日(1/2)が3日超過しているので、全ての復習日が3日後ろにずれる
+	parsedToday := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	got, err := scheduler.FormatWithOverdueMarkedInCompleted(
+		targetPatternSteps,
+		"user123",
+		nil,
+		nil,
+		"item123",
+		parsedLearnedDate,
+		parsedToday,
+	)
+	if err != nil {
+		t.Fatalf("FormatWithOverdueMarkedInCompleted() error = %v", err)
+	}
+
+	want := []time.Time{
+		time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+	}
+	for i, rd := range got {
+		if !rd.ScheduledDate().Equal(want[i]) {
+			t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), want[i])
+		}
+		if rd.IsCompleted() {
+			t.Errorf("Reviewdate[%d].IsCompleted() = true, want false", i)
+		}
+	}
+}
+
+func TestFormatWithOverdueMarkedInCompletedWithMonthUnit(t *testing.T) {
+	scheduler := NewScheduler()
+	targetPatternSteps := []*PatternDomain.PatternStep{
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 1, PatternDomain.IntervalUnitMonth)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 2, PatternDomain.IntervalUnitMonth)
+			return step
+		}(),
+	}
+	parsedLearnedDate := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	// 1つ目の復習日(2/29)が10日超過しているので、学習日を10日後ろ(2/10)にずらして同じ日付に揃える
+	parsedToday := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	got, err := scheduler.FormatWithOverdueMarkedInCompleted(
+		targetPatternSteps,
+		"user123",
+		nil,
+		nil,
+		"item123",
+		parsedLearnedDate,
+		parsedToday,
+	)
+	if err != nil {
+		t.Fatalf("FormatWithOverdueMarkedInCompleted() error = %v", err)
+	}
+
+	want := []time.Time{
+		time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC),
+	}
+	for i, rd := range got {
+		if !rd.ScheduledDate().Equal(want[i]) {
+			t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), want[i])
+		}
+	}
+}
+
+// 10分、4時間、1日、3日のステップ
+func intradayPatternSteps() []*PatternDomain.PatternStep {
+	tenMinutes, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 10, PatternDomain.IntervalUnitMinute)
+	fourHours, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 4, PatternDomain.IntervalUnitHour)
+	oneDay, _ := PatternDomain.ReconstructPatternStep("step3", "user1", "pattern1", 3, 1, PatternDomain.IntervalUnitDay)
+	threeDays, _ := PatternDomain.ReconstructPatternStep("step4", "user1", "pattern1", 4, 3, PatternDomain.IntervalUnitDay)
+	return []*PatternDomain.PatternStep{tenMinutes, fourHours, oneDay, threeDays}
+}
+
+func TestFormatWithOverdueMarkedCompletedWithIntradaySteps(t *testing.T) {
+	now := time.Date(2024, 1, 10, 9, 30, 0, 0, time.UTC)
+	s := NewScheduler().(*scheduler)
+	s.now = func() time.Time { return now }
+	date := func(day int) time.Time {
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+	tenMinutesAfterNow := now.Add(10 * time.Minute)
+	fourHoursAfterNow := now.Add(4 * time.Hour)
+	tenMinutesAfterLearned := date(3).Add(10 * time.Minute)
+	fourHoursAfterLearned := date(3).Add(4 * time.Hour)
+
+	tests := []struct {
+		name              string
+		parsedLearnedDate time.Time
+		wantDates         []time.Time
+		wantScheduledAts  []*time.Time
+		wantCompleted     []bool
+		wantIsFinished    bool
+	}{
+		{
+			name:              "学習日が今日の場合は現在時刻から数え、未完了",
+			parsedLearnedDate: date(10),
+			wantDates:         []time.Time{date(10), date(10), date(11), date(13)},
+			wantScheduledAts:  []*time.Time{&tenMinutesAfterNow, &fourHoursAfterNow, nil, nil},
+			wantCompleted:     []bool{false, false, false, false},
+			wantIsFinished:    false,
+		},
+		{
+			name:              "学習日が過去の場合は学習日の0時から数え、完了扱い",
+			parsedLearnedDate: date(3),
+			wantDates:         []time.Time{date(3), date(3), date(4), date(6)},
+			wantScheduledAts:  []*time.Time{&tenMinutesAfterLearned, &fourHoursAfterLearned, nil, nil},
+			wantCompleted:     []bool{true, true, true, true},
+			wantIsFinished:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isFinished, err := s.FormatWithOverdueMarkedCompleted(
+				intradayPatternSteps(),
+				"user1",
+				nil,
+				nil,
+				"item1",
+				tt.parsedLearnedDate,
+				date(10),
+			)
+			if err != nil {
+				t.Fatalf("FormatWithOverdueMarkedCompleted() error = %v", err)
+			}
+			if isFinished != tt.wantIsFinished {
+				t.Errorf("isFinished = %v, want %v", isFinished, tt.wantIsFinished)
+			}
+			for i, rd := range got {
+				if !rd.ScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tt.wantDates[i])
+				}
+				if rd.IsCompleted() != tt.wantCompleted[i] {
+					t.Errorf("Reviewdate[%d].IsCompleted() = %v, want %v", i, rd.IsCompleted(), tt.wantCompleted[i])
+				}
+				if !reflect.DeepEqual(rd.ScheduledAt(), tt.wantScheduledAts[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledAt() = %v, want %v", i, rd.ScheduledAt(), tt.wantScheduledAts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFormatWithOverdueMarkedInCompletedWithIntradaySteps(t *testing.T) {
+	now := time.Date(2024, 1, 10, 9, 30, 0, 0, time.UTC)
+	s := NewScheduler().(*scheduler)
+	s.now = func() time.Time { return now }
+	date := func(day int) time.Time {
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	// 最初の日単位のステップ(1/4)が6日超過しているので、学習日を6日後ろ(1/9)にずらす
+	got, err := s.FormatWithOverdueMarkedInCompleted(
+		intradayPatternSteps(),
+		"user1",
+		nil,
+		nil,
+		"item1",
+		date(3),
+		date(10),
+	)
+	if err != nil {
+		t.Fatalf("FormatWithOverdueMarkedInCompleted() error = %v", err)
+	}
+
+	tenMinutesAfterAnchor := date(9).Add(10 * time.Minute)
+	fourHoursAfterAnchor := date(9).Add(4 * time.Hour)
+	wantDates := []time.Time{date(9), date(9), date(10), date(12)}
+	wantScheduledAts := []*time.Time{&tenMinutesAfterAnchor, &fourHoursAfterAnchor, nil, nil}
+	for i, rd := range got {
+		if !rd.ScheduledDate().Equal(wantDates[i]) {
+			t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), wantDates[i])
+		}
+		if rd.IsCompleted() {
+			t.Errorf("Reviewdate[%d].IsCompleted() = true, want false", i)
+		}
+		if !reflect.DeepEqual(rd.ScheduledAt(), wantScheduledAts[i]) {
+			t.Errorf("Reviewdate[%d].ScheduledAt() = %v, want %v", i, rd.ScheduledAt(), wantScheduledAts[i])
+		}
+	}
+}
+
+func TestRescheduleByRecallGradeWithIntradaySteps(t *testing.T) {
+	scheduler := NewScheduler()
+	date := func(day int) time.Time {
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+	scheduledAt := date(1).Add(4 * time.Hour)
+	// 学習日1/1、復習日は1/1 0:10, 1/1 4:00, 1/2, 1/4
+	rd1, _ := ReconstructReviewdate("rd1", "user1", nil, nil, "item1", 1, date(1), date(1), true, nil, nil)
+	rd2, _ := ReconstructReviewdate("rd2", "user1", nil, nil, "item1", 2, date(1), date(1), false, nil, &scheduledAt)
+	rd3, _ := ReconstructReviewdate("rd3", "user1", nil, nil, "item1", 3, date(2), date(2), false, nil, nil)
+	rd4, _ := ReconstructReviewdate("rd4", "user1", nil, nil, "item1", 4, date(4), date(4), false, nil, nil)
+	reviewdates := []*Reviewdate{rd1, rd2, rd3, rd4}
+
+	// 分・時間単位のステップの復習日は組み直さず、日単位のステップの間隔（1日、3日）で組み直す
+	got, err := scheduler.RescheduleByRecallGrade(intradayPatternSteps(), reviewdates, 1, RecallGradeAgain, date(5))
+	if err != nil {
+		t.Fatalf("RescheduleByRecallGrade() error = %v", err)
+	}
+	wantIDs := []string{"rd3", "rd4"}
+	wantDates := []time.Time{date(6), date(8)}
+	if len(got) != len(wantIDs) {
+		t.Fatalf("len(got) = %d, want %d", len(got), len(wantIDs))
+	}
+	for i, rd := range got {
+		if rd.ReviewdateID() != wantIDs[i] {
+			t.Errorf("Reviewdate[%d].ReviewdateID() = %v, want %v", i, rd.ReviewdateID(), wantIDs[i])
+		}
+		if !rd.ScheduledDate().Equal(wantDates[i]) {
+			t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), wantDates[i])
+		}
+	}
+}
+
+func TestLoadBalanceAndDeferOverflowSkipIntradayReviewdates(t *testing.T) {
+	scheduler := NewScheduler()
+	date := func(day int) time.Time {
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+	scheduledAt := date(10).Add(10 * time.Minute)
+	rd1, _ := ReconstructReviewdate("rd1", "user1", nil, nil, "item1", 1, date(10), date(10), false, nil, &scheduledAt)
+	rd2, _ := ReconstructReviewdate("rd2", "user1", nil, nil, "item1", 2, date(20), date(20), false, nil, nil)
+	reviewdates := []*Reviewdate{rd1, rd2}
+	// 学習日の復習予定数が上限に達していても、分・時間単位のステップの復習日はずらさない
+	dailyCounts := []*DailyScheduledCount{
+		{ScheduledDate: date(10), Count: 5},
+	}
+
+	balanced, err := scheduler.LoadBalance(reviewdates, dailyCounts, date(10), date(10))
+	if err != nil {
+		t.Fatalf("LoadBalance() error = %v", err)
+	}
+	if balanced[0] != rd1 {
+		t.Errorf("LoadBalance() changed intraday reviewdate: %v", balanced[0].ScheduledDate())
+	}
+
+	deferred, err := scheduler.DeferOverflow(reviewdates, dailyCounts, 1, date(10))
+	if err != nil {
+		t.Fatalf("DeferOverflow() error = %v", err)
+	}
+	if deferred[0] != rd1 {
+		t.Errorf("DeferOverflow() changed intraday reviewdate: %v", deferred[0].ScheduledDate())
+	}
+	if !deferred[1].ScheduledDate().Equal(date(20)) {
+		t.Errorf("DeferOverflow() ScheduledDate = %v, want %v", deferred[1].ScheduledDate(), date(20))
+	}
+}
+
+func TestRescheduleByRecallGrade(t *testing.T) {
+	scheduler := NewScheduler()
+	targetPatternSteps := []*PatternDomain.PatternStep{
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step1", "user1", "pattern1", 1, 2, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step2", "user1", "pattern1", 2, 12, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+		func() *PatternDomain.PatternStep {
+			step, _ := PatternDomain.ReconstructPatternStep("step3", "user1", "pattern1", 3, 30, PatternDomain.IntervalUnitDay)
+			return step
+		}(),
+	}
+	// 学習日1/1、復習日は1/3, 1/13, 1/31
+	reviewdates := []*Reviewdate{
+		func() *Reviewdate {
+			rd, _ := ReconstructReviewdate("rd1", "user1", nil, nil, "item1", 1, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), false, nil, nil)
+			return rd
+		}(),
+		func() *Reviewdate {
+			rd, _ := ReconstructReviewdate("rd2", "user1", nil, nil, "item1", 2, time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC), false, nil, nil)
+			return rd
+		}(),
+		func() *Reviewdate {
+			rd, _ := ReconstructReviewdate("rd3", "user1", nil, nil, "item1", 3, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), false, nil, nil)
+			return rd
+		}(),
+	}
+	parsedToday := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		completedStepNumber int
+		recallGrade         string
+		wantIDs             []string
+		wantDates           []time.Time
+	}{
+		{
+			name:                "againの場合は今日を起点に後続の復習日を組み直す",
+			completedStepNumber: 1,
+			recallGrade:         RecallGradeAgain,
+			wantIDs:             []string{"rd2", "rd3"},
+			wantDates: []time.Time{
+				time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:                "easyの場合は次の間隔(10日)を1.3倍に伸ばし、後続も同じ日数ずらす",
+			completedStepNumber: 1,
+			recallGrade:         RecallGradeEasy,
+			wantIDs:             []string{"rd2", "rd3"},
+			wantDates: []time.Time{
+				time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:                "goodの場合は変更しない",
+			completedStepNumber: 1,
+			recallGrade:         RecallGradeGood,
+			wantIDs:             []string{},
+			wantDates:           []time.Time{},
+		},
+		{
+			name:                "hardの場合は変更しない",
+			completedStepNumber: 1,
+			recallGrade:         RecallGradeHard,
+			wantIDs:             []string{},
+			wantDates:           []time.Time{},
+		},
+		{
+			name:                "最後のステップの場合は後続がないので変更しない",
+			completedStepNumber: 3,
+			recallGrade:         RecallGradeAgain,
+			wantIDs:             []string{},
+			wantDates:           []time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scheduler.RescheduleByRecallGrade(
+				targetPatternSteps,
+				reviewdates,
+				tt.completedStepNumber,
+				tt.recallGrade,
+				parsedToday,
+			)
+			if err != nil {
+				t.Fatalf("RescheduleByRecallGrade() error = %v", err)
+			}
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("RescheduleByRecallGrade() returned %d review dates, want %d", len(got), len(tt.wantIDs))
+			}
+			for i, rd := range got {
+				if rd.ReviewdateID() != tt.wantIDs[i] {
+					t.Errorf("Reviewdate[%d].ReviewdateID() = %v, want %v", i, rd.ReviewdateID(), tt.wantIDs[i])
+				}
+				if !rd.ScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tt.wantDates[i])
+				}
+				if !rd.InitialScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].InitialScheduledDate() = %v, want %v", i, rd.InitialScheduledDate(), tt.wantDates[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRescheduleForUpdatedSteps(t *testing.T) {
+	scheduler := NewScheduler()
+	// 変更後のステップは1日、3日、7日、14日
+	targetPatternSteps := make([]*PatternDomain.PatternStep, 0, 4)
+	for i, intervalDays := range []int{1, 3, 7, 14} {
+		step, _ := PatternDomain.ReconstructPatternStep("step", "user1", "pattern1", i+1, intervalDays, PatternDomain.IntervalUnitDay)
+		targetPatternSteps = append(targetPatternSteps, step)
+	}
+	parsedLearnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                    string
+		lastCompletedStepNumber int
+		parsedToday             time.Time
+		wantStepNumbers         []int
+		wantDates               []time.Time
+	}{
+		{
+			name:                    "完了したステップより後のステップの復習日を学習日から算出し直す",
+			lastCompletedStepNumber: 1,
+			parsedToday:             time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			wantStepNumbers:         []int{2, 3, 4},
+			wantDates: []time.Time{
+				time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:                    "最初の復習日が今日より前になる場合は今日にずらし、以降の復習日も同じ日数ずらす",
+			lastCompletedStepNumber: 2,
+			parsedToday:             time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			wantStepNumbers:         []int{3, 4},
+			wantDates: []time.Time{
+				time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:                    "変更後の全ステップを完了している場合は生成しない",
+			lastCompletedStepNumber: 4,
+			parsedToday:             time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+			wantStepNumbers:         []int{},
+			wantDates:               []time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scheduler.RescheduleForUpdatedSteps(
+				targetPatternSteps,
+				"user1",
+				nil,
+				nil,
+				"item1",
+				tt.lastCompletedStepNumber,
+				parsedLearnedDate,
+				tt.parsedToday,
+			)
+			if err != nil {
+				t.Fatalf("RescheduleForUpdatedSteps() error = %v", err)
+			}
+			if len(got) != len(tt.wantStepNumbers) {
+				t.Fatalf("RescheduleForUpdatedSteps() returned %d review dates, want %d", len(got), len(tt.wantStepNumbers))
+			}
+			for i, rd := range got {
+				if rd.StepNumber() != tt.wantStepNumbers[i] {
+					t.Errorf("Reviewdate[%d].StepNumber() = %v, want %v", i, rd.StepNumber(), tt.wantStepNumbers[i])
+				}
+				if !rd.ScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tt.wantDates[i])
+				}
+				if rd.IsCompleted() {
+					t.Errorf("Reviewdate[%d].IsCompleted() = true, want false", i)
+				}
+			}
+		})
+	}
+}
+
+func TestRescheduleForUpdatedStepsWithIntradaySteps(t *testing.T) {
+	scheduler := NewScheduler()
+	parsedLearnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 分・時間単位のステップ（1, 2）は生成せず、日単位のステップ（1日、3日）だけを生成する
+	got, err := scheduler.RescheduleForUpdatedSteps(intradayPatternSteps(), "user1", nil, nil, "item1", 0, parsedLearnedDate, parsedLearnedDate)
+	if err != nil {
+		t.Fatalf("RescheduleForUpdatedSteps() error = %v", err)
+	}
+	wantStepNumbers := []int{3, 4}
+	wantDates := []time.Time{
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+	}
+	if len(got) != len(wantStepNumbers) {
+		t.Fatalf("len(got) = %d, want %d", len(got), len(wantStepNumbers))
+	}
+	for i, rd := range got {
+		if rd.StepNumber() != wantStepNumbers[i] {
+			t.Errorf("Reviewdate[%d].StepNumber() = %v, want %v", i, rd.StepNumber(), wantStepNumbers[i])
+		}
+		if !rd.ScheduledDate().Equal(wantDates[i]) {
+			t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), wantDates[i])
+		}
+		if rd.IsIntraday() {
+			t.Errorf("Reviewdate[%d].IsIntraday() = true, want false", i)
+		}
+	}
+}
+
+func TestLoadBalance(t *testing.T) {
+	scheduler := NewScheduler()
+	parsedLearnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	// 学習日1/1、復習日は1/11（ずらせる日数1日）, 1/31（2日）, 3/1（3日）
+	newReviewdates := func(isFirstCompleted bool) []*Reviewdate {
+		rd1, _ := ReconstructReviewdate("rd1", "user1", nil, nil, "item1", 1, date(1, 11), date(1, 11), isFirstCompleted, nil, nil)
+		rd2, _ := ReconstructReviewdate("rd2", "user1", nil, nil, "item1", 2, date(1, 31), date(1, 31), false, nil, nil)
+		rd3, _ := ReconstructReviewdate("rd3", "user1", nil, nil, "item1", 3, date(3, 1), date(3, 1), false, nil, nil)
+		return []*Reviewdate{rd1, rd2, rd3}
+	}
+
+	tests := []struct {
+		name             string
+		isFirstCompleted bool
+		dailyCounts      []*DailyScheduledCount
+		parsedToday      time.Time
+		wantDates        []time.Time
+	}{
+		{
+			name:        "復習予定がない場合は元の日のまま",
+			dailyCounts: []*DailyScheduledCount{},
+			parsedToday: parsedLearnedDate,
+			wantDates:   []time.Time{date(1, 11), date(1, 31), date(3, 1)},
+		},
+		{
+			name: "許容範囲内で復習予定数が最も少ない日にずらす（同数の場合は元の日に近い日、さらに同じなら前の日）",
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 10), Count: 2},
+				{ScheduledDate: date(1, 11), Count: 5},
+				{ScheduledDate: date(1, 12), Count: 1},
+				{ScheduledDate: date(1, 29), Count: 1},
+				{ScheduledDate: date(1, 30), Count: 3},
+				{ScheduledDate: date(1, 31), Count: 3},
+				{ScheduledDate: date(2, 1), Count: 3},
+				{ScheduledDate: date(2, 2), Count: 1},
+				{ScheduledDate: date(3, 1), Count: 2},
+			},
+			parsedToday: parsedLearnedDate,
+			wantDates:   []time.Time{date(1, 12), date(1, 29), date(2, 29)},
+		},
+		{
+			name: "今日より前にはずらさない",
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 11), Count: 5},
+				{ScheduledDate: date(1, 12), Count: 5},
+			},
+			parsedToday: date(1, 11),
+			wantDates:   []time.Time{date(1, 11), date(1, 31), date(3, 1)},
+		},
+		{
+			name:             "完了済みの復習日はずらさない",
+			isFirstCompleted: true,
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 11), Count: 5},
+			},
+			parsedToday: parsedLearnedDate,
+			wantDates:   []time.Time{date(1, 11), date(1, 31), date(3, 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewdates := newReviewdates(tt.isFirstCompleted)
+			got, err := scheduler.LoadBalance(reviewdates, tt.dailyCounts, parsedLearnedDate, tt.parsedToday)
+			if err != nil {
+				t.Fatalf("LoadBalance() error = %v", err)
+			}
+			if len(got) != len(tt.wantDates) {
+				t.Fatalf("LoadBalance() returned %d review dates, want %d", len(got), len(tt.wantDates))
+			}
+			for i, rd := range got {
+				if rd.ReviewdateID() != reviewdates[i].ReviewdateID() {
+					t.Errorf("Reviewdate[%d].ReviewdateID() = %v, want %v", i, rd.ReviewdateID(), reviewdates[i].ReviewdateID())
+				}
+				if !rd.ScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tt.wantDates[i])
+				}
+				if !rd.InitialScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].InitialScheduledDate() = %v, want %v", i, rd.InitialScheduledDate(), tt.wantDates[i])
+				}
+				if rd.IsCompleted() != reviewdates[i].IsCompleted() {
+					t.Errorf("Reviewdate[%d].IsCompleted() = %v, want %v", i, rd.IsCompleted(), reviewdates[i].IsCompleted())
+				}
+			}
+		})
+	}
+}
+
+func TestLoadBalanceRange(t *testing.T) {
+	parsedLearnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rd1, _ := ReconstructReviewdate("rd1", "user1", nil, nil, "item1", 1, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), false, nil, nil)
+	rd2, _ := ReconstructReviewdate("rd2", "user1", nil, nil, "item1", 2, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false, nil, nil)
+
+	from, to := LoadBalanceRange([]*Reviewdate{rd1, rd2}, parsedLearnedDate)
+
+	// 最初の復習日は間隔10日で1日、最後の復習日は間隔50日で5日ずらせる
+	wantFrom := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	wantTo := time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)
+	if !from.Equal(wantFrom) || !to.Equal(wantTo) {
+		t.Errorf("LoadBalanceRange() = %v, %v, want %v, %v", from, to, wantFrom, wantTo)
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestNextAdaptiveReviewdate(t *testing.T) {
+	scheduler := NewScheduler()
+	categoryID := "category1"
+	completedReviewdate, _ := ReconstructReviewdate("rd2", "user1", &categoryID, nil, "item1", 2, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), true, nil, nil)
+	state, _ := ReconstructSM2State("item1", "user1", 2.5, 2, 15)
+	parsedBaseDate := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	got, err := scheduler.NextAdaptiveReviewdate(completedReviewdate, state, parsedBaseDate)
+	if err != nil {
+		t.Fatalf("NextAdaptiveReviewdate() error = %v", err)
+	}
+
+	wantDate := time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC)
+	if got.StepNumber() != 3 {
+		t.Errorf("StepNumber() = %v, want 3", got.StepNumber())
+	}
+	if !got.ScheduledDate().Equal(wantDate) || !got.InitialScheduledDate().Equal(wantDate) {
+		t.Errorf("ScheduledDate() = %v, InitialScheduledDate() = %v, want %v", got.ScheduledDate(), got.InitialScheduledDate(), wantDate)
+	}
+	if got.IsCompleted() {
+		t.Error("IsCompleted() = true, want false")
+	}
+	if got.CategoryID() == nil || *got.CategoryID() != categoryID || got.ItemID() != "item1" {
+		t.Errorf("復習物の情報が引き継がれていない: categoryID=%v, itemID=%v", got.CategoryID(), got.ItemID())
+	}
+	if got.ReviewdateID() == "" || got.ReviewdateID() == completedReviewdate.ReviewdateID() {
+		t.Errorf("ReviewdateID() = %q, 新しいIDが採番されていない", got.ReviewdateID())
+	}
+}
+
+func TestNextMaintenanceReviewdate(t *testing.T) {
+	boxID := "box1"
+	lastReviewdate, _ := ReconstructReviewdate("rd3", "user1", nil, &boxID, "item1", 3, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), true, nil, nil)
+	parsedBaseDate := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		scheduler   IScheduler
+		parsedToday time.Time
+		wantDate    time.Time
+	}{
+		{
+			name:        "起点日から間隔日数後",
+			scheduler:   NewScheduler(),
+			parsedToday: parsedBaseDate,
+			wantDate:    time.Date(2024, 2, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// 2024/2/9は金曜日
+			name:        "除外する曜日は次の日にずらす",
+			scheduler:   NewScheduler().WithExcludedWeekdays(NewExcludedWeekdays([]int{5}, nil)),
+			parsedToday: parsedBaseDate,
+			wantDate:    time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "算出した復習日が今日より前の場合は今日",
+			scheduler:   NewScheduler(),
+			parsedToday: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			wantDate:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.scheduler.NextMaintenanceReviewdate(lastReviewdate, 30, parsedBaseDate, tc.parsedToday)
+			if err != nil {
+				t.Fatalf("NextMaintenanceReviewdate() error = %v", err)
+			}
+			if got.StepNumber() != 4 {
+				t.Errorf("StepNumber() = %v, want 4", got.StepNumber())
+			}
+			if !got.ScheduledDate().Equal(tc.wantDate) || !got.InitialScheduledDate().Equal(tc.wantDate) {
+				t.Errorf("ScheduledDate() = %v, InitialScheduledDate() = %v, want %v", got.ScheduledDate(), got.InitialScheduledDate(), tc.wantDate)
+			}
+			if got.IsCompleted() {
+				t.Error("IsCompleted() = true, want false")
+			}
+			if got.BoxID() == nil || *got.BoxID() != boxID || got.ItemID() != "item1" {
+				t.Errorf("復習物の情報が引き継がれていない: boxID=%v, itemID=%v", got.BoxID(), got.ItemID())
+			}
+			if got.ReviewdateID() == "" || got.ReviewdateID() == lastReviewdate.ReviewdateID() {
+				t.Errorf("ReviewdateID() = %q, 新しいIDが採番されていない", got.ReviewdateID())
+			}
+		})
+	}
+}
+
+func TestDeferOverflow(t *testing.T) {
+	scheduler := NewScheduler()
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	// 復習日は1/11, 1/12, 1/20
+	newReviewdates := func(isFirstCompleted bool) []*Reviewdate {
+		rd1, _ := ReconstructReviewdate("rd1", "user1", nil, nil, "item1", 1, date(1, 11), date(1, 11), isFirstCompleted, nil, nil)
+		rd2, _ := ReconstructReviewdate("rd2", "user1", nil, nil, "item1", 2, date(1, 12), date(1, 12), false, nil, nil)
+		rd3, _ := ReconstructReviewdate("rd3", "user1", nil, nil, "item1", 3, date(1, 20), date(1, 20), false, nil, nil)
+		return []*Reviewdate{rd1, rd2, rd3}
+	}
+
+	tests := []struct {
+		name             string
+		isFirstCompleted bool
+		dailyCounts      []*DailyScheduledCount
+		dailyReviewLimit int
+		excludedWeekdays ExcludedWeekdays
+		parsedToday      time.Time
+		wantDates        []time.Time
+	}{
+		{
+			name: "上限がない場合は元の日のまま",
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 11), Count: 10},
+			},
+			dailyReviewLimit: 0,
+			parsedToday:      date(1, 1),
+			wantDates:        []time.Time{date(1, 11), date(1, 12), date(1, 20)},
+		},
+		{
+			name: "上限に達していない日はそのまま",
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 11), Count: 1},
+			},
+			dailyReviewLimit: 2,
+			parsedToday:      date(1, 1),
+			wantDates:        []time.Time{date(1, 11), date(1, 12), date(1, 20)},
+		},
+		{
+			name: "上限に達している日の復習日は空きのある次の日に繰り越し、後続の復習日の順序も保つ",
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 11), Count: 2},
+				{ScheduledDate: date(1, 12), Count: 2},
+				{ScheduledDate: date(1, 13), Count: 1},
+			},
+			dailyReviewLimit: 2,
+			parsedToday:      date(1, 1),
+			// 1/13は1件目の繰り越しで上限に達するので、2件目は1/14になる
+			wantDates: []time.Time{date(1, 13), date(1, 14), date(1, 20)},
+		},
+		{
+			name:             "完了済みの復習日は繰り越さない",
+			isFirstCompleted: true,
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 11), Count: 2},
+			},
+			dailyReviewLimit: 2,
+			parsedToday:      date(1, 1),
+			wantDates:        []time.Time{date(1, 11), date(1, 12), date(1, 20)},
+		},
+		{
+			name: "今日より前の復習日は繰り越さない",
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 11), Count: 2},
+			},
+			dailyReviewLimit: 2,
+			parsedToday:      date(1, 12),
+			wantDates:        []time.Time{date(1, 11), date(1, 12), date(1, 20)},
+		},
+		{
+			name: "繰り越し先が除外する曜日の場合はさらに次の日に繰り越す",
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 11), Count: 2},
+				{ScheduledDate: date(1, 12), Count: 2},
+			},
+			dailyReviewLimit: 2,
+			// 1/13と1/20は土曜日、1/14は日曜日
+			excludedWeekdays: NewExcludedWeekdays([]int{0, 6}),
+			parsedToday:      date(1, 1),
+			wantDates:        []time.Time{date(1, 15), date(1, 16), date(1, 22)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewdates := newReviewdates(tt.isFirstCompleted)
+			got, err := scheduler.WithExcludedWeekdays(tt.excludedWeekdays).DeferOverflow(reviewdates, tt.dailyCounts, tt.dailyReviewLimit, tt.parsedToday)
+			if err != nil {
+				t.Fatalf("DeferOverflow() error = %v", err)
+			}
+			if len(got) != len(tt.wantDates) {
+				t.Fatalf("DeferOverflow() returned %d review dates, want %d", len(got), len(tt.wantDates))
+			}
+			for i, rd := range got {
+				if rd.ReviewdateID() != reviewdates[i].ReviewdateID() {
+					t.Errorf("Reviewdate[%d].ReviewdateID() = %v, want %v", i, rd.ReviewdateID(), reviewdates[i].ReviewdateID())
+				}
+				if !rd.ScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tt.wantDates[i])
+				}
+				if !rd.InitialScheduledDate().Equal(tt.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].InitialScheduledDate() = %v, want %v", i, rd.InitialScheduledDate(), tt.wantDates[i])
+				}
+				if rd.IsCompleted() != reviewdates[i].IsCompleted() {
+					t.Errorf("Reviewdate[%d].IsCompleted() = %v, want %v", i, rd.IsCompleted(), reviewdates[i].IsCompleted())
+				}
+			}
+		})
+	}
+}
+
+func TestDailyLoadRange(t *testing.T) {
+	parsedLearnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rd1, _ := ReconstructReviewdate("rd1", "user1", nil, nil, "item1", 1, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), false, nil, nil)
+	rd2, _ := ReconstructReviewdate("rd2", "user1", nil, nil, "item1", 2, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false, nil, nil)
+	reviewdates := []*Reviewdate{rd1, rd2}
+
+	tests := []struct {
+		name             string
+		dailyReviewLimit int
+		wantFrom         time.Time
+		wantTo           time.Time
+	}{
+		{
+			name:             "上限がない場合は負荷分散の範囲",
+			dailyReviewLimit: 0,
+			wantFrom:         time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			wantTo:           time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:             "上限がある場合は繰り越し先を探す期間まで広げる",
+			dailyReviewLimit: 5,
+			wantFrom:         time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			wantTo:           time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 366),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := DailyLoadRange(reviewdates, parsedLearnedDate, tt.dailyReviewLimit)
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("DailyLoadRange() = %v, %v, want %v, %v", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestAssignOverdueItemsWithinDailyLimit(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	parsedToday := date(1, 10)
+	overdueItems := []*OverdueItem{
+		{ItemID: "unset", OldDate: date(1, 5), TargetWeight: PatternDomain.TargetWeightUnset},
+		{ItemID: "light", OldDate: date(1, 6), TargetWeight: PatternDomain.TargetWeightLight},
+		{ItemID: "heavy-new", OldDate: date(1, 9), TargetWeight: PatternDomain.TargetWeightHeavy},
+		{ItemID: "heavy-old", OldDate: date(1, 8), TargetWeight: PatternDomain.TargetWeightHeavy},
+		{ItemID: "normal", OldDate: date(1, 7), TargetWeight: PatternDomain.TargetWeightNormal},
+	}
+
+	tests := []struct {
+		name             string
+		dailyCounts      []*DailyScheduledCount
+		dailyReviewLimit int
+		want             map[string]time.Time
+	}{
+		{
+			name: "重みが大きい順（同じ重みなら期限切れの復習日が古い順）に空きのある日を割り当てる",
+			dailyCounts: []*DailyScheduledCount{
+				{ScheduledDate: date(1, 10), Count: 1},
+				{ScheduledDate: date(1, 11), Count: 2},
+			},
+			dailyReviewLimit: 2,
+			want: map[string]time.Time{
+				"heavy-old": date(1, 10),
+				"heavy-new": date(1, 12),
+				"normal":    date(1, 12),
+				"light":     date(1, 13),
+				"unset":     date(1, 13),
+			},
+		},
+		{
+			name:             "今日に空きがあれば全て今日に割り当てる",
+			dailyCounts:      []*DailyScheduledCount{},
+			dailyReviewLimit: 5,
+			want: map[string]time.Time{
+				"heavy-old": date(1, 10),
+				"heavy-new": date(1, 10),
+				"normal":    date(1, 10),
+				"light":     date(1, 10),
+				"unset":     date(1, 10),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AssignOverdueItemsWithinDailyLimit(overdueItems, tt.dailyCounts, tt.dailyReviewLimit, nil, parsedToday)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AssignOverdueItemsWithinDailyLimit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignOverdueItemsWithinDailyLimit_ExcludedWeekdays(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	// 今日の2024-01-13は土曜日
+	parsedToday := date(1, 13)
+	overdueItems := []*OverdueItem{
+		{ItemID: "weekend-excluded", OldDate: date(1, 8), TargetWeight: PatternDomain.TargetWeightHeavy, ExcludedWeekdays: NewExcludedWeekdays([]int{0, 6})},
+		{ItemID: "no-excluded", OldDate: date(1, 9), TargetWeight: PatternDomain.TargetWeightNormal},
+		{ItemID: "sunday-excluded", OldDate: date(1, 10), TargetWeight: PatternDomain.TargetWeightLight, ExcludedWeekdays: NewExcludedWeekdays([]int{0})},
+	}
+	dailyCounts := []*DailyScheduledCount{
+		{ScheduledDate: date(1, 13), Count: 1},
+	}
+
+	// 除外する曜日は復習物毎に飛ばすので、優先順位が高い復習物より前の日に割り当てられることもある
+	want := map[string]time.Time{
+		"weekend-excluded": date(1, 15),
+		"no-excluded":      date(1, 13),
+		"sunday-excluded":  date(1, 15),
+	}
+
+	got := AssignOverdueItemsWithinDailyLimit(overdueItems, dailyCounts, 2, nil, parsedToday)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AssignOverdueItemsWithinDailyLimit() = %v, want %v", got, want)
+	}
+}
+
+func TestAssignOverdueItemsWithinDailyLimit_BlockedDates(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	parsedToday := date(1, 10)
+	overdueItems := []*OverdueItem{
+		{ItemID: "heavy", OldDate: date(1, 8), TargetWeight: PatternDomain.TargetWeightHeavy},
+		{ItemID: "light", OldDate: date(1, 9), TargetWeight: PatternDomain.TargetWeightLight},
+	}
+	// 今日と明日は復習日を置かない日付
+	blockedDates := NewBlockedDates([]time.Time{date(1, 10), date(1, 11)})
+
+	want := map[string]time.Time{
+		"heavy": date(1, 12),
+		"light": date(1, 13),
+	}
+
+	got := AssignOverdueItemsWithinDailyLimit(overdueItems, nil, 1, blockedDates, parsedToday)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AssignOverdueItemsWithinDailyLimit() = %v, want %v", got, want)
+	}
+}
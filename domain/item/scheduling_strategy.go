@@ -0,0 +1,145 @@
+package item
+
+import (
+	"math"
+	"time"
+
+	PatternDomain "github.com/minminseo/recall-setter/domain/pattern"
+)
+
+// 復習日の算出方式
+// 学習日から各ステップの復習日までの日数（累積）を返す
+// ステップの間隔が月単位の場合は日数が学習日によって変わるため、学習日（起点日）を受け取る
+type ISchedulingStrategy interface {
+	OffsetDays(targetPatternSteps []*PatternDomain.PatternStep, parsedBaseDate time.Time) []int
+}
+
+// 方式名とstrategyの対応表
+func defaultStrategies() map[string]ISchedulingStrategy {
+	return map[string]ISchedulingStrategy{
+		PatternDomain.SchedulingAlgorithmFixed:     &fixedStrategy{},
+		PatternDomain.SchedulingAlgorithmExpanding: &expandingStrategy{multiplier: expandingMultiplier},
+		PatternDomain.SchedulingAlgorithmSM2:       &sm2Strategy{easeFactor: sm2DefaultEaseFactor},
+		PatternDomain.SchedulingAlgorithmFSRS:      &fsrsStrategy{difficulty: fsrsDefaultDifficulty, requestRetention: fsrsRequestRetention},
+		// 適応型SM-2方式のステップは初回の間隔1件のみなので、その間隔をそのまま使う。2回目以降はSM2Stateから算出する
+		PatternDomain.SchedulingAlgorithmSM2Adaptive: &fixedStrategy{},
+	}
+}
+
+// 固定ステップ方式（従来の方式）
+// 各ステップの間隔をそのまま学習日に足す
+type fixedStrategy struct{}
+
+func (st *fixedStrategy) OffsetDays(targetPatternSteps []*PatternDomain.PatternStep, parsedBaseDate time.Time) []int {
+	offsets := make([]int, len(targetPatternSteps))
+	for i, step := range targetPatternSteps {
+		offsets[i] = step.DaysFrom(parsedBaseDate)
+	}
+	return offsets
+}
+
+// 拡大倍率方式
+// 最初のステップの間隔日数を起点に、前回の間隔にmultiplierを掛けた日数を次の間隔とする
+const expandingMultiplier = 2.0
+
+type expandingStrategy struct {
+	multiplier float64
+}
+
+func (st *expandingStrategy) OffsetDays(targetPatternSteps []*PatternDomain.PatternStep, parsedBaseDate time.Time) []int {
+	offsets := make([]int, len(targetPatternSteps))
+	if len(targetPatternSteps) == 0 {
+		return offsets
+	}
+
+	gap := float64(targetPatternSteps[0].DaysFrom(parsedBaseDate))
+	total := 0
+	for i := range targetPatternSteps {
+		if i > 0 {
+			gap *= st.multiplier
+		}
+		total += roundGap(gap)
+		offsets[i] = total
+	}
+	return offsets
+}
+
+// SM-2方式
+// 毎回標準的な想起ができた（EFが変化しない）とみなして、1日、6日、以降は前回間隔×EFで間隔を伸ばす
+// ステップの間隔日数は使わず、ステップ数のみ参照する
+const sm2DefaultEaseFactor = 2.5
+
+type sm2Strategy struct {
+	easeFactor float64
+}
+
+func (st *sm2Strategy) OffsetDays(targetPatternSteps []*PatternDomain.PatternStep, parsedBaseDate time.Time) []int {
+	offsets := make([]int, len(targetPatternSteps))
+
+	var gap float64
+	total := 0
+	for i := range targetPatternSteps {
+		switch i {
+		case 0:
+			gap = 1
+		case 1:
+			gap = sm2SecondIntervalDays
+		default:
+			gap = math.Round(gap * st.easeFactor)
+		}
+		total += roundGap(gap)
+		offsets[i] = total
+	}
+	return offsets
+}
+
+// FSRS風方式
+// 最初のステップの間隔日数を初期安定度とし、想起成功ごとに安定度を更新する
+// 目標保持率で復習する場合、次の間隔は安定度と一致する
+const (
+	fsrsDefaultDifficulty = 5.0
+	fsrsRequestRetention  = 0.9
+	fsrsW8                = 1.49
+	fsrsW9                = 0.14
+	fsrsW10               = 0.94
+)
+
+type fsrsStrategy struct {
+	difficulty       float64
+	requestRetention float64
+}
+
+func (st *fsrsStrategy) OffsetDays(targetPatternSteps []*PatternDomain.PatternStep, parsedBaseDate time.Time) []int {
+	offsets := make([]int, len(targetPatternSteps))
+	if len(targetPatternSteps) == 0 {
+		return offsets
+	}
+
+	stability := float64(targetPatternSteps[0].DaysFrom(parsedBaseDate))
+	total := 0
+	for i := range targetPatternSteps {
+		if i > 0 {
+			stability = st.nextStability(stability)
+		}
+		total += roundGap(stability)
+		offsets[i] = total
+	}
+	return offsets
+}
+
+func (st *fsrsStrategy) nextStability(stability float64) float64 {
+	growth := math.Exp(fsrsW8) *
+		(11 - st.difficulty) *
+		math.Pow(stability, -fsrsW9) *
+		(math.Exp(fsrsW10*(1-st.requestRetention)) - 1)
+	return stability * (1 + growth)
+}
+
+// 間隔は最低1日とし、復習日が前のステップと重ならないようにする
+func roundGap(gap float64) int {
+	days := int(math.Round(gap))
+	if days < 1 {
+		return 1
+	}
+	return days
+}
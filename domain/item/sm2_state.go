@@ -0,0 +1,130 @@
+package item
+
+import (
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// 適応型SM-2方式の復習物ごとの学習状態
+// easeFactor: 間隔の伸び率、repetitions: 連続して想起に成功した回数、intervalDays: 直近の復習間隔
+type SM2State struct {
+	itemID       string
+	userID       string
+	easeFactor   float64
+	repetitions  int
+	intervalDays int
+}
+
+const (
+	sm2MinEaseFactor      = 1.3
+	sm2SecondIntervalDays = 6
+)
+
+// 想起評価をSM-2の品質(0〜5)に対応付ける。評価なしはgoodとして扱う
+var sm2QualityByRecallGrade = map[string]int{
+	RecallGradeAgain: 2,
+	RecallGradeHard:  3,
+	RecallGradeGood:  4,
+	RecallGradeEasy:  5,
+}
+
+// 初回の復習間隔を持つ初期状態を生成する
+func NewSM2State(
+	itemID string,
+	userID string,
+	firstIntervalDays int,
+) (*SM2State, error) {
+	s := &SM2State{
+		itemID:       itemID,
+		userID:       userID,
+		easeFactor:   sm2DefaultEaseFactor,
+		repetitions:  0,
+		intervalDays: firstIntervalDays,
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func ReconstructSM2State(
+	itemID string,
+	userID string,
+	easeFactor float64,
+	repetitions int,
+	intervalDays int,
+) (*SM2State, error) {
+	s := &SM2State{
+		itemID:       itemID,
+		userID:       userID,
+		easeFactor:   easeFactor,
+		repetitions:  repetitions,
+		intervalDays: intervalDays,
+	}
+	return s, nil
+}
+
+func (s *SM2State) ItemID() string {
+	return s.itemID
+}
+
+func (s *SM2State) UserID() string {
+	return s.userID
+}
+
+func (s *SM2State) EaseFactor() float64 {
+	return s.easeFactor
+}
+
+func (s *SM2State) Repetitions() int {
+	return s.repetitions
+}
+
+func (s *SM2State) IntervalDays() int {
+	return s.intervalDays
+}
+
+func (s *SM2State) Validate() error {
+	return validation.ValidateStruct(s,
+		validation.Field(
+			&s.intervalDays,
+			validation.Required.Error("復習間隔は必須です"),
+			validation.Min(1).Error("復習間隔は1日以上で指定してください"),
+		),
+	)
+}
+
+// 想起評価を反映して、次の復習までの間隔を更新する
+// 失敗(again)の場合は連続成功回数をリセットし、初回の間隔からやり直す
+// 成功の場合は前回の間隔×EFとする。ただし1回目は最低6日とする
+func (s *SM2State) ApplyRecallGrade(recallGrade string, firstIntervalDays int) error {
+	if recallGrade == "" {
+		recallGrade = RecallGradeGood
+	}
+	if err := ValidateRecallGrade(recallGrade); err != nil {
+		return err
+	}
+	quality := sm2QualityByRecallGrade[recallGrade]
+
+	lapse := float64(5 - quality)
+	easeFactor := s.easeFactor + (0.1 - lapse*(0.08+lapse*0.02))
+	if easeFactor < sm2MinEaseFactor {
+		easeFactor = sm2MinEaseFactor
+	}
+	s.easeFactor = math.Round(easeFactor*100) / 100
+
+	if quality < 3 {
+		s.repetitions = 0
+		s.intervalDays = firstIntervalDays
+		return nil
+	}
+
+	s.repetitions++
+	nextInterval := roundGap(float64(s.intervalDays) * s.easeFactor)
+	if s.repetitions == 1 && nextInterval < sm2SecondIntervalDays {
+		nextInterval = sm2SecondIntervalDays
+	}
+	s.intervalDays = nextInterval
+	return nil
+}
@@ -0,0 +1,137 @@
+package item
+
+import "testing"
+
+func TestSM2State_ApplyRecallGrade(t *testing.T) {
+	tests := []struct {
+		name             string
+		easeFactor       float64
+		repetitions      int
+		intervalDays     int
+		recallGrade      string
+		wantEaseFactor   float64
+		wantRepetitions  int
+		wantIntervalDays int
+		wantErr          bool
+	}{
+		{
+			name:             "初期状態でgood（2回目の間隔は6日）",
+			easeFactor:       2.5,
+			repetitions:      0,
+			intervalDays:     1,
+			recallGrade:      RecallGradeGood,
+			wantEaseFactor:   2.5,
+			wantRepetitions:  1,
+			wantIntervalDays: 6,
+		},
+		{
+			name:             "評価なしはgoodとして扱う",
+			easeFactor:       2.5,
+			repetitions:      0,
+			intervalDays:     1,
+			recallGrade:      "",
+			wantEaseFactor:   2.5,
+			wantRepetitions:  1,
+			wantIntervalDays: 6,
+		},
+		{
+			name:             "初期状態でagain（初回の間隔に戻りEFが下がる）",
+			easeFactor:       2.5,
+			repetitions:      0,
+			intervalDays:     1,
+			recallGrade:      RecallGradeAgain,
+			wantEaseFactor:   2.18,
+			wantRepetitions:  0,
+			wantIntervalDays: 1,
+		},
+		{
+			name:             "2回目以降のgood（前回の間隔×EF）",
+			easeFactor:       2.5,
+			repetitions:      1,
+			intervalDays:     6,
+			recallGrade:      RecallGradeGood,
+			wantEaseFactor:   2.5,
+			wantRepetitions:  2,
+			wantIntervalDays: 15,
+		},
+		{
+			name:             "hard（EFが下がり、間隔の伸びが小さくなる）",
+			easeFactor:       2.5,
+			repetitions:      1,
+			intervalDays:     6,
+			recallGrade:      RecallGradeHard,
+			wantEaseFactor:   2.36,
+			wantRepetitions:  2,
+			wantIntervalDays: 14,
+		},
+		{
+			name:             "easy（EFが上がる）",
+			easeFactor:       2.5,
+			repetitions:      2,
+			intervalDays:     15,
+			recallGrade:      RecallGradeEasy,
+			wantEaseFactor:   2.6,
+			wantRepetitions:  3,
+			wantIntervalDays: 39,
+		},
+		{
+			name:             "EFは下限1.3を下回らない",
+			easeFactor:       1.3,
+			repetitions:      3,
+			intervalDays:     20,
+			recallGrade:      RecallGradeAgain,
+			wantEaseFactor:   1.3,
+			wantRepetitions:  0,
+			wantIntervalDays: 1,
+		},
+		{
+			name:         "未対応の評価",
+			easeFactor:   2.5,
+			repetitions:  0,
+			intervalDays: 1,
+			recallGrade:  "perfect",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := ReconstructSM2State("item1", "user1", tt.easeFactor, tt.repetitions, tt.intervalDays)
+			if err != nil {
+				t.Fatalf("ReconstructSM2State() error = %v", err)
+			}
+
+			err = state.ApplyRecallGrade(tt.recallGrade, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyRecallGrade() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if state.EaseFactor() != tt.wantEaseFactor {
+				t.Errorf("EaseFactor() = %v, want %v", state.EaseFactor(), tt.wantEaseFactor)
+			}
+			if state.Repetitions() != tt.wantRepetitions {
+				t.Errorf("Repetitions() = %v, want %v", state.Repetitions(), tt.wantRepetitions)
+			}
+			if state.IntervalDays() != tt.wantIntervalDays {
+				t.Errorf("IntervalDays() = %v, want %v", state.IntervalDays(), tt.wantIntervalDays)
+			}
+		})
+	}
+}
+
+func TestNewSM2State(t *testing.T) {
+	state, err := NewSM2State("item1", "user1", 2)
+	if err != nil {
+		t.Fatalf("NewSM2State() error = %v", err)
+	}
+	if state.EaseFactor() != 2.5 || state.Repetitions() != 0 || state.IntervalDays() != 2 {
+		t.Errorf("NewSM2State() = (%v, %v, %v), want (2.5, 0, 2)", state.EaseFactor(), state.Repetitions(), state.IntervalDays())
+	}
+
+	_, err = NewSM2State("item1", "user1", 0)
+	if err == nil {
+		t.Error("NewSM2State() 間隔0日でエラーにならない")
+	}
+}
@@ -3,8 +3,22 @@ package pattern
 import "errors"
 
 var (
-	ErrNoDiff                     = errors.New("変更点がありません")
-	ErrPatternNotFound            = errors.New("復習パターンが存在しません")
-	ErrPatternRelatedToItemDelete = errors.New("この復習パターンは復習物に紐づいているため削除できません")
-	ErrPatternRelatedToItemUpdate = errors.New("この復習パターンは復習物に紐づいているため変更できません")
+	ErrNoDiff                             = errors.New("変更点がありません")
+	ErrPatternNotFound                    = errors.New("復習パターンが存在しません")
+	ErrPatternRelatedToItemDelete         = errors.New("この復習パターンは復習物に紐づいているため削除できません")
+	ErrAdaptivePatternStepCount           = errors.New("適応型SM-2方式の復習パターンのステップは初回の間隔日数1件のみ指定してください")
+	ErrInvalidExcludedWeekday             = errors.New("除外する曜日は0（日曜日）から6（土曜日）で指定してください")
+	ErrDuplicatedExcludedWeekday          = errors.New("除外する曜日が重複しています")
+	ErrAllWeekdaysExcluded                = errors.New("全ての曜日を除外することはできません")
+	ErrAdaptivePatternMaintenanceInterval = errors.New("適応型SM-2方式の復習パターンには繰り返しの間隔日数を指定できません")
+	ErrAdaptivePatternIntervalUnit        = errors.New("適応型SM-2方式の復習パターンのステップは日単位で指定してください")
+	ErrIntradayStepTooLong                = errors.New("分・時間単位のステップは24時間未満で指定してください")
+	ErrIntradayStepAfterDailyStep         = errors.New("分・時間単位のステップは日単位以上のステップより前に指定してください")
+	ErrNoDailyStep                        = errors.New("日単位以上のステップを1つ以上指定してください")
+	ErrInvalidStepChangeMode              = errors.New("既存の復習物の扱いはkeepまたはrescheduleで指定してください")
+	ErrAdaptivePatternReschedule          = errors.New("適応型SM-2方式の復習パターンでは既存の復習物の復習日を組み直せません")
+	ErrInvalidPatternVersionNumber        = errors.New("パターンの版番号は1以上で指定してください")
+	ErrPatternPresetNotFound              = errors.New("指定されたプリセットが存在しません")
+	ErrUnsupportedPatternFormatVersion    = errors.New("対応していない形式のバージョンです")
+	ErrReassignToSamePattern              = errors.New("付け替え先には削除するパターン以外のパターンを指定してください")
 )
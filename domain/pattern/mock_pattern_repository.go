@@ -69,6 +69,20 @@ func (mr *MockIPatternRepositoryMockRecorder) CreatePatternSteps(ctx, steps any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatternSteps", reflect.TypeOf((*MockIPatternRepository)(nil).CreatePatternSteps), ctx, steps)
 }
 
+// CreatePatternVersion mocks base method.
+func (m *MockIPatternRepository) CreatePatternVersion(ctx context.Context, version *PatternVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePatternVersion", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePatternVersion indicates an expected call of CreatePatternVersion.
+func (mr *MockIPatternRepositoryMockRecorder) CreatePatternVersion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatternVersion", reflect.TypeOf((*MockIPatternRepository)(nil).CreatePatternVersion), ctx, version)
+}
+
 // DeletePattern mocks base method.
 func (m *MockIPatternRepository) DeletePattern(ctx context.Context, patternID, userID string) error {
 	m.ctrl.T.Helper()
@@ -97,6 +111,21 @@ func (mr *MockIPatternRepositoryMockRecorder) DeletePatternSteps(ctx, patternID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePatternSteps", reflect.TypeOf((*MockIPatternRepository)(nil).DeletePatternSteps), ctx, patternID, userID)
 }
 
+// FindLatestPatternVersionByPatternID mocks base method.
+func (m *MockIPatternRepository) FindLatestPatternVersionByPatternID(ctx context.Context, patternID, userID string) (*PatternVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestPatternVersionByPatternID", ctx, patternID, userID)
+	ret0, _ := ret[0].(*PatternVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestPatternVersionByPatternID indicates an expected call of FindLatestPatternVersionByPatternID.
+func (mr *MockIPatternRepositoryMockRecorder) FindLatestPatternVersionByPatternID(ctx, patternID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestPatternVersionByPatternID", reflect.TypeOf((*MockIPatternRepository)(nil).FindLatestPatternVersionByPatternID), ctx, patternID, userID)
+}
+
 // FindPatternByPatternID mocks base method.
 func (m *MockIPatternRepository) FindPatternByPatternID(ctx context.Context, patternID, userID string) (*Pattern, error) {
 	m.ctrl.T.Helper()
@@ -112,6 +141,21 @@ func (mr *MockIPatternRepositoryMockRecorder) FindPatternByPatternID(ctx, patter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPatternByPatternID", reflect.TypeOf((*MockIPatternRepository)(nil).FindPatternByPatternID), ctx, patternID, userID)
 }
 
+// FindPatternVersionByID mocks base method.
+func (m *MockIPatternRepository) FindPatternVersionByID(ctx context.Context, patternVersionID, userID string) (*PatternVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPatternVersionByID", ctx, patternVersionID, userID)
+	ret0, _ := ret[0].(*PatternVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPatternVersionByID indicates an expected call of FindPatternVersionByID.
+func (mr *MockIPatternRepositoryMockRecorder) FindPatternVersionByID(ctx, patternVersionID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPatternVersionByID", reflect.TypeOf((*MockIPatternRepository)(nil).FindPatternVersionByID), ctx, patternVersionID, userID)
+}
+
 // GetAllPatternStepsByPatternID mocks base method.
 func (m *MockIPatternRepository) GetAllPatternStepsByPatternID(ctx context.Context, patternID, userID string) ([]*PatternStep, error) {
 	m.ctrl.T.Helper()
@@ -172,6 +216,21 @@ func (mr *MockIPatternRepositoryMockRecorder) GetPatternTargetWeightsByPatternID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatternTargetWeightsByPatternIDs", reflect.TypeOf((*MockIPatternRepository)(nil).GetPatternTargetWeightsByPatternIDs), ctx, patternIDs)
 }
 
+// GetPatternVersionsByPatternID mocks base method.
+func (m *MockIPatternRepository) GetPatternVersionsByPatternID(ctx context.Context, patternID, userID string) ([]*PatternVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPatternVersionsByPatternID", ctx, patternID, userID)
+	ret0, _ := ret[0].([]*PatternVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPatternVersionsByPatternID indicates an expected call of GetPatternVersionsByPatternID.
+func (mr *MockIPatternRepositoryMockRecorder) GetPatternVersionsByPatternID(ctx, patternID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPatternVersionsByPatternID", reflect.TypeOf((*MockIPatternRepository)(nil).GetPatternVersionsByPatternID), ctx, patternID, userID)
+}
+
 // UpdatePattern mocks base method.
 func (m *MockIPatternRepository) UpdatePattern(ctx context.Context, pattern *Pattern) error {
 	m.ctrl.T.Helper()
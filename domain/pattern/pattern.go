@@ -8,12 +8,17 @@ import (
 )
 
 type Pattern struct {
-	patternID    string
-	userID       string
-	name         string
-	targetWeight string
-	registeredAt time.Time
-	editedAt     time.Time
+	patternID           string
+	userID              string
+	name                string
+	targetWeight        string
+	schedulingAlgorithm string
+	isLoadBalanced      bool
+	excludedWeekdays    []int
+	// 0の場合は最後のステップの完了で復習物を完了にする
+	maintenanceIntervalDays int
+	registeredAt            time.Time
+	editedAt                time.Time
 }
 
 func NewPattern(
@@ -21,6 +26,10 @@ func NewPattern(
 	userID string,
 	name string,
 	targetWeight string,
+	schedulingAlgorithm string,
+	isLoadBalanced bool,
+	excludedWeekdays []int,
+	maintenanceIntervalDays int,
 	registeredAt time.Time,
 	editedAt time.Time,
 ) (*Pattern, error) {
@@ -31,13 +40,26 @@ func NewPattern(
 	if err := validateTargetWeight(string(targetWeight)); err != nil {
 		return nil, err
 	}
+	if err := validateSchedulingAlgorithm(schedulingAlgorithm); err != nil {
+		return nil, err
+	}
+	if err := ValidateExcludedWeekdays(excludedWeekdays); err != nil {
+		return nil, err
+	}
+	if err := ValidateMaintenanceIntervalDays(schedulingAlgorithm, maintenanceIntervalDays); err != nil {
+		return nil, err
+	}
 	p := &Pattern{
-		patternID:    patternID,
-		userID:       userID,
-		name:         name,
-		targetWeight: targetWeight,
-		registeredAt: registeredAt,
-		editedAt:     editedAt,
+		patternID:               patternID,
+		userID:                  userID,
+		name:                    name,
+		targetWeight:            targetWeight,
+		schedulingAlgorithm:     schedulingAlgorithm,
+		isLoadBalanced:          isLoadBalanced,
+		excludedWeekdays:        excludedWeekdays,
+		maintenanceIntervalDays: maintenanceIntervalDays,
+		registeredAt:            registeredAt,
+		editedAt:                editedAt,
 	}
 	return p, nil
 }
@@ -47,16 +69,24 @@ func ReconstructPattern(
 	userID string,
 	name string,
 	targetWeight string,
+	schedulingAlgorithm string,
+	isLoadBalanced bool,
+	excludedWeekdays []int,
+	maintenanceIntervalDays int,
 	registeredAt time.Time,
 	editedAt time.Time,
 ) (*Pattern, error) {
 	p := &Pattern{
-		patternID:    patternID,
-		userID:       userID,
-		name:         name,
-		targetWeight: targetWeight,
-		registeredAt: registeredAt,
-		editedAt:     editedAt,
+		patternID:               patternID,
+		userID:                  userID,
+		name:                    name,
+		targetWeight:            targetWeight,
+		schedulingAlgorithm:     schedulingAlgorithm,
+		isLoadBalanced:          isLoadBalanced,
+		excludedWeekdays:        excludedWeekdays,
+		maintenanceIntervalDays: maintenanceIntervalDays,
+		registeredAt:            registeredAt,
+		editedAt:                editedAt,
 	}
 	return p, nil
 }
@@ -75,6 +105,24 @@ var allowedTargetWeights = map[string]struct{}{
 	TargetWeightUnset:  {},
 }
 
+// 復習日の算出方法。fixedはステップの間隔日数をそのまま使う従来の方式
+// sm2_adaptiveは復習日を事前に全て生成せず、復習日の完了時に想起評価から次の復習日を1件ずつ生成する
+const (
+	SchedulingAlgorithmFixed       string = "fixed"
+	SchedulingAlgorithmExpanding   string = "expanding"
+	SchedulingAlgorithmSM2         string = "sm2"
+	SchedulingAlgorithmFSRS        string = "fsrs"
+	SchedulingAlgorithmSM2Adaptive string = "sm2_adaptive"
+)
+
+var allowedSchedulingAlgorithms = map[string]struct{}{
+	SchedulingAlgorithmFixed:       {},
+	SchedulingAlgorithmExpanding:   {},
+	SchedulingAlgorithmSM2:         {},
+	SchedulingAlgorithmFSRS:        {},
+	SchedulingAlgorithmSM2Adaptive: {},
+}
+
 func validateName(name string) error {
 	return validation.Validate(
 		name,
@@ -95,6 +143,20 @@ func validateTargetWeight(targetWeight string) error {
 	)
 }
 
+func validateSchedulingAlgorithm(schedulingAlgorithm string) error {
+	return validation.Validate(
+		schedulingAlgorithm,
+		validation.Required.Error("スケジューリング方式は必須です"),
+		validation.By(func(value interface{}) error {
+			algrthm, _ := value.(string)
+			if _, ok := allowedSchedulingAlgorithms[algrthm]; !ok {
+				return errors.New("スケジューリング方式の値が不正です")
+			}
+			return nil
+		}),
+	)
+}
+
 func (p *Pattern) PatternID() string {
 	return p.patternID
 }
@@ -111,6 +173,35 @@ func (p *Pattern) TargetWeight() string {
 	return p.targetWeight
 }
 
+func (p *Pattern) SchedulingAlgorithm() string {
+	return p.schedulingAlgorithm
+}
+
+// 復習日を完了時に1件ずつ生成する方式かどうか
+func (p *Pattern) IsAdaptive() bool {
+	return p.schedulingAlgorithm == SchedulingAlgorithmSM2Adaptive
+}
+
+// trueの場合、生成する復習日を許容範囲内で復習予定数が最も少ない日にずらす
+func (p *Pattern) IsLoadBalanced() bool {
+	return p.isLoadBalanced
+}
+
+// 復習日を置かない曜日（0が日曜日、6が土曜日）
+func (p *Pattern) ExcludedWeekdays() []int {
+	return p.excludedWeekdays
+}
+
+// 最後のステップの完了後に復習日を繰り返し追加する間隔日数
+func (p *Pattern) MaintenanceIntervalDays() int {
+	return p.maintenanceIntervalDays
+}
+
+// trueの場合、最後のステップの復習日を完了しても復習物を完了にせず、手動で完了にするまで間隔日数毎に復習日を追加し続ける
+func (p *Pattern) HasMaintenanceInterval() bool {
+	return p.maintenanceIntervalDays > 0
+}
+
 func (p *Pattern) RegisteredAt() time.Time {
 	return p.registeredAt
 }
@@ -122,6 +213,10 @@ func (p *Pattern) EditedAt() time.Time {
 func (p *Pattern) UpdatePattern(
 	name string,
 	targetWeight string,
+	schedulingAlgorithm string,
+	isLoadBalanced bool,
+	excludedWeekdays []int,
+	maintenanceIntervalDays int,
 	editedAt time.Time,
 ) error {
 	if err := validateName(name); err != nil {
@@ -130,20 +225,57 @@ func (p *Pattern) UpdatePattern(
 	if err := validateTargetWeight(string(targetWeight)); err != nil {
 		return err
 	}
+	if err := validateSchedulingAlgorithm(schedulingAlgorithm); err != nil {
+		return err
+	}
+	if err := ValidateExcludedWeekdays(excludedWeekdays); err != nil {
+		return err
+	}
+	if err := ValidateMaintenanceIntervalDays(schedulingAlgorithm, maintenanceIntervalDays); err != nil {
+		return err
+	}
 
 	p.name = name
 	p.targetWeight = targetWeight
+	p.schedulingAlgorithm = schedulingAlgorithm
+	p.isLoadBalanced = isLoadBalanced
+	p.excludedWeekdays = excludedWeekdays
+	p.maintenanceIntervalDays = maintenanceIntervalDays
 	p.editedAt = editedAt
 
 	return nil
 }
 
+// 復習日の間隔の単位
+// monthは暦の月で数え、学習日と同じ日付（その月にない場合は月末）に復習日を置く
+// minuteとhourは日単位のステップより前に置く当日中の短期のステップで、復習日ではなく復習日時を持つ
+const (
+	IntervalUnitMinute string = "minute"
+	IntervalUnitHour   string = "hour"
+	IntervalUnitDay    string = "day"
+	IntervalUnitWeek   string = "week"
+	IntervalUnitMonth  string = "month"
+)
+
+var allowedIntervalUnits = map[string]struct{}{
+	IntervalUnitMinute: {},
+	IntervalUnitHour:   {},
+	IntervalUnitDay:    {},
+	IntervalUnitWeek:   {},
+	IntervalUnitMonth:  {},
+}
+
+// 分・時間単位のステップの間隔の上限（24時間未満）
+const MaxIntradayInterval = 24 * time.Hour
+
 type PatternStep struct {
 	patternStepID string
 	userID        string
 	patternID     string
 	stepNumber    int
-	intervalDays  int
+	// intervalUnitの単位での間隔
+	intervalDays int
+	intervalUnit string
 }
 
 func NewPatternStep(
@@ -152,6 +284,7 @@ func NewPatternStep(
 	patternID string,
 	stepNumber int,
 	intervalDays int,
+	intervalUnit string,
 ) (*PatternStep, error) {
 	if err := validateStepNumber(stepNumber); err != nil {
 		return nil, err
@@ -159,12 +292,16 @@ func NewPatternStep(
 	if err := validateIntervalDays(intervalDays); err != nil {
 		return nil, err
 	}
+	if err := validateIntervalUnit(intervalUnit); err != nil {
+		return nil, err
+	}
 	ps := &PatternStep{
 		patternStepID: patternStepID,
 		userID:        userID,
 		patternID:     patternID,
 		stepNumber:    stepNumber,
 		intervalDays:  intervalDays,
+		intervalUnit:  intervalUnit,
 	}
 
 	return ps, nil
@@ -186,16 +323,75 @@ func (ps *PatternStep) StepNumber() int {
 	return ps.stepNumber
 }
 
+// intervalUnitの単位での間隔。日数とは限らない
 func (ps *PatternStep) IntervalDays() int {
 	return ps.intervalDays
 }
 
+func (ps *PatternStep) IntervalUnit() string {
+	return ps.intervalUnit
+}
+
+// 分・時間単位の当日中のステップかどうか
+func (ps *PatternStep) IsIntraday() bool {
+	return ps.intervalUnit == IntervalUnitMinute || ps.intervalUnit == IntervalUnitHour
+}
+
+// 分・時間単位のステップの間隔。日単位以上のステップでは0を返す
+func (ps *PatternStep) Duration() time.Duration {
+	switch ps.intervalUnit {
+	case IntervalUnitMinute:
+		return time.Duration(ps.intervalDays) * time.Minute
+	case IntervalUnitHour:
+		return time.Duration(ps.intervalDays) * time.Hour
+	default:
+		return 0
+	}
+}
+
+// 起点日からステップの間隔だけ進めた日付を返す
+// 月単位の場合、進めた月に起点日と同じ日付がなければその月の末日とする（1月31日の1か月後は2月末日）
+// 分・時間単位の場合は起点日時から間隔だけ進めた日時を返す
+func (ps *PatternStep) AddTo(baseDate time.Time) time.Time {
+	switch ps.intervalUnit {
+	case IntervalUnitMinute, IntervalUnitHour:
+		return baseDate.Add(ps.Duration())
+	case IntervalUnitWeek:
+		return baseDate.AddDate(0, 0, ps.intervalDays*7)
+	case IntervalUnitMonth:
+		year, month, day := baseDate.Date()
+		firstOfMonth := time.Date(year, month+time.Month(ps.intervalDays), 1, 0, 0, 0, 0, baseDate.Location())
+		lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+		if day > lastDay {
+			day = lastDay
+		}
+		hour, minute, sec := baseDate.Clock()
+		return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, hour, minute, sec, baseDate.Nanosecond(), baseDate.Location())
+	default:
+		return baseDate.AddDate(0, 0, ps.intervalDays)
+	}
+}
+
+// 起点日からステップの復習日までの日数。月単位の場合は起点日によって変わる
+func (ps *PatternStep) DaysFrom(baseDate time.Time) int {
+	return daysBetween(baseDate, ps.AddTo(baseDate))
+}
+
+func daysBetween(from time.Time, to time.Time) int {
+	fromYear, fromMonth, fromDay := from.Date()
+	toYear, toMonth, toDay := to.Date()
+	fromDate := time.Date(fromYear, fromMonth, fromDay, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(toYear, toMonth, toDay, 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
 func ReconstructPatternStep(
 	patternStepID string,
 	userID string,
 	patternID string,
 	stepNumber int,
 	intervalDays int,
+	intervalUnit string,
 ) (*PatternStep, error) {
 	ps := &PatternStep{
 		patternStepID: patternStepID,
@@ -203,10 +399,41 @@ func ReconstructPatternStep(
 		patternID:     patternID,
 		stepNumber:    stepNumber,
 		intervalDays:  intervalDays,
+		intervalUnit:  intervalUnit,
 	}
 	return ps, nil
 }
 
+// 除外する曜日は0（日曜日）から6（土曜日）で重複なく指定し、少なくとも1つの曜日は残す
+func ValidateExcludedWeekdays(excludedWeekdays []int) error {
+	seen := make(map[int]struct{}, len(excludedWeekdays))
+	for _, d := range excludedWeekdays {
+		if d < 0 || d > 6 {
+			return ErrInvalidExcludedWeekday
+		}
+		if _, ok := seen[d]; ok {
+			return ErrDuplicatedExcludedWeekday
+		}
+		seen[d] = struct{}{}
+	}
+	if len(seen) == 7 {
+		return ErrAllWeekdaysExcluded
+	}
+	return nil
+}
+
+// 適応型の方式は元々手動で完了にするまで復習日を生成し続けるため、間隔日数は指定できない
+func ValidateMaintenanceIntervalDays(schedulingAlgorithm string, maintenanceIntervalDays int) error {
+	if schedulingAlgorithm == SchedulingAlgorithmSM2Adaptive && maintenanceIntervalDays != 0 {
+		return ErrAdaptivePatternMaintenanceInterval
+	}
+	return validation.Validate(
+		maintenanceIntervalDays,
+		validation.Min(0).Error("繰り返しの間隔日数は0以上で指定してください"),
+		validation.Max(32767).Error("繰り返しの間隔日数は32768日以上は指定できません"),
+	)
+}
+
 func validateStepNumber(stepNumber int) error {
 	return validation.Validate(
 		stepNumber,
@@ -224,6 +451,35 @@ func validateIntervalDays(intervalDays int) error {
 	)
 }
 
+func validateIntervalUnit(intervalUnit string) error {
+	return validation.Validate(
+		intervalUnit,
+		validation.Required.Error("復習日間隔の単位は必須です"),
+		validation.By(func(value interface{}) error {
+			unit, _ := value.(string)
+			if _, ok := allowedIntervalUnits[unit]; !ok {
+				return errors.New("復習日間隔の単位の値が不正です")
+			}
+			return nil
+		}),
+	)
+}
+
+// 適応型の方式では、ステップは初回の復習日までの間隔日数を表す1件のみとする
+// 学習状態（SM2State）は日数で間隔を持つため、ステップも日単位とする
+func ValidateStepsForSchedulingAlgorithm(schedulingAlgorithm string, steps []*PatternStep) error {
+	if schedulingAlgorithm != SchedulingAlgorithmSM2Adaptive {
+		return nil
+	}
+	if len(steps) != 1 {
+		return ErrAdaptivePatternStepCount
+	}
+	if steps[0].IntervalUnit() != IntervalUnitDay {
+		return ErrAdaptivePatternIntervalUnit
+	}
+	return nil
+}
+
 func ValidateSteps(steps []*PatternStep) error {
 
 	// ステップ数が0の場合はエラー
@@ -231,6 +487,10 @@ func ValidateSteps(steps []*PatternStep) error {
 		return errors.New("復習日間隔数は1つ以上指定してください")
 	}
 
+	if err := validateIntradaySteps(steps); err != nil {
+		return err
+	}
+
 	// ステップ数が1つの場合は昇順チェック不要
 	if len(steps) == 1 {
 		return nil
@@ -242,13 +502,18 @@ func ValidateSteps(steps []*PatternStep) error {
 		if curr.StepNumber() == prev.StepNumber() {
 			return errors.New("順序番号は重複してはいけません")
 		}
-		if curr.IntervalDays() == prev.IntervalDays() {
+		// 分・時間単位のステップから日単位以上のステップに切り替わる箇所は、当日中と翌日以降なので比較しない
+		isDuplicated, isDescending := false, false
+		if prev.IsIntraday() == curr.IsIntraday() {
+			isDuplicated, isDescending = compareIntervals(prev, curr)
+		}
+		if isDuplicated {
 			return errors.New("復習日間隔数は重複してはいけません")
 		}
 		if curr.StepNumber() < prev.StepNumber() {
 			return errors.New("順序番号は昇順で指定してください")
 		}
-		if curr.IntervalDays() < prev.IntervalDays() {
+		if isDescending {
 			return errors.New("復習日間隔数は昇順で指定してください")
 		}
 
@@ -261,3 +526,97 @@ func ValidateSteps(steps []*PatternStep) error {
 	}
 	return nil
 }
+
+// 単位の異なるステップを比較する際の起点日。月単位の日数は、起点日の月の長さ（28〜31日）と月末での切り詰めでしか変わらないため、
+// 平年とうるう年の各月の1日と28日以降の日（月末）だけを起点日にすれば、日数の最小と最大を全て含む
+var intervalComparisonBaseDates = func() []time.Time {
+	var dates []time.Time
+	for _, year := range []int{2023, 2024} {
+		for month := time.January; month <= time.December; month++ {
+			lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+			dates = append(dates, time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+			for day := 28; day <= lastDay; day++ {
+				dates = append(dates, time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+			}
+		}
+	}
+	return dates
+}()
+
+// 前のステップと次のステップの間隔を比較し、復習日が重なりうるか、前後が逆転しうるかを返す
+// 単位が同じ場合は間隔の数値で比較する。単位が異なる場合（例: 4週と1か月）は起点日によって日数が変わるため、境界となる起点日で比較する
+func compareIntervals(prev *PatternStep, curr *PatternStep) (bool, bool) {
+	if prev.IntervalUnit() == curr.IntervalUnit() {
+		return curr.IntervalDays() == prev.IntervalDays(), curr.IntervalDays() < prev.IntervalDays()
+	}
+	if prev.IsIntraday() && curr.IsIntraday() {
+		return curr.Duration() == prev.Duration(), curr.Duration() < prev.Duration()
+	}
+	isDuplicated, isDescending := false, false
+	for _, baseDate := range intervalComparisonBaseDates {
+		prevDays, currDays := prev.DaysFrom(baseDate), curr.DaysFrom(baseDate)
+		if currDays == prevDays {
+			isDuplicated = true
+		}
+		if currDays < prevDays {
+			isDescending = true
+		}
+	}
+	// いずれかの起点日で逆転する場合は、重なる起点日があっても昇順の誤りとして扱う
+	if isDescending {
+		isDuplicated = false
+	}
+	return isDuplicated, isDescending
+}
+
+// 分・時間単位のステップは24時間未満とし、日単位以上のステップより前にまとめて置く
+// 復習物の完了は日単位以上のステップで判定するため、日単位以上のステップを少なくとも1つ含める
+func validateIntradaySteps(steps []*PatternStep) error {
+	hasDailyStep := false
+	for _, step := range steps {
+		if !step.IsIntraday() {
+			hasDailyStep = true
+			continue
+		}
+		if step.Duration() >= MaxIntradayInterval {
+			return ErrIntradayStepTooLong
+		}
+		if hasDailyStep {
+			return ErrIntradayStepAfterDailyStep
+		}
+	}
+	if !hasDailyStep {
+		return ErrNoDailyStep
+	}
+	return nil
+}
+
+// ステップを分・時間単位のステップと日単位以上のステップに分ける
+// ValidateStepsを通ったステップでは、分・時間単位のステップが先頭にまとまっている
+func SplitIntradaySteps(steps []*PatternStep) ([]*PatternStep, []*PatternStep) {
+	intradaySteps := make([]*PatternStep, 0, len(steps))
+	dailySteps := make([]*PatternStep, 0, len(steps))
+	for _, step := range steps {
+		if step.IsIntraday() {
+			intradaySteps = append(intradaySteps, step)
+		} else {
+			dailySteps = append(dailySteps, step)
+		}
+	}
+	return intradaySteps, dailySteps
+}
+
+// 復習物に紐づくパターンのステップ（またはスケジューリング方式）を変更する際の、既存の復習物の扱い
+// 空文字の場合はkeepとして扱う。keepは既存の復習日と復習物の版をそのまま残し、rescheduleは未完了の復習日を変更後のステップで組み直して新しい版に移す
+const (
+	StepChangeModeKeep       string = "keep"
+	StepChangeModeReschedule string = "reschedule"
+)
+
+func ValidateStepChangeMode(stepChangeMode string) error {
+	switch stepChangeMode {
+	case "", StepChangeModeKeep, StepChangeModeReschedule:
+		return nil
+	}
+	return ErrInvalidStepChangeMode
+}
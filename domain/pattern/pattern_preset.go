@@ -0,0 +1,136 @@
+package pattern
+
+// よく知られた復習スケジュールを、ユーザーがステップを手で組まずに自分のパターンとして作成できるようにする組み込みのカタログ
+// プリセット自体はDBに保存せず、作成時にユーザーのパターンへ複製する
+type PatternPreset struct {
+	key string
+	// 言語毎の名前。ユーザーの言語の名前が無い場合は日本語の名前を使う
+	names               map[string]string
+	targetWeight        string
+	schedulingAlgorithm string
+	steps               []PatternPresetStep
+}
+
+type PatternPresetStep struct {
+	IntervalDays int
+	IntervalUnit string
+}
+
+func (p *PatternPreset) Key() string {
+	return p.key
+}
+
+func (p *PatternPreset) Name(language string) string {
+	if name, ok := p.names[language]; ok {
+		return name
+	}
+	return p.names[presetDefaultLanguage]
+}
+
+func (p *PatternPreset) TargetWeight() string {
+	return p.targetWeight
+}
+
+func (p *PatternPreset) SchedulingAlgorithm() string {
+	return p.schedulingAlgorithm
+}
+
+func (p *PatternPreset) Steps() []PatternPresetStep {
+	return p.steps
+}
+
+// ユーザーの言語はドメインのuserパッケージで定義しているが、パッケージ間の依存を避けるため値だけを合わせる
+const presetDefaultLanguage = "ja"
+
+const (
+	PatternPresetKeyLeitner    string = "leitner"
+	PatternPresetKeyPimsleur   string = "pimsleur"
+	PatternPresetKeyEbbinghaus string = "ebbinghaus"
+	PatternPresetKeyExamCram   string = "exam_cram"
+)
+
+// 一覧で返す順番を固定するためスライスで持つ
+var patternPresets = []*PatternPreset{
+	{
+		key: PatternPresetKeyLeitner,
+		names: map[string]string{
+			"ja": "ライトナー式",
+			"en": "Leitner system",
+		},
+		targetWeight:        TargetWeightUnset,
+		schedulingAlgorithm: SchedulingAlgorithmFixed,
+		steps: []PatternPresetStep{
+			{IntervalDays: 1, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 3, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 7, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 14, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 30, IntervalUnit: IntervalUnitDay},
+		},
+	},
+	{
+		key: PatternPresetKeyPimsleur,
+		names: map[string]string{
+			"ja": "ピムズラー式",
+			"en": "Pimsleur-style",
+		},
+		targetWeight:        TargetWeightUnset,
+		schedulingAlgorithm: SchedulingAlgorithmFixed,
+		steps: []PatternPresetStep{
+			{IntervalDays: 2, IntervalUnit: IntervalUnitMinute},
+			{IntervalDays: 10, IntervalUnit: IntervalUnitMinute},
+			{IntervalDays: 1, IntervalUnit: IntervalUnitHour},
+			{IntervalDays: 5, IntervalUnit: IntervalUnitHour},
+			{IntervalDays: 1, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 5, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 25, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 4, IntervalUnit: IntervalUnitMonth},
+		},
+	},
+	{
+		key: PatternPresetKeyEbbinghaus,
+		names: map[string]string{
+			"ja": "エビングハウスの忘却曲線",
+			"en": "Ebbinghaus forgetting curve",
+		},
+		targetWeight:        TargetWeightUnset,
+		schedulingAlgorithm: SchedulingAlgorithmFixed,
+		steps: []PatternPresetStep{
+			{IntervalDays: 20, IntervalUnit: IntervalUnitMinute},
+			{IntervalDays: 1, IntervalUnit: IntervalUnitHour},
+			{IntervalDays: 9, IntervalUnit: IntervalUnitHour},
+			{IntervalDays: 1, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 2, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 6, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 31, IntervalUnit: IntervalUnitDay},
+		},
+	},
+	{
+		key: PatternPresetKeyExamCram,
+		names: map[string]string{
+			"ja": "試験直前の詰め込み",
+			"en": "Exam cram",
+		},
+		targetWeight:        TargetWeightHeavy,
+		schedulingAlgorithm: SchedulingAlgorithmFixed,
+		steps: []PatternPresetStep{
+			{IntervalDays: 1, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 2, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 3, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 5, IntervalUnit: IntervalUnitDay},
+			{IntervalDays: 7, IntervalUnit: IntervalUnitDay},
+		},
+	},
+}
+
+func GetPatternPresets() []*PatternPreset {
+	return patternPresets
+}
+
+func FindPatternPreset(key string) (*PatternPreset, error) {
+	for _, p := range patternPresets {
+		if p.key == key {
+			return p, nil
+		}
+	}
+	return nil, ErrPatternPresetNotFound
+}
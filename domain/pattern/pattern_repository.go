@@ -27,4 +27,10 @@ type IPatternRepository interface {
 
 	// item_usecaseで使う。パターンIDからパターン名を取得する
 	GetPatternTargetWeightsByPatternIDs(ctx context.Context, patternIDs []string) ([]*TargetWeight, error)
+
+	// パターンの版（版のステップも合わせて保存・取得する）
+	CreatePatternVersion(ctx context.Context, version *PatternVersion) error
+	FindLatestPatternVersionByPatternID(ctx context.Context, patternID string, userID string) (*PatternVersion, error)
+	FindPatternVersionByID(ctx context.Context, patternVersionID string, userID string) (*PatternVersion, error)
+	GetPatternVersionsByPatternID(ctx context.Context, patternID string, userID string) ([]*PatternVersion, error)
 }
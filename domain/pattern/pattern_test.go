@@ -20,31 +20,37 @@ func TestNewPattern(t *testing.T) {
 	now := time.Now()
 
 	tests := []struct {
-		name         string
-		patternID    string
-		userID       string
-		patternName  string
-		targetWeight string
-		registeredAt time.Time
-		editedAt     time.Time
-		want         *Pattern
-		wantErr      bool
-		errMsg       string
+		name                string
+		patternID           string
+		userID              string
+		patternName         string
+		targetWeight        string
+		schedulingAlgorithm string
+		registeredAt        time.Time
+		editedAt            time.Time
+		want                *Pattern
+		wantErr             bool
+		errMsg              string
 	}{
 		{
-			name:         "有効なパターン（正常系）",
-			patternID:    testPatternID,
-			userID:       testUserID,
-			patternName:  "Standard Review",
-			targetWeight: TargetWeightNormal,
-			registeredAt: now,
-			editedAt:     now,
+			name:                "有効なパターン（正常系）",
+			patternID:           testPatternID,
+			userID:              testUserID,
+			patternName:         "Standard Review",
+			targetWeight:        TargetWeightNormal,
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			registeredAt:        now,
+			editedAt:            now,
 			want: func() *Pattern {
 				pattern, _ := ReconstructPattern(
 					testPatternID,
 					testUserID,
 					"Standard Review",
 					TargetWeightNormal,
+					SchedulingAlgorithmFixed,
+					false,
+					[]int{},
+					0,
 					now,
 					now,
 				)
@@ -53,43 +59,50 @@ func TestNewPattern(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:         "パターン名が空（異常系）",
-			patternID:    "pattern2",
-			userID:       testUserID,
-			patternName:  "",
-			targetWeight: TargetWeightNormal,
-			registeredAt: now,
-			editedAt:     now,
-			want:         nil,
-			wantErr:      true,
-			errMsg:       "名前は必須です",
-		},
-		{
-			name:         "重みが不正（異常系）",
-			patternID:    "pattern3",
-			userID:       testUserID,
-			patternName:  "Test Pattern",
-			targetWeight: "invalid",
-			registeredAt: now,
-			editedAt:     now,
-			want:         nil,
-			wantErr:      true,
-			errMsg:       "重みの値が不正です",
-		},
-		{
-			name:         "重みがHeavy（正常系）",
-			patternID:    "pattern4",
-			userID:       testUserID,
-			patternName:  "Heavy Pattern",
-			targetWeight: TargetWeightHeavy,
-			registeredAt: now,
-			editedAt:     now,
+			name:                "パターン名が空（異常系）",
+			patternID:           "pattern2",
+			userID:              testUserID,
+			patternName:         "",
+			targetWeight:        TargetWeightNormal,
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			registeredAt:        now,
+			editedAt:            now,
+			want:                nil,
+			wantErr:             true,
+			errMsg:              "名前は必須です",
+		},
+		{
+			name:                "重みが不正（異常系）",
+			patternID:           "pattern3",
+			userID:              testUserID,
+			patternName:         "Test Pattern",
+			targetWeight:        "invalid",
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			registeredAt:        now,
+			editedAt:            now,
+			want:                nil,
+			wantErr:             true,
+			errMsg:              "重みの値が不正です",
+		},
+		{
+			name:                "重みがHeavy（正常系）",
+			patternID:           "pattern4",
+			userID:              testUserID,
+			patternName:         "Heavy Pattern",
+			targetWeight:        TargetWeightHeavy,
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			registeredAt:        now,
+			editedAt:            now,
 			want: func() *Pattern {
 				pattern, _ := ReconstructPattern(
 					"pattern4",
 					testUserID,
 					"Heavy Pattern",
 					TargetWeightHeavy,
+					SchedulingAlgorithmFixed,
+					false,
+					[]int{},
+					0,
 					now,
 					now,
 				)
@@ -98,19 +111,24 @@ func TestNewPattern(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:         "重みがLight（正常系）",
-			patternID:    "pattern5",
-			userID:       testUserID,
-			patternName:  "Light Pattern",
-			targetWeight: TargetWeightLight,
-			registeredAt: now,
-			editedAt:     now,
+			name:                "重みがLight（正常系）",
+			patternID:           "pattern5",
+			userID:              testUserID,
+			patternName:         "Light Pattern",
+			targetWeight:        TargetWeightLight,
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			registeredAt:        now,
+			editedAt:            now,
 			want: func() *Pattern {
 				pattern, _ := ReconstructPattern(
 					"pattern5",
 					testUserID,
 					"Light Pattern",
 					TargetWeightLight,
+					SchedulingAlgorithmFixed,
+					false,
+					[]int{},
+					0,
 					now,
 					now,
 				)
@@ -119,19 +137,50 @@ func TestNewPattern(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:         "重みがUnset（正常系）",
-			patternID:    "pattern6",
-			userID:       testUserID,
-			patternName:  "Unset Pattern",
-			targetWeight: TargetWeightUnset,
-			registeredAt: now,
-			editedAt:     now,
+			name:                "重みがUnset（正常系）",
+			patternID:           "pattern6",
+			userID:              testUserID,
+			patternName:         "Unset Pattern",
+			targetWeight:        TargetWeightUnset,
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			registeredAt:        now,
+			editedAt:            now,
 			want: func() *Pattern {
 				pattern, _ := ReconstructPattern(
 					"pattern6",
 					testUserID,
 					"Unset Pattern",
 					TargetWeightUnset,
+					SchedulingAlgorithmFixed,
+					false,
+					[]int{},
+					0,
+					now,
+					now,
+				)
+				return pattern
+			}(),
+			wantErr: false,
+		},
+		{
+			name:                "スケジューリング方式がSM2（正常系）",
+			patternID:           "pattern7",
+			userID:              testUserID,
+			patternName:         "SM2 Pattern",
+			targetWeight:        TargetWeightNormal,
+			schedulingAlgorithm: SchedulingAlgorithmSM2,
+			registeredAt:        now,
+			editedAt:            now,
+			want: func() *Pattern {
+				pattern, _ := ReconstructPattern(
+					"pattern7",
+					testUserID,
+					"SM2 Pattern",
+					TargetWeightNormal,
+					SchedulingAlgorithmSM2,
+					false,
+					[]int{},
+					0,
 					now,
 					now,
 				)
@@ -139,6 +188,19 @@ func TestNewPattern(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name:                "スケジューリング方式が不正（異常系）",
+			patternID:           "pattern8",
+			userID:              testUserID,
+			patternName:         "Test Pattern",
+			targetWeight:        TargetWeightNormal,
+			schedulingAlgorithm: "invalid",
+			registeredAt:        now,
+			editedAt:            now,
+			want:                nil,
+			wantErr:             true,
+			errMsg:              "スケジューリング方式の値が不正です",
+		},
 	}
 
 	for _, tc := range tests {
@@ -146,7 +208,7 @@ func TestNewPattern(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			pattern, err := NewPattern(tc.patternID, tc.userID, tc.patternName, tc.targetWeight, tc.registeredAt, tc.editedAt)
+			pattern, err := NewPattern(tc.patternID, tc.userID, tc.patternName, tc.targetWeight, tc.schedulingAlgorithm, false, []int{}, 0, tc.registeredAt, tc.editedAt)
 
 			if tc.wantErr {
 				if err == nil {
@@ -187,7 +249,7 @@ func TestNewPattern(t *testing.T) {
 
 func TestPattern_UpdatePattern(t *testing.T) {
 	now := time.Now()
-	pattern, err := NewPattern(testPatternID, testUserID, "Original", TargetWeightNormal, now, now)
+	pattern, err := NewPattern(testPatternID, testUserID, "Original", TargetWeightNormal, SchedulingAlgorithmFixed, false, []int{}, 0, now, now)
 	if err != nil {
 		t.Fatalf("failed to create pattern: %v", err)
 	}
@@ -195,25 +257,31 @@ func TestPattern_UpdatePattern(t *testing.T) {
 	newTime := now.Add(time.Hour)
 
 	tests := []struct {
-		name         string
-		newName      string
-		targetWeight string
-		editedAt     time.Time
-		wantPattern  *Pattern
-		wantErr      bool
-		errMsg       string
+		name                string
+		newName             string
+		targetWeight        string
+		schedulingAlgorithm string
+		editedAt            time.Time
+		wantPattern         *Pattern
+		wantErr             bool
+		errMsg              string
 	}{
 		{
-			name:         "全項目を更新（正常系）",
-			newName:      "Updated Pattern",
-			targetWeight: TargetWeightHeavy,
-			editedAt:     newTime,
+			name:                "全項目を更新（正常系）",
+			newName:             "Updated Pattern",
+			targetWeight:        TargetWeightHeavy,
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			editedAt:            newTime,
 			wantPattern: func() *Pattern {
 				pattern, _ := ReconstructPattern(
 					testPatternID,
 					testUserID,
 					"Updated Pattern",
 					TargetWeightHeavy,
+					SchedulingAlgorithmFixed,
+					false,
+					[]int{},
+					0,
 					now,
 					newTime,
 				)
@@ -222,16 +290,21 @@ func TestPattern_UpdatePattern(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:         "パターン名が空（異常系）",
-			newName:      "",
-			targetWeight: TargetWeightNormal,
-			editedAt:     newTime,
+			name:                "パターン名が空（異常系）",
+			newName:             "",
+			targetWeight:        TargetWeightNormal,
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			editedAt:            newTime,
 			wantPattern: func() *Pattern {
 				pattern, _ := ReconstructPattern(
 					testPatternID,
 					testUserID,
 					"Original",
 					TargetWeightNormal,
+					SchedulingAlgorithmFixed,
+					false,
+					[]int{},
+					0,
 					now,
 					now,
 				)
@@ -241,16 +314,21 @@ func TestPattern_UpdatePattern(t *testing.T) {
 			errMsg:  "名前は必須です",
 		},
 		{
-			name:         "重みが不正（異常系）",
-			newName:      "Valid Name",
-			targetWeight: "invalid",
-			editedAt:     newTime,
+			name:                "重みが不正（異常系）",
+			newName:             "Valid Name",
+			targetWeight:        "invalid",
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			editedAt:            newTime,
 			wantPattern: func() *Pattern {
 				pattern, _ := ReconstructPattern(
 					testPatternID,
 					testUserID,
 					"Original",
 					TargetWeightNormal,
+					SchedulingAlgorithmFixed,
+					false,
+					[]int{},
+					0,
 					now,
 					now,
 				)
@@ -259,6 +337,53 @@ func TestPattern_UpdatePattern(t *testing.T) {
 			wantErr: true,
 			errMsg:  "重みの値が不正です",
 		},
+		{
+			name:                "スケジューリング方式を更新（正常系）",
+			newName:             "Original",
+			targetWeight:        TargetWeightNormal,
+			schedulingAlgorithm: SchedulingAlgorithmFSRS,
+			editedAt:            newTime,
+			wantPattern: func() *Pattern {
+				pattern, _ := ReconstructPattern(
+					testPatternID,
+					testUserID,
+					"Original",
+					TargetWeightNormal,
+					SchedulingAlgorithmFSRS,
+					false,
+					[]int{},
+					0,
+					now,
+					newTime,
+				)
+				return pattern
+			}(),
+			wantErr: false,
+		},
+		{
+			name:                "スケジューリング方式が不正（異常系）",
+			newName:             "Original",
+			targetWeight:        TargetWeightNormal,
+			schedulingAlgorithm: "invalid",
+			editedAt:            newTime,
+			wantPattern: func() *Pattern {
+				pattern, _ := ReconstructPattern(
+					testPatternID,
+					testUserID,
+					"Original",
+					TargetWeightNormal,
+					SchedulingAlgorithmFixed,
+					false,
+					[]int{},
+					0,
+					now,
+					now,
+				)
+				return pattern
+			}(),
+			wantErr: true,
+			errMsg:  "スケジューリング方式の値が不正です",
+		},
 	}
 
 	for _, tc := range tests {
@@ -267,7 +392,7 @@ func TestPattern_UpdatePattern(t *testing.T) {
 			// パターンをコピー
 			testPattern := *pattern
 
-			err := testPattern.UpdatePattern(tc.newName, tc.targetWeight, tc.editedAt)
+			err := testPattern.UpdatePattern(tc.newName, tc.targetWeight, tc.schedulingAlgorithm, false, []int{}, 0, tc.editedAt)
 
 			if tc.wantErr {
 				if err == nil {
@@ -319,6 +444,7 @@ func TestNewPatternStep(t *testing.T) {
 					testPatternID,
 					1,
 					1,
+					IntervalUnitDay,
 				)
 				return step
 			}(),
@@ -375,7 +501,7 @@ func TestNewPatternStep(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			step, err := NewPatternStep(tc.patternStepID, tc.userID, tc.patternID, tc.stepNumber, tc.intervalDays)
+			step, err := NewPatternStep(tc.patternStepID, tc.userID, tc.patternID, tc.stepNumber, tc.intervalDays, IntervalUnitDay)
 
 			if tc.wantErr {
 				if err == nil {
@@ -403,10 +529,28 @@ func TestValidateSteps(t *testing.T) {
 		t.Skip("スキップさせる")
 	}
 
-	s1, _ := ReconstructPatternStep("1", "u1", "p1", 1, 1)
-	s2, _ := ReconstructPatternStep("2", "u1", "p1", 2, 2)
-	s3, _ := ReconstructPatternStep("3", "u1", "p1", 1, 3) // StepNumber 重複
-	s4, _ := ReconstructPatternStep("4", "u1", "p1", 3, 2) // IntervalDays 重複
+	s1, _ := ReconstructPatternStep("1", "u1", "p1", 1, 1, IntervalUnitDay)
+	s2, _ := ReconstructPatternStep("2", "u1", "p1", 2, 2, IntervalUnitDay)
+	s3, _ := ReconstructPatternStep("3", "u1", "p1", 1, 3, IntervalUnitDay) // StepNumber 重複
+	s4, _ := ReconstructPatternStep("4", "u1", "p1", 3, 2, IntervalUnitDay) // IntervalDays 重複
+	weekStep2, _ := ReconstructPatternStep("5", "u1", "p1", 2, 1, IntervalUnitWeek)
+	fourWeeksStep2, _ := ReconstructPatternStep("6", "u1", "p1", 2, 4, IntervalUnitWeek)
+	monthStep3, _ := ReconstructPatternStep("7", "u1", "p1", 3, 1, IntervalUnitMonth)
+	thirtyDaysStep3, _ := ReconstructPatternStep("8", "u1", "p1", 3, 30, IntervalUnitDay)
+	monthStep2, _ := ReconstructPatternStep("9", "u1", "p1", 2, 1, IntervalUnitMonth)
+	daysStep3, _ := ReconstructPatternStep("10", "u1", "p1", 3, 20, IntervalUnitDay)
+	tenMinutesStep1, _ := ReconstructPatternStep("11", "u1", "p1", 1, 10, IntervalUnitMinute)
+	fourHoursStep2, _ := ReconstructPatternStep("12", "u1", "p1", 2, 4, IntervalUnitHour)
+	oneDayStep3, _ := ReconstructPatternStep("13", "u1", "p1", 3, 1, IntervalUnitDay)
+	sixtyMinutesStep1, _ := ReconstructPatternStep("14", "u1", "p1", 1, 60, IntervalUnitMinute)
+	oneHourStep2, _ := ReconstructPatternStep("15", "u1", "p1", 2, 1, IntervalUnitHour)
+	tenMinutesStep2, _ := ReconstructPatternStep("16", "u1", "p1", 2, 10, IntervalUnitMinute)
+	twentyFourHoursStep1, _ := ReconstructPatternStep("17", "u1", "p1", 1, 24, IntervalUnitHour)
+	twentyNineDaysStep3, _ := ReconstructPatternStep("18", "u1", "p1", 3, 29, IntervalUnitDay)
+	thirtyOneDaysStep3, _ := ReconstructPatternStep("19", "u1", "p1", 3, 31, IntervalUnitDay)
+	thirtyTwoDaysStep3, _ := ReconstructPatternStep("20", "u1", "p1", 3, 32, IntervalUnitDay)
+	twelveMonthsStep2, _ := ReconstructPatternStep("21", "u1", "p1", 2, 12, IntervalUnitMonth)
+	threeHundredSixtyFiveDaysStep3, _ := ReconstructPatternStep("22", "u1", "p1", 3, 365, IntervalUnitDay)
 
 	tests := []struct {
 		name    string
@@ -433,6 +577,94 @@ func TestValidateSteps(t *testing.T) {
 			args:    []*PatternStep{s1, s2},
 			wantErr: false,
 		},
+		// 正常系
+		{
+			name:    "日・週・月の単位が混在して昇順（正常系）",
+			args:    []*PatternStep{s1, weekStep2, monthStep3},
+			wantErr: false,
+		},
+		// 異常系
+		{
+			name:    "4週の次に1か月で、2月に復習日が重なる（異常系）",
+			args:    []*PatternStep{s1, fourWeeksStep2, monthStep3},
+			wantErr: true,
+			errMsg:  "復習日間隔数は重複してはいけません",
+		},
+		// 異常系
+		{
+			name:    "1か月の次に30日で、起点日によって前後が逆転する（異常系）",
+			args:    []*PatternStep{s1, monthStep2, thirtyDaysStep3},
+			wantErr: true,
+			errMsg:  "復習日間隔数は昇順で指定してください",
+		},
+		// 異常系
+		{
+			name:    "1か月の次に20日（異常系）",
+			args:    []*PatternStep{s1, monthStep2, daysStep3},
+			wantErr: true,
+			errMsg:  "復習日間隔数は昇順で指定してください",
+		},
+		// 異常系
+		{
+			name:    "1か月の次に29日で、平年の2月に前後が逆転する（異常系）",
+			args:    []*PatternStep{s1, monthStep2, twentyNineDaysStep3},
+			wantErr: true,
+			errMsg:  "復習日間隔数は昇順で指定してください",
+		},
+		// 異常系
+		{
+			name:    "1か月の次に31日で、31日ある月に復習日が重なる（異常系）",
+			args:    []*PatternStep{s1, monthStep2, thirtyOneDaysStep3},
+			wantErr: true,
+			errMsg:  "復習日間隔数は重複してはいけません",
+		},
+		// 正常系
+		{
+			name:    "1か月の次に32日（正常系）",
+			args:    []*PatternStep{s1, monthStep2, thirtyTwoDaysStep3},
+			wantErr: false,
+		},
+		// 異常系
+		{
+			name:    "12か月の次に365日で、うるう日を含む年に前後が逆転する（異常系）",
+			args:    []*PatternStep{s1, twelveMonthsStep2, threeHundredSixtyFiveDaysStep3},
+			wantErr: true,
+			errMsg:  "復習日間隔数は昇順で指定してください",
+		},
+		// 正常系
+		{
+			name:    "分・時間単位のステップの後に日単位のステップ（正常系）",
+			args:    []*PatternStep{tenMinutesStep1, fourHoursStep2, oneDayStep3},
+			wantErr: false,
+		},
+		// 異常系
+		{
+			name:    "60分の次に1時間で、復習日時が重なる（異常系）",
+			args:    []*PatternStep{sixtyMinutesStep1, oneHourStep2, oneDayStep3},
+			wantErr: true,
+			errMsg:  "復習日間隔数は重複してはいけません",
+		},
+		// 異常系
+		{
+			name:    "24時間以上の時間単位のステップ（異常系）",
+			args:    []*PatternStep{twentyFourHoursStep1, s2},
+			wantErr: true,
+			errMsg:  ErrIntradayStepTooLong.Error(),
+		},
+		// 異常系
+		{
+			name:    "日単位のステップの後に分単位のステップ（異常系）",
+			args:    []*PatternStep{s1, tenMinutesStep2},
+			wantErr: true,
+			errMsg:  ErrIntradayStepAfterDailyStep.Error(),
+		},
+		// 異常系
+		{
+			name:    "分・時間単位のステップのみ（異常系）",
+			args:    []*PatternStep{tenMinutesStep1, fourHoursStep2},
+			wantErr: true,
+			errMsg:  ErrNoDailyStep.Error(),
+		},
 		// 異常系
 		{
 			name:    "順序番号が昇順でない（異常系）",
@@ -491,3 +723,361 @@ func TestValidateSteps(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateStepsForSchedulingAlgorithm(t *testing.T) {
+	s1, _ := ReconstructPatternStep("1", "u1", "p1", 1, 1, IntervalUnitDay)
+	s2, _ := ReconstructPatternStep("2", "u1", "p1", 2, 3, IntervalUnitDay)
+	weekStep, _ := ReconstructPatternStep("3", "u1", "p1", 1, 1, IntervalUnitWeek)
+	monthStep, _ := ReconstructPatternStep("4", "u1", "p1", 1, 1, IntervalUnitMonth)
+
+	tests := []struct {
+		name                string
+		schedulingAlgorithm string
+		args                []*PatternStep
+		wantErr             error
+	}{
+		{
+			name:                "固定ステップ方式で複数ステップ（正常系）",
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			args:                []*PatternStep{s1, s2},
+			wantErr:             nil,
+		},
+		{
+			name:                "適応型SM-2方式で1ステップ（正常系）",
+			schedulingAlgorithm: SchedulingAlgorithmSM2Adaptive,
+			args:                []*PatternStep{s1},
+			wantErr:             nil,
+		},
+		{
+			name:                "適応型SM-2方式で週単位の1ステップ（異常系）",
+			schedulingAlgorithm: SchedulingAlgorithmSM2Adaptive,
+			args:                []*PatternStep{weekStep},
+			wantErr:             ErrAdaptivePatternIntervalUnit,
+		},
+		{
+			name:                "適応型SM-2方式で月単位の1ステップ（異常系）",
+			schedulingAlgorithm: SchedulingAlgorithmSM2Adaptive,
+			args:                []*PatternStep{monthStep},
+			wantErr:             ErrAdaptivePatternIntervalUnit,
+		},
+		{
+			name:                "適応型SM-2方式で複数ステップ（異常系）",
+			schedulingAlgorithm: SchedulingAlgorithmSM2Adaptive,
+			args:                []*PatternStep{s1, s2},
+			wantErr:             ErrAdaptivePatternStepCount,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateStepsForSchedulingAlgorithm(tt.schedulingAlgorithm, tt.args)
+			if err != tt.wantErr {
+				t.Errorf("予期しないエラー:実際の結果 %v, 期待 %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPatternStep_AddTo(t *testing.T) {
+	tests := []struct {
+		name         string
+		intervalDays int
+		intervalUnit string
+		baseDate     time.Time
+		want         time.Time
+		wantDays     int
+	}{
+		{
+			name:         "日単位",
+			intervalDays: 3,
+			intervalUnit: IntervalUnitDay,
+			baseDate:     time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC),
+			want:         time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC),
+			wantDays:     3,
+		},
+		{
+			name:         "週単位",
+			intervalDays: 2,
+			intervalUnit: IntervalUnitWeek,
+			baseDate:     time.Date(2024, 1, 30, 0, 0, 0, 0, time.UTC),
+			want:         time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC),
+			wantDays:     14,
+		},
+		{
+			name:         "月単位は同じ日付",
+			intervalDays: 3,
+			intervalUnit: IntervalUnitMonth,
+			baseDate:     time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			want:         time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+			wantDays:     91,
+		},
+		{
+			name:         "月単位で同じ日付がない月は月末日（うるう年）",
+			intervalDays: 1,
+			intervalUnit: IntervalUnitMonth,
+			baseDate:     time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			want:         time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			wantDays:     29,
+		},
+		{
+			name:         "月単位で年をまたぐ",
+			intervalDays: 2,
+			intervalUnit: IntervalUnitMonth,
+			baseDate:     time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			want:         time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+			wantDays:     59,
+		},
+		{
+			name:         "分単位は起点日時から進める",
+			intervalDays: 10,
+			intervalUnit: IntervalUnitMinute,
+			baseDate:     time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC),
+			want:         time.Date(2024, 1, 15, 9, 40, 0, 0, time.UTC),
+			wantDays:     0,
+		},
+		{
+			name:         "時間単位で日付をまたぐ",
+			intervalDays: 4,
+			intervalUnit: IntervalUnitHour,
+			baseDate:     time.Date(2024, 1, 15, 22, 0, 0, 0, time.UTC),
+			want:         time.Date(2024, 1, 16, 2, 0, 0, 0, time.UTC),
+			wantDays:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			step, err := NewPatternStep("1", "u1", "p1", 1, tt.intervalDays, tt.intervalUnit)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if got := step.AddTo(tt.baseDate); !got.Equal(tt.want) {
+				t.Errorf("AddTo() = %v, want %v", got, tt.want)
+			}
+			if got := step.DaysFrom(tt.baseDate); got != tt.wantDays {
+				t.Errorf("DaysFrom() = %v, want %v", got, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestValidateMaintenanceIntervalDays(t *testing.T) {
+	tests := []struct {
+		name                    string
+		schedulingAlgorithm     string
+		maintenanceIntervalDays int
+		wantErr                 bool
+		wantErrIs               error
+	}{
+		{
+			name:                    "繰り返さない（正常系）",
+			schedulingAlgorithm:     SchedulingAlgorithmFixed,
+			maintenanceIntervalDays: 0,
+			wantErr:                 false,
+		},
+		{
+			name:                    "固定ステップ方式で30日ごとに繰り返す（正常系）",
+			schedulingAlgorithm:     SchedulingAlgorithmFixed,
+			maintenanceIntervalDays: 30,
+			wantErr:                 false,
+		},
+		{
+			name:                    "間隔日数が負数（異常系）",
+			schedulingAlgorithm:     SchedulingAlgorithmFixed,
+			maintenanceIntervalDays: -1,
+			wantErr:                 true,
+		},
+		{
+			name:                    "間隔日数が上限を超える（異常系）",
+			schedulingAlgorithm:     SchedulingAlgorithmFixed,
+			maintenanceIntervalDays: 32768,
+			wantErr:                 true,
+		},
+		{
+			name:                    "適応型SM-2方式で繰り返さない（正常系）",
+			schedulingAlgorithm:     SchedulingAlgorithmSM2Adaptive,
+			maintenanceIntervalDays: 0,
+			wantErr:                 false,
+		},
+		{
+			name:                    "適応型SM-2方式で間隔日数を指定（異常系）",
+			schedulingAlgorithm:     SchedulingAlgorithmSM2Adaptive,
+			maintenanceIntervalDays: 30,
+			wantErr:                 true,
+			wantErrIs:               ErrAdaptivePatternMaintenanceInterval,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateMaintenanceIntervalDays(tt.schedulingAlgorithm, tt.maintenanceIntervalDays)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("予期しないエラー:実際の結果 %v, 期待するエラーの有無 %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && err != tt.wantErrIs {
+				t.Errorf("予期しないエラー:実際の結果 %v, 期待 %v", err, tt.wantErrIs)
+			}
+		})
+	}
+}
+
+func TestNewPatternVersion(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	step1, _ := NewPatternStep("step1", testUserID, testPatternID, 1, 1, IntervalUnitDay)
+	step2, _ := NewPatternStep("step2", testUserID, testPatternID, 2, 2, IntervalUnitWeek)
+
+	tests := []struct {
+		name                string
+		versionNumber       int
+		schedulingAlgorithm string
+		steps               []*PatternStep
+		wantErr             bool
+		wantErrIs           error
+	}{
+		{
+			name:                "版1を作成（正常系）",
+			versionNumber:       1,
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			steps:               []*PatternStep{step1, step2},
+			wantErr:             false,
+		},
+		{
+			name:                "版番号が0（異常系）",
+			versionNumber:       0,
+			schedulingAlgorithm: SchedulingAlgorithmFixed,
+			steps:               []*PatternStep{step1, step2},
+			wantErr:             true,
+			wantErrIs:           ErrInvalidPatternVersionNumber,
+		},
+		{
+			name:                "スケジューリング方式が無効な値（異常系）",
+			versionNumber:       2,
+			schedulingAlgorithm: "invalid",
+			steps:               []*PatternStep{step1, step2},
+			wantErr:             true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewPatternVersion("version1", testUserID, testPatternID, tt.versionNumber, tt.schedulingAlgorithm, tt.steps, createdAt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("予期しないエラー:実際の結果 %v, 期待するエラーの有無 %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && err != tt.wantErrIs {
+				t.Errorf("予期しないエラー:実際の結果 %v, 期待 %v", err, tt.wantErrIs)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.VersionNumber() != tt.versionNumber {
+				t.Errorf("VersionNumber() = %d, want %d", got.VersionNumber(), tt.versionNumber)
+			}
+			if len(got.Steps()) != len(tt.steps) {
+				t.Fatalf("len(Steps()) = %d, want %d", len(got.Steps()), len(tt.steps))
+			}
+			// 版のステップはパターンのステップとは別のIDを持つ複製
+			for i, s := range got.Steps() {
+				if s.PatternStepID() == tt.steps[i].PatternStepID() {
+					t.Errorf("Steps()[%d].PatternStepID() がパターンのステップと同じです", i)
+				}
+				if s.StepNumber() != tt.steps[i].StepNumber() || s.IntervalDays() != tt.steps[i].IntervalDays() || s.IntervalUnit() != tt.steps[i].IntervalUnit() {
+					t.Errorf("Steps()[%d] = %+v, want %+v", i, s, tt.steps[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGetPatternPresets(t *testing.T) {
+	// 組み込みのプリセットは全てパターンとして作成できるステップを持つ
+	for _, preset := range GetPatternPresets() {
+		preset := preset
+		t.Run(preset.Key(), func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := NewPattern(testPatternID, testUserID, preset.Name("ja"), preset.TargetWeight(), preset.SchedulingAlgorithm(), false, []int{}, 0, time.Now(), time.Now()); err != nil {
+				t.Fatalf("NewPattern() error = %v", err)
+			}
+			steps := make([]*PatternStep, len(preset.Steps()))
+			for i, s := range preset.Steps() {
+				step, err := NewPatternStep("step", testUserID, testPatternID, i+1, s.IntervalDays, s.IntervalUnit)
+				if err != nil {
+					t.Fatalf("NewPatternStep() error = %v", err)
+				}
+				steps[i] = step
+			}
+			if err := ValidateSteps(steps); err != nil {
+				t.Errorf("ValidateSteps() error = %v", err)
+			}
+			if err := ValidateStepsForSchedulingAlgorithm(preset.SchedulingAlgorithm(), steps); err != nil {
+				t.Errorf("ValidateStepsForSchedulingAlgorithm() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestFindPatternPreset(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		language  string
+		wantName  string
+		wantErrIs error
+	}{
+		{
+			name:     "日本語の名前（正常系）",
+			key:      PatternPresetKeyLeitner,
+			language: "ja",
+			wantName: "ライトナー式",
+		},
+		{
+			name:     "英語の名前（正常系）",
+			key:      PatternPresetKeyLeitner,
+			language: "en",
+			wantName: "Leitner system",
+		},
+		{
+			name:     "名前が無い言語は日本語の名前（正常系）",
+			key:      PatternPresetKeyExamCram,
+			language: "fr",
+			wantName: "試験直前の詰め込み",
+		},
+		{
+			name:      "存在しないキー（異常系）",
+			key:       "unknown",
+			wantErrIs: ErrPatternPresetNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FindPatternPreset(tt.key)
+			if err != tt.wantErrIs {
+				t.Fatalf("予期しないエラー:実際の結果 %v, 期待 %v", err, tt.wantErrIs)
+			}
+			if tt.wantErrIs != nil {
+				return
+			}
+			if got.Name(tt.language) != tt.wantName {
+				t.Errorf("Name() = %s, want %s", got.Name(tt.language), tt.wantName)
+			}
+		})
+	}
+}
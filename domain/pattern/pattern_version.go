@@ -0,0 +1,113 @@
+package pattern
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// パターンのステップとスケジューリング方式の不変のスナップショット
+// ステップ（または方式）を変更する度に新しい版を作り、復習物は復習日を算出した時の版を記録する
+// パターンを変更しても、変更前の版で作成した復習物は元のステップで復習日を算出する
+type PatternVersion struct {
+	patternVersionID    string
+	userID              string
+	patternID           string
+	versionNumber       int
+	schedulingAlgorithm string
+	// 版のステップ。PatternStepIDは版のステップのID
+	steps     []*PatternStep
+	createdAt time.Time
+}
+
+// パターンの作成時、またはステップ（方式）の変更時に、その時点のステップで新しい版を作る
+// パターンのステップは変更時に削除されるため、版には別のIDを振って複製したステップを持たせる
+// 版番号はパターン毎に1から順に振る
+func NewPatternVersion(
+	patternVersionID string,
+	userID string,
+	patternID string,
+	versionNumber int,
+	schedulingAlgorithm string,
+	steps []*PatternStep,
+	createdAt time.Time,
+) (*PatternVersion, error) {
+	if err := validateSchedulingAlgorithm(schedulingAlgorithm); err != nil {
+		return nil, err
+	}
+	if versionNumber < 1 {
+		return nil, ErrInvalidPatternVersionNumber
+	}
+	versionSteps := make([]*PatternStep, len(steps))
+	for i, s := range steps {
+		versionSteps[i] = &PatternStep{
+			patternStepID: uuid.NewString(),
+			userID:        userID,
+			patternID:     patternID,
+			stepNumber:    s.stepNumber,
+			intervalDays:  s.intervalDays,
+			intervalUnit:  s.intervalUnit,
+		}
+	}
+	return &PatternVersion{
+		patternVersionID:    patternVersionID,
+		userID:              userID,
+		patternID:           patternID,
+		versionNumber:       versionNumber,
+		schedulingAlgorithm: schedulingAlgorithm,
+		steps:               versionSteps,
+		createdAt:           createdAt,
+	}, nil
+}
+
+func ReconstructPatternVersion(
+	patternVersionID string,
+	userID string,
+	patternID string,
+	versionNumber int,
+	schedulingAlgorithm string,
+	steps []*PatternStep,
+	createdAt time.Time,
+) (*PatternVersion, error) {
+	return &PatternVersion{
+		patternVersionID:    patternVersionID,
+		userID:              userID,
+		patternID:           patternID,
+		versionNumber:       versionNumber,
+		schedulingAlgorithm: schedulingAlgorithm,
+		steps:               steps,
+		createdAt:           createdAt,
+	}, nil
+}
+
+func (pv *PatternVersion) PatternVersionID() string {
+	return pv.patternVersionID
+}
+
+func (pv *PatternVersion) UserID() string {
+	return pv.userID
+}
+
+func (pv *PatternVersion) PatternID() string {
+	return pv.patternID
+}
+
+func (pv *PatternVersion) VersionNumber() int {
+	return pv.versionNumber
+}
+
+func (pv *PatternVersion) SchedulingAlgorithm() string {
+	return pv.schedulingAlgorithm
+}
+
+func (pv *PatternVersion) IsAdaptive() bool {
+	return pv.schedulingAlgorithm == SchedulingAlgorithmSM2Adaptive
+}
+
+func (pv *PatternVersion) Steps() []*PatternStep {
+	return pv.steps
+}
+
+func (pv *PatternVersion) CreatedAt() time.Time {
+	return pv.createdAt
+}
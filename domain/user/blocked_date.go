@@ -0,0 +1,100 @@
+package user
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// 復習日を置かない日付の名前の最大文字数
+const MaxBlockedDateNameLength = 255
+
+// 1回のリクエストで登録できる復習日を置かない日付の最大件数
+const MaxBlockedDatesPerRequest = 1000
+
+var (
+	ErrBlockedDateBeforeToday = errors.New("復習日を置かない日付は今日以降で指定してください")
+	ErrBlockedDateNameTooLong = errors.New("復習日を置かない日付の名前は255文字以内で指定してください")
+	ErrBlockedDateDuplicated  = errors.New("既に登録されている日付です")
+	ErrBlockedDatesEmpty      = errors.New("復習日を置かない日付が指定されていません")
+	ErrTooManyBlockedDates    = errors.New("一度に登録できる復習日を置かない日付は1000件までです")
+	ErrBlockedDateNotFound    = errors.New("復習日を置かない日付が見つかりません")
+)
+
+// 復習日を置かない日付（祝日や予定のある日など）
+// 復習日の生成時やバッチ処理で復習日をずらす時に、この日付を避ける
+type BlockedDate struct {
+	id     string
+	userID string
+	date   time.Time
+	name   string
+}
+
+func NewBlockedDate(
+	id string,
+	userID string,
+	date time.Time,
+	name string,
+	parsedToday time.Time,
+) (*BlockedDate, error) {
+	if id == "" {
+		return nil, errors.New("復習日を置かない日付のIDが空です")
+	}
+	if userID == "" {
+		return nil, errors.New("ユーザーIDが空です")
+	}
+	if date.Before(parsedToday) {
+		return nil, ErrBlockedDateBeforeToday
+	}
+	if utf8.RuneCountInString(name) > MaxBlockedDateNameLength {
+		return nil, ErrBlockedDateNameTooLong
+	}
+	return &BlockedDate{
+		id:     id,
+		userID: userID,
+		date:   date,
+		name:   name,
+	}, nil
+}
+
+func ReconstructBlockedDate(
+	id string,
+	userID string,
+	date time.Time,
+	name string,
+) (*BlockedDate, error) {
+	return &BlockedDate{
+		id:     id,
+		userID: userID,
+		date:   date,
+		name:   name,
+	}, nil
+}
+
+func (b *BlockedDate) Update(date time.Time, name string, parsedToday time.Time) error {
+	if date.Before(parsedToday) {
+		return ErrBlockedDateBeforeToday
+	}
+	if utf8.RuneCountInString(name) > MaxBlockedDateNameLength {
+		return ErrBlockedDateNameTooLong
+	}
+	b.date = date
+	b.name = name
+	return nil
+}
+
+func (b *BlockedDate) ID() string {
+	return b.id
+}
+
+func (b *BlockedDate) UserID() string {
+	return b.userID
+}
+
+func (b *BlockedDate) Date() time.Time {
+	return b.date
+}
+
+func (b *BlockedDate) Name() string {
+	return b.name
+}
@@ -0,0 +1,17 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+type BlockedDateRepository interface {
+	Create(ctx context.Context, b *BlockedDate) error
+	GetByUserID(ctx context.Context, userID string) ([]*BlockedDate, error)
+	// 見つからない場合はErrBlockedDateNotFound
+	GetByID(ctx context.Context, id string, userID string) (*BlockedDate, error)
+	Update(ctx context.Context, b *BlockedDate) error
+	Delete(ctx context.Context, id string, userID string) error
+	// datesにあるユーザーの未完了の復習日を、次の復習日を置ける日にずらして、ずらした件数を返す
+	MoveReviewDatesOffBlockedDates(ctx context.Context, userID string, dates []time.Time) (int64, error)
+}
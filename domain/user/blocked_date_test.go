@@ -0,0 +1,108 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewBlockedDate(t *testing.T) {
+	parsedToday := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		id      string
+		userID  string
+		date    time.Time
+		label   string
+		wantErr error
+	}{
+		{
+			name:   "有効な入力（正常系）",
+			id:     "blocked-date-id",
+			userID: "user-id",
+			date:   time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			label:  "成人の日",
+		},
+		{
+			name:   "名前が空（正常系）",
+			id:     "blocked-date-id",
+			userID: "user-id",
+			date:   time.Date(2024, 2, 11, 0, 0, 0, 0, time.UTC),
+			label:  "",
+		},
+		{
+			name:    "日付が今日より前（異常系）",
+			id:      "blocked-date-id",
+			userID:  "user-id",
+			date:    time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			wantErr: ErrBlockedDateBeforeToday,
+		},
+		{
+			name:    "名前が長すぎる（異常系）",
+			id:      "blocked-date-id",
+			userID:  "user-id",
+			date:    time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			label:   strings.Repeat("あ", MaxBlockedDateNameLength+1),
+			wantErr: ErrBlockedDateNameTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := NewBlockedDate(tt.id, tt.userID, tt.date, tt.label, parsedToday)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("NewBlockedDate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewBlockedDate() unexpected error = %v", err)
+			}
+			if !b.Date().Equal(tt.date) || b.Name() != tt.label {
+				t.Errorf("NewBlockedDate() = (%v, %v), want (%v, %v)", b.Date(), b.Name(), tt.date, tt.label)
+			}
+		})
+	}
+}
+
+func TestBlockedDate_Update(t *testing.T) {
+	parsedToday := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	original := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		label    string
+		wantDate time.Time
+		wantErr  error
+	}{
+		{
+			name:     "有効な入力（正常系）",
+			date:     time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC),
+			label:    "振替休日",
+			wantDate: time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "日付が今日より前（異常系）",
+			date:     time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			wantDate: original,
+			wantErr:  ErrBlockedDateBeforeToday,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := ReconstructBlockedDate("blocked-date-id", "user-id", original, "")
+			err := b.Update(tt.date, tt.label, parsedToday)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+			if !b.Date().Equal(tt.wantDate) {
+				t.Errorf("Date() = %v, want %v", b.Date(), tt.wantDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package user
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 1つの予定から展開する日数の上限
+const MaxICSEventDays = 366
+
+var (
+	ErrInvalidICS      = errors.New("iCalendarファイルの形式が正しくありません")
+	ErrICSEventTooLong = errors.New("iCalendarファイルに366日を超える予定が含まれています")
+)
+
+// iCalendarファイルの予定（VEVENT）から取り出した日付
+type ICSDate struct {
+	Date time.Time
+	Name string
+}
+
+// iCalendarファイル（RFC 5545）の予定（VEVENT）を、予定のある日付の一覧にする
+// 終日の予定はDTENDの前日まで（DTENDがない場合はDTSTARTの1日のみ）、時刻付きの予定はDTSTARTからDTENDの日付までとする
+// 時刻付きの予定のタイムゾーンは変換せず、記載された日付をそのまま使う
+// 繰り返し（RRULE）とDURATIONには対応しない（DTSTARTの日付のみ）
+// 同じ日付に複数の予定がある場合は最初の予定の名前を使う。戻り値は日付順
+func ParseICS(r io.Reader) ([]ICSDate, error) {
+	lines, err := unfoldICSLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	isCalendar := false
+	inEvent := false
+	var summary, dtstart, dtend string
+	var dtstartIsDate, dtendIsDate bool
+	names := make(map[string]string)
+	var dates []time.Time
+	for _, line := range lines {
+		name, params, value, ok := splitICSProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			isCalendar = true
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			inEvent = true
+			summary, dtstart, dtend = "", "", ""
+			dtstartIsDate, dtendIsDate = false, false
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if !inEvent {
+				return nil, ErrInvalidICS
+			}
+			inEvent = false
+			eventDates, err := expandICSEvent(dtstart, dtstartIsDate, dtend, dtendIsDate)
+			if err != nil {
+				return nil, err
+			}
+			for _, d := range eventDates {
+				key := d.Format("2006-01-02")
+				if _, ok := names[key]; ok {
+					continue
+				}
+				names[key] = summary
+				dates = append(dates, d)
+			}
+		case inEvent && name == "SUMMARY":
+			summary = unescapeICSText(value)
+		case inEvent && name == "DTSTART":
+			dtstart = value
+			dtstartIsDate = isICSDateParam(params)
+		case inEvent && name == "DTEND":
+			dtend = value
+			dtendIsDate = isICSDateParam(params)
+		}
+	}
+	if !isCalendar || inEvent {
+		return nil, ErrInvalidICS
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+	result := make([]ICSDate, len(dates))
+	for i, d := range dates {
+		result[i] = ICSDate{
+			Date: d,
+			Name: names[d.Format("2006-01-02")],
+		}
+	}
+	return result, nil
+}
+
+// 折り返された行（空白またはタブで始まる行）を前の行に連結する
+func unfoldICSLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, ErrInvalidICS
+	}
+	return lines, nil
+}
+
+// "NAME;PARAM=...:VALUE"の形式の行を、名前とパラメータと値に分ける
+// パラメータの値はダブルクォートで囲まれている場合にコロンを含むことがある
+func splitICSProperty(line string) (string, string, string, bool) {
+	inQuote := false
+	for i, c := range line {
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+		case c == ':' && !inQuote:
+			nameAndParams := line[:i]
+			name, params, _ := strings.Cut(nameAndParams, ";")
+			return strings.ToUpper(name), params, line[i+1:], true
+		}
+	}
+	return "", "", "", false
+}
+
+// パラメータに値の型としてDATEが指定されているか
+func isICSDateParam(params string) bool {
+	for _, param := range strings.Split(params, ";") {
+		if strings.EqualFold(param, "VALUE=DATE") {
+			return true
+		}
+	}
+	return false
+}
+
+// DATE（20240101）またはDATE-TIME（20240101T090000、20240101T090000Z）の値を日付と時刻に分けて読む
+func parseICSDateValue(value string) (time.Time, time.Duration, error) {
+	if len(value) < 8 {
+		return time.Time{}, 0, ErrInvalidICS
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidICS
+	}
+	rest := strings.TrimSuffix(value[8:], "Z")
+	if rest == "" {
+		return date, 0, nil
+	}
+	clock, err := time.Parse("T150405", rest)
+	if err != nil {
+		return time.Time{}, 0, ErrInvalidICS
+	}
+	return date, clock.Sub(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)), nil
+}
+
+// 予定の開始と終了から、予定のある日付の一覧を返す
+func expandICSEvent(dtstart string, dtstartIsDate bool, dtend string, dtendIsDate bool) ([]time.Time, error) {
+	if dtstart == "" {
+		return nil, ErrInvalidICS
+	}
+	startDate, _, err := parseICSDateValue(dtstart)
+	if err != nil {
+		return nil, err
+	}
+	isAllDay := dtstartIsDate || len(dtstart) == 8
+
+	lastDate := startDate
+	if dtend != "" {
+		endDate, endClock, err := parseICSDateValue(dtend)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case isAllDay || dtendIsDate:
+			// 終日の予定のDTENDは予定の翌日
+			lastDate = endDate.AddDate(0, 0, -1)
+		case endClock == 0:
+			// 0時ちょうどに終わる予定は前日までとする
+			lastDate = endDate.AddDate(0, 0, -1)
+		default:
+			lastDate = endDate
+		}
+		if lastDate.Before(startDate) {
+			lastDate = startDate
+		}
+	}
+
+	days := int(lastDate.Sub(startDate).Hours()/24) + 1
+	if days > MaxICSEventDays {
+		return nil, ErrICSEventTooLong
+	}
+	dates := make([]time.Time, days)
+	for i := range dates {
+		dates[i] = startDate.AddDate(0, 0, i)
+	}
+	return dates, nil
+}
+
+// TEXT型の値のエスケープ（\\ \; \, \n \N）を戻す。改行は空白にする
+func unescapeICSText(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c != '\\' || i+1 >= len(value) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte(' ')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,125 @@
+package user
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseICS(t *testing.T) {
+	date := func(m time.Month, d int) time.Time {
+		return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		ics     string
+		want    []ICSDate
+		wantErr error
+	}{
+		{
+			name: "終日の予定（正常系）",
+			ics: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20240101\r\n" +
+				"DTEND;VALUE=DATE:20240102\r\n" +
+				"SUMMARY:元日\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []ICSDate{{Date: date(1, 1), Name: "元日"}},
+		},
+		{
+			name: "複数日の終日の予定はDTENDの前日まで（正常系）",
+			ics: "BEGIN:VCALENDAR\n" +
+				"BEGIN:VEVENT\n" +
+				"DTSTART;VALUE=DATE:20240503\n" +
+				"DTEND;VALUE=DATE:20240506\n" +
+				"SUMMARY:連休\n" +
+				"END:VEVENT\n" +
+				"END:VCALENDAR\n",
+			want: []ICSDate{
+				{Date: date(5, 3), Name: "連休"},
+				{Date: date(5, 4), Name: "連休"},
+				{Date: date(5, 5), Name: "連休"},
+			},
+		},
+		{
+			name: "DTENDがない予定と時刻付きの予定（正常系）",
+			ics: "BEGIN:VCALENDAR\n" +
+				"BEGIN:VEVENT\n" +
+				"DTSTART;TZID=Asia/Tokyo:20240212T090000\n" +
+				"DTEND;TZID=Asia/Tokyo:20240212T180000\n" +
+				"SUMMARY:出張\n" +
+				"END:VEVENT\n" +
+				"BEGIN:VEVENT\n" +
+				"DTSTART:20240211\n" +
+				"SUMMARY:建国記念の日\n" +
+				"END:VEVENT\n" +
+				"END:VCALENDAR\n",
+			want: []ICSDate{
+				{Date: date(2, 11), Name: "建国記念の日"},
+				{Date: date(2, 12), Name: "出張"},
+			},
+		},
+		{
+			name: "折り返し行とエスケープと重複（正常系）",
+			ics: "BEGIN:VCALENDAR\n" +
+				"BEGIN:VEVENT\n" +
+				"DTSTART;VALUE=DATE:20240101\n" +
+				"SUMMARY:New Year\\, \n" +
+				" Holiday\n" +
+				"END:VEVENT\n" +
+				"BEGIN:VEVENT\n" +
+				"DTSTART;VALUE=DATE:20240101\n" +
+				"SUMMARY:重複\n" +
+				"END:VEVENT\n" +
+				"END:VCALENDAR\n",
+			want: []ICSDate{{Date: date(1, 1), Name: "New Year, Holiday"}},
+		},
+		{
+			name:    "VCALENDARがない（異常系）",
+			ics:     "BEGIN:VEVENT\nDTSTART:20240101\nEND:VEVENT\n",
+			wantErr: ErrInvalidICS,
+		},
+		{
+			name:    "DTSTARTがない（異常系）",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:予定\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: ErrInvalidICS,
+		},
+		{
+			name:    "日付の形式が正しくない（異常系）",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2024-01-01\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: ErrInvalidICS,
+		},
+		{
+			name:    "予定が長すぎる（異常系）",
+			ics:     "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20240101\nDTEND;VALUE=DATE:20250103\nEND:VEVENT\nEND:VCALENDAR\n",
+			wantErr: ErrICSEventTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseICS(strings.NewReader(tt.ics))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ParseICS() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseICS() unexpected error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseICS() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Date.Equal(tt.want[i].Date) || got[i].Name != tt.want[i].Name {
+					t.Errorf("ParseICS()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: domain/user/pause_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=domain/user/pause_repository.go -destination=domain/user/mock_pause_repository.go -package user
+//
+
+// Package user is a generated GoMock package.
+package user
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPauseRepository is a mock of PauseRepository interface.
+type MockPauseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPauseRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPauseRepositoryMockRecorder is the mock recorder for MockPauseRepository.
+type MockPauseRepositoryMockRecorder struct {
+	mock *MockPauseRepository
+}
+
+// NewMockPauseRepository creates a new mock instance.
+func NewMockPauseRepository(ctrl *gomock.Controller) *MockPauseRepository {
+	mock := &MockPauseRepository{ctrl: ctrl}
+	mock.recorder = &MockPauseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPauseRepository) EXPECT() *MockPauseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPauseRepository) Create(ctx context.Context, p *Pause) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPauseRepositoryMockRecorder) Create(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPauseRepository)(nil).Create), ctx, p)
+}
+
+// HasOverlappingPause mocks base method.
+func (m *MockPauseRepository) HasOverlappingPause(ctx context.Context, userID string, startDate, endDate time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOverlappingPause", ctx, userID, startDate, endDate)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOverlappingPause indicates an expected call of HasOverlappingPause.
+func (mr *MockPauseRepositoryMockRecorder) HasOverlappingPause(ctx, userID, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOverlappingPause", reflect.TypeOf((*MockPauseRepository)(nil).HasOverlappingPause), ctx, userID, startDate, endDate)
+}
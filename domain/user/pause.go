@@ -0,0 +1,77 @@
+package user
+
+import (
+	"errors"
+	"time"
+)
+
+// 1回の休止期間の最大日数
+const MaxPauseDays = 366
+
+var (
+	ErrPauseStartDateBeforeToday = errors.New("休止期間の開始日は今日以降で指定してください")
+	ErrPauseEndDateBeforeStart   = errors.New("休止期間の終了日は開始日以降で指定してください")
+	ErrPauseTooLong              = errors.New("休止期間は366日以内で指定してください")
+	ErrPauseOverlapped           = errors.New("既存の休止期間と重なっています")
+)
+
+// 休止期間（開始日と終了日を含む）
+// 期間中はバッチ処理で期限切れの復習日をずらさず、期間の終了後に未完了の復習日を休止日数分まとめてずらす
+type Pause struct {
+	id        string
+	userID    string
+	startDate time.Time
+	endDate   time.Time
+}
+
+func NewPause(
+	id string,
+	userID string,
+	startDate time.Time,
+	endDate time.Time,
+	parsedToday time.Time,
+) (*Pause, error) {
+	if id == "" {
+		return nil, errors.New("休止期間IDが空です")
+	}
+	if userID == "" {
+		return nil, errors.New("ユーザーIDが空です")
+	}
+	if startDate.Before(parsedToday) {
+		return nil, ErrPauseStartDateBeforeToday
+	}
+	if endDate.Before(startDate) {
+		return nil, ErrPauseEndDateBeforeStart
+	}
+	p := &Pause{
+		id:        id,
+		userID:    userID,
+		startDate: startDate,
+		endDate:   endDate,
+	}
+	if p.Days() > MaxPauseDays {
+		return nil, ErrPauseTooLong
+	}
+	return p, nil
+}
+
+func (p *Pause) ID() string {
+	return p.id
+}
+
+func (p *Pause) UserID() string {
+	return p.userID
+}
+
+func (p *Pause) StartDate() time.Time {
+	return p.startDate
+}
+
+func (p *Pause) EndDate() time.Time {
+	return p.endDate
+}
+
+// 休止日数。期間終了後に未完了の復習日をこの日数分ずらす
+func (p *Pause) Days() int {
+	return int(p.endDate.Sub(p.startDate).Hours()/24) + 1
+}
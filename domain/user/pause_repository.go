@@ -0,0 +1,11 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+type PauseRepository interface {
+	Create(ctx context.Context, p *Pause) error
+	HasOverlappingPause(ctx context.Context, userID string, startDate time.Time, endDate time.Time) (bool, error)
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewPause(t *testing.T) {
+	parsedToday := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		id        string
+		userID    string
+		startDate time.Time
+		endDate   time.Time
+		wantDays  int
+		wantErr   error
+	}{
+		{
+			name:      "有効な入力（正常系）",
+			id:        "pause-id",
+			userID:    "user-id",
+			startDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+			wantDays:  7,
+		},
+		{
+			name:      "開始日と終了日が同じ（正常系）",
+			id:        "pause-id",
+			userID:    "user-id",
+			startDate: time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC),
+			wantDays:  1,
+		},
+		{
+			name:      "開始日が今日より前（異常系）",
+			id:        "pause-id",
+			userID:    "user-id",
+			startDate: time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+			wantErr:   ErrPauseStartDateBeforeToday,
+		},
+		{
+			name:      "終了日が開始日より前（異常系）",
+			id:        "pause-id",
+			userID:    "user-id",
+			startDate: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			wantErr:   ErrPauseEndDateBeforeStart,
+		},
+		{
+			name:      "休止期間が長すぎる（異常系）",
+			id:        "pause-id",
+			userID:    "user-id",
+			startDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			wantErr:   ErrPauseTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPause(tt.id, tt.userID, tt.startDate, tt.endDate, parsedToday)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("NewPause() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewPause() unexpected error = %v", err)
+			}
+			if p.Days() != tt.wantDays {
+				t.Errorf("Days() = %v, want %v", p.Days(), tt.wantDays)
+			}
+		})
+	}
+}
@@ -17,6 +17,9 @@ type User struct {
 	timezone          string
 	themeColor        string
 	language          string
+	dailyReviewLimit  int
+	excludedWeekdays  []int
+	overduePolicy     string
 	verifiedAt        *time.Time
 }
 
@@ -99,15 +102,21 @@ func ReconstructUserForSettings(
 	timezone string,
 	themeColor string,
 	language string,
+	dailyReviewLimit int,
+	excludedWeekdays []int,
+	overduePolicy string,
 	verifiedAt *time.Time,
 ) (*User, error) {
 	u := &User{
-		id:             id,
-		encryptedEmail: encryptedEmail,
-		timezone:       timezone,
-		themeColor:     themeColor,
-		language:       language,
-		verifiedAt:     verifiedAt,
+		id:               id,
+		encryptedEmail:   encryptedEmail,
+		timezone:         timezone,
+		themeColor:       themeColor,
+		language:         language,
+		dailyReviewLimit: dailyReviewLimit,
+		excludedWeekdays: excludedWeekdays,
+		overduePolicy:    overduePolicy,
+		verifiedAt:       verifiedAt,
 	}
 	return u, nil
 }
@@ -140,6 +149,21 @@ func (u *User) Language() string {
 	return u.language
 }
 
+// 1日あたりの復習数の上限。0の場合は上限なし
+func (u *User) DailyReviewLimit() int {
+	return u.dailyReviewLimit
+}
+
+// 全てのパターンで復習日を置かない曜日（0が日曜日、6が土曜日）
+func (u *User) ExcludedWeekdays() []int {
+	return u.excludedWeekdays
+}
+
+// バッチ処理での期限切れの復習日の扱い
+func (u *User) OverduePolicy() string {
+	return u.overduePolicy
+}
+
 func (u *User) VerifiedAt() *time.Time {
 	return u.verifiedAt
 }
@@ -149,6 +173,9 @@ func (u *User) UpdateSetting(
 	timezone string,
 	themeColor string,
 	language string,
+	dailyReviewLimit int,
+	excludedWeekdays []int,
+	overduePolicy string,
 	cryptoService *CryptoService,
 	searchKey string,
 ) error {
@@ -164,6 +191,15 @@ func (u *User) UpdateSetting(
 	if err := validateLanguage(language); err != nil {
 		return err
 	}
+	if err := validateDailyReviewLimit(dailyReviewLimit); err != nil {
+		return err
+	}
+	if err := validateExcludedWeekdays(excludedWeekdays); err != nil {
+		return err
+	}
+	if err := validateOverduePolicy(overduePolicy); err != nil {
+		return err
+	}
 
 	encryptedEmail, err := cryptoService.Encrypt(email)
 	if err != nil {
@@ -175,6 +211,9 @@ func (u *User) UpdateSetting(
 	u.timezone = timezone
 	u.themeColor = themeColor
 	u.language = language
+	u.dailyReviewLimit = dailyReviewLimit
+	u.excludedWeekdays = excludedWeekdays
+	u.overduePolicy = overduePolicy
 
 	return nil
 }
@@ -221,6 +260,12 @@ const (
 	// 言語
 	LanguageJa string = "ja"
 	LanguageEn string = "en"
+
+	// 期限切れの復習日の扱い
+	OverduePolicySlideAll         string = "slide_all"           // 期限切れの復習日を今日にずらし、後続の復習日も同じ日数だけずらす
+	OverduePolicySlideOverdueOnly string = "slide_overdue_only"  // 期限切れの復習日だけを今日にずらし、後続の復習日はそのままにする
+	OverduePolicyKeepOverdue      string = "keep_overdue"        // 期限切れの復習日をずらさず、今日の復習日一覧に期限切れとして表示する
+	OverduePolicyResetToFirstStep string = "reset_to_first_step" // 1ステップ目からやり直す
 )
 
 var allowedTimeZones = map[string]struct{}{
@@ -250,6 +295,12 @@ var allowedLanguages = map[string]struct{}{
 	LanguageJa: {},
 	LanguageEn: {},
 }
+var allowedOverduePolicies = map[string]struct{}{
+	OverduePolicySlideAll:         {},
+	OverduePolicySlideOverdueOnly: {},
+	OverduePolicyKeepOverdue:      {},
+	OverduePolicyResetToFirstStep: {},
+}
 
 // パスワードハッシュ化
 func encrypt(plainText string) string {
@@ -330,6 +381,48 @@ func validateLanguage(language string) error {
 	)
 }
 
+func validateDailyReviewLimit(dailyReviewLimit int) error {
+	return validation.Validate(
+		dailyReviewLimit,
+		validation.Min(0).Error("1日の復習数の上限は0以上で指定してください"),
+		validation.Max(32767).Error("1日の復習数の上限は32768件以上は指定できません"),
+	)
+}
+
+func validateExcludedWeekdays(excludedWeekdays []int) error {
+	seen := make(map[int]struct{}, len(excludedWeekdays))
+	for _, d := range excludedWeekdays {
+		if _, ok := seen[d]; ok {
+			return errors.New("除外する曜日が重複しています")
+		}
+		seen[d] = struct{}{}
+	}
+	if len(seen) == 7 {
+		return errors.New("全ての曜日を除外することはできません")
+	}
+	return validation.Validate(
+		excludedWeekdays,
+		validation.Each(
+			validation.Min(0).Error("除外する曜日は0（日曜日）から6（土曜日）で指定してください"),
+			validation.Max(6).Error("除外する曜日は0（日曜日）から6（土曜日）で指定してください"),
+		),
+	)
+}
+
+func validateOverduePolicy(overduePolicy string) error {
+	return validation.Validate(
+		overduePolicy,
+		validation.Required.Error("期限切れの復習日の扱いは必須です"),
+		validation.By(func(value interface{}) error {
+			policy, _ := value.(string)
+			if _, ok := allowedOverduePolicies[policy]; !ok {
+				return errors.New("期限切れの復習日の扱いの値が不正です")
+			}
+			return nil
+		}),
+	)
+}
+
 // 認証済みかを確認
 func (u *User) IsVerified() bool {
 	return u.verifiedAt != nil
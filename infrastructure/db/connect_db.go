@@ -49,7 +49,10 @@ func NewDB(ctx context.Context) (*pgxpool.Pool, error) {
 // context.WithValue用のキーの型
 type ctxKey string
 
-const queriesKey ctxKey = "queries"
+const (
+	queriesKey ctxKey = "queries"
+	connKey    ctxKey = "conn"
+)
 
 // contextにトランザクション用の*dbgen.Queriesがあるならそれを返す。
 // なければグローバルに初期化された*dbgen.Queriesを返す
@@ -73,3 +76,18 @@ func getQueriesWithContext(ctx context.Context) *dbgen.Queries {
 func WithQueries(ctx context.Context, q *dbgen.Queries) context.Context {
 	return context.WithValue(ctx, queriesKey, q)
 }
+
+// ctxに接続をセットして返す。アドバイザリロックを保持する接続など、決まった接続でクエリとトランザクションを実行したい場合に使う
+func WithConn(ctx context.Context, conn *pgxpool.Conn) context.Context {
+	ctx = context.WithValue(ctx, connKey, conn)
+	return WithQueries(ctx, dbgen.New(conn))
+}
+
+// contextに接続があるならそれを返す。なければnilを返す
+func GetConn(ctx context.Context) *pgxpool.Conn {
+	conn, ok := ctx.Value(connKey).(*pgxpool.Conn)
+	if !ok {
+		return nil
+	}
+	return conn
+}
@@ -134,6 +134,69 @@ func (q *Queries) GetAllBoxesByCategoryID(ctx context.Context, arg GetAllBoxesBy
 	return items, nil
 }
 
+const getAllBoxesByPatternID = `-- name: GetAllBoxesByPatternID :many
+SELECT
+    id,
+    user_id,
+    category_id,
+    pattern_id,
+    name,
+    registered_at,
+    edited_at
+FROM
+    review_boxes
+WHERE
+    pattern_id = $1
+AND
+    user_id = $2
+ORDER BY
+    registered_at
+`
+
+type GetAllBoxesByPatternIDParams struct {
+	PatternID pgtype.UUID `json:"pattern_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type GetAllBoxesByPatternIDRow struct {
+	ID           pgtype.UUID        `json:"id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	CategoryID   pgtype.UUID        `json:"category_id"`
+	PatternID    pgtype.UUID        `json:"pattern_id"`
+	Name         string             `json:"name"`
+	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
+	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+}
+
+// パターンの使用箇所の一覧用。パターンを使うボックスを取得
+func (q *Queries) GetAllBoxesByPatternID(ctx context.Context, arg GetAllBoxesByPatternIDParams) ([]GetAllBoxesByPatternIDRow, error) {
+	rows, err := q.db.Query(ctx, getAllBoxesByPatternID, arg.PatternID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAllBoxesByPatternIDRow{}
+	for rows.Next() {
+		var i GetAllBoxesByPatternIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.PatternID,
+			&i.Name,
+			&i.RegisteredAt,
+			&i.EditedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getBoxByID = `-- name: GetBoxByID :one
 SELECT
     id,
@@ -252,7 +315,7 @@ func (q *Queries) UpdateBox(ctx context.Context, arg UpdateBoxParams) error {
 	return err
 }
 
-const updateBoxIfNoReviewItems = `-- name: UpdateBoxIfNoReviewItems :execrows
+const updateBoxWithPatternID = `-- name: UpdateBoxWithPatternID :execrows
 UPDATE
     review_boxes
 SET
@@ -265,37 +328,59 @@ AND
     review_boxes.category_id = $5
 AND
     review_boxes.user_id = $6
-AND NOT EXISTS (
-            SELECT 
-                1
-            FROM 
-                review_items
-            WHERE 
-                review_items.box_id  = $7
-            AND 
-                review_items.user_id = $6
-            )
 `
 
-type UpdateBoxIfNoReviewItemsParams struct {
+type UpdateBoxWithPatternIDParams struct {
 	PatternID  pgtype.UUID        `json:"pattern_id"`
 	Name       string             `json:"name"`
 	EditedAt   pgtype.Timestamptz `json:"edited_at"`
 	ID         pgtype.UUID        `json:"id"`
 	CategoryID pgtype.UUID        `json:"category_id"`
 	UserID     pgtype.UUID        `json:"user_id"`
-	BoxID      pgtype.UUID        `json:"box_id"`
 }
 
-func (q *Queries) UpdateBoxIfNoReviewItems(ctx context.Context, arg UpdateBoxIfNoReviewItemsParams) (int64, error) {
-	result, err := q.db.Exec(ctx, updateBoxIfNoReviewItems,
+// ボックスのパターン変更。ボックス内の復習物の復習日の組み直しは呼び出し側で同一トランザクションで行う
+func (q *Queries) UpdateBoxWithPatternID(ctx context.Context, arg UpdateBoxWithPatternIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateBoxWithPatternID,
 		arg.PatternID,
 		arg.Name,
 		arg.EditedAt,
 		arg.ID,
 		arg.CategoryID,
 		arg.UserID,
-		arg.BoxID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateBoxesPatternIDByPatternID = `-- name: UpdateBoxesPatternIDByPatternID :execrows
+UPDATE
+    review_boxes
+SET
+    pattern_id = $1,
+    edited_at  = $2
+WHERE
+    pattern_id = $3
+AND
+    user_id = $4
+`
+
+type UpdateBoxesPatternIDByPatternIDParams struct {
+	NewPatternID pgtype.UUID        `json:"new_pattern_id"`
+	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	PatternID    pgtype.UUID        `json:"pattern_id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+}
+
+// パターンの削除時に、パターンを使うボックスを別のパターンに付け替える
+func (q *Queries) UpdateBoxesPatternIDByPatternID(ctx context.Context, arg UpdateBoxesPatternIDByPatternIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateBoxesPatternIDByPatternID,
+		arg.NewPatternID,
+		arg.EditedAt,
+		arg.PatternID,
+		arg.UserID,
 	)
 	if err != nil {
 		return 0, err
@@ -34,6 +34,7 @@ func (r iteratorForCreatePatternSteps) Values() ([]interface{}, error) {
 		r.rows[0].PatternID,
 		r.rows[0].StepNumber,
 		r.rows[0].IntervalDays,
+		r.rows[0].IntervalUnit,
 	}, nil
 }
 
@@ -43,7 +44,44 @@ func (r iteratorForCreatePatternSteps) Err() error {
 
 // 新規一括挿入時と、一括更新時に使う
 func (q *Queries) CreatePatternSteps(ctx context.Context, arg []CreatePatternStepsParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"pattern_steps"}, []string{"id", "user_id", "pattern_id", "step_number", "interval_days"}, &iteratorForCreatePatternSteps{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"pattern_steps"}, []string{"id", "user_id", "pattern_id", "step_number", "interval_days", "interval_unit"}, &iteratorForCreatePatternSteps{rows: arg})
+}
+
+// iteratorForCreatePatternVersionSteps implements pgx.CopyFromSource.
+type iteratorForCreatePatternVersionSteps struct {
+	rows                 []CreatePatternVersionStepsParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForCreatePatternVersionSteps) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForCreatePatternVersionSteps) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].ID,
+		r.rows[0].UserID,
+		r.rows[0].PatternVersionID,
+		r.rows[0].StepNumber,
+		r.rows[0].IntervalDays,
+		r.rows[0].IntervalUnit,
+	}, nil
+}
+
+func (r iteratorForCreatePatternVersionSteps) Err() error {
+	return nil
+}
+
+func (q *Queries) CreatePatternVersionSteps(ctx context.Context, arg []CreatePatternVersionStepsParams) (int64, error) {
+	return q.db.CopyFrom(ctx, []string{"pattern_version_steps"}, []string{"id", "user_id", "pattern_version_id", "step_number", "interval_days", "interval_unit"}, &iteratorForCreatePatternVersionSteps{rows: arg})
 }
 
 // iteratorForCreateReviewDates implements pgx.CopyFromSource.
@@ -75,6 +113,7 @@ func (r iteratorForCreateReviewDates) Values() ([]interface{}, error) {
 		r.rows[0].InitialScheduledDate,
 		r.rows[0].ScheduledDate,
 		r.rows[0].IsCompleted,
+		r.rows[0].ScheduledAt,
 	}, nil
 }
 
@@ -84,5 +123,5 @@ func (r iteratorForCreateReviewDates) Err() error {
 
 // 新規一括挿入時と、一括更新時に使う
 func (q *Queries) CreateReviewDates(ctx context.Context, arg []CreateReviewDatesParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"review_dates"}, []string{"id", "user_id", "category_id", "box_id", "item_id", "step_number", "initial_scheduled_date", "scheduled_date", "is_completed"}, &iteratorForCreateReviewDates{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"review_dates"}, []string{"id", "user_id", "category_id", "box_id", "item_id", "step_number", "initial_scheduled_date", "scheduled_date", "is_completed", "scheduled_at"}, &iteratorForCreateReviewDates{rows: arg})
 }
@@ -15,11 +15,40 @@ const countAllDailyReviewDates = `-- name: CountAllDailyReviewDates :one
 SELECT
     COUNT(*) AS count
 FROM
-    review_dates
+    review_dates rd
+JOIN
+    users u
+ON
+    u.id = rd.user_id
 WHERE
-    user_id = $1
-AND
-    scheduled_date = $2
+    rd.user_id = $1
+AND (
+        rd.scheduled_date = $2
+    OR (
+            u.overdue_policy = 'keep_overdue'
+        AND
+            rd.is_completed = false
+        AND
+            rd.scheduled_at IS NULL
+        AND
+            rd.scheduled_date < $2
+        AND
+            NOT EXISTS (
+                SELECT
+                    1
+                FROM
+                    review_dates e
+                WHERE
+                    e.item_id = rd.item_id
+                AND
+                    e.is_completed = false
+                AND
+                    e.scheduled_at IS NULL
+                AND
+                    e.scheduled_date < rd.scheduled_date
+            )
+    )
+)
 `
 
 type CountAllDailyReviewDatesParams struct {
@@ -28,6 +57,7 @@ type CountAllDailyReviewDatesParams struct {
 }
 
 // 今日の全復習日数を取得
+// 期限切れの復習日の扱いがkeep_overdueのユーザーは、今日の復習日一覧に含める期限切れの復習日も数える
 func (q *Queries) CountAllDailyReviewDates(ctx context.Context, arg CountAllDailyReviewDatesParams) (int64, error) {
 	row := q.db.QueryRow(ctx, countAllDailyReviewDates, arg.UserID, arg.TargetDate)
 	var count int64
@@ -174,6 +204,125 @@ func (q *Queries) CountDailyDatesUnclassifiedGroupedByCategoryByUserID(ctx conte
 	return items, nil
 }
 
+const countForecastDatesGroupedByBoxByUserID = `-- name: CountForecastDatesGroupedByBoxByUserID :many
+SELECT
+    scheduled_date,
+    category_id,
+    box_id,
+    COUNT(*) AS count
+FROM
+    review_dates
+WHERE
+    user_id = $1
+AND
+    scheduled_date BETWEEN $2 AND $3
+AND
+    is_completed = false
+AND
+    box_id IS NOT NULL
+GROUP BY
+    scheduled_date,
+    category_id,
+    box_id
+ORDER BY
+    scheduled_date
+`
+
+type CountForecastDatesGroupedByBoxByUserIDParams struct {
+	UserID   pgtype.UUID `json:"user_id"`
+	FromDate pgtype.Date `json:"from_date"`
+	ToDate   pgtype.Date `json:"to_date"`
+}
+
+type CountForecastDatesGroupedByBoxByUserIDRow struct {
+	ScheduledDate pgtype.Date `json:"scheduled_date"`
+	CategoryID    pgtype.UUID `json:"category_id"`
+	BoxID         pgtype.UUID `json:"box_id"`
+	Count         int64       `json:"count"`
+}
+
+// 復習量の予測系
+// 指定期間の未完了の復習日数を、復習日とボックス毎に集計
+func (q *Queries) CountForecastDatesGroupedByBoxByUserID(ctx context.Context, arg CountForecastDatesGroupedByBoxByUserIDParams) ([]CountForecastDatesGroupedByBoxByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, countForecastDatesGroupedByBoxByUserID, arg.UserID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountForecastDatesGroupedByBoxByUserIDRow{}
+	for rows.Next() {
+		var i CountForecastDatesGroupedByBoxByUserIDRow
+		if err := rows.Scan(
+			&i.ScheduledDate,
+			&i.CategoryID,
+			&i.BoxID,
+			&i.Count,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countForecastDatesUnclassifiedGroupedByCategoryByUserID = `-- name: CountForecastDatesUnclassifiedGroupedByCategoryByUserID :many
+SELECT
+    scheduled_date,
+    category_id,
+    COUNT(*) AS count
+FROM
+    review_dates
+WHERE
+    user_id = $1
+AND
+    scheduled_date BETWEEN $2 AND $3
+AND
+    is_completed = false
+AND
+    box_id IS NULL
+GROUP BY
+    scheduled_date,
+    category_id
+ORDER BY
+    scheduled_date
+`
+
+type CountForecastDatesUnclassifiedGroupedByCategoryByUserIDParams struct {
+	UserID   pgtype.UUID `json:"user_id"`
+	FromDate pgtype.Date `json:"from_date"`
+	ToDate   pgtype.Date `json:"to_date"`
+}
+
+type CountForecastDatesUnclassifiedGroupedByCategoryByUserIDRow struct {
+	ScheduledDate pgtype.Date `json:"scheduled_date"`
+	CategoryID    pgtype.UUID `json:"category_id"`
+	Count         int64       `json:"count"`
+}
+
+// 指定期間の未分類の未完了の復習日数を、復習日とカテゴリー毎に集計（category_idがNULLのものはホーム画面の未分類）
+func (q *Queries) CountForecastDatesUnclassifiedGroupedByCategoryByUserID(ctx context.Context, arg CountForecastDatesUnclassifiedGroupedByCategoryByUserIDParams) ([]CountForecastDatesUnclassifiedGroupedByCategoryByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, countForecastDatesUnclassifiedGroupedByCategoryByUserID, arg.UserID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountForecastDatesUnclassifiedGroupedByCategoryByUserIDRow{}
+	for rows.Next() {
+		var i CountForecastDatesUnclassifiedGroupedByCategoryByUserIDRow
+		if err := rows.Scan(&i.ScheduledDate, &i.CategoryID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countItemsGroupedByBoxByUserID = `-- name: CountItemsGroupedByBoxByUserID :many
 
 SELECT
@@ -218,6 +367,59 @@ func (q *Queries) CountItemsGroupedByBoxByUserID(ctx context.Context, userID pgt
 	return items, nil
 }
 
+const countScheduledDatesGroupedByDateByUserID = `-- name: CountScheduledDatesGroupedByDateByUserID :many
+SELECT
+    scheduled_date,
+    COUNT(*) AS count
+FROM
+    review_dates
+WHERE
+    user_id = $1
+AND
+    scheduled_date BETWEEN $2 AND $3
+AND
+    is_completed = false
+AND
+    scheduled_at IS NULL
+GROUP BY
+    scheduled_date
+ORDER BY
+    scheduled_date
+`
+
+type CountScheduledDatesGroupedByDateByUserIDParams struct {
+	UserID   pgtype.UUID `json:"user_id"`
+	FromDate pgtype.Date `json:"from_date"`
+	ToDate   pgtype.Date `json:"to_date"`
+}
+
+type CountScheduledDatesGroupedByDateByUserIDRow struct {
+	ScheduledDate pgtype.Date `json:"scheduled_date"`
+	Count         int64       `json:"count"`
+}
+
+// 復習日の負荷分散用
+// 指定期間の未完了の復習日数（分・時間単位のステップの復習日を除く）を、ボックスやカテゴリーを区別せず復習日毎に集計
+func (q *Queries) CountScheduledDatesGroupedByDateByUserID(ctx context.Context, arg CountScheduledDatesGroupedByDateByUserIDParams) ([]CountScheduledDatesGroupedByDateByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, countScheduledDatesGroupedByDateByUserID, arg.UserID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountScheduledDatesGroupedByDateByUserIDRow{}
+	for rows.Next() {
+		var i CountScheduledDatesGroupedByDateByUserIDRow
+		if err := rows.Scan(&i.ScheduledDate, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countUnclassifiedItemsByUserID = `-- name: CountUnclassifiedItemsByUserID :many
 SELECT
     COUNT(*) AS count
@@ -300,6 +502,7 @@ INSERT INTO
         category_id,
         box_id,
         pattern_id,
+        pattern_version_id,
         name,
         detail,
         learned_date,
@@ -313,6 +516,17 @@ VALUES (
     $3,
     $4,
     $5,
+    (
+        SELECT
+            pv.id
+        FROM
+            pattern_versions pv
+        WHERE
+            pv.pattern_id = $5
+        ORDER BY
+            pv.version_number DESC
+        LIMIT 1
+    ),
     $6,
     $7,
     $8,
@@ -336,6 +550,7 @@ type CreateItemParams struct {
 	EditedAt     pgtype.Timestamptz `json:"edited_at"`
 }
 
+// パターンの版は、パターンの最新の版（復習日の算出に使った現在のステップの版）を割り当てる
 func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) error {
 	_, err := q.db.Exec(ctx, createItem,
 		arg.ID,
@@ -354,15 +569,42 @@ func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) error {
 }
 
 type CreateReviewDatesParams struct {
-	ID                   pgtype.UUID `json:"id"`
-	UserID               pgtype.UUID `json:"user_id"`
-	CategoryID           pgtype.UUID `json:"category_id"`
-	BoxID                pgtype.UUID `json:"box_id"`
-	ItemID               pgtype.UUID `json:"item_id"`
-	StepNumber           int16       `json:"step_number"`
-	InitialScheduledDate pgtype.Date `json:"initial_scheduled_date"`
-	ScheduledDate        pgtype.Date `json:"scheduled_date"`
-	IsCompleted          bool        `json:"is_completed"`
+	ID                   pgtype.UUID        `json:"id"`
+	UserID               pgtype.UUID        `json:"user_id"`
+	CategoryID           pgtype.UUID        `json:"category_id"`
+	BoxID                pgtype.UUID        `json:"box_id"`
+	ItemID               pgtype.UUID        `json:"item_id"`
+	StepNumber           int16              `json:"step_number"`
+	InitialScheduledDate pgtype.Date        `json:"initial_scheduled_date"`
+	ScheduledDate        pgtype.Date        `json:"scheduled_date"`
+	IsCompleted          bool               `json:"is_completed"`
+	ScheduledAt          pgtype.Timestamptz `json:"scheduled_at"`
+}
+
+const deleteInCompletedReviewDatesAfterStep = `-- name: DeleteInCompletedReviewDatesAfterStep :exec
+DELETE
+FROM
+    review_dates
+WHERE
+    item_id = $1
+AND
+    user_id = $2
+AND
+    step_number > $3
+AND
+    is_completed = false
+`
+
+type DeleteInCompletedReviewDatesAfterStepParams struct {
+	ItemID     pgtype.UUID `json:"item_id"`
+	UserID     pgtype.UUID `json:"user_id"`
+	StepNumber int16       `json:"step_number"`
+}
+
+// 適応型の復習日の完了を取り消したときに、生成済みの次の復習日を削除する
+func (q *Queries) DeleteInCompletedReviewDatesAfterStep(ctx context.Context, arg DeleteInCompletedReviewDatesAfterStepParams) error {
+	_, err := q.db.Exec(ctx, deleteInCompletedReviewDatesAfterStep, arg.ItemID, arg.UserID, arg.StepNumber)
+	return err
 }
 
 const deleteItem = `-- name: DeleteItem :exec
@@ -417,6 +659,7 @@ SELECT
     rd.scheduled_date,
     rd.next_scheduled_date,
     rd.is_completed,
+    (rd.scheduled_date < $2::date) AS is_overdue,
     ri.id AS item_id,
     ri.name,
     ri.detail,
@@ -432,6 +675,7 @@ FROM (
         step_number,
         initial_scheduled_date,
         scheduled_date,
+        scheduled_at,
         is_completed,
         CAST(
             LAG(scheduled_date) OVER (
@@ -444,7 +688,12 @@ FROM (
         PARTITION BY item_id
         ORDER BY step_number
         ) AS date
-        ) AS next_scheduled_date
+        ) AS next_scheduled_date,
+        CAST(
+            MIN(scheduled_date) FILTER (WHERE is_completed = false AND scheduled_at IS NULL) OVER (
+        PARTITION BY item_id
+        ) AS date
+        ) AS first_incompleted_date
     FROM
         review_dates
     WHERE
@@ -454,8 +703,23 @@ JOIN
     review_items AS ri
 ON
     ri.id = rd.item_id
+JOIN
+    users AS u
+ON
+    u.id = ri.user_id
 WHERE
     rd.scheduled_date = $2::date
+OR (
+        u.overdue_policy = 'keep_overdue'
+    AND
+        rd.is_completed = false
+    AND
+        rd.scheduled_at IS NULL
+    AND
+        rd.scheduled_date < $2::date
+    AND
+        rd.scheduled_date = rd.first_incompleted_date
+)
 ORDER BY
     rd.category_id    NULLS LAST,
     rd.box_id         NULLS LAST,
@@ -477,6 +741,7 @@ type GetAllDailyReviewDatesRow struct {
 	ScheduledDate        pgtype.Date        `json:"scheduled_date"`
 	NextScheduledDate    pgtype.Date        `json:"next_scheduled_date"`
 	IsCompleted          bool               `json:"is_completed"`
+	IsOverdue            bool               `json:"is_overdue"`
 	ItemID               pgtype.UUID        `json:"item_id"`
 	Name                 string             `json:"name"`
 	Detail               pgtype.Text        `json:"detail"`
@@ -488,6 +753,7 @@ type GetAllDailyReviewDatesRow struct {
 // LAG→item_idごとにstep_numberの昇順で並べた時、scheduled_dateが持つstep_numberより一個前のstep_numberのscheduled_dateを取得
 // LEAD→item_idごとにstep_numberの昇順で並べた時、scheduled_dateが持つstep_numberより一個後のstep_numberのscheduled_dateを取得
 // 今日の復習日を取得するクエリ
+// 期限切れの復習日の扱いがkeep_overdueのユーザーは、復習物毎に最初の未完了の期限切れの復習日も期限切れとして含める（分・時間単位のステップの復習日は除く）
 func (q *Queries) GetAllDailyReviewDates(ctx context.Context, arg GetAllDailyReviewDatesParams) ([]GetAllDailyReviewDatesRow, error) {
 	rows, err := q.db.Query(ctx, getAllDailyReviewDates, arg.UserID, arg.Today)
 	if err != nil {
@@ -507,6 +773,7 @@ func (q *Queries) GetAllDailyReviewDates(ctx context.Context, arg GetAllDailyRev
 			&i.ScheduledDate,
 			&i.NextScheduledDate,
 			&i.IsCompleted,
+			&i.IsOverdue,
 			&i.ItemID,
 			&i.Name,
 			&i.Detail,
@@ -524,6 +791,84 @@ func (q *Queries) GetAllDailyReviewDates(ctx context.Context, arg GetAllDailyRev
 	return items, nil
 }
 
+const getAllItemsByPatternID = `-- name: GetAllItemsByPatternID :many
+SELECT
+    id,
+    user_id,
+    category_id,
+    box_id,
+    pattern_id,
+    pattern_version_id,
+    name,
+    detail,
+    learned_date,
+    is_Finished,
+    registered_at,
+    edited_at
+FROM
+    review_items
+WHERE
+    pattern_id = $1
+AND
+    user_id = $2
+ORDER BY
+    registered_at
+`
+
+type GetAllItemsByPatternIDParams struct {
+	PatternID pgtype.UUID `json:"pattern_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type GetAllItemsByPatternIDRow struct {
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
+}
+
+// パターンの使用箇所の一覧用。パターンを使う復習物（完了済みを含む）を取得
+func (q *Queries) GetAllItemsByPatternID(ctx context.Context, arg GetAllItemsByPatternIDParams) ([]GetAllItemsByPatternIDRow, error) {
+	rows, err := q.db.Query(ctx, getAllItemsByPatternID, arg.PatternID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAllItemsByPatternIDRow{}
+	for rows.Next() {
+		var i GetAllItemsByPatternIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.BoxID,
+			&i.PatternID,
+			&i.PatternVersionID,
+			&i.Name,
+			&i.Detail,
+			&i.LearnedDate,
+			&i.IsFinished,
+			&i.RegisteredAt,
+			&i.EditedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllReviewDatesByBoxID = `-- name: GetAllReviewDatesByBoxID :many
 SELECT
     id,
@@ -534,7 +879,9 @@ SELECT
     step_number,
     initial_scheduled_date,
     scheduled_date,
-    is_completed
+    is_completed,
+    recall_grade,
+    scheduled_at
 FROM
     review_dates
 WHERE
@@ -552,15 +899,17 @@ type GetAllReviewDatesByBoxIDParams struct {
 }
 
 type GetAllReviewDatesByBoxIDRow struct {
-	ID                   pgtype.UUID `json:"id"`
-	UserID               pgtype.UUID `json:"user_id"`
-	CategoryID           pgtype.UUID `json:"category_id"`
-	BoxID                pgtype.UUID `json:"box_id"`
-	ItemID               pgtype.UUID `json:"item_id"`
-	StepNumber           int16       `json:"step_number"`
-	InitialScheduledDate pgtype.Date `json:"initial_scheduled_date"`
-	ScheduledDate        pgtype.Date `json:"scheduled_date"`
-	IsCompleted          bool        `json:"is_completed"`
+	ID                   pgtype.UUID         `json:"id"`
+	UserID               pgtype.UUID         `json:"user_id"`
+	CategoryID           pgtype.UUID         `json:"category_id"`
+	BoxID                pgtype.UUID         `json:"box_id"`
+	ItemID               pgtype.UUID         `json:"item_id"`
+	StepNumber           int16               `json:"step_number"`
+	InitialScheduledDate pgtype.Date         `json:"initial_scheduled_date"`
+	ScheduledDate        pgtype.Date         `json:"scheduled_date"`
+	IsCompleted          bool                `json:"is_completed"`
+	RecallGrade          NullRecallGradeEnum `json:"recall_grade"`
+	ScheduledAt          pgtype.Timestamptz  `json:"scheduled_at"`
 }
 
 // 　ボックス内画面用の全復習物一覧取得機能（復習日（子）のみ一覧取得（親は区別しない。親が未完了復習物かどうかも区別しない））。
@@ -583,6 +932,8 @@ func (q *Queries) GetAllReviewDatesByBoxID(ctx context.Context, arg GetAllReview
 			&i.InitialScheduledDate,
 			&i.ScheduledDate,
 			&i.IsCompleted,
+			&i.RecallGrade,
+			&i.ScheduledAt,
 		); err != nil {
 			return nil, err
 		}
@@ -601,6 +952,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -625,17 +977,18 @@ type GetAllUnFinishedItemsByBoxIDParams struct {
 }
 
 type GetAllUnFinishedItemsByBoxIDRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	CategoryID   pgtype.UUID        `json:"category_id"`
-	BoxID        pgtype.UUID        `json:"box_id"`
-	PatternID    pgtype.UUID        `json:"pattern_id"`
-	Name         string             `json:"name"`
-	Detail       pgtype.Text        `json:"detail"`
-	LearnedDate  pgtype.Date        `json:"learned_date"`
-	IsFinished   bool               `json:"is_finished"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
 }
 
 // ボックス内画面用の未完了の全復習物一覧取得機能（復習物（親）のみ一覧取得）
@@ -654,6 +1007,87 @@ func (q *Queries) GetAllUnFinishedItemsByBoxID(ctx context.Context, arg GetAllUn
 			&i.CategoryID,
 			&i.BoxID,
 			&i.PatternID,
+			&i.PatternVersionID,
+			&i.Name,
+			&i.Detail,
+			&i.LearnedDate,
+			&i.IsFinished,
+			&i.RegisteredAt,
+			&i.EditedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllUnFinishedItemsByPatternID = `-- name: GetAllUnFinishedItemsByPatternID :many
+SELECT
+    id,
+    user_id,
+    category_id,
+    box_id,
+    pattern_id,
+    pattern_version_id,
+    name,
+    detail,
+    learned_date,
+    is_Finished,
+    registered_at,
+    edited_at
+FROM
+    review_items
+WHERE
+    pattern_id = $1
+AND
+    is_Finished = false
+AND
+    user_id = $2
+ORDER BY
+    registered_at
+`
+
+type GetAllUnFinishedItemsByPatternIDParams struct {
+	PatternID pgtype.UUID `json:"pattern_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type GetAllUnFinishedItemsByPatternIDRow struct {
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
+}
+
+// パターンのステップ変更時に、既存の復習日を組み直す対象の復習物を取得
+func (q *Queries) GetAllUnFinishedItemsByPatternID(ctx context.Context, arg GetAllUnFinishedItemsByPatternIDParams) ([]GetAllUnFinishedItemsByPatternIDRow, error) {
+	rows, err := q.db.Query(ctx, getAllUnFinishedItemsByPatternID, arg.PatternID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAllUnFinishedItemsByPatternIDRow{}
+	for rows.Next() {
+		var i GetAllUnFinishedItemsByPatternIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.BoxID,
+			&i.PatternID,
+			&i.PatternVersionID,
 			&i.Name,
 			&i.Detail,
 			&i.LearnedDate,
@@ -678,6 +1112,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -703,20 +1138,21 @@ type GetAllUnFinishedUnclassifiedItemsByCategoryIDParams struct {
 	UserID     pgtype.UUID `json:"user_id"`
 }
 
-type GetAllUnFinishedUnclassifiedItemsByCategoryIDRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	CategoryID   pgtype.UUID        `json:"category_id"`
-	BoxID        pgtype.UUID        `json:"box_id"`
-	PatternID    pgtype.UUID        `json:"pattern_id"`
-	Name         string             `json:"name"`
-	Detail       pgtype.Text        `json:"detail"`
-	LearnedDate  pgtype.Date        `json:"learned_date"`
-	IsFinished   bool               `json:"is_finished"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
-}
-
+type GetAllUnFinishedUnclassifiedItemsByCategoryIDRow struct {
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
+}
+
 func (q *Queries) GetAllUnFinishedUnclassifiedItemsByCategoryID(ctx context.Context, arg GetAllUnFinishedUnclassifiedItemsByCategoryIDParams) ([]GetAllUnFinishedUnclassifiedItemsByCategoryIDRow, error) {
 	rows, err := q.db.Query(ctx, getAllUnFinishedUnclassifiedItemsByCategoryID, arg.CategoryID, arg.UserID)
 	if err != nil {
@@ -732,6 +1168,7 @@ func (q *Queries) GetAllUnFinishedUnclassifiedItemsByCategoryID(ctx context.Cont
 			&i.CategoryID,
 			&i.BoxID,
 			&i.PatternID,
+			&i.PatternVersionID,
 			&i.Name,
 			&i.Detail,
 			&i.LearnedDate,
@@ -756,6 +1193,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -777,17 +1215,18 @@ ORDER BY
 `
 
 type GetAllUnFinishedUnclassifiedItemsByUserIDRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	CategoryID   pgtype.UUID        `json:"category_id"`
-	BoxID        pgtype.UUID        `json:"box_id"`
-	PatternID    pgtype.UUID        `json:"pattern_id"`
-	Name         string             `json:"name"`
-	Detail       pgtype.Text        `json:"detail"`
-	LearnedDate  pgtype.Date        `json:"learned_date"`
-	IsFinished   bool               `json:"is_finished"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
 }
 
 // ホーム画面の未分類未完了復習物
@@ -806,6 +1245,7 @@ func (q *Queries) GetAllUnFinishedUnclassifiedItemsByUserID(ctx context.Context,
 			&i.CategoryID,
 			&i.BoxID,
 			&i.PatternID,
+			&i.PatternVersionID,
 			&i.Name,
 			&i.Detail,
 			&i.LearnedDate,
@@ -833,7 +1273,9 @@ SELECT
     step_number,
     initial_scheduled_date,
     scheduled_date,
-    is_completed
+    is_completed,
+    recall_grade,
+    scheduled_at
 FROM
     review_dates
 WHERE
@@ -853,15 +1295,17 @@ type GetAllUnclassifiedReviewDatesByCategoryIDParams struct {
 }
 
 type GetAllUnclassifiedReviewDatesByCategoryIDRow struct {
-	ID                   pgtype.UUID `json:"id"`
-	UserID               pgtype.UUID `json:"user_id"`
-	CategoryID           pgtype.UUID `json:"category_id"`
-	BoxID                pgtype.UUID `json:"box_id"`
-	ItemID               pgtype.UUID `json:"item_id"`
-	StepNumber           int16       `json:"step_number"`
-	InitialScheduledDate pgtype.Date `json:"initial_scheduled_date"`
-	ScheduledDate        pgtype.Date `json:"scheduled_date"`
-	IsCompleted          bool        `json:"is_completed"`
+	ID                   pgtype.UUID         `json:"id"`
+	UserID               pgtype.UUID         `json:"user_id"`
+	CategoryID           pgtype.UUID         `json:"category_id"`
+	BoxID                pgtype.UUID         `json:"box_id"`
+	ItemID               pgtype.UUID         `json:"item_id"`
+	StepNumber           int16               `json:"step_number"`
+	InitialScheduledDate pgtype.Date         `json:"initial_scheduled_date"`
+	ScheduledDate        pgtype.Date         `json:"scheduled_date"`
+	IsCompleted          bool                `json:"is_completed"`
+	RecallGrade          NullRecallGradeEnum `json:"recall_grade"`
+	ScheduledAt          pgtype.Timestamptz  `json:"scheduled_at"`
 }
 
 func (q *Queries) GetAllUnclassifiedReviewDatesByCategoryID(ctx context.Context, arg GetAllUnclassifiedReviewDatesByCategoryIDParams) ([]GetAllUnclassifiedReviewDatesByCategoryIDRow, error) {
@@ -883,6 +1327,8 @@ func (q *Queries) GetAllUnclassifiedReviewDatesByCategoryID(ctx context.Context,
 			&i.InitialScheduledDate,
 			&i.ScheduledDate,
 			&i.IsCompleted,
+			&i.RecallGrade,
+			&i.ScheduledAt,
 		); err != nil {
 			return nil, err
 		}
@@ -904,7 +1350,9 @@ SELECT
     step_number,
     initial_scheduled_date,
     scheduled_date,
-    is_completed
+    is_completed,
+    recall_grade,
+    scheduled_at
 FROM
     review_dates
 WHERE
@@ -919,15 +1367,17 @@ ORDER BY
 `
 
 type GetAllUnclassifiedReviewDatesByUserIDRow struct {
-	ID                   pgtype.UUID `json:"id"`
-	UserID               pgtype.UUID `json:"user_id"`
-	CategoryID           pgtype.UUID `json:"category_id"`
-	BoxID                pgtype.UUID `json:"box_id"`
-	ItemID               pgtype.UUID `json:"item_id"`
-	StepNumber           int16       `json:"step_number"`
-	InitialScheduledDate pgtype.Date `json:"initial_scheduled_date"`
-	ScheduledDate        pgtype.Date `json:"scheduled_date"`
-	IsCompleted          bool        `json:"is_completed"`
+	ID                   pgtype.UUID         `json:"id"`
+	UserID               pgtype.UUID         `json:"user_id"`
+	CategoryID           pgtype.UUID         `json:"category_id"`
+	BoxID                pgtype.UUID         `json:"box_id"`
+	ItemID               pgtype.UUID         `json:"item_id"`
+	StepNumber           int16               `json:"step_number"`
+	InitialScheduledDate pgtype.Date         `json:"initial_scheduled_date"`
+	ScheduledDate        pgtype.Date         `json:"scheduled_date"`
+	IsCompleted          bool                `json:"is_completed"`
+	RecallGrade          NullRecallGradeEnum `json:"recall_grade"`
+	ScheduledAt          pgtype.Timestamptz  `json:"scheduled_at"`
 }
 
 func (q *Queries) GetAllUnclassifiedReviewDatesByUserID(ctx context.Context, userID pgtype.UUID) ([]GetAllUnclassifiedReviewDatesByUserIDRow, error) {
@@ -949,6 +1399,106 @@ func (q *Queries) GetAllUnclassifiedReviewDatesByUserID(ctx context.Context, use
 			&i.InitialScheduledDate,
 			&i.ScheduledDate,
 			&i.IsCompleted,
+			&i.RecallGrade,
+			&i.ScheduledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyReviewLimitByUserID = `-- name: GetDailyReviewLimitByUserID :one
+SELECT
+    daily_review_limit
+FROM
+    users
+WHERE
+    id = $1
+`
+
+// 1日あたりの復習数の上限を超える復習日の繰り越し用
+func (q *Queries) GetDailyReviewLimitByUserID(ctx context.Context, userID pgtype.UUID) (int16, error) {
+	row := q.db.QueryRow(ctx, getDailyReviewLimitByUserID, userID)
+	var daily_review_limit int16
+	err := row.Scan(&daily_review_limit)
+	return daily_review_limit, err
+}
+
+const getDueNowReviewDates = `-- name: GetDueNowReviewDates :many
+SELECT
+    rd.id,
+    rd.category_id,
+    rd.box_id,
+    rd.step_number,
+    rd.scheduled_date,
+    rd.scheduled_at,
+    ri.id AS item_id,
+    ri.name,
+    ri.detail,
+    ri.learned_date
+FROM
+    review_dates AS rd
+JOIN
+    review_items AS ri
+ON
+    ri.id = rd.item_id
+WHERE
+    rd.user_id = $1::uuid
+AND
+    rd.is_completed = false
+AND
+    rd.scheduled_at <= $2::timestamptz
+AND
+    ri.is_finished = false
+ORDER BY
+    rd.scheduled_at,
+    ri.registered_at
+`
+
+type GetDueNowReviewDatesParams struct {
+	UserID pgtype.UUID        `json:"user_id"`
+	Now    pgtype.Timestamptz `json:"now"`
+}
+
+type GetDueNowReviewDatesRow struct {
+	ID            pgtype.UUID        `json:"id"`
+	CategoryID    pgtype.UUID        `json:"category_id"`
+	BoxID         pgtype.UUID        `json:"box_id"`
+	StepNumber    int16              `json:"step_number"`
+	ScheduledDate pgtype.Date        `json:"scheduled_date"`
+	ScheduledAt   pgtype.Timestamptz `json:"scheduled_at"`
+	ItemID        pgtype.UUID        `json:"item_id"`
+	Name          string             `json:"name"`
+	Detail        pgtype.Text        `json:"detail"`
+	LearnedDate   pgtype.Date        `json:"learned_date"`
+}
+
+// 分・時間単位のステップのうち、復習日時を過ぎた未完了の復習日を取得するクエリ
+func (q *Queries) GetDueNowReviewDates(ctx context.Context, arg GetDueNowReviewDatesParams) ([]GetDueNowReviewDatesRow, error) {
+	rows, err := q.db.Query(ctx, getDueNowReviewDates, arg.UserID, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDueNowReviewDatesRow{}
+	for rows.Next() {
+		var i GetDueNowReviewDatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CategoryID,
+			&i.BoxID,
+			&i.StepNumber,
+			&i.ScheduledDate,
+			&i.ScheduledAt,
+			&i.ItemID,
+			&i.Name,
+			&i.Detail,
+			&i.LearnedDate,
 		); err != nil {
 			return nil, err
 		}
@@ -984,6 +1534,23 @@ func (q *Queries) GetEditedAtByItemID(ctx context.Context, arg GetEditedAtByItem
 	return edited_at, err
 }
 
+const getExcludedWeekdaysByUserID = `-- name: GetExcludedWeekdaysByUserID :one
+SELECT
+    excluded_weekdays
+FROM
+    users
+WHERE
+    id = $1
+`
+
+// 復習日を置かない曜日のユーザー設定（パターンの設定と合わせて使う）
+func (q *Queries) GetExcludedWeekdaysByUserID(ctx context.Context, userID pgtype.UUID) ([]int16, error) {
+	row := q.db.QueryRow(ctx, getExcludedWeekdaysByUserID, userID)
+	var excluded_weekdays []int16
+	err := row.Scan(&excluded_weekdays)
+	return excluded_weekdays, err
+}
+
 const getFinishedItemsByBoxID = `-- name: GetFinishedItemsByBoxID :many
 SELECT
     id,
@@ -991,6 +1558,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -1015,17 +1583,18 @@ type GetFinishedItemsByBoxIDParams struct {
 }
 
 type GetFinishedItemsByBoxIDRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	CategoryID   pgtype.UUID        `json:"category_id"`
-	BoxID        pgtype.UUID        `json:"box_id"`
-	PatternID    pgtype.UUID        `json:"pattern_id"`
-	Name         string             `json:"name"`
-	Detail       pgtype.Text        `json:"detail"`
-	LearnedDate  pgtype.Date        `json:"learned_date"`
-	IsFinished   bool               `json:"is_finished"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
 }
 
 // ボックス内画面用の完了の全復習物一覧取得系（復習物（親）のみ一覧取得）
@@ -1044,6 +1613,7 @@ func (q *Queries) GetFinishedItemsByBoxID(ctx context.Context, arg GetFinishedIt
 			&i.CategoryID,
 			&i.BoxID,
 			&i.PatternID,
+			&i.PatternVersionID,
 			&i.Name,
 			&i.Detail,
 			&i.LearnedDate,
@@ -1068,6 +1638,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -1088,17 +1659,18 @@ type GetItemByIDParams struct {
 }
 
 type GetItemByIDRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	CategoryID   pgtype.UUID        `json:"category_id"`
-	BoxID        pgtype.UUID        `json:"box_id"`
-	PatternID    pgtype.UUID        `json:"pattern_id"`
-	Name         string             `json:"name"`
-	Detail       pgtype.Text        `json:"detail"`
-	LearnedDate  pgtype.Date        `json:"learned_date"`
-	IsFinished   bool               `json:"is_finished"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
 }
 
 // 学習日変更など、どういうリクエストなのかを判定するために使う
@@ -1111,6 +1683,7 @@ func (q *Queries) GetItemByID(ctx context.Context, arg GetItemByIDParams) (GetIt
 		&i.CategoryID,
 		&i.BoxID,
 		&i.PatternID,
+		&i.PatternVersionID,
 		&i.Name,
 		&i.Detail,
 		&i.LearnedDate,
@@ -1170,7 +1743,9 @@ SELECT
     step_number,
     initial_scheduled_date,
     scheduled_date,
-    is_completed
+    is_completed,
+    recall_grade,
+    scheduled_at
 FROM
     review_dates
 WHERE
@@ -1187,15 +1762,17 @@ type GetReviewDatesByItemIDParams struct {
 }
 
 type GetReviewDatesByItemIDRow struct {
-	ID                   pgtype.UUID `json:"id"`
-	UserID               pgtype.UUID `json:"user_id"`
-	CategoryID           pgtype.UUID `json:"category_id"`
-	BoxID                pgtype.UUID `json:"box_id"`
-	ItemID               pgtype.UUID `json:"item_id"`
-	StepNumber           int16       `json:"step_number"`
-	InitialScheduledDate pgtype.Date `json:"initial_scheduled_date"`
-	ScheduledDate        pgtype.Date `json:"scheduled_date"`
-	IsCompleted          bool        `json:"is_completed"`
+	ID                   pgtype.UUID         `json:"id"`
+	UserID               pgtype.UUID         `json:"user_id"`
+	CategoryID           pgtype.UUID         `json:"category_id"`
+	BoxID                pgtype.UUID         `json:"box_id"`
+	ItemID               pgtype.UUID         `json:"item_id"`
+	StepNumber           int16               `json:"step_number"`
+	InitialScheduledDate pgtype.Date         `json:"initial_scheduled_date"`
+	ScheduledDate        pgtype.Date         `json:"scheduled_date"`
+	IsCompleted          bool                `json:"is_completed"`
+	RecallGrade          NullRecallGradeEnum `json:"recall_grade"`
+	ScheduledAt          pgtype.Timestamptz  `json:"scheduled_at"`
 }
 
 func (q *Queries) GetReviewDatesByItemID(ctx context.Context, arg GetReviewDatesByItemIDParams) ([]GetReviewDatesByItemIDRow, error) {
@@ -1217,6 +1794,8 @@ func (q *Queries) GetReviewDatesByItemID(ctx context.Context, arg GetReviewDates
 			&i.InitialScheduledDate,
 			&i.ScheduledDate,
 			&i.IsCompleted,
+			&i.RecallGrade,
+			&i.ScheduledAt,
 		); err != nil {
 			return nil, err
 		}
@@ -1235,6 +1814,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -1261,17 +1841,18 @@ type GetUnclassfiedFinishedItemsByCategoryIDParams struct {
 }
 
 type GetUnclassfiedFinishedItemsByCategoryIDRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	CategoryID   pgtype.UUID        `json:"category_id"`
-	BoxID        pgtype.UUID        `json:"box_id"`
-	PatternID    pgtype.UUID        `json:"pattern_id"`
-	Name         string             `json:"name"`
-	Detail       pgtype.Text        `json:"detail"`
-	LearnedDate  pgtype.Date        `json:"learned_date"`
-	IsFinished   bool               `json:"is_finished"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
 }
 
 func (q *Queries) GetUnclassfiedFinishedItemsByCategoryID(ctx context.Context, arg GetUnclassfiedFinishedItemsByCategoryIDParams) ([]GetUnclassfiedFinishedItemsByCategoryIDRow, error) {
@@ -1289,6 +1870,7 @@ func (q *Queries) GetUnclassfiedFinishedItemsByCategoryID(ctx context.Context, a
 			&i.CategoryID,
 			&i.BoxID,
 			&i.PatternID,
+			&i.PatternVersionID,
 			&i.Name,
 			&i.Detail,
 			&i.LearnedDate,
@@ -1313,6 +1895,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -1334,17 +1917,18 @@ ORDER BY
 `
 
 type GetUnclassfiedFinishedItemsByUserIDRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	CategoryID   pgtype.UUID        `json:"category_id"`
-	BoxID        pgtype.UUID        `json:"box_id"`
-	PatternID    pgtype.UUID        `json:"pattern_id"`
-	Name         string             `json:"name"`
-	Detail       pgtype.Text        `json:"detail"`
-	LearnedDate  pgtype.Date        `json:"learned_date"`
-	IsFinished   bool               `json:"is_finished"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
 }
 
 func (q *Queries) GetUnclassfiedFinishedItemsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetUnclassfiedFinishedItemsByUserIDRow, error) {
@@ -1362,6 +1946,7 @@ func (q *Queries) GetUnclassfiedFinishedItemsByUserID(ctx context.Context, userI
 			&i.CategoryID,
 			&i.BoxID,
 			&i.PatternID,
+			&i.PatternVersionID,
 			&i.Name,
 			&i.Detail,
 			&i.LearnedDate,
@@ -1432,12 +2017,142 @@ func (q *Queries) IsPatternRelatedToItemByPatternID(ctx context.Context, arg IsP
 	return exists, err
 }
 
+const lockReviewDatesOfUnFinishedItemsByBoxID = `-- name: LockReviewDatesOfUnFinishedItemsByBoxID :exec
+SELECT
+    rd.id
+FROM
+    review_dates rd
+JOIN
+    review_items ri
+ON
+    ri.id = rd.item_id
+WHERE
+    ri.box_id = $1
+AND
+    ri.user_id = $2
+AND
+    ri.is_finished = FALSE
+ORDER BY
+    rd.id
+FOR UPDATE OF rd
+`
+
+type LockReviewDatesOfUnFinishedItemsByBoxIDParams struct {
+	BoxID  pgtype.UUID `json:"box_id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+// ボックスのパターン変更で組み直す間に、復習日の完了や編集で組み直す前の状態が変わらないように、ボックス内の未完了の復習物の復習日と復習物の行をロックする
+// 復習日の完了と同じく、復習日、復習物の順にロックする
+func (q *Queries) LockReviewDatesOfUnFinishedItemsByBoxID(ctx context.Context, arg LockReviewDatesOfUnFinishedItemsByBoxIDParams) error {
+	_, err := q.db.Exec(ctx, lockReviewDatesOfUnFinishedItemsByBoxID, arg.BoxID, arg.UserID)
+	return err
+}
+
+const lockUnFinishedItemsByBoxID = `-- name: LockUnFinishedItemsByBoxID :exec
+SELECT
+    id
+FROM
+    review_items
+WHERE
+    box_id = $1
+AND
+    user_id = $2
+AND
+    is_finished = FALSE
+ORDER BY
+    id
+FOR UPDATE
+`
+
+type LockUnFinishedItemsByBoxIDParams struct {
+	BoxID  pgtype.UUID `json:"box_id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) LockUnFinishedItemsByBoxID(ctx context.Context, arg LockUnFinishedItemsByBoxIDParams) error {
+	_, err := q.db.Exec(ctx, lockUnFinishedItemsByBoxID, arg.BoxID, arg.UserID)
+	return err
+}
+
+const lockReviewDatesOfUnFinishedItemsByPatternID = `-- name: LockReviewDatesOfUnFinishedItemsByPatternID :exec
+SELECT
+    rd.id
+FROM
+    review_dates rd
+JOIN
+    review_items ri
+ON
+    ri.id = rd.item_id
+WHERE
+    ri.pattern_id = $1
+AND
+    ri.user_id = $2
+AND
+    ri.is_finished = FALSE
+ORDER BY
+    rd.id
+FOR UPDATE OF rd
+`
+
+type LockReviewDatesOfUnFinishedItemsByPatternIDParams struct {
+	PatternID pgtype.UUID `json:"pattern_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+// パターンのステップ変更や削除時の付け替えで組み直す間に、復習日の完了や編集で組み直す前の状態が変わらないように、パターンを使う未完了の復習物の復習日と復習物の行をロックする
+// 復習日の完了と同じく、復習日、復習物の順にロックする
+func (q *Queries) LockReviewDatesOfUnFinishedItemsByPatternID(ctx context.Context, arg LockReviewDatesOfUnFinishedItemsByPatternIDParams) error {
+	_, err := q.db.Exec(ctx, lockReviewDatesOfUnFinishedItemsByPatternID, arg.PatternID, arg.UserID)
+	return err
+}
+
+const lockUnFinishedItemsByPatternID = `-- name: LockUnFinishedItemsByPatternID :exec
+SELECT
+    id
+FROM
+    review_items
+WHERE
+    pattern_id = $1
+AND
+    user_id = $2
+AND
+    is_finished = FALSE
+ORDER BY
+    id
+FOR UPDATE
+`
+
+type LockUnFinishedItemsByPatternIDParams struct {
+	PatternID pgtype.UUID `json:"pattern_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) LockUnFinishedItemsByPatternID(ctx context.Context, arg LockUnFinishedItemsByPatternIDParams) error {
+	_, err := q.db.Exec(ctx, lockUnFinishedItemsByPatternID, arg.PatternID, arg.UserID)
+	return err
+}
+
 const updateItem = `-- name: UpdateItem :exec
 UPDATE
     review_items
 SET
     category_id = $1,
     box_id = $2,
+    pattern_version_id = CASE
+        WHEN review_items.pattern_id IS NOT DISTINCT FROM $3 THEN review_items.pattern_version_id
+        ELSE (
+            SELECT
+                pv.id
+            FROM
+                pattern_versions pv
+            WHERE
+                pv.pattern_id = $3
+            ORDER BY
+                pv.version_number DESC
+            LIMIT 1
+        )
+    END,
     pattern_id = $3,
     name = $4,
     detail = $5,
@@ -1464,6 +2179,7 @@ type UpdateItemParams struct {
 }
 
 // 移動、完了、学習日変更、その他編集に使う
+// パターンを変更する場合は、変更後のパターンの最新の版を割り当てる。同じパターンのままの場合は版を変えない
 func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) error {
 	_, err := q.db.Exec(ctx, updateItem,
 		arg.CategoryID,
@@ -1526,24 +2242,125 @@ func (q *Queries) UpdateItemAsUnfinished(ctx context.Context, arg UpdateItemAsUn
 	return err
 }
 
+const updateItemPatternVersionID = `-- name: UpdateItemPatternVersionID :exec
+UPDATE
+    review_items
+SET
+    pattern_version_id = $1
+WHERE
+    id = $2
+AND
+    user_id = $3
+`
+
+type UpdateItemPatternVersionIDParams struct {
+	PatternVersionID pgtype.UUID `json:"pattern_version_id"`
+	ID               pgtype.UUID `json:"id"`
+	UserID           pgtype.UUID `json:"user_id"`
+}
+
+// パターンのステップ変更で復習日を組み直した復習物に、変更後の版を割り当てる
+func (q *Queries) UpdateItemPatternVersionID(ctx context.Context, arg UpdateItemPatternVersionIDParams) error {
+	_, err := q.db.Exec(ctx, updateItemPatternVersionID, arg.PatternVersionID, arg.ID, arg.UserID)
+	return err
+}
+
+const updateItemsPatternIDByBoxID = `-- name: UpdateItemsPatternIDByBoxID :exec
+UPDATE
+    review_items
+SET
+    pattern_id = $1,
+    pattern_version_id = (
+        SELECT
+            pv.id
+        FROM
+            pattern_versions pv
+        WHERE
+            pv.pattern_id = $1
+        ORDER BY
+            pv.version_number DESC
+        LIMIT 1
+    )
+WHERE
+    box_id = $2
+AND
+    user_id = $3
+AND
+    is_finished = FALSE
+`
+
+type UpdateItemsPatternIDByBoxIDParams struct {
+	PatternID pgtype.UUID `json:"pattern_id"`
+	BoxID     pgtype.UUID `json:"box_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+// ボックスのパターン変更時に、ボックス内の未完了の復習物のパターンも変更する
+// 完了済みの復習物は完了した時のパターンと版のまま残す
+// 復習日は変更後のパターンの現在のステップで組み直すため、パターンの最新の版を割り当てる
+func (q *Queries) UpdateItemsPatternIDByBoxID(ctx context.Context, arg UpdateItemsPatternIDByBoxIDParams) error {
+	_, err := q.db.Exec(ctx, updateItemsPatternIDByBoxID, arg.PatternID, arg.BoxID, arg.UserID)
+	return err
+}
+
+const updateItemsPatternIDByPatternID = `-- name: UpdateItemsPatternIDByPatternID :execrows
+UPDATE
+    review_items
+SET
+    pattern_id = $1,
+    pattern_version_id = (
+        SELECT
+            pv.id
+        FROM
+            pattern_versions pv
+        WHERE
+            pv.pattern_id = $1
+        ORDER BY
+            pv.version_number DESC
+        LIMIT 1
+    )
+WHERE
+    pattern_id = $2
+AND
+    user_id = $3
+`
+
+type UpdateItemsPatternIDByPatternIDParams struct {
+	NewPatternID pgtype.UUID `json:"new_pattern_id"`
+	PatternID    pgtype.UUID `json:"pattern_id"`
+	UserID       pgtype.UUID `json:"user_id"`
+}
+
+// パターンの削除時に、パターンを使う復習物（完了済みを含む）を別のパターンに付け替える
+// 未完了の復習日は付け替え先のパターンの現在のステップで組み直すため、付け替え先の最新の版を割り当てる
+func (q *Queries) UpdateItemsPatternIDByPatternID(ctx context.Context, arg UpdateItemsPatternIDByPatternIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateItemsPatternIDByPatternID, arg.NewPatternID, arg.PatternID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateReviewDateAsCompleted = `-- name: UpdateReviewDateAsCompleted :exec
 UPDATE
     review_dates
 SET
-    is_completed = true
+    is_completed = true,
+    recall_grade = $1
 WHERE
-    id = $1
+    id = $2
 AND
-    user_id = $2
+    user_id = $3
 `
 
 type UpdateReviewDateAsCompletedParams struct {
-	ID     pgtype.UUID `json:"id"`
-	UserID pgtype.UUID `json:"user_id"`
+	RecallGrade NullRecallGradeEnum `json:"recall_grade"`
+	ID          pgtype.UUID         `json:"id"`
+	UserID      pgtype.UUID         `json:"user_id"`
 }
 
 func (q *Queries) UpdateReviewDateAsCompleted(ctx context.Context, arg UpdateReviewDateAsCompletedParams) error {
-	_, err := q.db.Exec(ctx, updateReviewDateAsCompleted, arg.ID, arg.UserID)
+	_, err := q.db.Exec(ctx, updateReviewDateAsCompleted, arg.RecallGrade, arg.ID, arg.UserID)
 	return err
 }
 
@@ -1551,7 +2368,8 @@ const updateReviewDateAsInCompleted = `-- name: UpdateReviewDateAsInCompleted :e
 UPDATE
     review_dates
 SET
-    is_completed = false
+    is_completed = false,
+    recall_grade = NULL
 WHERE
     id = $1
 AND
@@ -1575,11 +2393,12 @@ SET
     box_id = v.box_id,
     initial_scheduled_date = v.initial_scheduled_date,
     scheduled_date = v.scheduled_date,
-    is_completed = v.is_completed
+    is_completed = v.is_completed,
+    scheduled_at = v.scheduled_at
 FROM
     UNNEST(
         $2::reviewdate_input[]
-    ) AS v(id, category_id, box_id, initial_scheduled_date, scheduled_date, is_completed)
+    ) AS v(id, category_id, box_id, initial_scheduled_date, scheduled_date, is_completed, scheduled_at)
 WHERE
     r.id = v.id
 AND
@@ -11,6 +11,227 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type BatchRunStatusEnum string
+
+const (
+	BatchRunStatusEnumRunning   BatchRunStatusEnum = "running"
+	BatchRunStatusEnumSucceeded BatchRunStatusEnum = "succeeded"
+	BatchRunStatusEnumFailed    BatchRunStatusEnum = "failed"
+)
+
+func (e *BatchRunStatusEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BatchRunStatusEnum(s)
+	case string:
+		*e = BatchRunStatusEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BatchRunStatusEnum: %T", src)
+	}
+	return nil
+}
+
+type NullBatchRunStatusEnum struct {
+	BatchRunStatusEnum BatchRunStatusEnum `json:"batch_run_status_enum"`
+	Valid              bool               `json:"valid"` // Valid is true if BatchRunStatusEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBatchRunStatusEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.BatchRunStatusEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BatchRunStatusEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBatchRunStatusEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BatchRunStatusEnum), nil
+}
+
+type IntervalUnitEnum string
+
+const (
+	IntervalUnitEnumMinute IntervalUnitEnum = "minute"
+	IntervalUnitEnumHour   IntervalUnitEnum = "hour"
+	IntervalUnitEnumDay    IntervalUnitEnum = "day"
+	IntervalUnitEnumWeek   IntervalUnitEnum = "week"
+	IntervalUnitEnumMonth  IntervalUnitEnum = "month"
+)
+
+func (e *IntervalUnitEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = IntervalUnitEnum(s)
+	case string:
+		*e = IntervalUnitEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for IntervalUnitEnum: %T", src)
+	}
+	return nil
+}
+
+type NullIntervalUnitEnum struct {
+	IntervalUnitEnum IntervalUnitEnum `json:"interval_unit_enum"`
+	Valid            bool             `json:"valid"` // Valid is true if IntervalUnitEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullIntervalUnitEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.IntervalUnitEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.IntervalUnitEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullIntervalUnitEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.IntervalUnitEnum), nil
+}
+
+type OverduePolicyEnum string
+
+const (
+	OverduePolicyEnumSlideAll         OverduePolicyEnum = "slide_all"
+	OverduePolicyEnumSlideOverdueOnly OverduePolicyEnum = "slide_overdue_only"
+	OverduePolicyEnumKeepOverdue      OverduePolicyEnum = "keep_overdue"
+	OverduePolicyEnumResetToFirstStep OverduePolicyEnum = "reset_to_first_step"
+)
+
+func (e *OverduePolicyEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OverduePolicyEnum(s)
+	case string:
+		*e = OverduePolicyEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OverduePolicyEnum: %T", src)
+	}
+	return nil
+}
+
+type NullOverduePolicyEnum struct {
+	OverduePolicyEnum OverduePolicyEnum `json:"overdue_policy_enum"`
+	Valid             bool              `json:"valid"` // Valid is true if OverduePolicyEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOverduePolicyEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.OverduePolicyEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OverduePolicyEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOverduePolicyEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OverduePolicyEnum), nil
+}
+
+type RecallGradeEnum string
+
+const (
+	RecallGradeEnumAgain RecallGradeEnum = "again"
+	RecallGradeEnumHard  RecallGradeEnum = "hard"
+	RecallGradeEnumGood  RecallGradeEnum = "good"
+	RecallGradeEnumEasy  RecallGradeEnum = "easy"
+)
+
+func (e *RecallGradeEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RecallGradeEnum(s)
+	case string:
+		*e = RecallGradeEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RecallGradeEnum: %T", src)
+	}
+	return nil
+}
+
+type NullRecallGradeEnum struct {
+	RecallGradeEnum RecallGradeEnum `json:"recall_grade_enum"`
+	Valid           bool            `json:"valid"` // Valid is true if RecallGradeEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRecallGradeEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.RecallGradeEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RecallGradeEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRecallGradeEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RecallGradeEnum), nil
+}
+
+type SchedulingAlgorithmEnum string
+
+const (
+	SchedulingAlgorithmEnumFixed       SchedulingAlgorithmEnum = "fixed"
+	SchedulingAlgorithmEnumExpanding   SchedulingAlgorithmEnum = "expanding"
+	SchedulingAlgorithmEnumSm2         SchedulingAlgorithmEnum = "sm2"
+	SchedulingAlgorithmEnumFsrs        SchedulingAlgorithmEnum = "fsrs"
+	SchedulingAlgorithmEnumSm2Adaptive SchedulingAlgorithmEnum = "sm2_adaptive"
+)
+
+func (e *SchedulingAlgorithmEnum) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SchedulingAlgorithmEnum(s)
+	case string:
+		*e = SchedulingAlgorithmEnum(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SchedulingAlgorithmEnum: %T", src)
+	}
+	return nil
+}
+
+type NullSchedulingAlgorithmEnum struct {
+	SchedulingAlgorithmEnum SchedulingAlgorithmEnum `json:"scheduling_algorithm_enum"`
+	Valid                   bool                    `json:"valid"` // Valid is true if SchedulingAlgorithmEnum is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSchedulingAlgorithmEnum) Scan(value interface{}) error {
+	if value == nil {
+		ns.SchedulingAlgorithmEnum, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SchedulingAlgorithmEnum.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSchedulingAlgorithmEnum) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SchedulingAlgorithmEnum), nil
+}
+
 type TargetWeightEnum string
 
 const (
@@ -97,6 +318,18 @@ func (ns NullThemeColorEnum) Value() (driver.Value, error) {
 	return string(ns.ThemeColorEnum), nil
 }
 
+type BatchRun struct {
+	ID           pgtype.UUID        `json:"id"`
+	JobName      string             `json:"job_name"`
+	WindowStart  pgtype.Timestamptz `json:"window_start"`
+	CaughtUpFrom pgtype.Timestamptz `json:"caught_up_from"`
+	Status       BatchRunStatusEnum `json:"status"`
+	RowsAffected int64              `json:"rows_affected"`
+	ErrorMessage pgtype.Text        `json:"error_message"`
+	StartedAt    pgtype.Timestamptz `json:"started_at"`
+	FinishedAt   pgtype.Timestamptz `json:"finished_at"`
+}
+
 type Category struct {
 	ID           pgtype.UUID        `json:"id"`
 	UserID       pgtype.UUID        `json:"user_id"`
@@ -124,6 +357,26 @@ type PatternStep struct {
 	IntervalDays int16              `json:"interval_days"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	IntervalUnit IntervalUnitEnum   `json:"interval_unit"`
+}
+
+type PatternVersion struct {
+	ID                  pgtype.UUID             `json:"id"`
+	UserID              pgtype.UUID             `json:"user_id"`
+	PatternID           pgtype.UUID             `json:"pattern_id"`
+	VersionNumber       int32                   `json:"version_number"`
+	SchedulingAlgorithm SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	CreatedAt           pgtype.Timestamptz      `json:"created_at"`
+}
+
+type PatternVersionStep struct {
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+	StepNumber       int16              `json:"step_number"`
+	IntervalDays     int16              `json:"interval_days"`
+	IntervalUnit     IntervalUnitEnum   `json:"interval_unit"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 }
 
 type ReviewBox struct {
@@ -139,55 +392,94 @@ type ReviewBox struct {
 }
 
 type ReviewDate struct {
-	ID                   pgtype.UUID        `json:"id"`
-	UserID               pgtype.UUID        `json:"user_id"`
-	CategoryID           pgtype.UUID        `json:"category_id"`
-	BoxID                pgtype.UUID        `json:"box_id"`
-	ItemID               pgtype.UUID        `json:"item_id"`
-	StepNumber           int16              `json:"step_number"`
-	InitialScheduledDate pgtype.Date        `json:"initial_scheduled_date"`
-	ScheduledDate        pgtype.Date        `json:"scheduled_date"`
-	IsCompleted          bool               `json:"is_completed"`
-	CreatedAt            pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	ID                   pgtype.UUID         `json:"id"`
+	UserID               pgtype.UUID         `json:"user_id"`
+	CategoryID           pgtype.UUID         `json:"category_id"`
+	BoxID                pgtype.UUID         `json:"box_id"`
+	ItemID               pgtype.UUID         `json:"item_id"`
+	StepNumber           int16               `json:"step_number"`
+	InitialScheduledDate pgtype.Date         `json:"initial_scheduled_date"`
+	ScheduledDate        pgtype.Date         `json:"scheduled_date"`
+	IsCompleted          bool                `json:"is_completed"`
+	CreatedAt            pgtype.Timestamptz  `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz  `json:"updated_at"`
+	RecallGrade          NullRecallGradeEnum `json:"recall_grade"`
+	ScheduledAt          pgtype.Timestamptz  `json:"scheduled_at"`
 }
 
 type ReviewItem struct {
-	ID           pgtype.UUID        `json:"id"`
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	CategoryID       pgtype.UUID        `json:"category_id"`
+	BoxID            pgtype.UUID        `json:"box_id"`
+	PatternID        pgtype.UUID        `json:"pattern_id"`
+	Name             string             `json:"name"`
+	Detail           pgtype.Text        `json:"detail"`
+	LearnedDate      pgtype.Date        `json:"learned_date"`
+	IsFinished       bool               `json:"is_finished"`
+	RegisteredAt     pgtype.Timestamptz `json:"registered_at"`
+	EditedAt         pgtype.Timestamptz `json:"edited_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	PatternVersionID pgtype.UUID        `json:"pattern_version_id"`
+}
+
+type ReviewItemSm2State struct {
+	ItemID       pgtype.UUID        `json:"item_id"`
 	UserID       pgtype.UUID        `json:"user_id"`
-	CategoryID   pgtype.UUID        `json:"category_id"`
-	BoxID        pgtype.UUID        `json:"box_id"`
-	PatternID    pgtype.UUID        `json:"pattern_id"`
-	Name         string             `json:"name"`
-	Detail       pgtype.Text        `json:"detail"`
-	LearnedDate  pgtype.Date        `json:"learned_date"`
-	IsFinished   bool               `json:"is_finished"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	EaseFactor   float64            `json:"ease_factor"`
+	Repetitions  int16              `json:"repetitions"`
+	IntervalDays int16              `json:"interval_days"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 }
 
 type ReviewPattern struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	Name         string             `json:"name"`
-	TargetWeight TargetWeightEnum   `json:"target_weight"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	ID                      pgtype.UUID             `json:"id"`
+	UserID                  pgtype.UUID             `json:"user_id"`
+	Name                    string                  `json:"name"`
+	TargetWeight            TargetWeightEnum        `json:"target_weight"`
+	RegisteredAt            pgtype.Timestamptz      `json:"registered_at"`
+	EditedAt                pgtype.Timestamptz      `json:"edited_at"`
+	CreatedAt               pgtype.Timestamptz      `json:"created_at"`
+	UpdatedAt               pgtype.Timestamptz      `json:"updated_at"`
+	SchedulingAlgorithm     SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	IsLoadBalanced          bool                    `json:"is_load_balanced"`
+	ExcludedWeekdays        []int16                 `json:"excluded_weekdays"`
+	MaintenanceIntervalDays int16                   `json:"maintenance_interval_days"`
 }
 
 type User struct {
-	ID             pgtype.UUID        `json:"id"`
-	EmailSearchKey string             `json:"email_search_key"`
-	Email          string             `json:"email"`
-	Password       string             `json:"password"`
-	Timezone       string             `json:"timezone"`
-	ThemeColor     ThemeColorEnum     `json:"theme_color"`
-	Language       string             `json:"language"`
-	VerifiedAt     pgtype.Timestamptz `json:"verified_at"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	ID               pgtype.UUID        `json:"id"`
+	EmailSearchKey   string             `json:"email_search_key"`
+	Email            string             `json:"email"`
+	Password         string             `json:"password"`
+	Timezone         string             `json:"timezone"`
+	ThemeColor       ThemeColorEnum     `json:"theme_color"`
+	Language         string             `json:"language"`
+	VerifiedAt       pgtype.Timestamptz `json:"verified_at"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	DailyReviewLimit int16              `json:"daily_review_limit"`
+	ExcludedWeekdays []int16            `json:"excluded_weekdays"`
+	OverduePolicy    OverduePolicyEnum  `json:"overdue_policy"`
+}
+
+type UserBlockedDate struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	BlockedDate pgtype.Date        `json:"blocked_date"`
+	Name        string             `json:"name"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UserPause struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	StartDate pgtype.Date        `json:"start_date"`
+	EndDate   pgtype.Date        `json:"end_date"`
+	AppliedAt pgtype.Timestamptz `json:"applied_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
@@ -18,6 +18,10 @@ INSERT INTO
         user_id,
         name,
         target_weight,
+        scheduling_algorithm,
+        is_load_balanced,
+        excluded_weekdays,
+        maintenance_interval_days,
         registered_at,
         edited_at
     )
@@ -27,17 +31,25 @@ VALUES (
         $3,
         $4,
         $5,
-        $6
+        $6,
+        $7,
+        $8,
+        $9,
+        $10
     )
 `
 
 type CreatePatternParams struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	Name         string             `json:"name"`
-	TargetWeight TargetWeightEnum   `json:"target_weight"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	ID                      pgtype.UUID             `json:"id"`
+	UserID                  pgtype.UUID             `json:"user_id"`
+	Name                    string                  `json:"name"`
+	TargetWeight            TargetWeightEnum        `json:"target_weight"`
+	SchedulingAlgorithm     SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	IsLoadBalanced          bool                    `json:"is_load_balanced"`
+	ExcludedWeekdays        []int16                 `json:"excluded_weekdays"`
+	MaintenanceIntervalDays int16                   `json:"maintenance_interval_days"`
+	RegisteredAt            pgtype.Timestamptz      `json:"registered_at"`
+	EditedAt                pgtype.Timestamptz      `json:"edited_at"`
 }
 
 func (q *Queries) CreatePattern(ctx context.Context, arg CreatePatternParams) error {
@@ -46,6 +58,10 @@ func (q *Queries) CreatePattern(ctx context.Context, arg CreatePatternParams) er
 		arg.UserID,
 		arg.Name,
 		arg.TargetWeight,
+		arg.SchedulingAlgorithm,
+		arg.IsLoadBalanced,
+		arg.ExcludedWeekdays,
+		arg.MaintenanceIntervalDays,
 		arg.RegisteredAt,
 		arg.EditedAt,
 	)
@@ -53,11 +69,63 @@ func (q *Queries) CreatePattern(ctx context.Context, arg CreatePatternParams) er
 }
 
 type CreatePatternStepsParams struct {
-	ID           pgtype.UUID `json:"id"`
-	UserID       pgtype.UUID `json:"user_id"`
-	PatternID    pgtype.UUID `json:"pattern_id"`
-	StepNumber   int16       `json:"step_number"`
-	IntervalDays int16       `json:"interval_days"`
+	ID           pgtype.UUID      `json:"id"`
+	UserID       pgtype.UUID      `json:"user_id"`
+	PatternID    pgtype.UUID      `json:"pattern_id"`
+	StepNumber   int16            `json:"step_number"`
+	IntervalDays int16            `json:"interval_days"`
+	IntervalUnit IntervalUnitEnum `json:"interval_unit"`
+}
+
+const createPatternVersion = `-- name: CreatePatternVersion :exec
+INSERT INTO
+    pattern_versions (
+        id,
+        user_id,
+        pattern_id,
+        version_number,
+        scheduling_algorithm,
+        created_at
+    )
+VALUES (
+        $1,
+        $2,
+        $3,
+        $4,
+        $5,
+        $6
+    )
+`
+
+type CreatePatternVersionParams struct {
+	ID                  pgtype.UUID             `json:"id"`
+	UserID              pgtype.UUID             `json:"user_id"`
+	PatternID           pgtype.UUID             `json:"pattern_id"`
+	VersionNumber       int32                   `json:"version_number"`
+	SchedulingAlgorithm SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	CreatedAt           pgtype.Timestamptz      `json:"created_at"`
+}
+
+// パターンの作成時と、ステップ（方式）の変更時に版を追加する
+func (q *Queries) CreatePatternVersion(ctx context.Context, arg CreatePatternVersionParams) error {
+	_, err := q.db.Exec(ctx, createPatternVersion,
+		arg.ID,
+		arg.UserID,
+		arg.PatternID,
+		arg.VersionNumber,
+		arg.SchedulingAlgorithm,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+type CreatePatternVersionStepsParams struct {
+	ID               pgtype.UUID      `json:"id"`
+	UserID           pgtype.UUID      `json:"user_id"`
+	PatternVersionID pgtype.UUID      `json:"pattern_version_id"`
+	StepNumber       int16            `json:"step_number"`
+	IntervalDays     int16            `json:"interval_days"`
+	IntervalUnit     IntervalUnitEnum `json:"interval_unit"`
 }
 
 const deletePattern = `-- name: DeletePattern :exec
@@ -107,7 +175,8 @@ SELECT
     user_id,
     pattern_id,
     step_number,
-    interval_days
+    interval_days,
+    interval_unit
 FROM
     pattern_steps
 WHERE
@@ -118,11 +187,12 @@ ORDER BY
 `
 
 type GetAllPatternStepsByUserIDRow struct {
-	ID           pgtype.UUID `json:"id"`
-	UserID       pgtype.UUID `json:"user_id"`
-	PatternID    pgtype.UUID `json:"pattern_id"`
-	StepNumber   int16       `json:"step_number"`
-	IntervalDays int16       `json:"interval_days"`
+	ID           pgtype.UUID      `json:"id"`
+	UserID       pgtype.UUID      `json:"user_id"`
+	PatternID    pgtype.UUID      `json:"pattern_id"`
+	StepNumber   int16            `json:"step_number"`
+	IntervalDays int16            `json:"interval_days"`
+	IntervalUnit IntervalUnitEnum `json:"interval_unit"`
 }
 
 // 　全パターン取得機能（ステップ（子）のみ一覧取得（親は区別しない））
@@ -141,6 +211,7 @@ func (q *Queries) GetAllPatternStepsByUserID(ctx context.Context, userID pgtype.
 			&i.PatternID,
 			&i.StepNumber,
 			&i.IntervalDays,
+			&i.IntervalUnit,
 		); err != nil {
 			return nil, err
 		}
@@ -158,6 +229,10 @@ SELECT
     user_id,
     name,
     target_weight,
+    scheduling_algorithm,
+    is_load_balanced,
+    excluded_weekdays,
+    maintenance_interval_days,
     registered_at,
     edited_at
 FROM
@@ -169,12 +244,16 @@ ORDER BY
 `
 
 type GetAllPatternsByUserIDRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	Name         string             `json:"name"`
-	TargetWeight TargetWeightEnum   `json:"target_weight"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	ID                      pgtype.UUID             `json:"id"`
+	UserID                  pgtype.UUID             `json:"user_id"`
+	Name                    string                  `json:"name"`
+	TargetWeight            TargetWeightEnum        `json:"target_weight"`
+	SchedulingAlgorithm     SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	IsLoadBalanced          bool                    `json:"is_load_balanced"`
+	ExcludedWeekdays        []int16                 `json:"excluded_weekdays"`
+	MaintenanceIntervalDays int16                   `json:"maintenance_interval_days"`
+	RegisteredAt            pgtype.Timestamptz      `json:"registered_at"`
+	EditedAt                pgtype.Timestamptz      `json:"edited_at"`
 }
 
 // 全パターン取得機能（パターン（親）のみ一覧取得）
@@ -192,6 +271,10 @@ func (q *Queries) GetAllPatternsByUserID(ctx context.Context, userID pgtype.UUID
 			&i.UserID,
 			&i.Name,
 			&i.TargetWeight,
+			&i.SchedulingAlgorithm,
+			&i.IsLoadBalanced,
+			&i.ExcludedWeekdays,
+			&i.MaintenanceIntervalDays,
 			&i.RegisteredAt,
 			&i.EditedAt,
 		); err != nil {
@@ -205,12 +288,64 @@ func (q *Queries) GetAllPatternsByUserID(ctx context.Context, userID pgtype.UUID
 	return items, nil
 }
 
+const getLatestPatternVersionByPatternID = `-- name: GetLatestPatternVersionByPatternID :one
+SELECT
+    id,
+    user_id,
+    pattern_id,
+    version_number,
+    scheduling_algorithm,
+    created_at
+FROM
+    pattern_versions
+WHERE
+    pattern_id = $1
+AND
+    user_id = $2
+ORDER BY
+    version_number DESC
+LIMIT 1
+`
+
+type GetLatestPatternVersionByPatternIDParams struct {
+	PatternID pgtype.UUID `json:"pattern_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type GetLatestPatternVersionByPatternIDRow struct {
+	ID                  pgtype.UUID             `json:"id"`
+	UserID              pgtype.UUID             `json:"user_id"`
+	PatternID           pgtype.UUID             `json:"pattern_id"`
+	VersionNumber       int32                   `json:"version_number"`
+	SchedulingAlgorithm SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	CreatedAt           pgtype.Timestamptz      `json:"created_at"`
+}
+
+// 次の版番号の採番と、復習物に割り当てる現在の版の取得に使う
+func (q *Queries) GetLatestPatternVersionByPatternID(ctx context.Context, arg GetLatestPatternVersionByPatternIDParams) (GetLatestPatternVersionByPatternIDRow, error) {
+	row := q.db.QueryRow(ctx, getLatestPatternVersionByPatternID, arg.PatternID, arg.UserID)
+	var i GetLatestPatternVersionByPatternIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PatternID,
+		&i.VersionNumber,
+		&i.SchedulingAlgorithm,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getPatternByID = `-- name: GetPatternByID :one
 SELECT
     id,
     user_id,
     name,
     target_weight,
+    scheduling_algorithm,
+    is_load_balanced,
+    excluded_weekdays,
+    maintenance_interval_days,
     registered_at,
     edited_at
 FROM
@@ -227,12 +362,16 @@ type GetPatternByIDParams struct {
 }
 
 type GetPatternByIDRow struct {
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
-	Name         string             `json:"name"`
-	TargetWeight TargetWeightEnum   `json:"target_weight"`
-	RegisteredAt pgtype.Timestamptz `json:"registered_at"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	ID                      pgtype.UUID             `json:"id"`
+	UserID                  pgtype.UUID             `json:"user_id"`
+	Name                    string                  `json:"name"`
+	TargetWeight            TargetWeightEnum        `json:"target_weight"`
+	SchedulingAlgorithm     SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	IsLoadBalanced          bool                    `json:"is_load_balanced"`
+	ExcludedWeekdays        []int16                 `json:"excluded_weekdays"`
+	MaintenanceIntervalDays int16                   `json:"maintenance_interval_days"`
+	RegisteredAt            pgtype.Timestamptz      `json:"registered_at"`
+	EditedAt                pgtype.Timestamptz      `json:"edited_at"`
 }
 
 // 復習パターンそのものが更新対象かどうか判定するために使う
@@ -244,6 +383,10 @@ func (q *Queries) GetPatternByID(ctx context.Context, arg GetPatternByIDParams)
 		&i.UserID,
 		&i.Name,
 		&i.TargetWeight,
+		&i.SchedulingAlgorithm,
+		&i.IsLoadBalanced,
+		&i.ExcludedWeekdays,
+		&i.MaintenanceIntervalDays,
 		&i.RegisteredAt,
 		&i.EditedAt,
 	)
@@ -256,7 +399,8 @@ SELECT
     user_id,
     pattern_id,
     step_number,
-    interval_days
+    interval_days,
+    interval_unit
 FROM
     pattern_steps
 WHERE
@@ -273,11 +417,12 @@ type GetPatternStepsByPatternIDParams struct {
 }
 
 type GetPatternStepsByPatternIDRow struct {
-	ID           pgtype.UUID `json:"id"`
-	UserID       pgtype.UUID `json:"user_id"`
-	PatternID    pgtype.UUID `json:"pattern_id"`
-	StepNumber   int16       `json:"step_number"`
-	IntervalDays int16       `json:"interval_days"`
+	ID           pgtype.UUID      `json:"id"`
+	UserID       pgtype.UUID      `json:"user_id"`
+	PatternID    pgtype.UUID      `json:"pattern_id"`
+	StepNumber   int16            `json:"step_number"`
+	IntervalDays int16            `json:"interval_days"`
+	IntervalUnit IntervalUnitEnum `json:"interval_unit"`
 }
 
 // 復習ステップが更新対象かどうか判定するために使う
@@ -296,6 +441,7 @@ func (q *Queries) GetPatternStepsByPatternID(ctx context.Context, arg GetPattern
 			&i.PatternID,
 			&i.StepNumber,
 			&i.IntervalDays,
+			&i.IntervalUnit,
 		); err != nil {
 			return nil, err
 		}
@@ -344,25 +490,260 @@ func (q *Queries) GetPatternTargetWeightsByPatternIDs(ctx context.Context, patte
 	return items, nil
 }
 
+const getPatternVersionByID = `-- name: GetPatternVersionByID :one
+SELECT
+    id,
+    user_id,
+    pattern_id,
+    version_number,
+    scheduling_algorithm,
+    created_at
+FROM
+    pattern_versions
+WHERE
+    id = $1
+AND
+    user_id = $2
+`
+
+type GetPatternVersionByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type GetPatternVersionByIDRow struct {
+	ID                  pgtype.UUID             `json:"id"`
+	UserID              pgtype.UUID             `json:"user_id"`
+	PatternID           pgtype.UUID             `json:"pattern_id"`
+	VersionNumber       int32                   `json:"version_number"`
+	SchedulingAlgorithm SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	CreatedAt           pgtype.Timestamptz      `json:"created_at"`
+}
+
+// 復習物が記録している版の取得に使う
+func (q *Queries) GetPatternVersionByID(ctx context.Context, arg GetPatternVersionByIDParams) (GetPatternVersionByIDRow, error) {
+	row := q.db.QueryRow(ctx, getPatternVersionByID, arg.ID, arg.UserID)
+	var i GetPatternVersionByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.PatternID,
+		&i.VersionNumber,
+		&i.SchedulingAlgorithm,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPatternVersionStepsByPatternID = `-- name: GetPatternVersionStepsByPatternID :many
+SELECT
+    pvs.id,
+    pvs.user_id,
+    pvs.pattern_version_id,
+    pvs.step_number,
+    pvs.interval_days,
+    pvs.interval_unit
+FROM
+    pattern_version_steps pvs
+JOIN
+    pattern_versions pv ON pv.id = pvs.pattern_version_id
+WHERE
+    pv.pattern_id = $1
+AND
+    pvs.user_id = $2
+ORDER BY
+    pv.version_number,
+    pvs.step_number
+`
+
+type GetPatternVersionStepsByPatternIDParams struct {
+	PatternID pgtype.UUID `json:"pattern_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type GetPatternVersionStepsByPatternIDRow struct {
+	ID               pgtype.UUID      `json:"id"`
+	UserID           pgtype.UUID      `json:"user_id"`
+	PatternVersionID pgtype.UUID      `json:"pattern_version_id"`
+	StepNumber       int16            `json:"step_number"`
+	IntervalDays     int16            `json:"interval_days"`
+	IntervalUnit     IntervalUnitEnum `json:"interval_unit"`
+}
+
+// 版の履歴一覧（パターンの全ての版のステップ（子）を取得（版は区別しない））
+func (q *Queries) GetPatternVersionStepsByPatternID(ctx context.Context, arg GetPatternVersionStepsByPatternIDParams) ([]GetPatternVersionStepsByPatternIDRow, error) {
+	rows, err := q.db.Query(ctx, getPatternVersionStepsByPatternID, arg.PatternID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPatternVersionStepsByPatternIDRow{}
+	for rows.Next() {
+		var i GetPatternVersionStepsByPatternIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.PatternVersionID,
+			&i.StepNumber,
+			&i.IntervalDays,
+			&i.IntervalUnit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPatternVersionStepsByPatternVersionID = `-- name: GetPatternVersionStepsByPatternVersionID :many
+SELECT
+    id,
+    user_id,
+    pattern_version_id,
+    step_number,
+    interval_days,
+    interval_unit
+FROM
+    pattern_version_steps
+WHERE
+    pattern_version_id = $1
+AND
+    user_id = $2
+ORDER BY
+    step_number
+`
+
+type GetPatternVersionStepsByPatternVersionIDParams struct {
+	PatternVersionID pgtype.UUID `json:"pattern_version_id"`
+	UserID           pgtype.UUID `json:"user_id"`
+}
+
+type GetPatternVersionStepsByPatternVersionIDRow struct {
+	ID               pgtype.UUID      `json:"id"`
+	UserID           pgtype.UUID      `json:"user_id"`
+	PatternVersionID pgtype.UUID      `json:"pattern_version_id"`
+	StepNumber       int16            `json:"step_number"`
+	IntervalDays     int16            `json:"interval_days"`
+	IntervalUnit     IntervalUnitEnum `json:"interval_unit"`
+}
+
+func (q *Queries) GetPatternVersionStepsByPatternVersionID(ctx context.Context, arg GetPatternVersionStepsByPatternVersionIDParams) ([]GetPatternVersionStepsByPatternVersionIDRow, error) {
+	rows, err := q.db.Query(ctx, getPatternVersionStepsByPatternVersionID, arg.PatternVersionID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPatternVersionStepsByPatternVersionIDRow{}
+	for rows.Next() {
+		var i GetPatternVersionStepsByPatternVersionIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.PatternVersionID,
+			&i.StepNumber,
+			&i.IntervalDays,
+			&i.IntervalUnit,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPatternVersionsByPatternID = `-- name: GetPatternVersionsByPatternID :many
+SELECT
+    id,
+    user_id,
+    pattern_id,
+    version_number,
+    scheduling_algorithm,
+    created_at
+FROM
+    pattern_versions
+WHERE
+    pattern_id = $1
+AND
+    user_id = $2
+ORDER BY
+    version_number
+`
+
+type GetPatternVersionsByPatternIDParams struct {
+	PatternID pgtype.UUID `json:"pattern_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type GetPatternVersionsByPatternIDRow struct {
+	ID                  pgtype.UUID             `json:"id"`
+	UserID              pgtype.UUID             `json:"user_id"`
+	PatternID           pgtype.UUID             `json:"pattern_id"`
+	VersionNumber       int32                   `json:"version_number"`
+	SchedulingAlgorithm SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	CreatedAt           pgtype.Timestamptz      `json:"created_at"`
+}
+
+// 版の履歴一覧（版（親）のみ取得）
+func (q *Queries) GetPatternVersionsByPatternID(ctx context.Context, arg GetPatternVersionsByPatternIDParams) ([]GetPatternVersionsByPatternIDRow, error) {
+	rows, err := q.db.Query(ctx, getPatternVersionsByPatternID, arg.PatternID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPatternVersionsByPatternIDRow{}
+	for rows.Next() {
+		var i GetPatternVersionsByPatternIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.PatternID,
+			&i.VersionNumber,
+			&i.SchedulingAlgorithm,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updatePattern = `-- name: UpdatePattern :exec
 UPDATE
     review_patterns
 SET
     name = $1,
     target_weight = $2,
-    edited_at = $3
+    scheduling_algorithm = $3,
+    is_load_balanced = $4,
+    excluded_weekdays = $5,
+    maintenance_interval_days = $6,
+    edited_at = $7
 WHERE
-    id = $4
+    id = $8
 AND
-    user_id = $5
+    user_id = $9
 `
 
 type UpdatePatternParams struct {
-	Name         string             `json:"name"`
-	TargetWeight TargetWeightEnum   `json:"target_weight"`
-	EditedAt     pgtype.Timestamptz `json:"edited_at"`
-	ID           pgtype.UUID        `json:"id"`
-	UserID       pgtype.UUID        `json:"user_id"`
+	Name                    string                  `json:"name"`
+	TargetWeight            TargetWeightEnum        `json:"target_weight"`
+	SchedulingAlgorithm     SchedulingAlgorithmEnum `json:"scheduling_algorithm"`
+	IsLoadBalanced          bool                    `json:"is_load_balanced"`
+	ExcludedWeekdays        []int16                 `json:"excluded_weekdays"`
+	MaintenanceIntervalDays int16                   `json:"maintenance_interval_days"`
+	EditedAt                pgtype.Timestamptz      `json:"edited_at"`
+	ID                      pgtype.UUID             `json:"id"`
+	UserID                  pgtype.UUID             `json:"user_id"`
 }
 
 // pattern系のリクエストで、更新対象の中に復習パターンそのものが含まれる場合に発行するクエリ
@@ -370,6 +751,10 @@ func (q *Queries) UpdatePattern(ctx context.Context, arg UpdatePatternParams) er
 	_, err := q.db.Exec(ctx, updatePattern,
 		arg.Name,
 		arg.TargetWeight,
+		arg.SchedulingAlgorithm,
+		arg.IsLoadBalanced,
+		arg.ExcludedWeekdays,
+		arg.MaintenanceIntervalDays,
 		arg.EditedAt,
 		arg.ID,
 		arg.UserID,
@@ -11,42 +11,76 @@ import (
 )
 
 type Querier interface {
+	// ロックを保持していなかった場合（接続が切れてロックを失った場合など）はfalseを返す
+	AdvisoryUnlock(ctx context.Context, lockName string) (bool, error)
+	// 終了した休止期間の反映。休止期間の開始日以降の未完了の復習日を休止日数分ずらし、反映済みにする
+	// 休止期間中に期限切れになった復習日も今日にまとめず、休止前の間隔のまま後ろにずらす（除外する曜日と復習日を置かない日付は避ける）
+	ApplyEndedPauses(ctx context.Context, arg ApplyEndedPausesParams) (int64, error)
 	// 今日の全復習日数を取得
+	// 期限切れの復習日の扱いがkeep_overdueのユーザーは、今日の復習日一覧に含める期限切れの復習日も数える
 	CountAllDailyReviewDates(ctx context.Context, arg CountAllDailyReviewDatesParams) (int64, error)
 	CountDailyDatesGroupedByBoxByUserID(ctx context.Context, arg CountDailyDatesGroupedByBoxByUserIDParams) ([]CountDailyDatesGroupedByBoxByUserIDRow, error)
 	CountDailyDatesUnclassifiedByUserID(ctx context.Context, arg CountDailyDatesUnclassifiedByUserIDParams) ([]int64, error)
 	CountDailyDatesUnclassifiedGroupedByCategoryByUserID(ctx context.Context, arg CountDailyDatesUnclassifiedGroupedByCategoryByUserIDParams) ([]CountDailyDatesUnclassifiedGroupedByCategoryByUserIDRow, error)
+	// 復習量の予測系
+	// 指定期間の未完了の復習日数を、復習日とボックス毎に集計
+	CountForecastDatesGroupedByBoxByUserID(ctx context.Context, arg CountForecastDatesGroupedByBoxByUserIDParams) ([]CountForecastDatesGroupedByBoxByUserIDRow, error)
+	// 指定期間の未分類の未完了の復習日数を、復習日とカテゴリー毎に集計（category_idがNULLのものはホーム画面の未分類）
+	CountForecastDatesUnclassifiedGroupedByCategoryByUserID(ctx context.Context, arg CountForecastDatesUnclassifiedGroupedByCategoryByUserIDParams) ([]CountForecastDatesUnclassifiedGroupedByCategoryByUserIDRow, error)
 	// ここから下は概要表示用の取得クエリ
 	CountItemsGroupedByBoxByUserID(ctx context.Context, userID pgtype.UUID) ([]CountItemsGroupedByBoxByUserIDRow, error)
+	// 復習日の負荷分散用
+	// 指定期間の未完了の復習日数（分・時間単位のステップの復習日を除く）を、ボックスやカテゴリーを区別せず復習日毎に集計
+	CountScheduledDatesGroupedByDateByUserID(ctx context.Context, arg CountScheduledDatesGroupedByDateByUserIDParams) ([]CountScheduledDatesGroupedByDateByUserIDRow, error)
 	CountUnclassifiedItemsByUserID(ctx context.Context, userID pgtype.UUID) ([]int64, error)
 	CountUnclassifiedItemsGroupedByCategoryByUserID(ctx context.Context, userID pgtype.UUID) ([]CountUnclassifiedItemsGroupedByCategoryByUserIDRow, error)
+	// 同じ実行枠の実行記録がある場合と、同じバッチ処理が実行中の場合は作成しない。作成できたかは影響行数で判定する
+	CreateBatchRun(ctx context.Context, arg CreateBatchRunParams) (int64, error)
+	// 既に同じ日付が登録されている場合は何もしない
+	CreateBlockedDate(ctx context.Context, arg CreateBlockedDateParams) error
 	CreateBox(ctx context.Context, arg CreateBoxParams) error
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) error
 	CreateEmailVerification(ctx context.Context, arg CreateEmailVerificationParams) error
+	// パターンの版は、パターンの最新の版（復習日の算出に使った現在のステップの版）を割り当てる
 	CreateItem(ctx context.Context, arg CreateItemParams) error
 	CreatePattern(ctx context.Context, arg CreatePatternParams) error
 	// 新規一括挿入時と、一括更新時に使う
 	CreatePatternSteps(ctx context.Context, arg []CreatePatternStepsParams) (int64, error)
+	// パターンの作成時と、ステップ（方式）の変更時に版を追加する
+	CreatePatternVersion(ctx context.Context, arg CreatePatternVersionParams) error
+	CreatePatternVersionSteps(ctx context.Context, arg []CreatePatternVersionStepsParams) (int64, error)
+	CreatePause(ctx context.Context, arg CreatePauseParams) error
 	// 新規一括挿入時と、一括更新時に使う
 	CreateReviewDates(ctx context.Context, arg []CreateReviewDatesParams) (int64, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) error
+	DeleteBlockedDate(ctx context.Context, arg DeleteBlockedDateParams) error
 	DeleteBox(ctx context.Context, arg DeleteBoxParams) error
 	DeleteCategory(ctx context.Context, arg DeleteCategoryParams) error
 	DeleteEmailVerificationByUserID(ctx context.Context, userID pgtype.UUID) error
+	// 適応型の復習日の完了を取り消したときに、生成済みの次の復習日を削除する
+	DeleteInCompletedReviewDatesAfterStep(ctx context.Context, arg DeleteInCompletedReviewDatesAfterStepParams) error
 	DeleteItem(ctx context.Context, arg DeleteItemParams) error
 	DeletePattern(ctx context.Context, arg DeletePatternParams) error
 	// 復習ステップが更新対象に含まれた場合に発行する一括削除用のクエリ
 	DeletePatternSteps(ctx context.Context, arg DeletePatternStepsParams) error
 	// 復習日のパターンIDがnilに変更されたとき
 	DeleteReviewDates(ctx context.Context, arg DeleteReviewDatesParams) error
+	// 実行中のまま残っている実行記録（実行中にプロセスが停止した場合など）を失敗にする
+	FailStaleBatchRuns(ctx context.Context, arg FailStaleBatchRunsParams) error
 	FindEmailVerificationByUserID(ctx context.Context, userID pgtype.UUID) (FindEmailVerificationByUserIDRow, error)
 	FindUserByEmailSearchKey(ctx context.Context, emailSearchKey string) (FindUserByEmailSearchKeyRow, error)
+	FinishBatchRun(ctx context.Context, arg FinishBatchRunParams) error
 	GetAllBoxesByCategoryID(ctx context.Context, arg GetAllBoxesByCategoryIDParams) ([]GetAllBoxesByCategoryIDRow, error)
+	// パターンの使用箇所の一覧用。パターンを使うボックスを取得
+	GetAllBoxesByPatternID(ctx context.Context, arg GetAllBoxesByPatternIDParams) ([]GetAllBoxesByPatternIDRow, error)
 	GetAllCategoriesByUserID(ctx context.Context, userID pgtype.UUID) ([]GetAllCategoriesByUserIDRow, error)
 	// LAG→item_idごとにstep_numberの昇順で並べた時、scheduled_dateが持つstep_numberより一個前のstep_numberのscheduled_dateを取得
 	// LEAD→item_idごとにstep_numberの昇順で並べた時、scheduled_dateが持つstep_numberより一個後のstep_numberのscheduled_dateを取得
 	// 今日の復習日を取得するクエリ
+	// 期限切れの復習日の扱いがkeep_overdueのユーザーは、復習物毎に最初の未完了の期限切れの復習日も期限切れとして含める（分・時間単位のステップの復習日は除く）
 	GetAllDailyReviewDates(ctx context.Context, arg GetAllDailyReviewDatesParams) ([]GetAllDailyReviewDatesRow, error)
+	// パターンの使用箇所の一覧用。パターンを使う復習物（完了済みを含む）を取得
+	GetAllItemsByPatternID(ctx context.Context, arg GetAllItemsByPatternIDParams) ([]GetAllItemsByPatternIDRow, error)
 	//　全パターン取得機能（ステップ（子）のみ一覧取得（親は区別しない））
 	GetAllPatternStepsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetAllPatternStepsByUserIDRow, error)
 	// 全パターン取得機能（パターン（親）のみ一覧取得）
@@ -55,11 +89,15 @@ type Querier interface {
 	GetAllReviewDatesByBoxID(ctx context.Context, arg GetAllReviewDatesByBoxIDParams) ([]GetAllReviewDatesByBoxIDRow, error)
 	// ボックス内画面用の未完了の全復習物一覧取得機能（復習物（親）のみ一覧取得）
 	GetAllUnFinishedItemsByBoxID(ctx context.Context, arg GetAllUnFinishedItemsByBoxIDParams) ([]GetAllUnFinishedItemsByBoxIDRow, error)
+	// パターンのステップ変更時に、既存の復習日を組み直す対象の復習物を取得
+	GetAllUnFinishedItemsByPatternID(ctx context.Context, arg GetAllUnFinishedItemsByPatternIDParams) ([]GetAllUnFinishedItemsByPatternIDRow, error)
 	GetAllUnFinishedUnclassifiedItemsByCategoryID(ctx context.Context, arg GetAllUnFinishedUnclassifiedItemsByCategoryIDParams) ([]GetAllUnFinishedUnclassifiedItemsByCategoryIDRow, error)
 	// ホーム画面の未分類未完了復習物
 	GetAllUnFinishedUnclassifiedItemsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetAllUnFinishedUnclassifiedItemsByUserIDRow, error)
 	GetAllUnclassifiedReviewDatesByCategoryID(ctx context.Context, arg GetAllUnclassifiedReviewDatesByCategoryIDParams) ([]GetAllUnclassifiedReviewDatesByCategoryIDRow, error)
 	GetAllUnclassifiedReviewDatesByUserID(ctx context.Context, userID pgtype.UUID) ([]GetAllUnclassifiedReviewDatesByUserIDRow, error)
+	GetBlockedDateByID(ctx context.Context, arg GetBlockedDateByIDParams) (GetBlockedDateByIDRow, error)
+	GetBlockedDatesByUserID(ctx context.Context, userID pgtype.UUID) ([]GetBlockedDatesByUserIDRow, error)
 	GetBoxByID(ctx context.Context, arg GetBoxByIDParams) (GetBoxByIDRow, error)
 	// item_usecaseで使うクエリ。
 	// args: box_ids uuid[]
@@ -68,12 +106,29 @@ type Querier interface {
 	// item_usecaseで使うクエリ
 	// args: category_ids uuid[]
 	GetCategoryNamesByCategoryIDs(ctx context.Context, categoryIds []pgtype.UUID) ([]GetCategoryNamesByCategoryIDsRow, error)
+	// 1日あたりの復習数の上限を超える復習日の繰り越し用
+	GetDailyReviewLimitByUserID(ctx context.Context, userID pgtype.UUID) (int16, error)
+	// 分・時間単位のステップのうち、復習日時を過ぎた未完了の復習日を取得するクエリ
+	GetDueNowReviewDates(ctx context.Context, arg GetDueNowReviewDatesParams) ([]GetDueNowReviewDatesRow, error)
 	// EditedAt取得専用
 	GetEditedAtByItemID(ctx context.Context, arg GetEditedAtByItemIDParams) (pgtype.Timestamptz, error)
+	// 復習日を置かない曜日のユーザー設定（パターンの設定と合わせて使う）
+	GetExcludedWeekdaysByUserID(ctx context.Context, userID pgtype.UUID) ([]int16, error)
 	// ボックス内画面用の完了の全復習物一覧取得系（復習物（親）のみ一覧取得）
 	GetFinishedItemsByBoxID(ctx context.Context, arg GetFinishedItemsByBoxIDParams) ([]GetFinishedItemsByBoxIDRow, error)
 	// 学習日変更など、どういうリクエストなのかを判定するために使う
 	GetItemByID(ctx context.Context, arg GetItemByIDParams) (GetItemByIDRow, error)
+	// 実行されなかった実行枠を判定するために使う。実行記録がない場合はNULL
+	GetLatestBatchRunWindowStart(ctx context.Context, jobName string) (pgtype.Timestamptz, error)
+	// 次の版番号の採番と、復習物に割り当てる現在の版の取得に使う
+	GetLatestPatternVersionByPatternID(ctx context.Context, arg GetLatestPatternVersionByPatternIDParams) (GetLatestPatternVersionByPatternIDRow, error)
+	// 日付けを跨いだタイムゾーンを判定するために使う。失敗した実行は一部のユーザーしか処理していない場合があるため対象外。成功した実行記録がない場合はNULL
+	GetLatestSucceededBatchRunWindowStart(ctx context.Context, jobName string) (pgtype.Timestamptz, error)
+	// 1日あたりの復習数の上限があるユーザーの期限切れの復習物を、繰り越し先の決定に必要な情報と合わせて取得
+	// パターンが設定されていない復習物は重みなし扱い
+	// 期限切れの復習日の扱いがslide_all、slide_overdue_only、reset_to_first_stepのユーザーが対象（reset_to_first_stepのユーザーの適応型のパターンの復習物はslide_allとして返す）
+	// 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+	GetOverdueItemsWithDailyReviewLimit(ctx context.Context, arg GetOverdueItemsWithDailyReviewLimitParams) ([]GetOverdueItemsWithDailyReviewLimitRow, error)
 	// 復習パターンそのものが更新対象かどうか判定するために使う
 	GetPatternByID(ctx context.Context, arg GetPatternByIDParams) (GetPatternByIDRow, error)
 	// 復習ステップが更新対象かどうか判定するために使う
@@ -81,24 +136,93 @@ type Querier interface {
 	// item_usecaseで使うクエリ。
 	// args: pattern_ids uuid[]
 	GetPatternTargetWeightsByPatternIDs(ctx context.Context, patternIds []pgtype.UUID) ([]GetPatternTargetWeightsByPatternIDsRow, error)
+	// 復習物が記録している版の取得に使う
+	GetPatternVersionByID(ctx context.Context, arg GetPatternVersionByIDParams) (GetPatternVersionByIDRow, error)
+	// 版の履歴一覧（パターンの全ての版のステップ（子）を取得（版は区別しない））
+	GetPatternVersionStepsByPatternID(ctx context.Context, arg GetPatternVersionStepsByPatternIDParams) ([]GetPatternVersionStepsByPatternIDRow, error)
+	GetPatternVersionStepsByPatternVersionID(ctx context.Context, arg GetPatternVersionStepsByPatternVersionIDParams) ([]GetPatternVersionStepsByPatternVersionIDRow, error)
+	// 版の履歴一覧（版（親）のみ取得）
+	GetPatternVersionsByPatternID(ctx context.Context, arg GetPatternVersionsByPatternIDParams) ([]GetPatternVersionsByPatternIDRow, error)
 	// 復習日Upate処理用。ReviewDateIDを使い回すために使う
 	GetReviewDateIDsByItemID(ctx context.Context, arg GetReviewDateIDsByItemIDParams) ([]pgtype.UUID, error)
+	// ドライラン用。バッチ処理で更新した後の復習日を取得して、更新前と比べる
+	GetReviewDatesByIDs(ctx context.Context, reviewDateIds []pgtype.UUID) ([]GetReviewDatesByIDsRow, error)
 	GetReviewDatesByItemID(ctx context.Context, arg GetReviewDatesByItemIDParams) ([]GetReviewDatesByItemIDRow, error)
+	// ドライラン用。user_idsのユーザーのうち、バッチ処理でずれる可能性がある復習物（期限切れの未完了の復習日がある復習物と、終了した休止期間を反映するユーザーの復習物）の全ての復習日を取得
+	GetReviewDatesToUpdateByBatch(ctx context.Context, arg GetReviewDatesToUpdateByBatchParams) ([]GetReviewDatesToUpdateByBatchRow, error)
+	// 適応型SM-2方式の学習状態の取得
+	GetSM2StateByItemID(ctx context.Context, arg GetSM2StateByItemIDParams) (GetSM2StateByItemIDRow, error)
 	GetUnclassfiedFinishedItemsByCategoryID(ctx context.Context, arg GetUnclassfiedFinishedItemsByCategoryIDParams) ([]GetUnclassfiedFinishedItemsByCategoryIDRow, error)
 	GetUnclassfiedFinishedItemsByUserID(ctx context.Context, userID pgtype.UUID) ([]GetUnclassfiedFinishedItemsByUserIDRow, error)
+	// タイムゾーンのユーザーをID順に上限件数ずつ取得。after_user_idより後のユーザーを返す（NULLの場合は最初から）
+	GetUserIDsByTimezones(ctx context.Context, arg GetUserIDsByTimezonesParams) ([]pgtype.UUID, error)
 	GetUserSettingByID(ctx context.Context, id pgtype.UUID) (GetUserSettingByIDRow, error)
+	// 日付けを跨いだタイムゾーンを判定するために、ユーザーが設定しているタイムゾーンを取得
+	GetUserTimezones(ctx context.Context) ([]string, error)
 	// 完了済みの復習日がないか判別するためのクエリ
 	HasCompletedReviewDateByItemID(ctx context.Context, arg HasCompletedReviewDateByItemIDParams) (bool, error)
+	// 休止期間が既存の休止期間と重なるかを判定するために使う
+	HasOverlappingPause(ctx context.Context, arg HasOverlappingPauseParams) (bool, error)
 	// patternパッケージで使う
 	IsPatternRelatedToItemByPatternID(ctx context.Context, arg IsPatternRelatedToItemByPatternIDParams) (bool, error)
+	// ボックスのパターン変更で組み直す間に、復習日の完了や編集で組み直す前の状態が変わらないように、ボックス内の未完了の復習物の復習日と復習物の行をロックする
+	// 復習日の完了と同じく、復習日、復習物の順にロックする
+	LockReviewDatesOfUnFinishedItemsByBoxID(ctx context.Context, arg LockReviewDatesOfUnFinishedItemsByBoxIDParams) error
+	LockUnFinishedItemsByBoxID(ctx context.Context, arg LockUnFinishedItemsByBoxIDParams) error
+	// パターンのステップ変更や削除時の付け替えで組み直す間に、復習日の完了や編集で組み直す前の状態が変わらないように、パターンを使う未完了の復習物の復習日と復習物の行をロックする
+	// 復習日の完了と同じく、復習日、復習物の順にロックする
+	LockReviewDatesOfUnFinishedItemsByPatternID(ctx context.Context, arg LockReviewDatesOfUnFinishedItemsByPatternIDParams) error
+	LockUnFinishedItemsByPatternID(ctx context.Context, arg LockUnFinishedItemsByPatternIDParams) error
+	// 期限切れの復習物の期限切れの復習日だけを繰り越し先の日付に移す（後続の復習日はそのまま）
+	// 繰り越し先の日付が除外する曜日や復習日を置かない日付の場合は、次の復習日を置ける日にする
+	MoveOverdueScheduledDatesByItemID(ctx context.Context, arg MoveOverdueScheduledDatesByItemIDParams) (int64, error)
+	// 1日あたりの復習数の上限がなく、期限切れの復習日の扱いがslide_overdue_onlyのユーザーの期限切れの復習日だけを今日に移す（後続の復習日はそのまま）
+	// 今日が除外する曜日（ユーザーとパターンの設定）や復習日を置かない日付の場合は、次の復習日を置ける日にする
+	// 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+	MoveOverdueScheduledDatesToToday(ctx context.Context, arg MoveOverdueScheduledDatesToTodayParams) (int64, error)
+	// 復習日を置かない日付にある未完了の復習日を、次の復習日を置ける日（除外する曜日はユーザーとパターンの設定）にずらす（後続の復習日はそのまま）
+	// 復習日を置かない日付の登録と同じトランザクションで、登録した後に実行する
+	// 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+	MoveReviewDatesOffBlockedDates(ctx context.Context, arg MoveReviewDatesOffBlockedDatesParams) (int64, error)
+	// 1日あたりの復習数の上限がなく、期限切れの復習日の扱いがreset_to_first_stepのユーザーの期限切れの復習物を1ステップ目からやり直す
+	// 1ステップ目が今日になるように全ての復習日をずらし、完了済みの復習日も未完了に戻す（ステップ間の間隔は元のまま）
+	// ずらした先が除外する曜日（ユーザーとパターンの設定）や復習日を置かない日付の場合は、次の復習日を置ける日にする
+	// 適応型のパターンの復習物は復習日を1件ずつ生成しているため対象外（slide_allと同じ扱い）
+	ResetOverdueItemsToFirstStep(ctx context.Context, arg ResetOverdueItemsToFirstStepParams) (int64, error)
+	// 期限切れの復習物を1ステップ目からやり直す。1ステップ目が繰り越し先の日付になるように全ての復習日をずらし、完了済みの復習日も未完了に戻す（ステップ間の間隔は元のまま）
+	// ずらした先が除外する曜日や復習日を置かない日付の場合は、次の復習日を置ける日にする
+	ResetScheduledDatesToFirstStepByItemID(ctx context.Context, arg ResetScheduledDatesToFirstStepByItemIDParams) (int64, error)
+	// 期限切れの復習物の未完了の復習日を、繰り越し先の日付までずらす（後続の復習日も同じ日数だけずらす）
+	// 後続の復習日がずらした先で除外する曜日や復習日を置かない日付になる場合は、次の復習日を置ける日にする
+	SlideScheduledDatesByItemID(ctx context.Context, arg SlideScheduledDatesByItemIDParams) (int64, error)
+	// バッチ処理を複数のレプリカで動かす時に、1つのレプリカだけが実行するためのセッション単位のロック。ロックの名前からキーを作る
+	TryAdvisoryLock(ctx context.Context, lockName string) (bool, error)
+	UpdateBlockedDate(ctx context.Context, arg UpdateBlockedDateParams) error
 	UpdateBox(ctx context.Context, arg UpdateBoxParams) error
-	UpdateBoxIfNoReviewItems(ctx context.Context, arg UpdateBoxIfNoReviewItemsParams) (int64, error)
+	// ボックスのパターン変更。ボックス内の復習物の復習日の組み直しは呼び出し側で同一トランザクションで行う
+	UpdateBoxWithPatternID(ctx context.Context, arg UpdateBoxWithPatternIDParams) (int64, error)
+	// パターンの削除時に、パターンを使うボックスを別のパターンに付け替える
+	UpdateBoxesPatternIDByPatternID(ctx context.Context, arg UpdateBoxesPatternIDByPatternIDParams) (int64, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) error
 	// 移動、完了、学習日変更、その他編集に使う
+	// パターンを変更する場合は、変更後のパターンの最新の版を割り当てる。同じパターンのままの場合は版を変えない
 	UpdateItem(ctx context.Context, arg UpdateItemParams) error
 	UpdateItemAsFinished(ctx context.Context, arg UpdateItemAsFinishedParams) error
 	UpdateItemAsUnfinished(ctx context.Context, arg UpdateItemAsUnfinishedParams) error
-	UpdateOverdueScheduledDatesAndSlideFutureDates(ctx context.Context) error
+	// パターンのステップ変更で復習日を組み直した復習物に、変更後の版を割り当てる
+	UpdateItemPatternVersionID(ctx context.Context, arg UpdateItemPatternVersionIDParams) error
+	// ボックスのパターン変更時に、ボックス内の未完了の復習物のパターンも変更する
+	// 完了済みの復習物は完了した時のパターンと版のまま残す
+	// 復習日は変更後のパターンの現在のステップで組み直すため、パターンの最新の版を割り当てる
+	UpdateItemsPatternIDByBoxID(ctx context.Context, arg UpdateItemsPatternIDByBoxIDParams) error
+	// パターンの削除時に、パターンを使う復習物（完了済みを含む）を別のパターンに付け替える
+	// 未完了の復習日は付け替え先のパターンの現在のステップで組み直すため、付け替え先の最新の版を割り当てる
+	UpdateItemsPatternIDByPatternID(ctx context.Context, arg UpdateItemsPatternIDByPatternIDParams) (int64, error)
+	// 1日あたりの復習数の上限がないユーザーの期限切れの復習日を今日にずらし、後続の復習日も同じ日数だけずらす
+	// ずらした先が除外する曜日（ユーザーとパターンの設定）や復習日を置かない日付の場合は、次の復習日を置ける日にする
+	// 期限切れの復習日の扱いがslide_allのユーザーが対象。reset_to_first_stepのユーザーでも適応型のパターンの復習物は1ステップ目に戻せないためこちらで扱う
+	// 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+	UpdateOverdueScheduledDatesAndSlideFutureDates(ctx context.Context, arg UpdateOverdueScheduledDatesAndSlideFutureDatesParams) (int64, error)
 	// pattern系のリクエストで、更新対象の中に復習パターンそのものが含まれる場合に発行するクエリ
 	UpdatePattern(ctx context.Context, arg UpdatePatternParams) error
 	UpdateReviewDateAsCompleted(ctx context.Context, arg UpdateReviewDateAsCompletedParams) error
@@ -110,6 +234,8 @@ type Querier interface {
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
 	UpdateVerifiedAt(ctx context.Context, arg UpdateVerifiedAtParams) error
+	// 初回完了時は挿入、2回目以降は上書き
+	UpsertSM2State(ctx context.Context, arg UpsertSM2StateParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -7,15 +7,657 @@ package dbgen
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const applyEndedPauses = `-- name: ApplyEndedPauses :execrows
+WITH p AS (
+    UPDATE user_pauses up
+        SET
+            applied_at = now(),
+            updated_at = now()
+        FROM
+            users u
+        WHERE
+            u.id = up.user_id
+        AND
+            up.applied_at IS NULL
+        AND
+            up.end_date < ($1::timestamptz AT TIME ZONE u.timezone)::date
+        AND
+            up.user_id = ANY($2::uuid[])
+        RETURNING
+            up.user_id,
+            up.start_date,
+            (up.end_date - up.start_date + 1) AS pause_days,
+            u.excluded_weekdays
 )
+UPDATE review_dates rd
+    SET
+        scheduled_date = next_schedulable_date(rd.scheduled_date + p.pause_days, p.user_id, p.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}'))
+    FROM
+        p
+    JOIN
+        review_items ri
+    ON
+        ri.user_id = p.user_id
+    LEFT JOIN
+        review_patterns rp
+    ON
+        rp.id = ri.pattern_id
+    WHERE
+        rd.item_id = ri.id
+    AND
+        rd.user_id = p.user_id
+    AND
+        rd.scheduled_date >= p.start_date
+    AND
+        rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL
+`
+
+type ApplyEndedPausesParams struct {
+	Now     pgtype.Timestamptz `json:"now"`
+	UserIds []pgtype.UUID      `json:"user_ids"`
+}
+
+// 終了した休止期間の反映。休止期間の開始日以降の未完了の復習日を休止日数分ずらし、反映済みにする
+// 休止期間中に期限切れになった復習日も今日にまとめず、休止前の間隔のまま後ろにずらす（除外する曜日と復習日を置かない日付は避ける）
+func (q *Queries) ApplyEndedPauses(ctx context.Context, arg ApplyEndedPausesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, applyEndedPauses, arg.Now, arg.UserIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getOverdueItemsWithDailyReviewLimit = `-- name: GetOverdueItemsWithDailyReviewLimit :many
+SELECT
+    ri.user_id,
+    ri.id AS item_id,
+    MIN(rd.scheduled_date)::date AS old_date,
+    ($1::timestamptz AT TIME ZONE u.timezone)::date AS today_local,
+    u.daily_review_limit,
+    (CASE
+        WHEN u.overdue_policy = 'reset_to_first_step' AND rp.scheduling_algorithm = 'sm2_adaptive' THEN 'slide_all'
+        ELSE u.overdue_policy
+    END)::overdue_policy_enum AS overdue_policy,
+    COALESCE(rp.target_weight, 'unset')::target_weight_enum AS target_weight,
+    (u.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}'))::smallint[] AS excluded_weekdays
+FROM
+    review_dates rd
+JOIN
+    review_items ri
+ON
+    ri.id = rd.item_id
+JOIN
+    users u
+ON
+    u.id = ri.user_id
+LEFT JOIN
+    review_patterns rp
+ON
+    rp.id = ri.pattern_id
+WHERE
+    rd.is_completed = FALSE
+AND
+    rd.scheduled_at IS NULL
+AND
+    rd.scheduled_date < ($1::timestamptz AT TIME ZONE u.timezone)::date
+AND
+    rd.user_id = ANY($2::uuid[])
+AND
+    u.daily_review_limit > 0
+AND
+    u.overdue_policy IN ('slide_all', 'slide_overdue_only', 'reset_to_first_step')
+AND
+    NOT EXISTS (
+        SELECT
+            1
+        FROM
+            user_pauses up
+        WHERE
+            up.user_id = u.id
+        AND
+            ($1::timestamptz AT TIME ZONE u.timezone)::date BETWEEN up.start_date AND up.end_date
+    )
+GROUP BY
+    ri.user_id, ri.id, u.timezone, u.daily_review_limit, u.overdue_policy, rp.scheduling_algorithm, rp.target_weight, u.excluded_weekdays, rp.excluded_weekdays
+ORDER BY
+    ri.user_id, ri.id
+`
+
+type GetOverdueItemsWithDailyReviewLimitParams struct {
+	Now     pgtype.Timestamptz `json:"now"`
+	UserIds []pgtype.UUID      `json:"user_ids"`
+}
+
+type GetOverdueItemsWithDailyReviewLimitRow struct {
+	UserID           pgtype.UUID       `json:"user_id"`
+	ItemID           pgtype.UUID       `json:"item_id"`
+	OldDate          pgtype.Date       `json:"old_date"`
+	TodayLocal       pgtype.Date       `json:"today_local"`
+	DailyReviewLimit int16             `json:"daily_review_limit"`
+	OverduePolicy    OverduePolicyEnum `json:"overdue_policy"`
+	TargetWeight     TargetWeightEnum  `json:"target_weight"`
+	ExcludedWeekdays []int16           `json:"excluded_weekdays"`
+}
+
+// 1日あたりの復習数の上限があるユーザーの期限切れの復習物を、繰り越し先の決定に必要な情報と合わせて取得
+// パターンが設定されていない復習物は重みなし扱い
+// 期限切れの復習日の扱いがslide_all、slide_overdue_only、reset_to_first_stepのユーザーが対象（reset_to_first_stepのユーザーの適応型のパターンの復習物はslide_allとして返す）
+// 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+func (q *Queries) GetOverdueItemsWithDailyReviewLimit(ctx context.Context, arg GetOverdueItemsWithDailyReviewLimitParams) ([]GetOverdueItemsWithDailyReviewLimitRow, error) {
+	rows, err := q.db.Query(ctx, getOverdueItemsWithDailyReviewLimit, arg.Now, arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOverdueItemsWithDailyReviewLimitRow{}
+	for rows.Next() {
+		var i GetOverdueItemsWithDailyReviewLimitRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.ItemID,
+			&i.OldDate,
+			&i.TodayLocal,
+			&i.DailyReviewLimit,
+			&i.OverduePolicy,
+			&i.TargetWeight,
+			&i.ExcludedWeekdays,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewDatesByIDs = `-- name: GetReviewDatesByIDs :many
+SELECT
+    id,
+    user_id,
+    item_id,
+    step_number,
+    scheduled_date
+FROM
+    review_dates
+WHERE
+    id = ANY($1::uuid[])
+ORDER BY
+    user_id, item_id, step_number
+`
+
+type GetReviewDatesByIDsRow struct {
+	ID            pgtype.UUID `json:"id"`
+	UserID        pgtype.UUID `json:"user_id"`
+	ItemID        pgtype.UUID `json:"item_id"`
+	StepNumber    int16       `json:"step_number"`
+	ScheduledDate pgtype.Date `json:"scheduled_date"`
+}
+
+// ドライラン用。バッチ処理で更新した後の復習日を取得して、更新前と比べる
+func (q *Queries) GetReviewDatesByIDs(ctx context.Context, reviewDateIds []pgtype.UUID) ([]GetReviewDatesByIDsRow, error) {
+	rows, err := q.db.Query(ctx, getReviewDatesByIDs, reviewDateIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReviewDatesByIDsRow{}
+	for rows.Next() {
+		var i GetReviewDatesByIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ItemID,
+			&i.StepNumber,
+			&i.ScheduledDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewDatesToUpdateByBatch = `-- name: GetReviewDatesToUpdateByBatch :many
+SELECT
+    rd.id,
+    rd.user_id,
+    rd.item_id,
+    rd.step_number,
+    rd.scheduled_date
+FROM
+    review_dates rd
+JOIN
+    users u
+ON
+    u.id = rd.user_id
+WHERE
+    rd.user_id = ANY($1::uuid[])
+AND (
+        EXISTS (
+            SELECT
+                1
+            FROM
+                review_dates o
+            WHERE
+                o.item_id = rd.item_id
+            AND
+                o.is_completed = FALSE
+            AND
+                o.scheduled_at IS NULL
+            AND
+                o.scheduled_date < ($2::timestamptz AT TIME ZONE u.timezone)::date
+        )
+    OR
+        EXISTS (
+            SELECT
+                1
+            FROM
+                user_pauses up
+            WHERE
+                up.user_id = u.id
+            AND
+                up.applied_at IS NULL
+            AND
+                up.end_date < ($2::timestamptz AT TIME ZONE u.timezone)::date
+        )
+)
+ORDER BY
+    rd.user_id, rd.item_id, rd.step_number
+`
+
+type GetReviewDatesToUpdateByBatchParams struct {
+	UserIds []pgtype.UUID      `json:"user_ids"`
+	Now     pgtype.Timestamptz `json:"now"`
+}
+
+type GetReviewDatesToUpdateByBatchRow struct {
+	ID            pgtype.UUID `json:"id"`
+	UserID        pgtype.UUID `json:"user_id"`
+	ItemID        pgtype.UUID `json:"item_id"`
+	StepNumber    int16       `json:"step_number"`
+	ScheduledDate pgtype.Date `json:"scheduled_date"`
+}
+
+// ドライラン用。user_idsのユーザーのうち、バッチ処理でずれる可能性がある復習物（期限切れの未完了の復習日がある復習物と、終了した休止期間を反映するユーザーの復習物）の全ての復習日を取得
+func (q *Queries) GetReviewDatesToUpdateByBatch(ctx context.Context, arg GetReviewDatesToUpdateByBatchParams) ([]GetReviewDatesToUpdateByBatchRow, error) {
+	rows, err := q.db.Query(ctx, getReviewDatesToUpdateByBatch, arg.UserIds, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReviewDatesToUpdateByBatchRow{}
+	for rows.Next() {
+		var i GetReviewDatesToUpdateByBatchRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ItemID,
+			&i.StepNumber,
+			&i.ScheduledDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
 
-const updateOverdueScheduledDatesAndSlideFutureDates = `-- name: UpdateOverdueScheduledDatesAndSlideFutureDates :exec
+const getUserIDsByTimezones = `-- name: GetUserIDsByTimezones :many
+SELECT
+    id
+FROM
+    users
+WHERE
+    timezone = ANY($1::text[])
+AND
+    ($2::uuid IS NULL OR id > $2::uuid)
+ORDER BY
+    id
+LIMIT
+    $3
+`
+
+type GetUserIDsByTimezonesParams struct {
+	Timezones   []string    `json:"timezones"`
+	AfterUserID pgtype.UUID `json:"after_user_id"`
+	ChunkSize   int32       `json:"chunk_size"`
+}
+
+// タイムゾーンのユーザーをID順に上限件数ずつ取得。after_user_idより後のユーザーを返す（NULLの場合は最初から）
+func (q *Queries) GetUserIDsByTimezones(ctx context.Context, arg GetUserIDsByTimezonesParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, getUserIDsByTimezones, arg.Timezones, arg.AfterUserID, arg.ChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserTimezones = `-- name: GetUserTimezones :many
+SELECT DISTINCT
+    timezone
+FROM
+    users
+ORDER BY
+    timezone
+`
+
+// 日付けを跨いだタイムゾーンを判定するために、ユーザーが設定しているタイムゾーンを取得
+func (q *Queries) GetUserTimezones(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, getUserTimezones)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var timezone string
+		if err := rows.Scan(&timezone); err != nil {
+			return nil, err
+		}
+		items = append(items, timezone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveOverdueScheduledDatesByItemID = `-- name: MoveOverdueScheduledDatesByItemID :execrows
+UPDATE review_dates
+    SET
+        scheduled_date = next_schedulable_date($1::date, user_id, $2::smallint[])
+    WHERE
+        item_id = $3
+    AND
+        scheduled_date < $4::date
+    AND
+        is_completed = FALSE
+    AND
+        scheduled_at IS NULL
+`
+
+type MoveOverdueScheduledDatesByItemIDParams struct {
+	NewDate          pgtype.Date `json:"new_date"`
+	ExcludedWeekdays []int16     `json:"excluded_weekdays"`
+	ItemID           pgtype.UUID `json:"item_id"`
+	Today            pgtype.Date `json:"today"`
+}
+
+// 期限切れの復習物の期限切れの復習日だけを繰り越し先の日付に移す（後続の復習日はそのまま）
+// 繰り越し先の日付が除外する曜日や復習日を置かない日付の場合は、次の復習日を置ける日にする
+func (q *Queries) MoveOverdueScheduledDatesByItemID(ctx context.Context, arg MoveOverdueScheduledDatesByItemIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveOverdueScheduledDatesByItemID,
+		arg.NewDate,
+		arg.ExcludedWeekdays,
+		arg.ItemID,
+		arg.Today,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const moveOverdueScheduledDatesToToday = `-- name: MoveOverdueScheduledDatesToToday :execrows
+UPDATE review_dates rd
+    SET
+        scheduled_date = next_schedulable_date(($1::timestamptz AT TIME ZONE u.timezone)::date, rd.user_id, u.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}'))
+    FROM
+        review_items ri
+    JOIN
+        users u
+    ON
+        u.id = ri.user_id
+    LEFT JOIN
+        review_patterns rp
+    ON
+        rp.id = ri.pattern_id
+    WHERE
+        rd.item_id = ri.id
+    AND
+        rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL
+    AND
+        rd.scheduled_date < ($1::timestamptz AT TIME ZONE u.timezone)::date
+    AND
+        rd.user_id = ANY($2::uuid[])
+    AND
+        u.daily_review_limit = 0
+    AND
+        u.overdue_policy = 'slide_overdue_only'
+    AND
+        NOT EXISTS (
+            SELECT
+                1
+            FROM
+                user_pauses up
+            WHERE
+                up.user_id = u.id
+            AND
+                ($1::timestamptz AT TIME ZONE u.timezone)::date BETWEEN up.start_date AND up.end_date
+        )
+`
+
+type MoveOverdueScheduledDatesToTodayParams struct {
+	Now     pgtype.Timestamptz `json:"now"`
+	UserIds []pgtype.UUID      `json:"user_ids"`
+}
+
+// 1日あたりの復習数の上限がなく、期限切れの復習日の扱いがslide_overdue_onlyのユーザーの期限切れの復習日だけを今日に移す（後続の復習日はそのまま）
+// 今日が除外する曜日（ユーザーとパターンの設定）や復習日を置かない日付の場合は、次の復習日を置ける日にする
+// 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+func (q *Queries) MoveOverdueScheduledDatesToToday(ctx context.Context, arg MoveOverdueScheduledDatesToTodayParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveOverdueScheduledDatesToToday, arg.Now, arg.UserIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const resetOverdueItemsToFirstStep = `-- name: ResetOverdueItemsToFirstStep :execrows
+WITH c AS (
+    SELECT
+        ri.id AS item_id,
+        (($1::timestamptz AT TIME ZONE u.timezone)::date - (
+            SELECT
+                f.scheduled_date
+            FROM
+                review_dates f
+            WHERE
+                f.item_id = ri.id
+            ORDER BY
+                f.step_number
+            LIMIT 1
+        )) AS delta_days,
+        u.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}') AS excluded_weekdays
+    FROM
+        review_dates rd
+    JOIN
+        review_items ri
+    ON
+        ri.id = rd.item_id
+    JOIN
+        users u
+    ON
+        u.id = ri.user_id
+    LEFT JOIN
+        review_patterns rp
+    ON
+        rp.id = ri.pattern_id
+    WHERE
+        rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL
+    AND
+        rd.scheduled_date < ($1::timestamptz AT TIME ZONE u.timezone)::date
+    AND
+        rd.user_id = ANY($2::uuid[])
+    AND
+        u.daily_review_limit = 0
+    AND
+        u.overdue_policy = 'reset_to_first_step'
+    AND
+        COALESCE(rp.scheduling_algorithm, 'fixed') <> 'sm2_adaptive'
+    AND
+        NOT EXISTS (
+            SELECT
+                1
+            FROM
+                user_pauses up
+            WHERE
+                up.user_id = u.id
+            AND
+                ($1::timestamptz AT TIME ZONE u.timezone)::date BETWEEN up.start_date AND up.end_date
+        )
+    GROUP BY
+        ri.id, u.timezone, u.excluded_weekdays, rp.excluded_weekdays
+)
+UPDATE review_dates rd
+    SET
+        scheduled_date = CASE
+            WHEN rd.scheduled_at IS NULL THEN next_schedulable_date(rd.scheduled_date + c.delta_days, rd.user_id, c.excluded_weekdays)
+            ELSE rd.scheduled_date + c.delta_days
+        END,
+        scheduled_at = rd.scheduled_at + make_interval(days => c.delta_days),
+        is_completed = FALSE,
+        recall_grade = NULL
+    FROM
+        c
+    WHERE
+        rd.item_id = c.item_id
+`
+
+type ResetOverdueItemsToFirstStepParams struct {
+	Now     pgtype.Timestamptz `json:"now"`
+	UserIds []pgtype.UUID      `json:"user_ids"`
+}
+
+// 1日あたりの復習数の上限がなく、期限切れの復習日の扱いがreset_to_first_stepのユーザーの期限切れの復習物を1ステップ目からやり直す
+// 1ステップ目が今日になるように全ての復習日をずらし、完了済みの復習日も未完了に戻す（ステップ間の間隔は元のまま）
+// ずらした先が除外する曜日（ユーザーとパターンの設定）や復習日を置かない日付の場合は、次の復習日を置ける日にする
+// 適応型のパターンの復習物は復習日を1件ずつ生成しているため対象外（slide_allと同じ扱い）
+func (q *Queries) ResetOverdueItemsToFirstStep(ctx context.Context, arg ResetOverdueItemsToFirstStepParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resetOverdueItemsToFirstStep, arg.Now, arg.UserIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const resetScheduledDatesToFirstStepByItemID = `-- name: ResetScheduledDatesToFirstStepByItemID :execrows
+WITH c AS (
+    SELECT
+        ($1::date - f.scheduled_date) AS delta_days
+    FROM
+        review_dates f
+    WHERE
+        f.item_id = $2
+    ORDER BY
+        f.step_number
+    LIMIT 1
+)
+UPDATE review_dates rd
+    SET
+        scheduled_date = CASE
+            WHEN rd.scheduled_at IS NULL THEN next_schedulable_date(rd.scheduled_date + c.delta_days, rd.user_id, $3::smallint[])
+            ELSE rd.scheduled_date + c.delta_days
+        END,
+        scheduled_at = rd.scheduled_at + make_interval(days => c.delta_days),
+        is_completed = FALSE,
+        recall_grade = NULL
+    FROM
+        c
+    WHERE
+        rd.item_id = $2
+`
+
+type ResetScheduledDatesToFirstStepByItemIDParams struct {
+	NewDate          pgtype.Date `json:"new_date"`
+	ItemID           pgtype.UUID `json:"item_id"`
+	ExcludedWeekdays []int16     `json:"excluded_weekdays"`
+}
+
+// 期限切れの復習物を1ステップ目からやり直す。1ステップ目が繰り越し先の日付になるように全ての復習日をずらし、完了済みの復習日も未完了に戻す（ステップ間の間隔は元のまま）
+// ずらした先が除外する曜日や復習日を置かない日付の場合は、次の復習日を置ける日にする
+func (q *Queries) ResetScheduledDatesToFirstStepByItemID(ctx context.Context, arg ResetScheduledDatesToFirstStepByItemIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resetScheduledDatesToFirstStepByItemID, arg.NewDate, arg.ItemID, arg.ExcludedWeekdays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const slideScheduledDatesByItemID = `-- name: SlideScheduledDatesByItemID :execrows
+UPDATE review_dates
+    SET
+        scheduled_date = next_schedulable_date(scheduled_date + ($1::date - $2::date), user_id, $3::smallint[])
+    WHERE
+        item_id = $4
+    AND
+        scheduled_date >= $2::date
+    AND
+        is_completed = FALSE
+    AND
+        scheduled_at IS NULL
+`
+
+type SlideScheduledDatesByItemIDParams struct {
+	NewDate          pgtype.Date `json:"new_date"`
+	OldDate          pgtype.Date `json:"old_date"`
+	ExcludedWeekdays []int16     `json:"excluded_weekdays"`
+	ItemID           pgtype.UUID `json:"item_id"`
+}
+
+// 期限切れの復習物の未完了の復習日を、繰り越し先の日付までずらす（後続の復習日も同じ日数だけずらす）
+// 後続の復習日がずらした先で除外する曜日や復習日を置かない日付になる場合は、次の復習日を置ける日にする
+func (q *Queries) SlideScheduledDatesByItemID(ctx context.Context, arg SlideScheduledDatesByItemIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, slideScheduledDatesByItemID,
+		arg.NewDate,
+		arg.OldDate,
+		arg.ExcludedWeekdays,
+		arg.ItemID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateOverdueScheduledDatesAndSlideFutureDates = `-- name: UpdateOverdueScheduledDatesAndSlideFutureDates :execrows
 WITH c AS (
     SELECT
         ri.id AS item_id,
     MIN(rd.scheduled_date) AS old_date,
-    (now() AT TIME ZONE u.timezone)::date AS today_local,
-    ((now() AT TIME ZONE u.timezone)::date - MIN(rd.scheduled_date)) AS delta_days
+    ($1::timestamptz AT TIME ZONE u.timezone)::date AS today_local,
+    (($1::timestamptz AT TIME ZONE u.timezone)::date - MIN(rd.scheduled_date)) AS delta_days,
+    u.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}') AS excluded_weekdays
     FROM 
         review_dates rd
     JOIN 
@@ -26,16 +668,43 @@ WITH c AS (
         users u
     ON
         u.id  = ri.user_id
+    LEFT JOIN
+        review_patterns rp
+    ON
+        rp.id = ri.pattern_id
     WHERE
         rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL
     AND 
-        rd.scheduled_date < (now() AT TIME ZONE u.timezone)::date
+        rd.scheduled_date < ($1::timestamptz AT TIME ZONE u.timezone)::date
+    AND
+        rd.user_id = ANY($2::uuid[])
+    AND
+        u.daily_review_limit = 0
+    AND
+        (
+            u.overdue_policy = 'slide_all'
+        OR
+            (u.overdue_policy = 'reset_to_first_step' AND rp.scheduling_algorithm = 'sm2_adaptive')
+        )
+    AND
+        NOT EXISTS (
+            SELECT
+                1
+            FROM
+                user_pauses up
+            WHERE
+                up.user_id = u.id
+            AND
+                ($1::timestamptz AT TIME ZONE u.timezone)::date BETWEEN up.start_date AND up.end_date
+        )
     GROUP BY 
-        ri.id, u.timezone
+        ri.id, u.timezone, u.excluded_weekdays, rp.excluded_weekdays
 )
 UPDATE review_dates rd
     SET 
-        scheduled_date = rd.scheduled_date + c.delta_days
+        scheduled_date = next_schedulable_date(rd.scheduled_date + c.delta_days, rd.user_id, c.excluded_weekdays)
     FROM 
         c
     WHERE
@@ -44,9 +713,23 @@ UPDATE review_dates rd
         rd.scheduled_date >= c.old_date
     AND
         rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL
 `
 
-func (q *Queries) UpdateOverdueScheduledDatesAndSlideFutureDates(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, updateOverdueScheduledDatesAndSlideFutureDates)
-	return err
+type UpdateOverdueScheduledDatesAndSlideFutureDatesParams struct {
+	Now     pgtype.Timestamptz `json:"now"`
+	UserIds []pgtype.UUID      `json:"user_ids"`
+}
+
+// 1日あたりの復習数の上限がないユーザーの期限切れの復習日を今日にずらし、後続の復習日も同じ日数だけずらす
+// ずらした先が除外する曜日（ユーザーとパターンの設定）や復習日を置かない日付の場合は、次の復習日を置ける日にする
+// 期限切れの復習日の扱いがslide_allのユーザーが対象。reset_to_first_stepのユーザーでも適応型のパターンの復習物は1ステップ目に戻せないためこちらで扱う
+// 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+func (q *Queries) UpdateOverdueScheduledDatesAndSlideFutureDates(ctx context.Context, arg UpdateOverdueScheduledDatesAndSlideFutureDatesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateOverdueScheduledDatesAndSlideFutureDates, arg.Now, arg.UserIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
@@ -97,7 +97,10 @@ SELECT
     email,
     timezone,
     theme_color,
-    language
+    language,
+    daily_review_limit,
+    excluded_weekdays,
+    overdue_policy
 FROM
     users
 WHERE
@@ -105,10 +108,13 @@ WHERE
 `
 
 type GetUserSettingByIDRow struct {
-	Email      string         `json:"email"`
-	Timezone   string         `json:"timezone"`
-	ThemeColor ThemeColorEnum `json:"theme_color"`
-	Language   string         `json:"language"`
+	Email            string            `json:"email"`
+	Timezone         string            `json:"timezone"`
+	ThemeColor       ThemeColorEnum    `json:"theme_color"`
+	Language         string            `json:"language"`
+	DailyReviewLimit int16             `json:"daily_review_limit"`
+	ExcludedWeekdays []int16           `json:"excluded_weekdays"`
+	OverduePolicy    OverduePolicyEnum `json:"overdue_policy"`
 }
 
 func (q *Queries) GetUserSettingByID(ctx context.Context, id pgtype.UUID) (GetUserSettingByIDRow, error) {
@@ -119,6 +125,9 @@ func (q *Queries) GetUserSettingByID(ctx context.Context, id pgtype.UUID) (GetUs
 		&i.Timezone,
 		&i.ThemeColor,
 		&i.Language,
+		&i.DailyReviewLimit,
+		&i.ExcludedWeekdays,
+		&i.OverduePolicy,
 	)
 	return i, err
 }
@@ -131,18 +140,24 @@ SET
     email = $2,
     timezone = $3,
     theme_color = $4,
-    language = $5
+    language = $5,
+    daily_review_limit = $6,
+    excluded_weekdays = $7,
+    overdue_policy = $8
 WHERE
-    id = $6
+    id = $9
 `
 
 type UpdateUserParams struct {
-	EmailSearchKey string         `json:"email_search_key"`
-	Email          string         `json:"email"`
-	Timezone       string         `json:"timezone"`
-	ThemeColor     ThemeColorEnum `json:"theme_color"`
-	Language       string         `json:"language"`
-	ID             pgtype.UUID    `json:"id"`
+	EmailSearchKey   string            `json:"email_search_key"`
+	Email            string            `json:"email"`
+	Timezone         string            `json:"timezone"`
+	ThemeColor       ThemeColorEnum    `json:"theme_color"`
+	Language         string            `json:"language"`
+	DailyReviewLimit int16             `json:"daily_review_limit"`
+	ExcludedWeekdays []int16           `json:"excluded_weekdays"`
+	OverduePolicy    OverduePolicyEnum `json:"overdue_policy"`
+	ID               pgtype.UUID       `json:"id"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
@@ -152,6 +167,9 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 		arg.Timezone,
 		arg.ThemeColor,
 		arg.Language,
+		arg.DailyReviewLimit,
+		arg.ExcludedWeekdays,
+		arg.OverduePolicy,
 		arg.ID,
 	)
 	return err
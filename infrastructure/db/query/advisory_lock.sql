@@ -0,0 +1,7 @@
+-- バッチ処理を複数のレプリカで動かす時に、1つのレプリカだけが実行するためのセッション単位のロック。ロックの名前からキーを作る
+-- name: TryAdvisoryLock :one
+SELECT pg_try_advisory_lock(hashtext(sqlc.arg(lock_name)::text)) AS acquired;
+
+-- ロックを保持していなかった場合（接続が切れてロックを失った場合など）はfalseを返す
+-- name: AdvisoryUnlock :one
+SELECT pg_advisory_unlock(hashtext(sqlc.arg(lock_name)::text)) AS released;
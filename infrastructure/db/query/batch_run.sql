@@ -0,0 +1,64 @@
+-- 同じ実行枠の実行記録がある場合と、同じバッチ処理が実行中の場合は作成しない。作成できたかは影響行数で判定する
+-- name: CreateBatchRun :execrows
+INSERT INTO batch_runs (
+    id,
+    job_name,
+    window_start,
+    caught_up_from,
+    status,
+    started_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+)
+ON CONFLICT DO NOTHING;
+
+-- 実行中のまま残っている実行記録（実行中にプロセスが停止した場合など）を失敗にする
+-- name: FailStaleBatchRuns :exec
+UPDATE
+    batch_runs
+SET
+    status        = 'failed',
+    error_message = sqlc.arg(error_message),
+    finished_at   = sqlc.arg(finished_at)
+WHERE
+    job_name = sqlc.arg(job_name)
+AND
+    status = 'running'
+AND
+    started_at < sqlc.arg(started_before);
+
+-- name: FinishBatchRun :exec
+UPDATE
+    batch_runs
+SET
+    status        = $1,
+    rows_affected = $2,
+    error_message = $3,
+    finished_at   = $4
+WHERE
+    id = $5;
+
+-- 実行されなかった実行枠を判定するために使う。実行記録がない場合はNULL
+-- name: GetLatestBatchRunWindowStart :one
+SELECT
+    MAX(window_start)::timestamptz AS window_start
+FROM
+    batch_runs
+WHERE
+    job_name = $1;
+
+-- 日付けを跨いだタイムゾーンを判定するために使う。失敗した実行は一部のユーザーしか処理していない場合があるため対象外。成功した実行記録がない場合はNULL
+-- name: GetLatestSucceededBatchRunWindowStart :one
+SELECT
+    MAX(window_start)::timestamptz AS window_start
+FROM
+    batch_runs
+WHERE
+    job_name = $1
+AND
+    status = 'succeeded';
@@ -0,0 +1,86 @@
+-- 既に同じ日付が登録されている場合は何もしない
+-- name: CreateBlockedDate :exec
+INSERT INTO user_blocked_dates (
+    id,
+    user_id,
+    blocked_date,
+    name
+) VALUES (
+    sqlc.arg(id),
+    sqlc.arg(user_id),
+    sqlc.arg(blocked_date),
+    sqlc.arg(name)
+)
+ON CONFLICT (user_id, blocked_date) DO NOTHING;
+
+-- name: GetBlockedDatesByUserID :many
+SELECT
+    id,
+    user_id,
+    blocked_date,
+    name
+FROM
+    user_blocked_dates
+WHERE
+    user_id = sqlc.arg(user_id)
+ORDER BY
+    blocked_date;
+
+-- name: GetBlockedDateByID :one
+SELECT
+    id,
+    user_id,
+    blocked_date,
+    name
+FROM
+    user_blocked_dates
+WHERE
+    id = sqlc.arg(id)
+AND
+    user_id = sqlc.arg(user_id);
+
+-- name: UpdateBlockedDate :exec
+UPDATE user_blocked_dates
+    SET
+        blocked_date = sqlc.arg(blocked_date),
+        name = sqlc.arg(name),
+        updated_at = now()
+    WHERE
+        id = sqlc.arg(id)
+    AND
+        user_id = sqlc.arg(user_id);
+
+-- name: DeleteBlockedDate :exec
+DELETE FROM user_blocked_dates
+WHERE
+    id = sqlc.arg(id)
+AND
+    user_id = sqlc.arg(user_id);
+
+-- 復習日を置かない日付にある未完了の復習日を、次の復習日を置ける日（除外する曜日はユーザーとパターンの設定）にずらす（後続の復習日はそのまま）
+-- 復習日を置かない日付の登録と同じトランザクションで、登録した後に実行する
+-- 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+-- name: MoveReviewDatesOffBlockedDates :execrows
+UPDATE review_dates rd
+    SET
+        scheduled_date = next_schedulable_date(rd.scheduled_date, rd.user_id, u.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}'))
+    FROM
+        review_items ri
+    JOIN
+        users u
+    ON
+        u.id = ri.user_id
+    LEFT JOIN
+        review_patterns rp
+    ON
+        rp.id = ri.pattern_id
+    WHERE
+        rd.item_id = ri.id
+    AND
+        rd.user_id = sqlc.arg(user_id)
+    AND
+        rd.scheduled_date = ANY(sqlc.arg(blocked_dates)::date[])
+    AND
+        rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL;
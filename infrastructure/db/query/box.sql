@@ -65,7 +65,8 @@ WHERE
 AND
     user_id = sqlc.arg(user_id);
 
--- name: UpdateBoxIfNoReviewItems :execrows
+-- ボックスのパターン変更。ボックス内の復習物の復習日の組み直しは呼び出し側で同一トランザクションで行う
+-- name: UpdateBoxWithPatternID :execrows
 UPDATE
     review_boxes
 SET
@@ -77,18 +78,38 @@ WHERE
 AND
     review_boxes.category_id = sqlc.arg(category_id)
 AND
-    review_boxes.user_id = sqlc.arg(user_id)
-AND NOT EXISTS (
-            SELECT 
-                1
-            FROM 
-                review_items
-            WHERE 
-                review_items.box_id  = sqlc.arg(box_id)
-            AND 
-                review_items.user_id = sqlc.arg(user_id)
-            );
+    review_boxes.user_id = sqlc.arg(user_id);
 
+-- パターンの使用箇所の一覧用。パターンを使うボックスを取得
+-- name: GetAllBoxesByPatternID :many
+SELECT
+    id,
+    user_id,
+    category_id,
+    pattern_id,
+    name,
+    registered_at,
+    edited_at
+FROM
+    review_boxes
+WHERE
+    pattern_id = sqlc.arg(pattern_id)
+AND
+    user_id = sqlc.arg(user_id)
+ORDER BY
+    registered_at;
+
+-- パターンの削除時に、パターンを使うボックスを別のパターンに付け替える
+-- name: UpdateBoxesPatternIDByPatternID :execrows
+UPDATE
+    review_boxes
+SET
+    pattern_id = sqlc.arg(new_pattern_id),
+    edited_at  = sqlc.arg(edited_at)
+WHERE
+    pattern_id = sqlc.arg(pattern_id)
+AND
+    user_id = sqlc.arg(user_id);
 
 -- name: DeleteBox :exec
 DELETE FROM
@@ -1,3 +1,4 @@
+-- パターンの版は、パターンの最新の版（復習日の算出に使った現在のステップの版）を割り当てる
 -- name: CreateItem :exec
 INSERT INTO
     review_items (
@@ -6,6 +7,7 @@ INSERT INTO
         category_id,
         box_id,
         pattern_id,
+        pattern_version_id,
         name,
         detail,
         learned_date,
@@ -19,6 +21,17 @@ VALUES (
     sqlc.arg(category_id),
     sqlc.arg(box_id),
     sqlc.arg(pattern_id),
+    (
+        SELECT
+            pv.id
+        FROM
+            pattern_versions pv
+        WHERE
+            pv.pattern_id = sqlc.arg(pattern_id)
+        ORDER BY
+            pv.version_number DESC
+        LIMIT 1
+    ),
     sqlc.arg(name),
     sqlc.arg(detail),
     sqlc.arg(learned_date),
@@ -39,7 +52,8 @@ INSERT INTO
         step_number,
         initial_scheduled_date,
         scheduled_date,
-        is_completed
+        is_completed,
+        scheduled_at
     ) VALUES (
         sqlc.arg(id),
         sqlc.arg(user_id),
@@ -49,7 +63,8 @@ INSERT INTO
         sqlc.arg(step_number),
         sqlc.arg(initial_scheduled_date),
         sqlc.arg(scheduled_date),
-        sqlc.arg(is_completed)
+        sqlc.arg(is_completed),
+        sqlc.arg(scheduled_at)
     );
 
 
@@ -61,6 +76,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -102,12 +118,27 @@ ORDER BY
     step_number;
 
 -- 移動、完了、学習日変更、その他編集に使う
+-- パターンを変更する場合は、変更後のパターンの最新の版を割り当てる。同じパターンのままの場合は版を変えない
 -- name: UpdateItem :exec
 UPDATE
     review_items
 SET
     category_id = sqlc.arg(category_id),
     box_id = sqlc.arg(box_id),
+    pattern_version_id = CASE
+        WHEN review_items.pattern_id IS NOT DISTINCT FROM sqlc.arg(pattern_id) THEN review_items.pattern_version_id
+        ELSE (
+            SELECT
+                pv.id
+            FROM
+                pattern_versions pv
+            WHERE
+                pv.pattern_id = sqlc.arg(pattern_id)
+            ORDER BY
+                pv.version_number DESC
+            LIMIT 1
+        )
+    END,
     pattern_id = sqlc.arg(pattern_id),
     name = sqlc.arg(name),
     detail = sqlc.arg(detail),
@@ -127,11 +158,12 @@ SET
     box_id = v.box_id,
     initial_scheduled_date = v.initial_scheduled_date,
     scheduled_date = v.scheduled_date,
-    is_completed = v.is_completed
+    is_completed = v.is_completed,
+    scheduled_at = v.scheduled_at
 FROM
     UNNEST(
         sqlc.arg(input)::reviewdate_input[]
-    ) AS v(id, category_id, box_id, initial_scheduled_date, scheduled_date, is_completed)
+    ) AS v(id, category_id, box_id, initial_scheduled_date, scheduled_date, is_completed, scheduled_at)
 WHERE
     r.id = v.id
 AND
@@ -177,11 +209,144 @@ WHERE
 AND
     user_id = sqlc.arg(user_id);
 
+-- ボックスのパターン変更で組み直す間に、復習日の完了や編集で組み直す前の状態が変わらないように、ボックス内の未完了の復習物の復習日と復習物の行をロックする
+-- 復習日の完了と同じく、復習日、復習物の順にロックする
+-- name: LockReviewDatesOfUnFinishedItemsByBoxID :exec
+SELECT
+    rd.id
+FROM
+    review_dates rd
+JOIN
+    review_items ri
+ON
+    ri.id = rd.item_id
+WHERE
+    ri.box_id = sqlc.arg(box_id)
+AND
+    ri.user_id = sqlc.arg(user_id)
+AND
+    ri.is_finished = FALSE
+ORDER BY
+    rd.id
+FOR UPDATE OF rd;
+
+-- name: LockUnFinishedItemsByBoxID :exec
+SELECT
+    id
+FROM
+    review_items
+WHERE
+    box_id = sqlc.arg(box_id)
+AND
+    user_id = sqlc.arg(user_id)
+AND
+    is_finished = FALSE
+ORDER BY
+    id
+FOR UPDATE;
+
+-- パターンのステップ変更や削除時の付け替えで組み直す間に、復習日の完了や編集で組み直す前の状態が変わらないように、パターンを使う未完了の復習物の復習日と復習物の行をロックする
+-- 復習日の完了と同じく、復習日、復習物の順にロックする
+-- name: LockReviewDatesOfUnFinishedItemsByPatternID :exec
+SELECT
+    rd.id
+FROM
+    review_dates rd
+JOIN
+    review_items ri
+ON
+    ri.id = rd.item_id
+WHERE
+    ri.pattern_id = sqlc.arg(pattern_id)
+AND
+    ri.user_id = sqlc.arg(user_id)
+AND
+    ri.is_finished = FALSE
+ORDER BY
+    rd.id
+FOR UPDATE OF rd;
+
+-- name: LockUnFinishedItemsByPatternID :exec
+SELECT
+    id
+FROM
+    review_items
+WHERE
+    pattern_id = sqlc.arg(pattern_id)
+AND
+    user_id = sqlc.arg(user_id)
+AND
+    is_finished = FALSE
+ORDER BY
+    id
+FOR UPDATE;
+
+-- ボックスのパターン変更時に、ボックス内の未完了の復習物のパターンも変更する
+-- 完了済みの復習物は完了した時のパターンと版のまま残す
+-- 復習日は変更後のパターンの現在のステップで組み直すため、パターンの最新の版を割り当てる
+-- name: UpdateItemsPatternIDByBoxID :exec
+UPDATE
+    review_items
+SET
+    pattern_id = sqlc.arg(pattern_id),
+    pattern_version_id = (
+        SELECT
+            pv.id
+        FROM
+            pattern_versions pv
+        WHERE
+            pv.pattern_id = sqlc.arg(pattern_id)
+        ORDER BY
+            pv.version_number DESC
+        LIMIT 1
+    )
+WHERE
+    box_id = sqlc.arg(box_id)
+AND
+    user_id = sqlc.arg(user_id)
+AND
+    is_finished = FALSE;
+
+-- パターンの削除時に、パターンを使う復習物（完了済みを含む）を別のパターンに付け替える
+-- 未完了の復習日は付け替え先のパターンの現在のステップで組み直すため、付け替え先の最新の版を割り当てる
+-- name: UpdateItemsPatternIDByPatternID :execrows
+UPDATE
+    review_items
+SET
+    pattern_id = sqlc.arg(new_pattern_id),
+    pattern_version_id = (
+        SELECT
+            pv.id
+        FROM
+            pattern_versions pv
+        WHERE
+            pv.pattern_id = sqlc.arg(new_pattern_id)
+        ORDER BY
+            pv.version_number DESC
+        LIMIT 1
+    )
+WHERE
+    pattern_id = sqlc.arg(pattern_id)
+AND
+    user_id = sqlc.arg(user_id);
+
+-- パターンのステップ変更で復習日を組み直した復習物に、変更後の版を割り当てる
+-- name: UpdateItemPatternVersionID :exec
+UPDATE
+    review_items
+SET
+    pattern_version_id = sqlc.arg(pattern_version_id)
+WHERE
+    id = sqlc.arg(id)
+AND
+    user_id = sqlc.arg(user_id);
+
 -- name: UpdateReviewDateAsCompleted :exec
 UPDATE
     review_dates
 SET
-    is_completed = true
+    is_completed = true,
+    recall_grade = sqlc.narg(recall_grade)
 WHERE
     id = sqlc.arg(id)
 AND
@@ -191,7 +356,8 @@ AND
 UPDATE
     review_dates
 SET
-    is_completed = false
+    is_completed = false,
+    recall_grade = NULL
 WHERE
     id = sqlc.arg(id)
 AND
@@ -207,7 +373,9 @@ SELECT
     step_number,
     initial_scheduled_date,
     scheduled_date,
-    is_completed
+    is_completed,
+    recall_grade,
+    scheduled_at
 FROM
     review_dates
 WHERE
@@ -237,6 +405,21 @@ AND
     user_id = sqlc.arg(user_id);
 
 
+-- 適応型の復習日の完了を取り消したときに、生成済みの次の復習日を削除する
+-- name: DeleteInCompletedReviewDatesAfterStep :exec
+DELETE
+FROM
+    review_dates
+WHERE
+    item_id = sqlc.arg(item_id)
+AND
+    user_id = sqlc.arg(user_id)
+AND
+    step_number > sqlc.arg(step_number)
+AND
+    is_completed = false;
+
+
 -- ボックス内画面用の未完了の全復習物一覧取得機能（復習物（親）のみ一覧取得）
 -- name: GetAllUnFinishedItemsByBoxID :many
 SELECT
@@ -245,6 +428,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -273,7 +457,9 @@ SELECT
     step_number,
     initial_scheduled_date,
     scheduled_date,
-    is_completed
+    is_completed,
+    recall_grade,
+    scheduled_at
 FROM
     review_dates
 WHERE
@@ -292,6 +478,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -321,7 +508,9 @@ SELECT
     step_number,
     initial_scheduled_date,
     scheduled_date,
-    is_completed
+    is_completed,
+    recall_grade,
+    scheduled_at
 FROM
     review_dates
 WHERE
@@ -341,6 +530,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -370,7 +560,9 @@ SELECT
     step_number,
     initial_scheduled_date,
     scheduled_date,
-    is_completed
+    is_completed,
+    recall_grade,
+    scheduled_at
 FROM
     review_dates
 WHERE
@@ -481,6 +673,92 @@ AND
 AND
     box_id IS NULL;
 
+-- 復習量の予測系
+-- 指定期間の未完了の復習日数を、復習日とボックス毎に集計
+-- name: CountForecastDatesGroupedByBoxByUserID :many
+SELECT
+    scheduled_date,
+    category_id,
+    box_id,
+    COUNT(*) AS count
+FROM
+    review_dates
+WHERE
+    user_id = sqlc.arg(user_id)
+AND
+    scheduled_date BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date)
+AND
+    is_completed = false
+AND
+    box_id IS NOT NULL
+GROUP BY
+    scheduled_date,
+    category_id,
+    box_id
+ORDER BY
+    scheduled_date;
+
+-- 指定期間の未分類の未完了の復習日数を、復習日とカテゴリー毎に集計（category_idがNULLのものはホーム画面の未分類）
+-- name: CountForecastDatesUnclassifiedGroupedByCategoryByUserID :many
+SELECT
+    scheduled_date,
+    category_id,
+    COUNT(*) AS count
+FROM
+    review_dates
+WHERE
+    user_id = sqlc.arg(user_id)
+AND
+    scheduled_date BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date)
+AND
+    is_completed = false
+AND
+    box_id IS NULL
+GROUP BY
+    scheduled_date,
+    category_id
+ORDER BY
+    scheduled_date;
+
+-- 復習日の負荷分散用
+-- 指定期間の未完了の復習日数（分・時間単位のステップの復習日を除く）を、ボックスやカテゴリーを区別せず復習日毎に集計
+-- name: CountScheduledDatesGroupedByDateByUserID :many
+SELECT
+    scheduled_date,
+    COUNT(*) AS count
+FROM
+    review_dates
+WHERE
+    user_id = sqlc.arg(user_id)
+AND
+    scheduled_date BETWEEN sqlc.arg(from_date) AND sqlc.arg(to_date)
+AND
+    is_completed = false
+AND
+    scheduled_at IS NULL
+GROUP BY
+    scheduled_date
+ORDER BY
+    scheduled_date;
+
+-- 1日あたりの復習数の上限を超える復習日の繰り越し用
+-- name: GetDailyReviewLimitByUserID :one
+SELECT
+    daily_review_limit
+FROM
+    users
+WHERE
+    id = sqlc.arg(user_id);
+
+-- 復習日を置かない曜日のユーザー設定（パターンの設定と合わせて使う）
+-- name: GetExcludedWeekdaysByUserID :one
+SELECT
+    excluded_weekdays
+FROM
+    users
+WHERE
+    id = sqlc.arg(user_id);
+
 -- EditedAt取得専用
 -- name: GetEditedAtByItemID :one
 SELECT
@@ -505,20 +783,101 @@ SELECT EXISTS (
         user_id = sqlc.arg(user_id)
 );
 
+-- パターンの使用箇所の一覧用。パターンを使う復習物（完了済みを含む）を取得
+-- name: GetAllItemsByPatternID :many
+SELECT
+    id,
+    user_id,
+    category_id,
+    box_id,
+    pattern_id,
+    pattern_version_id,
+    name,
+    detail,
+    learned_date,
+    is_Finished,
+    registered_at,
+    edited_at
+FROM
+    review_items
+WHERE
+    pattern_id = sqlc.arg(pattern_id)
+AND
+    user_id = sqlc.arg(user_id)
+ORDER BY
+    registered_at;
+
+-- パターンのステップ変更時に、既存の復習日を組み直す対象の復習物を取得
+-- name: GetAllUnFinishedItemsByPatternID :many
+SELECT
+    id,
+    user_id,
+    category_id,
+    box_id,
+    pattern_id,
+    pattern_version_id,
+    name,
+    detail,
+    learned_date,
+    is_Finished,
+    registered_at,
+    edited_at
+FROM
+    review_items
+WHERE
+    pattern_id = sqlc.arg(pattern_id)
+AND
+    is_Finished = false
+AND
+    user_id = sqlc.arg(user_id)
+ORDER BY
+    registered_at;
+
 -- 今日の全復習日数を取得
+-- 期限切れの復習日の扱いがkeep_overdueのユーザーは、今日の復習日一覧に含める期限切れの復習日も数える
 -- name: CountAllDailyReviewDates :one
 SELECT
     COUNT(*) AS count
 FROM
-    review_dates
+    review_dates rd
+JOIN
+    users u
+ON
+    u.id = rd.user_id
 WHERE
-    user_id = sqlc.arg(user_id)
-AND
-    scheduled_date = sqlc.arg(target_date);
+    rd.user_id = sqlc.arg(user_id)
+AND (
+        rd.scheduled_date = sqlc.arg(target_date)
+    OR (
+            u.overdue_policy = 'keep_overdue'
+        AND
+            rd.is_completed = false
+        AND
+            rd.scheduled_at IS NULL
+        AND
+            rd.scheduled_date < sqlc.arg(target_date)
+        AND
+            NOT EXISTS (
+                SELECT
+                    1
+                FROM
+                    review_dates e
+                WHERE
+                    e.item_id = rd.item_id
+                AND
+                    e.is_completed = false
+                AND
+                    e.scheduled_at IS NULL
+                AND
+                    e.scheduled_date < rd.scheduled_date
+            )
+    )
+);
 
 -- LAG→item_idごとにstep_numberの昇順で並べた時、scheduled_dateが持つstep_numberより一個前のstep_numberのscheduled_dateを取得
 -- LEAD→item_idごとにstep_numberの昇順で並べた時、scheduled_dateが持つstep_numberより一個後のstep_numberのscheduled_dateを取得
 -- 今日の復習日を取得するクエリ
+-- 期限切れの復習日の扱いがkeep_overdueのユーザーは、復習物毎に最初の未完了の期限切れの復習日も期限切れとして含める（分・時間単位のステップの復習日は除く）
 -- name: GetAllDailyReviewDates :many
 SELECT
     rd.id,
@@ -530,6 +889,7 @@ SELECT
     rd.scheduled_date,
     rd.next_scheduled_date,
     rd.is_completed,
+    (rd.scheduled_date < sqlc.arg(today)::date) AS is_overdue,
     ri.id AS item_id,
     ri.name,
     ri.detail,
@@ -545,6 +905,7 @@ FROM (
         step_number,
         initial_scheduled_date,
         scheduled_date,
+        scheduled_at,
         is_completed,
         CAST(
             LAG(scheduled_date) OVER (
@@ -557,7 +918,12 @@ FROM (
         PARTITION BY item_id
         ORDER BY step_number
         ) AS date
-        ) AS next_scheduled_date
+        ) AS next_scheduled_date,
+        CAST(
+            MIN(scheduled_date) FILTER (WHERE is_completed = false AND scheduled_at IS NULL) OVER (
+        PARTITION BY item_id
+        ) AS date
+        ) AS first_incompleted_date
     FROM
         review_dates
     WHERE
@@ -567,13 +933,59 @@ JOIN
     review_items AS ri
 ON
     ri.id = rd.item_id
+JOIN
+    users AS u
+ON
+    u.id = ri.user_id
 WHERE
     rd.scheduled_date = sqlc.arg(today)::date
+OR (
+        u.overdue_policy = 'keep_overdue'
+    AND
+        rd.is_completed = false
+    AND
+        rd.scheduled_at IS NULL
+    AND
+        rd.scheduled_date < sqlc.arg(today)::date
+    AND
+        rd.scheduled_date = rd.first_incompleted_date
+)
 ORDER BY
     rd.category_id    NULLS LAST,
     rd.box_id         NULLS LAST,
     ri.registered_at;
 
+-- 分・時間単位のステップのうち、復習日時を過ぎた未完了の復習日を取得するクエリ
+-- name: GetDueNowReviewDates :many
+SELECT
+    rd.id,
+    rd.category_id,
+    rd.box_id,
+    rd.step_number,
+    rd.scheduled_date,
+    rd.scheduled_at,
+    ri.id AS item_id,
+    ri.name,
+    ri.detail,
+    ri.learned_date
+FROM
+    review_dates AS rd
+JOIN
+    review_items AS ri
+ON
+    ri.id = rd.item_id
+WHERE
+    rd.user_id = sqlc.arg(user_id)::uuid
+AND
+    rd.is_completed = false
+AND
+    rd.scheduled_at <= sqlc.arg(now)::timestamptz
+AND
+    ri.is_finished = false
+ORDER BY
+    rd.scheduled_at,
+    ri.registered_at;
+
 
 
 -- ボックス内画面用の完了の全復習物一覧取得系（復習物（親）のみ一覧取得）
@@ -584,6 +996,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -609,6 +1022,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -635,6 +1049,7 @@ SELECT
     category_id,
     box_id,
     pattern_id,
+    pattern_version_id,
     name,
     detail,
     learned_date,
@@ -5,6 +5,10 @@ INSERT INTO
         user_id,
         name,
         target_weight,
+        scheduling_algorithm,
+        is_load_balanced,
+        excluded_weekdays,
+        maintenance_interval_days,
         registered_at,
         edited_at
     )
@@ -13,6 +17,10 @@ VALUES (
         sqlc.arg(user_id),
         sqlc.arg(name),
         sqlc.arg(target_weight),
+        sqlc.arg(scheduling_algorithm),
+        sqlc.arg(is_load_balanced),
+        sqlc.arg(excluded_weekdays),
+        sqlc.arg(maintenance_interval_days),
         sqlc.arg(registered_at),
         sqlc.arg(edited_at)
     );
@@ -25,13 +33,15 @@ INSERT INTO
         user_id,
         pattern_id,
         step_number,
-        interval_days
+        interval_days,
+        interval_unit
     ) VALUES (
         sqlc.arg(id),
         sqlc.arg(user_id),
         sqlc.arg(pattern_id),
         sqlc.arg(step_number),
-        sqlc.arg(interval_days)
+        sqlc.arg(interval_days),
+        sqlc.arg(interval_unit)
     );
 
 
@@ -42,6 +52,10 @@ SELECT
     user_id,
     name,
     target_weight,
+    scheduling_algorithm,
+    is_load_balanced,
+    excluded_weekdays,
+    maintenance_interval_days,
     registered_at,
     edited_at
 FROM
@@ -58,7 +72,8 @@ SELECT
     user_id,
     pattern_id,
     step_number,
-    interval_days
+    interval_days,
+    interval_unit
 FROM
     pattern_steps
 WHERE
@@ -75,6 +90,10 @@ UPDATE
 SET
     name = sqlc.arg(name),
     target_weight = sqlc.arg(target_weight),
+    scheduling_algorithm = sqlc.arg(scheduling_algorithm),
+    is_load_balanced = sqlc.arg(is_load_balanced),
+    excluded_weekdays = sqlc.arg(excluded_weekdays),
+    maintenance_interval_days = sqlc.arg(maintenance_interval_days),
     edited_at = sqlc.arg(edited_at)
 WHERE
     id = sqlc.arg(id)
@@ -110,6 +129,10 @@ SELECT
     user_id,
     name,
     target_weight,
+    scheduling_algorithm,
+    is_load_balanced,
+    excluded_weekdays,
+    maintenance_interval_days,
     registered_at,
     edited_at
 FROM
@@ -126,7 +149,8 @@ SELECT
     user_id,
     pattern_id,
     step_number,
-    interval_days
+    interval_days,
+    interval_unit
 FROM
     pattern_steps
 WHERE
@@ -145,3 +169,132 @@ FROM
     review_patterns
 WHERE
     id = ANY(sqlc.arg(pattern_ids)::uuid[]);
+
+-- パターンの作成時と、ステップ（方式）の変更時に版を追加する
+-- name: CreatePatternVersion :exec
+INSERT INTO
+    pattern_versions (
+        id,
+        user_id,
+        pattern_id,
+        version_number,
+        scheduling_algorithm,
+        created_at
+    )
+VALUES (
+        sqlc.arg(id),
+        sqlc.arg(user_id),
+        sqlc.arg(pattern_id),
+        sqlc.arg(version_number),
+        sqlc.arg(scheduling_algorithm),
+        sqlc.arg(created_at)
+    );
+
+-- name: CreatePatternVersionSteps :copyfrom
+INSERT INTO
+    pattern_version_steps (
+        id,
+        user_id,
+        pattern_version_id,
+        step_number,
+        interval_days,
+        interval_unit
+    ) VALUES (
+        sqlc.arg(id),
+        sqlc.arg(user_id),
+        sqlc.arg(pattern_version_id),
+        sqlc.arg(step_number),
+        sqlc.arg(interval_days),
+        sqlc.arg(interval_unit)
+    );
+
+-- 次の版番号の採番と、復習物に割り当てる現在の版の取得に使う
+-- name: GetLatestPatternVersionByPatternID :one
+SELECT
+    id,
+    user_id,
+    pattern_id,
+    version_number,
+    scheduling_algorithm,
+    created_at
+FROM
+    pattern_versions
+WHERE
+    pattern_id = sqlc.arg(pattern_id)
+AND
+    user_id = sqlc.arg(user_id)
+ORDER BY
+    version_number DESC
+LIMIT 1;
+
+-- 復習物が記録している版の取得に使う
+-- name: GetPatternVersionByID :one
+SELECT
+    id,
+    user_id,
+    pattern_id,
+    version_number,
+    scheduling_algorithm,
+    created_at
+FROM
+    pattern_versions
+WHERE
+    id = sqlc.arg(id)
+AND
+    user_id = sqlc.arg(user_id);
+
+-- 版の履歴一覧（版（親）のみ取得）
+-- name: GetPatternVersionsByPatternID :many
+SELECT
+    id,
+    user_id,
+    pattern_id,
+    version_number,
+    scheduling_algorithm,
+    created_at
+FROM
+    pattern_versions
+WHERE
+    pattern_id = sqlc.arg(pattern_id)
+AND
+    user_id = sqlc.arg(user_id)
+ORDER BY
+    version_number;
+
+-- name: GetPatternVersionStepsByPatternVersionID :many
+SELECT
+    id,
+    user_id,
+    pattern_version_id,
+    step_number,
+    interval_days,
+    interval_unit
+FROM
+    pattern_version_steps
+WHERE
+    pattern_version_id = sqlc.arg(pattern_version_id)
+AND
+    user_id = sqlc.arg(user_id)
+ORDER BY
+    step_number;
+
+-- 版の履歴一覧（パターンの全ての版のステップ（子）を取得（版は区別しない））
+-- name: GetPatternVersionStepsByPatternID :many
+SELECT
+    pvs.id,
+    pvs.user_id,
+    pvs.pattern_version_id,
+    pvs.step_number,
+    pvs.interval_days,
+    pvs.interval_unit
+FROM
+    pattern_version_steps pvs
+JOIN
+    pattern_versions pv ON pv.id = pvs.pattern_version_id
+WHERE
+    pv.pattern_id = sqlc.arg(pattern_id)
+AND
+    pvs.user_id = sqlc.arg(user_id)
+ORDER BY
+    pv.version_number,
+    pvs.step_number;
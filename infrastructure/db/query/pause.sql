@@ -0,0 +1,27 @@
+-- name: CreatePause :exec
+INSERT INTO user_pauses (
+    id,
+    user_id,
+    start_date,
+    end_date
+) VALUES (
+    sqlc.arg(id),
+    sqlc.arg(user_id),
+    sqlc.arg(start_date),
+    sqlc.arg(end_date)
+);
+
+-- 休止期間が既存の休止期間と重なるかを判定するために使う
+-- name: HasOverlappingPause :one
+SELECT EXISTS (
+    SELECT
+        1
+    FROM
+        user_pauses
+    WHERE
+        user_id = sqlc.arg(user_id)
+    AND
+        start_date <= sqlc.arg(end_date)
+    AND
+        end_date >= sqlc.arg(start_date)
+);
@@ -1,10 +1,63 @@
--- name: UpdateOverdueScheduledDatesAndSlideFutureDates :exec
+-- 終了した休止期間の反映。休止期間の開始日以降の未完了の復習日を休止日数分ずらし、反映済みにする
+-- 休止期間中に期限切れになった復習日も今日にまとめず、休止前の間隔のまま後ろにずらす（除外する曜日と復習日を置かない日付は避ける）
+-- name: ApplyEndedPauses :execrows
+WITH p AS (
+    UPDATE user_pauses up
+        SET
+            applied_at = now(),
+            updated_at = now()
+        FROM
+            users u
+        WHERE
+            u.id = up.user_id
+        AND
+            up.applied_at IS NULL
+        AND
+            up.end_date < (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date
+        AND
+            up.user_id = ANY(sqlc.arg(user_ids)::uuid[])
+        RETURNING
+            up.user_id,
+            up.start_date,
+            (up.end_date - up.start_date + 1) AS pause_days,
+            u.excluded_weekdays
+)
+UPDATE review_dates rd
+    SET
+        scheduled_date = next_schedulable_date(rd.scheduled_date + p.pause_days, p.user_id, p.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}'))
+    FROM
+        p
+    JOIN
+        review_items ri
+    ON
+        ri.user_id = p.user_id
+    LEFT JOIN
+        review_patterns rp
+    ON
+        rp.id = ri.pattern_id
+    WHERE
+        rd.item_id = ri.id
+    AND
+        rd.user_id = p.user_id
+    AND
+        rd.scheduled_date >= p.start_date
+    AND
+        rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL;
+
+-- 1日あたりの復習数の上限がないユーザーの期限切れの復習日を今日にずらし、後続の復習日も同じ日数だけずらす
+-- ずらした先が除外する曜日（ユーザーとパターンの設定）や復習日を置かない日付の場合は、次の復習日を置ける日にする
+-- 期限切れの復習日の扱いがslide_allのユーザーが対象。reset_to_first_stepのユーザーでも適応型のパターンの復習物は1ステップ目に戻せないためこちらで扱う
+-- 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+-- name: UpdateOverdueScheduledDatesAndSlideFutureDates :execrows
 WITH c AS (
     SELECT
         ri.id AS item_id,
     MIN(rd.scheduled_date) AS old_date,
-    (now() AT TIME ZONE u.timezone)::date AS today_local,
-    ((now() AT TIME ZONE u.timezone)::date - MIN(rd.scheduled_date)) AS delta_days
+    (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date AS today_local,
+    ((sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date - MIN(rd.scheduled_date)) AS delta_days,
+    u.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}') AS excluded_weekdays
     FROM 
         review_dates rd
     JOIN 
@@ -15,16 +68,43 @@ WITH c AS (
         users u
     ON
         u.id  = ri.user_id
+    LEFT JOIN
+        review_patterns rp
+    ON
+        rp.id = ri.pattern_id
     WHERE
         rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL
     AND 
-        rd.scheduled_date < (now() AT TIME ZONE u.timezone)::date
+        rd.scheduled_date < (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date
+    AND
+        rd.user_id = ANY(sqlc.arg(user_ids)::uuid[])
+    AND
+        u.daily_review_limit = 0
+    AND
+        (
+            u.overdue_policy = 'slide_all'
+        OR
+            (u.overdue_policy = 'reset_to_first_step' AND rp.scheduling_algorithm = 'sm2_adaptive')
+        )
+    AND
+        NOT EXISTS (
+            SELECT
+                1
+            FROM
+                user_pauses up
+            WHERE
+                up.user_id = u.id
+            AND
+                (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date BETWEEN up.start_date AND up.end_date
+        )
     GROUP BY 
-        ri.id, u.timezone
+        ri.id, u.timezone, u.excluded_weekdays, rp.excluded_weekdays
 )
 UPDATE review_dates rd
     SET 
-        scheduled_date = rd.scheduled_date + c.delta_days
+        scheduled_date = next_schedulable_date(rd.scheduled_date + c.delta_days, rd.user_id, c.excluded_weekdays)
     FROM 
         c
     WHERE
@@ -32,4 +112,329 @@ UPDATE review_dates rd
     AND 
         rd.scheduled_date >= c.old_date
     AND
-        rd.is_completed = FALSE;
\ No newline at end of file
+        rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL;
+
+-- 1日あたりの復習数の上限があるユーザーの期限切れの復習物を、繰り越し先の決定に必要な情報と合わせて取得
+-- パターンが設定されていない復習物は重みなし扱い
+-- 期限切れの復習日の扱いがslide_all、slide_overdue_only、reset_to_first_stepのユーザーが対象（reset_to_first_stepのユーザーの適応型のパターンの復習物はslide_allとして返す）
+-- 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+-- name: GetOverdueItemsWithDailyReviewLimit :many
+SELECT
+    ri.user_id,
+    ri.id AS item_id,
+    MIN(rd.scheduled_date)::date AS old_date,
+    (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date AS today_local,
+    u.daily_review_limit,
+    (CASE
+        WHEN u.overdue_policy = 'reset_to_first_step' AND rp.scheduling_algorithm = 'sm2_adaptive' THEN 'slide_all'
+        ELSE u.overdue_policy
+    END)::overdue_policy_enum AS overdue_policy,
+    COALESCE(rp.target_weight, 'unset')::target_weight_enum AS target_weight,
+    (u.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}'))::smallint[] AS excluded_weekdays
+FROM
+    review_dates rd
+JOIN
+    review_items ri
+ON
+    ri.id = rd.item_id
+JOIN
+    users u
+ON
+    u.id = ri.user_id
+LEFT JOIN
+    review_patterns rp
+ON
+    rp.id = ri.pattern_id
+WHERE
+    rd.is_completed = FALSE
+AND
+    rd.scheduled_at IS NULL
+AND
+    rd.scheduled_date < (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date
+AND
+    rd.user_id = ANY(sqlc.arg(user_ids)::uuid[])
+AND
+    u.daily_review_limit > 0
+AND
+    u.overdue_policy IN ('slide_all', 'slide_overdue_only', 'reset_to_first_step')
+AND
+    NOT EXISTS (
+        SELECT
+            1
+        FROM
+            user_pauses up
+        WHERE
+            up.user_id = u.id
+        AND
+            (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date BETWEEN up.start_date AND up.end_date
+    )
+GROUP BY
+    ri.user_id, ri.id, u.timezone, u.daily_review_limit, u.overdue_policy, rp.scheduling_algorithm, rp.target_weight, u.excluded_weekdays, rp.excluded_weekdays
+ORDER BY
+    ri.user_id, ri.id;
+
+-- 日付けを跨いだタイムゾーンを判定するために、ユーザーが設定しているタイムゾーンを取得
+-- name: GetUserTimezones :many
+SELECT DISTINCT
+    timezone
+FROM
+    users
+ORDER BY
+    timezone;
+
+-- タイムゾーンのユーザーをID順に上限件数ずつ取得。after_user_idより後のユーザーを返す（NULLの場合は最初から）
+-- name: GetUserIDsByTimezones :many
+SELECT
+    id
+FROM
+    users
+WHERE
+    timezone = ANY(sqlc.arg(timezones)::text[])
+AND
+    (sqlc.narg(after_user_id)::uuid IS NULL OR id > sqlc.narg(after_user_id)::uuid)
+ORDER BY
+    id
+LIMIT
+    sqlc.arg(chunk_size);
+
+-- ドライラン用。user_idsのユーザーのうち、バッチ処理でずれる可能性がある復習物（期限切れの未完了の復習日がある復習物と、終了した休止期間を反映するユーザーの復習物）の全ての復習日を取得
+-- name: GetReviewDatesToUpdateByBatch :many
+SELECT
+    rd.id,
+    rd.user_id,
+    rd.item_id,
+    rd.step_number,
+    rd.scheduled_date
+FROM
+    review_dates rd
+JOIN
+    users u
+ON
+    u.id = rd.user_id
+WHERE
+    rd.user_id = ANY(sqlc.arg(user_ids)::uuid[])
+AND (
+        EXISTS (
+            SELECT
+                1
+            FROM
+                review_dates o
+            WHERE
+                o.item_id = rd.item_id
+            AND
+                o.is_completed = FALSE
+            AND
+                o.scheduled_at IS NULL
+            AND
+                o.scheduled_date < (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date
+        )
+    OR
+        EXISTS (
+            SELECT
+                1
+            FROM
+                user_pauses up
+            WHERE
+                up.user_id = u.id
+            AND
+                up.applied_at IS NULL
+            AND
+                up.end_date < (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date
+        )
+)
+ORDER BY
+    rd.user_id, rd.item_id, rd.step_number;
+
+-- ドライラン用。バッチ処理で更新した後の復習日を取得して、更新前と比べる
+-- name: GetReviewDatesByIDs :many
+SELECT
+    id,
+    user_id,
+    item_id,
+    step_number,
+    scheduled_date
+FROM
+    review_dates
+WHERE
+    id = ANY(sqlc.arg(review_date_ids)::uuid[])
+ORDER BY
+    user_id, item_id, step_number;
+
+-- 期限切れの復習物の未完了の復習日を、繰り越し先の日付までずらす（後続の復習日も同じ日数だけずらす）
+-- 後続の復習日がずらした先で除外する曜日や復習日を置かない日付になる場合は、次の復習日を置ける日にする
+-- name: SlideScheduledDatesByItemID :execrows
+UPDATE review_dates
+    SET
+        scheduled_date = next_schedulable_date(scheduled_date + (sqlc.arg(new_date)::date - sqlc.arg(old_date)::date), user_id, sqlc.arg(excluded_weekdays)::smallint[])
+    WHERE
+        item_id = sqlc.arg(item_id)
+    AND
+        scheduled_date >= sqlc.arg(old_date)::date
+    AND
+        is_completed = FALSE
+    AND
+        scheduled_at IS NULL;
+
+-- 期限切れの復習物の期限切れの復習日だけを繰り越し先の日付に移す（後続の復習日はそのまま）
+-- 繰り越し先の日付が除外する曜日や復習日を置かない日付の場合は、次の復習日を置ける日にする
+-- name: MoveOverdueScheduledDatesByItemID :execrows
+UPDATE review_dates
+    SET
+        scheduled_date = next_schedulable_date(sqlc.arg(new_date)::date, user_id, sqlc.arg(excluded_weekdays)::smallint[])
+    WHERE
+        item_id = sqlc.arg(item_id)
+    AND
+        scheduled_date < sqlc.arg(today)::date
+    AND
+        is_completed = FALSE
+    AND
+        scheduled_at IS NULL;
+
+-- 期限切れの復習物を1ステップ目からやり直す。1ステップ目が繰り越し先の日付になるように全ての復習日をずらし、完了済みの復習日も未完了に戻す（ステップ間の間隔は元のまま）
+-- ずらした先が除外する曜日や復習日を置かない日付の場合は、次の復習日を置ける日にする
+-- name: ResetScheduledDatesToFirstStepByItemID :execrows
+WITH c AS (
+    SELECT
+        (sqlc.arg(new_date)::date - f.scheduled_date) AS delta_days
+    FROM
+        review_dates f
+    WHERE
+        f.item_id = sqlc.arg(item_id)
+    ORDER BY
+        f.step_number
+    LIMIT 1
+)
+UPDATE review_dates rd
+    SET
+        scheduled_date = CASE
+            WHEN rd.scheduled_at IS NULL THEN next_schedulable_date(rd.scheduled_date + c.delta_days, rd.user_id, sqlc.arg(excluded_weekdays)::smallint[])
+            ELSE rd.scheduled_date + c.delta_days
+        END,
+        scheduled_at = rd.scheduled_at + make_interval(days => c.delta_days),
+        is_completed = FALSE,
+        recall_grade = NULL
+    FROM
+        c
+    WHERE
+        rd.item_id = sqlc.arg(item_id);
+
+-- 1日あたりの復習数の上限がなく、期限切れの復習日の扱いがslide_overdue_onlyのユーザーの期限切れの復習日だけを今日に移す（後続の復習日はそのまま）
+-- 今日が除外する曜日（ユーザーとパターンの設定）や復習日を置かない日付の場合は、次の復習日を置ける日にする
+-- 分・時間単位のステップの復習日（scheduled_atを持つもの）は対象外
+-- name: MoveOverdueScheduledDatesToToday :execrows
+UPDATE review_dates rd
+    SET
+        scheduled_date = next_schedulable_date((sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date, rd.user_id, u.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}'))
+    FROM
+        review_items ri
+    JOIN
+        users u
+    ON
+        u.id = ri.user_id
+    LEFT JOIN
+        review_patterns rp
+    ON
+        rp.id = ri.pattern_id
+    WHERE
+        rd.item_id = ri.id
+    AND
+        rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL
+    AND
+        rd.scheduled_date < (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date
+    AND
+        rd.user_id = ANY(sqlc.arg(user_ids)::uuid[])
+    AND
+        u.daily_review_limit = 0
+    AND
+        u.overdue_policy = 'slide_overdue_only'
+    AND
+        NOT EXISTS (
+            SELECT
+                1
+            FROM
+                user_pauses up
+            WHERE
+                up.user_id = u.id
+            AND
+                (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date BETWEEN up.start_date AND up.end_date
+        );
+
+-- 1日あたりの復習数の上限がなく、期限切れの復習日の扱いがreset_to_first_stepのユーザーの期限切れの復習物を1ステップ目からやり直す
+-- 1ステップ目が今日になるように全ての復習日をずらし、完了済みの復習日も未完了に戻す（ステップ間の間隔は元のまま）
+-- ずらした先が除外する曜日（ユーザーとパターンの設定）や復習日を置かない日付の場合は、次の復習日を置ける日にする
+-- 適応型のパターンの復習物は復習日を1件ずつ生成しているため対象外（slide_allと同じ扱い）
+-- name: ResetOverdueItemsToFirstStep :execrows
+WITH c AS (
+    SELECT
+        ri.id AS item_id,
+        ((sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date - (
+            SELECT
+                f.scheduled_date
+            FROM
+                review_dates f
+            WHERE
+                f.item_id = ri.id
+            ORDER BY
+                f.step_number
+            LIMIT 1
+        )) AS delta_days,
+        u.excluded_weekdays || COALESCE(rp.excluded_weekdays, '{}') AS excluded_weekdays
+    FROM
+        review_dates rd
+    JOIN
+        review_items ri
+    ON
+        ri.id = rd.item_id
+    JOIN
+        users u
+    ON
+        u.id = ri.user_id
+    LEFT JOIN
+        review_patterns rp
+    ON
+        rp.id = ri.pattern_id
+    WHERE
+        rd.is_completed = FALSE
+    AND
+        rd.scheduled_at IS NULL
+    AND
+        rd.scheduled_date < (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date
+    AND
+        rd.user_id = ANY(sqlc.arg(user_ids)::uuid[])
+    AND
+        u.daily_review_limit = 0
+    AND
+        u.overdue_policy = 'reset_to_first_step'
+    AND
+        COALESCE(rp.scheduling_algorithm, 'fixed') <> 'sm2_adaptive'
+    AND
+        NOT EXISTS (
+            SELECT
+                1
+            FROM
+                user_pauses up
+            WHERE
+                up.user_id = u.id
+            AND
+                (sqlc.arg(now)::timestamptz AT TIME ZONE u.timezone)::date BETWEEN up.start_date AND up.end_date
+        )
+    GROUP BY
+        ri.id, u.timezone, u.excluded_weekdays, rp.excluded_weekdays
+)
+UPDATE review_dates rd
+    SET
+        scheduled_date = CASE
+            WHEN rd.scheduled_at IS NULL THEN next_schedulable_date(rd.scheduled_date + c.delta_days, rd.user_id, c.excluded_weekdays)
+            ELSE rd.scheduled_date + c.delta_days
+        END,
+        scheduled_at = rd.scheduled_at + make_interval(days => c.delta_days),
+        is_completed = FALSE,
+        recall_grade = NULL
+    FROM
+        c
+    WHERE
+        rd.item_id = c.item_id;
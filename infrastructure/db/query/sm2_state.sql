@@ -0,0 +1,37 @@
+-- 適応型SM-2方式の学習状態の取得
+-- name: GetSM2StateByItemID :one
+SELECT
+    item_id,
+    user_id,
+    ease_factor,
+    repetitions,
+    interval_days
+FROM
+    review_item_sm2_states
+WHERE
+    item_id = sqlc.arg(item_id)
+AND
+    user_id = sqlc.arg(user_id);
+
+-- 初回完了時は挿入、2回目以降は上書き
+-- name: UpsertSM2State :exec
+INSERT INTO
+    review_item_sm2_states (
+        item_id,
+        user_id,
+        ease_factor,
+        repetitions,
+        interval_days
+    )
+VALUES (
+        sqlc.arg(item_id),
+        sqlc.arg(user_id),
+        sqlc.arg(ease_factor),
+        sqlc.arg(repetitions),
+        sqlc.arg(interval_days)
+    )
+ON CONFLICT (item_id) DO UPDATE
+SET
+    ease_factor = EXCLUDED.ease_factor,
+    repetitions = EXCLUDED.repetitions,
+    interval_days = EXCLUDED.interval_days;
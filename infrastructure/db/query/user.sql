@@ -36,7 +36,10 @@ SELECT
     email,
     timezone,
     theme_color,
-    language
+    language,
+    daily_review_limit,
+    excluded_weekdays,
+    overdue_policy
 FROM
     users
 WHERE
@@ -50,7 +53,10 @@ SET
     email = sqlc.arg(email),
     timezone = sqlc.arg(timezone),
     theme_color = sqlc.arg(theme_color),
-    language = sqlc.arg(language)
+    language = sqlc.arg(language),
+    daily_review_limit = sqlc.arg(daily_review_limit),
+    excluded_weekdays = sqlc.arg(excluded_weekdays),
+    overdue_policy = sqlc.arg(overdue_policy)
 WHERE
     id = sqlc.arg(id);
 
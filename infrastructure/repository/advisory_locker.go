@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/minminseo/recall-setter/infrastructure/db"
+	"github.com/minminseo/recall-setter/infrastructure/db/dbgen"
+	lockUsecase "github.com/minminseo/recall-setter/usecase/lock"
+)
+
+// PostgreSQLのセッション単位のアドバイザリロックで、複数のプロセスのうち1つだけが処理を実行するようにする
+type AdvisoryLocker struct {
+	pool *pgxpool.Pool
+	mu   sync.Mutex
+	// ロックの名前毎に、前回ロックを取得できたか。ロックの取得と喪失をログに出すために使う
+	held map[string]bool
+}
+
+func NewAdvisoryLocker(pool *pgxpool.Pool) lockUsecase.ILocker {
+	return &AdvisoryLocker{
+		pool: pool,
+		held: make(map[string]bool),
+	}
+}
+
+func (l *AdvisoryLocker) RunWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	// セッション単位のロックは取得した接続に紐づくため、処理の間は同じ接続を確保しておく
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	q := dbgen.New(conn)
+	acquired, err := q.TryAdvisoryLock(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if !acquired {
+		if l.swapHeld(name, false) {
+			slog.Warn("ロックを失いました。他のプロセスがロックを保持しています。", "lock", name)
+		}
+		return false, nil
+	}
+	if !l.swapHeld(name, true) {
+		slog.Info("ロックを取得しました。このプロセスで実行します。", "lock", name)
+	}
+
+	// ロックを保持する接続でfnのクエリとトランザクションを実行する
+	// 接続が切れてロックを失った場合は、fnの更新もコミットされない
+	fnErr := fn(db.WithConn(ctx, conn))
+
+	// fnでctxがタイムアウトしていてもロックは解放する
+	released, err := q.AdvisoryUnlock(context.WithoutCancel(ctx), name)
+	if err != nil || !released {
+		l.swapHeld(name, false)
+		slog.Warn("ロックを失っていました。", "lock", name, "error", err)
+		// ロックが残ったままの接続をプールに戻さないように、接続を閉じてから返す
+		_ = conn.Conn().Close(context.WithoutCancel(ctx))
+	}
+	return true, fnErr
+}
+
+// ロックを取得できたかを記録し、前回の値を返す
+func (l *AdvisoryLocker) swapHeld(name string, held bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	prev := l.held[name]
+	l.held[name] = held
+	return prev
+}
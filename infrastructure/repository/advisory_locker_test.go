@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/minminseo/recall-setter/infrastructure/db"
+)
+
+func TestAdvisoryLocker(t *testing.T) {
+	if testing.Short() {
+		t.Skip("統合テストをスキップします")
+	}
+
+	ctx := GetTestContext()
+	leader := NewAdvisoryLocker(testPool)
+	follower := NewAdvisoryLocker(testPool)
+	lockName := "test_advisory_locker"
+
+	t.Run("正常系:ロックを保持している間は他のプロセスがロックを取得できないこと", func(t *testing.T) {
+		var followerAcquired bool
+		acquired, err := leader.RunWithLock(ctx, lockName, func(ctx context.Context) error {
+			if db.GetConn(ctx) == nil {
+				t.Errorf("ロックを保持している接続がctxに設定されていません")
+			}
+			var err error
+			followerAcquired, err = follower.RunWithLock(ctx, lockName, func(ctx context.Context) error {
+				t.Errorf("ロックを取得できないプロセスで処理が実行されました")
+				return nil
+			})
+			return err
+		})
+		if err != nil {
+			t.Fatalf("RunWithLock() error = %v", err)
+		}
+		if !acquired {
+			t.Errorf("RunWithLock() acquired = false, want true")
+		}
+		if followerAcquired {
+			t.Errorf("他のプロセスのRunWithLock() acquired = true, want false")
+		}
+	})
+
+	t.Run("正常系:処理の終了後はロックを解放し、他のプロセスがロックを取得できること", func(t *testing.T) {
+		var executed bool
+		acquired, err := follower.RunWithLock(ctx, lockName, func(ctx context.Context) error {
+			executed = true
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("RunWithLock() error = %v", err)
+		}
+		if !acquired || !executed {
+			t.Errorf("RunWithLock() acquired = %v, executed = %v, want true", acquired, executed)
+		}
+	})
+
+	t.Run("異常系:処理のエラーを返し、ロックは解放されること", func(t *testing.T) {
+		wantErr := errors.New("database connection failed")
+		acquired, err := leader.RunWithLock(ctx, lockName, func(ctx context.Context) error {
+			return wantErr
+		})
+		if !errors.Is(err, wantErr) {
+			t.Fatalf("RunWithLock() error = %v, want %v", err, wantErr)
+		}
+		if !acquired {
+			t.Errorf("RunWithLock() acquired = false, want true")
+		}
+
+		acquired, err = follower.RunWithLock(ctx, lockName, func(ctx context.Context) error { return nil })
+		if err != nil || !acquired {
+			t.Errorf("ロックが解放されていません: acquired = %v, error = %v", acquired, err)
+		}
+	})
+}
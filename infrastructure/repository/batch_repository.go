@@ -2,12 +2,57 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	batchDomain "github.com/minminseo/recall-setter/domain/batch"
+	itemDomain "github.com/minminseo/recall-setter/domain/item"
 	"github.com/minminseo/recall-setter/infrastructure/db"
+	"github.com/minminseo/recall-setter/infrastructure/db/dbgen"
 )
 
 type IBatchRepository interface {
-	ExecuteUpdateOverdueScheduledDates(ctx context.Context) error
+	// 以下は日付けを跨いだタイムゾーンのユーザーを、上限件数ずつに分けて処理するために使う
+	GetUserTimezones(ctx context.Context) ([]string, error)
+	// afterUserIDより後のユーザーをID順にlimit件まで返す（afterUserIDが空の場合は最初から）
+	GetUserIDsByTimezones(ctx context.Context, timezones []string, afterUserID string, limit int) ([]string, error)
+
+	// 以下の更新はuserIDsのユーザーだけを対象にする
+
+	// 終了した休止期間の開始日以降の未完了の復習日を、休止日数分ずらす
+	ExecuteApplyEndedPauses(ctx context.Context, now time.Time, userIDs []string) (int64, error)
+
+	// 1日あたりの復習数の上限がないユーザーの期限切れの復習日をまとめて今日にずらす（休止期間中のユーザーは除く）
+	ExecuteUpdateOverdueScheduledDates(ctx context.Context, now time.Time, userIDs []string) (int64, error)
+
+	// 1日あたりの復習数の上限がないユーザーのうち、期限切れの復習日だけをずらすユーザーの期限切れの復習日をまとめて今日に移す
+	ExecuteMoveOverdueScheduledDatesToToday(ctx context.Context, now time.Time, userIDs []string) (int64, error)
+
+	// 1日あたりの復習数の上限がないユーザーのうち、1ステップ目からやり直すユーザーの期限切れの復習物を、1ステップ目が今日になるようにやり直す
+	ExecuteResetOverdueItemsToFirstStep(ctx context.Context, now time.Time, userIDs []string) (int64, error)
+
+	// 以下は1日あたりの復習数の上限があるユーザーの期限切れの復習日を繰り越すために使う
+	GetOverdueItemsWithDailyReviewLimit(ctx context.Context, now time.Time, userIDs []string) ([]*itemDomain.OverdueItem, error)
+	CountScheduledDatesGroupedByDateByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*itemDomain.DailyScheduledCount, error)
+	GetBlockedDatesByUserID(ctx context.Context, userID string) ([]time.Time, error)
+	SlideScheduledDatesByItemID(ctx context.Context, itemID string, oldDate time.Time, newDate time.Time, excludedWeekdays itemDomain.ExcludedWeekdays) (int64, error)
+	MoveOverdueScheduledDatesByItemID(ctx context.Context, itemID string, today time.Time, newDate time.Time, excludedWeekdays itemDomain.ExcludedWeekdays) (int64, error)
+	ResetScheduledDatesToFirstStepByItemID(ctx context.Context, itemID string, newDate time.Time, excludedWeekdays itemDomain.ExcludedWeekdays) (int64, error)
+
+	// 以下はドライランで、バッチ処理の前後の復習日を比べるために使う
+	GetReviewDatesToUpdateByBatch(ctx context.Context, now time.Time, userIDs []string) ([]*batchDomain.ReviewDateSnapshot, error)
+	GetReviewDatesByIDs(ctx context.Context, reviewDateIDs []string) ([]*batchDomain.ReviewDateSnapshot, error)
+
+	// 以下はバッチ処理の実行記録に使う
+	// 同じ実行枠の実行記録がある場合と、同じバッチ処理が実行中の場合は作成せずにfalseを返す
+	CreateBatchRun(ctx context.Context, run *batchDomain.BatchRun) (bool, error)
+	FinishBatchRun(ctx context.Context, run *batchDomain.BatchRun) error
+	FailStaleBatchRuns(ctx context.Context, jobName string, startedBefore time.Time, finishedAt time.Time) error
+	// 実行記録がない場合はnilを返す
+	GetLatestBatchRunWindowStart(ctx context.Context, jobName string) (*time.Time, error)
+	// 成功した実行記録がない場合はnilを返す
+	GetLatestSucceededBatchRunWindowStart(ctx context.Context, jobName string) (*time.Time, error)
 }
 
 type batchRepository struct{}
@@ -16,7 +61,339 @@ func NewBatchRepository() IBatchRepository {
 	return &batchRepository{}
 }
 
-func (r *batchRepository) ExecuteUpdateOverdueScheduledDates(ctx context.Context) error {
+func (r *batchRepository) GetUserTimezones(ctx context.Context) ([]string, error) {
+	q := db.GetQuery(ctx)
+	return q.GetUserTimezones(ctx)
+}
+
+func (r *batchRepository) GetUserIDsByTimezones(ctx context.Context, timezones []string, afterUserID string, limit int) ([]string, error) {
+	q := db.GetQuery(ctx)
+	var pgAfterUserID pgtype.UUID
+	if afterUserID != "" {
+		var err error
+		pgAfterUserID, err = toUUID(afterUserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	params := dbgen.GetUserIDsByTimezonesParams{
+		Timezones:   timezones,
+		AfterUserID: pgAfterUserID,
+		ChunkSize:   int32(limit), // #nosec G115
+	}
+	rows, err := q.GetUserIDsByTimezones(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, len(rows))
+	for i, row := range rows {
+		userIDs[i] = uuid.UUID(row.Bytes).String()
+	}
+	return userIDs, nil
+}
+
+func (r *batchRepository) ExecuteApplyEndedPauses(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	q := db.GetQuery(ctx)
+	pgUserIDs, err := toUUIDs(userIDs)
+	if err != nil {
+		return 0, err
+	}
+	params := dbgen.ApplyEndedPausesParams{
+		Now:     pgtype.Timestamptz{Time: now, Valid: true},
+		UserIds: pgUserIDs,
+	}
+	return q.ApplyEndedPauses(ctx, params)
+}
+
+func (r *batchRepository) ExecuteUpdateOverdueScheduledDates(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	q := db.GetQuery(ctx)
+	pgUserIDs, err := toUUIDs(userIDs)
+	if err != nil {
+		return 0, err
+	}
+	params := dbgen.UpdateOverdueScheduledDatesAndSlideFutureDatesParams{
+		Now:     pgtype.Timestamptz{Time: now, Valid: true},
+		UserIds: pgUserIDs,
+	}
+	return q.UpdateOverdueScheduledDatesAndSlideFutureDates(ctx, params)
+}
+
+func (r *batchRepository) ExecuteMoveOverdueScheduledDatesToToday(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	q := db.GetQuery(ctx)
+	pgUserIDs, err := toUUIDs(userIDs)
+	if err != nil {
+		return 0, err
+	}
+	params := dbgen.MoveOverdueScheduledDatesToTodayParams{
+		Now:     pgtype.Timestamptz{Time: now, Valid: true},
+		UserIds: pgUserIDs,
+	}
+	return q.MoveOverdueScheduledDatesToToday(ctx, params)
+}
+
+func (r *batchRepository) ExecuteResetOverdueItemsToFirstStep(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	q := db.GetQuery(ctx)
+	pgUserIDs, err := toUUIDs(userIDs)
+	if err != nil {
+		return 0, err
+	}
+	params := dbgen.ResetOverdueItemsToFirstStepParams{
+		Now:     pgtype.Timestamptz{Time: now, Valid: true},
+		UserIds: pgUserIDs,
+	}
+	return q.ResetOverdueItemsToFirstStep(ctx, params)
+}
+
+func (r *batchRepository) GetOverdueItemsWithDailyReviewLimit(ctx context.Context, now time.Time, userIDs []string) ([]*itemDomain.OverdueItem, error) {
+	q := db.GetQuery(ctx)
+	pgUserIDs, err := toUUIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	params := dbgen.GetOverdueItemsWithDailyReviewLimitParams{
+		Now:     pgtype.Timestamptz{Time: now, Valid: true},
+		UserIds: pgUserIDs,
+	}
+	rows, err := q.GetOverdueItemsWithDailyReviewLimit(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*itemDomain.OverdueItem, len(rows))
+	for i, row := range rows {
+		results[i] = &itemDomain.OverdueItem{
+			UserID:           uuid.UUID(row.UserID.Bytes).String(),
+			ItemID:           uuid.UUID(row.ItemID.Bytes).String(),
+			OldDate:          row.OldDate.Time,
+			Today:            row.TodayLocal.Time,
+			DailyReviewLimit: int(row.DailyReviewLimit),
+			OverduePolicy:    string(row.OverduePolicy),
+			TargetWeight:     string(row.TargetWeight),
+			ExcludedWeekdays: itemDomain.NewExcludedWeekdays(fromWeekdays(row.ExcludedWeekdays)),
+		}
+	}
+	return results, nil
+}
+
+func (r *batchRepository) CountScheduledDatesGroupedByDateByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*itemDomain.DailyScheduledCount, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	params := dbgen.CountScheduledDatesGroupedByDateByUserIDParams{
+		UserID:   pgUserID,
+		FromDate: pgtype.Date{Time: fromDate, Valid: true},
+		ToDate:   pgtype.Date{Time: toDate, Valid: true},
+	}
+	rows, err := q.CountScheduledDatesGroupedByDateByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*itemDomain.DailyScheduledCount, len(rows))
+	for i, row := range rows {
+		results[i] = &itemDomain.DailyScheduledCount{
+			ScheduledDate: row.ScheduledDate.Time,
+			Count:         int(row.Count),
+		}
+	}
+	return results, nil
+}
+
+func (r *batchRepository) GetBlockedDatesByUserID(ctx context.Context, userID string) ([]time.Time, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.GetBlockedDatesByUserID(ctx, pgUserID)
+	if err != nil {
+		return nil, err
+	}
+	blockedDates := make([]time.Time, len(rows))
+	for i, row := range rows {
+		blockedDates[i] = row.BlockedDate.Time
+	}
+	return blockedDates, nil
+}
+
+func (r *batchRepository) SlideScheduledDatesByItemID(ctx context.Context, itemID string, oldDate time.Time, newDate time.Time, excludedWeekdays itemDomain.ExcludedWeekdays) (int64, error) {
+	q := db.GetQuery(ctx)
+	pgItemID, err := toUUID(itemID)
+	if err != nil {
+		return 0, err
+	}
+	params := dbgen.SlideScheduledDatesByItemIDParams{
+		NewDate:          pgtype.Date{Time: newDate, Valid: true},
+		OldDate:          pgtype.Date{Time: oldDate, Valid: true},
+		ExcludedWeekdays: toWeekdays(excludedWeekdays.Weekdays()),
+		ItemID:           pgItemID,
+	}
+	return q.SlideScheduledDatesByItemID(ctx, params)
+}
+
+func (r *batchRepository) MoveOverdueScheduledDatesByItemID(ctx context.Context, itemID string, today time.Time, newDate time.Time, excludedWeekdays itemDomain.ExcludedWeekdays) (int64, error) {
+	q := db.GetQuery(ctx)
+	pgItemID, err := toUUID(itemID)
+	if err != nil {
+		return 0, err
+	}
+	params := dbgen.MoveOverdueScheduledDatesByItemIDParams{
+		NewDate:          pgtype.Date{Time: newDate, Valid: true},
+		ExcludedWeekdays: toWeekdays(excludedWeekdays.Weekdays()),
+		ItemID:           pgItemID,
+		Today:            pgtype.Date{Time: today, Valid: true},
+	}
+	return q.MoveOverdueScheduledDatesByItemID(ctx, params)
+}
+
+func (r *batchRepository) ResetScheduledDatesToFirstStepByItemID(ctx context.Context, itemID string, newDate time.Time, excludedWeekdays itemDomain.ExcludedWeekdays) (int64, error) {
+	q := db.GetQuery(ctx)
+	pgItemID, err := toUUID(itemID)
+	if err != nil {
+		return 0, err
+	}
+	params := dbgen.ResetScheduledDatesToFirstStepByItemIDParams{
+		NewDate:          pgtype.Date{Time: newDate, Valid: true},
+		ItemID:           pgItemID,
+		ExcludedWeekdays: toWeekdays(excludedWeekdays.Weekdays()),
+	}
+	return q.ResetScheduledDatesToFirstStepByItemID(ctx, params)
+}
+
+func (r *batchRepository) GetReviewDatesToUpdateByBatch(ctx context.Context, now time.Time, userIDs []string) ([]*batchDomain.ReviewDateSnapshot, error) {
+	q := db.GetQuery(ctx)
+	pgUserIDs, err := toUUIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	params := dbgen.GetReviewDatesToUpdateByBatchParams{
+		UserIds: pgUserIDs,
+		Now:     pgtype.Timestamptz{Time: now, Valid: true},
+	}
+	rows, err := q.GetReviewDatesToUpdateByBatch(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*batchDomain.ReviewDateSnapshot, len(rows))
+	for i, row := range rows {
+		results[i] = &batchDomain.ReviewDateSnapshot{
+			ReviewDateID:  uuid.UUID(row.ID.Bytes).String(),
+			UserID:        uuid.UUID(row.UserID.Bytes).String(),
+			ItemID:        uuid.UUID(row.ItemID.Bytes).String(),
+			StepNumber:    int(row.StepNumber),
+			ScheduledDate: row.ScheduledDate.Time,
+		}
+	}
+	return results, nil
+}
+
+func (r *batchRepository) GetReviewDatesByIDs(ctx context.Context, reviewDateIDs []string) ([]*batchDomain.ReviewDateSnapshot, error) {
+	q := db.GetQuery(ctx)
+	pgReviewDateIDs := make([]pgtype.UUID, len(reviewDateIDs))
+	for i, id := range reviewDateIDs {
+		pgID, err := toUUID(id)
+		if err != nil {
+			return nil, err
+		}
+		pgReviewDateIDs[i] = pgID
+	}
+	rows, err := q.GetReviewDatesByIDs(ctx, pgReviewDateIDs)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*batchDomain.ReviewDateSnapshot, len(rows))
+	for i, row := range rows {
+		results[i] = &batchDomain.ReviewDateSnapshot{
+			ReviewDateID:  uuid.UUID(row.ID.Bytes).String(),
+			UserID:        uuid.UUID(row.UserID.Bytes).String(),
+			ItemID:        uuid.UUID(row.ItemID.Bytes).String(),
+			StepNumber:    int(row.StepNumber),
+			ScheduledDate: row.ScheduledDate.Time,
+		}
+	}
+	return results, nil
+}
+
+func (r *batchRepository) CreateBatchRun(ctx context.Context, run *batchDomain.BatchRun) (bool, error) {
+	q := db.GetQuery(ctx)
+	pgID, err := toUUID(run.ID())
+	if err != nil {
+		return false, err
+	}
+	var caughtUpFrom pgtype.Timestamptz
+	if run.CaughtUpFrom() != nil {
+		caughtUpFrom = pgtype.Timestamptz{Time: *run.CaughtUpFrom(), Valid: true}
+	}
+	params := dbgen.CreateBatchRunParams{
+		ID:           pgID,
+		JobName:      run.JobName(),
+		WindowStart:  pgtype.Timestamptz{Time: run.WindowStart(), Valid: true},
+		CaughtUpFrom: caughtUpFrom,
+		Status:       dbgen.BatchRunStatusEnum(run.Status()),
+		StartedAt:    pgtype.Timestamptz{Time: run.StartedAt(), Valid: true},
+	}
+	rows, err := q.CreateBatchRun(ctx, params)
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *batchRepository) FinishBatchRun(ctx context.Context, run *batchDomain.BatchRun) error {
+	q := db.GetQuery(ctx)
+	pgID, err := toUUID(run.ID())
+	if err != nil {
+		return err
+	}
+	var errorMessage pgtype.Text
+	if run.ErrorMessage() != nil {
+		errorMessage = pgtype.Text{String: *run.ErrorMessage(), Valid: true}
+	}
+	var finishedAt pgtype.Timestamptz
+	if run.FinishedAt() != nil {
+		finishedAt = pgtype.Timestamptz{Time: *run.FinishedAt(), Valid: true}
+	}
+	params := dbgen.FinishBatchRunParams{
+		Status:       dbgen.BatchRunStatusEnum(run.Status()),
+		RowsAffected: run.RowsAffected(),
+		ErrorMessage: errorMessage,
+		FinishedAt:   finishedAt,
+		ID:           pgID,
+	}
+	return q.FinishBatchRun(ctx, params)
+}
+
+func (r *batchRepository) FailStaleBatchRuns(ctx context.Context, jobName string, startedBefore time.Time, finishedAt time.Time) error {
+	q := db.GetQuery(ctx)
+	params := dbgen.FailStaleBatchRunsParams{
+		ErrorMessage:  pgtype.Text{String: batchDomain.StaleRunErrorMessage, Valid: true},
+		FinishedAt:    pgtype.Timestamptz{Time: finishedAt, Valid: true},
+		JobName:       jobName,
+		StartedBefore: pgtype.Timestamptz{Time: startedBefore, Valid: true},
+	}
+	return q.FailStaleBatchRuns(ctx, params)
+}
+
+func (r *batchRepository) GetLatestBatchRunWindowStart(ctx context.Context, jobName string) (*time.Time, error) {
+	q := db.GetQuery(ctx)
+	windowStart, err := q.GetLatestBatchRunWindowStart(ctx, jobName)
+	if err != nil {
+		return nil, err
+	}
+	if !windowStart.Valid {
+		return nil, nil
+	}
+	return &windowStart.Time, nil
+}
+
+func (r *batchRepository) GetLatestSucceededBatchRunWindowStart(ctx context.Context, jobName string) (*time.Time, error) {
 	q := db.GetQuery(ctx)
-	return q.UpdateOverdueScheduledDatesAndSlideFutureDates(ctx)
+	windowStart, err := q.GetLatestSucceededBatchRunWindowStart(ctx, jobName)
+	if err != nil {
+		return nil, err
+	}
+	if !windowStart.Valid {
+		return nil, nil
+	}
+	return &windowStart.Time, nil
 }
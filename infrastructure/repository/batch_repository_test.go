@@ -1,7 +1,14 @@
 package repository
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchDomain "github.com/minminseo/recall-setter/domain/batch"
+	itemDomain "github.com/minminseo/recall-setter/domain/item"
 )
 
 func TestBatchRepository_ExecuteUpdateOverdueScheduledDates(t *testing.T) {
@@ -28,7 +35,202 @@ func TestBatchRepository_ExecuteUpdateOverdueScheduledDates(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewBatchRepository()
 
-			err := repo.ExecuteUpdateOverdueScheduledDates(ctx)
+			_, err := repo.ExecuteUpdateOverdueScheduledDates(ctx, time.Now(), fixtureUserIDs(t, repo))
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+			}
+		})
+	}
+}
+
+func TestBatchRepository_GetOverdueItemsWithDailyReviewLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewBatchRepository()
+
+	// 復習物を持つユーザーは1日の復習数の上限を設定していないので、対象の復習物はない
+	items, err := repo.GetOverdueItemsWithDailyReviewLimit(ctx, time.Now(), fixtureUserIDs(t, repo))
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("GetOverdueItemsWithDailyReviewLimit() returned %d items, want 0", len(items))
+	}
+}
+
+func TestBatchRepository_SlideScheduledDatesByItemID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name             string
+		itemID           string
+		userID           string
+		oldDate          time.Time
+		newDate          time.Time
+		excludedWeekdays itemDomain.ExcludedWeekdays
+		wantDates        []time.Time
+		wantErr          bool
+	}{
+		{
+			name:      "期限切れの復習日以降の未完了の復習日を同じ日数だけずらす場合",
+			itemID:    "a50e8400-e29b-41d4-a716-446655440001",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			oldDate:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			newDate:   time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			wantDates: []time.Time{time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+			wantErr:   false,
+		},
+		{
+			name:             "後続の復習日がずらした先で除外する曜日になる場合は次の除外しない曜日にする",
+			itemID:           "a50e8400-e29b-41d4-a716-446655440001",
+			userID:           "550e8400-e29b-41d4-a716-446655440001",
+			oldDate:          time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			newDate:          time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			excludedWeekdays: itemDomain.NewExcludedWeekdays([]int{int(time.Monday)}),
+			wantDates:        []time.Time{time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)},
+			wantErr:          false,
+		},
+		{
+			name:    "無効な復習物IDの場合",
+			itemID:  "invalid-uuid",
+			oldDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			newDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewBatchRepository()
+
+			_, err := repo.SlideScheduledDatesByItemID(ctx, tc.itemID, tc.oldDate, tc.newDate, tc.excludedWeekdays)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			reviewdates, err := NewItemRepository().GetReviewDatesByItemID(ctx, tc.itemID, tc.userID)
+			if err != nil {
+				t.Fatalf("failed to get review dates: %v", err)
+			}
+			if len(reviewdates) != len(tc.wantDates) {
+				t.Fatalf("got %d review dates, want %d", len(reviewdates), len(tc.wantDates))
+			}
+			for i, rd := range reviewdates {
+				if !rd.ScheduledDate().Equal(tc.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tc.wantDates[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBatchRepository_ExecuteApplyEndedPauses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewBatchRepository()
+
+	// フィクスチャの休止期間は反映済みなので、復習日はずれない
+	rows, err := repo.ExecuteApplyEndedPauses(ctx, time.Now(), fixtureUserIDs(t, repo))
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if rows != 0 {
+		t.Errorf("ExecuteApplyEndedPauses() rows = %d, want 0", rows)
+	}
+
+	reviewdates, err := NewItemRepository().GetReviewDatesByItemID(ctx, "a50e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440001")
+	if err != nil {
+		t.Fatalf("failed to get review dates: %v", err)
+	}
+	wantDates := []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)}
+	if len(reviewdates) != len(wantDates) {
+		t.Fatalf("got %d review dates, want %d", len(reviewdates), len(wantDates))
+	}
+	for i, rd := range reviewdates {
+		if !rd.ScheduledDate().Equal(wantDates[i]) {
+			t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), wantDates[i])
+		}
+	}
+}
+
+func TestBatchRepository_MoveOverdueScheduledDatesByItemID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name      string
+		itemID    string
+		userID    string
+		today     time.Time
+		newDate   time.Time
+		wantDates []time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "期限切れの復習日だけを移し、後続の復習日はそのままにする場合",
+			itemID:    "a50e8400-e29b-41d4-a716-446655440001",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			today:     time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			newDate:   time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			wantDates: []time.Time{time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)},
+			wantErr:   false,
+		},
+		{
+			name:    "無効な復習物IDの場合",
+			itemID:  "invalid-uuid",
+			today:   time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			newDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewBatchRepository()
+
+			_, err := repo.MoveOverdueScheduledDatesByItemID(ctx, tc.itemID, tc.today, tc.newDate, itemDomain.ExcludedWeekdays(0))
 
 			if tc.wantErr {
 				if err == nil {
@@ -39,7 +241,470 @@ func TestBatchRepository_ExecuteUpdateOverdueScheduledDates(t *testing.T) {
 
 			if err != nil {
 				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			reviewdates, err := NewItemRepository().GetReviewDatesByItemID(ctx, tc.itemID, tc.userID)
+			if err != nil {
+				t.Fatalf("failed to get review dates: %v", err)
+			}
+			if len(reviewdates) != len(tc.wantDates) {
+				t.Fatalf("got %d review dates, want %d", len(reviewdates), len(tc.wantDates))
+			}
+			for i, rd := range reviewdates {
+				if !rd.ScheduledDate().Equal(tc.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tc.wantDates[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBatchRepository_ResetScheduledDatesToFirstStepByItemID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name      string
+		itemID    string
+		userID    string
+		newDate   time.Time
+		wantDates []time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "1ステップ目が繰り越し先の日付になるように全ての復習日をずらし、未完了に戻す場合",
+			itemID:    "a50e8400-e29b-41d4-a716-446655440001",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			newDate:   time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			wantDates: []time.Time{time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+			wantErr:   false,
+		},
+		{
+			name:    "無効な復習物IDの場合",
+			itemID:  "invalid-uuid",
+			newDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewBatchRepository()
+
+			_, err := repo.ResetScheduledDatesToFirstStepByItemID(ctx, tc.itemID, tc.newDate, itemDomain.ExcludedWeekdays(0))
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			reviewdates, err := NewItemRepository().GetReviewDatesByItemID(ctx, tc.itemID, tc.userID)
+			if err != nil {
+				t.Fatalf("failed to get review dates: %v", err)
+			}
+			if len(reviewdates) != len(tc.wantDates) {
+				t.Fatalf("got %d review dates, want %d", len(reviewdates), len(tc.wantDates))
+			}
+			for i, rd := range reviewdates {
+				if !rd.ScheduledDate().Equal(tc.wantDates[i]) {
+					t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), tc.wantDates[i])
+				}
+				if rd.IsCompleted() {
+					t.Errorf("Reviewdate[%d].IsCompleted() = true, want false", i)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchRepository_ExecuteOverdueScheduledDatesKeepOverdue(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewBatchRepository()
+
+	if _, err := repo.ExecuteUpdateOverdueScheduledDates(ctx, time.Now(), fixtureUserIDs(t, repo)); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if _, err := repo.ExecuteMoveOverdueScheduledDatesToToday(ctx, time.Now(), fixtureUserIDs(t, repo)); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if _, err := repo.ExecuteResetOverdueItemsToFirstStep(ctx, time.Now(), fixtureUserIDs(t, repo)); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	// 期限切れの復習日をずらさないユーザーの期限切れの復習日は、どの更新でもずれない
+	reviewdates, err := NewItemRepository().GetReviewDatesByItemID(ctx, "a50e8400-e29b-41d4-a716-446655440004", "550e8400-e29b-41d4-a716-446655440002")
+	if err != nil {
+		t.Fatalf("failed to get review dates: %v", err)
+	}
+	wantDates := []time.Time{time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)}
+	if len(reviewdates) != len(wantDates) {
+		t.Fatalf("got %d review dates, want %d", len(reviewdates), len(wantDates))
+	}
+	for i, rd := range reviewdates {
+		if !rd.ScheduledDate().Equal(wantDates[i]) {
+			t.Errorf("Reviewdate[%d].ScheduledDate() = %v, want %v", i, rd.ScheduledDate(), wantDates[i])
+		}
+	}
+}
+
+func TestBatchRepository_BatchRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewBatchRepository()
+	jobName := batchDomain.JobNameUpdateOverdueScheduledDates
+	windowStart := time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC)
+
+	latest, err := repo.GetLatestBatchRunWindowStart(ctx, jobName)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if latest != nil {
+		t.Fatalf("GetLatestBatchRunWindowStart() = %v, want nil", *latest)
+	}
+
+	run, err := batchDomain.NewBatchRun("d50e8400-e29b-41d4-a716-446655440001", jobName, windowStart, nil, windowStart)
+	if err != nil {
+		t.Fatalf("failed to create batch run: %v", err)
+	}
+	created, err := repo.CreateBatchRun(ctx, run)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if !created {
+		t.Fatal("CreateBatchRun() = false, want true")
+	}
+
+	// 実行中の間は次の実行枠の実行記録も作成しない
+	nextRun, err := batchDomain.NewBatchRun("d50e8400-e29b-41d4-a716-446655440002", jobName, windowStart.Add(batchDomain.RunInterval), &windowStart, windowStart.Add(batchDomain.RunInterval))
+	if err != nil {
+		t.Fatalf("failed to create batch run: %v", err)
+	}
+	created, err = repo.CreateBatchRun(ctx, nextRun)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if created {
+		t.Error("実行中のバッチ処理がある場合: CreateBatchRun() = true, want false")
+	}
+
+	run.Succeed(3, windowStart.Add(time.Minute))
+	if err := repo.FinishBatchRun(ctx, run); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	// 同じ実行枠の実行記録は2回作成しない
+	sameWindowRun, err := batchDomain.NewBatchRun("d50e8400-e29b-41d4-a716-446655440003", jobName, windowStart, nil, windowStart.Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("failed to create batch run: %v", err)
+	}
+	created, err = repo.CreateBatchRun(ctx, sameWindowRun)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if created {
+		t.Error("同じ実行枠の場合: CreateBatchRun() = true, want false")
+	}
+
+	// 実行中のまま残った実行記録は失敗にすると、次の実行枠の実行記録を作成できる
+	created, err = repo.CreateBatchRun(ctx, nextRun)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if !created {
+		t.Fatal("CreateBatchRun() = false, want true")
+	}
+	if err := repo.FailStaleBatchRuns(ctx, jobName, windowStart.Add(time.Hour), windowStart.Add(time.Hour)); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	afterStaleRun, err := batchDomain.NewBatchRun("d50e8400-e29b-41d4-a716-446655440004", jobName, windowStart.Add(time.Hour), &windowStart, windowStart.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("failed to create batch run: %v", err)
+	}
+	created, err = repo.CreateBatchRun(ctx, afterStaleRun)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if !created {
+		t.Error("実行中のまま残った実行記録を失敗にした場合: CreateBatchRun() = false, want true")
+	}
+
+	latest, err = repo.GetLatestBatchRunWindowStart(ctx, jobName)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if latest == nil || !latest.Equal(windowStart.Add(time.Hour)) {
+		t.Errorf("GetLatestBatchRunWindowStart() = %v, want %v", latest, windowStart.Add(time.Hour))
+	}
+}
+
+func TestBatchRepository_GetReviewDatesToUpdateByBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name            string
+		now             time.Time
+		userIDs         []string
+		wantReviewDates []string
+	}{
+		{
+			name: "ユーザーのタイムゾーンで日付けを跨いだ復習物の復習日を取得する",
+			// Asia/Tokyoでは2024-01-03、America/New_Yorkでは2024-01-02
+			now:             time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC),
+			userIDs:         []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"},
+			wantReviewDates: []string{"b50e8400-e29b-41d4-a716-446655440001", "b50e8400-e29b-41d4-a716-446655440002"},
+		},
+		{
+			name:            "指定していないユーザーの復習日は取得しない",
+			now:             time.Date(2024, 1, 2, 16, 0, 0, 0, time.UTC),
+			userIDs:         []string{"550e8400-e29b-41d4-a716-446655440002"},
+			wantReviewDates: []string{},
+		},
+		{
+			name: "ユーザーのタイムゾーンで日付けを跨いでいない場合は取得しない",
+			// Asia/Tokyoでは2024-01-02
+			now:             time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC),
+			userIDs:         []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"},
+			wantReviewDates: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewBatchRepository()
+
+			snapshots, err := repo.GetReviewDatesToUpdateByBatch(ctx, tc.now, tc.userIDs)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if len(snapshots) != len(tc.wantReviewDates) {
+				t.Fatalf("got %d review dates, want %d", len(snapshots), len(tc.wantReviewDates))
+			}
+			ids := make([]string, len(snapshots))
+			for i, snapshot := range snapshots {
+				if snapshot.ReviewDateID != tc.wantReviewDates[i] {
+					t.Errorf("snapshots[%d].ReviewDateID = %s, want %s", i, snapshot.ReviewDateID, tc.wantReviewDates[i])
+				}
+				ids[i] = snapshot.ReviewDateID
+			}
+
+			// 更新していないので、IDで取得し直した復習日は同じ
+			reloaded, err := repo.GetReviewDatesByIDs(ctx, ids)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if len(reloaded) != len(snapshots) {
+				t.Fatalf("got %d review dates, want %d", len(reloaded), len(snapshots))
+			}
+			for i := range reloaded {
+				if !reloaded[i].ScheduledDate.Equal(snapshots[i].ScheduledDate) {
+					t.Errorf("reloaded[%d].ScheduledDate = %v, want %v", i, reloaded[i].ScheduledDate, snapshots[i].ScheduledDate)
+				}
 			}
 		})
 	}
 }
+
+// フィクスチャの全てのユーザーのID
+func fixtureUserIDs(t *testing.T, repo IBatchRepository) []string {
+	t.Helper()
+	ctx := GetTestContext()
+	timezones, err := repo.GetUserTimezones(ctx)
+	if err != nil {
+		t.Fatalf("failed to get timezones: %v", err)
+	}
+	userIDs, err := repo.GetUserIDsByTimezones(ctx, timezones, "", 100)
+	if err != nil {
+		t.Fatalf("failed to get user ids: %v", err)
+	}
+	return userIDs
+}
+
+// 復習日のID毎の復習日と完了状態
+func snapshotReviewDates(t *testing.T) map[string]string {
+	t.Helper()
+	rows, err := GetTestDB().Query("SELECT id, scheduled_date, is_completed FROM review_dates")
+	if err != nil {
+		t.Fatalf("failed to get review dates: %v", err)
+	}
+	defer rows.Close()
+	snapshot := make(map[string]string)
+	for rows.Next() {
+		var id string
+		var scheduledDate time.Time
+		var isCompleted bool
+		if err := rows.Scan(&id, &scheduledDate, &isCompleted); err != nil {
+			t.Fatalf("failed to scan review date: %v", err)
+		}
+		snapshot[id] = fmt.Sprintf("%s %t", scheduledDate.Format(time.DateOnly), isCompleted)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to get review dates: %v", err)
+	}
+	return snapshot
+}
+
+func TestBatchRepository_GetUserIDsByTimezones(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewBatchRepository()
+
+	timezones, err := repo.GetUserTimezones(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if diff := cmp.Diff([]string{"America/New_York", "Asia/Tokyo", "Europe/London"}, timezones); diff != "" {
+		t.Errorf("GetUserTimezones() mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name        string
+		timezones   []string
+		afterUserID string
+		limit       int
+		want        []string
+	}{
+		{
+			name:      "タイムゾーンのユーザーをID順に取得する",
+			timezones: []string{"Asia/Tokyo"},
+			limit:     10,
+			want:      []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440004"},
+		},
+		{
+			name:      "上限件数まで取得する",
+			timezones: []string{"Asia/Tokyo", "America/New_York"},
+			limit:     2,
+			want:      []string{"550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002"},
+		},
+		{
+			name:        "指定したユーザーより後のユーザーを取得する",
+			timezones:   []string{"Asia/Tokyo", "America/New_York"},
+			afterUserID: "550e8400-e29b-41d4-a716-446655440002",
+			limit:       2,
+			want:        []string{"550e8400-e29b-41d4-a716-446655440004"},
+		},
+		{
+			name:      "タイムゾーンを指定しない場合は取得しない",
+			timezones: []string{},
+			limit:     10,
+			want:      []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := repo.GetUserIDsByTimezones(ctx, tc.timezones, tc.afterUserID, tc.limit)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetUserIDsByTimezones() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// ユーザーを1件ずつ分けて更新しても、全てのユーザーを1回で更新した場合と同じ復習日になる
+func TestBatchRepository_UserChunksProduceSameResult(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewBatchRepository()
+	// フィクスチャの全ての未完了の復習日が期限切れになる時刻
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	update := func(userIDs []string) int64 {
+		var total int64
+		for _, execute := range []func(context.Context, time.Time, []string) (int64, error){
+			repo.ExecuteApplyEndedPauses,
+			repo.ExecuteUpdateOverdueScheduledDates,
+			repo.ExecuteMoveOverdueScheduledDatesToToday,
+			repo.ExecuteResetOverdueItemsToFirstStep,
+		} {
+			rows, err := execute(ctx, now, userIDs)
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			total += rows
+		}
+		return total
+	}
+
+	PrepareTestDatabase(t)
+	wantRows := update(fixtureUserIDs(t, repo))
+	want := snapshotReviewDates(t)
+
+	PrepareTestDatabase(t)
+	timezones, err := repo.GetUserTimezones(ctx)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	var gotRows int64
+	afterUserID := ""
+	for {
+		userIDs, err := repo.GetUserIDsByTimezones(ctx, timezones, afterUserID, 1)
+		if err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+		if len(userIDs) == 0 {
+			break
+		}
+		gotRows += update(userIDs)
+		afterUserID = userIDs[0]
+	}
+	got := snapshotReviewDates(t)
+
+	if wantRows == 0 {
+		t.Fatalf("フィクスチャの復習日が更新されていません")
+	}
+	if gotRows != wantRows {
+		t.Errorf("rows = %d, want %d", gotRows, wantRows)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("review dates mismatch (-want +got):\n%s", diff)
+	}
+}
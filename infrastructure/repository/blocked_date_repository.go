@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	userDomain "github.com/minminseo/recall-setter/domain/user"
+	"github.com/minminseo/recall-setter/infrastructure/db"
+	"github.com/minminseo/recall-setter/infrastructure/db/dbgen"
+)
+
+type blockedDateRepository struct{}
+
+func NewBlockedDateRepository() userDomain.BlockedDateRepository {
+	return &blockedDateRepository{}
+}
+
+func (r *blockedDateRepository) Create(ctx context.Context, b *userDomain.BlockedDate) error {
+	q := db.GetQuery(ctx)
+
+	pgID, err := toUUID(b.ID())
+	if err != nil {
+		return err
+	}
+
+	pgUserID, err := toUUID(b.UserID())
+	if err != nil {
+		return err
+	}
+
+	params := dbgen.CreateBlockedDateParams{
+		ID:          pgID,
+		UserID:      pgUserID,
+		BlockedDate: pgtype.Date{Time: b.Date(), Valid: true},
+		Name:        b.Name(),
+	}
+
+	return q.CreateBlockedDate(ctx, params)
+}
+
+func (r *blockedDateRepository) GetByUserID(ctx context.Context, userID string) ([]*userDomain.BlockedDate, error) {
+	q := db.GetQuery(ctx)
+
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.GetBlockedDatesByUserID(ctx, pgUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedDates := make([]*userDomain.BlockedDate, 0, len(rows))
+	for _, row := range rows {
+		b, err := userDomain.ReconstructBlockedDate(
+			uuid.UUID(row.ID.Bytes).String(),
+			uuid.UUID(row.UserID.Bytes).String(),
+			row.BlockedDate.Time,
+			row.Name,
+		)
+		if err != nil {
+			return nil, err
+		}
+		blockedDates = append(blockedDates, b)
+	}
+	return blockedDates, nil
+}
+
+func (r *blockedDateRepository) GetByID(ctx context.Context, id string, userID string) (*userDomain.BlockedDate, error) {
+	q := db.GetQuery(ctx)
+
+	pgID, err := toUUID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := dbgen.GetBlockedDateByIDParams{
+		ID:     pgID,
+		UserID: pgUserID,
+	}
+	row, err := q.GetBlockedDateByID(ctx, params)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userDomain.ErrBlockedDateNotFound
+		}
+		return nil, err
+	}
+
+	return userDomain.ReconstructBlockedDate(
+		uuid.UUID(row.ID.Bytes).String(),
+		uuid.UUID(row.UserID.Bytes).String(),
+		row.BlockedDate.Time,
+		row.Name,
+	)
+}
+
+func (r *blockedDateRepository) Update(ctx context.Context, b *userDomain.BlockedDate) error {
+	q := db.GetQuery(ctx)
+
+	pgID, err := toUUID(b.ID())
+	if err != nil {
+		return err
+	}
+
+	pgUserID, err := toUUID(b.UserID())
+	if err != nil {
+		return err
+	}
+
+	params := dbgen.UpdateBlockedDateParams{
+		BlockedDate: pgtype.Date{Time: b.Date(), Valid: true},
+		Name:        b.Name(),
+		ID:          pgID,
+		UserID:      pgUserID,
+	}
+
+	return q.UpdateBlockedDate(ctx, params)
+}
+
+func (r *blockedDateRepository) Delete(ctx context.Context, id string, userID string) error {
+	q := db.GetQuery(ctx)
+
+	pgID, err := toUUID(id)
+	if err != nil {
+		return err
+	}
+
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return err
+	}
+
+	params := dbgen.DeleteBlockedDateParams{
+		ID:     pgID,
+		UserID: pgUserID,
+	}
+
+	return q.DeleteBlockedDate(ctx, params)
+}
+
+func (r *blockedDateRepository) MoveReviewDatesOffBlockedDates(ctx context.Context, userID string, dates []time.Time) (int64, error) {
+	q := db.GetQuery(ctx)
+
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	pgDates := make([]pgtype.Date, len(dates))
+	for i, d := range dates {
+		pgDates[i] = pgtype.Date{Time: d, Valid: true}
+	}
+
+	params := dbgen.MoveReviewDatesOffBlockedDatesParams{
+		UserID:       pgUserID,
+		BlockedDates: pgDates,
+	}
+
+	return q.MoveReviewDatesOffBlockedDates(ctx, params)
+}
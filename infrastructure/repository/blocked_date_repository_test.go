@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	userDomain "github.com/minminseo/recall-setter/domain/user"
+)
+
+func TestBlockedDateRepository_Create(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	newBlockedDate := func(userID string, date time.Time) *userDomain.BlockedDate {
+		b, _ := userDomain.NewBlockedDate(uuid.New().String(), userID, date, "祝日", date)
+		return b
+	}
+
+	tests := []struct {
+		name        string
+		blockedDate *userDomain.BlockedDate
+		wantCount   int
+		wantErr     bool
+	}{
+		{
+			name:        "既存ユーザーで復習日を置かない日付を作成（正常系）",
+			blockedDate: newBlockedDate("550e8400-e29b-41d4-a716-446655440002", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)),
+			wantCount:   1,
+			wantErr:     false,
+		},
+		{
+			name:        "既に登録されている日付の場合は何もしない（正常系）",
+			blockedDate: newBlockedDate("550e8400-e29b-41d4-a716-446655440001", time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC)),
+			wantCount:   2,
+			wantErr:     false,
+		},
+		{
+			name:        "存在しないユーザーで作成（外部キー制約違反）",
+			blockedDate: newBlockedDate(uuid.New().String(), time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC)),
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewBlockedDateRepository()
+
+			err := repo.Create(ctx, tc.blockedDate)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			got, err := repo.GetByUserID(ctx, tc.blockedDate.UserID())
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if len(got) != tc.wantCount {
+				t.Errorf("GetByUserID() len = %v, want %v", len(got), tc.wantCount)
+			}
+		})
+	}
+}
+
+func TestBlockedDateRepository_GetByID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name     string
+		id       string
+		userID   string
+		wantDate time.Time
+		wantName string
+		wantErr  error
+	}{
+		{
+			name:     "存在する日付の場合",
+			id:       "c60e8400-e29b-41d4-a716-446655440001",
+			userID:   "550e8400-e29b-41d4-a716-446655440001",
+			wantDate: time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC),
+			wantName: "振替休日",
+		},
+		{
+			name:    "他のユーザーの日付の場合",
+			id:      "c60e8400-e29b-41d4-a716-446655440001",
+			userID:  "550e8400-e29b-41d4-a716-446655440002",
+			wantErr: userDomain.ErrBlockedDateNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewBlockedDateRepository()
+
+			got, err := repo.GetByID(ctx, tc.id, tc.userID)
+
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Errorf("GetByID() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if !got.Date().Equal(tc.wantDate) || got.Name() != tc.wantName {
+				t.Errorf("GetByID() = (%v, %v), want (%v, %v)", got.Date(), got.Name(), tc.wantDate, tc.wantName)
+			}
+		})
+	}
+}
+
+func TestBlockedDateRepository_UpdateAndDelete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewBlockedDateRepository()
+	id := "c60e8400-e29b-41d4-a716-446655440001"
+	userID := "550e8400-e29b-41d4-a716-446655440001"
+
+	b, err := repo.GetByID(ctx, id, userID)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	newDate := time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC)
+	if err := b.Update(newDate, "有給休暇", newDate); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if err := repo.Update(ctx, b); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, id, userID)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if !got.Date().Equal(newDate) || got.Name() != "有給休暇" {
+		t.Errorf("GetByID() = (%v, %v), want (%v, %v)", got.Date(), got.Name(), newDate, "有給休暇")
+	}
+
+	if err := repo.Delete(ctx, id, userID); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, id, userID); !errors.Is(err, userDomain.ErrBlockedDateNotFound) {
+		t.Errorf("GetByID() error = %v, want %v", err, userDomain.ErrBlockedDateNotFound)
+	}
+}
+
+func TestBlockedDateRepository_MoveReviewDatesOffBlockedDates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewBlockedDateRepository()
+	userID := "550e8400-e29b-41d4-a716-446655440001"
+	// 2024-01-04には未完了の復習日、2024-01-03には完了済みの復習日がある。2024-01-05は他のユーザーの復習日だけがある
+	dates := []time.Time{
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+	}
+	for _, d := range dates {
+		b, _ := userDomain.NewBlockedDate(uuid.New().String(), userID, d, "", d)
+		if err := repo.Create(ctx, b); err != nil {
+			t.Fatalf("予期しないエラー: %v", err)
+		}
+	}
+
+	rows, err := repo.MoveReviewDatesOffBlockedDates(ctx, userID, dates)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if rows != 1 {
+		t.Errorf("MoveReviewDatesOffBlockedDates() = %d, want 1", rows)
+	}
+
+	tests := []struct {
+		name         string
+		reviewDateID string
+		want         string
+	}{
+		{name: "未完了の復習日は復習日を置かない日付の次の日にずれる", reviewDateID: "b50e8400-e29b-41d4-a716-446655440002", want: "2024-01-06"},
+		{name: "完了済みの復習日はずらさない", reviewDateID: "b50e8400-e29b-41d4-a716-446655440003", want: "2024-01-03"},
+		{name: "他のユーザーの復習日はずらさない", reviewDateID: "b50e8400-e29b-41d4-a716-446655440005", want: "2024-01-05"},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var got time.Time
+			if err := GetTestDB().QueryRow("SELECT scheduled_date FROM review_dates WHERE id = $1", tc.reviewDateID).Scan(&got); err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if got.Format("2006-01-02") != tc.want {
+				t.Errorf("scheduled_date = %s, want %s", got.Format("2006-01-02"), tc.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -207,16 +208,15 @@ func (r *boxRepository) UpdateWithPatternID(ctx context.Context, box *boxDomain.
 
 	pgEditedAt := pgtype.Timestamptz{Time: box.EditedAt(), Valid: true}
 
-	params := dbgen.UpdateBoxIfNoReviewItemsParams{
+	params := dbgen.UpdateBoxWithPatternIDParams{
 		PatternID:  pgPatternID,
 		Name:       box.Name(),
 		EditedAt:   pgEditedAt,
 		ID:         pgID,
 		CategoryID: pgCategoryID,
 		UserID:     pgUserID,
-		BoxID:      pgID,
 	}
-	return q.UpdateBoxIfNoReviewItems(ctx, params)
+	return q.UpdateBoxWithPatternID(ctx, params)
 }
 
 func (r *boxRepository) Delete(ctx context.Context, boxID string, categoryID string, userID string) error {
@@ -265,3 +265,80 @@ func (r *boxRepository) GetBoxNamesByBoxIDs(ctx context.Context, ids []string) (
 	}
 	return out, nil
 }
+
+func (r *boxRepository) GetAllByPatternID(ctx context.Context, patternID string, userID string) ([]*boxDomain.Box, error) {
+	q := db.GetQuery(ctx)
+
+	parsedPatternID, err := uuid.Parse(patternID)
+	if err != nil {
+		return nil, err
+	}
+	pgPatternID := pgtype.UUID{Bytes: parsedPatternID, Valid: true}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, err
+	}
+	pgUserID := pgtype.UUID{Bytes: parsedUserID, Valid: true}
+
+	rows, err := q.GetAllBoxesByPatternID(ctx, dbgen.GetAllBoxesByPatternIDParams{
+		PatternID: pgPatternID,
+		UserID:    pgUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	boxes := make([]*boxDomain.Box, len(rows))
+	for i, row := range rows {
+		id := uuid.UUID(row.ID.Bytes).String()
+		uid := uuid.UUID(row.UserID.Bytes).String()
+		cid := uuid.UUID(row.CategoryID.Bytes).String()
+		pid := uuid.UUID(row.PatternID.Bytes).String()
+
+		b, err := boxDomain.ReconstructBox(
+			id,
+			uid,
+			cid,
+			pid,
+			row.Name,
+			row.RegisteredAt.Time,
+			row.EditedAt.Time,
+		)
+		if err != nil {
+			return nil, err
+		}
+		boxes[i] = b
+	}
+	return boxes, nil
+}
+
+func (r *boxRepository) UpdatePatternIDByPatternID(ctx context.Context, patternID string, userID string, newPatternID string, editedAt time.Time) (int64, error) {
+	q := db.GetQuery(ctx)
+
+	parsedPatternID, err := uuid.Parse(patternID)
+	if err != nil {
+		return 0, err
+	}
+	pgPatternID := pgtype.UUID{Bytes: parsedPatternID, Valid: true}
+
+	parsedUserID, err := uuid.Parse(userID)
+	if err != nil {
+		return 0, err
+	}
+	pgUserID := pgtype.UUID{Bytes: parsedUserID, Valid: true}
+
+	parsedNewPatternID, err := uuid.Parse(newPatternID)
+	if err != nil {
+		return 0, err
+	}
+	pgNewPatternID := pgtype.UUID{Bytes: parsedNewPatternID, Valid: true}
+
+	params := dbgen.UpdateBoxesPatternIDByPatternIDParams{
+		NewPatternID: pgNewPatternID,
+		EditedAt:     pgtype.Timestamptz{Time: editedAt, Valid: true},
+		PatternID:    pgPatternID,
+		UserID:       pgUserID,
+	}
+	return q.UpdateBoxesPatternIDByPatternID(ctx, params)
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"sort"
 	"testing"
 	"time"
 
@@ -595,6 +596,53 @@ func TestBoxRepository_UpdateWithPatternID(t *testing.T) {
 			wantErr:         false,
 			expectedUpdated: 1,
 		},
+		{
+			name: "復習物があるボックスのパターンID更新に成功する場合",
+			box: func() *boxDomain.Box {
+				box, _ := boxDomain.ReconstructBox(
+					"950e8400-e29b-41d4-a716-446655440003",
+					"550e8400-e29b-41d4-a716-446655440001",
+					"650e8400-e29b-41d4-a716-446655440002",
+					"750e8400-e29b-41d4-a716-446655440002", // 変更するパターンID
+					"力学",
+					time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+					time.Now(),
+				)
+				return box
+			}(),
+			want: func() *boxDomain.Box {
+				box, _ := boxDomain.ReconstructBox(
+					"950e8400-e29b-41d4-a716-446655440003",
+					"550e8400-e29b-41d4-a716-446655440001",
+					"650e8400-e29b-41d4-a716-446655440002",
+					"750e8400-e29b-41d4-a716-446655440002",
+					"力学",
+					time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+					time.Time{},
+				)
+				return box
+			}(),
+			wantErr:         false,
+			expectedUpdated: 1,
+		},
+		{
+			name: "存在しないボックスの場合（更新数0）",
+			box: func() *boxDomain.Box {
+				box, _ := boxDomain.ReconstructBox(
+					"950e8400-e29b-41d4-a716-446655440099",
+					"550e8400-e29b-41d4-a716-446655440001",
+					"650e8400-e29b-41d4-a716-446655440001",
+					"750e8400-e29b-41d4-a716-446655440002",
+					"存在しないボックス",
+					time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+					time.Now(),
+				)
+				return box
+			}(),
+			want:            nil,
+			wantErr:         false,
+			expectedUpdated: 0,
+		},
 	}
 
 	for _, tc := range tests {
@@ -729,3 +777,147 @@ func TestBoxRepository_GetBoxNamesByBoxIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestBoxRepository_GetAllByPatternID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name       string
+		patternID  string
+		userID     string
+		wantBoxIDs []string
+		wantErr    bool
+	}{
+		{
+			name:      "パターンを使うボックスを取得する場合",
+			patternID: "750e8400-e29b-41d4-a716-446655440001",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			wantBoxIDs: []string{
+				"950e8400-e29b-41d4-a716-446655440001",
+				"950e8400-e29b-41d4-a716-446655440003",
+				"950e8400-e29b-41d4-a716-446655440005",
+			},
+			wantErr: false,
+		},
+		{
+			name:       "他のユーザーのパターンIDを指定した場合",
+			patternID:  "750e8400-e29b-41d4-a716-446655440001",
+			userID:     "550e8400-e29b-41d4-a716-446655440002",
+			wantBoxIDs: []string{},
+			wantErr:    false,
+		},
+		{
+			name:      "無効なパターンIDの場合",
+			patternID: "invalid-uuid",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewBoxRepository()
+
+			boxes, err := repo.GetAllByPatternID(ctx, tc.patternID, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			gotBoxIDs := make([]string, len(boxes))
+			for i, b := range boxes {
+				gotBoxIDs[i] = b.ID()
+			}
+
+			sort.Strings(gotBoxIDs)
+			if diff := cmp.Diff(tc.wantBoxIDs, gotBoxIDs); diff != "" {
+				t.Errorf("GetAllByPatternID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBoxRepository_UpdatePatternIDByPatternID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name         string
+		patternID    string
+		userID       string
+		newPatternID string
+		wantAffected int64
+		wantErr      bool
+	}{
+		{
+			name:         "パターンを使うボックスを別のパターンに付け替える場合",
+			patternID:    "750e8400-e29b-41d4-a716-446655440002",
+			userID:       "550e8400-e29b-41d4-a716-446655440001",
+			newPatternID: "750e8400-e29b-41d4-a716-446655440001",
+			wantAffected: 1,
+			wantErr:      false,
+		},
+		{
+			name:         "無効な付け替え先のパターンIDの場合",
+			patternID:    "750e8400-e29b-41d4-a716-446655440001",
+			userID:       "550e8400-e29b-41d4-a716-446655440001",
+			newPatternID: "invalid-uuid",
+			wantErr:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewBoxRepository()
+
+			affected, err := repo.UpdatePatternIDByPatternID(ctx, tc.patternID, tc.userID, tc.newPatternID, time.Now())
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if affected != tc.wantAffected {
+				t.Errorf("UpdatePatternIDByPatternID() affected = %v, want %v", affected, tc.wantAffected)
+			}
+
+			// 付け替え元のパターンを使うボックスが残っていないことを確認
+			boxes, err := repo.GetAllByPatternID(ctx, tc.patternID, tc.userID)
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+			if len(boxes) != 0 {
+				t.Errorf("付け替え後もボックスが残っています: %d", len(boxes))
+			}
+		})
+	}
+}
@@ -108,10 +108,15 @@ func CleanupTestDatabase(t *testing.T) {
 	t.Helper()
 
 	tables := []string{
+		"batch_runs",
+		"user_blocked_dates",
+		"user_pauses",
 		"email_verifications",
 		"review_dates",
 		"review_items",
 		"review_boxes",
+		"pattern_version_steps",
+		"pattern_versions",
 		"pattern_steps",
 		"review_patterns",
 		"categories",
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -28,6 +29,40 @@ func toNullableUUID(s *string) (pgtype.UUID, error) {
 	return toUUID(*s)
 }
 
+// 想起評価をdbgen.NullRecallGradeEnumに変換するヘルパー関数。nilの場合はNULLとして扱う。
+func toNullRecallGrade(s *string) dbgen.NullRecallGradeEnum {
+	if s == nil {
+		return dbgen.NullRecallGradeEnum{Valid: false}
+	}
+	return dbgen.NullRecallGradeEnum{RecallGradeEnum: dbgen.RecallGradeEnum(*s), Valid: true}
+}
+
+// dbgen.NullRecallGradeEnumを想起評価に変換するヘルパー関数。NULLの場合はnilを返す。
+func fromNullRecallGrade(ng dbgen.NullRecallGradeEnum) *string {
+	if !ng.Valid {
+		return nil
+	}
+	grade := string(ng.RecallGradeEnum)
+	return &grade
+}
+
+// 復習日時をpgtype.Timestamptzに変換するヘルパー関数。nilの場合はNULLとして扱う。
+func toNullableTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{Valid: false}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+// pgtype.Timestamptzを復習日時に変換するヘルパー関数。NULLの場合はnilを返す。
+func fromNullableTimestamptz(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time.UTC()
+	return &t
+}
+
 func (r *itemRepository) CreateItem(ctx context.Context, item *itemDomain.Item) error {
 	q := db.GetQuery(ctx)
 
@@ -106,6 +141,7 @@ func (r *itemRepository) CreateReviewdates(ctx context.Context, reviewdates []*i
 			InitialScheduledDate: pgtype.Date{Time: rd.InitialScheduledDate(), Valid: true},
 			ScheduledDate:        pgtype.Date{Time: rd.ScheduledDate(), Valid: true},
 			IsCompleted:          rd.IsCompleted(),
+			ScheduledAt:          toNullableTimestamptz(rd.ScheduledAt()),
 		}
 
 		rows[i] = []interface{}{
@@ -118,10 +154,11 @@ func (r *itemRepository) CreateReviewdates(ctx context.Context, reviewdates []*i
 			params[i].InitialScheduledDate,
 			params[i].ScheduledDate,
 			params[i].IsCompleted,
+			params[i].ScheduledAt,
 		}
 	}
 
-	columns := []string{"id", "user_id", "category_id", "box_id", "item_id", "step_number", "initial_scheduled_date", "scheduled_date", "is_completed"}
+	columns := []string{"id", "user_id", "category_id", "box_id", "item_id", "step_number", "initial_scheduled_date", "scheduled_date", "is_completed", "scheduled_at"}
 	return q.CopyFrom(
 		ctx,
 		pgx.Identifier{"review_dates"},
@@ -149,7 +186,7 @@ func (r *itemRepository) GetItemByID(ctx context.Context, itemID string, userID
 		return nil, err
 	}
 
-	var categoryID, boxID, patternID *string
+	var categoryID, boxID, patternID, patternVersionID *string
 	if row.CategoryID.Valid {
 		idStr := uuid.UUID(row.CategoryID.Bytes).String()
 		categoryID = &idStr
@@ -162,6 +199,10 @@ func (r *itemRepository) GetItemByID(ctx context.Context, itemID string, userID
 		idStr := uuid.UUID(row.PatternID.Bytes).String()
 		patternID = &idStr
 	}
+	if row.PatternVersionID.Valid {
+		idStr := uuid.UUID(row.PatternVersionID.Bytes).String()
+		patternVersionID = &idStr
+	}
 
 	return itemDomain.ReconstructItem(
 		uuid.UUID(row.ID.Bytes).String(),
@@ -169,6 +210,7 @@ func (r *itemRepository) GetItemByID(ctx context.Context, itemID string, userID
 		categoryID,
 		boxID,
 		patternID,
+		patternVersionID,
 		row.Name,
 		row.Detail.String,
 		row.LearnedDate.Time,
@@ -270,7 +312,7 @@ func (r *itemRepository) UpdateReviewDates(ctx context.Context, reviewdates []*i
 
 	inputs := make([]string, len(reviewdates))
 	for i, rd := range reviewdates {
-		// UNNESTに渡すための(id,category_id,box_id,scheduled_date,is_completed)形式の文字列を生成
+		// UNNESTに渡すための(id,category_id,box_id,scheduled_date,is_completed,scheduled_at)形式の文字列を生成
 		var categoryID string
 		if rd.CategoryID() != nil {
 			categoryID = *rd.CategoryID()
@@ -279,7 +321,12 @@ func (r *itemRepository) UpdateReviewDates(ctx context.Context, reviewdates []*i
 		if rd.BoxID() != nil {
 			boxID = *rd.BoxID()
 		}
-		inputs[i] = fmt.Sprintf("(%s,%s,%s,%s,%s,%t)", rd.ReviewdateID(), categoryID, boxID, rd.InitialScheduledDate().Format("2006-01-02"), rd.ScheduledDate().Format("2006-01-02"), rd.IsCompleted())
+		// 空の場合はNULLになる
+		var scheduledAt string
+		if rd.ScheduledAt() != nil {
+			scheduledAt = rd.ScheduledAt().UTC().Format(time.RFC3339Nano)
+		}
+		inputs[i] = fmt.Sprintf("(%s,%s,%s,%s,%s,%t,%s)", rd.ReviewdateID(), categoryID, boxID, rd.InitialScheduledDate().Format("2006-01-02"), rd.ScheduledDate().Format("2006-01-02"), rd.IsCompleted(), scheduledAt)
 	}
 
 	params := dbgen.UpdateReviewDatesParams{
@@ -355,7 +402,101 @@ func (r *itemRepository) UpdateItemAsUnFinished(ctx context.Context, itemID stri
 	return q.UpdateItemAsUnfinished(ctx, params)
 }
 
-func (r *itemRepository) UpdateReviewDateAsCompleted(ctx context.Context, reviewdateID string, userID string) error {
+// 復習日の完了と同じく、復習日、復習物の順にロックする
+func (r *itemRepository) LockUnFinishedItemsByBoxID(ctx context.Context, boxID string, userID string) error {
+	q := db.GetQuery(ctx)
+	pgBoxID, err := toUUID(boxID)
+	if err != nil {
+		return err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return err
+	}
+	reviewDatesParams := dbgen.LockReviewDatesOfUnFinishedItemsByBoxIDParams{
+		BoxID:  pgBoxID,
+		UserID: pgUserID,
+	}
+	if err := q.LockReviewDatesOfUnFinishedItemsByBoxID(ctx, reviewDatesParams); err != nil {
+		return err
+	}
+	itemsParams := dbgen.LockUnFinishedItemsByBoxIDParams{
+		BoxID:  pgBoxID,
+		UserID: pgUserID,
+	}
+	return q.LockUnFinishedItemsByBoxID(ctx, itemsParams)
+}
+
+// 復習日の完了と同じく、復習日、復習物の順にロックする
+func (r *itemRepository) LockUnFinishedItemsByPatternID(ctx context.Context, patternID string, userID string) error {
+	q := db.GetQuery(ctx)
+	pgPatternID, err := toUUID(patternID)
+	if err != nil {
+		return err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return err
+	}
+	reviewDatesParams := dbgen.LockReviewDatesOfUnFinishedItemsByPatternIDParams{
+		PatternID: pgPatternID,
+		UserID:    pgUserID,
+	}
+	if err := q.LockReviewDatesOfUnFinishedItemsByPatternID(ctx, reviewDatesParams); err != nil {
+		return err
+	}
+	itemsParams := dbgen.LockUnFinishedItemsByPatternIDParams{
+		PatternID: pgPatternID,
+		UserID:    pgUserID,
+	}
+	return q.LockUnFinishedItemsByPatternID(ctx, itemsParams)
+}
+
+func (r *itemRepository) UpdateItemsPatternIDByBoxID(ctx context.Context, boxID string, userID string, patternID string) error {
+	q := db.GetQuery(ctx)
+	pgBoxID, err := toUUID(boxID)
+	if err != nil {
+		return err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return err
+	}
+	pgPatternID, err := toUUID(patternID)
+	if err != nil {
+		return err
+	}
+	params := dbgen.UpdateItemsPatternIDByBoxIDParams{
+		PatternID: pgPatternID,
+		BoxID:     pgBoxID,
+		UserID:    pgUserID,
+	}
+	return q.UpdateItemsPatternIDByBoxID(ctx, params)
+}
+
+func (r *itemRepository) UpdateItemPatternVersionID(ctx context.Context, itemID string, userID string, patternVersionID string) error {
+	q := db.GetQuery(ctx)
+	pgItemID, err := toUUID(itemID)
+	if err != nil {
+		return err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return err
+	}
+	pgPatternVersionID, err := toUUID(patternVersionID)
+	if err != nil {
+		return err
+	}
+	params := dbgen.UpdateItemPatternVersionIDParams{
+		PatternVersionID: pgPatternVersionID,
+		ID:               pgItemID,
+		UserID:           pgUserID,
+	}
+	return q.UpdateItemPatternVersionID(ctx, params)
+}
+
+func (r *itemRepository) UpdateReviewDateAsCompleted(ctx context.Context, reviewdateID string, userID string, recallGrade *string) error {
 	q := db.GetQuery(ctx)
 	pgID, err := toUUID(reviewdateID)
 	if err != nil {
@@ -366,8 +507,9 @@ func (r *itemRepository) UpdateReviewDateAsCompleted(ctx context.Context, review
 		return err
 	}
 	params := dbgen.UpdateReviewDateAsCompletedParams{
-		ID:     pgID,
-		UserID: pgUserID,
+		RecallGrade: toNullRecallGrade(recallGrade),
+		ID:          pgID,
+		UserID:      pgUserID,
 	}
 	return q.UpdateReviewDateAsCompleted(ctx, params)
 }
@@ -430,6 +572,8 @@ func (r *itemRepository) GetReviewDatesByItemID(ctx context.Context, itemID stri
 			row.InitialScheduledDate.Time,
 			row.ScheduledDate.Time,
 			row.IsCompleted,
+			fromNullRecallGrade(row.RecallGrade),
+			fromNullableTimestamptz(row.ScheduledAt),
 		)
 		if err != nil {
 			return nil, err
@@ -472,6 +616,76 @@ func (r *itemRepository) DeleteReviewDates(ctx context.Context, itemID string, u
 	return q.DeleteReviewDates(ctx, params)
 }
 
+func (r *itemRepository) DeleteInCompletedReviewDatesAfterStep(ctx context.Context, itemID string, userID string, stepNumber int) error {
+	q := db.GetQuery(ctx)
+	pgItemID, err := toUUID(itemID)
+	if err != nil {
+		return err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return err
+	}
+	params := dbgen.DeleteInCompletedReviewDatesAfterStepParams{
+		ItemID:     pgItemID,
+		UserID:     pgUserID,
+		StepNumber: int16(stepNumber),
+	}
+	return q.DeleteInCompletedReviewDatesAfterStep(ctx, params)
+}
+
+func (r *itemRepository) FindSM2StateByItemID(ctx context.Context, itemID string, userID string) (*itemDomain.SM2State, error) {
+	q := db.GetQuery(ctx)
+	pgItemID, err := toUUID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	params := dbgen.GetSM2StateByItemIDParams{
+		ItemID: pgItemID,
+		UserID: pgUserID,
+	}
+	row, err := q.GetSM2StateByItemID(ctx, params)
+	if err != nil {
+		// 学習状態は初回完了時に作成されるため、存在しない場合はエラーにしない
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return itemDomain.ReconstructSM2State(
+		uuid.UUID(row.ItemID.Bytes).String(),
+		uuid.UUID(row.UserID.Bytes).String(),
+		row.EaseFactor,
+		int(row.Repetitions),
+		int(row.IntervalDays),
+	)
+}
+
+func (r *itemRepository) UpsertSM2State(ctx context.Context, state *itemDomain.SM2State) error {
+	q := db.GetQuery(ctx)
+	pgItemID, err := toUUID(state.ItemID())
+	if err != nil {
+		return err
+	}
+	pgUserID, err := toUUID(state.UserID())
+	if err != nil {
+		return err
+	}
+	params := dbgen.UpsertSM2StateParams{
+		ItemID:       pgItemID,
+		UserID:       pgUserID,
+		EaseFactor:   state.EaseFactor(),
+		Repetitions:  int16(state.Repetitions()),
+		IntervalDays: int16(state.IntervalDays()),
+	}
+	return q.UpsertSM2State(ctx, params)
+}
+
 func (r *itemRepository) GetAllUnFinishedItemsByBoxID(ctx context.Context, boxID string, userID string) ([]*itemDomain.Item, error) {
 	q := db.GetQuery(ctx)
 	pgBoxID, err := toUUID(boxID)
@@ -493,7 +707,66 @@ func (r *itemRepository) GetAllUnFinishedItemsByBoxID(ctx context.Context, boxID
 
 	results := make([]*itemDomain.Item, len(rows))
 	for i, row := range rows {
-		var categoryID, boxID, patternID *string
+		var categoryID, boxID, patternID, patternVersionID *string
+		if row.CategoryID.Valid {
+			idStr := uuid.UUID(row.CategoryID.Bytes).String()
+			categoryID = &idStr
+		}
+		if row.BoxID.Valid {
+			idStr := uuid.UUID(row.BoxID.Bytes).String()
+			boxID = &idStr
+		}
+		if row.PatternID.Valid {
+			idStr := uuid.UUID(row.PatternID.Bytes).String()
+			patternID = &idStr
+		}
+		if row.PatternVersionID.Valid {
+			idStr := uuid.UUID(row.PatternVersionID.Bytes).String()
+			patternVersionID = &idStr
+		}
+		results[i], err = itemDomain.ReconstructItem(
+			uuid.UUID(row.ID.Bytes).String(),
+			uuid.UUID(row.UserID.Bytes).String(),
+			categoryID,
+			boxID,
+			patternID,
+			patternVersionID,
+			row.Name,
+			row.Detail.String,
+			row.LearnedDate.Time,
+			row.IsFinished,
+			row.RegisteredAt.Time,
+			row.EditedAt.Time,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func (r *itemRepository) GetAllUnFinishedItemsByPatternID(ctx context.Context, patternID string, userID string) ([]*itemDomain.Item, error) {
+	q := db.GetQuery(ctx)
+	pgPatternID, err := toUUID(patternID)
+	if err != nil {
+		return nil, err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	params := dbgen.GetAllUnFinishedItemsByPatternIDParams{
+		PatternID: pgPatternID,
+		UserID:    pgUserID,
+	}
+	rows, err := q.GetAllUnFinishedItemsByPatternID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*itemDomain.Item, len(rows))
+	for i, row := range rows {
+		var categoryID, boxID, patternID, patternVersionID *string
 		if row.CategoryID.Valid {
 			idStr := uuid.UUID(row.CategoryID.Bytes).String()
 			categoryID = &idStr
@@ -506,12 +779,17 @@ func (r *itemRepository) GetAllUnFinishedItemsByBoxID(ctx context.Context, boxID
 			idStr := uuid.UUID(row.PatternID.Bytes).String()
 			patternID = &idStr
 		}
+		if row.PatternVersionID.Valid {
+			idStr := uuid.UUID(row.PatternVersionID.Bytes).String()
+			patternVersionID = &idStr
+		}
 		results[i], err = itemDomain.ReconstructItem(
 			uuid.UUID(row.ID.Bytes).String(),
 			uuid.UUID(row.UserID.Bytes).String(),
 			categoryID,
 			boxID,
 			patternID,
+			patternVersionID,
 			row.Name,
 			row.Detail.String,
 			row.LearnedDate.Time,
@@ -565,6 +843,8 @@ func (r *itemRepository) GetAllReviewDatesByBoxID(ctx context.Context, boxID str
 			row.InitialScheduledDate.Time,
 			row.ScheduledDate.Time,
 			row.IsCompleted,
+			fromNullRecallGrade(row.RecallGrade),
+			fromNullableTimestamptz(row.ScheduledAt),
 		)
 		if err != nil {
 			return nil, err
@@ -585,17 +865,22 @@ func (r *itemRepository) GetAllUnFinishedUnclassifiedItemsByUserID(ctx context.C
 	}
 	results := make([]*itemDomain.Item, len(rows))
 	for i, row := range rows {
-		var patternID *string
+		var patternID, patternVersionID *string
 		if row.PatternID.Valid {
 			idStr := uuid.UUID(row.PatternID.Bytes).String()
 			patternID = &idStr
 		}
+		if row.PatternVersionID.Valid {
+			idStr := uuid.UUID(row.PatternVersionID.Bytes).String()
+			patternVersionID = &idStr
+		}
 		results[i], err = itemDomain.ReconstructItem(
 			uuid.UUID(row.ID.Bytes).String(),
 			uuid.UUID(row.UserID.Bytes).String(),
 			nil, // Unclassified
 			nil, // Unclassified
 			patternID,
+			patternVersionID,
 			row.Name,
 			row.Detail.String,
 			row.LearnedDate.Time,
@@ -632,6 +917,8 @@ func (r *itemRepository) GetAllUnclassifiedReviewDatesByUserID(ctx context.Conte
 			row.InitialScheduledDate.Time,
 			row.ScheduledDate.Time,
 			row.IsCompleted,
+			fromNullRecallGrade(row.RecallGrade),
+			fromNullableTimestamptz(row.ScheduledAt),
 		)
 		if err != nil {
 			return nil, err
@@ -662,11 +949,15 @@ func (r *itemRepository) GetAllUnFinishedUnclassifiedItemsByCategoryID(ctx conte
 	results := make([]*itemDomain.Item, len(rows))
 	for i, row := range rows {
 		catIDStr := uuid.UUID(row.CategoryID.Bytes).String()
-		var patternID *string
+		var patternID, patternVersionID *string
 		if row.PatternID.Valid {
 			idStr := uuid.UUID(row.PatternID.Bytes).String()
 			patternID = &idStr
 		}
+		if row.PatternVersionID.Valid {
+			idStr := uuid.UUID(row.PatternVersionID.Bytes).String()
+			patternVersionID = &idStr
+		}
 
 		results[i], err = itemDomain.ReconstructItem(
 			uuid.UUID(row.ID.Bytes).String(),
@@ -674,6 +965,7 @@ func (r *itemRepository) GetAllUnFinishedUnclassifiedItemsByCategoryID(ctx conte
 			&catIDStr,
 			nil, // Unclassified
 			patternID,
+			patternVersionID,
 			row.Name,
 			row.Detail.String,
 			row.LearnedDate.Time,
@@ -719,6 +1011,8 @@ func (r *itemRepository) GetAllUnclassifiedReviewDatesByCategoryID(ctx context.C
 			row.InitialScheduledDate.Time,
 			row.ScheduledDate.Time,
 			row.IsCompleted,
+			fromNullRecallGrade(row.RecallGrade),
+			fromNullableTimestamptz(row.ScheduledAt),
 		)
 		if err != nil {
 			return nil, err
@@ -853,6 +1147,132 @@ func (r *itemRepository) CountDailyDatesUnclassifiedByUserID(ctx context.Context
 	return int(counts[0]), nil
 }
 
+func (r *itemRepository) CountForecastDatesGroupedByBoxByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*itemDomain.ForecastCountGroupedByBox, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	params := dbgen.CountForecastDatesGroupedByBoxByUserIDParams{
+		UserID:   pgUserID,
+		FromDate: pgtype.Date{Time: fromDate, Valid: true},
+		ToDate:   pgtype.Date{Time: toDate, Valid: true},
+	}
+	rows, err := q.CountForecastDatesGroupedByBoxByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*itemDomain.ForecastCountGroupedByBox, len(rows))
+	for i, row := range rows {
+		results[i] = &itemDomain.ForecastCountGroupedByBox{
+			ScheduledDate: row.ScheduledDate.Time,
+			CategoryID:    uuid.UUID(row.CategoryID.Bytes).String(),
+			BoxID:         uuid.UUID(row.BoxID.Bytes).String(),
+			Count:         int(row.Count),
+		}
+	}
+	return results, nil
+}
+
+func (r *itemRepository) CountForecastDatesUnclassifiedGroupedByCategoryByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*itemDomain.UnclassifiedForecastCountGroupedByCategory, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	params := dbgen.CountForecastDatesUnclassifiedGroupedByCategoryByUserIDParams{
+		UserID:   pgUserID,
+		FromDate: pgtype.Date{Time: fromDate, Valid: true},
+		ToDate:   pgtype.Date{Time: toDate, Valid: true},
+	}
+	rows, err := q.CountForecastDatesUnclassifiedGroupedByCategoryByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*itemDomain.UnclassifiedForecastCountGroupedByCategory, len(rows))
+	for i, row := range rows {
+		var categoryID *string
+		if row.CategoryID.Valid {
+			id := uuid.UUID(row.CategoryID.Bytes).String()
+			categoryID = &id
+		}
+		results[i] = &itemDomain.UnclassifiedForecastCountGroupedByCategory{
+			ScheduledDate: row.ScheduledDate.Time,
+			CategoryID:    categoryID,
+			Count:         int(row.Count),
+		}
+	}
+	return results, nil
+}
+
+func (r *itemRepository) CountScheduledDatesGroupedByDateByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*itemDomain.DailyScheduledCount, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	params := dbgen.CountScheduledDatesGroupedByDateByUserIDParams{
+		UserID:   pgUserID,
+		FromDate: pgtype.Date{Time: fromDate, Valid: true},
+		ToDate:   pgtype.Date{Time: toDate, Valid: true},
+	}
+	rows, err := q.CountScheduledDatesGroupedByDateByUserID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*itemDomain.DailyScheduledCount, len(rows))
+	for i, row := range rows {
+		results[i] = &itemDomain.DailyScheduledCount{
+			ScheduledDate: row.ScheduledDate.Time,
+			Count:         int(row.Count),
+		}
+	}
+	return results, nil
+}
+
+func (r *itemRepository) GetDailyReviewLimitByUserID(ctx context.Context, userID string) (int, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return 0, err
+	}
+	limit, err := q.GetDailyReviewLimitByUserID(ctx, pgUserID)
+	if err != nil {
+		return 0, err
+	}
+	return int(limit), nil
+}
+
+func (r *itemRepository) GetExcludedWeekdaysByUserID(ctx context.Context, userID string) ([]int, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	excludedWeekdays, err := q.GetExcludedWeekdaysByUserID(ctx, pgUserID)
+	if err != nil {
+		return nil, err
+	}
+	return fromWeekdays(excludedWeekdays), nil
+}
+
+func (r *itemRepository) GetBlockedDatesByUserID(ctx context.Context, userID string) ([]time.Time, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.GetBlockedDatesByUserID(ctx, pgUserID)
+	if err != nil {
+		return nil, err
+	}
+	blockedDates := make([]time.Time, len(rows))
+	for i, row := range rows {
+		blockedDates[i] = row.BlockedDate.Time
+	}
+	return blockedDates, nil
+}
+
 func (r *itemRepository) IsPatternRelatedToItemByPatternID(ctx context.Context, patternID string, userID string) (bool, error) {
 	q := db.GetQuery(ctx)
 	pgPatternID, err := toUUID(patternID)
@@ -870,6 +1290,87 @@ func (r *itemRepository) IsPatternRelatedToItemByPatternID(ctx context.Context,
 	return q.IsPatternRelatedToItemByPatternID(ctx, params)
 }
 
+func (r *itemRepository) GetAllItemsByPatternID(ctx context.Context, patternID string, userID string) ([]*itemDomain.Item, error) {
+	q := db.GetQuery(ctx)
+	pgPatternID, err := toUUID(patternID)
+	if err != nil {
+		return nil, err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	params := dbgen.GetAllItemsByPatternIDParams{
+		PatternID: pgPatternID,
+		UserID:    pgUserID,
+	}
+	rows, err := q.GetAllItemsByPatternID(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*itemDomain.Item, len(rows))
+	for i, row := range rows {
+		var categoryID, boxID, patternID, patternVersionID *string
+		if row.CategoryID.Valid {
+			idStr := uuid.UUID(row.CategoryID.Bytes).String()
+			categoryID = &idStr
+		}
+		if row.BoxID.Valid {
+			idStr := uuid.UUID(row.BoxID.Bytes).String()
+			boxID = &idStr
+		}
+		if row.PatternID.Valid {
+			idStr := uuid.UUID(row.PatternID.Bytes).String()
+			patternID = &idStr
+		}
+		if row.PatternVersionID.Valid {
+			idStr := uuid.UUID(row.PatternVersionID.Bytes).String()
+			patternVersionID = &idStr
+		}
+		results[i], err = itemDomain.ReconstructItem(
+			uuid.UUID(row.ID.Bytes).String(),
+			uuid.UUID(row.UserID.Bytes).String(),
+			categoryID,
+			boxID,
+			patternID,
+			patternVersionID,
+			row.Name,
+			row.Detail.String,
+			row.LearnedDate.Time,
+			row.IsFinished,
+			row.RegisteredAt.Time,
+			row.EditedAt.Time,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func (r *itemRepository) UpdateItemsPatternIDByPatternID(ctx context.Context, patternID string, userID string, newPatternID string) (int64, error) {
+	q := db.GetQuery(ctx)
+	pgPatternID, err := toUUID(patternID)
+	if err != nil {
+		return 0, err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return 0, err
+	}
+	pgNewPatternID, err := toUUID(newPatternID)
+	if err != nil {
+		return 0, err
+	}
+	params := dbgen.UpdateItemsPatternIDByPatternIDParams{
+		NewPatternID: pgNewPatternID,
+		PatternID:    pgPatternID,
+		UserID:       pgUserID,
+	}
+	return q.UpdateItemsPatternIDByPatternID(ctx, params)
+}
+
 // EditedAtの取得専用
 func (r *itemRepository) GetEditedAtByItemID(ctx context.Context, itemID string, userID string) (time.Time, error) {
 	q := db.GetQuery(ctx)
@@ -982,6 +1483,7 @@ func (r *itemRepository) GetAllDailyReviewDates(ctx context.Context, userID stri
 			ScheduledDate:        scheduled,
 			NextScheduledDate:    next,
 			IsCompleted:          row.IsCompleted,
+			IsOverdue:            row.IsOverdue,
 			ItemID:               itemID,
 			Name:                 row.Name,
 			Detail:               detail,
@@ -994,6 +1496,53 @@ func (r *itemRepository) GetAllDailyReviewDates(ctx context.Context, userID stri
 	return results, nil
 }
 
+func (r *itemRepository) GetDueNowReviewDates(ctx context.Context, userID string, now time.Time) ([]*itemDomain.DueNowReviewDate, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := dbgen.GetDueNowReviewDatesParams{
+		UserID: pgUserID,
+		Now:    pgtype.Timestamptz{Time: now, Valid: true},
+	}
+
+	rows, err := q.GetDueNowReviewDates(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*itemDomain.DueNowReviewDate, len(rows))
+	for i, row := range rows {
+		var categoryID *string
+		if row.CategoryID.Valid {
+			s := uuid.UUID(row.CategoryID.Bytes).String()
+			categoryID = &s
+		}
+
+		var boxID *string
+		if row.BoxID.Valid {
+			s := uuid.UUID(row.BoxID.Bytes).String()
+			boxID = &s
+		}
+
+		results[i] = &itemDomain.DueNowReviewDate{
+			ReviewdateID:  uuid.UUID(row.ID.Bytes).String(),
+			CategoryID:    categoryID,
+			BoxID:         boxID,
+			StepNumber:    int(row.StepNumber),
+			ScheduledDate: row.ScheduledDate.Time,
+			ScheduledAt:   row.ScheduledAt.Time.UTC(),
+			ItemID:        uuid.UUID(row.ItemID.Bytes).String(),
+			Name:          row.Name,
+			Detail:        row.Detail.String,
+			LearnedDate:   row.LearnedDate.Time,
+		}
+	}
+	return results, nil
+}
+
 func (r *itemRepository) GetFinishedItemsByBoxID(ctx context.Context, boxID string, userID string) ([]*itemDomain.Item, error) {
 	q := db.GetQuery(ctx)
 	pgBoxID, err := toUUID(boxID)
@@ -1015,7 +1564,7 @@ func (r *itemRepository) GetFinishedItemsByBoxID(ctx context.Context, boxID stri
 
 	results := make([]*itemDomain.Item, len(rows))
 	for i, row := range rows {
-		var categoryID, boxID, patternID *string
+		var categoryID, boxID, patternID, patternVersionID *string
 		if row.CategoryID.Valid {
 			idStr := uuid.UUID(row.CategoryID.Bytes).String()
 			categoryID = &idStr
@@ -1028,12 +1577,17 @@ func (r *itemRepository) GetFinishedItemsByBoxID(ctx context.Context, boxID stri
 			idStr := uuid.UUID(row.PatternID.Bytes).String()
 			patternID = &idStr
 		}
+		if row.PatternVersionID.Valid {
+			idStr := uuid.UUID(row.PatternVersionID.Bytes).String()
+			patternVersionID = &idStr
+		}
 		results[i], err = itemDomain.ReconstructItem(
 			uuid.UUID(row.ID.Bytes).String(),
 			uuid.UUID(row.UserID.Bytes).String(),
 			categoryID,
 			boxID,
 			patternID,
+			patternVersionID,
 			row.Name,
 			row.Detail.String,
 			row.LearnedDate.Time,
@@ -1069,17 +1623,22 @@ func (r *itemRepository) GetUnclassfiedFinishedItemsByCategoryID(ctx context.Con
 	results := make([]*itemDomain.Item, len(rows))
 	for i, row := range rows {
 		catIDStr := uuid.UUID(row.CategoryID.Bytes).String()
-		var patternID *string
+		var patternID, patternVersionID *string
 		if row.PatternID.Valid {
 			idStr := uuid.UUID(row.PatternID.Bytes).String()
 			patternID = &idStr
 		}
+		if row.PatternVersionID.Valid {
+			idStr := uuid.UUID(row.PatternVersionID.Bytes).String()
+			patternVersionID = &idStr
+		}
 		results[i], err = itemDomain.ReconstructItem(
 			uuid.UUID(row.ID.Bytes).String(),
 			uuid.UUID(row.UserID.Bytes).String(),
 			&catIDStr,
 			nil, // Unclassified
 			patternID,
+			patternVersionID,
 			row.Name,
 			row.Detail.String,
 			row.LearnedDate.Time,
@@ -1106,17 +1665,22 @@ func (r *itemRepository) GetUnclassfiedFinishedItemsByUserID(ctx context.Context
 	}
 	results := make([]*itemDomain.Item, len(rows))
 	for i, row := range rows {
-		var patternID *string
+		var patternID, patternVersionID *string
 		if row.PatternID.Valid {
 			idStr := uuid.UUID(row.PatternID.Bytes).String()
 			patternID = &idStr
 		}
+		if row.PatternVersionID.Valid {
+			idStr := uuid.UUID(row.PatternVersionID.Bytes).String()
+			patternVersionID = &idStr
+		}
 		results[i], err = itemDomain.ReconstructItem(
 			uuid.UUID(row.ID.Bytes).String(),
 			uuid.UUID(row.UserID.Bytes).String(),
 			nil, // Unclassified
 			nil, // Unclassified
 			patternID,
+			patternVersionID,
 			row.Name,
 			row.Detail.String,
 			row.LearnedDate.Time,
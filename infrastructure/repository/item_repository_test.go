@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -144,6 +146,8 @@ func TestItemRepository_CreateReviewdates(t *testing.T) {
 						time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -158,6 +162,8 @@ func TestItemRepository_CreateReviewdates(t *testing.T) {
 						time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -174,6 +180,8 @@ func TestItemRepository_CreateReviewdates(t *testing.T) {
 						time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -188,6 +196,8 @@ func TestItemRepository_CreateReviewdates(t *testing.T) {
 						time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -273,6 +283,7 @@ func TestItemRepository_GetItemByID(t *testing.T) {
 					stringPtr("650e8400-e29b-41d4-a716-446655440001"),
 					stringPtr("950e8400-e29b-41d4-a716-446655440001"),
 					stringPtr("750e8400-e29b-41d4-a716-446655440001"),
+					nil,
 					"二次方程式",
 					"ax^2 + bx + c = 0の解の公式を覚える",
 					time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -465,6 +476,7 @@ func TestItemRepository_UpdateItem(t *testing.T) {
 					stringPtr("650e8400-e29b-41d4-a716-446655440001"),
 					stringPtr("950e8400-e29b-41d4-a716-446655440001"),
 					stringPtr("750e8400-e29b-41d4-a716-446655440001"),
+					nil,
 					"更新された二次方程式",
 					"更新された詳細",
 					time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
@@ -481,6 +493,7 @@ func TestItemRepository_UpdateItem(t *testing.T) {
 					stringPtr("650e8400-e29b-41d4-a716-446655440001"),
 					stringPtr("950e8400-e29b-41d4-a716-446655440001"),
 					stringPtr("750e8400-e29b-41d4-a716-446655440001"),
+					nil,
 					"更新された二次方程式",
 					"更新された詳細",
 					time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
@@ -534,6 +547,7 @@ func TestItemRepository_UpdateItem(t *testing.T) {
 					tc.want.CategoryID(),
 					tc.want.BoxID(),
 					tc.want.PatternID(),
+					tc.want.PatternVersionID(),
 					tc.want.Name(),
 					tc.want.Detail(),
 					tc.want.LearnedDate(),
@@ -580,6 +594,8 @@ func TestItemRepository_UpdateReviewDates(t *testing.T) {
 						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), // 更新されたスケジュール日
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -597,6 +613,8 @@ func TestItemRepository_UpdateReviewDates(t *testing.T) {
 						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -683,6 +701,8 @@ func TestItemRepository_UpdateReviewDatesBack(t *testing.T) {
 						time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), // 巻き戻されたスケジュール日
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -700,6 +720,8 @@ func TestItemRepository_UpdateReviewDatesBack(t *testing.T) {
 						time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -786,6 +808,7 @@ func TestItemRepository_UpdateItemAsFinished(t *testing.T) {
 					stringPtr("650e8400-e29b-41d4-a716-446655440001"),
 					stringPtr("950e8400-e29b-41d4-a716-446655440001"),
 					stringPtr("750e8400-e29b-41d4-a716-446655440001"),
+					nil,
 					"二次方程式",
 					"ax^2 + bx + c = 0の解の公式を覚える",
 					time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -879,6 +902,7 @@ func TestItemRepository_UpdateItemAsUnFinished(t *testing.T) {
 					stringPtr("650e8400-e29b-41d4-a716-446655440001"),
 					stringPtr("950e8400-e29b-41d4-a716-446655440002"),
 					stringPtr("750e8400-e29b-41d4-a716-446655440002"),
+					nil,
 					"円の面積",
 					"π × r^2の公式を理解する",
 					time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
@@ -946,6 +970,7 @@ func TestItemRepository_UpdateReviewDateAsCompleted(t *testing.T) {
 		name         string
 		reviewdateID string
 		userID       string
+		recallGrade  *string
 		want         *itemDomain.Reviewdate
 		wantErr      bool
 	}{
@@ -964,6 +989,31 @@ func TestItemRepository_UpdateReviewDateAsCompleted(t *testing.T) {
 					time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
 					time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
 					true, // 完了状態に更新される
+					nil,
+					nil,
+				)
+				return reviewdate
+			}(),
+			wantErr: false,
+		},
+		{
+			name:         "想起評価付きで復習日を完了状態に更新する場合",
+			reviewdateID: "b50e8400-e29b-41d4-a716-446655440001",
+			userID:       "550e8400-e29b-41d4-a716-446655440001",
+			recallGrade:  stringPtr("again"),
+			want: func() *itemDomain.Reviewdate {
+				reviewdate, _ := itemDomain.ReconstructReviewdate(
+					"b50e8400-e29b-41d4-a716-446655440001",
+					"550e8400-e29b-41d4-a716-446655440001",
+					stringPtr("650e8400-e29b-41d4-a716-446655440001"),
+					stringPtr("950e8400-e29b-41d4-a716-446655440001"),
+					"a50e8400-e29b-41d4-a716-446655440001",
+					1,
+					time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+					time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+					true,
+					stringPtr("again"), // 想起評価が記録される
+					nil,
 				)
 				return reviewdate
 			}(),
@@ -977,7 +1027,7 @@ func TestItemRepository_UpdateReviewDateAsCompleted(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			err := repo.UpdateReviewDateAsCompleted(ctx, tc.reviewdateID, tc.userID)
+			err := repo.UpdateReviewDateAsCompleted(ctx, tc.reviewdateID, tc.userID, tc.recallGrade)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1051,6 +1101,8 @@ func TestItemRepository_UpdateReviewDateAsInCompleted(t *testing.T) {
 					time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
 					time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
 					false, // 未完了状態に更新される
+					nil,
+					nil,
 				)
 				return reviewdate
 			}(),
@@ -1139,6 +1191,8 @@ func TestItemRepository_GetReviewDatesByItemID(t *testing.T) {
 						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -1153,6 +1207,8 @@ func TestItemRepository_GetReviewDatesByItemID(t *testing.T) {
 						time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
 						false,
+						nil,
+						nil,
 					)
 					return reviewdate
 				}(),
@@ -1342,6 +1398,7 @@ func TestItemRepository_GetAllUnFinishedItemsByBoxID(t *testing.T) {
 						stringPtr("650e8400-e29b-41d4-a716-446655440001"),
 						stringPtr("950e8400-e29b-41d4-a716-446655440001"),
 						stringPtr("750e8400-e29b-41d4-a716-446655440001"),
+						nil,
 						"二次方程式",
 						"ax^2 + bx + c = 0の解の公式を覚える",
 						time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
@@ -1390,7 +1447,7 @@ func TestItemRepository_GetAllUnFinishedItemsByBoxID(t *testing.T) {
 	}
 }
 
-func TestItemRepository_GetAllReviewDatesByBoxID(t *testing.T) {
+func TestItemRepository_GetAllUnFinishedItemsByPatternID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1399,48 +1456,68 @@ func TestItemRepository_GetAllReviewDatesByBoxID(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name    string
-		boxID   string
-		userID  string
-		want    []*itemDomain.Reviewdate
-		wantErr bool
+		name      string
+		patternID string
+		userID    string
+		want      []*itemDomain.Item
+		wantErr   bool
 	}{
 		{
-			name:   "ボックス内の全復習日を取得する場合",
-			boxID:  "950e8400-e29b-41d4-a716-446655440001",
-			userID: "550e8400-e29b-41d4-a716-446655440001",
-			want: []*itemDomain.Reviewdate{
-				func() *itemDomain.Reviewdate {
-					reviewdate, _ := itemDomain.ReconstructReviewdate(
-						"b50e8400-e29b-41d4-a716-446655440001",
+			name:      "パターンを使う未完了復習物を取得する場合",
+			patternID: "750e8400-e29b-41d4-a716-446655440001",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			want: []*itemDomain.Item{
+				func() *itemDomain.Item {
+					item, _ := itemDomain.ReconstructItem(
+						"a50e8400-e29b-41d4-a716-446655440001",
 						"550e8400-e29b-41d4-a716-446655440001",
 						stringPtr("650e8400-e29b-41d4-a716-446655440001"),
 						stringPtr("950e8400-e29b-41d4-a716-446655440001"),
-						"a50e8400-e29b-41d4-a716-446655440001",
-						1,
-						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
-						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						stringPtr("750e8400-e29b-41d4-a716-446655440001"),
+						nil,
+						"二次方程式",
+						"ax^2 + bx + c = 0の解の公式を覚える",
+						time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 						false,
+						time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
 					)
-					return reviewdate
+					return item
 				}(),
-				func() *itemDomain.Reviewdate {
-					reviewdate, _ := itemDomain.ReconstructReviewdate(
-						"b50e8400-e29b-41d4-a716-446655440002",
+				func() *itemDomain.Item {
+					item, _ := itemDomain.ReconstructItem(
+						"a50e8400-e29b-41d4-a716-446655440003",
 						"550e8400-e29b-41d4-a716-446655440001",
-						stringPtr("650e8400-e29b-41d4-a716-446655440001"),
-						stringPtr("950e8400-e29b-41d4-a716-446655440001"),
-						"a50e8400-e29b-41d4-a716-446655440001",
-						2,
-						time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
-						time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+						stringPtr("650e8400-e29b-41d4-a716-446655440002"),
+						stringPtr("950e8400-e29b-41d4-a716-446655440003"),
+						stringPtr("750e8400-e29b-41d4-a716-446655440001"),
+						nil,
+						"ニュートンの第一法則",
+						"慣性の法則について理解する",
+						time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
 						false,
+						time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
 					)
-					return reviewdate
+					return item
 				}(),
 			},
 			wantErr: false,
 		},
+		{
+			name:      "完了済み復習物のみのパターンの場合（空の結果）",
+			patternID: "750e8400-e29b-41d4-a716-446655440002",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			want:      []*itemDomain.Item{},
+			wantErr:   false,
+		},
+		{
+			name:      "無効なパターンIDの場合",
+			patternID: "invalid-uuid",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			want:      nil,
+			wantErr:   true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -1449,7 +1526,7 @@ func TestItemRepository_GetAllReviewDatesByBoxID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			reviewdates, err := repo.GetAllReviewDatesByBoxID(ctx, tc.boxID, tc.userID)
+			items, err := repo.GetAllUnFinishedItemsByPatternID(ctx, tc.patternID, tc.userID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1463,14 +1540,14 @@ func TestItemRepository_GetAllReviewDatesByBoxID(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, reviewdates, cmp.AllowUnexported(itemDomain.Reviewdate{})); diff != "" {
-				t.Errorf("GetAllReviewDatesByBoxID() mismatch (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tc.want, items, cmp.AllowUnexported(itemDomain.Item{})); diff != "" {
+				t.Errorf("GetAllUnFinishedItemsByPatternID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByUserID(t *testing.T) {
+func TestItemRepository_UpdateItemsPatternIDByBoxID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1480,15 +1557,26 @@ func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByUserID(t *testing.T)
 
 	tests := []struct {
 		name          string
+		boxID         string
 		userID        string
+		patternID     string
+		wantPatternID string
 		wantErr       bool
-		expectedCount int
 	}{
 		{
-			name:          "ユーザー2の未分類復習物を取得（完全未分類なし）",
-			userID:        "550e8400-e29b-41d4-a716-446655440002",
+			name:          "ボックス内の復習物のパターンIDを更新する場合",
+			boxID:         "950e8400-e29b-41d4-a716-446655440003",
+			userID:        "550e8400-e29b-41d4-a716-446655440001",
+			patternID:     "750e8400-e29b-41d4-a716-446655440002",
+			wantPatternID: "750e8400-e29b-41d4-a716-446655440002",
 			wantErr:       false,
-			expectedCount: 1,
+		},
+		{
+			name:      "無効なパターンIDの場合",
+			boxID:     "950e8400-e29b-41d4-a716-446655440003",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			patternID: "invalid-uuid",
+			wantErr:   true,
 		},
 	}
 
@@ -1498,7 +1586,7 @@ func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByUserID(t *testing.T)
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			items, err := repo.GetAllUnFinishedUnclassifiedItemsByUserID(ctx, tc.userID)
+			err := repo.UpdateItemsPatternIDByBoxID(ctx, tc.boxID, tc.userID, tc.patternID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1509,31 +1597,28 @@ func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByUserID(t *testing.T)
 
 			if err != nil {
 				t.Errorf("予期しないエラー: %v", err)
+				return
 			}
 
-			if items == nil {
-				t.Error("復習物のスライスがnilです")
+			items, err := repo.GetAllUnFinishedItemsByBoxID(ctx, tc.boxID, tc.userID)
+			if err != nil {
+				t.Errorf("更新された復習物の取得に失敗: %v", err)
 				return
 			}
-
-			if len(items) != tc.expectedCount {
-				t.Errorf("期待される復習物数: %d, 実際: %d", tc.expectedCount, len(items))
+			if len(items) == 0 {
+				t.Error("ボックス内の復習物が取得できませんでした")
+				return
 			}
-
-			// 返された全ての復習物が指定ユーザーのものであり、ボックスがnilであることを検証
 			for _, item := range items {
-				if item.UserID() != tc.userID {
-					t.Errorf("期待されるユーザーID: %s, 実際: %s", tc.userID, item.UserID())
-				}
-				if item.BoxID() != nil {
-					t.Errorf("復習物のBoxIDはnilであるべきですが、%sが設定されています", *item.BoxID())
+				if item.PatternID() == nil || *item.PatternID() != tc.wantPatternID {
+					t.Errorf("パターンIDが更新されていません: item=%s, got=%v, want=%s", item.ItemID(), item.PatternID(), tc.wantPatternID)
 				}
 			}
 		})
 	}
 }
 
-func TestItemRepository_GetAllUnclassifiedReviewDatesByUserID(t *testing.T) {
+func TestItemRepository_UpdateItemsPatternIDByBoxID_KeepsFinishedItems(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1541,69 +1626,65 @@ func TestItemRepository_GetAllUnclassifiedReviewDatesByUserID(t *testing.T) {
 	PrepareTestDatabase(t)
 	defer CleanupTestDatabase(t)
 
-	tests := []struct {
-		name    string
-		userID  string
-		want    []*itemDomain.Reviewdate
-		wantErr bool
-	}{
-		{
-			name:   "未分類復習日を取得する場合",
-			userID: "550e8400-e29b-41d4-a716-446655440002",
-			want: []*itemDomain.Reviewdate{
-				func() *itemDomain.Reviewdate {
-					reviewdate, _ := itemDomain.ReconstructReviewdate(
-						"b50e8400-e29b-41d4-a716-446655440006",
-						"550e8400-e29b-41d4-a716-446655440002",
-						nil, // 真の未分類のため nil
-						nil, // 未分類のため nil
-						"a50e8400-e29b-41d4-a716-446655440006",
-						1,
-						time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
-						time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
-						false,
-					)
-					return reviewdate
-				}(),
-			},
-			wantErr: false,
-		},
-		{
-			name:    "未分類復習日がないユーザーの場合",
-			userID:  "550e8400-e29b-41d4-a716-446655440001",
-			want:    []*itemDomain.Reviewdate{},
-			wantErr: false,
-		},
+	ctx := GetTestContext()
+	repo := NewItemRepository()
+	userID := "550e8400-e29b-41d4-a716-446655440001"
+	// ボックス内の完了済みの復習物はパターン750e8400-...-446655440002のまま残す
+	finishedItemID := "a50e8400-e29b-41d4-a716-446655440002"
+
+	err := repo.UpdateItemsPatternIDByBoxID(ctx, "950e8400-e29b-41d4-a716-446655440002", userID, "750e8400-e29b-41d4-a716-446655440001")
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
 	}
 
-	for _, tc := range tests {
-		tc := tc
-		t.Run(tc.name, func(t *testing.T) {
-			ctx := GetTestContext()
-			repo := NewItemRepository()
+	item, err := repo.GetItemByID(ctx, finishedItemID, userID)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if item.PatternID() == nil || *item.PatternID() != "750e8400-e29b-41d4-a716-446655440002" {
+		t.Errorf("完了済みの復習物のパターンIDが変更されています: got=%v", item.PatternID())
+	}
+}
 
-			reviewdates, err := repo.GetAllUnclassifiedReviewDatesByUserID(ctx, tc.userID)
+func TestItemRepository_LockUnFinishedItemsByBoxID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
 
-			if tc.wantErr {
-				if err == nil {
-					t.Error("エラーが発生するはずですが、発生しませんでした")
-				}
-				return
-			}
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
 
-			if err != nil {
-				t.Errorf("予期しないエラー: %v", err)
-				return
-			}
+	repo := NewItemRepository()
+	transactionManager := NewTransactionManager(testDBPool)
 
-			if diff := cmp.Diff(tc.want, reviewdates, cmp.AllowUnexported(itemDomain.Reviewdate{})); diff != "" {
-				t.Errorf("GetAllUnclassifiedReviewDatesByUserID() mismatch (-want +got):\n%s", diff)
-			}
-		})
+	err := transactionManager.RunInTransaction(GetTestContext(), func(ctx context.Context) error {
+		return repo.LockUnFinishedItemsByBoxID(ctx, "950e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440001")
+	})
+	if err != nil {
+		t.Errorf("予期しないエラー: %v", err)
 	}
 }
 
-func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByCategoryID(t *testing.T) {
+func TestItemRepository_LockUnFinishedItemsByPatternID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	repo := NewItemRepository()
+	transactionManager := NewTransactionManager(testDBPool)
+
+	err := transactionManager.RunInTransaction(GetTestContext(), func(ctx context.Context) error {
+		return repo.LockUnFinishedItemsByPatternID(ctx, "750e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440001")
+	})
+	if err != nil {
+		t.Errorf("予期しないエラー: %v", err)
+	}
+}
+
+func TestItemRepository_UpdateItemPatternVersionID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1612,42 +1693,25 @@ func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByCategoryID(t *testing
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name       string
-		categoryID string
-		userID     string
-		want       []*itemDomain.Item
-		wantErr    bool
+		name             string
+		itemID           string
+		userID           string
+		patternVersionID string
+		wantErr          bool
 	}{
 		{
-			name:       "カテゴリの未分類未完了復習物を取得する場合",
-			categoryID: "650e8400-e29b-41d4-a716-446655440004",
-			userID:     "550e8400-e29b-41d4-a716-446655440002",
-			want: []*itemDomain.Item{
-				func() *itemDomain.Item {
-					item, _ := itemDomain.ReconstructItem(
-						"a50e8400-e29b-41d4-a716-446655440005",
-						"550e8400-e29b-41d4-a716-446655440002",
-						stringPtr("650e8400-e29b-41d4-a716-446655440004"),
-						nil, // 未分類のため nil
-						nil,
-						"明治維新",
-						"1868年の政治変革について",
-						time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
-						false,
-						time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
-						time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
-					)
-					return item
-				}(),
-			},
-			wantErr: false,
+			name:             "復習物のパターンの版を更新する場合",
+			itemID:           "a50e8400-e29b-41d4-a716-446655440001",
+			userID:           "550e8400-e29b-41d4-a716-446655440001",
+			patternVersionID: "d50e8400-e29b-41d4-a716-446655440002",
+			wantErr:          false,
 		},
 		{
-			name:       "未分類未完了復習物がないカテゴリの場合",
-			categoryID: "650e8400-e29b-41d4-a716-446655440001",
-			userID:     "550e8400-e29b-41d4-a716-446655440001",
-			want:       []*itemDomain.Item{},
-			wantErr:    false,
+			name:             "無効なパターンの版IDの場合",
+			itemID:           "a50e8400-e29b-41d4-a716-446655440001",
+			userID:           "550e8400-e29b-41d4-a716-446655440001",
+			patternVersionID: "invalid-uuid",
+			wantErr:          true,
 		},
 	}
 
@@ -1657,7 +1721,7 @@ func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByCategoryID(t *testing
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			items, err := repo.GetAllUnFinishedUnclassifiedItemsByCategoryID(ctx, tc.categoryID, tc.userID)
+			err := repo.UpdateItemPatternVersionID(ctx, tc.itemID, tc.userID, tc.patternVersionID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1671,14 +1735,238 @@ func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByCategoryID(t *testing
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, items, cmp.AllowUnexported(itemDomain.Item{})); diff != "" {
-				t.Errorf("GetAllUnFinishedUnclassifiedItemsByCategoryID() mismatch (-want +got):\n%s", diff)
+			item, err := repo.GetItemByID(ctx, tc.itemID, tc.userID)
+			if err != nil {
+				t.Errorf("更新された復習物の取得に失敗: %v", err)
+				return
+			}
+			if item.PatternVersionID() == nil || *item.PatternVersionID() != tc.patternVersionID {
+				t.Errorf("パターンの版が更新されていません: got=%v, want=%s", item.PatternVersionID(), tc.patternVersionID)
 			}
 		})
 	}
 }
 
-func TestItemRepository_GetAllUnclassifiedReviewDatesByCategoryID(t *testing.T) {
+func TestItemRepository_GetAllReviewDatesByBoxID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name    string
+		boxID   string
+		userID  string
+		want    []*itemDomain.Reviewdate
+		wantErr bool
+	}{
+		{
+			name:   "ボックス内の全復習日を取得する場合",
+			boxID:  "950e8400-e29b-41d4-a716-446655440001",
+			userID: "550e8400-e29b-41d4-a716-446655440001",
+			want: []*itemDomain.Reviewdate{
+				func() *itemDomain.Reviewdate {
+					reviewdate, _ := itemDomain.ReconstructReviewdate(
+						"b50e8400-e29b-41d4-a716-446655440001",
+						"550e8400-e29b-41d4-a716-446655440001",
+						stringPtr("650e8400-e29b-41d4-a716-446655440001"),
+						stringPtr("950e8400-e29b-41d4-a716-446655440001"),
+						"a50e8400-e29b-41d4-a716-446655440001",
+						1,
+						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						false,
+						nil,
+						nil,
+					)
+					return reviewdate
+				}(),
+				func() *itemDomain.Reviewdate {
+					reviewdate, _ := itemDomain.ReconstructReviewdate(
+						"b50e8400-e29b-41d4-a716-446655440002",
+						"550e8400-e29b-41d4-a716-446655440001",
+						stringPtr("650e8400-e29b-41d4-a716-446655440001"),
+						stringPtr("950e8400-e29b-41d4-a716-446655440001"),
+						"a50e8400-e29b-41d4-a716-446655440001",
+						2,
+						time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+						false,
+						nil,
+						nil,
+					)
+					return reviewdate
+				}(),
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			reviewdates, err := repo.GetAllReviewDatesByBoxID(ctx, tc.boxID, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, reviewdates, cmp.AllowUnexported(itemDomain.Reviewdate{})); diff != "" {
+				t.Errorf("GetAllReviewDatesByBoxID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByUserID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name          string
+		userID        string
+		wantErr       bool
+		expectedCount int
+	}{
+		{
+			name:          "ユーザー2の未分類復習物を取得（完全未分類なし）",
+			userID:        "550e8400-e29b-41d4-a716-446655440002",
+			wantErr:       false,
+			expectedCount: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			items, err := repo.GetAllUnFinishedUnclassifiedItemsByUserID(ctx, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+			}
+
+			if items == nil {
+				t.Error("復習物のスライスがnilです")
+				return
+			}
+
+			if len(items) != tc.expectedCount {
+				t.Errorf("期待される復習物数: %d, 実際: %d", tc.expectedCount, len(items))
+			}
+
+			// 返された全ての復習物が指定ユーザーのものであり、ボックスがnilであることを検証
+			for _, item := range items {
+				if item.UserID() != tc.userID {
+					t.Errorf("期待されるユーザーID: %s, 実際: %s", tc.userID, item.UserID())
+				}
+				if item.BoxID() != nil {
+					t.Errorf("復習物のBoxIDはnilであるべきですが、%sが設定されています", *item.BoxID())
+				}
+			}
+		})
+	}
+}
+
+func TestItemRepository_GetAllUnclassifiedReviewDatesByUserID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name    string
+		userID  string
+		want    []*itemDomain.Reviewdate
+		wantErr bool
+	}{
+		{
+			name:   "未分類復習日を取得する場合",
+			userID: "550e8400-e29b-41d4-a716-446655440002",
+			want: []*itemDomain.Reviewdate{
+				func() *itemDomain.Reviewdate {
+					reviewdate, _ := itemDomain.ReconstructReviewdate(
+						"b50e8400-e29b-41d4-a716-446655440006",
+						"550e8400-e29b-41d4-a716-446655440002",
+						nil, // 真の未分類のため nil
+						nil, // 未分類のため nil
+						"a50e8400-e29b-41d4-a716-446655440006",
+						1,
+						time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+						false,
+						nil,
+						nil,
+					)
+					return reviewdate
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "未分類復習日がないユーザーの場合",
+			userID:  "550e8400-e29b-41d4-a716-446655440001",
+			want:    []*itemDomain.Reviewdate{},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			reviewdates, err := repo.GetAllUnclassifiedReviewDatesByUserID(ctx, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, reviewdates, cmp.AllowUnexported(itemDomain.Reviewdate{})); diff != "" {
+				t.Errorf("GetAllUnclassifiedReviewDatesByUserID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_GetAllUnFinishedUnclassifiedItemsByCategoryID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1690,36 +1978,39 @@ func TestItemRepository_GetAllUnclassifiedReviewDatesByCategoryID(t *testing.T)
 		name       string
 		categoryID string
 		userID     string
-		want       []*itemDomain.Reviewdate
+		want       []*itemDomain.Item
 		wantErr    bool
 	}{
 		{
-			name:       "カテゴリの未分類復習日を取得する場合",
+			name:       "カテゴリの未分類未完了復習物を取得する場合",
 			categoryID: "650e8400-e29b-41d4-a716-446655440004",
 			userID:     "550e8400-e29b-41d4-a716-446655440002",
-			want: []*itemDomain.Reviewdate{
-				func() *itemDomain.Reviewdate {
-					reviewdate, _ := itemDomain.ReconstructReviewdate(
-						"b50e8400-e29b-41d4-a716-446655440007",
+			want: []*itemDomain.Item{
+				func() *itemDomain.Item {
+					item, _ := itemDomain.ReconstructItem(
+						"a50e8400-e29b-41d4-a716-446655440005",
 						"550e8400-e29b-41d4-a716-446655440002",
 						stringPtr("650e8400-e29b-41d4-a716-446655440004"),
 						nil, // 未分類のため nil
-						"a50e8400-e29b-41d4-a716-446655440005",
-						1,
-						time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
-						time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+						nil,
+						nil,
+						"明治維新",
+						"1868年の政治変革について",
+						time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
 						false,
+						time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
 					)
-					return reviewdate
+					return item
 				}(),
 			},
 			wantErr: false,
 		},
 		{
-			name:       "未分類復習日がないカテゴリの場合",
+			name:       "未分類未完了復習物がないカテゴリの場合",
 			categoryID: "650e8400-e29b-41d4-a716-446655440001",
 			userID:     "550e8400-e29b-41d4-a716-446655440001",
-			want:       []*itemDomain.Reviewdate{},
+			want:       []*itemDomain.Item{},
 			wantErr:    false,
 		},
 	}
@@ -1727,10 +2018,753 @@ func TestItemRepository_GetAllUnclassifiedReviewDatesByCategoryID(t *testing.T)
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			ctx := GetTestContext()
-			repo := NewItemRepository()
-
-			reviewdates, err := repo.GetAllUnclassifiedReviewDatesByCategoryID(ctx, tc.categoryID, tc.userID)
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			items, err := repo.GetAllUnFinishedUnclassifiedItemsByCategoryID(ctx, tc.categoryID, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, items, cmp.AllowUnexported(itemDomain.Item{})); diff != "" {
+				t.Errorf("GetAllUnFinishedUnclassifiedItemsByCategoryID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_GetAllUnclassifiedReviewDatesByCategoryID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name       string
+		categoryID string
+		userID     string
+		want       []*itemDomain.Reviewdate
+		wantErr    bool
+	}{
+		{
+			name:       "カテゴリの未分類復習日を取得する場合",
+			categoryID: "650e8400-e29b-41d4-a716-446655440004",
+			userID:     "550e8400-e29b-41d4-a716-446655440002",
+			want: []*itemDomain.Reviewdate{
+				func() *itemDomain.Reviewdate {
+					reviewdate, _ := itemDomain.ReconstructReviewdate(
+						"b50e8400-e29b-41d4-a716-446655440007",
+						"550e8400-e29b-41d4-a716-446655440002",
+						stringPtr("650e8400-e29b-41d4-a716-446655440004"),
+						nil, // 未分類のため nil
+						"a50e8400-e29b-41d4-a716-446655440005",
+						1,
+						time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+						false,
+						nil,
+						nil,
+					)
+					return reviewdate
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name:       "未分類復習日がないカテゴリの場合",
+			categoryID: "650e8400-e29b-41d4-a716-446655440001",
+			userID:     "550e8400-e29b-41d4-a716-446655440001",
+			want:       []*itemDomain.Reviewdate{},
+			wantErr:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			reviewdates, err := repo.GetAllUnclassifiedReviewDatesByCategoryID(ctx, tc.categoryID, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, reviewdates, cmp.AllowUnexported(itemDomain.Reviewdate{})); diff != "" {
+				t.Errorf("GetAllUnclassifiedReviewDatesByCategoryID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_CountItemsGroupedByBoxByUserID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name    string
+		userID  string
+		want    []*itemDomain.ItemCountGroupedByBox
+		wantErr bool
+	}{
+		{
+			name:   "ユーザーのボックス毎の復習物数を取得する場合",
+			userID: "550e8400-e29b-41d4-a716-446655440001",
+			want: []*itemDomain.ItemCountGroupedByBox{
+				{
+					CategoryID: "650e8400-e29b-41d4-a716-446655440001",
+					BoxID:      "950e8400-e29b-41d4-a716-446655440001",
+					Count:      1, // 二次方程式の復習物
+				},
+				{
+					CategoryID: "650e8400-e29b-41d4-a716-446655440002",
+					BoxID:      "950e8400-e29b-41d4-a716-446655440003",
+					Count:      1, // ニュートンの第一法則の復習物
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			counts, err := repo.CountItemsGroupedByBoxByUserID(ctx, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, counts, cmp.AllowUnexported(itemDomain.ItemCountGroupedByBox{})); diff != "" {
+				t.Errorf("CountItemsGroupedByBoxByUserID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_CountUnclassifiedItemsGroupedByCategoryByUserID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name    string
+		userID  string
+		want    []*itemDomain.UnclassifiedItemCountGroupedByCategory
+		wantErr bool
+	}{
+		{
+			name:   "ユーザーのカテゴリ毎の未分類復習物数を取得する場合",
+			userID: "550e8400-e29b-41d4-a716-446655440002",
+			want: []*itemDomain.UnclassifiedItemCountGroupedByCategory{
+				{
+					CategoryID: "650e8400-e29b-41d4-a716-446655440004",
+					Count:      1, // 明治維新の復習物（未分類）
+				},
+				{
+					CategoryID: "00000000-0000-0000-0000-000000000000", // category_id=nullの場合のUUID表現
+					Count:      1,                                      // Goroutineの復習物（完全未分類）
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "未分類復習物がないユーザーの場合",
+			userID:  "550e8400-e29b-41d4-a716-446655440001",
+			want:    []*itemDomain.UnclassifiedItemCountGroupedByCategory{},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			counts, err := repo.CountUnclassifiedItemsGroupedByCategoryByUserID(ctx, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, counts, cmp.AllowUnexported(itemDomain.UnclassifiedItemCountGroupedByCategory{})); diff != "" {
+				t.Errorf("CountUnclassifiedItemsGroupedByCategoryByUserID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_CountUnclassifiedItemsByUserID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name          string
+		userID        string
+		wantErr       bool
+		expectedCount int64
+	}{
+		{
+			name:          "ユーザー2の未分類復習物数をカウント（2件）",
+			userID:        "550e8400-e29b-41d4-a716-446655440002",
+			wantErr:       false,
+			expectedCount: 2, // 明治維新とGoroutineの2件
+		},
+		{
+			name:          "ユーザー1の未分類復習物数をカウント（0件）",
+			userID:        "550e8400-e29b-41d4-a716-446655440001",
+			wantErr:       false,
+			expectedCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			count, err := repo.CountUnclassifiedItemsByUserID(ctx, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if int64(count) != tc.expectedCount {
+				t.Errorf("expected count %d, got %d", tc.expectedCount, count)
+			}
+		})
+	}
+}
+
+func TestItemRepository_CountDailyDatesGroupedByBoxByUserID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name       string
+		userID     string
+		targetDate time.Time
+		want       []*itemDomain.DailyCountGroupedByBox
+		wantErr    bool
+	}{
+		{
+			name:       "特定日のボックス毎の復習日数を取得する場合",
+			userID:     "550e8400-e29b-41d4-a716-446655440001",
+			targetDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			want: []*itemDomain.DailyCountGroupedByBox{
+				{
+					CategoryID: "650e8400-e29b-41d4-a716-446655440001",
+					BoxID:      "950e8400-e29b-41d4-a716-446655440001",
+					Count:      1, // 2024-01-02にスケジュールされた復習日
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			counts, err := repo.CountDailyDatesGroupedByBoxByUserID(ctx, tc.userID, tc.targetDate)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, counts, cmp.AllowUnexported(itemDomain.DailyCountGroupedByBox{})); diff != "" {
+				t.Errorf("CountDailyDatesGroupedByBoxByUserID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_CountDailyDatesUnclassifiedGroupedByCategoryByUserID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name       string
+		userID     string
+		targetDate time.Time
+		want       []*itemDomain.UnclassifiedDailyDatesCountGroupedByCategory
+		wantErr    bool
+	}{
+		{
+			name:       "特定日のカテゴリ毎の未分類復習日数を取得する場合",
+			userID:     "550e8400-e29b-41d4-a716-446655440002",
+			targetDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			want: []*itemDomain.UnclassifiedDailyDatesCountGroupedByCategory{
+				{
+					CategoryID: "650e8400-e29b-41d4-a716-446655440004",
+					Count:      1, // 2024-01-06にスケジュールされた未分類復習日
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			counts, err := repo.CountDailyDatesUnclassifiedGroupedByCategoryByUserID(ctx, tc.userID, tc.targetDate)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, counts, cmp.AllowUnexported(itemDomain.UnclassifiedDailyDatesCountGroupedByCategory{})); diff != "" {
+				t.Errorf("CountDailyDatesUnclassifiedGroupedByCategoryByUserID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_CountDailyDatesUnclassifiedByUserID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name       string
+		userID     string
+		targetDate time.Time
+		want       int
+		wantErr    bool
+	}{
+		{
+			name:       "特定日のユーザーの未分類復習日数を取得する場合",
+			userID:     "550e8400-e29b-41d4-a716-446655440002",
+			targetDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			want:       1, // 2024-01-06にスケジュールされた未分類復習日
+			wantErr:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			count, err := repo.CountDailyDatesUnclassifiedByUserID(ctx, tc.userID, tc.targetDate)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if count != tc.want {
+				t.Errorf("CountDailyDatesUnclassifiedByUserID() = %d, want %d", count, tc.want)
+			}
+		})
+	}
+}
+
+func TestItemRepository_GetEditedAtByItemID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name    string
+		itemID  string
+		userID  string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:    "復習物のEditedAtを取得する場合",
+			itemID:  "a50e8400-e29b-41d4-a716-446655440001",
+			userID:  "550e8400-e29b-41d4-a716-446655440001",
+			want:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			editedAt, err := repo.GetEditedAtByItemID(ctx, tc.itemID, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if !editedAt.Equal(tc.want) {
+				t.Errorf("GetEditedAtByItemID() = %v, want %v", editedAt, tc.want)
+			}
+		})
+	}
+}
+
+func TestItemRepository_CountAllDailyReviewDates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name        string
+		userID      string
+		parsedToday time.Time
+		want        int
+		wantErr     bool
+	}{
+		{
+			name:        "特定日の全復習日数を取得する場合",
+			userID:      "550e8400-e29b-41d4-a716-446655440001",
+			parsedToday: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			want:        1, // 2024-01-02にスケジュールされた復習日
+			wantErr:     false,
+		},
+		{
+			name:        "期限切れの復習日をずらさないユーザーの期限切れの復習日も数える場合",
+			userID:      "550e8400-e29b-41d4-a716-446655440002",
+			parsedToday: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			want:        2, // 2024-01-06の復習日と2024-01-05の期限切れの復習日
+			wantErr:     false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			count, err := repo.CountAllDailyReviewDates(ctx, tc.userID, tc.parsedToday)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if count != tc.want {
+				t.Errorf("CountAllDailyReviewDates() = %d, want %d", count, tc.want)
+			}
+		})
+	}
+}
+
+func TestItemRepository_GetAllDailyReviewDates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name        string
+		userID      string
+		parsedToday time.Time
+		want        []*itemDomain.DailyReviewDate
+		wantErr     bool
+	}{
+		{
+			name:        "特定日の全復習日詳細を取得する場合",
+			userID:      "550e8400-e29b-41d4-a716-446655440001",
+			parsedToday: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			want: []*itemDomain.DailyReviewDate{
+				{
+					ReviewdateID:         "b50e8400-e29b-41d4-a716-446655440001",
+					CategoryID:           stringPtr("650e8400-e29b-41d4-a716-446655440001"),
+					BoxID:                stringPtr("950e8400-e29b-41d4-a716-446655440001"),
+					StepNumber:           1,
+					InitialScheduledDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+					PrevScheduledDate:    nil,
+					ScheduledDate:        time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+					NextScheduledDate:    timePtr(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
+					IsCompleted:          false,
+					ItemID:               "a50e8400-e29b-41d4-a716-446655440001",
+					Name:                 "二次方程式",
+					Detail:               "ax^2 + bx + c = 0の解の公式を覚える",
+					LearnedDate:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					RegisteredAt:         time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+					EditedAt:             time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "期限切れの復習日をずらさないユーザーの期限切れの復習日も取得する場合",
+			userID:      "550e8400-e29b-41d4-a716-446655440002",
+			parsedToday: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			want: []*itemDomain.DailyReviewDate{
+				{
+					ReviewdateID:         "b50e8400-e29b-41d4-a716-446655440005",
+					CategoryID:           stringPtr("650e8400-e29b-41d4-a716-446655440003"),
+					BoxID:                stringPtr("950e8400-e29b-41d4-a716-446655440004"),
+					StepNumber:           1,
+					InitialScheduledDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+					PrevScheduledDate:    nil,
+					ScheduledDate:        time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+					NextScheduledDate:    nil,
+					IsCompleted:          false,
+					IsOverdue:            true,
+					ItemID:               "a50e8400-e29b-41d4-a716-446655440004",
+					Name:                 "英語の過去形",
+					Detail:               "不規則動詞の過去形を覚える",
+					LearnedDate:          time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+					RegisteredAt:         time.Date(2024, 1, 1, 13, 30, 0, 0, time.UTC),
+					EditedAt:             time.Date(2024, 1, 1, 13, 30, 0, 0, time.UTC),
+				},
+				{
+					ReviewdateID:         "b50e8400-e29b-41d4-a716-446655440007",
+					CategoryID:           stringPtr("650e8400-e29b-41d4-a716-446655440004"),
+					BoxID:                nil,
+					StepNumber:           1,
+					InitialScheduledDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+					PrevScheduledDate:    nil,
+					ScheduledDate:        time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+					NextScheduledDate:    nil,
+					IsCompleted:          false,
+					IsOverdue:            false,
+					ItemID:               "a50e8400-e29b-41d4-a716-446655440005",
+					Name:                 "明治維新",
+					Detail:               "1868年の政治変革について",
+					LearnedDate:          time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+					RegisteredAt:         time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
+					EditedAt:             time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "復習日がない日付の場合",
+			userID:      "550e8400-e29b-41d4-a716-446655440001",
+			parsedToday: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			want:        []*itemDomain.DailyReviewDate{},
+			wantErr:     false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewItemRepository()
+
+			reviewDates, err := repo.GetAllDailyReviewDates(ctx, tc.userID, tc.parsedToday)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tc.want, reviewDates, cmp.AllowUnexported(itemDomain.DailyReviewDate{})); diff != "" {
+				t.Errorf("GetAllDailyReviewDates() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestItemRepository_GetDueNowReviewDates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewItemRepository()
+
+	// 日単位でない段階の復習日を登録しておく
+	intradayReviewdate, err := itemDomain.NewIntradayReviewdate(
+		"c50e8400-e29b-41d4-a716-446655440101",
+		"550e8400-e29b-41d4-a716-446655440001",
+		stringPtr("650e8400-e29b-41d4-a716-446655440001"),
+		stringPtr("950e8400-e29b-41d4-a716-446655440001"),
+		"a50e8400-e29b-41d4-a716-446655440001",
+		10,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 12, 10, 0, 0, time.UTC),
+		false,
+	)
+	if err != nil {
+		t.Fatalf("復習日の作成に失敗しました: %v", err)
+	}
+	if _, err := repo.CreateReviewdates(ctx, []*itemDomain.Reviewdate{intradayReviewdate}); err != nil {
+		t.Fatalf("復習日の登録に失敗しました: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		userID  string
+		now     time.Time
+		want    []*itemDomain.DueNowReviewDate
+		wantErr bool
+	}{
+		{
+			name:   "復習日時を過ぎた復習日を取得する場合",
+			userID: "550e8400-e29b-41d4-a716-446655440001",
+			now:    time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
+			want: []*itemDomain.DueNowReviewDate{
+				{
+					ReviewdateID:  "c50e8400-e29b-41d4-a716-446655440101",
+					CategoryID:    stringPtr("650e8400-e29b-41d4-a716-446655440001"),
+					BoxID:         stringPtr("950e8400-e29b-41d4-a716-446655440001"),
+					StepNumber:    10,
+					ScheduledDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					ScheduledAt:   time.Date(2024, 1, 1, 12, 10, 0, 0, time.UTC),
+					ItemID:        "a50e8400-e29b-41d4-a716-446655440001",
+					Name:          "二次方程式",
+					Detail:        "ax^2 + bx + c = 0の解の公式を覚える",
+					LearnedDate:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "復習日時をまだ過ぎていない場合",
+			userID:  "550e8400-e29b-41d4-a716-446655440001",
+			now:     time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			want:    []*itemDomain.DueNowReviewDate{},
+			wantErr: false,
+		},
+		{
+			name:    "無効なユーザーIDの場合",
+			userID:  "invalid-uuid",
+			now:     time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			reviewDates, err := repo.GetDueNowReviewDates(ctx, tc.userID, tc.now)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1744,14 +2778,14 @@ func TestItemRepository_GetAllUnclassifiedReviewDatesByCategoryID(t *testing.T)
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, reviewdates, cmp.AllowUnexported(itemDomain.Reviewdate{})); diff != "" {
-				t.Errorf("GetAllUnclassifiedReviewDatesByCategoryID() mismatch (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tc.want, reviewDates); diff != "" {
+				t.Errorf("GetDueNowReviewDates() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_CountItemsGroupedByBoxByUserID(t *testing.T) {
+func TestItemRepository_GetFinishedItemsByBoxID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1761,27 +2795,43 @@ func TestItemRepository_CountItemsGroupedByBoxByUserID(t *testing.T) {
 
 	tests := []struct {
 		name    string
+		boxID   string
 		userID  string
-		want    []*itemDomain.ItemCountGroupedByBox
+		want    []*itemDomain.Item
 		wantErr bool
 	}{
 		{
-			name:   "ユーザーのボックス毎の復習物数を取得する場合",
+			name:   "ボックス内の完了済み復習物を取得する場合",
+			boxID:  "950e8400-e29b-41d4-a716-446655440002",
 			userID: "550e8400-e29b-41d4-a716-446655440001",
-			want: []*itemDomain.ItemCountGroupedByBox{
-				{
-					CategoryID: "650e8400-e29b-41d4-a716-446655440001",
-					BoxID:      "950e8400-e29b-41d4-a716-446655440001",
-					Count:      1, // 二次方程式の復習物
-				},
-				{
-					CategoryID: "650e8400-e29b-41d4-a716-446655440002",
-					BoxID:      "950e8400-e29b-41d4-a716-446655440003",
-					Count:      1, // ニュートンの第一法則の復習物
-				},
+			want: []*itemDomain.Item{
+				func() *itemDomain.Item {
+					item, _ := itemDomain.ReconstructItem(
+						"a50e8400-e29b-41d4-a716-446655440002",
+						"550e8400-e29b-41d4-a716-446655440001",
+						stringPtr("650e8400-e29b-41d4-a716-446655440001"),
+						stringPtr("950e8400-e29b-41d4-a716-446655440002"),
+						stringPtr("750e8400-e29b-41d4-a716-446655440002"),
+						nil,
+						"円の面積",
+						"π × r^2の公式を理解する",
+						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+						true,
+						time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
+						time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
+					)
+					return item
+				}(),
 			},
 			wantErr: false,
 		},
+		{
+			name:    "未完了復習物のみのボックスの場合（空の結果）",
+			boxID:   "950e8400-e29b-41d4-a716-446655440001",
+			userID:  "550e8400-e29b-41d4-a716-446655440001",
+			want:    []*itemDomain.Item{},
+			wantErr: false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -1790,7 +2840,7 @@ func TestItemRepository_CountItemsGroupedByBoxByUserID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			counts, err := repo.CountItemsGroupedByBoxByUserID(ctx, tc.userID)
+			items, err := repo.GetFinishedItemsByBoxID(ctx, tc.boxID, tc.userID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1804,14 +2854,14 @@ func TestItemRepository_CountItemsGroupedByBoxByUserID(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, counts, cmp.AllowUnexported(itemDomain.ItemCountGroupedByBox{})); diff != "" {
-				t.Errorf("CountItemsGroupedByBoxByUserID() mismatch (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tc.want, items, cmp.AllowUnexported(itemDomain.Item{})); diff != "" {
+				t.Errorf("GetFinishedItemsByBoxID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_CountUnclassifiedItemsGroupedByCategoryByUserID(t *testing.T) {
+func TestItemRepository_GetUnclassfiedFinishedItemsByCategoryID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1820,31 +2870,32 @@ func TestItemRepository_CountUnclassifiedItemsGroupedByCategoryByUserID(t *testi
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name    string
-		userID  string
-		want    []*itemDomain.UnclassifiedItemCountGroupedByCategory
-		wantErr bool
+		name       string
+		categoryID string
+		userID     string
+		want       []*itemDomain.Item
+		wantErr    bool
 	}{
 		{
-			name:   "ユーザーのカテゴリ毎の未分類復習物数を取得する場合",
-			userID: "550e8400-e29b-41d4-a716-446655440002",
-			want: []*itemDomain.UnclassifiedItemCountGroupedByCategory{
-				{
-					CategoryID: "650e8400-e29b-41d4-a716-446655440004",
-					Count:      1, // 明治維新の復習物（未分類）
-				},
-				{
-					CategoryID: "00000000-0000-0000-0000-000000000000", // category_id=nullの場合のUUID表現
-					Count:      1,                                      // Goroutineの復習物（完全未分類）
-				},
-			},
-			wantErr: false,
+			name:       "未分類完了済み復習物がないカテゴリの場合（GetUnclassfiedFinishedItemsByCategoryIDは真の未分類のみ）",
+			categoryID: "650e8400-e29b-41d4-a716-446655440003",
+			userID:     "550e8400-e29b-41d4-a716-446655440002",
+			want:       []*itemDomain.Item{},
+			wantErr:    false,
 		},
 		{
-			name:    "未分類復習物がないユーザーの場合",
-			userID:  "550e8400-e29b-41d4-a716-446655440001",
-			want:    []*itemDomain.UnclassifiedItemCountGroupedByCategory{},
-			wantErr: false,
+			name:       "未分類完了済み復習物がないカテゴリの場合",
+			categoryID: "650e8400-e29b-41d4-a716-446655440001",
+			userID:     "550e8400-e29b-41d4-a716-446655440001",
+			want:       []*itemDomain.Item{},
+			wantErr:    false,
+		},
+		{
+			name:       "存在しないカテゴリの場合",
+			categoryID: uuid.New().String(),
+			userID:     "550e8400-e29b-41d4-a716-446655440001",
+			want:       []*itemDomain.Item{},
+			wantErr:    false,
 		},
 	}
 
@@ -1854,7 +2905,7 @@ func TestItemRepository_CountUnclassifiedItemsGroupedByCategoryByUserID(t *testi
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			counts, err := repo.CountUnclassifiedItemsGroupedByCategoryByUserID(ctx, tc.userID)
+			items, err := repo.GetUnclassfiedFinishedItemsByCategoryID(ctx, tc.categoryID, tc.userID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1868,14 +2919,14 @@ func TestItemRepository_CountUnclassifiedItemsGroupedByCategoryByUserID(t *testi
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, counts, cmp.AllowUnexported(itemDomain.UnclassifiedItemCountGroupedByCategory{})); diff != "" {
-				t.Errorf("CountUnclassifiedItemsGroupedByCategoryByUserID() mismatch (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tc.want, items, cmp.AllowUnexported(itemDomain.Item{})); diff != "" {
+				t.Errorf("GetUnclassfiedFinishedItemsByCategoryID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_CountUnclassifiedItemsByUserID(t *testing.T) {
+func TestItemRepository_GetUnclassfiedFinishedItemsByUserID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1884,22 +2935,40 @@ func TestItemRepository_CountUnclassifiedItemsByUserID(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name          string
-		userID        string
-		wantErr       bool
-		expectedCount int64
+		name    string
+		userID  string
+		want    []*itemDomain.Item
+		wantErr bool
 	}{
 		{
-			name:          "ユーザー2の未分類復習物数をカウント（2件）",
-			userID:        "550e8400-e29b-41d4-a716-446655440002",
-			wantErr:       false,
-			expectedCount: 2, // 明治維新とGoroutineの2件
+			name:   "ユーザーの未分類完了済み復習物を取得する場合",
+			userID: "550e8400-e29b-41d4-a716-446655440002",
+			want: []*itemDomain.Item{
+				func() *itemDomain.Item {
+					item, _ := itemDomain.ReconstructItem(
+						"a50e8400-e29b-41d4-a716-446655440006",
+						"550e8400-e29b-41d4-a716-446655440002",
+						nil, // 真の未分類のため nil
+						nil, // 未分類のため nil
+						nil,
+						nil,
+						"江戸時代",
+						"江戸時代の政治と社会を学ぶ",
+						time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+						true,
+						time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC),
+						time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC),
+					)
+					return item
+				}(),
+			},
+			wantErr: false,
 		},
 		{
-			name:          "ユーザー1の未分類復習物数をカウント（0件）",
-			userID:        "550e8400-e29b-41d4-a716-446655440001",
-			wantErr:       false,
-			expectedCount: 0,
+			name:    "未分類完了済み復習物がないユーザーの場合",
+			userID:  "550e8400-e29b-41d4-a716-446655440001",
+			want:    []*itemDomain.Item{},
+			wantErr: false,
 		},
 	}
 
@@ -1909,27 +2978,28 @@ func TestItemRepository_CountUnclassifiedItemsByUserID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			count, err := repo.CountUnclassifiedItemsByUserID(ctx, tc.userID)
+			items, err := repo.GetUnclassfiedFinishedItemsByUserID(ctx, tc.userID)
 
 			if tc.wantErr {
 				if err == nil {
-					t.Error("expected error but got none")
+					t.Error("エラーが発生するはずですが、発生しませんでした")
 				}
 				return
 			}
 
 			if err != nil {
-				t.Errorf("unexpected error: %v", err)
+				t.Errorf("予期しないエラー: %v", err)
+				return
 			}
 
-			if int64(count) != tc.expectedCount {
-				t.Errorf("expected count %d, got %d", tc.expectedCount, count)
+			if diff := cmp.Diff(tc.want, items, cmp.AllowUnexported(itemDomain.Item{})); diff != "" {
+				t.Errorf("GetUnclassfiedFinishedItemsByUserID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_CountDailyDatesGroupedByBoxByUserID(t *testing.T) {
+func TestItemRepository_IsPatternRelatedToItemByPatternID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1938,24 +3008,25 @@ func TestItemRepository_CountDailyDatesGroupedByBoxByUserID(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name       string
-		userID     string
-		targetDate time.Time
-		want       []*itemDomain.DailyCountGroupedByBox
-		wantErr    bool
+		name      string
+		patternID string
+		userID    string
+		want      bool
+		wantErr   bool
 	}{
 		{
-			name:       "特定日のボックス毎の復習日数を取得する場合",
-			userID:     "550e8400-e29b-41d4-a716-446655440001",
-			targetDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
-			want: []*itemDomain.DailyCountGroupedByBox{
-				{
-					CategoryID: "650e8400-e29b-41d4-a716-446655440001",
-					BoxID:      "950e8400-e29b-41d4-a716-446655440001",
-					Count:      1, // 2024-01-02にスケジュールされた復習日
-				},
-			},
-			wantErr: false,
+			name:      "パターンが復習物に関連している場合",
+			patternID: "750e8400-e29b-41d4-a716-446655440001",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			want:      true,
+			wantErr:   false,
+		},
+		{
+			name:      "パターンが復習物に関連していない場合(異なるユーザーのパターン)",
+			patternID: "750e8400-e29b-41d4-a716-446655440003",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			want:      false,
+			wantErr:   false,
 		},
 	}
 
@@ -1965,7 +3036,7 @@ func TestItemRepository_CountDailyDatesGroupedByBoxByUserID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			counts, err := repo.CountDailyDatesGroupedByBoxByUserID(ctx, tc.userID, tc.targetDate)
+			isRelated, err := repo.IsPatternRelatedToItemByPatternID(ctx, tc.patternID, tc.userID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -1979,14 +3050,14 @@ func TestItemRepository_CountDailyDatesGroupedByBoxByUserID(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, counts, cmp.AllowUnexported(itemDomain.DailyCountGroupedByBox{})); diff != "" {
-				t.Errorf("CountDailyDatesGroupedByBoxByUserID() mismatch (-want +got):\n%s", diff)
+			if isRelated != tc.want {
+				t.Errorf("IsPatternRelatedToItemByPatternID() = %v, want %v", isRelated, tc.want)
 			}
 		})
 	}
 }
 
-func TestItemRepository_CountDailyDatesUnclassifiedGroupedByCategoryByUserID(t *testing.T) {
+func TestItemRepository_UpsertSM2State(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -1994,23 +3065,28 @@ func TestItemRepository_CountDailyDatesUnclassifiedGroupedByCategoryByUserID(t *
 	PrepareTestDatabase(t)
 	defer CleanupTestDatabase(t)
 
+	itemID := "a50e8400-e29b-41d4-a716-446655440001" // 二次方程式の復習物
+	userID := "550e8400-e29b-41d4-a716-446655440001"
+
+	initialState, _ := itemDomain.NewSM2State(itemID, userID, 1)
+	updatedState, _ := itemDomain.ReconstructSM2State(itemID, userID, 2.36, 2, 14)
+
 	tests := []struct {
-		name       string
-		userID     string
-		targetDate time.Time
-		want       []*itemDomain.UnclassifiedDailyDatesCountGroupedByCategory
-		wantErr    bool
+		name    string
+		states  []*itemDomain.SM2State // 順に保存する学習状態
+		want    *itemDomain.SM2State
+		wantErr bool
 	}{
 		{
-			name:       "特定日のカテゴリ毎の未分類復習日数を取得する場合",
-			userID:     "550e8400-e29b-41d4-a716-446655440002",
-			targetDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
-			want: []*itemDomain.UnclassifiedDailyDatesCountGroupedByCategory{
-				{
-					CategoryID: "650e8400-e29b-41d4-a716-446655440004",
-					Count:      1, // 2024-01-06にスケジュールされた未分類復習日
-				},
-			},
+			name:    "学習状態の新規作成に成功する場合",
+			states:  []*itemDomain.SM2State{initialState},
+			want:    initialState,
+			wantErr: false,
+		},
+		{
+			name:    "既存の学習状態の更新に成功する場合",
+			states:  []*itemDomain.SM2State{initialState, updatedState},
+			want:    updatedState,
 			wantErr: false,
 		},
 	}
@@ -2021,28 +3097,56 @@ func TestItemRepository_CountDailyDatesUnclassifiedGroupedByCategoryByUserID(t *
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			counts, err := repo.CountDailyDatesUnclassifiedGroupedByCategoryByUserID(ctx, tc.userID, tc.targetDate)
-
-			if tc.wantErr {
-				if err == nil {
-					t.Error("エラーが発生するはずですが、発生しませんでした")
+			for _, state := range tc.states {
+				err := repo.UpsertSM2State(ctx, state)
+				if tc.wantErr {
+					if err == nil {
+						t.Error("エラーが発生するはずですが、発生しませんでした")
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("予期しないエラー: %v", err)
+					return
 				}
-				return
 			}
 
+			actual, err := repo.FindSM2StateByItemID(ctx, itemID, userID)
 			if err != nil {
-				t.Errorf("予期しないエラー: %v", err)
+				t.Errorf("学習状態の取得に失敗: %v", err)
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, counts, cmp.AllowUnexported(itemDomain.UnclassifiedDailyDatesCountGroupedByCategory{})); diff != "" {
-				t.Errorf("CountDailyDatesUnclassifiedGroupedByCategoryByUserID() mismatch (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tc.want, actual, cmp.AllowUnexported(itemDomain.SM2State{})); diff != "" {
+				t.Errorf("UpsertSM2State() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_CountDailyDatesUnclassifiedByUserID(t *testing.T) {
+func TestItemRepository_FindSM2StateByItemID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	ctx := GetTestContext()
+	repo := NewItemRepository()
+
+	// 学習状態が未作成の場合はnilを返す
+	actual, err := repo.FindSM2StateByItemID(ctx, "a50e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440001")
+	if err != nil {
+		t.Errorf("予期しないエラー: %v", err)
+		return
+	}
+	if actual != nil {
+		t.Errorf("FindSM2StateByItemID() = %v, want nil", actual)
+	}
+}
+
+func TestItemRepository_CountForecastDatesGroupedByBoxByUserID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -2051,18 +3155,48 @@ func TestItemRepository_CountDailyDatesUnclassifiedByUserID(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name       string
-		userID     string
-		targetDate time.Time
-		want       int
-		wantErr    bool
+		name     string
+		userID   string
+		fromDate time.Time
+		toDate   time.Time
+		want     []*itemDomain.ForecastCountGroupedByBox
+		wantErr  bool
 	}{
 		{
-			name:       "特定日のユーザーの未分類復習日数を取得する場合",
-			userID:     "550e8400-e29b-41d4-a716-446655440002",
-			targetDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
-			want:       1, // 2024-01-06にスケジュールされた未分類復習日
-			wantErr:    false,
+			name:     "期間内の日毎・ボックス毎の未完了の復習日数を取得する場合",
+			userID:   "550e8400-e29b-41d4-a716-446655440001",
+			fromDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			toDate:   time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			want: []*itemDomain.ForecastCountGroupedByBox{
+				{
+					ScheduledDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+					CategoryID:    "650e8400-e29b-41d4-a716-446655440001",
+					BoxID:         "950e8400-e29b-41d4-a716-446655440001",
+					Count:         1,
+				},
+				// 2024-01-03の復習日は完了済みなので含まない
+				{
+					ScheduledDate: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+					CategoryID:    "650e8400-e29b-41d4-a716-446655440001",
+					BoxID:         "950e8400-e29b-41d4-a716-446655440001",
+					Count:         1,
+				},
+				{
+					ScheduledDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+					CategoryID:    "650e8400-e29b-41d4-a716-446655440002",
+					BoxID:         "950e8400-e29b-41d4-a716-446655440003",
+					Count:         1,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "期間内に復習日がない場合",
+			userID:   "550e8400-e29b-41d4-a716-446655440001",
+			fromDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			toDate:   time.Date(2024, 2, 7, 0, 0, 0, 0, time.UTC),
+			want:     []*itemDomain.ForecastCountGroupedByBox{},
+			wantErr:  false,
 		},
 	}
 
@@ -2072,7 +3206,7 @@ func TestItemRepository_CountDailyDatesUnclassifiedByUserID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			count, err := repo.CountDailyDatesUnclassifiedByUserID(ctx, tc.userID, tc.targetDate)
+			counts, err := repo.CountForecastDatesGroupedByBoxByUserID(ctx, tc.userID, tc.fromDate, tc.toDate)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2086,14 +3220,14 @@ func TestItemRepository_CountDailyDatesUnclassifiedByUserID(t *testing.T) {
 				return
 			}
 
-			if count != tc.want {
-				t.Errorf("CountDailyDatesUnclassifiedByUserID() = %d, want %d", count, tc.want)
+			if diff := cmp.Diff(tc.want, counts); diff != "" {
+				t.Errorf("CountForecastDatesGroupedByBoxByUserID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_GetEditedAtByItemID(t *testing.T) {
+func TestItemRepository_CountForecastDatesUnclassifiedGroupedByCategoryByUserID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -2102,17 +3236,30 @@ func TestItemRepository_GetEditedAtByItemID(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name    string
-		itemID  string
-		userID  string
-		want    time.Time
-		wantErr bool
+		name     string
+		userID   string
+		fromDate time.Time
+		toDate   time.Time
+		want     []*itemDomain.UnclassifiedForecastCountGroupedByCategory
+		wantErr  bool
 	}{
 		{
-			name:    "復習物のEditedAtを取得する場合",
-			itemID:  "a50e8400-e29b-41d4-a716-446655440001",
-			userID:  "550e8400-e29b-41d4-a716-446655440001",
-			want:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			name:     "期間内の日毎・カテゴリー毎の未分類の復習日数を取得する場合",
+			userID:   "550e8400-e29b-41d4-a716-446655440002",
+			fromDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			toDate:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+			want: []*itemDomain.UnclassifiedForecastCountGroupedByCategory{
+				{
+					ScheduledDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+					CategoryID:    stringPtr("650e8400-e29b-41d4-a716-446655440004"),
+					Count:         1,
+				},
+				{
+					ScheduledDate: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+					CategoryID:    nil, // ホーム画面の未分類
+					Count:         1,
+				},
+			},
 			wantErr: false,
 		},
 	}
@@ -2123,7 +3270,7 @@ func TestItemRepository_GetEditedAtByItemID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			editedAt, err := repo.GetEditedAtByItemID(ctx, tc.itemID, tc.userID)
+			counts, err := repo.CountForecastDatesUnclassifiedGroupedByCategoryByUserID(ctx, tc.userID, tc.fromDate, tc.toDate)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2137,14 +3284,14 @@ func TestItemRepository_GetEditedAtByItemID(t *testing.T) {
 				return
 			}
 
-			if !editedAt.Equal(tc.want) {
-				t.Errorf("GetEditedAtByItemID() = %v, want %v", editedAt, tc.want)
+			if diff := cmp.Diff(tc.want, counts); diff != "" {
+				t.Errorf("CountForecastDatesUnclassifiedGroupedByCategoryByUserID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_CountAllDailyReviewDates(t *testing.T) {
+func TestItemRepository_GetDailyReviewLimitByUserID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -2153,18 +3300,27 @@ func TestItemRepository_CountAllDailyReviewDates(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name        string
-		userID      string
-		parsedToday time.Time
-		want        int
-		wantErr     bool
+		name    string
+		userID  string
+		want    int
+		wantErr bool
 	}{
 		{
-			name:        "特定日の全復習日数を取得する場合",
-			userID:      "550e8400-e29b-41d4-a716-446655440001",
-			parsedToday: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
-			want:        1, // 2024-01-02にスケジュールされた復習日
-			wantErr:     false,
+			name:    "上限を設定していないユーザーの場合",
+			userID:  "550e8400-e29b-41d4-a716-446655440001",
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "上限を設定しているユーザーの場合",
+			userID:  "550e8400-e29b-41d4-a716-446655440003",
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "無効なユーザーIDの場合",
+			userID:  "invalid-uuid",
+			wantErr: true,
 		},
 	}
 
@@ -2174,7 +3330,7 @@ func TestItemRepository_CountAllDailyReviewDates(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			count, err := repo.CountAllDailyReviewDates(ctx, tc.userID, tc.parsedToday)
+			limit, err := repo.GetDailyReviewLimitByUserID(ctx, tc.userID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2188,14 +3344,14 @@ func TestItemRepository_CountAllDailyReviewDates(t *testing.T) {
 				return
 			}
 
-			if count != tc.want {
-				t.Errorf("CountAllDailyReviewDates() = %d, want %d", count, tc.want)
+			if limit != tc.want {
+				t.Errorf("GetDailyReviewLimitByUserID() = %v, want %v", limit, tc.want)
 			}
 		})
 	}
 }
 
-func TestItemRepository_GetAllDailyReviewDates(t *testing.T) {
+func TestItemRepository_GetExcludedWeekdaysByUserID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -2204,43 +3360,27 @@ func TestItemRepository_GetAllDailyReviewDates(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name        string
-		userID      string
-		parsedToday time.Time
-		want        []*itemDomain.DailyReviewDate
-		wantErr     bool
+		name    string
+		userID  string
+		want    []int
+		wantErr bool
 	}{
 		{
-			name:        "特定日の全復習日詳細を取得する場合",
-			userID:      "550e8400-e29b-41d4-a716-446655440001",
-			parsedToday: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
-			want: []*itemDomain.DailyReviewDate{
-				{
-					ReviewdateID:         "b50e8400-e29b-41d4-a716-446655440001",
-					CategoryID:           stringPtr("650e8400-e29b-41d4-a716-446655440001"),
-					BoxID:                stringPtr("950e8400-e29b-41d4-a716-446655440001"),
-					StepNumber:           1,
-					InitialScheduledDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
-					PrevScheduledDate:    nil,
-					ScheduledDate:        time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
-					NextScheduledDate:    timePtr(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)),
-					IsCompleted:          false,
-					ItemID:               "a50e8400-e29b-41d4-a716-446655440001",
-					Name:                 "二次方程式",
-					Detail:               "ax^2 + bx + c = 0の解の公式を覚える",
-					LearnedDate:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
-					RegisteredAt:         time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
-					EditedAt:             time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
-				},
-			},
+			name:    "除外する曜日を設定していないユーザーの場合",
+			userID:  "550e8400-e29b-41d4-a716-446655440001",
+			want:    []int{},
 			wantErr: false,
 		},
 		{
-			name:        "復習日がない日付の場合",
-			userID:      "550e8400-e29b-41d4-a716-446655440001",
-			parsedToday: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
-			want:        []*itemDomain.DailyReviewDate{},
-			wantErr:     false,
+			name:    "除外する曜日を設定しているユーザーの場合",
+			userID:  "550e8400-e29b-41d4-a716-446655440004",
+			want:    []int{0, 6},
+			wantErr: false,
+		},
+		{
+			name:    "無効なユーザーIDの場合",
+			userID:  "invalid-uuid",
+			wantErr: true,
 		},
 	}
 
@@ -2250,7 +3390,7 @@ func TestItemRepository_GetAllDailyReviewDates(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			reviewDates, err := repo.GetAllDailyReviewDates(ctx, tc.userID, tc.parsedToday)
+			weekdays, err := repo.GetExcludedWeekdaysByUserID(ctx, tc.userID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2264,14 +3404,14 @@ func TestItemRepository_GetAllDailyReviewDates(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, reviewDates, cmp.AllowUnexported(itemDomain.DailyReviewDate{})); diff != "" {
-				t.Errorf("GetAllDailyReviewDates() mismatch (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tc.want, weekdays); diff != "" {
+				t.Errorf("GetExcludedWeekdaysByUserID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_GetFinishedItemsByBoxID(t *testing.T) {
+func TestItemRepository_GetBlockedDatesByUserID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -2281,42 +3421,30 @@ func TestItemRepository_GetFinishedItemsByBoxID(t *testing.T) {
 
 	tests := []struct {
 		name    string
-		boxID   string
 		userID  string
-		want    []*itemDomain.Item
+		want    []time.Time
 		wantErr bool
 	}{
 		{
-			name:   "ボックス内の完了済み復習物を取得する場合",
-			boxID:  "950e8400-e29b-41d4-a716-446655440002",
+			name:   "復習日を置かない日付を登録しているユーザーの場合（日付順）",
 			userID: "550e8400-e29b-41d4-a716-446655440001",
-			want: []*itemDomain.Item{
-				func() *itemDomain.Item {
-					item, _ := itemDomain.ReconstructItem(
-						"a50e8400-e29b-41d4-a716-446655440002",
-						"550e8400-e29b-41d4-a716-446655440001",
-						stringPtr("650e8400-e29b-41d4-a716-446655440001"),
-						stringPtr("950e8400-e29b-41d4-a716-446655440002"),
-						stringPtr("750e8400-e29b-41d4-a716-446655440002"),
-						"円の面積",
-						"π × r^2の公式を理解する",
-						time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
-						true,
-						time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
-						time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC),
-					)
-					return item
-				}(),
+			want: []time.Time{
+				time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC),
 			},
 			wantErr: false,
 		},
 		{
-			name:    "未完了復習物のみのボックスの場合（空の結果）",
-			boxID:   "950e8400-e29b-41d4-a716-446655440001",
-			userID:  "550e8400-e29b-41d4-a716-446655440001",
-			want:    []*itemDomain.Item{},
+			name:    "復習日を置かない日付を登録していないユーザーの場合",
+			userID:  "550e8400-e29b-41d4-a716-446655440002",
+			want:    []time.Time{},
 			wantErr: false,
 		},
+		{
+			name:    "無効なユーザーIDの場合",
+			userID:  "invalid-uuid",
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -2325,7 +3453,7 @@ func TestItemRepository_GetFinishedItemsByBoxID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			items, err := repo.GetFinishedItemsByBoxID(ctx, tc.boxID, tc.userID)
+			dates, err := repo.GetBlockedDatesByUserID(ctx, tc.userID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2339,14 +3467,14 @@ func TestItemRepository_GetFinishedItemsByBoxID(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, items, cmp.AllowUnexported(itemDomain.Item{})); diff != "" {
-				t.Errorf("GetFinishedItemsByBoxID() mismatch (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tc.want, dates); diff != "" {
+				t.Errorf("GetBlockedDatesByUserID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_GetUnclassfiedFinishedItemsByCategoryID(t *testing.T) {
+func TestItemRepository_CountScheduledDatesGroupedByDateByUserID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -2355,32 +3483,37 @@ func TestItemRepository_GetUnclassfiedFinishedItemsByCategoryID(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name       string
-		categoryID string
-		userID     string
-		want       []*itemDomain.Item
-		wantErr    bool
+		name     string
+		userID   string
+		fromDate time.Time
+		toDate   time.Time
+		want     []*itemDomain.DailyScheduledCount
+		wantErr  bool
 	}{
 		{
-			name:       "未分類完了済み復習物がないカテゴリの場合（GetUnclassfiedFinishedItemsByCategoryIDは真の未分類のみ）",
-			categoryID: "650e8400-e29b-41d4-a716-446655440003",
-			userID:     "550e8400-e29b-41d4-a716-446655440002",
-			want:       []*itemDomain.Item{},
-			wantErr:    false,
-		},
-		{
-			name:       "未分類完了済み復習物がないカテゴリの場合",
-			categoryID: "650e8400-e29b-41d4-a716-446655440001",
-			userID:     "550e8400-e29b-41d4-a716-446655440001",
-			want:       []*itemDomain.Item{},
-			wantErr:    false,
+			name:     "期間内の日毎の未完了の復習日数を取得する場合",
+			userID:   "550e8400-e29b-41d4-a716-446655440001",
+			fromDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			toDate:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+			want: []*itemDomain.DailyScheduledCount{
+				{ScheduledDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Count: 1},
+				// 2024-01-03の復習日は完了済みなので含まない
+				{ScheduledDate: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), Count: 1},
+				{ScheduledDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), Count: 1},
+			},
+			wantErr: false,
 		},
 		{
-			name:       "存在しないカテゴリの場合",
-			categoryID: uuid.New().String(),
-			userID:     "550e8400-e29b-41d4-a716-446655440001",
-			want:       []*itemDomain.Item{},
-			wantErr:    false,
+			name:     "ボックスやカテゴリーを区別せずに集計する場合",
+			userID:   "550e8400-e29b-41d4-a716-446655440002",
+			fromDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			toDate:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+			want: []*itemDomain.DailyScheduledCount{
+				{ScheduledDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Count: 1},
+				{ScheduledDate: time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), Count: 1},
+				{ScheduledDate: time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), Count: 1},
+			},
+			wantErr: false,
 		},
 	}
 
@@ -2390,7 +3523,7 @@ func TestItemRepository_GetUnclassfiedFinishedItemsByCategoryID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			items, err := repo.GetUnclassfiedFinishedItemsByCategoryID(ctx, tc.categoryID, tc.userID)
+			counts, err := repo.CountScheduledDatesGroupedByDateByUserID(ctx, tc.userID, tc.fromDate, tc.toDate)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2404,14 +3537,14 @@ func TestItemRepository_GetUnclassfiedFinishedItemsByCategoryID(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, items, cmp.AllowUnexported(itemDomain.Item{})); diff != "" {
-				t.Errorf("GetUnclassfiedFinishedItemsByCategoryID() mismatch (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tc.want, counts); diff != "" {
+				t.Errorf("CountScheduledDatesGroupedByDateByUserID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_GetUnclassfiedFinishedItemsByUserID(t *testing.T) {
+func TestItemRepository_GetAllItemsByPatternID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -2420,40 +3553,37 @@ func TestItemRepository_GetUnclassfiedFinishedItemsByUserID(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name    string
-		userID  string
-		want    []*itemDomain.Item
-		wantErr bool
+		name        string
+		patternID   string
+		userID      string
+		wantItemIDs []string
+		wantErr     bool
 	}{
 		{
-			name:   "ユーザーの未分類完了済み復習物を取得する場合",
-			userID: "550e8400-e29b-41d4-a716-446655440002",
-			want: []*itemDomain.Item{
-				func() *itemDomain.Item {
-					item, _ := itemDomain.ReconstructItem(
-						"a50e8400-e29b-41d4-a716-446655440006",
-						"550e8400-e29b-41d4-a716-446655440002",
-						nil, // 真の未分類のため nil
-						nil, // 未分類のため nil
-						nil,
-						"江戸時代",
-						"江戸時代の政治と社会を学ぶ",
-						time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
-						true,
-						time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC),
-						time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC),
-					)
-					return item
-				}(),
+			name:      "パターンを使う復習物を取得する場合",
+			patternID: "750e8400-e29b-41d4-a716-446655440001",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			wantItemIDs: []string{
+				"a50e8400-e29b-41d4-a716-446655440001",
+				"a50e8400-e29b-41d4-a716-446655440003",
 			},
 			wantErr: false,
 		},
 		{
-			name:    "未分類完了済み復習物がないユーザーの場合",
-			userID:  "550e8400-e29b-41d4-a716-446655440001",
-			want:    []*itemDomain.Item{},
+			name:      "完了済みの復習物も取得する場合",
+			patternID: "750e8400-e29b-41d4-a716-446655440002",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			wantItemIDs: []string{
+				"a50e8400-e29b-41d4-a716-446655440002",
+			},
 			wantErr: false,
 		},
+		{
+			name:      "無効なユーザーIDの場合",
+			patternID: "750e8400-e29b-41d4-a716-446655440001",
+			userID:    "invalid-uuid",
+			wantErr:   true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -2462,7 +3592,7 @@ func TestItemRepository_GetUnclassfiedFinishedItemsByUserID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			items, err := repo.GetUnclassfiedFinishedItemsByUserID(ctx, tc.userID)
+			items, err := repo.GetAllItemsByPatternID(ctx, tc.patternID, tc.userID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2476,14 +3606,20 @@ func TestItemRepository_GetUnclassfiedFinishedItemsByUserID(t *testing.T) {
 				return
 			}
 
-			if diff := cmp.Diff(tc.want, items, cmp.AllowUnexported(itemDomain.Item{})); diff != "" {
-				t.Errorf("GetUnclassfiedFinishedItemsByUserID() mismatch (-want +got):\n%s", diff)
+			gotItemIDs := make([]string, len(items))
+			for i, item := range items {
+				gotItemIDs[i] = item.ItemID()
+			}
+
+			sort.Strings(gotItemIDs)
+			if diff := cmp.Diff(tc.wantItemIDs, gotItemIDs); diff != "" {
+				t.Errorf("GetAllItemsByPatternID() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestItemRepository_IsPatternRelatedToItemByPatternID(t *testing.T) {
+func TestItemRepository_UpdateItemsPatternIDByPatternID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
 	}
@@ -2492,25 +3628,27 @@ func TestItemRepository_IsPatternRelatedToItemByPatternID(t *testing.T) {
 	defer CleanupTestDatabase(t)
 
 	tests := []struct {
-		name      string
-		patternID string
-		userID    string
-		want      bool
-		wantErr   bool
+		name         string
+		patternID    string
+		userID       string
+		newPatternID string
+		wantAffected int64
+		wantErr      bool
 	}{
 		{
-			name:      "パターンが復習物に関連している場合",
-			patternID: "750e8400-e29b-41d4-a716-446655440001",
-			userID:    "550e8400-e29b-41d4-a716-446655440001",
-			want:      true,
-			wantErr:   false,
+			name:         "パターンを使う復習物を別のパターンに付け替える場合",
+			patternID:    "750e8400-e29b-41d4-a716-446655440001",
+			userID:       "550e8400-e29b-41d4-a716-446655440001",
+			newPatternID: "750e8400-e29b-41d4-a716-446655440002",
+			wantAffected: 2,
+			wantErr:      false,
 		},
 		{
-			name:      "パターンが復習物に関連していない場合(異なるユーザーのパターン)",
-			patternID: "750e8400-e29b-41d4-a716-446655440003",
-			userID:    "550e8400-e29b-41d4-a716-446655440001",
-			want:      false,
-			wantErr:   false,
+			name:         "無効なパターンIDの場合",
+			patternID:    "invalid-uuid",
+			userID:       "550e8400-e29b-41d4-a716-446655440001",
+			newPatternID: "750e8400-e29b-41d4-a716-446655440002",
+			wantErr:      true,
 		},
 	}
 
@@ -2520,7 +3658,7 @@ func TestItemRepository_IsPatternRelatedToItemByPatternID(t *testing.T) {
 			ctx := GetTestContext()
 			repo := NewItemRepository()
 
-			isRelated, err := repo.IsPatternRelatedToItemByPatternID(ctx, tc.patternID, tc.userID)
+			affected, err := repo.UpdateItemsPatternIDByPatternID(ctx, tc.patternID, tc.userID, tc.newPatternID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -2534,8 +3672,18 @@ func TestItemRepository_IsPatternRelatedToItemByPatternID(t *testing.T) {
 				return
 			}
 
-			if isRelated != tc.want {
-				t.Errorf("IsPatternRelatedToItemByPatternID() = %v, want %v", isRelated, tc.want)
+			if affected != tc.wantAffected {
+				t.Errorf("UpdateItemsPatternIDByPatternID() affected = %v, want %v", affected, tc.wantAffected)
+			}
+
+			// 付け替え元のパターンを使う復習物が残っていないことを確認
+			items, err := repo.GetAllItemsByPatternID(ctx, tc.patternID, tc.userID)
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+			if len(items) != 0 {
+				t.Errorf("付け替え後も復習物が残っています: %d", len(items))
 			}
 		})
 	}
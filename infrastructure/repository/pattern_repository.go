@@ -27,12 +27,16 @@ func (r *patternRepository) CreatePattern(ctx context.Context, p *patternDomain.
 	pgEdit := pgtype.Timestamptz{Time: p.EditedAt(), Valid: true}
 
 	params := dbgen.CreatePatternParams{
-		ID:           pgID,
-		UserID:       pgUserID,
-		Name:         p.Name(),
-		TargetWeight: dbgen.TargetWeightEnum(p.TargetWeight()),
-		RegisteredAt: pgReg,
-		EditedAt:     pgEdit,
+		ID:                      pgID,
+		UserID:                  pgUserID,
+		Name:                    p.Name(),
+		TargetWeight:            dbgen.TargetWeightEnum(p.TargetWeight()),
+		SchedulingAlgorithm:     dbgen.SchedulingAlgorithmEnum(p.SchedulingAlgorithm()),
+		IsLoadBalanced:          p.IsLoadBalanced(),
+		ExcludedWeekdays:        toWeekdays(p.ExcludedWeekdays()),
+		MaintenanceIntervalDays: int16(p.MaintenanceIntervalDays()), // #nosec G115
+		RegisteredAt:            pgReg,
+		EditedAt:                pgEdit,
 	}
 
 	return q.CreatePattern(ctx, params)
@@ -40,7 +44,7 @@ func (r *patternRepository) CreatePattern(ctx context.Context, p *patternDomain.
 
 func (r *patternRepository) CreatePatternSteps(ctx context.Context, steps []*patternDomain.PatternStep) (int64, error) {
 	q := db.GetQuery(ctx)
-	colums := []string{"id", "user_id", "pattern_id", "step_number", "interval_days"}
+	colums := []string{"id", "user_id", "pattern_id", "step_number", "interval_days", "interval_unit"}
 	cps := make([]dbgen.CreatePatternStepsParams, len(steps))
 	rows := make([][]any, len(steps))
 	for i, s := range steps {
@@ -53,6 +57,7 @@ func (r *patternRepository) CreatePatternSteps(ctx context.Context, steps []*pat
 			PatternID:    pgPatternID,
 			StepNumber:   int16(s.StepNumber()),   // #nosec G115
 			IntervalDays: int16(s.IntervalDays()), // #nosec G115
+			IntervalUnit: dbgen.IntervalUnitEnum(s.IntervalUnit()),
 		}
 		rows[i] = []any{
 			cps[i].ID,
@@ -60,6 +65,7 @@ func (r *patternRepository) CreatePatternSteps(ctx context.Context, steps []*pat
 			cps[i].PatternID,
 			cps[i].StepNumber,
 			cps[i].IntervalDays,
+			cps[i].IntervalUnit,
 		}
 	}
 	return q.CopyFrom(
@@ -87,6 +93,10 @@ func (r *patternRepository) GetAllPatternsByUserID(ctx context.Context, userID s
 			userID,
 			row.Name,
 			string(row.TargetWeight),
+			string(row.SchedulingAlgorithm),
+			row.IsLoadBalanced,
+			fromWeekdays(row.ExcludedWeekdays),
+			int(row.MaintenanceIntervalDays),
 			row.RegisteredAt.Time,
 			row.EditedAt.Time,
 		)
@@ -119,6 +129,7 @@ func (r *patternRepository) GetAllPatternStepsByUserID(ctx context.Context, user
 			patternID,
 			int(row.StepNumber),
 			int(row.IntervalDays),
+			string(row.IntervalUnit),
 		)
 		if err != nil {
 			return nil, err
@@ -134,11 +145,15 @@ func (r *patternRepository) UpdatePattern(ctx context.Context, p *patternDomain.
 	pgEdit := pgtype.Timestamptz{Time: p.EditedAt(), Valid: true}
 
 	params := dbgen.UpdatePatternParams{
-		Name:         p.Name(),
-		TargetWeight: dbgen.TargetWeightEnum(p.TargetWeight()),
-		EditedAt:     pgEdit,
-		ID:           pgID,
-		UserID:       pgUserID,
+		Name:                    p.Name(),
+		TargetWeight:            dbgen.TargetWeightEnum(p.TargetWeight()),
+		SchedulingAlgorithm:     dbgen.SchedulingAlgorithmEnum(p.SchedulingAlgorithm()),
+		IsLoadBalanced:          p.IsLoadBalanced(),
+		ExcludedWeekdays:        toWeekdays(p.ExcludedWeekdays()),
+		MaintenanceIntervalDays: int16(p.MaintenanceIntervalDays()), // #nosec G115
+		EditedAt:                pgEdit,
+		ID:                      pgID,
+		UserID:                  pgUserID,
 	}
 	return q.UpdatePattern(ctx, params)
 }
@@ -193,6 +208,10 @@ func (r *patternRepository) FindPatternByPatternID(ctx context.Context, patternI
 		userID,
 		row.Name,
 		string(row.TargetWeight),
+		string(row.SchedulingAlgorithm),
+		row.IsLoadBalanced,
+		fromWeekdays(row.ExcludedWeekdays),
+		int(row.MaintenanceIntervalDays),
 		row.RegisteredAt.Time,
 		row.EditedAt.Time,
 	)
@@ -232,6 +251,7 @@ func (r *patternRepository) GetAllPatternStepsByPatternID(ctx context.Context, p
 			patternID,
 			int(row.StepNumber),
 			int(row.IntervalDays),
+			string(row.IntervalUnit),
 		)
 		if err != nil {
 			return nil, err
@@ -263,3 +283,183 @@ func (r *patternRepository) GetPatternTargetWeightsByPatternIDs(ctx context.Cont
 	}
 	return out, nil
 }
+
+// 版の保存時に、版のステップも合わせて保存する
+func (r *patternRepository) CreatePatternVersion(ctx context.Context, v *patternDomain.PatternVersion) error {
+	q := db.GetQuery(ctx)
+	pgID, _ := toUUID(v.PatternVersionID())
+	pgUserID, _ := toUUID(v.UserID())
+	pgPatternID, _ := toUUID(v.PatternID())
+	params := dbgen.CreatePatternVersionParams{
+		ID:                  pgID,
+		UserID:              pgUserID,
+		PatternID:           pgPatternID,
+		VersionNumber:       int32(v.VersionNumber()), // #nosec G115
+		SchedulingAlgorithm: dbgen.SchedulingAlgorithmEnum(v.SchedulingAlgorithm()),
+		CreatedAt:           pgtype.Timestamptz{Time: v.CreatedAt(), Valid: true},
+	}
+	if err := q.CreatePatternVersion(ctx, params); err != nil {
+		return err
+	}
+
+	colums := []string{"id", "user_id", "pattern_version_id", "step_number", "interval_days", "interval_unit"}
+	rows := make([][]any, len(v.Steps()))
+	for i, s := range v.Steps() {
+		pgStepID, _ := toUUID(s.PatternStepID())
+		rows[i] = []any{
+			pgStepID,
+			pgUserID,
+			pgID,
+			int16(s.StepNumber()),   // #nosec G115
+			int16(s.IntervalDays()), // #nosec G115
+			dbgen.IntervalUnitEnum(s.IntervalUnit()),
+		}
+	}
+	_, err := q.CopyFrom(
+		ctx,
+		pgx.Identifier{"pattern_version_steps"},
+		colums,
+		pgx.CopyFromRows(rows),
+	)
+	return err
+}
+
+// 次の版番号の採番と、版の履歴の現在の版の判定に使う
+func (r *patternRepository) FindLatestPatternVersionByPatternID(ctx context.Context, patternID string, userID string) (*patternDomain.PatternVersion, error) {
+	q := db.GetQuery(ctx)
+	pgPatternID, err := toUUID(patternID)
+	if err != nil {
+		return nil, err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := q.GetLatestPatternVersionByPatternID(ctx, dbgen.GetLatestPatternVersionByPatternIDParams{
+		PatternID: pgPatternID,
+		UserID:    pgUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.reconstructPatternVersion(ctx, dbgen.GetPatternVersionByIDRow(row), userID)
+}
+
+// 復習物が記録している版の取得用
+func (r *patternRepository) FindPatternVersionByID(ctx context.Context, patternVersionID string, userID string) (*patternDomain.PatternVersion, error) {
+	q := db.GetQuery(ctx)
+	pgID, err := toUUID(patternVersionID)
+	if err != nil {
+		return nil, err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	row, err := q.GetPatternVersionByID(ctx, dbgen.GetPatternVersionByIDParams{
+		ID:     pgID,
+		UserID: pgUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.reconstructPatternVersion(ctx, row, userID)
+}
+
+func (r *patternRepository) reconstructPatternVersion(ctx context.Context, row dbgen.GetPatternVersionByIDRow, userID string) (*patternDomain.PatternVersion, error) {
+	q := db.GetQuery(ctx)
+	pgUserID, _ := toUUID(userID)
+	stepRows, err := q.GetPatternVersionStepsByPatternVersionID(ctx, dbgen.GetPatternVersionStepsByPatternVersionIDParams{
+		PatternVersionID: row.ID,
+		UserID:           pgUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	patternID := uuid.UUID(row.PatternID.Bytes).String()
+	steps := make([]*patternDomain.PatternStep, len(stepRows))
+	for i, stepRow := range stepRows {
+		steps[i], err = patternDomain.ReconstructPatternStep(
+			uuid.UUID(stepRow.ID.Bytes).String(),
+			userID,
+			patternID,
+			int(stepRow.StepNumber),
+			int(stepRow.IntervalDays),
+			string(stepRow.IntervalUnit),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return patternDomain.ReconstructPatternVersion(
+		uuid.UUID(row.ID.Bytes).String(),
+		userID,
+		patternID,
+		int(row.VersionNumber),
+		string(row.SchedulingAlgorithm),
+		steps,
+		row.CreatedAt.Time,
+	)
+}
+
+// 版の履歴一覧取得用。版の昇順で返す
+func (r *patternRepository) GetPatternVersionsByPatternID(ctx context.Context, patternID string, userID string) ([]*patternDomain.PatternVersion, error) {
+	q := db.GetQuery(ctx)
+	pgPatternID, err := toUUID(patternID)
+	if err != nil {
+		return nil, err
+	}
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := q.GetPatternVersionsByPatternID(ctx, dbgen.GetPatternVersionsByPatternIDParams{
+		PatternID: pgPatternID,
+		UserID:    pgUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stepRows, err := q.GetPatternVersionStepsByPatternID(ctx, dbgen.GetPatternVersionStepsByPatternIDParams{
+		PatternID: pgPatternID,
+		UserID:    pgUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stepsByVersionID := make(map[string][]*patternDomain.PatternStep)
+	for _, stepRow := range stepRows {
+		versionID := uuid.UUID(stepRow.PatternVersionID.Bytes).String()
+		step, err := patternDomain.ReconstructPatternStep(
+			uuid.UUID(stepRow.ID.Bytes).String(),
+			userID,
+			patternID,
+			int(stepRow.StepNumber),
+			int(stepRow.IntervalDays),
+			string(stepRow.IntervalUnit),
+		)
+		if err != nil {
+			return nil, err
+		}
+		stepsByVersionID[versionID] = append(stepsByVersionID[versionID], step)
+	}
+
+	results := make([]*patternDomain.PatternVersion, len(rows))
+	for i, row := range rows {
+		versionID := uuid.UUID(row.ID.Bytes).String()
+		results[i], err = patternDomain.ReconstructPatternVersion(
+			versionID,
+			userID,
+			patternID,
+			int(row.VersionNumber),
+			string(row.SchedulingAlgorithm),
+			stepsByVersionID[versionID],
+			row.CreatedAt.Time,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
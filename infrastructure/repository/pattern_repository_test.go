@@ -31,6 +31,10 @@ func TestPatternRepository_CreatePattern(t *testing.T) {
 					"550e8400-e29b-41d4-a716-446655440001", // Exists in fixture
 					"新しいパターン",
 					"normal",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					time.Now(),
 					time.Now(),
 				)
@@ -42,6 +46,10 @@ func TestPatternRepository_CreatePattern(t *testing.T) {
 					"550e8400-e29b-41d4-a716-446655440001",
 					"新しいパターン",
 					"normal",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					time.Time{}, // RegisteredAtは動的に設定
 					time.Time{}, // EditedAtは動的に設定
 				)
@@ -57,6 +65,10 @@ func TestPatternRepository_CreatePattern(t *testing.T) {
 					uuid.New().String(), // Does not exist in fixture
 					"存在しないユーザーパターン",
 					"normal",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					time.Now(),
 					time.Now(),
 				)
@@ -73,6 +85,10 @@ func TestPatternRepository_CreatePattern(t *testing.T) {
 					"550e8400-e29b-41d4-a716-446655440001",
 					"無効な重みパターン",
 					"invalid_weight", // Invalid enum value
+					"fixed",
+					false,
+					[]int{},
+					0,
 					time.Now(),
 					time.Now(),
 				)
@@ -121,6 +137,10 @@ func TestPatternRepository_CreatePattern(t *testing.T) {
 				tc.want.UserID(),
 				tc.want.Name(),
 				tc.want.TargetWeight(),
+				"fixed",
+				false,
+				[]int{},
+				0,
 				createdPattern.RegisteredAt(),
 				createdPattern.EditedAt(),
 			)
@@ -158,6 +178,10 @@ func TestPatternRepository_GetAllPatternsByUserID(t *testing.T) {
 						"550e8400-e29b-41d4-a716-446655440001",
 						"フィボナッチパターン",
 						"normal",
+						"fixed",
+						false,
+						[]int{},
+						0,
 						time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
 						time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
 					)
@@ -169,6 +193,10 @@ func TestPatternRepository_GetAllPatternsByUserID(t *testing.T) {
 						"550e8400-e29b-41d4-a716-446655440001",
 						"エビングハウスパターン",
 						"heavy",
+						"fixed",
+						false,
+						[]int{},
+						0,
 						time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC),
 						time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC),
 					)
@@ -180,6 +208,10 @@ func TestPatternRepository_GetAllPatternsByUserID(t *testing.T) {
 						"550e8400-e29b-41d4-a716-446655440001",
 						"ステップ未作成のパターン",
 						"light",
+						"fixed",
+						false,
+						[]int{},
+						0,
 						time.Date(2024, 1, 1, 9, 00, 0, 0, time.UTC),
 						time.Date(2024, 1, 1, 9, 00, 0, 0, time.UTC),
 					)
@@ -258,6 +290,10 @@ func TestPatternRepository_UpdatePattern(t *testing.T) {
 					"550e8400-e29b-41d4-a716-446655440001",
 					"更新されたフィボナッチパターン",
 					"heavy",
+					"fixed",
+					false,
+					[]int{},
+					30,
 					time.Now().Add(-24 * time.Hour),
 					time.Now(),
 				)
@@ -269,6 +305,10 @@ func TestPatternRepository_UpdatePattern(t *testing.T) {
 					"550e8400-e29b-41d4-a716-446655440001",
 					"更新されたフィボナッチパターン",
 					"heavy",
+					"fixed",
+					false,
+					[]int{},
+					30,
 					time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
 					time.Time{}, // EditedAtは動的に設定
 				)
@@ -284,6 +324,10 @@ func TestPatternRepository_UpdatePattern(t *testing.T) {
 					"550e8400-e29b-41d4-a716-446655440001",
 					"パターン",
 					"invalid_weight",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					time.Now().Add(-24 * time.Hour),
 					time.Now(),
 				)
@@ -334,6 +378,10 @@ func TestPatternRepository_UpdatePattern(t *testing.T) {
 					tc.want.UserID(),
 					tc.want.Name(),
 					tc.want.TargetWeight(),
+					"fixed",
+					false,
+					[]int{},
+					tc.want.MaintenanceIntervalDays(),
 					tc.want.RegisteredAt(),
 					updatedPattern.EditedAt(),
 				)
@@ -438,6 +486,10 @@ func TestPatternRepository_FindPatternByPatternID(t *testing.T) {
 					"550e8400-e29b-41d4-a716-446655440001",
 					"フィボナッチパターン",
 					"normal",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
 					time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
 				)
@@ -508,6 +560,7 @@ func TestPatternRepository_CreatePatternSteps(t *testing.T) {
 						"750e8400-e29b-41d4-a716-446655440005",
 						1,
 						1,
+						patternDomain.IntervalUnitDay,
 					)
 					return ps
 				}(),
@@ -518,6 +571,7 @@ func TestPatternRepository_CreatePatternSteps(t *testing.T) {
 						"750e8400-e29b-41d4-a716-446655440005",
 						2,
 						3,
+						patternDomain.IntervalUnitMonth,
 					)
 					return ps
 				}(),
@@ -530,6 +584,7 @@ func TestPatternRepository_CreatePatternSteps(t *testing.T) {
 						"750e8400-e29b-41d4-a716-446655440005",
 						1,
 						1,
+						patternDomain.IntervalUnitDay,
 					)
 					return ps
 				}(),
@@ -540,6 +595,7 @@ func TestPatternRepository_CreatePatternSteps(t *testing.T) {
 						"750e8400-e29b-41d4-a716-446655440005",
 						2,
 						3,
+						patternDomain.IntervalUnitMonth,
 					)
 					return ps
 				}(),
@@ -556,6 +612,7 @@ func TestPatternRepository_CreatePatternSteps(t *testing.T) {
 						"750e8400-e29b-41d4-a716-446655440999", // 存在しないパターンID
 						1,
 						1,
+						patternDomain.IntervalUnitDay,
 					)
 					return ps
 				}(),
@@ -633,11 +690,11 @@ func TestPatternRepository_GetAllPatternStepsByUserID(t *testing.T) {
 			name:   "ユーザー1のパターンステップを取得（5件）",
 			userID: "550e8400-e29b-41d4-a716-446655440001",
 			want: func() []patternDomain.PatternStep {
-				ps1, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 1, 1)
-				ps2, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440002", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 2, 2)
-				ps3, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440003", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 3, 3)
-				ps4, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440004", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440002", 1, 1)
-				ps5, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440005", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440002", 2, 5)
+				ps1, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 1, 1, patternDomain.IntervalUnitDay)
+				ps2, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440002", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 2, 2, patternDomain.IntervalUnitDay)
+				ps3, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440003", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 3, 3, patternDomain.IntervalUnitDay)
+				ps4, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440004", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440002", 1, 1, patternDomain.IntervalUnitDay)
+				ps5, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440005", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440002", 2, 5, patternDomain.IntervalUnitDay)
 				return []patternDomain.PatternStep{*ps1, *ps2, *ps3, *ps4, *ps5}
 			}(),
 			wantErr:       false,
@@ -647,8 +704,8 @@ func TestPatternRepository_GetAllPatternStepsByUserID(t *testing.T) {
 			name:   "ユーザー2のパターンステップを取得（2件）",
 			userID: "550e8400-e29b-41d4-a716-446655440002",
 			want: func() []patternDomain.PatternStep {
-				ps1, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440006", "550e8400-e29b-41d4-a716-446655440002", "750e8400-e29b-41d4-a716-446655440003", 1, 7)
-				ps2, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440007", "550e8400-e29b-41d4-a716-446655440002", "750e8400-e29b-41d4-a716-446655440004", 1, 3)
+				ps1, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440006", "550e8400-e29b-41d4-a716-446655440002", "750e8400-e29b-41d4-a716-446655440003", 1, 7, patternDomain.IntervalUnitDay)
+				ps2, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440007", "550e8400-e29b-41d4-a716-446655440002", "750e8400-e29b-41d4-a716-446655440004", 1, 3, patternDomain.IntervalUnitDay)
 				return []patternDomain.PatternStep{*ps1, *ps2}
 			}(),
 			wantErr:       false,
@@ -793,9 +850,9 @@ func TestPatternRepository_GetAllPatternStepsByPatternID(t *testing.T) {
 			patternID: "750e8400-e29b-41d4-a716-446655440001",
 			userID:    "550e8400-e29b-41d4-a716-446655440001",
 			want: func() []patternDomain.PatternStep {
-				ps1, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 1, 1)
-				ps2, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440002", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 2, 2)
-				ps3, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440003", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 3, 3)
+				ps1, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 1, 1, patternDomain.IntervalUnitDay)
+				ps2, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440002", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 2, 2, patternDomain.IntervalUnitDay)
+				ps3, _ := patternDomain.ReconstructPatternStep("850e8400-e29b-41d4-a716-446655440003", "550e8400-e29b-41d4-a716-446655440001", "750e8400-e29b-41d4-a716-446655440001", 3, 3, patternDomain.IntervalUnitDay)
 				return []patternDomain.PatternStep{*ps1, *ps2, *ps3}
 			}(),
 			wantErr: false,
@@ -914,3 +971,187 @@ func TestPatternRepository_GetPatternTargetWeightsByPatternIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestPatternRepository_CreatePatternVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	userID := "550e8400-e29b-41d4-a716-446655440001"
+	patternID := "750e8400-e29b-41d4-a716-446655440002"
+	step1, _ := patternDomain.NewPatternStep("850e8400-e29b-41d4-a716-446655440901", userID, patternID, 1, 2, "day")
+	step2, _ := patternDomain.NewPatternStep("850e8400-e29b-41d4-a716-446655440902", userID, patternID, 2, 6, "day")
+	version, _ := patternDomain.NewPatternVersion(
+		"d50e8400-e29b-41d4-a716-446655440901",
+		userID,
+		patternID,
+		1,
+		"fixed",
+		[]*patternDomain.PatternStep{step1, step2},
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	)
+
+	ctx := GetTestContext()
+	repo := NewPatternRepository()
+
+	err := repo.CreatePatternVersion(ctx, version)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	got, err := repo.FindPatternVersionByID(ctx, version.PatternVersionID(), userID)
+	if err != nil {
+		t.Fatalf("作成された版の取得に失敗: %v", err)
+	}
+
+	// 期待値との比較
+	if diff := cmp.Diff(version, got, cmp.AllowUnexported(patternDomain.PatternVersion{}, patternDomain.PatternStep{})); diff != "" {
+		t.Errorf("CreatePatternVersion() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPatternRepository_FindLatestPatternVersionByPatternID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name              string
+		patternID         string
+		userID            string
+		wantVersionID     string
+		wantVersionNumber int
+		wantStepCount     int
+		wantErr           bool
+	}{
+		{
+			name:              "最新の版を取得する場合",
+			patternID:         "750e8400-e29b-41d4-a716-446655440001",
+			userID:            "550e8400-e29b-41d4-a716-446655440001",
+			wantVersionID:     "d50e8400-e29b-41d4-a716-446655440002",
+			wantVersionNumber: 2,
+			wantStepCount:     3,
+			wantErr:           false,
+		},
+		{
+			name:      "版のないパターンの場合",
+			patternID: "750e8400-e29b-41d4-a716-446655440003",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewPatternRepository()
+
+			version, err := repo.FindLatestPatternVersionByPatternID(ctx, tc.patternID, tc.userID)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if version.PatternVersionID() != tc.wantVersionID {
+				t.Errorf("PatternVersionID() = %s, want %s", version.PatternVersionID(), tc.wantVersionID)
+			}
+			if version.VersionNumber() != tc.wantVersionNumber {
+				t.Errorf("VersionNumber() = %d, want %d", version.VersionNumber(), tc.wantVersionNumber)
+			}
+			if len(version.Steps()) != tc.wantStepCount {
+				t.Errorf("len(Steps()) = %d, want %d", len(version.Steps()), tc.wantStepCount)
+			}
+		})
+	}
+}
+
+func TestPatternRepository_GetPatternVersionsByPatternID(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	userID := "550e8400-e29b-41d4-a716-446655440001"
+	patternID := "750e8400-e29b-41d4-a716-446655440001"
+
+	tests := []struct {
+		name      string
+		patternID string
+		userID    string
+		want      []*patternDomain.PatternVersion
+	}{
+		{
+			name:      "版を版番号の昇順で取得する場合",
+			patternID: patternID,
+			userID:    userID,
+			want: func() []*patternDomain.PatternVersion {
+				v1Step1, _ := patternDomain.ReconstructPatternStep("d60e8400-e29b-41d4-a716-446655440001", userID, patternID, 1, 1, "day")
+				v1Step2, _ := patternDomain.ReconstructPatternStep("d60e8400-e29b-41d4-a716-446655440002", userID, patternID, 2, 3, "day")
+				v2Step1, _ := patternDomain.ReconstructPatternStep("d60e8400-e29b-41d4-a716-446655440003", userID, patternID, 1, 1, "day")
+				v2Step2, _ := patternDomain.ReconstructPatternStep("d60e8400-e29b-41d4-a716-446655440004", userID, patternID, 2, 2, "day")
+				v2Step3, _ := patternDomain.ReconstructPatternStep("d60e8400-e29b-41d4-a716-446655440005", userID, patternID, 3, 3, "day")
+				v1, _ := patternDomain.ReconstructPatternVersion(
+					"d50e8400-e29b-41d4-a716-446655440001",
+					userID,
+					patternID,
+					1,
+					"fixed",
+					[]*patternDomain.PatternStep{v1Step1, v1Step2},
+					time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+				)
+				v2, _ := patternDomain.ReconstructPatternVersion(
+					"d50e8400-e29b-41d4-a716-446655440002",
+					userID,
+					patternID,
+					2,
+					"fixed",
+					[]*patternDomain.PatternStep{v2Step1, v2Step2, v2Step3},
+					time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+				)
+				return []*patternDomain.PatternVersion{v1, v2}
+			}(),
+		},
+		{
+			name:      "版のないパターンの場合",
+			patternID: "750e8400-e29b-41d4-a716-446655440003",
+			userID:    userID,
+			want:      []*patternDomain.PatternVersion{},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewPatternRepository()
+
+			versions, err := repo.GetPatternVersionsByPatternID(ctx, tc.patternID, tc.userID)
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			// 期待値との比較
+			if diff := cmp.Diff(tc.want, versions, cmp.AllowUnexported(patternDomain.PatternVersion{}, patternDomain.PatternStep{})); diff != "" {
+				t.Errorf("GetPatternVersionsByPatternID() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	userDomain "github.com/minminseo/recall-setter/domain/user"
+	"github.com/minminseo/recall-setter/infrastructure/db"
+	"github.com/minminseo/recall-setter/infrastructure/db/dbgen"
+)
+
+type pauseRepository struct{}
+
+func NewPauseRepository() userDomain.PauseRepository {
+	return &pauseRepository{}
+}
+
+func (r *pauseRepository) Create(ctx context.Context, p *userDomain.Pause) error {
+	q := db.GetQuery(ctx)
+
+	pgPauseID, err := toUUID(p.ID())
+	if err != nil {
+		return err
+	}
+
+	pgUserID, err := toUUID(p.UserID())
+	if err != nil {
+		return err
+	}
+
+	params := dbgen.CreatePauseParams{
+		ID:        pgPauseID,
+		UserID:    pgUserID,
+		StartDate: pgtype.Date{Time: p.StartDate(), Valid: true},
+		EndDate:   pgtype.Date{Time: p.EndDate(), Valid: true},
+	}
+
+	return q.CreatePause(ctx, params)
+}
+
+func (r *pauseRepository) HasOverlappingPause(ctx context.Context, userID string, startDate time.Time, endDate time.Time) (bool, error) {
+	q := db.GetQuery(ctx)
+
+	pgUserID, err := toUUID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	params := dbgen.HasOverlappingPauseParams{
+		UserID:    pgUserID,
+		EndDate:   pgtype.Date{Time: endDate, Valid: true},
+		StartDate: pgtype.Date{Time: startDate, Valid: true},
+	}
+
+	return q.HasOverlappingPause(ctx, params)
+}
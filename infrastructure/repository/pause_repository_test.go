@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	userDomain "github.com/minminseo/recall-setter/domain/user"
+)
+
+func TestPauseRepository_Create(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	newPause := func(userID string) *userDomain.Pause {
+		startDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+		p, _ := userDomain.NewPause(uuid.New().String(), userID, startDate, time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC), startDate)
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		pause   *userDomain.Pause
+		wantErr bool
+	}{
+		{
+			name:    "既存ユーザーで休止期間作成（正常系）",
+			pause:   newPause("550e8400-e29b-41d4-a716-446655440002"),
+			wantErr: false,
+		},
+		{
+			name:    "存在しないユーザーで休止期間作成（外部キー制約違反）",
+			pause:   newPause(uuid.New().String()),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewPauseRepository()
+
+			err := repo.Create(ctx, tc.pause)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			isOverlapped, err := repo.HasOverlappingPause(ctx, tc.pause.UserID(), tc.pause.StartDate(), tc.pause.EndDate())
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if !isOverlapped {
+				t.Error("作成した休止期間が取得できませんでした")
+			}
+		})
+	}
+}
+
+func TestPauseRepository_HasOverlappingPause(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	PrepareTestDatabase(t)
+	defer CleanupTestDatabase(t)
+
+	tests := []struct {
+		name      string
+		userID    string
+		startDate time.Time
+		endDate   time.Time
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "既存の休止期間と一部が重なる場合",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			startDate: time.Date(2024, 2, 7, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+			want:      true,
+		},
+		{
+			name:      "既存の休止期間の翌日から始まる場合",
+			userID:    "550e8400-e29b-41d4-a716-446655440001",
+			startDate: time.Date(2024, 2, 8, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+			want:      false,
+		},
+		{
+			name:      "他のユーザーの休止期間とは重ならない場合",
+			userID:    "550e8400-e29b-41d4-a716-446655440002",
+			startDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2024, 2, 7, 0, 0, 0, 0, time.UTC),
+			want:      false,
+		},
+		{
+			name:    "無効なユーザーIDの場合",
+			userID:  "invalid-uuid",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := GetTestContext()
+			repo := NewPauseRepository()
+
+			got, err := repo.HasOverlappingPause(ctx, tc.userID, tc.startDate, tc.endDate)
+
+			if tc.wantErr {
+				if err == nil {
+					t.Error("エラーが発生するはずですが、発生しませんでした")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("予期しないエラー: %v", err)
+				return
+			}
+
+			if got != tc.want {
+				t.Errorf("HasOverlappingPause() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -12,3 +12,32 @@ func toUUID(s string) (pgtype.UUID, error) {
 	}
 	return pgtype.UUID{Bytes: u, Valid: true}, nil
 }
+
+func toUUIDs(ss []string) ([]pgtype.UUID, error) {
+	result := make([]pgtype.UUID, len(ss))
+	for i, s := range ss {
+		u, err := toUUID(s)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = u
+	}
+	return result, nil
+}
+
+// 曜日の一覧をSMALLINT[]カラム用に変換する。NOT NULLのカラムなのでnilは空配列にする
+func toWeekdays(weekdays []int) []int16 {
+	result := make([]int16, len(weekdays))
+	for i, d := range weekdays {
+		result[i] = int16(d) // #nosec G115
+	}
+	return result
+}
+
+func fromWeekdays(weekdays []int16) []int {
+	result := make([]int, len(weekdays))
+	for i, d := range weekdays {
+		result[i] = int(d)
+	}
+	return result
+}
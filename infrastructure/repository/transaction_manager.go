@@ -9,6 +9,7 @@ import (
 
 	transactionUsecase "github.com/minminseo/recall-setter/usecase/transaction"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/minminseo/recall-setter/infrastructure/db"
 )
@@ -23,7 +24,14 @@ func NewTransactionManager(pool *pgxpool.Pool) transactionUsecase.ITransactionMa
 
 func (tm *TransactionManager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
 	// 渡されたプールを使ってトランザクション開始
-	tx, err := tm.pool.Begin(ctx)
+	// ctxに接続がある場合（アドバイザリロックを保持している場合など）は、その接続でトランザクションを開始する
+	var tx pgx.Tx
+	var err error
+	if conn := db.GetConn(ctx); conn != nil {
+		tx, err = conn.Begin(ctx)
+	} else {
+		tx, err = tm.pool.Begin(ctx)
+	}
 	if err != nil {
 		return err
 	}
@@ -96,6 +96,9 @@ func (r *userRepository) GetSettingByID(ctx context.Context, userID string) (*us
 		row.Timezone,
 		string(row.ThemeColor),
 		row.Language,
+		int(row.DailyReviewLimit),
+		fromWeekdays(row.ExcludedWeekdays),
+		string(row.OverduePolicy),
 		nil, // VerifiedAt使わない
 	)
 	if err != nil {
@@ -114,12 +117,15 @@ func (r *userRepository) Update(ctx context.Context, u *userDomain.User) error {
 	pgID := pgtype.UUID{Bytes: parsed, Valid: true}
 
 	params := dbgen.UpdateUserParams{
-		EmailSearchKey: u.EmailSearchKey(),
-		Email:          u.EncryptedEmail(),
-		Timezone:       u.Timezone(),
-		ThemeColor:     dbgen.ThemeColorEnum(u.ThemeColor()),
-		Language:       u.Language(),
-		ID:             pgID,
+		EmailSearchKey:   u.EmailSearchKey(),
+		Email:            u.EncryptedEmail(),
+		Timezone:         u.Timezone(),
+		ThemeColor:       dbgen.ThemeColorEnum(u.ThemeColor()),
+		Language:         u.Language(),
+		DailyReviewLimit: int16(u.DailyReviewLimit()), // #nosec G115
+		ExcludedWeekdays: toWeekdays(u.ExcludedWeekdays()),
+		OverduePolicy:    dbgen.OverduePolicyEnum(u.OverduePolicy()),
+		ID:               pgID,
 	}
 
 	return q.UpdateUser(ctx, params)
@@ -0,0 +1,212 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ジョブの実行時刻を決める
+type Schedule interface {
+	// afterより後の最初の実行時刻を返す。実行時刻がない場合はゼロ値を返す
+	Next(after time.Time) time.Time
+}
+
+// cron形式（分 時 日 月 曜日）のスケジュール。時刻はUTCで評価する
+type cronSchedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// 日と曜日の両方を指定した場合は、どちらかに一致すれば実行する（cronと同じ）
+	domStar bool
+	dowStar bool
+}
+
+// 実行時刻にどのタイムゾーンが現地時刻になったかをジョブに渡すスケジュール
+type locationSchedule interface {
+	Schedule
+	// tに現地時刻が実行時刻になったタイムゾーンを返す
+	LocationsAt(t time.Time) []*time.Location
+}
+
+// 実行時刻を探す範囲。2月30日のように実行時刻がない式で探し続けないようにする
+const cronSearchYears = 5
+
+// "*/15 * * * *" のような5つのフィールドの式を解析する
+// 各フィールドは *、数値、範囲（1-5）、間隔（*/15、0-30/10）、カンマ区切りのリストを指定できる。曜日は0と7が日曜日
+func ParseCron(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron式のフィールドは5つ必要です: %q", expr)
+	}
+
+	minute, err := parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("cron式の分が不正です: %w", err)
+	}
+	hour, err := parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("cron式の時が不正です: %w", err)
+	}
+	dom, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("cron式の日が不正です: %w", err)
+	}
+	month, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("cron式の月が不正です: %w", err)
+	}
+	dow, err := parseCronField(fields[4], 0, 7)
+	if err != nil {
+		return nil, fmt.Errorf("cron式の曜日が不正です: %w", err)
+	}
+	// 7の日曜日は0にまとめる
+	if dow&(1<<7) != 0 {
+		dow = dow&^(1<<7) | 1
+	}
+
+	return &cronSchedule{
+		minute:  minute,
+		hour:    hour,
+		dom:     dom,
+		month:   month,
+		dow:     dow,
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			s, err := strconv.Atoi(stepPart)
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("間隔が不正です: %q", part)
+			}
+			step = s
+		}
+
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = min, max
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			s, err1 := strconv.Atoi(from)
+			e, err2 := strconv.Atoi(to)
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("範囲が不正です: %q", part)
+			}
+			start, end = s, e
+		default:
+			s, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("値が不正です: %q", part)
+			}
+			start, end = s, s
+			// "5/10" は5から最大値までの間隔とする
+			if hasStep {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("値は%dから%dの範囲で指定してください: %q", min, max, part)
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (c *cronSchedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// 各タイムゾーンの現地時刻が指定した時刻になった時に実行するスケジュール
+// ユーザーのタイムゾーン毎に朝の通知を送るような、タイムゾーン毎に時刻を揃えるジョブで使う
+type localTimeSchedule struct {
+	hour      int
+	minute    int
+	locations []*time.Location
+}
+
+func NewLocalTimeSchedule(hour, minute int, locations ...*time.Location) (Schedule, error) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return nil, fmt.Errorf("時刻が不正です: %02d:%02d", hour, minute)
+	}
+	if len(locations) == 0 {
+		return nil, errors.New("タイムゾーンを1つ以上指定してください")
+	}
+	return &localTimeSchedule{
+		hour:      hour,
+		minute:    minute,
+		locations: locations,
+	}, nil
+}
+
+func (l *localTimeSchedule) Next(after time.Time) time.Time {
+	var next time.Time
+	for _, loc := range l.locations {
+		local := after.In(loc)
+		t := time.Date(local.Year(), local.Month(), local.Day(), l.hour, l.minute, 0, 0, loc)
+		if !t.After(after) {
+			t = time.Date(local.Year(), local.Month(), local.Day()+1, l.hour, l.minute, 0, 0, loc)
+		}
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next.UTC()
+}
+
+// 複数のタイムゾーンが同じ時刻に現地時刻になる場合は全部返す
+func (l *localTimeSchedule) LocationsAt(t time.Time) []*time.Location {
+	var locations []*time.Location
+	for _, loc := range l.locations {
+		local := t.In(loc)
+		if local.Hour() == l.hour && local.Minute() == l.minute {
+			locations = append(locations, loc)
+		}
+	}
+	return locations
+}
@@ -0,0 +1,204 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	// 2024-01-10は水曜日
+	after := time.Date(2024, 1, 10, 15, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		expr    string
+		after   time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "15分毎（正常系）",
+			expr:  "*/15 * * * *",
+			after: after,
+			want:  time.Date(2024, 1, 10, 15, 15, 0, 0, time.UTC),
+		},
+		{
+			name:  "実行時刻ちょうどの場合は次の実行時刻（正常系）",
+			expr:  "*/15 * * * *",
+			after: time.Date(2024, 1, 10, 15, 15, 0, 0, time.UTC),
+			want:  time.Date(2024, 1, 10, 15, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "毎日3時30分（正常系）",
+			expr:  "30 3 * * *",
+			after: after,
+			want:  time.Date(2024, 1, 11, 3, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "月末を跨ぐ（正常系）",
+			expr:  "0 0 1 * *",
+			after: after,
+			want:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "曜日の範囲（正常系）",
+			expr:  "0 9 * * 6-7",
+			after: after,
+			want:  time.Date(2024, 1, 13, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "日と曜日の両方を指定した場合はどちらかに一致（正常系）",
+			expr:  "0 0 20 * 5",
+			after: after,
+			want:  time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "リストと範囲の間隔（正常系）",
+			expr:  "5,0-30/20 16 * * *",
+			after: after,
+			want:  time.Date(2024, 1, 10, 16, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "閏年の2月29日（正常系）",
+			expr:  "0 0 29 2 *",
+			after: after,
+			want:  time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "実行時刻がない場合はゼロ値（正常系）",
+			expr:  "0 0 30 2 *",
+			after: after,
+			want:  time.Time{},
+		},
+		{
+			name: "UTC以外の時刻はUTCで評価する（正常系）",
+			expr: "0 0 * * *",
+			// 日本時間の1月10日8時はUTCの1月9日23時
+			after: time.Date(2024, 1, 10, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60)),
+			want:  time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "フィールドが足りない（異常系）",
+			expr:    "*/15 * * *",
+			wantErr: true,
+		},
+		{
+			name:    "範囲外の値（異常系）",
+			expr:    "60 * * * *",
+			wantErr: true,
+		},
+		{
+			name:    "間隔が0（異常系）",
+			expr:    "*/0 * * * *",
+			wantErr: true,
+		},
+		{
+			name:    "逆順の範囲（異常系）",
+			expr:    "0 5-1 * * *",
+			wantErr: true,
+		},
+		{
+			name:    "数値以外（異常系）",
+			expr:    "0 0 * * mon",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseCron(tc.expr)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("エラーを期待しましたが、nilでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if got := schedule.Next(tc.after); !got.Equal(tc.want) {
+				t.Errorf("Next() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocalTimeSchedule_Next(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("タイムゾーンの読み込みに失敗: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("タイムゾーンの読み込みに失敗: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		hour      int
+		minute    int
+		locations []*time.Location
+		after     time.Time
+		want      time.Time
+		wantErr   bool
+	}{
+		{
+			name:      "現地時刻の当日（正常系）",
+			hour:      8,
+			locations: []*time.Location{tokyo},
+			after:     time.Date(2024, 1, 9, 22, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 1, 9, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "現地時刻を過ぎている場合は翌日（正常系）",
+			hour:      8,
+			locations: []*time.Location{tokyo},
+			after:     time.Date(2024, 1, 9, 23, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 1, 10, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "複数のタイムゾーンの中で最も早い実行時刻（正常系）",
+			hour:      8,
+			minute:    30,
+			locations: []*time.Location{tokyo, newYork},
+			after:     time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 1, 10, 13, 30, 0, 0, time.UTC),
+		},
+		{
+			name:      "夏時間の期間はオフセットが変わる（正常系）",
+			hour:      8,
+			locations: []*time.Location{newYork},
+			after:     time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "時刻が範囲外（異常系）",
+			hour:      24,
+			locations: []*time.Location{tokyo},
+			wantErr:   true,
+		},
+		{
+			name:    "タイムゾーンがない（異常系）",
+			hour:    8,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := NewLocalTimeSchedule(tc.hour, tc.minute, tc.locations...)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("エラーを期待しましたが、nilでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if got := schedule.Next(tc.after); !got.Equal(tc.want) {
+				t.Errorf("Next() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// 名前を付けて登録する定期実行のジョブ
+type Job struct {
+	Name     string
+	Schedule Schedule
+	// 1回の実行のタイムアウト
+	Timeout time.Duration
+	// 起動時に次の実行時刻を待たずに1回実行する（停止中に実行されなかった分の処理用）
+	RunOnStart bool
+	// tは実行時刻（スケジュール通りに実行した場合はスケジュールの実行時刻）
+	// 現地時刻のスケジュールの場合、tに現地時刻になったタイムゾーンはFiredLocations(ctx)で取得する
+	Run func(ctx context.Context, t time.Time) error
+}
+
+// ジョブ毎の実行結果の集計。Metricsで取得できる。実行が終わる度にログにも出力し、ログ収集ツールで集計する
+type JobMetrics struct {
+	Runs     int64
+	Failures int64
+	Panics   int64
+	// 前回の実行が終わっていないため実行しなかった回数
+	Skips           int64
+	LastDuration    time.Duration
+	LastSucceededAt *time.Time
+}
+
+var (
+	ErrJobNotFound = errors.New("ジョブが登録されていません")
+	ErrJobRunning  = errors.New("前回の実行が終わっていないため実行しません")
+)
+
+type registeredJob struct {
+	job     Job
+	mu      sync.Mutex
+	metrics JobMetrics
+	// 実行中は次の実行時刻になっても実行しない。タイムアウトが実行間隔より長いジョブを同時に実行しないようにする
+	running bool
+}
+
+type firedLocationsKey struct{}
+
+// 現地時刻のスケジュールで実行したジョブの、実行時刻に現地時刻になったタイムゾーンを返す
+// それ以外のスケジュールの場合や、スケジュール外の時刻で実行した場合は空
+func FiredLocations(ctx context.Context) []*time.Location {
+	locations, _ := ctx.Value(firedLocationsKey{}).([]*time.Location)
+	return locations
+}
+
+type Scheduler struct {
+	jobs []*registeredJob
+	wg   sync.WaitGroup
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" {
+		return errors.New("ジョブ名が空です")
+	}
+	if job.Schedule == nil {
+		return fmt.Errorf("ジョブのスケジュールがありません: %s", job.Name)
+	}
+	if job.Timeout <= 0 {
+		return fmt.Errorf("ジョブのタイムアウトは0より大きくしてください: %s", job.Name)
+	}
+	if job.Run == nil {
+		return fmt.Errorf("ジョブの処理がありません: %s", job.Name)
+	}
+	if s.find(job.Name) != nil {
+		return fmt.Errorf("同じ名前のジョブが登録されています: %s", job.Name)
+	}
+	s.jobs = append(s.jobs, &registeredJob{job: job})
+	return nil
+}
+
+// 登録した順に返す
+func (s *Scheduler) JobNames() []string {
+	names := make([]string, len(s.jobs))
+	for i, rj := range s.jobs {
+		names[i] = rj.job.Name
+	}
+	return names
+}
+
+func (s *Scheduler) Metrics(name string) (JobMetrics, error) {
+	rj := s.find(name)
+	if rj == nil {
+		return JobMetrics{}, ErrJobNotFound
+	}
+	rj.mu.Lock()
+	defer rj.mu.Unlock()
+	return rj.metrics, nil
+}
+
+// スケジュールを待たずにジョブを1回実行する
+func (s *Scheduler) RunOnce(ctx context.Context, name string, t time.Time) error {
+	rj := s.find(name)
+	if rj == nil {
+		return ErrJobNotFound
+	}
+	return s.run(ctx, rj, t)
+}
+
+// 登録したジョブをそれぞれのスケジュールで実行する
+// ctxが終了すると新しい実行を始めずに、実行中のジョブが終わるのを待ってから返す
+func (s *Scheduler) Start(ctx context.Context) {
+	slog.Info("バッチスケジューラーを起動しました。", "jobs", s.JobNames())
+	for _, rj := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, rj)
+	}
+	<-ctx.Done()
+	slog.Info("バッチスケジューラーを停止します。実行中のジョブの終了を待ちます。")
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, rj *registeredJob) {
+	defer s.wg.Done()
+
+	if rj.job.RunOnStart {
+		_ = s.run(ctx, rj, time.Now())
+	}
+
+	for {
+		next := rj.job.Schedule.Next(time.Now())
+		if next.IsZero() {
+			slog.Error("次の実行時刻がないため、ジョブのスケジュールを終了します。", "job", rj.job.Name)
+			return
+		}
+		slog.Info("次の実行時刻まで待機します。", "job", rj.job.Name, "次の実行時刻", next.Format(time.RFC3339))
+
+		// 次の実行時刻までゴルーチンを休止（CPUリソースを無駄に消費するビジーループ対策）
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// 実行に時間がかかっても次の実行時刻がずれないように、ゴルーチンで実行する
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			_ = s.run(ctx, rj, next)
+		}()
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, rj *registeredJob, t time.Time) error {
+	rj.mu.Lock()
+	if rj.running {
+		rj.metrics.Skips++
+		skips := rj.metrics.Skips
+		rj.mu.Unlock()
+		slog.Warn("前回の実行が終わっていないため、ジョブを実行しません。", "job", rj.job.Name, "実行時刻", t.Format(time.RFC3339), "スキップ回数", skips)
+		return ErrJobRunning
+	}
+	rj.running = true
+	rj.mu.Unlock()
+
+	slog.Info("ジョブを開始します。", "job", rj.job.Name, "実行時刻", t.Format(time.RFC3339))
+
+	// 停止時に実行中のジョブを途中で止めないように、ctxのキャンセルは引き継がずにジョブ毎のタイムアウトだけを設定する
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rj.job.Timeout)
+	defer cancel()
+	if ls, ok := rj.job.Schedule.(locationSchedule); ok {
+		ctx = context.WithValue(ctx, firedLocationsKey{}, ls.LocationsAt(t))
+	}
+
+	startedAt := time.Now()
+	panicked, err := runRecovered(ctx, rj.job, t)
+	duration := time.Since(startedAt)
+
+	rj.mu.Lock()
+	rj.running = false
+	rj.metrics.Runs++
+	rj.metrics.LastDuration = duration
+	if err != nil {
+		rj.metrics.Failures++
+	} else {
+		finishedAt := time.Now()
+		rj.metrics.LastSucceededAt = &finishedAt
+	}
+	if panicked {
+		rj.metrics.Panics++
+	}
+	metrics := rj.metrics
+	rj.mu.Unlock()
+
+	attrs := []any{
+		"job", rj.job.Name,
+		"実行時間", duration.String(),
+		"実行回数", metrics.Runs,
+		"失敗回数", metrics.Failures,
+		"パニック回数", metrics.Panics,
+		"スキップ回数", metrics.Skips,
+	}
+	if err != nil {
+		slog.Error("ジョブ中にエラーが発生しました。", append(attrs, "error", err)...)
+		return err
+	}
+	slog.Info("ジョブが正常に完了しました。", attrs...)
+	return nil
+}
+
+// ジョブがパニックしてもプロセスを止めずに、エラーとして扱う
+func runRecovered(ctx context.Context, job Job, t time.Time) (panicked bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("ジョブがパニックしました。", "job", job.Name, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("ジョブがパニックしました: %v", r)
+			panicked = true
+		}
+	}()
+	return false, job.Run(ctx, t)
+}
+
+func (s *Scheduler) find(name string) *registeredJob {
+	for _, rj := range s.jobs {
+		if rj.job.Name == name {
+			return rj
+		}
+	}
+	return nil
+}
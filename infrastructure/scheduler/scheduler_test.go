@@ -0,0 +1,322 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// テストでは実行時刻が来ないスケジュール
+type neverSchedule struct{}
+
+func (neverSchedule) Next(after time.Time) time.Time {
+	return time.Time{}
+}
+
+func TestScheduler_Register(t *testing.T) {
+	run := func(ctx context.Context, t time.Time) error { return nil }
+
+	tests := []struct {
+		name    string
+		job     Job
+		wantErr bool
+	}{
+		{
+			name: "ジョブを登録できる（正常系）",
+			job:  Job{Name: "job", Schedule: neverSchedule{}, Timeout: time.Minute, Run: run},
+		},
+		{
+			name:    "ジョブ名が空（異常系）",
+			job:     Job{Schedule: neverSchedule{}, Timeout: time.Minute, Run: run},
+			wantErr: true,
+		},
+		{
+			name:    "スケジュールがない（異常系）",
+			job:     Job{Name: "job", Timeout: time.Minute, Run: run},
+			wantErr: true,
+		},
+		{
+			name:    "タイムアウトがない（異常系）",
+			job:     Job{Name: "job", Schedule: neverSchedule{}, Run: run},
+			wantErr: true,
+		},
+		{
+			name:    "処理がない（異常系）",
+			job:     Job{Name: "job", Schedule: neverSchedule{}, Timeout: time.Minute},
+			wantErr: true,
+		},
+		{
+			name:    "同じ名前のジョブが登録されている（異常系）",
+			job:     Job{Name: "registered", Schedule: neverSchedule{}, Timeout: time.Minute, Run: run},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewScheduler()
+			if err := s.Register(Job{Name: "registered", Schedule: neverSchedule{}, Timeout: time.Minute, Run: run}); err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+
+			err := s.Register(tc.job)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("エラーを期待しましたが、nilでした")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if names := s.JobNames(); len(names) != 2 || names[1] != tc.job.Name {
+				t.Errorf("JobNames() = %v", names)
+			}
+		})
+	}
+}
+
+func TestScheduler_RunOnce(t *testing.T) {
+	runAt := time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		run          func(ctx context.Context, t time.Time) error
+		wantErr      bool
+		wantFailures int64
+		wantPanics   int64
+	}{
+		{
+			name: "ジョブを実行時刻で実行する（正常系）",
+			run: func(ctx context.Context, t time.Time) error {
+				if !t.Equal(runAt) {
+					return errors.New("実行時刻が違います")
+				}
+				if _, ok := ctx.Deadline(); !ok {
+					return errors.New("タイムアウトが設定されていません")
+				}
+				return nil
+			},
+		},
+		{
+			name: "ジョブのエラーを返す（異常系）",
+			run: func(ctx context.Context, t time.Time) error {
+				return errors.New("database connection failed")
+			},
+			wantErr:      true,
+			wantFailures: 1,
+		},
+		{
+			name: "パニックしたジョブはエラーにする（異常系）",
+			run: func(ctx context.Context, t time.Time) error {
+				panic("nil pointer dereference")
+			},
+			wantErr:      true,
+			wantFailures: 1,
+			wantPanics:   1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewScheduler()
+			if err := s.Register(Job{Name: "job", Schedule: neverSchedule{}, Timeout: time.Minute, Run: tc.run}); err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+
+			err := s.RunOnce(context.Background(), "job", runAt)
+			if tc.wantErr && err == nil {
+				t.Fatalf("エラーを期待しましたが、nilでした")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+
+			metrics, err := s.Metrics("job")
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if metrics.Runs != 1 || metrics.Failures != tc.wantFailures || metrics.Panics != tc.wantPanics {
+				t.Errorf("Metrics() = %+v, want runs=1 failures=%d panics=%d", metrics, tc.wantFailures, tc.wantPanics)
+			}
+			if (metrics.LastSucceededAt != nil) == tc.wantErr {
+				t.Errorf("LastSucceededAt = %v", metrics.LastSucceededAt)
+			}
+		})
+	}
+
+	t.Run("登録されていないジョブ（異常系）", func(t *testing.T) {
+		s := NewScheduler()
+		if err := s.RunOnce(context.Background(), "unknown", runAt); !errors.Is(err, ErrJobNotFound) {
+			t.Errorf("RunOnce() error = %v, want %v", err, ErrJobNotFound)
+		}
+	})
+}
+
+func TestScheduler_Start(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := make(chan time.Time, 1)
+
+	s := NewScheduler()
+	err := s.Register(Job{
+		Name:       "job",
+		Schedule:   neverSchedule{},
+		Timeout:    time.Minute,
+		RunOnStart: true,
+		Run: func(ctx context.Context, t time.Time) error {
+			runs <- t
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.Start(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-runs:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("起動時にジョブが実行されませんでした")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("ctxが終了してもスケジューラーが停止しませんでした")
+	}
+}
+
+func TestScheduler_RunSkipsWhileRunning(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	s := NewScheduler()
+	err := s.Register(Job{
+		Name:     "job",
+		Schedule: neverSchedule{},
+		Timeout:  time.Minute,
+		Run: func(ctx context.Context, t time.Time) error {
+			close(started)
+			<-release
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	firstErr := make(chan error, 1)
+	go func() {
+		firstErr <- s.RunOnce(context.Background(), "job", time.Now())
+	}()
+	<-started
+
+	// 前回の実行が終わっていない間は実行しない
+	if err := s.RunOnce(context.Background(), "job", time.Now()); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("RunOnce() error = %v, want %v", err, ErrJobRunning)
+	}
+	metrics, err := s.Metrics("job")
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if metrics.Runs != 0 || metrics.Skips != 1 {
+		t.Errorf("Metrics() = %+v, want runs=0 skips=1", metrics)
+	}
+
+	close(release)
+	if err := <-firstErr; err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	// 前回の実行が終われば実行する
+	started = make(chan struct{})
+	if err := s.RunOnce(context.Background(), "job", time.Now()); err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	metrics, err = s.Metrics("job")
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+	if metrics.Runs != 2 || metrics.Skips != 1 {
+		t.Errorf("Metrics() = %+v, want runs=2 skips=1", metrics)
+	}
+}
+
+func TestScheduler_FiredLocations(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("タイムゾーンの読み込みに失敗: %v", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("タイムゾーンの読み込みに失敗: %v", err)
+	}
+	localTime, err := NewLocalTimeSchedule(8, 0, tokyo, newYork)
+	if err != nil {
+		t.Fatalf("予期しないエラー: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		runAt    time.Time
+		want     []*time.Location
+	}{
+		{
+			name:     "現地時刻になったタイムゾーンを渡す（正常系）",
+			schedule: localTime,
+			runAt:    time.Date(2024, 1, 9, 23, 0, 0, 0, time.UTC),
+			want:     []*time.Location{tokyo},
+		},
+		{
+			name:     "スケジュール外の時刻で実行した場合は渡さない（正常系）",
+			schedule: localTime,
+			runAt:    time.Date(2024, 1, 9, 23, 15, 0, 0, time.UTC),
+			want:     nil,
+		},
+		{
+			name:     "現地時刻のスケジュールでない場合は渡さない（正常系）",
+			schedule: neverSchedule{},
+			runAt:    time.Date(2024, 1, 9, 23, 0, 0, 0, time.UTC),
+			want:     nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []*time.Location
+			s := NewScheduler()
+			err := s.Register(Job{
+				Name:     "job",
+				Schedule: tc.schedule,
+				Timeout:  time.Minute,
+				Run: func(ctx context.Context, t time.Time) error {
+					got = FiredLocations(ctx)
+					return nil
+				},
+			})
+			if err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+
+			if err := s.RunOnce(context.Background(), "job", tc.runAt); err != nil {
+				t.Fatalf("予期しないエラー: %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("FiredLocations() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("FiredLocations()[%d] = %v, want %v", i, got[i], tc.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+ALTER TABLE review_patterns
+    DROP COLUMN IF EXISTS scheduling_algorithm;
+
+DROP TYPE IF EXISTS scheduling_algorithm_enum;
\ No newline at end of file
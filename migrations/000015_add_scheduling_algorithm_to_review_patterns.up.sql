@@ -0,0 +1,4 @@
+CREATE TYPE scheduling_algorithm_enum AS ENUM ('fixed', 'expanding', 'sm2', 'fsrs');
+
+ALTER TABLE review_patterns
+    ADD COLUMN scheduling_algorithm scheduling_algorithm_enum NOT NULL DEFAULT 'fixed';
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE review_dates
+    DROP COLUMN IF EXISTS recall_grade;
+
+DROP TYPE IF EXISTS recall_grade_enum;
\ No newline at end of file
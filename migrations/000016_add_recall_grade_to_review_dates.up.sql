@@ -0,0 +1,4 @@
+CREATE TYPE recall_grade_enum AS ENUM ('again', 'hard', 'good', 'easy');
+
+ALTER TABLE review_dates
+    ADD COLUMN recall_grade recall_grade_enum;
\ No newline at end of file
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS review_item_sm2_states;
+
+-- enumの値は個別に削除できないため、sm2_adaptiveをsm2に戻してから型を作り直す
+UPDATE review_patterns SET scheduling_algorithm = 'sm2' WHERE scheduling_algorithm = 'sm2_adaptive';
+
+ALTER TABLE review_patterns ALTER COLUMN scheduling_algorithm DROP DEFAULT;
+ALTER TYPE scheduling_algorithm_enum RENAME TO scheduling_algorithm_enum_old;
+CREATE TYPE scheduling_algorithm_enum AS ENUM ('fixed', 'expanding', 'sm2', 'fsrs');
+ALTER TABLE review_patterns
+    ALTER COLUMN scheduling_algorithm TYPE scheduling_algorithm_enum
+    USING scheduling_algorithm::text::scheduling_algorithm_enum;
+ALTER TABLE review_patterns ALTER COLUMN scheduling_algorithm SET DEFAULT 'fixed';
+DROP TYPE scheduling_algorithm_enum_old;
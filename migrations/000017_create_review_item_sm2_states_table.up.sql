@@ -0,0 +1,21 @@
+ALTER TYPE scheduling_algorithm_enum ADD VALUE IF NOT EXISTS 'sm2_adaptive';
+
+-- 適応型SM-2方式の復習物ごとの学習状態
+CREATE TABLE review_item_sm2_states (
+    item_id UUID PRIMARY KEY REFERENCES review_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ease_factor DOUBLE PRECISION NOT NULL DEFAULT 2.5 CHECK (ease_factor >= 1.3),
+    repetitions SMALLINT NOT NULL DEFAULT 0 CHECK (repetitions >= 0),
+    interval_days SMALLINT NOT NULL CHECK (interval_days > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+DO $$
+BEGIN
+    DROP TRIGGER IF EXISTS trigger_set_updated_at ON review_item_sm2_states;
+    CREATE TRIGGER trigger_set_updated_at
+        BEFORE UPDATE ON review_item_sm2_states
+        FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+END;
+$$;
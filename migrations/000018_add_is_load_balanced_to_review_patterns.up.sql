@@ -0,0 +1,2 @@
+ALTER TABLE review_patterns
+    ADD COLUMN is_load_balanced BOOLEAN NOT NULL DEFAULT FALSE;
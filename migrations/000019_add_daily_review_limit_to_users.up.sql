@@ -0,0 +1,3 @@
+-- 1日あたりの復習数の上限。0の場合は上限なし
+ALTER TABLE users
+    ADD COLUMN daily_review_limit SMALLINT NOT NULL DEFAULT 0 CHECK (daily_review_limit >= 0);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_user_pauses_user_id;
+
+DROP TABLE IF EXISTS user_pauses;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- 休止期間。期間中はバッチ処理で期限切れの復習日をずらさず、期間の終了後に未完了の復習日を休止日数分まとめてずらす
+-- applied_atは休止日数分のずらしを反映した日時（未反映の場合はNULL）
+CREATE TABLE user_pauses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    applied_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (start_date <= end_date)
+);
+
+CREATE INDEX idx_user_pauses_user_id ON user_pauses (user_id);
@@ -0,0 +1,7 @@
+DROP FUNCTION IF EXISTS next_allowed_date(DATE, SMALLINT[]);
+
+ALTER TABLE review_patterns
+    DROP COLUMN IF EXISTS excluded_weekdays;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS excluded_weekdays;
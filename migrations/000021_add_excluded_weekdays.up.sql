@@ -0,0 +1,28 @@
+-- 復習日を置かない曜日。0が日曜日、6が土曜日（EXTRACT(DOW FROM ...)と同じ）
+-- ユーザー全体の設定とパターン毎の設定の両方を除外する
+ALTER TABLE users
+    ADD COLUMN excluded_weekdays SMALLINT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE review_patterns
+    ADD COLUMN excluded_weekdays SMALLINT[] NOT NULL DEFAULT '{}';
+
+-- 指定日が除外する曜日の場合、次の除外しない曜日の日付を返す
+-- 全ての曜日が除外されている場合は指定日のままとする
+CREATE OR REPLACE FUNCTION next_allowed_date(d DATE, excluded_weekdays SMALLINT[])
+    RETURNS DATE AS $$
+    SELECT
+        COALESCE(
+            (
+                SELECT
+                    d + k
+                FROM
+                    generate_series(0, 6) AS k
+                WHERE
+                    NOT (EXTRACT(DOW FROM d + k)::SMALLINT = ANY(excluded_weekdays))
+                ORDER BY
+                    k
+                LIMIT 1
+            ),
+            d
+        );
+$$ LANGUAGE sql IMMUTABLE;
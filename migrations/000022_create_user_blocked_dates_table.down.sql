@@ -0,0 +1,3 @@
+DROP FUNCTION IF EXISTS next_schedulable_date(DATE, UUID, SMALLINT[]);
+
+DROP TABLE IF EXISTS user_blocked_dates;
\ No newline at end of file
@@ -0,0 +1,47 @@
+-- 復習日を置かない日付（祝日や予定のある日など）。iCalendarファイルの取り込み、または日付の直接指定で登録する
+CREATE TABLE user_blocked_dates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, blocked_date)
+);
+
+-- 指定日が除外する曜日、またはユーザーの復習日を置かない日付の場合、次の復習日を置ける日付を返す
+-- 全ての曜日が除外されている場合は曜日の除外を行わない（next_allowed_dateと同じ）
+-- 366日先まで見つからない場合は指定日のままとする
+CREATE OR REPLACE FUNCTION next_schedulable_date(d DATE, target_user_id UUID, excluded_weekdays SMALLINT[])
+    RETURNS DATE AS $$
+    SELECT
+        COALESCE(
+            (
+                SELECT
+                    d + k
+                FROM
+                    generate_series(0, 366) AS k
+                WHERE
+                    (
+                        NOT (EXTRACT(DOW FROM d + k)::SMALLINT = ANY(excluded_weekdays))
+                    OR
+                        ARRAY[0, 1, 2, 3, 4, 5, 6]::SMALLINT[] <@ excluded_weekdays
+                    )
+                AND
+                    NOT EXISTS (
+                        SELECT
+                            1
+                        FROM
+                            user_blocked_dates ubd
+                        WHERE
+                            ubd.user_id = target_user_id
+                        AND
+                            ubd.blocked_date = d + k
+                    )
+                ORDER BY
+                    k
+                LIMIT 1
+            ),
+            d
+        );
+$$ LANGUAGE sql STABLE;
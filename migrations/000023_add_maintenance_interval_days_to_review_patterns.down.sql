@@ -0,0 +1,2 @@
+ALTER TABLE review_patterns
+    DROP COLUMN IF EXISTS maintenance_interval_days;
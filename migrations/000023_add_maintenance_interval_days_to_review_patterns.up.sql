@@ -0,0 +1,3 @@
+-- 最後のステップの復習日を完了した後に、復習日を繰り返し追加する間隔日数。0の場合は繰り返さず復習物を完了にする
+ALTER TABLE review_patterns
+    ADD COLUMN maintenance_interval_days SMALLINT NOT NULL DEFAULT 0 CHECK (maintenance_interval_days >= 0);
@@ -0,0 +1,4 @@
+ALTER TABLE pattern_steps
+    DROP COLUMN IF EXISTS interval_unit;
+
+DROP TYPE IF EXISTS interval_unit_enum;
\ No newline at end of file
@@ -0,0 +1,5 @@
+CREATE TYPE interval_unit_enum AS ENUM ('day', 'week', 'month');
+
+-- interval_daysをinterval_unitの単位での間隔として扱う。monthは暦の月で数える
+ALTER TABLE pattern_steps
+    ADD COLUMN interval_unit interval_unit_enum NOT NULL DEFAULT 'day';
\ No newline at end of file
@@ -0,0 +1,17 @@
+ALTER TYPE reviewdate_input
+    DROP ATTRIBUTE IF EXISTS scheduled_at;
+
+ALTER TABLE review_dates
+    DROP COLUMN IF EXISTS scheduled_at;
+
+-- enumの値は削除できないため、minuteとhourを除いた型を作り直す（分・時間単位のステップが残っている場合は失敗する）
+ALTER TYPE interval_unit_enum RENAME TO interval_unit_enum_old;
+
+CREATE TYPE interval_unit_enum AS ENUM ('day', 'week', 'month');
+
+ALTER TABLE pattern_steps
+    ALTER COLUMN interval_unit DROP DEFAULT,
+    ALTER COLUMN interval_unit TYPE interval_unit_enum USING interval_unit::text::interval_unit_enum,
+    ALTER COLUMN interval_unit SET DEFAULT 'day';
+
+DROP TYPE interval_unit_enum_old;
\ No newline at end of file
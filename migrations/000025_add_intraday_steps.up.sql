@@ -0,0 +1,9 @@
+ALTER TYPE interval_unit_enum ADD VALUE IF NOT EXISTS 'minute' BEFORE 'day';
+ALTER TYPE interval_unit_enum ADD VALUE IF NOT EXISTS 'hour' BEFORE 'day';
+
+-- 分・時間単位のステップの復習日時。日単位以上のステップの復習日はNULL
+ALTER TABLE review_dates
+    ADD COLUMN scheduled_at TIMESTAMPTZ;
+
+ALTER TYPE reviewdate_input
+    ADD ATTRIBUTE scheduled_at TIMESTAMPTZ;
\ No newline at end of file
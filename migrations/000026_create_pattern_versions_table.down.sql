@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_review_items_pattern_version_id;
+
+ALTER TABLE review_items
+    DROP COLUMN IF EXISTS pattern_version_id;
+
+DROP TABLE IF EXISTS pattern_version_steps;
+
+DROP TABLE IF EXISTS pattern_versions;
@@ -0,0 +1,59 @@
+-- パターンのステップとスケジューリング方式の不変のスナップショット。ステップ（または方式）を変更する度に新しい版を作る
+CREATE TABLE pattern_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pattern_id UUID NOT NULL REFERENCES review_patterns(id) ON DELETE CASCADE,
+    version_number INTEGER NOT NULL CHECK (version_number > 0),
+    scheduling_algorithm scheduling_algorithm_enum NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (pattern_id, version_number)
+);
+
+CREATE TABLE pattern_version_steps (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pattern_version_id UUID NOT NULL REFERENCES pattern_versions(id) ON DELETE CASCADE,
+    step_number SMALLINT NOT NULL,
+    interval_days SMALLINT NOT NULL CHECK (interval_days > 0),
+    interval_unit interval_unit_enum NOT NULL DEFAULT 'day',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (pattern_version_id, step_number)
+);
+
+-- 復習物の復習日を算出したパターンの版。パターンのない復習物はNULL
+ALTER TABLE review_items
+    ADD COLUMN pattern_version_id UUID REFERENCES pattern_versions(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_review_items_pattern_version_id ON review_items (pattern_version_id);
+
+-- 既存のパターンは現在のステップを版1とし、パターンを使う復習物に割り当てる
+INSERT INTO pattern_versions (user_id, pattern_id, version_number, scheduling_algorithm, created_at)
+SELECT
+    user_id,
+    id,
+    1,
+    scheduling_algorithm,
+    edited_at
+FROM
+    review_patterns;
+
+INSERT INTO pattern_version_steps (user_id, pattern_version_id, step_number, interval_days, interval_unit)
+SELECT
+    ps.user_id,
+    pv.id,
+    ps.step_number,
+    ps.interval_days,
+    ps.interval_unit
+FROM
+    pattern_steps ps
+JOIN
+    pattern_versions pv ON pv.pattern_id = ps.pattern_id;
+
+UPDATE
+    review_items
+SET
+    pattern_version_id = pv.id
+FROM
+    pattern_versions pv
+WHERE
+    pv.pattern_id = review_items.pattern_id;
@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS overdue_policy;
+
+DROP TYPE IF EXISTS overdue_policy_enum;
@@ -0,0 +1,9 @@
+-- 期限切れの復習日の扱い
+-- slide_all: 期限切れの復習日を今日にずらし、後続の復習日も同じ日数だけずらす
+-- slide_overdue_only: 期限切れの復習日だけを今日にずらし、後続の復習日はそのままにする
+-- keep_overdue: 期限切れの復習日をずらさず、今日の復習日一覧に期限切れとして表示する
+-- reset_to_first_step: 1ステップ目からやり直す（1ステップ目を今日にして、全ての復習日を未完了に戻す）
+CREATE TYPE overdue_policy_enum AS ENUM ('slide_all', 'slide_overdue_only', 'keep_overdue', 'reset_to_first_step');
+
+ALTER TABLE users
+    ADD COLUMN overdue_policy overdue_policy_enum NOT NULL DEFAULT 'slide_all';
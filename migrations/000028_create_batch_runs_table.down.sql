@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_batch_runs_running_job_name;
+
+DROP TABLE IF EXISTS batch_runs;
+
+DROP TYPE IF EXISTS batch_run_status_enum;
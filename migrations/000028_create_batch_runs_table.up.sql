@@ -0,0 +1,20 @@
+CREATE TYPE batch_run_status_enum AS ENUM ('running', 'succeeded', 'failed');
+
+-- バッチ処理の実行記録。15分毎の実行枠につき1件で、同じ実行枠を2回実行しない
+CREATE TABLE batch_runs (
+    id UUID PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    -- 実行枠の開始時刻（UTCの15分単位）
+    window_start TIMESTAMPTZ NOT NULL,
+    -- 停止中などで実行されなかった実行枠がある場合、その最初の実行枠の開始時刻。この実行でまとめて処理する
+    caught_up_from TIMESTAMPTZ,
+    status batch_run_status_enum NOT NULL,
+    rows_affected BIGINT NOT NULL DEFAULT 0,
+    error_message TEXT,
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    UNIQUE (job_name, window_start)
+);
+
+-- 同じバッチ処理が同時に2つ実行されないようにする（同時に実行すると期限切れの復習日を2重にずらすため）
+CREATE UNIQUE INDEX idx_batch_runs_running_job_name ON batch_runs (job_name) WHERE status = 'running';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_review_dates_user_id_scheduled_date_uncompleted;
+
+DROP INDEX IF EXISTS idx_users_timezone_id;
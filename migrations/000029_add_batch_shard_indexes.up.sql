@@ -0,0 +1,6 @@
+-- バッチ処理で日付けを跨いだタイムゾーンのユーザーを、ID順に上限件数ずつ取得するために使う
+CREATE INDEX idx_users_timezone_id ON users (timezone, id);
+
+-- バッチ処理でユーザー毎に期限切れの未完了の復習日を探すために使う
+CREATE INDEX idx_review_dates_user_id_scheduled_date_uncompleted ON review_dates (user_id, scheduled_date)
+    WHERE is_completed = FALSE AND scheduled_at IS NULL;
@@ -100,6 +100,12 @@ func NewRouter(
 		userGroup.GET("", uc.GetUserSetting)
 		userGroup.PUT("", uc.UpdateSetting)
 		userGroup.PUT("/password", uc.UpdatePassword)
+		userGroup.POST("/pauses", uc.CreatePause)
+		userGroup.GET("/blocked-dates", uc.GetBlockedDates)
+		userGroup.POST("/blocked-dates", uc.CreateBlockedDates)
+		userGroup.POST("/blocked-dates/import", uc.ImportBlockedDates)
+		userGroup.PUT("/blocked-dates/:id", uc.UpdateBlockedDate)
+		userGroup.DELETE("/blocked-dates/:id", uc.DeleteBlockedDate)
 	}
 
 	// カテゴリー系
@@ -130,6 +136,20 @@ func NewRouter(
 		patternGroup.GET("", pc.GetPatterns)
 		patternGroup.PUT("/:id", pc.UpdatePattern)
 		patternGroup.DELETE("/:id", pc.DeletePattern)
+		patternGroup.POST("/preview", pc.PreviewSchedule)
+		patternGroup.POST("/:id/preview", pc.PreviewSchedule)
+		patternGroup.GET("/:id/versions", pc.GetPatternVersions)
+		patternGroup.GET("/:id/usage", pc.GetPatternUsage)
+		patternGroup.POST("/from-preset/:key", pc.CreatePatternFromPreset)
+		patternGroup.GET("/export", pc.ExportPatterns)
+		patternGroup.POST("/import", pc.ImportPatterns)
+	}
+
+	// 組み込みの復習パターンのプリセット
+	patternPresetGroup := e.Group("/pattern-presets")
+	patternPresetGroup.Use(authMiddleware)
+	{
+		patternPresetGroup.GET("", pc.GetPatternPresets)
 	}
 
 	// 復習打つ形
@@ -144,6 +164,8 @@ func NewRouter(
 		itemGroup.GET("/:box_id", ic.GetAllUnFinishedItemsByBoxID)
 		itemGroup.GET("/unclassified/:category_id", ic.GetAllUnFinishedUnclassifiedItemsByCategoryID)
 		itemGroup.GET("/today", ic.GetAllDailyReviewDates)
+		// 分・時間単位のステップのうち、復習日時を過ぎた復習日一覧
+		itemGroup.GET("/due-now", ic.GetDueNowReviewDates)
 
 		// 完了済み復習物一覧取得系
 		itemGroup.GET("/finished/unclassified", ic.GetUnclassfiedFinishedItemsByUserID)
@@ -186,6 +208,9 @@ func NewRouter(
 
 		// 今日の全復習日数を取得
 		summaryGroup.GET("/daily-reviews/count", ic.CountAllDailyReviewDates)
+
+		// 指定日から指定日数分の復習日数の予測（ボックス毎、カテゴリー毎の未分類、ホーム画面の未分類の内訳付き）
+		summaryGroup.GET("/forecast", ic.GetReviewForecast)
 	}
 
 	return e
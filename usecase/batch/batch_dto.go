@@ -0,0 +1,18 @@
+package batch
+
+import "time"
+
+type DryRunUpdateOverdueScheduledDatesOutput struct {
+	RowsAffected int64
+	Changes      []*ReviewDateChangeOutput
+}
+
+type ReviewDateChangeOutput struct {
+	UserID       string
+	ItemID       string
+	ReviewDateID string
+	StepNumber   int
+	OldDate      time.Time
+	NewDate      time.Time
+	Days         int
+}
@@ -2,32 +2,366 @@ package batch
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"time"
 
+	"github.com/google/uuid"
+	BatchDomain "github.com/minminseo/recall-setter/domain/batch"
+	ItemDomain "github.com/minminseo/recall-setter/domain/item"
+	UserDomain "github.com/minminseo/recall-setter/domain/user"
 	"github.com/minminseo/recall-setter/infrastructure/repository"
+	"github.com/minminseo/recall-setter/usecase/lock"
+	"github.com/minminseo/recall-setter/usecase/transaction"
 )
 
 type IBatchUsecase interface {
-	ExecuteUpdateOverdueScheduledDates(ctx context.Context) error
+	// nowが属する実行枠で期限切れの復習日を更新し、実行記録を残す。同じ実行枠では2回実行しない
+	// 複数のプロセスで動かしている場合は、ロックを取得できたプロセスだけが実行する
+	RunUpdateOverdueScheduledDates(ctx context.Context, now time.Time) error
+	// nowを基準にtimezonesのユーザーの期限切れの復習日を更新し、更新した行数を返す
+	// ユーザーを上限件数ずつ別のトランザクションで更新するため、エラーの場合もそれまでにコミットした行数を返す
+	ExecuteUpdateOverdueScheduledDates(ctx context.Context, now time.Time, timezones []string) (int64, error)
+	// nowを基準に全てのユーザーの期限切れの復習日を更新した場合にずれる復習日を返す。更新はロールバックし、実行記録も残さない
+	// 実際のバッチ処理と同じロックを取得し、取得できない場合はErrBatchRunningを返す
+	DryRunUpdateOverdueScheduledDates(ctx context.Context, now time.Time) (*DryRunUpdateOverdueScheduledDatesOutput, error)
 }
 
 type batchUsecase struct {
-	batchRepo repository.IBatchRepository
+	batchRepo          repository.IBatchRepository
+	transactionManager transaction.ITransactionManager
+	locker             lock.ILocker
 }
 
-func NewBatchUsecase(batchRepo repository.IBatchRepository) IBatchUsecase {
-	return &batchUsecase{batchRepo: batchRepo}
+func NewBatchUsecase(batchRepo repository.IBatchRepository, transactionManager transaction.ITransactionManager, locker lock.ILocker) IBatchUsecase {
+	return &batchUsecase{
+		batchRepo:          batchRepo,
+		transactionManager: transactionManager,
+		locker:             locker,
+	}
+}
+
+func (u *batchUsecase) RunUpdateOverdueScheduledDates(ctx context.Context, now time.Time) error {
+	jobName := BatchDomain.JobNameUpdateOverdueScheduledDates
+	now = now.UTC()
+
+	acquired, err := u.locker.RunWithLock(ctx, jobName, func(ctx context.Context) error {
+		return u.runUpdateOverdueScheduledDates(ctx, jobName, now)
+	})
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		slog.Info("他のプロセスがバッチ処理を実行しているため実行しません。", "実行枠", BatchDomain.WindowStart(now).Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (u *batchUsecase) runUpdateOverdueScheduledDates(ctx context.Context, jobName string, now time.Time) error {
+
+	// 実行中にプロセスが停止した実行記録が残っていると以降の実行記録を作成できないため、先に失敗にする
+	err := u.batchRepo.FailStaleBatchRuns(ctx, jobName, now.Add(-BatchDomain.StaleRunTimeout), now)
+	if err != nil {
+		slog.Error("実行中のまま残っている実行記録の更新に失敗しました。", "error", err)
+		return err
+	}
+
+	lastWindowStart, err := u.batchRepo.GetLatestBatchRunWindowStart(ctx, jobName)
+	if err != nil {
+		slog.Error("前回の実行記録の取得に失敗しました。", "error", err)
+		return err
+	}
+
+	run, err := BatchDomain.NewBatchRun(uuid.NewString(), jobName, now, lastWindowStart, now)
+	if err != nil {
+		return err
+	}
+
+	created, err := u.batchRepo.CreateBatchRun(ctx, run)
+	if err != nil {
+		slog.Error("実行記録の作成に失敗しました。", "error", err)
+		return err
+	}
+	if !created {
+		slog.Info("同じ実行枠で実行済み、または実行中のバッチ処理があるため実行しません。", "実行枠", run.WindowStart().Format(time.RFC3339))
+		return nil
+	}
+
+	// 各ユーザーの今日を基準に期限切れの復習日をまとめて更新するため、実行されなかった実行枠の分もこの実行で処理される
+	if run.MissedWindows() > 0 {
+		slog.Warn("実行されなかった実行枠があります。この実行でまとめて処理します。",
+			"最初の実行枠", run.CaughtUpFrom().Format(time.RFC3339),
+			"実行枠数", run.MissedWindows(),
+		)
+	}
+
+	rowsAffected, err := u.updateOverdueScheduledDatesOfCrossedTimezones(ctx, jobName, now)
+	if err != nil {
+		run.Fail(err, rowsAffected, time.Now().UTC())
+	} else {
+		run.Succeed(rowsAffected, time.Now().UTC())
+	}
+
+	// タイムアウトでctxが終了していても実行記録は残す
+	finishErr := u.batchRepo.FinishBatchRun(context.WithoutCancel(ctx), run)
+	if finishErr != nil {
+		slog.Error("実行記録の更新に失敗しました。", "error", finishErr)
+		if err == nil {
+			return finishErr
+		}
+	}
+	return err
 }
 
-func (u *batchUsecase) ExecuteUpdateOverdueScheduledDates(ctx context.Context) error {
+// 期限切れの復習日は現地の日付けが変わった時にだけ増えるため、前回の成功した実行から日付けを跨いだタイムゾーンのユーザーだけを更新する
+// 失敗した実行で更新できなかったユーザーも、次の実行でまとめて更新する
+// 日付けを跨いだ後の日中に生じた更新対象（過去の日付けへの復習日の変更、期限切れの扱いやタイムゾーンの変更、過去の日付けで終わる休止期間の登録など）は、
+// そのユーザーのタイムゾーンが次に日付けを跨ぐ時（最大24時間後）にまとめて更新する。日中の変更のために全てのユーザーを毎回処理しないよう、この遅れは意図したもの
+func (u *batchUsecase) updateOverdueScheduledDatesOfCrossedTimezones(ctx context.Context, jobName string, now time.Time) (int64, error) {
+	lastSucceededWindowStart, err := u.batchRepo.GetLatestSucceededBatchRunWindowStart(ctx, jobName)
+	if err != nil {
+		slog.Error("前回の成功した実行記録の取得に失敗しました。", "error", err)
+		return 0, err
+	}
+	timezones, err := u.batchRepo.GetUserTimezones(ctx)
+	if err != nil {
+		slog.Error("ユーザーのタイムゾーンの取得に失敗しました。", "error", err)
+		return 0, err
+	}
+
+	crossed := BatchDomain.TimezonesCrossedMidnight(timezones, lastSucceededWindowStart, now)
+	if len(crossed) == 0 {
+		slog.Info("日付けを跨いだタイムゾーンがないため、期限切れ復習日の更新処理を実行しません。")
+		return 0, nil
+	}
+	slog.Info("日付けを跨いだタイムゾーンのユーザーを更新します。", "タイムゾーン", crossed)
+	return u.ExecuteUpdateOverdueScheduledDates(ctx, now, crossed)
+}
+
+func (u *batchUsecase) ExecuteUpdateOverdueScheduledDates(ctx context.Context, now time.Time, timezones []string) (int64, error) {
 	slog.Info("期限切れ復習日の更新処理を開始します。")
 
-	err := u.batchRepo.ExecuteUpdateOverdueScheduledDates(ctx)
+	var rowsAffected int64
+	err := u.forEachUserChunk(ctx, timezones, func(userIDs []string) error {
+		// 途中で失敗した時にユーザーの一部の更新だけが残ると、再実行で復習日を2重にずらすため、ユーザー毎の更新は1つのトランザクションで行う
+		// 上限件数ずつトランザクションを分けて、復習日の行ロックを持つ時間を短く抑える
+		var rows int64
+		err := u.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			rows, err = u.updateOverdueScheduledDatesByUserIDs(ctx, now, userIDs)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		rowsAffected += rows
+		return nil
+	})
+	if err != nil {
+		return rowsAffected, err
+	}
+
+	slog.Info("未完了復習日の更新処理が正常に完了しました。", "更新件数", rowsAffected)
+	return rowsAffected, nil
+}
+
+// timezonesのユーザーをID順に上限件数ずつfnに渡す
+func (u *batchUsecase) forEachUserChunk(ctx context.Context, timezones []string, fn func(userIDs []string) error) error {
+	afterUserID := ""
+	for {
+		userIDs, err := u.batchRepo.GetUserIDsByTimezones(ctx, timezones, afterUserID, BatchDomain.UserChunkSize)
+		if err != nil {
+			slog.Error("更新するユーザーの取得に失敗しました。", "error", err)
+			return err
+		}
+		if len(userIDs) == 0 {
+			return nil
+		}
+		if err := fn(userIDs); err != nil {
+			return err
+		}
+		if len(userIDs) < BatchDomain.UserChunkSize {
+			return nil
+		}
+		afterUserID = userIDs[len(userIDs)-1]
+	}
+}
+
+func (u *batchUsecase) updateOverdueScheduledDatesByUserIDs(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	var rowsAffected int64
+
+	// 休止期間中に期限切れになった復習日を今日にまとめないように、期限切れの処理より先に終了した休止期間を反映する
+	rows, err := u.batchRepo.ExecuteApplyEndedPauses(ctx, now, userIDs)
+	if err != nil {
+		slog.Error("終了した休止期間の反映に失敗しました。", "error", err)
+		return 0, err
+	}
+	rowsAffected += rows
+
+	// 期限切れの復習日の扱いはユーザー毎に選べるため、扱い毎に更新する（keep_overdueのユーザーの期限切れの復習日は更新しない）
+	rows, err = u.batchRepo.ExecuteUpdateOverdueScheduledDates(ctx, now, userIDs)
 	if err != nil {
 		slog.Error("未完了復習日の更新に失敗しました。", "error", err)
+		return 0, err
+	}
+	rowsAffected += rows
+
+	rows, err = u.batchRepo.ExecuteMoveOverdueScheduledDatesToToday(ctx, now, userIDs)
+	if err != nil {
+		slog.Error("期限切れの復習日だけをずらすユーザーの未完了復習日の更新に失敗しました。", "error", err)
+		return 0, err
+	}
+	rowsAffected += rows
+
+	rows, err = u.batchRepo.ExecuteResetOverdueItemsToFirstStep(ctx, now, userIDs)
+	if err != nil {
+		slog.Error("1ステップ目からやり直すユーザーの未完了復習日の更新に失敗しました。", "error", err)
+		return 0, err
+	}
+	rowsAffected += rows
+
+	rows, err = u.deferOverdueItemsWithinDailyLimit(ctx, now, userIDs)
+	if err != nil {
+		slog.Error("1日の復習数の上限があるユーザーの未完了復習日の繰り越しに失敗しました。", "error", err)
+		return 0, err
+	}
+	rowsAffected += rows
+
+	return rowsAffected, nil
+}
+
+// ドライランの更新をロールバックするために返すエラー
+var errDryRunRollback = errors.New("ドライランのためロールバックします")
+
+// 他のプロセスがバッチ処理を実行しているため、ドライランを実行できない場合のエラー
+var ErrBatchRunning = errors.New("他のプロセスがバッチ処理を実行しているため、ドライランを実行できません")
+
+func (u *batchUsecase) DryRunUpdateOverdueScheduledDates(ctx context.Context, now time.Time) (*DryRunUpdateOverdueScheduledDatesOutput, error) {
+	slog.Info("ドライランで期限切れ復習日の更新処理を実行します。", "基準時刻", now.Format(time.RFC3339))
+
+	// 実際のバッチ処理と同時に実行すると、ロールバックするまで同じ復習日の行ロックを取り合うため、同じロックを取得して実行する
+	var output *DryRunUpdateOverdueScheduledDatesOutput
+	acquired, err := u.locker.RunWithLock(ctx, BatchDomain.JobNameUpdateOverdueScheduledDates, func(ctx context.Context) error {
+		var err error
+		output, err = u.dryRunUpdateOverdueScheduledDates(ctx, now)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	if !acquired {
+		return nil, ErrBatchRunning
+	}
+	return output, nil
+}
 
-	slog.Info("未完了復習日の更新処理が正常に完了しました。")
-	return nil
+func (u *batchUsecase) dryRunUpdateOverdueScheduledDates(ctx context.Context, now time.Time) (*DryRunUpdateOverdueScheduledDatesOutput, error) {
+	// 基準時刻を指定して実行するため、前回の実行から日付けを跨いだかは見ずに全てのタイムゾーンのユーザーを対象にする
+	timezones, err := u.batchRepo.GetUserTimezones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &DryRunUpdateOverdueScheduledDatesOutput{
+		Changes: []*ReviewDateChangeOutput{},
+	}
+	// 実際のバッチ処理と同じく上限件数ずつトランザクションを分け、チャンク毎にロールバックして行ロックを持つ時間を短く抑える
+	// 更新は全てロールバックするため、チャンク毎の更新前後の復習日は他のチャンクの更新の影響を受けない
+	err = u.forEachUserChunk(ctx, timezones, func(userIDs []string) error {
+		err := u.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+			before, err := u.batchRepo.GetReviewDatesToUpdateByBatch(ctx, now, userIDs)
+			if err != nil {
+				return err
+			}
+
+			rows, err := u.updateOverdueScheduledDatesByUserIDs(ctx, now, userIDs)
+			if err != nil {
+				return err
+			}
+
+			reviewDateIDs := make([]string, len(before))
+			for i, b := range before {
+				reviewDateIDs[i] = b.ReviewDateID
+			}
+			after, err := u.batchRepo.GetReviewDatesByIDs(ctx, reviewDateIDs)
+			if err != nil {
+				return err
+			}
+
+			output.RowsAffected += rows
+			for _, c := range BatchDomain.DiffReviewDates(before, after) {
+				output.Changes = append(output.Changes, &ReviewDateChangeOutput{
+					UserID:       c.UserID,
+					ItemID:       c.ItemID,
+					ReviewDateID: c.ReviewDateID,
+					StepNumber:   c.StepNumber,
+					OldDate:      c.OldDate,
+					NewDate:      c.NewDate,
+					Days:         c.Days,
+				})
+			}
+			return errDryRunRollback
+		})
+		if err != nil && !errors.Is(err, errDryRunRollback) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// 1日あたりの復習数の上限があるユーザーは、期限切れの復習物を今日にまとめず、上限を超える分を空きのある次の日以降に繰り越す
+// 重みが大きいパターンの復習物から先に今日に近い日を割り当てる
+func (u *batchUsecase) deferOverdueItemsWithinDailyLimit(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	overdueItems, err := u.batchRepo.GetOverdueItemsWithDailyReviewLimit(ctx, now, userIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	// ユーザー毎にまとめる（取得結果はユーザーID順）
+	var overdueUserIDs []string
+	itemsByUserID := make(map[string][]*ItemDomain.OverdueItem)
+	for _, oi := range overdueItems {
+		if _, ok := itemsByUserID[oi.UserID]; !ok {
+			overdueUserIDs = append(overdueUserIDs, oi.UserID)
+		}
+		itemsByUserID[oi.UserID] = append(itemsByUserID[oi.UserID], oi)
+	}
+
+	var rowsAffected int64
+	for _, userID := range overdueUserIDs {
+		items := itemsByUserID[userID]
+		today := items[0].Today
+		dailyCounts, err := u.batchRepo.CountScheduledDatesGroupedByDateByUserID(ctx, userID, today, today.AddDate(0, 0, ItemDomain.DailyReviewLimitSearchDays))
+		if err != nil {
+			return 0, err
+		}
+		blockedDates, err := u.batchRepo.GetBlockedDatesByUserID(ctx, userID)
+		if err != nil {
+			return 0, err
+		}
+		newDates := ItemDomain.AssignOverdueItemsWithinDailyLimit(items, dailyCounts, items[0].DailyReviewLimit, ItemDomain.NewBlockedDates(blockedDates), today)
+		for _, oi := range items {
+			var rows int64
+			switch oi.OverduePolicy {
+			// 期限切れの復習日だけをずらすユーザーは、後続の復習日を繰り越さない
+			case UserDomain.OverduePolicySlideOverdueOnly:
+				rows, err = u.batchRepo.MoveOverdueScheduledDatesByItemID(ctx, oi.ItemID, today, newDates[oi.ItemID], oi.ExcludedWeekdays)
+			// 1ステップ目からやり直すユーザーは、1ステップ目が繰り越し先の日付になるようにやり直す
+			case UserDomain.OverduePolicyResetToFirstStep:
+				rows, err = u.batchRepo.ResetScheduledDatesToFirstStepByItemID(ctx, oi.ItemID, newDates[oi.ItemID], oi.ExcludedWeekdays)
+			default:
+				rows, err = u.batchRepo.SlideScheduledDatesByItemID(ctx, oi.ItemID, oi.OldDate, newDates[oi.ItemID], oi.ExcludedWeekdays)
+			}
+			if err != nil {
+				return 0, err
+			}
+			rowsAffected += rows
+		}
+		slog.Info("1日の復習数の上限に合わせて期限切れの復習日を繰り越しました。", "user_id", userID, "件数", len(items))
+	}
+	return rowsAffected, nil
 }
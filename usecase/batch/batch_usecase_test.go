@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	BatchDomain "github.com/minminseo/recall-setter/domain/batch"
+	ItemDomain "github.com/minminseo/recall-setter/domain/item"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -18,11 +21,161 @@ type MockBatchRepository struct {
 	mock.Mock
 }
 
-func (m *MockBatchRepository) ExecuteUpdateOverdueScheduledDates(ctx context.Context) error {
+func (m *MockBatchRepository) GetUserTimezones(ctx context.Context) ([]string, error) {
 	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockBatchRepository) GetUserIDsByTimezones(ctx context.Context, timezones []string, afterUserID string, limit int) ([]string, error) {
+	args := m.Called(ctx, timezones, afterUserID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockBatchRepository) ExecuteApplyEndedPauses(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	args := m.Called(ctx, now, userIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBatchRepository) ExecuteUpdateOverdueScheduledDates(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	args := m.Called(ctx, now, userIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBatchRepository) ExecuteMoveOverdueScheduledDatesToToday(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	args := m.Called(ctx, now, userIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBatchRepository) ExecuteResetOverdueItemsToFirstStep(ctx context.Context, now time.Time, userIDs []string) (int64, error) {
+	args := m.Called(ctx, now, userIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBatchRepository) GetOverdueItemsWithDailyReviewLimit(ctx context.Context, now time.Time, userIDs []string) ([]*ItemDomain.OverdueItem, error) {
+	args := m.Called(ctx, now, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ItemDomain.OverdueItem), args.Error(1)
+}
+
+func (m *MockBatchRepository) CountScheduledDatesGroupedByDateByUserID(ctx context.Context, userID string, fromDate time.Time, toDate time.Time) ([]*ItemDomain.DailyScheduledCount, error) {
+	args := m.Called(ctx, userID, fromDate, toDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*ItemDomain.DailyScheduledCount), args.Error(1)
+}
+
+func (m *MockBatchRepository) GetBlockedDatesByUserID(ctx context.Context, userID string) ([]time.Time, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+func (m *MockBatchRepository) SlideScheduledDatesByItemID(ctx context.Context, itemID string, oldDate time.Time, newDate time.Time, excludedWeekdays ItemDomain.ExcludedWeekdays) (int64, error) {
+	args := m.Called(ctx, itemID, oldDate, newDate, excludedWeekdays)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBatchRepository) MoveOverdueScheduledDatesByItemID(ctx context.Context, itemID string, today time.Time, newDate time.Time, excludedWeekdays ItemDomain.ExcludedWeekdays) (int64, error) {
+	args := m.Called(ctx, itemID, today, newDate, excludedWeekdays)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBatchRepository) ResetScheduledDatesToFirstStepByItemID(ctx context.Context, itemID string, newDate time.Time, excludedWeekdays ItemDomain.ExcludedWeekdays) (int64, error) {
+	args := m.Called(ctx, itemID, newDate, excludedWeekdays)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBatchRepository) GetReviewDatesToUpdateByBatch(ctx context.Context, now time.Time, userIDs []string) ([]*BatchDomain.ReviewDateSnapshot, error) {
+	args := m.Called(ctx, now, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*BatchDomain.ReviewDateSnapshot), args.Error(1)
+}
+
+func (m *MockBatchRepository) GetReviewDatesByIDs(ctx context.Context, reviewDateIDs []string) ([]*BatchDomain.ReviewDateSnapshot, error) {
+	args := m.Called(ctx, reviewDateIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*BatchDomain.ReviewDateSnapshot), args.Error(1)
+}
+
+func (m *MockBatchRepository) CreateBatchRun(ctx context.Context, run *BatchDomain.BatchRun) (bool, error) {
+	args := m.Called(ctx, run)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBatchRepository) FinishBatchRun(ctx context.Context, run *BatchDomain.BatchRun) error {
+	args := m.Called(ctx, run)
 	return args.Error(0)
 }
 
+func (m *MockBatchRepository) FailStaleBatchRuns(ctx context.Context, jobName string, startedBefore time.Time, finishedAt time.Time) error {
+	args := m.Called(ctx, jobName, startedBefore, finishedAt)
+	return args.Error(0)
+}
+
+func (m *MockBatchRepository) GetLatestBatchRunWindowStart(ctx context.Context, jobName string) (*time.Time, error) {
+	args := m.Called(ctx, jobName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockBatchRepository) GetLatestSucceededBatchRunWindowStart(ctx context.Context, jobName string) (*time.Time, error) {
+	args := m.Called(ctx, jobName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+// バッチ処理の基準時刻（日本時間の0時）
+var testNow = time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC)
+
+// ユーザーが設定しているタイムゾーンと、更新するユーザー（1回で取得できる件数）
+var (
+	testTimezones = []string{"Asia/Tokyo", "UTC"}
+	testUserIDs   = []string{"user1", "user2"}
+)
+
+// トランザクションを張らずに渡された処理をそのまま実行する。処理が返したエラー（ロールバックするかどうか）と実行回数を記録する
+type fakeTransactionManager struct {
+	fnErr error
+	calls int
+}
+
+func (f *fakeTransactionManager) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	f.fnErr = fn(ctx)
+	return f.fnErr
+}
+
+// ロックを取得できた場合は渡された処理をそのまま実行する。heldByOtherの場合は他のプロセスがロックを保持している
+type fakeLocker struct {
+	heldByOther bool
+}
+
+func (f *fakeLocker) RunWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	if f.heldByOther {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
 func TestNewBatchUsecase(t *testing.T) {
 	tests := []struct {
 		name string
@@ -47,9 +200,9 @@ func TestNewBatchUsecase(t *testing.T) {
 
 			var usecase IBatchUsecase
 			if tt.repo == nil {
-				usecase = NewBatchUsecase(nil)
+				usecase = NewBatchUsecase(nil, nil, nil)
 			} else {
-				usecase = NewBatchUsecase(tt.repo.(*MockBatchRepository))
+				usecase = NewBatchUsecase(tt.repo.(*MockBatchRepository), &fakeTransactionManager{}, &fakeLocker{})
 			}
 
 			if tt.want {
@@ -72,7 +225,12 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates(t *testing.T) {
 		{
 			name: "リポジトリが正常に実行される場合",
 			setupMock: func(m *MockBatchRepository, ctx context.Context) {
-				m.On("ExecuteUpdateOverdueScheduledDates", ctx).Return(nil)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return([]*ItemDomain.OverdueItem{}, nil)
 			},
 			setupCtx: func() context.Context {
 				return context.Background()
@@ -82,7 +240,181 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates(t *testing.T) {
 		{
 			name: "リポジトリでエラーが発生する場合",
 			setupMock: func(m *MockBatchRepository, ctx context.Context) {
-				m.On("ExecuteUpdateOverdueScheduledDates", ctx).Return(errors.New("database connection failed"))
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), errors.New("database connection failed"))
+			},
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+			wantErr: true,
+		},
+		{
+			name: "1日の復習数の上限があるユーザーの期限切れの復習日を重みが大きい順に繰り越す場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				today := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+				overdueItems := []*ItemDomain.OverdueItem{
+					{UserID: "user1", ItemID: "item-light", OldDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Today: today, DailyReviewLimit: 1, TargetWeight: "light"},
+					{UserID: "user1", ItemID: "item-heavy", OldDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), Today: today, DailyReviewLimit: 1, TargetWeight: "heavy"},
+				}
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return(overdueItems, nil)
+				m.On("CountScheduledDatesGroupedByDateByUserID", ctx, "user1", today, today.AddDate(0, 0, ItemDomain.DailyReviewLimitSearchDays)).
+					Return([]*ItemDomain.DailyScheduledCount{}, nil)
+				m.On("GetBlockedDatesByUserID", ctx, "user1").Return([]time.Time{}, nil)
+				m.On("SlideScheduledDatesByItemID", ctx, "item-light", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), today.AddDate(0, 0, 1), ItemDomain.ExcludedWeekdays(0)).Return(int64(0), nil)
+				m.On("SlideScheduledDatesByItemID", ctx, "item-heavy", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), today, ItemDomain.ExcludedWeekdays(0)).Return(int64(0), nil)
+			},
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+			wantErr: false,
+		},
+		{
+			name: "1日の復習数の上限があるユーザーの期限切れの復習日は除外する曜日を避けて繰り越す場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				// 2024-01-10は水曜日
+				today := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+				excludedWeekdays := ItemDomain.NewExcludedWeekdays([]int{int(time.Wednesday)})
+				overdueItems := []*ItemDomain.OverdueItem{
+					{UserID: "user1", ItemID: "item-1", OldDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), Today: today, DailyReviewLimit: 1, TargetWeight: "normal", ExcludedWeekdays: excludedWeekdays},
+				}
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return(overdueItems, nil)
+				m.On("CountScheduledDatesGroupedByDateByUserID", ctx, "user1", today, today.AddDate(0, 0, ItemDomain.DailyReviewLimitSearchDays)).
+					Return([]*ItemDomain.DailyScheduledCount{}, nil)
+				m.On("GetBlockedDatesByUserID", ctx, "user1").Return([]time.Time{}, nil)
+				m.On("SlideScheduledDatesByItemID", ctx, "item-1", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), today.AddDate(0, 0, 1), excludedWeekdays).Return(int64(0), nil)
+			},
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+			wantErr: false,
+		},
+		{
+			name: "1日の復習数の上限があるユーザーの期限切れの復習日は復習日を置かない日付を避けて繰り越す場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				today := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+				overdueItems := []*ItemDomain.OverdueItem{
+					{UserID: "user1", ItemID: "item-1", OldDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), Today: today, DailyReviewLimit: 1, TargetWeight: "normal"},
+				}
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return(overdueItems, nil)
+				m.On("CountScheduledDatesGroupedByDateByUserID", ctx, "user1", today, today.AddDate(0, 0, ItemDomain.DailyReviewLimitSearchDays)).
+					Return([]*ItemDomain.DailyScheduledCount{}, nil)
+				m.On("GetBlockedDatesByUserID", ctx, "user1").Return([]time.Time{today, today.AddDate(0, 0, 1)}, nil)
+				m.On("SlideScheduledDatesByItemID", ctx, "item-1", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), today.AddDate(0, 0, 2), ItemDomain.ExcludedWeekdays(0)).Return(int64(0), nil)
+			},
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+			wantErr: false,
+		},
+		{
+			name: "期限切れの復習日だけをずらすユーザーの期限切れの復習日は後続の復習日を繰り越さない場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				today := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+				overdueItems := []*ItemDomain.OverdueItem{
+					{UserID: "user1", ItemID: "item-1", OldDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), Today: today, DailyReviewLimit: 1, OverduePolicy: "slide_overdue_only", TargetWeight: "normal"},
+				}
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return(overdueItems, nil)
+				m.On("CountScheduledDatesGroupedByDateByUserID", ctx, "user1", today, today.AddDate(0, 0, ItemDomain.DailyReviewLimitSearchDays)).
+					Return([]*ItemDomain.DailyScheduledCount{{ScheduledDate: today, Count: 1}}, nil)
+				m.On("GetBlockedDatesByUserID", ctx, "user1").Return([]time.Time{}, nil)
+				m.On("MoveOverdueScheduledDatesByItemID", ctx, "item-1", today, today.AddDate(0, 0, 1), ItemDomain.ExcludedWeekdays(0)).Return(int64(0), nil)
+			},
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+			wantErr: false,
+		},
+		{
+			name: "1ステップ目からやり直すユーザーの期限切れの復習物は1ステップ目が繰り越し先の日付になるようにやり直す場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				today := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+				overdueItems := []*ItemDomain.OverdueItem{
+					{UserID: "user1", ItemID: "item-1", OldDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), Today: today, DailyReviewLimit: 1, OverduePolicy: "reset_to_first_step", TargetWeight: "normal"},
+				}
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return(overdueItems, nil)
+				m.On("CountScheduledDatesGroupedByDateByUserID", ctx, "user1", today, today.AddDate(0, 0, ItemDomain.DailyReviewLimitSearchDays)).
+					Return([]*ItemDomain.DailyScheduledCount{{ScheduledDate: today, Count: 1}}, nil)
+				m.On("GetBlockedDatesByUserID", ctx, "user1").Return([]time.Time{}, nil)
+				m.On("ResetScheduledDatesToFirstStepByItemID", ctx, "item-1", today.AddDate(0, 0, 1), ItemDomain.ExcludedWeekdays(0)).Return(int64(3), nil)
+			},
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+			wantErr: false,
+		},
+		{
+			name: "期限切れの復習日だけをずらすユーザーの更新でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), errors.New("database connection failed"))
+			},
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+			wantErr: true,
+		},
+		{
+			name: "1ステップ目からやり直すユーザーの更新でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), errors.New("database connection failed"))
+			},
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+			wantErr: true,
+		},
+		{
+			name: "終了した休止期間の反映でエラーが発生する場合は期限切れの処理を行わない",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), errors.New("database connection failed"))
+			},
+			setupCtx: func() context.Context {
+				return context.Background()
+			},
+			wantErr: true,
+		},
+		{
+			name: "期限切れの復習物の取得でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return(nil, errors.New("database connection failed"))
 			},
 			setupCtx: func() context.Context {
 				return context.Background()
@@ -92,7 +424,9 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates(t *testing.T) {
 		{
 			name: "contextがキャンセルされた場合",
 			setupMock: func(m *MockBatchRepository, ctx context.Context) {
-				m.On("ExecuteUpdateOverdueScheduledDates", ctx).Return(context.Canceled)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), context.Canceled)
 			},
 			setupCtx: func() context.Context {
 				ctx, cancel := context.WithCancel(context.Background())
@@ -105,7 +439,9 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates(t *testing.T) {
 		{
 			name: "contextにタイムアウトが設定されている場合",
 			setupMock: func(m *MockBatchRepository, ctx context.Context) {
-				m.On("ExecuteUpdateOverdueScheduledDates", ctx).Return(context.DeadlineExceeded)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), context.DeadlineExceeded)
 			},
 			setupCtx: func() context.Context {
 				ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
@@ -123,12 +459,12 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates(t *testing.T) {
 			t.Parallel()
 
 			mockRepo := &MockBatchRepository{}
-			usecase := NewBatchUsecase(mockRepo)
+			usecase := NewBatchUsecase(mockRepo, &fakeTransactionManager{}, &fakeLocker{})
 			ctx := tt.setupCtx()
 
 			tt.setupMock(mockRepo, ctx)
 
-			err := usecase.ExecuteUpdateOverdueScheduledDates(ctx)
+			_, err := usecase.ExecuteUpdateOverdueScheduledDates(ctx, testNow, testTimezones)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -154,7 +490,12 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates_LogOutput(t *testing.T)
 		{
 			name: "成功時のログ出力確認",
 			setupMock: func(m *MockBatchRepository, ctx context.Context) {
-				m.On("ExecuteUpdateOverdueScheduledDates", ctx).Return(nil)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return([]*ItemDomain.OverdueItem{}, nil)
 			},
 			expectedLogs: []string{
 				"期限切れ復習日の更新処理を開始します",
@@ -167,7 +508,9 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates_LogOutput(t *testing.T)
 		{
 			name: "エラー時のログ出力確認",
 			setupMock: func(m *MockBatchRepository, ctx context.Context) {
-				m.On("ExecuteUpdateOverdueScheduledDates", ctx).Return(errors.New("update failed"))
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), errors.New("update failed"))
 			},
 			expectedLogs: []string{
 				"期限切れ復習日の更新処理を開始します",
@@ -189,12 +532,12 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates_LogOutput(t *testing.T)
 			slog.SetDefault(logger)
 
 			mockRepo := &MockBatchRepository{}
-			usecase := NewBatchUsecase(mockRepo)
+			usecase := NewBatchUsecase(mockRepo, &fakeTransactionManager{}, &fakeLocker{})
 			ctx := context.Background()
 
 			tt.setupMock(mockRepo, ctx)
 
-			_ = usecase.ExecuteUpdateOverdueScheduledDates(ctx)
+			_, _ = usecase.ExecuteUpdateOverdueScheduledDates(ctx, testNow, testTimezones)
 
 			logOutput := buf.String()
 
@@ -213,13 +556,18 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates_LogOutput(t *testing.T)
 
 func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates_Idempotency(t *testing.T) {
 	mockRepo := &MockBatchRepository{}
-	usecase := NewBatchUsecase(mockRepo)
+	usecase := NewBatchUsecase(mockRepo, &fakeTransactionManager{}, &fakeLocker{})
 	ctx := context.Background()
 
-	mockRepo.On("ExecuteUpdateOverdueScheduledDates", ctx).Return(nil).Times(3)
+	mockRepo.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil).Times(3)
+	mockRepo.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil).Times(3)
+	mockRepo.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), nil).Times(3)
+	mockRepo.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil).Times(3)
+	mockRepo.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil).Times(3)
+	mockRepo.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return([]*ItemDomain.OverdueItem{}, nil).Times(3)
 
 	for i := 0; i < 3; i++ {
-		err := usecase.ExecuteUpdateOverdueScheduledDates(ctx)
+		_, err := usecase.ExecuteUpdateOverdueScheduledDates(ctx, testNow, testTimezones)
 		require.NoError(t, err)
 	}
 
@@ -243,12 +591,14 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates_ErrorTypes(t *testing.T
 			t.Parallel()
 
 			mockRepo := &MockBatchRepository{}
-			usecase := NewBatchUsecase(mockRepo)
+			usecase := NewBatchUsecase(mockRepo, &fakeTransactionManager{}, &fakeLocker{})
 			ctx := context.Background()
 
-			mockRepo.On("ExecuteUpdateOverdueScheduledDates", ctx).Return(errorType.err)
+			mockRepo.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+			mockRepo.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+			mockRepo.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), errorType.err)
 
-			err := usecase.ExecuteUpdateOverdueScheduledDates(ctx)
+			_, err := usecase.ExecuteUpdateOverdueScheduledDates(ctx, testNow, testTimezones)
 
 			require.Error(t, err)
 			if diff := cmp.Diff(errorType.err, err, cmpopts.EquateErrors()); diff != "" {
@@ -259,3 +609,543 @@ func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates_ErrorTypes(t *testing.T
 		})
 	}
 }
+
+func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates_RowsAffected(t *testing.T) {
+	mockRepo := &MockBatchRepository{}
+	usecase := NewBatchUsecase(mockRepo, &fakeTransactionManager{}, &fakeLocker{})
+	ctx := context.Background()
+
+	today := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	overdueItems := []*ItemDomain.OverdueItem{
+		{UserID: "user1", ItemID: "item-1", OldDate: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), Today: today, DailyReviewLimit: 1, TargetWeight: "normal"},
+	}
+	mockRepo.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+	mockRepo.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(1), nil)
+	mockRepo.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(2), nil)
+	mockRepo.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(3), nil)
+	mockRepo.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(4), nil)
+	mockRepo.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return(overdueItems, nil)
+	mockRepo.On("CountScheduledDatesGroupedByDateByUserID", ctx, "user1", today, today.AddDate(0, 0, ItemDomain.DailyReviewLimitSearchDays)).
+		Return([]*ItemDomain.DailyScheduledCount{}, nil)
+	mockRepo.On("GetBlockedDatesByUserID", ctx, "user1").Return([]time.Time{}, nil)
+	mockRepo.On("SlideScheduledDatesByItemID", ctx, "item-1", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), today, ItemDomain.ExcludedWeekdays(0)).Return(int64(5), nil)
+
+	rows, err := usecase.ExecuteUpdateOverdueScheduledDates(ctx, testNow, testTimezones)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(15), rows)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBatchUsecase_ExecuteUpdateOverdueScheduledDates_Chunks(t *testing.T) {
+	// 1回目は上限件数ちょうど取得できたため、最後のユーザーより後のユーザーを続けて取得する
+	firstChunk := make([]string, BatchDomain.UserChunkSize)
+	for i := range firstChunk {
+		firstChunk[i] = fmt.Sprintf("user%04d", i)
+	}
+	lastUserID := firstChunk[len(firstChunk)-1]
+
+	setupFirstChunk := func(m *MockBatchRepository, ctx context.Context) {
+		m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(firstChunk, nil)
+		m.On("ExecuteApplyEndedPauses", ctx, testNow, firstChunk).Return(int64(1), nil)
+		m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, firstChunk).Return(int64(2), nil)
+		m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, firstChunk).Return(int64(0), nil)
+		m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, firstChunk).Return(int64(0), nil)
+		m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, firstChunk).Return([]*ItemDomain.OverdueItem{}, nil)
+		m.On("GetUserIDsByTimezones", ctx, testTimezones, lastUserID, BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(*MockBatchRepository, context.Context)
+		wantRows  int64
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name: "ユーザーを上限件数ずつ別のトランザクションで更新する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				setupFirstChunk(m, ctx)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(4), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return([]*ItemDomain.OverdueItem{}, nil)
+			},
+			wantRows:  7,
+			wantCalls: 2,
+			wantErr:   false,
+		},
+		{
+			name: "途中のユーザーの更新でエラーが発生する場合はそれまでにコミットした行数を返す",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				setupFirstChunk(m, ctx)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), errors.New("database connection failed"))
+			},
+			wantRows:  3,
+			wantCalls: 2,
+			wantErr:   true,
+		},
+		{
+			name: "更新するユーザーがいない場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return([]string{}, nil)
+			},
+			wantRows:  0,
+			wantCalls: 0,
+			wantErr:   false,
+		},
+		{
+			name: "更新するユーザーの取得でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(nil, errors.New("database connection failed"))
+			},
+			wantRows:  0,
+			wantCalls: 0,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := &MockBatchRepository{}
+			tm := &fakeTransactionManager{}
+			usecase := NewBatchUsecase(mockRepo, tm, &fakeLocker{})
+			ctx := context.Background()
+
+			tt.setupMock(mockRepo, ctx)
+
+			rows, err := usecase.ExecuteUpdateOverdueScheduledDates(ctx, testNow, testTimezones)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantRows, rows)
+			require.Equal(t, tt.wantCalls, tm.calls)
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBatchUsecase_RunUpdateOverdueScheduledDates(t *testing.T) {
+	jobName := BatchDomain.JobNameUpdateOverdueScheduledDates
+	windowStart := testNow
+	prevWindowStart := windowStart.Add(-BatchDomain.RunInterval)
+	// 基準時刻の実行枠で日付けを跨ぐのは日本時間だけ
+	crossedTimezones := []string{"Asia/Tokyo"}
+
+	setupSuccessfulUpdate := func(m *MockBatchRepository, ctx context.Context, timezones []string) {
+		m.On("GetUserIDsByTimezones", ctx, timezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+		m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+		m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(4), nil)
+		m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+		m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(2), nil)
+		m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return([]*ItemDomain.OverdueItem{}, nil)
+	}
+
+	tests := []struct {
+		name        string
+		heldByOther bool
+		setupMock   func(*MockBatchRepository, context.Context)
+		wantErr     bool
+	}{
+		{
+			name: "日付けを跨いだタイムゾーンのユーザーの期限切れの復習日を更新し、成功した実行記録を残す場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("CreateBatchRun", ctx, mock.MatchedBy(func(run *BatchDomain.BatchRun) bool {
+					return run.WindowStart().Equal(windowStart) && run.CaughtUpFrom() == nil && run.Status() == BatchDomain.BatchRunStatusRunning
+				})).Return(true, nil)
+				m.On("GetLatestSucceededBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				setupSuccessfulUpdate(m, ctx, crossedTimezones)
+				m.On("FinishBatchRun", mock.Anything, mock.MatchedBy(func(run *BatchDomain.BatchRun) bool {
+					return run.Status() == BatchDomain.BatchRunStatusSucceeded && run.RowsAffected() == 6 && run.FinishedAt() != nil
+				})).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "同じ実行枠で実行済みの場合は更新しない",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&windowStart, nil)
+				m.On("CreateBatchRun", ctx, mock.Anything).Return(false, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "実行されなかった実行枠がある場合は起動時の実行枠でまとめて更新する",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				lastWindowStart := windowStart.Add(-time.Hour)
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&lastWindowStart, nil)
+				m.On("CreateBatchRun", ctx, mock.MatchedBy(func(run *BatchDomain.BatchRun) bool {
+					return run.CaughtUpFrom() != nil && run.CaughtUpFrom().Equal(windowStart.Add(-45*time.Minute)) && run.MissedWindows() == 3
+				})).Return(true, nil)
+				m.On("GetLatestSucceededBatchRunWindowStart", ctx, jobName).Return(&lastWindowStart, nil)
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				setupSuccessfulUpdate(m, ctx, crossedTimezones)
+				m.On("FinishBatchRun", mock.Anything, mock.MatchedBy(func(run *BatchDomain.BatchRun) bool {
+					return run.Status() == BatchDomain.BatchRunStatusSucceeded
+				})).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "前回の実行が失敗していた場合は前回の成功した実行から日付けを跨いだタイムゾーンを更新する",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				// UTCの1月9日23時から、UTCと日本時間の両方で日付けを跨いでいる
+				lastSucceededWindowStart := time.Date(2024, 1, 9, 23, 0, 0, 0, time.UTC)
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("CreateBatchRun", ctx, mock.Anything).Return(true, nil)
+				m.On("GetLatestSucceededBatchRunWindowStart", ctx, jobName).Return(&lastSucceededWindowStart, nil)
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				setupSuccessfulUpdate(m, ctx, testTimezones)
+				m.On("FinishBatchRun", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "初めての実行の場合は全てのタイムゾーンを更新する",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(nil, nil)
+				m.On("CreateBatchRun", ctx, mock.MatchedBy(func(run *BatchDomain.BatchRun) bool {
+					return run.CaughtUpFrom() == nil
+				})).Return(true, nil)
+				m.On("GetLatestSucceededBatchRunWindowStart", ctx, jobName).Return(nil, nil)
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				setupSuccessfulUpdate(m, ctx, testTimezones)
+				m.On("FinishBatchRun", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "日付けを跨いだタイムゾーンがない場合は更新せず、成功した実行記録を残す",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("CreateBatchRun", ctx, mock.Anything).Return(true, nil)
+				m.On("GetLatestSucceededBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("GetUserTimezones", ctx).Return([]string{"UTC"}, nil)
+				m.On("FinishBatchRun", mock.Anything, mock.MatchedBy(func(run *BatchDomain.BatchRun) bool {
+					return run.Status() == BatchDomain.BatchRunStatusSucceeded && run.RowsAffected() == 0
+				})).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "更新でエラーが発生する場合は失敗した実行記録を残す",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("CreateBatchRun", ctx, mock.Anything).Return(true, nil)
+				m.On("GetLatestSucceededBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				m.On("GetUserIDsByTimezones", ctx, crossedTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(0), errors.New("database connection failed"))
+				m.On("FinishBatchRun", mock.Anything, mock.MatchedBy(func(run *BatchDomain.BatchRun) bool {
+					return run.Status() == BatchDomain.BatchRunStatusFailed && run.ErrorMessage() != nil && *run.ErrorMessage() == "database connection failed"
+				})).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "ユーザーのタイムゾーンの取得でエラーが発生する場合は失敗した実行記録を残す",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("CreateBatchRun", ctx, mock.Anything).Return(true, nil)
+				m.On("GetLatestSucceededBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("GetUserTimezones", ctx).Return(nil, errors.New("database connection failed"))
+				m.On("FinishBatchRun", mock.Anything, mock.MatchedBy(func(run *BatchDomain.BatchRun) bool {
+					return run.Status() == BatchDomain.BatchRunStatusFailed
+				})).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "実行中のまま残っている実行記録の更新でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(errors.New("database connection failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "実行記録の作成でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("CreateBatchRun", ctx, mock.Anything).Return(false, errors.New("database connection failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "実行記録の更新でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+				m.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("CreateBatchRun", ctx, mock.Anything).Return(true, nil)
+				m.On("GetLatestSucceededBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				setupSuccessfulUpdate(m, ctx, crossedTimezones)
+				m.On("FinishBatchRun", mock.Anything, mock.Anything).Return(errors.New("database connection failed"))
+			},
+			wantErr: true,
+		},
+		{
+			name:        "他のプロセスがロックを保持している場合は実行記録も作成しない",
+			heldByOther: true,
+			setupMock:   func(m *MockBatchRepository, ctx context.Context) {},
+			wantErr:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := &MockBatchRepository{}
+			usecase := NewBatchUsecase(mockRepo, &fakeTransactionManager{}, &fakeLocker{heldByOther: tt.heldByOther})
+			ctx := context.Background()
+
+			tt.setupMock(mockRepo, ctx)
+
+			err := usecase.RunUpdateOverdueScheduledDates(ctx, windowStart)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBatchUsecase_RunUpdateOverdueScheduledDates_SkipsTimezonesNotCrossedMidnight(t *testing.T) {
+	jobName := BatchDomain.JobNameUpdateOverdueScheduledDates
+	prevWindowStart := testNow.Add(-BatchDomain.RunInterval)
+	// 基準時刻（日本時間の0時）の実行枠では、UTCのユーザーの日付けは変わっていない
+	tokyoUserIDs := []string{"user-tokyo"}
+	utcUserIDs := []string{"user-utc"}
+
+	mockRepo := &MockBatchRepository{}
+	usecase := NewBatchUsecase(mockRepo, &fakeTransactionManager{}, &fakeLocker{})
+	ctx := context.Background()
+
+	mockRepo.On("FailStaleBatchRuns", ctx, jobName, mock.Anything, mock.Anything).Return(nil)
+	mockRepo.On("GetLatestBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+	mockRepo.On("CreateBatchRun", ctx, mock.Anything).Return(true, nil)
+	mockRepo.On("GetLatestSucceededBatchRunWindowStart", ctx, jobName).Return(&prevWindowStart, nil)
+	mockRepo.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+	mockRepo.On("GetUserIDsByTimezones", ctx, []string{"Asia/Tokyo"}, "", BatchDomain.UserChunkSize).Return(tokyoUserIDs, nil)
+	mockRepo.On("ExecuteApplyEndedPauses", ctx, testNow, tokyoUserIDs).Return(int64(0), nil)
+	mockRepo.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, tokyoUserIDs).Return(int64(1), nil)
+	mockRepo.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, tokyoUserIDs).Return(int64(0), nil)
+	mockRepo.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, tokyoUserIDs).Return(int64(0), nil)
+	mockRepo.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, tokyoUserIDs).Return([]*ItemDomain.OverdueItem{}, nil)
+	mockRepo.On("FinishBatchRun", mock.Anything, mock.MatchedBy(func(run *BatchDomain.BatchRun) bool {
+		return run.Status() == BatchDomain.BatchRunStatusSucceeded && run.RowsAffected() == 1
+	})).Return(nil)
+
+	err := usecase.RunUpdateOverdueScheduledDates(ctx, testNow)
+	require.NoError(t, err)
+
+	// 日付けを跨いでいないUTCのユーザーは取得も更新もしない
+	mockRepo.AssertNotCalled(t, "GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize)
+	mockRepo.AssertNotCalled(t, "GetUserIDsByTimezones", ctx, []string{"UTC"}, "", BatchDomain.UserChunkSize)
+	for _, method := range []string{
+		"ExecuteApplyEndedPauses",
+		"ExecuteUpdateOverdueScheduledDates",
+		"ExecuteMoveOverdueScheduledDatesToToday",
+		"ExecuteResetOverdueItemsToFirstStep",
+		"GetOverdueItemsWithDailyReviewLimit",
+	} {
+		mockRepo.AssertNotCalled(t, method, ctx, testNow, utcUserIDs)
+		mockRepo.AssertNumberOfCalls(t, method, 1)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBatchUsecase_DryRunUpdateOverdueScheduledDates(t *testing.T) {
+	errDatabase := errors.New("database connection failed")
+	date := func(day int) time.Time {
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+	}
+	before := []*BatchDomain.ReviewDateSnapshot{
+		{ReviewDateID: "rd-1", UserID: "user1", ItemID: "item-1", StepNumber: 1, ScheduledDate: date(8)},
+		{ReviewDateID: "rd-2", UserID: "user1", ItemID: "item-1", StepNumber: 2, ScheduledDate: date(12)},
+		{ReviewDateID: "rd-3", UserID: "user2", ItemID: "item-2", StepNumber: 1, ScheduledDate: date(9)},
+	}
+	after := []*BatchDomain.ReviewDateSnapshot{
+		{ReviewDateID: "rd-1", UserID: "user1", ItemID: "item-1", StepNumber: 1, ScheduledDate: date(10)},
+		{ReviewDateID: "rd-2", UserID: "user1", ItemID: "item-1", StepNumber: 2, ScheduledDate: date(14)},
+		{ReviewDateID: "rd-3", UserID: "user2", ItemID: "item-2", StepNumber: 1, ScheduledDate: date(9)},
+	}
+
+	// 上限件数ちょうどのユーザーの次に、残りのユーザーを取得する
+	firstChunkUserIDs := make([]string, BatchDomain.UserChunkSize)
+	for i := range firstChunkUserIDs {
+		firstChunkUserIDs[i] = fmt.Sprintf("user%03d", i)
+	}
+	lastChunkUserIDs := []string{"user999"}
+	firstChunkBefore := []*BatchDomain.ReviewDateSnapshot{
+		{ReviewDateID: "rd-1", UserID: "user000", ItemID: "item-1", StepNumber: 1, ScheduledDate: date(8)},
+	}
+	firstChunkAfter := []*BatchDomain.ReviewDateSnapshot{
+		{ReviewDateID: "rd-1", UserID: "user000", ItemID: "item-1", StepNumber: 1, ScheduledDate: date(10)},
+	}
+	lastChunkBefore := []*BatchDomain.ReviewDateSnapshot{
+		{ReviewDateID: "rd-9", UserID: "user999", ItemID: "item-9", StepNumber: 1, ScheduledDate: date(9)},
+	}
+	lastChunkAfter := []*BatchDomain.ReviewDateSnapshot{
+		{ReviewDateID: "rd-9", UserID: "user999", ItemID: "item-9", StepNumber: 1, ScheduledDate: date(10)},
+	}
+	expectUpdate := func(m *MockBatchRepository, ctx context.Context, userIDs []string, rows int64) {
+		m.On("ExecuteApplyEndedPauses", ctx, testNow, userIDs).Return(int64(0), nil)
+		m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, userIDs).Return(rows, nil)
+		m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, userIDs).Return(int64(0), nil)
+		m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, userIDs).Return(int64(0), nil)
+		m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, userIDs).Return([]*ItemDomain.OverdueItem{}, nil)
+	}
+
+	tests := []struct {
+		name        string
+		heldByOther bool
+		setupMock   func(*MockBatchRepository, context.Context)
+		want        *DryRunUpdateOverdueScheduledDatesOutput
+		wantTxCalls int
+		wantErr     error
+	}{
+		{
+			name: "ずれる復習日を返し、更新はロールバックする場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				m.On("GetReviewDatesToUpdateByBatch", ctx, testNow, testUserIDs).Return(before, nil)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(2), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return([]*ItemDomain.OverdueItem{}, nil)
+				m.On("GetReviewDatesByIDs", ctx, []string{"rd-1", "rd-2", "rd-3"}).Return(after, nil)
+			},
+			want: &DryRunUpdateOverdueScheduledDatesOutput{
+				RowsAffected: 2,
+				Changes: []*ReviewDateChangeOutput{
+					{UserID: "user1", ItemID: "item-1", ReviewDateID: "rd-1", StepNumber: 1, OldDate: date(8), NewDate: date(10), Days: 2},
+					{UserID: "user1", ItemID: "item-1", ReviewDateID: "rd-2", StepNumber: 2, OldDate: date(12), NewDate: date(14), Days: 2},
+				},
+			},
+			wantTxCalls: 1,
+		},
+		{
+			name: "上限件数ずつトランザクションを分けて、チャンク毎にロールバックする場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(firstChunkUserIDs, nil)
+				m.On("GetReviewDatesToUpdateByBatch", ctx, testNow, firstChunkUserIDs).Return(firstChunkBefore, nil)
+				expectUpdate(m, ctx, firstChunkUserIDs, 1)
+				m.On("GetReviewDatesByIDs", ctx, []string{"rd-1"}).Return(firstChunkAfter, nil)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, firstChunkUserIDs[len(firstChunkUserIDs)-1], BatchDomain.UserChunkSize).Return(lastChunkUserIDs, nil)
+				m.On("GetReviewDatesToUpdateByBatch", ctx, testNow, lastChunkUserIDs).Return(lastChunkBefore, nil)
+				expectUpdate(m, ctx, lastChunkUserIDs, 1)
+				m.On("GetReviewDatesByIDs", ctx, []string{"rd-9"}).Return(lastChunkAfter, nil)
+			},
+			want: &DryRunUpdateOverdueScheduledDatesOutput{
+				RowsAffected: 2,
+				Changes: []*ReviewDateChangeOutput{
+					{UserID: "user000", ItemID: "item-1", ReviewDateID: "rd-1", StepNumber: 1, OldDate: date(8), NewDate: date(10), Days: 2},
+					{UserID: "user999", ItemID: "item-9", ReviewDateID: "rd-9", StepNumber: 1, OldDate: date(9), NewDate: date(10), Days: 1},
+				},
+			},
+			wantTxCalls: 2,
+		},
+		{
+			name:        "他のプロセスがバッチ処理を実行している場合は実行しない",
+			heldByOther: true,
+			setupMock:   func(m *MockBatchRepository, ctx context.Context) {},
+			wantErr:     ErrBatchRunning,
+		},
+		{
+			name: "更新前の復習日の取得でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("GetReviewDatesToUpdateByBatch", ctx, testNow, testUserIDs).Return(nil, errDatabase)
+			},
+			wantTxCalls: 1,
+			wantErr:     errDatabase,
+		},
+		{
+			name: "更新でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				m.On("GetReviewDatesToUpdateByBatch", ctx, testNow, testUserIDs).Return(before, nil)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), errDatabase)
+			},
+			wantTxCalls: 1,
+			wantErr:     errDatabase,
+		},
+		{
+			name: "更新後の復習日の取得でエラーが発生する場合",
+			setupMock: func(m *MockBatchRepository, ctx context.Context) {
+				m.On("GetUserTimezones", ctx).Return(testTimezones, nil)
+				m.On("GetReviewDatesToUpdateByBatch", ctx, testNow, testUserIDs).Return(before, nil)
+				m.On("GetUserIDsByTimezones", ctx, testTimezones, "", BatchDomain.UserChunkSize).Return(testUserIDs, nil)
+				m.On("ExecuteApplyEndedPauses", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteUpdateOverdueScheduledDates", ctx, testNow, testUserIDs).Return(int64(2), nil)
+				m.On("ExecuteMoveOverdueScheduledDatesToToday", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("ExecuteResetOverdueItemsToFirstStep", ctx, testNow, testUserIDs).Return(int64(0), nil)
+				m.On("GetOverdueItemsWithDailyReviewLimit", ctx, testNow, testUserIDs).Return([]*ItemDomain.OverdueItem{}, nil)
+				m.On("GetReviewDatesByIDs", ctx, []string{"rd-1", "rd-2", "rd-3"}).Return(nil, errDatabase)
+			},
+			wantTxCalls: 1,
+			wantErr:     errDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRepo := &MockBatchRepository{}
+			tm := &fakeTransactionManager{}
+			usecase := NewBatchUsecase(mockRepo, tm, &fakeLocker{heldByOther: tt.heldByOther})
+			ctx := context.Background()
+
+			tt.setupMock(mockRepo, ctx)
+
+			got, err := usecase.DryRunUpdateOverdueScheduledDates(ctx, testNow)
+
+			// 成功した場合も更新は必ずロールバックする
+			require.Equal(t, tt.wantTxCalls, tm.calls)
+			if tm.calls > 0 {
+				require.Error(t, tm.fnErr)
+			}
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				require.ErrorIs(t, tm.fnErr, errDryRunRollback)
+				if diff := cmp.Diff(tt.want, got); diff != "" {
+					t.Errorf("DryRunUpdateOverdueScheduledDates() mismatch (-want +got):\n%s", diff)
+				}
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -35,13 +35,32 @@ type UpdateBoxInput struct {
 	CategoryID string
 	PatternID  string
 	Name       string
+	// パターンを変更する場合に、ボックス内の復習物の復習日を組み直す基準の今日の日付。ボックスに未完了の復習物がある場合は必須
+	Today string
+	// trueの場合、組み直した結果を返すだけで永続化しない
+	IsDryRun bool
+}
+
+type ReplannedReviewDateOutput struct {
+	StepNumber    int
+	ScheduledDate string
+	IsCompleted   bool
+}
+
+// パターン変更で組み直した復習物の復習スケジュール。組み直さずに残した完了済みの復習日も含む
+type ReplannedItemOutput struct {
+	ItemID      string
+	Name        string
+	IsFinished  bool
+	ReviewDates []ReplannedReviewDateOutput
 }
 
 type UpdateBoxOutput struct {
-	ID         string
-	UserID     string
-	CategoryID string
-	PatternID  string
-	Name       string
-	EditedAt   time.Time
+	ID             string
+	UserID         string
+	CategoryID     string
+	PatternID      string
+	Name           string
+	EditedAt       time.Time
+	ReplannedItems []ReplannedItemOutput
 }
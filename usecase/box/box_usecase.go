@@ -6,21 +6,35 @@ import (
 
 	"github.com/google/uuid"
 	boxDomain "github.com/minminseo/recall-setter/domain/box"
+	itemDomain "github.com/minminseo/recall-setter/domain/item"
+	patternDomain "github.com/minminseo/recall-setter/domain/pattern"
+	"github.com/minminseo/recall-setter/usecase/transaction"
 )
 
 type boxUsecase struct {
 	boxRepo boxDomain.IBoxRepository
-	// transactionManager transaction.ITransactionManager
+	// ボックスのパターン変更時に、ボックス内の復習物の復習日を新しいパターンで組み直すため
+	itemRepo    itemDomain.IItemRepository
+	patternRepo patternDomain.IPatternRepository
+	// ボックスのパターンと復習物の復習日を同一トランザクションで永続化するため
+	transactionManager transaction.ITransactionManager
+	scheduler          itemDomain.IScheduler
 }
 
 // NewBoxUsecase はコンストラクタ
 func NewBoxUsecase(
 	boxRepo boxDomain.IBoxRepository,
-	// transactionManager transaction.ITransactionManager,
+	itemRepo itemDomain.IItemRepository,
+	patternRepo patternDomain.IPatternRepository,
+	transactionManager transaction.ITransactionManager,
+	scheduler itemDomain.IScheduler,
 ) IBoxUsecase {
 	return &boxUsecase{
-		boxRepo: boxRepo,
-		// transactionManager: transactionManager,
+		boxRepo:            boxRepo,
+		itemRepo:           itemRepo,
+		patternRepo:        patternRepo,
+		transactionManager: transactionManager,
+		scheduler:          scheduler,
 	}
 }
 
@@ -92,32 +106,234 @@ func (bu *boxUsecase) UpdateBox(ctx context.Context, input UpdateBoxInput) (*Upd
 		return nil, err
 	}
 
+	resBox := &UpdateBoxOutput{
+		ID:             targetBox.ID(),
+		UserID:         targetBox.UserID(),
+		CategoryID:     targetBox.CategoryID(),
+		PatternID:      targetBox.PatternID(),
+		Name:           targetBox.Name(),
+		EditedAt:       targetBox.EditedAt(),
+		ReplannedItems: []ReplannedItemOutput{},
+	}
+
 	if isSamePattern {
+		if input.IsDryRun {
+			return resBox, nil
+		}
 		err = bu.boxRepo.Update(ctx, targetBox)
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		affected, err := bu.boxRepo.UpdateWithPatternID(ctx, targetBox)
+		return resBox, nil
+	}
+
+	// パターンが変わる場合は、ボックス内の未完了の復習物の復習日を新しいパターンで組み直す
+	// ドライランの場合は組み直した結果を返すだけで、永続化は行わない
+	if input.IsDryRun {
+		replannedItems, err := bu.replanItems(ctx, targetBox, input.Today)
 		if err != nil {
 			return nil, err
 		}
+		resBox.ReplannedItems = toReplannedItemOutputs(replannedItems)
+		return resBox, nil
+	}
+
+	err = bu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		affected, err := bu.boxRepo.UpdateWithPatternID(ctx, targetBox)
+		if err != nil {
+			return err
+		}
 		if affected == 0 {
-			return nil, boxDomain.ErrPatternConflict
+			return boxDomain.ErrBoxNotFound
 		}
-	}
 
-	resBox := &UpdateBoxOutput{
-		ID:         targetBox.ID(),
-		UserID:     targetBox.UserID(),
-		CategoryID: targetBox.CategoryID(),
-		PatternID:  targetBox.PatternID(),
-		Name:       targetBox.Name(),
-		EditedAt:   targetBox.EditedAt(),
+		// 読み込んでから書き込むまでに完了や編集された復習日を上書きしないように、ロックしてからトランザクション内で読み込んで組み直す
+		err = bu.itemRepo.LockUnFinishedItemsByBoxID(ctx, targetBox.ID(), targetBox.UserID())
+		if err != nil {
+			return err
+		}
+		replannedItems, err := bu.replanItems(ctx, targetBox, input.Today)
+		if err != nil {
+			return err
+		}
+
+		err = bu.itemRepo.UpdateItemsPatternIDByBoxID(ctx, targetBox.ID(), targetBox.UserID(), targetBox.PatternID())
+		if err != nil {
+			return err
+		}
+
+		// 最後に完了したステップより後の未完了の復習日を削除→組み直した復習日を一括挿入
+		var newReviewdates []*itemDomain.Reviewdate
+		for _, ri := range replannedItems {
+			err = bu.itemRepo.DeleteInCompletedReviewDatesAfterStep(ctx, ri.item.ItemID(), targetBox.UserID(), ri.lastCompletedStepNumber)
+			if err != nil {
+				return err
+			}
+			if ri.isFinished {
+				err = bu.itemRepo.UpdateItemAsFinished(ctx, ri.item.ItemID(), targetBox.UserID(), editedAt)
+				if err != nil {
+					return err
+				}
+			}
+			newReviewdates = append(newReviewdates, ri.newReviewdates...)
+		}
+		if len(newReviewdates) > 0 {
+			if _, err := bu.itemRepo.CreateReviewdates(ctx, newReviewdates); err != nil {
+				return err
+			}
+		}
+		resBox.ReplannedItems = toReplannedItemOutputs(replannedItems)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
 	return resBox, nil
 }
 
+// ボックスのパターン変更で組み直す復習物
+type replannedItem struct {
+	item                    *itemDomain.Item
+	lastCompletedStepNumber int
+	// 最後に完了したステップまでの復習日。組み直さずに残す
+	keptReviewdates []*itemDomain.Reviewdate
+	// 最後に完了したステップより後の、新しいパターンで組み直した復習日
+	newReviewdates []*itemDomain.Reviewdate
+	// 完了したステップが新しいパターンの全ステップに達している（繰り返しの間隔日数もない）場合は復習物を完了にする
+	isFinished bool
+}
+
+func toReplannedItemOutputs(replannedItems []*replannedItem) []ReplannedItemOutput {
+	res := make([]ReplannedItemOutput, len(replannedItems))
+	for i, ri := range replannedItems {
+		res[i] = ri.toOutput()
+	}
+	return res
+}
+
+func (ri *replannedItem) toOutput() ReplannedItemOutput {
+	reviewdates := append(append([]*itemDomain.Reviewdate{}, ri.keptReviewdates...), ri.newReviewdates...)
+	out := ReplannedItemOutput{
+		ItemID:     ri.item.ItemID(),
+		Name:       ri.item.Name(),
+		IsFinished: ri.isFinished,
+	}
+	out.ReviewDates = make([]ReplannedReviewDateOutput, len(reviewdates))
+	for i, rd := range reviewdates {
+		out.ReviewDates[i] = ReplannedReviewDateOutput{
+			StepNumber:    rd.StepNumber(),
+			ScheduledDate: rd.ScheduledDate().Format("2006-01-02"),
+			IsCompleted:   rd.IsCompleted(),
+		}
+	}
+	return out
+}
+
+// ボックス内の未完了の復習物毎に、完了済みの復習日は残したまま、以降の未完了の復習日を新しいパターンのステップで組み直す
+// 復習物を作成した時と同じく、学習日から新しいパターンの各ステップの復習日を算出する
+func (bu *boxUsecase) replanItems(ctx context.Context, targetBox *boxDomain.Box, today string) ([]*replannedItem, error) {
+	items, err := bu.itemRepo.GetAllUnFinishedItemsByBoxID(ctx, targetBox.ID(), targetBox.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return []*replannedItem{}, nil
+	}
+
+	parsedToday, err := time.Parse("2006-01-02", today)
+	if err != nil {
+		return nil, err
+	}
+
+	targetPattern, err := bu.patternRepo.FindPatternByPatternID(ctx, targetBox.PatternID(), targetBox.UserID())
+	if err != nil {
+		return nil, err
+	}
+	// 適応型の方式は復習日を完了時に1件ずつ生成するため、事前に組み直せない
+	if targetPattern.IsAdaptive() {
+		return nil, patternDomain.ErrAdaptivePatternReschedule
+	}
+	targetPatternSteps, err := bu.patternRepo.GetAllPatternStepsByPatternID(ctx, targetBox.PatternID(), targetBox.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler, err := bu.scheduler.WithAlgorithm(targetPattern.SchedulingAlgorithm())
+	if err != nil {
+		return nil, err
+	}
+	// 復習物作成時と同じく、パターンとユーザー設定の除外する曜日、ユーザーの復習日を置かない日付を避ける
+	userExcludedWeekdays, err := bu.itemRepo.GetExcludedWeekdaysByUserID(ctx, targetBox.UserID())
+	if err != nil {
+		return nil, err
+	}
+	blockedDates, err := bu.itemRepo.GetBlockedDatesByUserID(ctx, targetBox.UserID())
+	if err != nil {
+		return nil, err
+	}
+	scheduler = scheduler.
+		WithExcludedWeekdays(itemDomain.NewExcludedWeekdays(targetPattern.ExcludedWeekdays(), userExcludedWeekdays)).
+		WithBlockedDates(itemDomain.NewBlockedDates(blockedDates))
+
+	result := make([]*replannedItem, len(items))
+	for i, item := range items {
+		reviewdates, err := bu.itemRepo.GetReviewDatesByItemID(ctx, item.ItemID(), item.UserID())
+		if err != nil {
+			return nil, err
+		}
+
+		lastCompleted := itemDomain.LastCompletedReviewdate(reviewdates)
+		lastCompletedStepNumber := 0
+		if lastCompleted != nil {
+			lastCompletedStepNumber = lastCompleted.StepNumber()
+		}
+		keptReviewdates := make([]*itemDomain.Reviewdate, 0, len(reviewdates))
+		for _, rd := range reviewdates {
+			if rd.StepNumber() <= lastCompletedStepNumber {
+				keptReviewdates = append(keptReviewdates, rd)
+			}
+		}
+
+		newReviewdates, err := scheduler.RescheduleForUpdatedSteps(
+			targetPatternSteps,
+			item.UserID(),
+			item.CategoryID(),
+			item.BoxID(),
+			item.ItemID(),
+			lastCompletedStepNumber,
+			item.LearnedDate(),
+			parsedToday,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		isFinished := false
+		if len(newReviewdates) == 0 {
+			// 新しいパターンのステップを全て完了している場合は、繰り返しの間隔日数があれば次の復習日を追加し、なければ完了にする
+			if targetPattern.HasMaintenanceInterval() && lastCompleted != nil {
+				nextReviewdate, err := scheduler.NextMaintenanceReviewdate(lastCompleted, targetPattern.MaintenanceIntervalDays(), lastCompleted.ScheduledDate(), parsedToday)
+				if err != nil {
+					return nil, err
+				}
+				newReviewdates = []*itemDomain.Reviewdate{nextReviewdate}
+			} else {
+				isFinished = true
+			}
+		}
+
+		result[i] = &replannedItem{
+			item:                    item,
+			lastCompletedStepNumber: lastCompletedStepNumber,
+			keptReviewdates:         keptReviewdates,
+			newReviewdates:          newReviewdates,
+			isFinished:              isFinished,
+		}
+	}
+	return result, nil
+}
+
 func (bu *boxUsecase) DeleteBox(ctx context.Context, boxID string, categoryID string, userID string) error {
 	return bu.boxRepo.Delete(ctx, boxID, categoryID, userID)
 }
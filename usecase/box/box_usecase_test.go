@@ -10,6 +10,9 @@ import (
 	"go.uber.org/mock/gomock"
 
 	boxDomain "github.com/minminseo/recall-setter/domain/box"
+	itemDomain "github.com/minminseo/recall-setter/domain/item"
+	patternDomain "github.com/minminseo/recall-setter/domain/pattern"
+	"github.com/minminseo/recall-setter/usecase/transaction"
 )
 
 func TestCreateBox(t *testing.T) {
@@ -91,7 +94,7 @@ func TestCreateBox(t *testing.T) {
 			mockRepo := boxDomain.NewMockIBoxRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			usecase := NewBoxUsecase(mockRepo)
+			usecase := NewBoxUsecase(mockRepo, itemDomain.NewMockIItemRepository(ctrl), patternDomain.NewMockIPatternRepository(ctrl), transaction.NewMockITransactionManager(ctrl), itemDomain.NewMockIScheduler(ctrl))
 			got, err := usecase.CreateBox(ctx, tt.input)
 
 			if (err != nil) != tt.wantErr {
@@ -200,7 +203,7 @@ func TestGetBoxesByCategoryID(t *testing.T) {
 			mockRepo := boxDomain.NewMockIBoxRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			usecase := NewBoxUsecase(mockRepo)
+			usecase := NewBoxUsecase(mockRepo, itemDomain.NewMockIItemRepository(ctrl), patternDomain.NewMockIPatternRepository(ctrl), transaction.NewMockITransactionManager(ctrl), itemDomain.NewMockIScheduler(ctrl))
 			got, err := usecase.GetBoxesByCategoryID(ctx, tt.categoryID, tt.userID)
 
 			if (err != nil) != tt.wantErr {
@@ -220,13 +223,160 @@ func TestGetBoxesByCategoryID(t *testing.T) {
 func TestUpdateBox(t *testing.T) {
 	ctx := context.Background()
 	mockTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	learnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	newExistingBox := func() *boxDomain.Box {
+		existing, _ := boxDomain.ReconstructBox(
+			"44444444-4444-4444-4444-444444444444",
+			"11111111-1111-1111-1111-111111111111",
+			"22222222-2222-2222-2222-222222222222",
+			"33333333-3333-3333-3333-333333333333",
+			"英語学習ボックス",
+			mockTime,
+			mockTime,
+		)
+		return existing
+	}
+	newPattern := func(schedulingAlgorithm string) *patternDomain.Pattern {
+		pattern, _ := patternDomain.ReconstructPattern(
+			"55555555-5555-5555-5555-555555555555",
+			"11111111-1111-1111-1111-111111111111",
+			"新しいパターン",
+			"light",
+			schedulingAlgorithm,
+			false,
+			[]int{},
+			0,
+			mockTime,
+			mockTime,
+		)
+		return pattern
+	}
+	step1, _ := patternDomain.ReconstructPatternStep("step-1", "11111111-1111-1111-1111-111111111111", "55555555-5555-5555-5555-555555555555", 1, 1, patternDomain.IntervalUnitDay)
+	step2, _ := patternDomain.ReconstructPatternStep("step-2", "11111111-1111-1111-1111-111111111111", "55555555-5555-5555-5555-555555555555", 2, 7, patternDomain.IntervalUnitDay)
+	steps := []*patternDomain.PatternStep{step1, step2}
+
+	categoryID := "22222222-2222-2222-2222-222222222222"
+	boxID := "44444444-4444-4444-4444-444444444444"
+	oldPatternID := "33333333-3333-3333-3333-333333333333"
+	item1, _ := itemDomain.ReconstructItem("item-1", "11111111-1111-1111-1111-111111111111", &categoryID, &boxID, &oldPatternID, nil, "復習物1", "", learnedDate, false, mockTime, mockTime)
+	completedReviewdate1, _ := itemDomain.NewReviewdate("rd-1", "11111111-1111-1111-1111-111111111111", &categoryID, &boxID, "item-1", 1, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true)
+	inCompletedReviewdate2, _ := itemDomain.NewReviewdate("rd-2", "11111111-1111-1111-1111-111111111111", &categoryID, &boxID, "item-1", 2, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), false)
+	replannedReviewdate2, _ := itemDomain.NewReviewdate("rd-new-2", "11111111-1111-1111-1111-111111111111", &categoryID, &boxID, "item-1", 2, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), false)
+
+	// パターン変更時の復習物の読み込みから組み直しまでの共通の呼び出し
+	expectReplan := func(
+		itemRepo *itemDomain.MockIItemRepository,
+		patternRepo *patternDomain.MockIPatternRepository,
+		scheduler *itemDomain.MockIScheduler,
+		replannedReviewdates []*itemDomain.Reviewdate,
+	) []any {
+		return []any{
+			itemRepo.EXPECT().
+				GetAllUnFinishedItemsByBoxID(ctx, "44444444-4444-4444-4444-444444444444", "11111111-1111-1111-1111-111111111111").
+				Return([]*itemDomain.Item{item1}, nil).
+				Times(1),
+			patternRepo.EXPECT().
+				FindPatternByPatternID(ctx, "55555555-5555-5555-5555-555555555555", "11111111-1111-1111-1111-111111111111").
+				Return(newPattern(patternDomain.SchedulingAlgorithmFixed), nil).
+				Times(1),
+			patternRepo.EXPECT().
+				GetAllPatternStepsByPatternID(ctx, "55555555-5555-5555-5555-555555555555", "11111111-1111-1111-1111-111111111111").
+				Return(steps, nil).
+				Times(1),
+			scheduler.EXPECT().
+				WithAlgorithm(patternDomain.SchedulingAlgorithmFixed).
+				Return(scheduler, nil).
+				Times(1),
+			itemRepo.EXPECT().
+				GetExcludedWeekdaysByUserID(ctx, "11111111-1111-1111-1111-111111111111").
+				Return([]int{}, nil).
+				Times(1),
+			itemRepo.EXPECT().
+				GetBlockedDatesByUserID(ctx, "11111111-1111-1111-1111-111111111111").
+				Return([]time.Time{}, nil).
+				Times(1),
+			scheduler.EXPECT().
+				WithExcludedWeekdays(gomock.Any()).
+				Return(scheduler).
+				Times(1),
+			scheduler.EXPECT().
+				WithBlockedDates(gomock.Any()).
+				Return(scheduler).
+				Times(1),
+			itemRepo.EXPECT().
+				GetReviewDatesByItemID(ctx, "item-1", "11111111-1111-1111-1111-111111111111").
+				Return([]*itemDomain.Reviewdate{completedReviewdate1, inCompletedReviewdate2}, nil).
+				Times(1),
+			scheduler.EXPECT().
+				RescheduleForUpdatedSteps(steps, "11111111-1111-1111-1111-111111111111", &categoryID, &boxID, "item-1", 1, learnedDate, today).
+				Return(replannedReviewdates, nil).
+				Times(1),
+		}
+	}
+	// ボックスの取得からトランザクション内で復習物をロックするまでの共通の呼び出し
+	expectTransaction := func(
+		boxRepo *boxDomain.MockIBoxRepository,
+		itemRepo *itemDomain.MockIItemRepository,
+		txManager *transaction.MockITransactionManager,
+	) []any {
+		return []any{
+			boxRepo.EXPECT().
+				GetByID(ctx, "44444444-4444-4444-4444-444444444444", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
+				Return(newExistingBox(), nil).
+				Times(1),
+			txManager.EXPECT().
+				RunInTransaction(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).
+				Times(1),
+			boxRepo.EXPECT().
+				UpdateWithPatternID(ctx, gomock.Any()).
+				Return(int64(1), nil).
+				Times(1),
+			itemRepo.EXPECT().
+				LockUnFinishedItemsByBoxID(ctx, "44444444-4444-4444-4444-444444444444", "11111111-1111-1111-1111-111111111111").
+				Return(nil).
+				Times(1),
+		}
+	}
+	expectUpdateItemsPattern := func(itemRepo *itemDomain.MockIItemRepository) any {
+		return itemRepo.EXPECT().
+			UpdateItemsPatternIDByBoxID(ctx, "44444444-4444-4444-4444-444444444444", "11111111-1111-1111-1111-111111111111", "55555555-5555-5555-5555-555555555555").
+			Return(nil).
+			Times(1)
+	}
+	patternChangeInput := UpdateBoxInput{
+		ID:         "44444444-4444-4444-4444-444444444444",
+		UserID:     "11111111-1111-1111-1111-111111111111",
+		CategoryID: "22222222-2222-2222-2222-222222222222",
+		PatternID:  "55555555-5555-5555-5555-555555555555",
+		Name:       "パターン変更されたボックス",
+		Today:      "2024-01-03",
+	}
+	patternChangeDryRunInput := patternChangeInput
+	patternChangeDryRunInput.IsDryRun = true
+
+	replannedItemOutput := ReplannedItemOutput{
+		ItemID:     "item-1",
+		Name:       "復習物1",
+		IsFinished: false,
+		ReviewDates: []ReplannedReviewDateOutput{
+			{StepNumber: 1, ScheduledDate: "2024-01-02", IsCompleted: true},
+			{StepNumber: 2, ScheduledDate: "2024-01-08", IsCompleted: false},
+		},
+	}
 
 	tests := []struct {
 		name      string
 		input     UpdateBoxInput
-		setupMock func(*boxDomain.MockIBoxRepository)
+		setupMock func(*boxDomain.MockIBoxRepository, *itemDomain.MockIItemRepository, *patternDomain.MockIPatternRepository, *transaction.MockITransactionManager, *itemDomain.MockIScheduler)
 		want      *UpdateBoxOutput
 		wantErr   bool
+		// 返るエラーを確認する場合に指定する
+		wantErrIs error
 	}{
 		{
 			name: "正常系_同じパターンIDでの名前変更",
@@ -237,38 +387,29 @@ func TestUpdateBox(t *testing.T) {
 				PatternID:  "33333333-3333-3333-3333-333333333333",
 				Name:       "更新された英語学習ボックス",
 			},
-			setupMock: func(m *boxDomain.MockIBoxRepository) {
-				existing, _ := boxDomain.ReconstructBox(
-					"44444444-4444-4444-4444-444444444444",
-					"11111111-1111-1111-1111-111111111111",
-					"22222222-2222-2222-2222-222222222222",
-					"33333333-3333-3333-3333-333333333333",
-					"英語学習ボックス",
-					mockTime,
-					mockTime,
-				)
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
 				gomock.InOrder(
-					m.EXPECT().
+					boxRepo.EXPECT().
 						GetByID(ctx, "44444444-4444-4444-4444-444444444444", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
-						Return(existing, nil).
+						Return(newExistingBox(), nil).
 						Times(1),
-					m.EXPECT().
+					boxRepo.EXPECT().
 						Update(ctx, gomock.Any()).
 						Return(nil).
 						Times(1),
 				)
 			},
 			want: &UpdateBoxOutput{
-				ID:         "44444444-4444-4444-4444-444444444444",
-				UserID:     "11111111-1111-1111-1111-111111111111",
-				CategoryID: "22222222-2222-2222-2222-222222222222",
-				PatternID:  "33333333-3333-3333-3333-333333333333",
-				Name:       "更新された英語学習ボックス",
+				ID:             "44444444-4444-4444-4444-444444444444",
+				UserID:         "11111111-1111-1111-1111-111111111111",
+				CategoryID:     "22222222-2222-2222-2222-222222222222",
+				PatternID:      "33333333-3333-3333-3333-333333333333",
+				Name:           "更新された英語学習ボックス",
+				ReplannedItems: []ReplannedItemOutput{},
 			},
-			wantErr: false,
 		},
 		{
-			name: "正常系_異なるパターンIDでの更新成功",
+			name: "正常系_未完了の復習物がないボックスのパターン変更",
 			input: UpdateBoxInput{
 				ID:         "44444444-4444-4444-4444-444444444444",
 				UserID:     "11111111-1111-1111-1111-111111111111",
@@ -276,26 +417,94 @@ func TestUpdateBox(t *testing.T) {
 				PatternID:  "55555555-5555-5555-5555-555555555555",
 				Name:       "パターン変更されたボックス",
 			},
-			setupMock: func(m *boxDomain.MockIBoxRepository) {
-				existing, _ := boxDomain.ReconstructBox(
-					"44444444-4444-4444-4444-444444444444",
-					"11111111-1111-1111-1111-111111111111",
-					"22222222-2222-2222-2222-222222222222",
-					"33333333-3333-3333-3333-333333333333",
-					"英語学習ボックス",
-					mockTime,
-					mockTime,
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectTransaction(boxRepo, itemRepo, txManager)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						GetAllUnFinishedItemsByBoxID(ctx, "44444444-4444-4444-4444-444444444444", "11111111-1111-1111-1111-111111111111").
+						Return([]*itemDomain.Item{}, nil).
+						Times(1),
+					expectUpdateItemsPattern(itemRepo),
 				)
-				gomock.InOrder(
-					m.EXPECT().
-						GetByID(ctx, "44444444-4444-4444-4444-444444444444", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
-						Return(existing, nil).
+				gomock.InOrder(calls...)
+			},
+			want: &UpdateBoxOutput{
+				ID:             "44444444-4444-4444-4444-444444444444",
+				UserID:         "11111111-1111-1111-1111-111111111111",
+				CategoryID:     "22222222-2222-2222-2222-222222222222",
+				PatternID:      "55555555-5555-5555-5555-555555555555",
+				Name:           "パターン変更されたボックス",
+				ReplannedItems: []ReplannedItemOutput{},
+			},
+		},
+		{
+			name:  "正常系_パターン変更で完了済みの復習日を残して以降の復習日を組み直す",
+			input: patternChangeInput,
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectTransaction(boxRepo, itemRepo, txManager)
+				calls = append(calls, expectReplan(itemRepo, patternRepo, scheduler, []*itemDomain.Reviewdate{replannedReviewdate2})...)
+				calls = append(calls,
+					expectUpdateItemsPattern(itemRepo),
+					itemRepo.EXPECT().
+						DeleteInCompletedReviewDatesAfterStep(ctx, "item-1", "11111111-1111-1111-1111-111111111111", 1).
+						Return(nil).
 						Times(1),
-					m.EXPECT().
-						UpdateWithPatternID(ctx, gomock.Any()).
+					itemRepo.EXPECT().
+						CreateReviewdates(ctx, []*itemDomain.Reviewdate{replannedReviewdate2}).
 						Return(int64(1), nil).
 						Times(1),
 				)
+				gomock.InOrder(calls...)
+			},
+			want: &UpdateBoxOutput{
+				ID:             "44444444-4444-4444-4444-444444444444",
+				UserID:         "11111111-1111-1111-1111-111111111111",
+				CategoryID:     "22222222-2222-2222-2222-222222222222",
+				PatternID:      "55555555-5555-5555-5555-555555555555",
+				Name:           "パターン変更されたボックス",
+				ReplannedItems: []ReplannedItemOutput{replannedItemOutput},
+			},
+		},
+		{
+			name:  "正常系_ドライランの場合は組み直した結果を返して永続化しない",
+			input: patternChangeDryRunInput,
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := []any{
+					boxRepo.EXPECT().
+						GetByID(ctx, "44444444-4444-4444-4444-444444444444", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
+						Return(newExistingBox(), nil).
+						Times(1),
+				}
+				calls = append(calls, expectReplan(itemRepo, patternRepo, scheduler, []*itemDomain.Reviewdate{replannedReviewdate2})...)
+				gomock.InOrder(calls...)
+			},
+			want: &UpdateBoxOutput{
+				ID:             "44444444-4444-4444-4444-444444444444",
+				UserID:         "11111111-1111-1111-1111-111111111111",
+				CategoryID:     "22222222-2222-2222-2222-222222222222",
+				PatternID:      "55555555-5555-5555-5555-555555555555",
+				Name:           "パターン変更されたボックス",
+				ReplannedItems: []ReplannedItemOutput{replannedItemOutput},
+			},
+		},
+		{
+			name:  "正常系_新しいパターンの全ステップを完了している復習物は完了にする",
+			input: patternChangeInput,
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectTransaction(boxRepo, itemRepo, txManager)
+				calls = append(calls, expectReplan(itemRepo, patternRepo, scheduler, []*itemDomain.Reviewdate{})...)
+				calls = append(calls,
+					expectUpdateItemsPattern(itemRepo),
+					itemRepo.EXPECT().
+						DeleteInCompletedReviewDatesAfterStep(ctx, "item-1", "11111111-1111-1111-1111-111111111111", 1).
+						Return(nil).
+						Times(1),
+					itemRepo.EXPECT().
+						UpdateItemAsFinished(ctx, "item-1", "11111111-1111-1111-1111-111111111111", gomock.Any()).
+						Return(nil).
+						Times(1),
+				)
+				gomock.InOrder(calls...)
 			},
 			want: &UpdateBoxOutput{
 				ID:         "44444444-4444-4444-4444-444444444444",
@@ -303,8 +512,64 @@ func TestUpdateBox(t *testing.T) {
 				CategoryID: "22222222-2222-2222-2222-222222222222",
 				PatternID:  "55555555-5555-5555-5555-555555555555",
 				Name:       "パターン変更されたボックス",
+				ReplannedItems: []ReplannedItemOutput{
+					{
+						ItemID:     "item-1",
+						Name:       "復習物1",
+						IsFinished: true,
+						ReviewDates: []ReplannedReviewDateOutput{
+							{StepNumber: 1, ScheduledDate: "2024-01-02", IsCompleted: true},
+						},
+					},
+				},
 			},
-			wantErr: false,
+		},
+		{
+			name:  "異常系_適応型のパターンへの変更",
+			input: patternChangeInput,
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectTransaction(boxRepo, itemRepo, txManager)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						GetAllUnFinishedItemsByBoxID(ctx, "44444444-4444-4444-4444-444444444444", "11111111-1111-1111-1111-111111111111").
+						Return([]*itemDomain.Item{item1}, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, "55555555-5555-5555-5555-555555555555", "11111111-1111-1111-1111-111111111111").
+						Return(newPattern(patternDomain.SchedulingAlgorithmSM2Adaptive), nil).
+						Times(1),
+				)
+				gomock.InOrder(calls...)
+			},
+			wantErr:   true,
+			wantErrIs: patternDomain.ErrAdaptivePatternReschedule,
+		},
+		{
+			name:  "異常系_復習物のロックに失敗した場合は組み直さない",
+			input: patternChangeInput,
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				gomock.InOrder(
+					boxRepo.EXPECT().
+						GetByID(ctx, "44444444-4444-4444-4444-444444444444", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
+						Return(newExistingBox(), nil).
+						Times(1),
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					boxRepo.EXPECT().
+						UpdateWithPatternID(ctx, gomock.Any()).
+						Return(int64(1), nil).
+						Times(1),
+					itemRepo.EXPECT().
+						LockUnFinishedItemsByBoxID(ctx, "44444444-4444-4444-4444-444444444444", "11111111-1111-1111-1111-111111111111").
+						Return(errors.New("lock timeout")).
+						Times(1),
+				)
+			},
+			wantErr: true,
 		},
 		{
 			name: "異常系_存在しないボックスでの更新失敗",
@@ -315,15 +580,14 @@ func TestUpdateBox(t *testing.T) {
 				PatternID:  "33333333-3333-3333-3333-333333333333",
 				Name:       "更新テスト",
 			},
-			setupMock: func(m *boxDomain.MockIBoxRepository) {
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
 				gomock.InOrder(
-					m.EXPECT().
+					boxRepo.EXPECT().
 						GetByID(ctx, "nonexistent-box-id", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
 						Return(nil, errors.New("box not found")).
 						Times(1),
 				)
 			},
-			want:    nil,
 			wantErr: true,
 		},
 		{
@@ -335,24 +599,14 @@ func TestUpdateBox(t *testing.T) {
 				PatternID:  "33333333-3333-3333-3333-333333333333",
 				Name:       "",
 			},
-			setupMock: func(m *boxDomain.MockIBoxRepository) {
-				existingBox, _ := boxDomain.ReconstructBox(
-					"44444444-4444-4444-4444-444444444444",
-					"11111111-1111-1111-1111-111111111111",
-					"22222222-2222-2222-2222-222222222222",
-					"33333333-3333-3333-3333-333333333333",
-					"英語学習ボックス",
-					mockTime,
-					mockTime,
-				)
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
 				gomock.InOrder(
-					m.EXPECT().
+					boxRepo.EXPECT().
 						GetByID(gomock.Any(), "44444444-4444-4444-4444-444444444444", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
-						Return(existingBox, nil).
+						Return(newExistingBox(), nil).
 						Times(1),
 				)
 			},
-			want:    nil,
 			wantErr: true,
 		},
 		{
@@ -364,28 +618,18 @@ func TestUpdateBox(t *testing.T) {
 				PatternID:  "33333333-3333-3333-3333-333333333333",
 				Name:       "更新された英語学習ボックス",
 			},
-			setupMock: func(m *boxDomain.MockIBoxRepository) {
-				existing, _ := boxDomain.ReconstructBox(
-					"44444444-4444-4444-4444-444444444444",
-					"11111111-1111-1111-1111-111111111111",
-					"22222222-2222-2222-2222-222222222222",
-					"33333333-3333-3333-3333-333333333333",
-					"英語学習ボックス",
-					mockTime,
-					mockTime,
-				)
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
 				gomock.InOrder(
-					m.EXPECT().
+					boxRepo.EXPECT().
 						GetByID(ctx, "44444444-4444-4444-4444-444444444444", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
-						Return(existing, nil).
+						Return(newExistingBox(), nil).
 						Times(1),
-					m.EXPECT().
+					boxRepo.EXPECT().
 						Update(ctx, gomock.Any()).
 						Return(errors.New("database error")).
 						Times(1),
 				)
 			},
-			want:    nil,
 			wantErr: true,
 		},
 		{
@@ -397,32 +641,28 @@ func TestUpdateBox(t *testing.T) {
 				PatternID:  "55555555-5555-5555-5555-555555555555",
 				Name:       "パターン変更されたボックス",
 			},
-			setupMock: func(m *boxDomain.MockIBoxRepository) {
-				existing, _ := boxDomain.ReconstructBox(
-					"44444444-4444-4444-4444-444444444444",
-					"11111111-1111-1111-1111-111111111111",
-					"22222222-2222-2222-2222-222222222222",
-					"33333333-3333-3333-3333-333333333333",
-					"英語学習ボックス",
-					mockTime,
-					mockTime,
-				)
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
 				gomock.InOrder(
-					m.EXPECT().
+					boxRepo.EXPECT().
 						GetByID(ctx, "44444444-4444-4444-4444-444444444444", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
-						Return(existing, nil).
+						Return(newExistingBox(), nil).
 						Times(1),
-					m.EXPECT().
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					boxRepo.EXPECT().
 						UpdateWithPatternID(ctx, gomock.Any()).
 						Return(int64(0), errors.New("database error")).
 						Times(1),
 				)
 			},
-			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "異常系_パターン変更時の競合エラー",
+			name: "異常系_パターン変更時に更新対象のボックスが存在しない",
 			input: UpdateBoxInput{
 				ID:         "44444444-4444-4444-4444-444444444444",
 				CategoryID: "22222222-2222-2222-2222-222222222222",
@@ -430,29 +670,26 @@ func TestUpdateBox(t *testing.T) {
 				PatternID:  "55555555-5555-5555-5555-555555555555",
 				Name:       "パターン変更されたボックス",
 			},
-			setupMock: func(m *boxDomain.MockIBoxRepository) {
-				existing, _ := boxDomain.ReconstructBox(
-					"44444444-4444-4444-4444-444444444444",
-					"11111111-1111-1111-1111-111111111111",
-					"22222222-2222-2222-2222-222222222222",
-					"33333333-3333-3333-3333-333333333333",
-					"英語学習ボックス",
-					mockTime,
-					mockTime,
-				)
+			setupMock: func(boxRepo *boxDomain.MockIBoxRepository, itemRepo *itemDomain.MockIItemRepository, patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
 				gomock.InOrder(
-					m.EXPECT().
+					boxRepo.EXPECT().
 						GetByID(ctx, "44444444-4444-4444-4444-444444444444", "22222222-2222-2222-2222-222222222222", "11111111-1111-1111-1111-111111111111").
-						Return(existing, nil).
+						Return(newExistingBox(), nil).
 						Times(1),
-					m.EXPECT().
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					boxRepo.EXPECT().
 						UpdateWithPatternID(ctx, gomock.Any()).
 						Return(int64(0), nil).
 						Times(1),
 				)
 			},
-			want:    nil,
-			wantErr: true,
+			wantErr:   true,
+			wantErrIs: boxDomain.ErrBoxNotFound,
 		},
 	}
 
@@ -464,9 +701,13 @@ func TestUpdateBox(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockRepo := boxDomain.NewMockIBoxRepository(ctrl)
-			tt.setupMock(mockRepo)
+			mockItemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			mockPatternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			mockTxManager := transaction.NewMockITransactionManager(ctrl)
+			mockScheduler := itemDomain.NewMockIScheduler(ctrl)
+			tt.setupMock(mockRepo, mockItemRepo, mockPatternRepo, mockTxManager, mockScheduler)
 
-			usecase := NewBoxUsecase(mockRepo)
+			usecase := NewBoxUsecase(mockRepo, mockItemRepo, mockPatternRepo, mockTxManager, mockScheduler)
 			got, err := usecase.UpdateBox(ctx, tt.input)
 
 			if (err != nil) != tt.wantErr {
@@ -474,6 +715,9 @@ func TestUpdateBox(t *testing.T) {
 				return
 			}
 			if tt.wantErr {
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("UpdateBox() error = %v, wantErrIs %v", err, tt.wantErrIs)
+				}
 				return
 			}
 
@@ -556,7 +800,7 @@ func TestDeleteBox(t *testing.T) {
 			mockRepo := boxDomain.NewMockIBoxRepository(ctrl)
 			tt.setupMock(mockRepo)
 
-			usecase := NewBoxUsecase(mockRepo)
+			usecase := NewBoxUsecase(mockRepo, itemDomain.NewMockIItemRepository(ctrl), patternDomain.NewMockIPatternRepository(ctrl), transaction.NewMockITransactionManager(ctrl), itemDomain.NewMockIScheduler(ctrl))
 			err := usecase.DeleteBox(ctx, tt.boxID, tt.categoryID, tt.userID)
 
 			if (err != nil) != tt.wantErr {
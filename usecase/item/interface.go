@@ -31,9 +31,15 @@ type IItemUsecase interface {
 	// 今日の全復習日数を取得する
 	CountAllDailyReviewDates(ctx context.Context, userID string, today string) (int, error)
 
+	// fromから指定日数分の各日の復習日数を取得する
+	GetReviewForecast(ctx context.Context, userID string, from string, days int) (*ReviewForecastOutput, error)
+
 	// 今日の復習日一覧を取得する
 	GetAllDailyReviewDates(ctx context.Context, userID string, today string) (*GetDailyReviewDatesOutput, error)
 
+	// 分・時間単位のステップのうち、復習日時を過ぎた未完了の復習日一覧を取得する
+	GetDueNowReviewDates(ctx context.Context, userID string) ([]*DueNowReviewDateOutput, error)
+
 	// 完了済み復習物を取得する系
 	GetFinishedItemsByBoxID(ctx context.Context, boxID string, userID string) ([]*GetItemOutput, error)
 	GetUnclassfiedFinishedItemsByCategoryID(ctx context.Context, userID string, categoryID string) ([]*GetItemOutput, error)
@@ -22,6 +22,7 @@ type CreateReviewdateOutput struct {
 	InitialScheduledDate string
 	ScheduledDate        string
 	IsCompleted          bool
+	ScheduledAt          *time.Time // 分・時間単位のステップの復習日時。日単位以上のステップではnil
 }
 
 type CreateItemOutput struct {
@@ -65,6 +66,8 @@ type UpdateReviewDateOutput struct {
 	InitialScheduledDate string
 	ScheduledDate        string
 	IsCompleted          bool
+	ScheduledAt          *time.Time // 分・時間単位のステップの復習日時。日単位以上のステップではnil
+	RecallGrade          *string    // nilなら未評価
 }
 
 type UpdateItemOutput struct {
@@ -118,6 +121,8 @@ type UpdateReviewDateAsCompletedInput struct {
 	UserID       string
 	ItemID       string
 	StepNumber   int
+	RecallGrade  string // 空文字なら評価なし
+	Today        string // 想起評価による再計算と適応型・繰り返しの次の復習日の算出に使用
 }
 
 // 全ての復習日が完了したかどうかも返す（IsFinished）
@@ -126,7 +131,9 @@ type UpdateReviewDateAsCompletedOutput struct {
 	UserID       string
 	IsCompleted  bool
 	IsFinished   bool
+	RecallGrade  *string
 	EditedAt     time.Time
+	ReviewDates  []UpdateReviewDateOutput // 想起評価によって再計算された後続の復習日、または生成された次の復習日のみ
 }
 
 type UpdateReviewDateAsInCompletedInput struct {
@@ -193,6 +200,8 @@ type GetReviewDateOutput struct {
 	InitialScheduledDate string
 	ScheduledDate        string
 	IsCompleted          bool
+	ScheduledAt          *time.Time // 分・時間単位のステップの復習日時。日単位以上のステップではnil
+	RecallGrade          *string    // nilなら未評価
 }
 
 type GetItemOutput struct {
@@ -233,6 +242,22 @@ type UnclassifiedDailyDatesCountGroupedByCategoryOutput struct {
 	Count      int
 }
 
+// 復習量の予測
+// UnclassifiedCountはホーム画面の未分類（カテゴリーもボックスもない）の復習日数
+type ForecastDayOutput struct {
+	Date                   string
+	TotalCount             int
+	ByBox                  []DailyCountGroupedByBoxOutput
+	UnclassifiedByCategory []UnclassifiedDailyDatesCountGroupedByCategoryOutput
+	UnclassifiedCount      int
+}
+
+type ReviewForecastOutput struct {
+	From string
+	To   string
+	Days []ForecastDayOutput
+}
+
 /*
 変更したい日付の変更前が今日じゃないならエラー→これTZ考慮必要じゃん
 変更後の日付がinitial_scheduled_dateより前ならエラー
@@ -250,6 +275,7 @@ type DailyReviewDatesByBoxOutput struct {
 	ScheduledDate        string
 	NextScheduledDate    *string
 	IsCompleted          bool
+	IsOverdue            bool
 
 	// 復習物の情報
 	ItemID       string
@@ -277,6 +303,7 @@ type UnclassifiedDailyReviewDatesGroupedByCategoryOutput struct {
 	ScheduledDate        string
 	NextScheduledDate    *string
 	IsCompleted          bool
+	IsOverdue            bool
 
 	// 復習物の情報
 	ItemID       string
@@ -302,6 +329,7 @@ type UnclassifiedDailyReviewDatesGroupedByUserOutput struct {
 	ScheduledDate        string
 	NextScheduledDate    *string
 	IsCompleted          bool
+	IsOverdue            bool
 
 	// 復習物の情報
 	ItemID       string
@@ -316,3 +344,18 @@ type GetDailyReviewDatesOutput struct {
 	Categories                    []DailyReviewDatesGroupedByCategoryOutput
 	DailyReviewDatesGroupedByUser []UnclassifiedDailyReviewDatesGroupedByUserOutput
 }
+
+type DueNowReviewDateOutput struct {
+	ReviewDateID  string
+	CategoryID    *string // nilなら未分類
+	BoxID         *string // nilなら未分類
+	StepNumber    int
+	ScheduledDate string
+	ScheduledAt   time.Time
+
+	// 復習物の情報
+	ItemID      string
+	ItemName    string
+	Detail      string
+	LearnedDate string
+}
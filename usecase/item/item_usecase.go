@@ -39,6 +39,69 @@ func NewItemUsecase(
 	}
 }
 
+// パターンに設定されたスケジューリング方式で復習日を算出するスケジューラを取得
+// 負荷分散の有無など、パターンの設定を参照するためにパターンも返す
+func (iu *ItemUsecase) schedulerByPatternID(ctx context.Context, patternID string, userID string) (ItemDomain.IScheduler, *PatternDomain.Pattern, error) {
+	targetPattern, err := iu.patternRepo.FindPatternByPatternID(ctx, patternID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	scheduler, err := iu.scheduler.WithAlgorithm(targetPattern.SchedulingAlgorithm())
+	if err != nil {
+		return nil, nil, err
+	}
+	scheduler, err = iu.withSchedulingRestrictions(ctx, scheduler, targetPattern, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return scheduler, targetPattern, nil
+}
+
+// 復習物が記録しているパターンの版を取得する。版のない復習物はnilを返す
+// パターンのステップや方式を変更しても、変更前の版で復習日を算出した復習物は元のステップと方式で復習日を算出し続ける
+func (iu *ItemUsecase) findItemPatternVersion(ctx context.Context, targetItem *ItemDomain.Item) (*PatternDomain.PatternVersion, error) {
+	if targetItem.PatternVersionID() == nil {
+		return nil, nil
+	}
+	return iu.patternRepo.FindPatternVersionByID(ctx, *targetItem.PatternVersionID(), targetItem.UserID())
+}
+
+// 復習物の版のステップを返す。版のない復習物はパターンの現在のステップを使う
+func (iu *ItemUsecase) itemPatternSteps(ctx context.Context, itemVersion *PatternDomain.PatternVersion, patternID string, userID string) ([]*PatternDomain.PatternStep, error) {
+	if itemVersion != nil {
+		return itemVersion.Steps(), nil
+	}
+	return iu.patternRepo.GetAllPatternStepsByPatternID(ctx, patternID, userID)
+}
+
+// 復習物の版の方式を返す。版のない復習物はパターンの現在の方式を使う
+func itemSchedulingAlgorithm(itemVersion *PatternDomain.PatternVersion, targetPattern *PatternDomain.Pattern) string {
+	if itemVersion != nil {
+		return itemVersion.SchedulingAlgorithm()
+	}
+	return targetPattern.SchedulingAlgorithm()
+}
+
+// パターンとユーザー設定の除外する曜日、ユーザーの復習日を置かない日付を避けて復習日を算出するスケジューラを返す
+// 復習物の作成・更新・復習日の変更のどの操作でも同じ日を避けるために、スケジューラを取得した直後に適用する
+func (iu *ItemUsecase) withSchedulingRestrictions(
+	ctx context.Context,
+	scheduler ItemDomain.IScheduler,
+	targetPattern *PatternDomain.Pattern,
+	userID string,
+) (ItemDomain.IScheduler, error) {
+	userExcludedWeekdays, err := iu.itemRepo.GetExcludedWeekdaysByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	blockedDates, err := iu.itemRepo.GetBlockedDatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	excludedWeekdays := ItemDomain.NewExcludedWeekdays(targetPattern.ExcludedWeekdays(), userExcludedWeekdays)
+	return scheduler.WithExcludedWeekdays(excludedWeekdays).WithBlockedDates(ItemDomain.NewBlockedDates(blockedDates)), nil
+}
+
 // 復習物作成
 func (iu *ItemUsecase) CreateItem(ctx context.Context, in CreateItemInput) (*CreateItemOutput, error) {
 	ItemID := uuid.NewString()
@@ -95,6 +158,10 @@ func (iu *ItemUsecase) CreateItem(ctx context.Context, in CreateItemInput) (*Cre
 		if err != nil {
 			return nil, err
 		}
+		scheduler, targetPattern, err := iu.schedulerByPatternID(ctx, *in.PatternID, in.UserID)
+		if err != nil {
+			return nil, err
+		}
 		parsedToday, err := time.Parse("2006-01-02", in.Today)
 		if err != nil {
 			return nil, err
@@ -102,7 +169,7 @@ func (iu *ItemUsecase) CreateItem(ctx context.Context, in CreateItemInput) (*Cre
 
 		if in.IsMarkOverdueAsCompleted {
 			var isFinished bool
-			newReviewdates, isFinished, err = iu.scheduler.FormatWithOverdueMarkedCompleted(
+			newReviewdates, isFinished, err = scheduler.FormatWithOverdueMarkedCompleted(
 				targetPatternSteps,
 				in.UserID,
 				in.CategoryID,
@@ -114,6 +181,16 @@ func (iu *ItemUsecase) CreateItem(ctx context.Context, in CreateItemInput) (*Cre
 			if err != nil {
 				return nil, err
 			}
+			// 繰り返しの間隔日数が設定されている場合は完了にせず、最後の復習日の次の復習日を追加する
+			if isFinished && targetPattern.HasMaintenanceInterval() {
+				lastReviewdate := newReviewdates[len(newReviewdates)-1]
+				nextReviewdate, err := scheduler.NextMaintenanceReviewdate(lastReviewdate, targetPattern.MaintenanceIntervalDays(), lastReviewdate.ScheduledDate(), parsedToday)
+				if err != nil {
+					return nil, err
+				}
+				newReviewdates = append(newReviewdates, nextReviewdate)
+				isFinished = false
+			}
 			// もし最後のステップが今日より前なら（復習物作成の時点で全復習日完了扱いなら）、newItem.isFinishedをtrueにする
 			if isFinished {
 				// isFinished=trueで新しいItemを作成
@@ -135,7 +212,7 @@ func (iu *ItemUsecase) CreateItem(ctx context.Context, in CreateItemInput) (*Cre
 				}
 			}
 		} else {
-			newReviewdates, err = iu.scheduler.FormatWithOverdueMarkedInCompleted(
+			newReviewdates, err = scheduler.FormatWithOverdueMarkedInCompleted(
 				targetPatternSteps,
 				in.UserID,
 				in.CategoryID,
@@ -148,6 +225,11 @@ func (iu *ItemUsecase) CreateItem(ctx context.Context, in CreateItemInput) (*Cre
 				return nil, err
 			}
 		}
+
+		newReviewdates, err = ItemDomain.AdjustReviewdatesToDailyLoad(ctx, iu.itemRepo, scheduler, targetPattern.IsLoadBalanced(), newReviewdates, in.UserID, parsedLearnedDate, parsedToday)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// 永続化
@@ -193,6 +275,7 @@ func (iu *ItemUsecase) CreateItem(ctx context.Context, in CreateItemInput) (*Cre
 			InitialScheduledDate: rs.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        rs.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          rs.IsCompleted(),
+			ScheduledAt:          rs.ScheduledAt(),
 		}
 	}
 	return out, nil
@@ -287,12 +370,18 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 	}
 
 	var requstedSelectedPatternSteps []*PatternDomain.PatternStep
+	var requestedScheduler ItemDomain.IScheduler
+	var requestedPattern *PatternDomain.Pattern
 	// NULL → NOT NULLの場合はINSERTクエリ確定でパターンの比較が不要なのでrequstedSelectedPatternStepsだけ取得
 	if isPatternNilToNotNil {
 		requstedSelectedPatternSteps, err = iu.patternRepo.GetAllPatternStepsByPatternID(ctx, *input.PatternID, input.UserID)
 		if err != nil {
 			return nil, err
 		}
+		requestedScheduler, requestedPattern, err = iu.schedulerByPatternID(ctx, *input.PatternID, input.UserID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// NOT NULL →　NOT NULLの場合はINSERTクエリかUPDATEクエリで済むか判別するためのフラグを作成する必要があるのでrequstedSelectedPatternStepsとcurrentSelectedPatternSteps両方取得
@@ -317,6 +406,22 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 		if err != nil {
 			return nil, err
 		}
+		currentPattern, err := iu.patternRepo.FindPatternByPatternID(ctx, *currentItem.PatternID(), currentItem.UserID())
+		if err != nil {
+			return nil, err
+		}
+		requestedPattern, err = iu.patternRepo.FindPatternByPatternID(ctx, *input.PatternID, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		requestedScheduler, err = iu.scheduler.WithAlgorithm(requestedPattern.SchedulingAlgorithm())
+		if err != nil {
+			return nil, err
+		}
+		requestedScheduler, err = iu.withSchedulingRestrictions(ctx, requestedScheduler, requestedPattern, input.UserID)
+		if err != nil {
+			return nil, err
+		}
 
 		// a. pattern_idを外部キーに持つpattern_stepsのレコード数の長さが異なるか
 		// 2, 3
@@ -326,9 +431,13 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 
 		// b. pattern_idを外部キーに持つpattern_stepsのレコード数の長さは同じだが、interval_daysの構成が異なるか
 		// 2, 3
-		if !isPatternStepsLengthDiff {
+		// スケジューリング方式が異なる場合は、interval_daysが同じでも復習日が変わるので構成が異なるとみなす
+		if !isPatternStepsLengthDiff && currentPattern.SchedulingAlgorithm() != requestedPattern.SchedulingAlgorithm() {
+			isOnlyPatternStepsIntervalDaysDiff = true
+		}
+		if !isPatternStepsLengthDiff && !isOnlyPatternStepsIntervalDaysDiff {
 			for i, currentStep := range currentSelectedPatternSteps {
-				if currentStep.IntervalDays() != requstedSelectedPatternSteps[i].IntervalDays() {
+				if currentStep.IntervalDays() != requstedSelectedPatternSteps[i].IntervalDays() || currentStep.IntervalUnit() != requstedSelectedPatternSteps[i].IntervalUnit() {
 					isOnlyPatternStepsIntervalDaysDiff = true
 					break
 				}
@@ -365,12 +474,14 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 		return nil, err
 	}
 	var newReviewdates []*ItemDomain.Reviewdate
+	// 既存のIDを再利用する場合に、繰り返しの間隔日数で末尾に追加した復習日の数
+	appendedReviewdateCount := 0
 
 	if isPatternNilToNotNil || isPatternStepsLengthDiff {
 		//　IDを新規作成
 		if input.IsMarkOverdueAsCompleted {
 			var isFinished bool
-			newReviewdates, isFinished, err = iu.scheduler.FormatWithOverdueMarkedCompleted(
+			newReviewdates, isFinished, err = requestedScheduler.FormatWithOverdueMarkedCompleted(
 				requstedSelectedPatternSteps,
 				input.UserID,
 				input.CategoryID,
@@ -382,6 +493,16 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 			if err != nil {
 				return nil, err
 			}
+			// 繰り返しの間隔日数が設定されている場合は完了にせず、最後の復習日の次の復習日を追加する
+			if isFinished && requestedPattern.HasMaintenanceInterval() {
+				lastReviewdate := newReviewdates[len(newReviewdates)-1]
+				nextReviewdate, err := requestedScheduler.NextMaintenanceReviewdate(lastReviewdate, requestedPattern.MaintenanceIntervalDays(), lastReviewdate.ScheduledDate(), parsedToday)
+				if err != nil {
+					return nil, err
+				}
+				newReviewdates = append(newReviewdates, nextReviewdate)
+				isFinished = false
+			}
 			// もし最後のステップが今日より前なら（復習物作成の時点で全復習日完了扱いなら）、newItem.isFinishedをtrueにする
 			if isFinished {
 				// 完了状態で新しいItemを作成
@@ -403,7 +524,7 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 				}
 			}
 		} else {
-			newReviewdates, err = iu.scheduler.FormatWithOverdueMarkedInCompleted(
+			newReviewdates, err = requestedScheduler.FormatWithOverdueMarkedInCompleted(
 				requstedSelectedPatternSteps,
 				input.UserID,
 				input.CategoryID,
@@ -429,7 +550,7 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 		}
 		var isFinished bool
 		if input.IsMarkOverdueAsCompleted {
-			newReviewdates, isFinished, err = iu.scheduler.FormatWithOverdueMarkedCompletedWithIDs(
+			newReviewdates, isFinished, err = requestedScheduler.FormatWithOverdueMarkedCompletedWithIDs(
 				requstedSelectedPatternSteps,
 				reviewDateIDs,
 				input.UserID,
@@ -442,6 +563,18 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 			if err != nil {
 				return nil, err
 			}
+			// 繰り返しの間隔日数が設定されている場合は完了にせず、最後の復習日の次の復習日を追加する
+			// 追加した復習日は既存のIDを持たないので、永続化の際は新規作成する
+			if isFinished && requestedPattern.HasMaintenanceInterval() {
+				lastReviewdate := newReviewdates[len(newReviewdates)-1]
+				nextReviewdate, err := requestedScheduler.NextMaintenanceReviewdate(lastReviewdate, requestedPattern.MaintenanceIntervalDays(), lastReviewdate.ScheduledDate(), parsedToday)
+				if err != nil {
+					return nil, err
+				}
+				newReviewdates = append(newReviewdates, nextReviewdate)
+				appendedReviewdateCount = 1
+				isFinished = false
+			}
 			// もし最後のステップが今日より前なら（復習物作成の時点で全復習日完了扱いなら）、newItem.isFinishedをtrueにする
 			if isFinished {
 				// 完了状態で新しいItemを作成
@@ -463,7 +596,7 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 				}
 			}
 		} else {
-			newReviewdates, err = iu.scheduler.FormatWithOverdueMarkedInCompletedWithIDs(
+			newReviewdates, err = requestedScheduler.FormatWithOverdueMarkedInCompletedWithIDs(
 				requstedSelectedPatternSteps,
 				reviewDateIDs,
 				input.UserID,
@@ -479,6 +612,14 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 		}
 	}
 
+	// 2で復習日を再計算した場合、日毎の復習予定数に合わせて調整する
+	if len(newReviewdates) > 0 {
+		newReviewdates, err = ItemDomain.AdjustReviewdatesToDailyLoad(ctx, iu.itemRepo, requestedScheduler, requestedPattern.IsLoadBalanced(), newReviewdates, input.UserID, persedInputLearnedDate, parsedToday)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// 3. review_datesのcategory_idとbox_idだけの更新を行うためにnewReviewdatesを生成する処理。
 	isOnlyCategoryIDBoxIDUpdate := false // category_idとbox_idだけの永続化処理を行うためのフラグ
 	if (isSamePatternStepsStructure || isSamePatternID) && (!isLearnedDateChanged) &&
@@ -490,17 +631,32 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 		}
 		newReviewdates = make([]*ItemDomain.Reviewdate, len(currentReviewdates))
 		for i, rd := range currentReviewdates {
-			newReviewdates[i], err = ItemDomain.NewReviewdate(
-				rd.ReviewdateID(),
-				rd.UserID(),
-				rd.CategoryID(),
-				rd.BoxID(),
-				rd.ItemID(),
-				rd.StepNumber(),
-				rd.InitialScheduledDate(),
-				rd.ScheduledDate(),
-				rd.IsCompleted(),
-			)
+			// 分・時間単位のステップの復習日は、復習日時も永続化し直すので引き継ぐ
+			if rd.IsIntraday() {
+				newReviewdates[i], err = ItemDomain.NewIntradayReviewdate(
+					rd.ReviewdateID(),
+					rd.UserID(),
+					rd.CategoryID(),
+					rd.BoxID(),
+					rd.ItemID(),
+					rd.StepNumber(),
+					rd.ScheduledDate(),
+					*rd.ScheduledAt(),
+					rd.IsCompleted(),
+				)
+			} else {
+				newReviewdates[i], err = ItemDomain.NewReviewdate(
+					rd.ReviewdateID(),
+					rd.UserID(),
+					rd.CategoryID(),
+					rd.BoxID(),
+					rd.ItemID(),
+					rd.StepNumber(),
+					rd.InitialScheduledDate(),
+					rd.ScheduledDate(),
+					rd.IsCompleted(),
+				)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -542,10 +698,17 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 			isOnlyPatternStepsIntervalDaysDiff ||
 			isOnlyCategoryIDBoxIDUpdate {
 
-			err = iu.itemRepo.UpdateReviewDates(ctx, newReviewdates, input.UserID)
+			reusedCount := len(newReviewdates) - appendedReviewdateCount
+			err = iu.itemRepo.UpdateReviewDates(ctx, newReviewdates[:reusedCount], input.UserID)
 			if err != nil {
 				return err
 			}
+			if appendedReviewdateCount > 0 {
+				_, err = iu.itemRepo.CreateReviewdates(ctx, newReviewdates[reusedCount:])
+				if err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
@@ -577,6 +740,8 @@ func (iu *ItemUsecase) UpdateItem(ctx context.Context, input UpdateItemInput) (*
 			InitialScheduledDate: rs.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        rs.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          rs.IsCompleted(),
+			ScheduledAt:          rs.ScheduledAt(),
+			RecallGrade:          rs.RecallGrade(),
 		}
 	}
 
@@ -607,9 +772,29 @@ func (iu *ItemUsecase) UpdateReviewDates(ctx context.Context, input UpdateBackRe
 	if err != nil {
 		return nil, err
 	}
+	targetPattern, err := iu.patternRepo.FindPatternByPatternID(ctx, input.PatternID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// 適応型はステップ数と復習日の数が一致しないため、最後のステップかどうかの判定は行わない
+	if targetPattern.IsAdaptive() {
+		return iu.updateAdaptiveReviewDates(ctx, input, targetPattern, targetPatternSteps[0].IntervalDays(), parsedInitialScheduledDate, parsedNewScheduledDate, parsedToday)
+	}
+
+	// 分・時間単位のステップの復習日は当日中の復習日時を持つため、日付を変更できない
+	for _, step := range targetPatternSteps {
+		if step.StepNumber() == input.StepNumber && step.IsIntraday() {
+			return nil, ItemDomain.ErrIntradayReviewDateNotEditable
+		}
+	}
 
-	isLastStep := false
 	lastStepNumber := targetPatternSteps[len(targetPatternSteps)-1].StepNumber()
+	// 繰り返しの間隔日数が設定されている場合、最後のステップ以降の復習日は完了にした上で次の復習日を生成する
+	if targetPattern.HasMaintenanceInterval() && input.StepNumber >= lastStepNumber {
+		return iu.updateMaintenanceReviewDates(ctx, input, targetPattern, parsedInitialScheduledDate, parsedNewScheduledDate, parsedToday)
+	}
+
+	isLastStep := false
 	if lastStepNumber == input.StepNumber {
 		isLastStep = true
 	} else {
@@ -618,6 +803,8 @@ func (iu *ItemUsecase) UpdateReviewDates(ctx context.Context, input UpdateBackRe
 
 	var newReviewdates []*ItemDomain.Reviewdate
 	var isFinished bool
+	// 繰り返しの間隔日数で追加する復習日
+	var nextReviewdates []*ItemDomain.Reviewdate
 	if isLastStep {
 		// isLastStepがtrueということは最後の復習日なのでインスタンス化させるのは一つのみで良い。
 		newReviewdate, err := ItemDomain.NewReviewdate(
@@ -646,12 +833,20 @@ func (iu *ItemUsecase) UpdateReviewDates(ctx context.Context, input UpdateBackRe
 		if err != nil {
 			return nil, err
 		}
+		scheduler, err := iu.scheduler.WithAlgorithm(targetPattern.SchedulingAlgorithm())
+		if err != nil {
+			return nil, err
+		}
+		scheduler, err = iu.withSchedulingRestrictions(ctx, scheduler, targetPattern, input.UserID)
+		if err != nil {
+			return nil, err
+		}
 
 		if input.IsMarkOverdueAsCompleted {
 			calculatedDuration := int(parsedNewScheduledDate.Sub(parsedInitialScheduledDate).Hours() / 24)
 			FakeLearnedDate := parsedLearnedDate.AddDate(0, 0, calculatedDuration) // これでFormat〇〇系の関数を使い回せる
 
-			newReviewdates, isFinished, err = iu.scheduler.FormatWithOverdueMarkedCompletedWithIDs(
+			newReviewdates, isFinished, err = scheduler.FormatWithOverdueMarkedCompletedWithIDs(
 				targetPatternSteps,
 				reviewDateIDs,
 				input.UserID,
@@ -664,14 +859,26 @@ func (iu *ItemUsecase) UpdateReviewDates(ctx context.Context, input UpdateBackRe
 			if err != nil {
 				return nil, err
 			}
+			// 繰り返しの間隔日数が設定されている場合は完了にせず、最後の復習日の次の復習日を追加する
+			if isFinished && targetPattern.HasMaintenanceInterval() {
+				lastReviewdate := newReviewdates[len(newReviewdates)-1]
+				nextReviewdate, err := scheduler.NextMaintenanceReviewdate(lastReviewdate, targetPattern.MaintenanceIntervalDays(), lastReviewdate.ScheduledDate(), parsedToday)
+				if err != nil {
+					return nil, err
+				}
+				nextReviewdates = []*ItemDomain.Reviewdate{nextReviewdate}
+				isFinished = false
+			}
 			// isFinishedがtrueの場合、UpdateItemAsFinishedを実行
 		} else {
 			calculatedDuration := int(parsedNewScheduledDate.Sub(parsedInitialScheduledDate).Hours() / 24)
 			FakeLearnedDate := parsedLearnedDate.AddDate(0, 0, calculatedDuration)
+			// 学習日から次のステップまでの日数はスケジューリング方式によって異なるのでschedulerから取得する
+			offsetDays := scheduler.OffsetDays(targetPatternSteps, FakeLearnedDate)
 			var nextIntervalDays int
-			for _, step := range targetPatternSteps {
+			for i, step := range targetPatternSteps {
 				if step.StepNumber() == input.StepNumber+1 { // isLastStep=false下での処理なので、ここではinput.StepNumber+1は必ず存在する。
-					nextIntervalDays = step.IntervalDays()
+					nextIntervalDays = offsetDays[i]
 					break
 				}
 			}
@@ -681,7 +888,7 @@ func (iu *ItemUsecase) UpdateReviewDates(ctx context.Context, input UpdateBackRe
 				diff = parsedToday.Sub(calculatedNextScheduledDate)
 				FakeLearnedDate = FakeLearnedDate.AddDate(0, 0, int(diff.Hours()/24))
 			}
-			newReviewdates, err = iu.scheduler.FormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates(
+			newReviewdates, err = scheduler.FormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates(
 				targetPatternSteps,
 				reviewDateIDs,
 				input.UserID,
@@ -748,28 +955,498 @@ func (iu *ItemUsecase) UpdateReviewDates(ctx context.Context, input UpdateBackRe
 		if err != nil {
 			return nil, err
 		}
+	} else if len(nextReviewdates) > 0 {
+		err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+			err = iu.itemRepo.UpdateReviewDatesBack(ctx, filteredReviewdates, input.UserID)
+			if err != nil {
+				return err
+			}
+
+			// "_"←はCopyfromの返り値の、「挿入された行数」。使わないのでブランク識別子にする。
+			_, err = iu.itemRepo.CreateReviewdates(ctx, nextReviewdates)
+			if err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err = iu.itemRepo.UpdateReviewDatesBack(ctx, filteredReviewdates, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 最新の復習日たちをDBから取得（クライアントで復習日のうち何回目以降を上書きすべきか考慮せずに済むため）
+	latestReviewdates, err := iu.itemRepo.GetReviewDatesByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &UpdateBackReviewDateOutput{
+		ItemID:     input.ItemID,
+		UserID:     input.UserID,
+		IsFinished: isFinished,
+		EditedAt:   resultEditedAt,
+	}
+	res.ReviewDates = make([]UpdateReviewDateOutput, len(latestReviewdates))
+	for i, rs := range latestReviewdates {
+		res.ReviewDates[i] = UpdateReviewDateOutput{
+			ReviewDateID:         rs.ReviewdateID(),
+			UserID:               rs.UserID(),
+			CategoryID:           rs.CategoryID(),
+			BoxID:                rs.BoxID(),
+			ItemID:               rs.ItemID(),
+			StepNumber:           rs.StepNumber(),
+			InitialScheduledDate: rs.InitialScheduledDate().Format("2006-01-02"),
+			ScheduledDate:        rs.ScheduledDate().Format("2006-01-02"),
+			IsCompleted:          rs.IsCompleted(),
+			ScheduledAt:          rs.ScheduledAt(),
+			RecallGrade:          rs.RecallGrade(),
+		}
+	}
+
+	return res, nil
+}
+
+// 適応型の復習日の更新（編集）
+// 対象の復習日を指定日に完了したものとして扱い、学習状態を進めて次の復習日を1件生成する
+func (iu *ItemUsecase) updateAdaptiveReviewDates(
+	ctx context.Context,
+	input UpdateBackReviewDateInput,
+	targetPattern *PatternDomain.Pattern,
+	firstIntervalDays int,
+	parsedInitialScheduledDate time.Time,
+	parsedNewScheduledDate time.Time,
+	parsedToday time.Time,
+) (*UpdateBackReviewDateOutput, error) {
+	targetReviewdates, err := iu.itemRepo.GetReviewDatesByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	latestReviewdate := targetReviewdates[len(targetReviewdates)-1]
+	if latestReviewdate.StepNumber() != input.StepNumber {
+		return nil, ItemDomain.ErrAdaptiveReviewDateNotLatest
+	}
+
+	updatedReviewdate, err := ItemDomain.NewReviewdate(
+		input.ReviewDateID,
+		input.UserID,
+		input.CategoryID,
+		input.BoxID,
+		input.ItemID,
+		input.StepNumber,
+		parsedInitialScheduledDate,
+		parsedNewScheduledDate,
+		true,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := iu.itemRepo.FindSM2StateByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		state, err = ItemDomain.NewSM2State(input.ItemID, input.UserID, firstIntervalDays)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 想起評価の指定がない完了はgoodとして扱う
+	err = state.ApplyRecallGrade("", firstIntervalDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// 次の復習日が過去になる場合は今日にずらす
+	parsedBaseDate := parsedNewScheduledDate
+	if parsedBaseDate.AddDate(0, 0, state.IntervalDays()).Before(parsedToday) {
+		parsedBaseDate = parsedToday.AddDate(0, 0, -state.IntervalDays())
+	}
+	scheduler, err := iu.withSchedulingRestrictions(ctx, iu.scheduler, targetPattern, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	nextReviewdate, err := scheduler.NextAdaptiveReviewdate(updatedReviewdate, state, parsedBaseDate)
+	if err != nil {
+		return nil, err
+	}
+
+	targetEditedAt, err := iu.itemRepo.GetEditedAtByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		err = iu.itemRepo.UpdateReviewDatesBack(ctx, []*ItemDomain.Reviewdate{updatedReviewdate}, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		_, err = iu.itemRepo.CreateReviewdates(ctx, []*ItemDomain.Reviewdate{nextReviewdate})
+		if err != nil {
+			return err
+		}
+
+		err = iu.itemRepo.UpsertSM2State(ctx, state)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 最新の復習日たちをDBから取得（クライアントで復習日のうち何回目以降を上書きすべきか考慮せずに済むため）
+	latestReviewdates, err := iu.itemRepo.GetReviewDatesByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &UpdateBackReviewDateOutput{
+		ItemID:     input.ItemID,
+		UserID:     input.UserID,
+		IsFinished: false,
+		EditedAt:   targetEditedAt,
+	}
+	res.ReviewDates = make([]UpdateReviewDateOutput, len(latestReviewdates))
+	for i, rs := range latestReviewdates {
+		res.ReviewDates[i] = UpdateReviewDateOutput{
+			ReviewDateID:         rs.ReviewdateID(),
+			UserID:               rs.UserID(),
+			CategoryID:           rs.CategoryID(),
+			BoxID:                rs.BoxID(),
+			ItemID:               rs.ItemID(),
+			StepNumber:           rs.StepNumber(),
+			InitialScheduledDate: rs.InitialScheduledDate().Format("2006-01-02"),
+			ScheduledDate:        rs.ScheduledDate().Format("2006-01-02"),
+			IsCompleted:          rs.IsCompleted(),
+			ScheduledAt:          rs.ScheduledAt(),
+			RecallGrade:          rs.RecallGrade(),
+		}
+	}
+
+	return res, nil
+}
+
+// 繰り返しの間隔日数が設定されたパターンの、最後のステップ以降の復習日の更新（編集）
+// 対象の復習日を指定日に完了したものとして扱い、指定日から間隔日数後に次の復習日を1件生成する
+func (iu *ItemUsecase) updateMaintenanceReviewDates(
+	ctx context.Context,
+	input UpdateBackReviewDateInput,
+	targetPattern *PatternDomain.Pattern,
+	parsedInitialScheduledDate time.Time,
+	parsedNewScheduledDate time.Time,
+	parsedToday time.Time,
+) (*UpdateBackReviewDateOutput, error) {
+	targetReviewdates, err := iu.itemRepo.GetReviewDatesByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// 次の復習日はステップ番号が対象の次になるため、後続の復習日がある場合は受け付けない
+	latestReviewdate := targetReviewdates[len(targetReviewdates)-1]
+	if latestReviewdate.StepNumber() != input.StepNumber {
+		return nil, ItemDomain.ErrMaintenanceReviewDateNotLatest
+	}
+
+	updatedReviewdate, err := ItemDomain.NewReviewdate(
+		input.ReviewDateID,
+		input.UserID,
+		input.CategoryID,
+		input.BoxID,
+		input.ItemID,
+		input.StepNumber,
+		parsedInitialScheduledDate,
+		parsedNewScheduledDate,
+		true,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler, err := iu.scheduler.WithAlgorithm(targetPattern.SchedulingAlgorithm())
+	if err != nil {
+		return nil, err
+	}
+	scheduler, err = iu.withSchedulingRestrictions(ctx, scheduler, targetPattern, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	nextReviewdate, err := scheduler.NextMaintenanceReviewdate(updatedReviewdate, targetPattern.MaintenanceIntervalDays(), parsedNewScheduledDate, parsedToday)
+	if err != nil {
+		return nil, err
+	}
+
+	targetEditedAt, err := iu.itemRepo.GetEditedAtByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		err = iu.itemRepo.UpdateReviewDatesBack(ctx, []*ItemDomain.Reviewdate{updatedReviewdate}, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		_, err = iu.itemRepo.CreateReviewdates(ctx, []*ItemDomain.Reviewdate{nextReviewdate})
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 最新の復習日たちをDBから取得（クライアントで復習日のうち何回目以降を上書きすべきか考慮せずに済むため）
+	latestReviewdates, err := iu.itemRepo.GetReviewDatesByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &UpdateBackReviewDateOutput{
+		ItemID:     input.ItemID,
+		UserID:     input.UserID,
+		IsFinished: false,
+		EditedAt:   targetEditedAt,
+	}
+	res.ReviewDates = make([]UpdateReviewDateOutput, len(latestReviewdates))
+	for i, rs := range latestReviewdates {
+		res.ReviewDates[i] = UpdateReviewDateOutput{
+			ReviewDateID:         rs.ReviewdateID(),
+			UserID:               rs.UserID(),
+			CategoryID:           rs.CategoryID(),
+			BoxID:                rs.BoxID(),
+			ItemID:               rs.ItemID(),
+			StepNumber:           rs.StepNumber(),
+			InitialScheduledDate: rs.InitialScheduledDate().Format("2006-01-02"),
+			ScheduledDate:        rs.ScheduledDate().Format("2006-01-02"),
+			IsCompleted:          rs.IsCompleted(),
+			ScheduledAt:          rs.ScheduledAt(),
+			RecallGrade:          rs.RecallGrade(),
+		}
+	}
+
+	return res, nil
+}
+
+// 復習物を手動で途中完了に更新
+func (iu *ItemUsecase) UpdateItemAsFinishedForce(ctx context.Context, input UpdateItemAsFinishedForceInput) (*UpdateItemAsFinishedForceOutput, error) {
+
+	targetItem, err := iu.itemRepo.GetItemByID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	editedAt := time.Now().UTC()
+	err = iu.itemRepo.UpdateItemAsFinished(ctx, input.ItemID, input.UserID, editedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	resItem := &UpdateItemAsFinishedForceOutput{
+		ItemID:     targetItem.ItemID(),
+		UserID:     targetItem.UserID(),
+		IsFinished: true,
+		EditedAt:   editedAt,
+	}
+
+	return resItem, nil
+}
+
+// 復習物の復習日を完了済みに更新
+// 想起評価が指定された場合は評価を記録し、again/easyの場合は後続の復習日を再計算する
+func (iu *ItemUsecase) UpdateReviewDateAsCompleted(ctx context.Context, input UpdateReviewDateAsCompletedInput) (*UpdateReviewDateAsCompletedOutput, error) {
+	// 想起評価は任意。未指定の場合は評価なしで完了扱いにする
+	var recallGrade *string
+	if input.RecallGrade != "" {
+		err := ItemDomain.ValidateRecallGrade(input.RecallGrade)
+		if err != nil {
+			return nil, err
+		}
+		recallGrade = &input.RecallGrade
+	}
+
+	targetItem, err := iu.itemRepo.GetItemByID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	targetReviewdates, err := iu.itemRepo.GetReviewDatesByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 復習日の生成方法はパターンの方式によって異なるため、復習物に紐づくパターンと復習物の版を取得
+	var targetPattern *PatternDomain.Pattern
+	var itemVersion *PatternDomain.PatternVersion
+	if targetItem.PatternID() != nil {
+		targetPattern, err = iu.patternRepo.FindPatternByPatternID(ctx, *targetItem.PatternID(), input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		itemVersion, err = iu.findItemPatternVersion(ctx, targetItem)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if targetPattern != nil && itemSchedulingAlgorithm(itemVersion, targetPattern) == PatternDomain.SchedulingAlgorithmSM2Adaptive {
+		return iu.completeAdaptiveReviewDate(ctx, input, recallGrade, targetPattern, itemVersion, targetReviewdates)
+	}
+
+	// targetReviewdatesの最後の復習日のStepNumberがinput.StepNumberと一致するかどうかを判定するフラグを作成
+	var isLastStepNumberMatch bool
+	// ここで検証
+	lastReviewdate := targetReviewdates[len(targetReviewdates)-1]
+	if lastReviewdate.StepNumber() == input.StepNumber {
+		isLastStepNumberMatch = true
+	} else {
+		isLastStepNumberMatch = false
+	}
+
+	// 後続の復習日がある場合のみ、想起評価に応じて再計算
+	rescheduledReviewdates := []*ItemDomain.Reviewdate{}
+	if targetPattern != nil && !isLastStepNumberMatch && ItemDomain.IsRescheduleRequired(input.RecallGrade) {
+		parsedToday, err := time.Parse("2006-01-02", input.Today)
+		if err != nil {
+			return nil, err
+		}
+		patternSteps, err := iu.itemPatternSteps(ctx, itemVersion, targetPattern.PatternID(), input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		scheduler, err := iu.scheduler.WithAlgorithm(itemSchedulingAlgorithm(itemVersion, targetPattern))
+		if err != nil {
+			return nil, err
+		}
+		scheduler, err = iu.withSchedulingRestrictions(ctx, scheduler, targetPattern, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		rescheduledReviewdates, err = scheduler.RescheduleByRecallGrade(
+			patternSteps,
+			targetReviewdates,
+			input.StepNumber,
+			input.RecallGrade,
+			parsedToday,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 繰り返しの間隔日数が設定されている場合、最後の復習日が完了しても復習物は完了にせず、次の復習日を生成する
+	var nextReviewdate *ItemDomain.Reviewdate
+	if targetPattern != nil && isLastStepNumberMatch && targetPattern.HasMaintenanceInterval() {
+		// 次の復習日は実際に復習した日を起点にする。未指定の場合は復習予定日を起点とする
+		parsedBaseDate := lastReviewdate.ScheduledDate()
+		var parsedToday time.Time
+		if input.Today != "" {
+			parsedToday, err = time.Parse("2006-01-02", input.Today)
+			if err != nil {
+				return nil, err
+			}
+			parsedBaseDate = parsedToday
+		}
+		scheduler, err := iu.scheduler.WithAlgorithm(itemSchedulingAlgorithm(itemVersion, targetPattern))
+		if err != nil {
+			return nil, err
+		}
+		scheduler, err = iu.withSchedulingRestrictions(ctx, scheduler, targetPattern, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		nextReviewdate, err = scheduler.NextMaintenanceReviewdate(lastReviewdate, targetPattern.MaintenanceIntervalDays(), parsedBaseDate, parsedToday)
+		if err != nil {
+			return nil, err
+		}
+	}
+	isFinished := isLastStepNumberMatch && nextReviewdate == nil
+
+	targetEditedAt, err := iu.itemRepo.GetEditedAtByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	resultEditedAt := targetEditedAt
+	// 次の復習日を生成した場合、完了と次の復習日の生成を同一トランザクションで永続化
+	if nextReviewdate != nil {
+		err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+			err = iu.itemRepo.UpdateReviewDateAsCompleted(ctx, input.ReviewDateID, input.UserID, recallGrade)
+			if err != nil {
+				return err
+			}
+
+			// "_"←はCopyfromの返り値の、「挿入された行数」。使わないのでブランク識別子にする。
+			_, err = iu.itemRepo.CreateReviewdates(ctx, []*ItemDomain.Reviewdate{nextReviewdate})
+			if err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		rescheduledReviewdates = []*ItemDomain.Reviewdate{nextReviewdate}
+
+		// 最後の復習日が完了した場合、復習物を完了済みに更新
+	} else if isLastStepNumberMatch {
+		err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+			err = iu.itemRepo.UpdateReviewDateAsCompleted(ctx, input.ReviewDateID, input.UserID, recallGrade)
+			if err != nil {
+				return err
+			}
+
+			resultEditedAt = time.Now().UTC()
+			err = iu.itemRepo.UpdateItemAsFinished(ctx, input.ItemID, input.UserID, resultEditedAt)
+			if err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// 後続の復習日を再計算した場合、完了と再計算を同一トランザクションで永続化
+	} else if len(rescheduledReviewdates) > 0 {
+		err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+			err = iu.itemRepo.UpdateReviewDateAsCompleted(ctx, input.ReviewDateID, input.UserID, recallGrade)
+			if err != nil {
+				return err
+			}
+
+			err = iu.itemRepo.UpdateReviewDates(ctx, rescheduledReviewdates, input.UserID)
+			if err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// 復習物そのものは未完了のまま
 	} else {
-		err = iu.itemRepo.UpdateReviewDatesBack(ctx, filteredReviewdates, input.UserID)
+		err = iu.itemRepo.UpdateReviewDateAsCompleted(ctx, input.ReviewDateID, input.UserID, recallGrade)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// 最新の復習日たちをDBから取得（クライアントで復習日のうち何回目以降を上書きすべきか考慮せずに済むため）
-	latestReviewdates, err := iu.itemRepo.GetReviewDatesByItemID(ctx, input.ItemID, input.UserID)
-	if err != nil {
-		return nil, err
-	}
-
-	res := &UpdateBackReviewDateOutput{
-		ItemID:     input.ItemID,
-		UserID:     input.UserID,
-		IsFinished: isFinished,
-		EditedAt:   resultEditedAt,
+	resReviewdate := &UpdateReviewDateAsCompletedOutput{
+		ReviewDateID: input.ReviewDateID,
+		UserID:       input.UserID,
+		IsCompleted:  true,
+		IsFinished:   isFinished,
+		RecallGrade:  recallGrade,
+		EditedAt:     resultEditedAt,
 	}
-	res.ReviewDates = make([]UpdateReviewDateOutput, len(latestReviewdates))
-	for i, rs := range latestReviewdates {
-		res.ReviewDates[i] = UpdateReviewDateOutput{
+	resReviewdate.ReviewDates = make([]UpdateReviewDateOutput, len(rescheduledReviewdates))
+	for i, rs := range rescheduledReviewdates {
+		resReviewdate.ReviewDates[i] = UpdateReviewDateOutput{
 			ReviewDateID:         rs.ReviewdateID(),
 			UserID:               rs.UserID(),
 			CategoryID:           rs.CategoryID(),
@@ -779,50 +1456,167 @@ func (iu *ItemUsecase) UpdateReviewDates(ctx context.Context, input UpdateBackRe
 			InitialScheduledDate: rs.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        rs.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          rs.IsCompleted(),
+			ScheduledAt:          rs.ScheduledAt(),
+			RecallGrade:          rs.RecallGrade(),
 		}
 	}
 
-	return res, nil
+	return resReviewdate, nil
 }
 
-// 復習物を手動で途中完了に更新
-func (iu *ItemUsecase) UpdateItemAsFinishedForce(ctx context.Context, input UpdateItemAsFinishedForceInput) (*UpdateItemAsFinishedForceOutput, error) {
+// 適応型の復習日の完了
+// 学習状態に想起評価を反映し、次の復習日を1件だけ生成する。適応型の復習物は手動で完了にするまで終わらない
+func (iu *ItemUsecase) completeAdaptiveReviewDate(
+	ctx context.Context,
+	input UpdateReviewDateAsCompletedInput,
+	recallGrade *string,
+	targetPattern *PatternDomain.Pattern,
+	itemVersion *PatternDomain.PatternVersion,
+	targetReviewdates []*ItemDomain.Reviewdate,
+) (*UpdateReviewDateAsCompletedOutput, error) {
+	// 未完了の復習日は常に最新の1件のみなので、それ以外の完了操作は受け付けない
+	completedReviewdate := targetReviewdates[len(targetReviewdates)-1]
+	if completedReviewdate.StepNumber() != input.StepNumber {
+		return nil, ItemDomain.ErrAdaptiveReviewDateNotLatest
+	}
+
+	patternSteps, err := iu.itemPatternSteps(ctx, itemVersion, targetPattern.PatternID(), input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	firstIntervalDays := patternSteps[0].IntervalDays()
 
-	targetItem, err := iu.itemRepo.GetItemByID(ctx, input.ItemID, input.UserID)
+	state, err := iu.itemRepo.FindSM2StateByItemID(ctx, input.ItemID, input.UserID)
 	if err != nil {
 		return nil, err
 	}
-	editedAt := time.Now().UTC()
-	err = iu.itemRepo.UpdateItemAsFinished(ctx, input.ItemID, input.UserID, editedAt)
+	// 初回完了時は学習状態がまだないので初期状態から始める
+	if state == nil {
+		state, err = ItemDomain.NewSM2State(input.ItemID, input.UserID, firstIntervalDays)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = state.ApplyRecallGrade(input.RecallGrade, firstIntervalDays)
 	if err != nil {
 		return nil, err
 	}
 
-	resItem := &UpdateItemAsFinishedForceOutput{
-		ItemID:     targetItem.ItemID(),
-		UserID:     targetItem.UserID(),
-		IsFinished: true,
-		EditedAt:   editedAt,
+	// 次の復習日は実際に復習した日を起点にする。未指定の場合は復習予定日を起点とする
+	parsedBaseDate := completedReviewdate.ScheduledDate()
+	if input.Today != "" {
+		parsedBaseDate, err = time.Parse("2006-01-02", input.Today)
+		if err != nil {
+			return nil, err
+		}
+	}
+	scheduler, err := iu.withSchedulingRestrictions(ctx, iu.scheduler, targetPattern, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	nextReviewdate, err := scheduler.NextAdaptiveReviewdate(completedReviewdate, state, parsedBaseDate)
+	if err != nil {
+		return nil, err
 	}
 
-	return resItem, nil
+	targetEditedAt, err := iu.itemRepo.GetEditedAtByItemID(ctx, input.ItemID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 完了、次の復習日の生成、学習状態の更新を同一トランザクションで永続化
+	err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		err = iu.itemRepo.UpdateReviewDateAsCompleted(ctx, input.ReviewDateID, input.UserID, recallGrade)
+		if err != nil {
+			return err
+		}
+
+		// "_"←はCopyfromの返り値の、「挿入された行数」。使わないのでブランク識別子にする。
+		_, err = iu.itemRepo.CreateReviewdates(ctx, []*ItemDomain.Reviewdate{nextReviewdate})
+		if err != nil {
+			return err
+		}
+
+		err = iu.itemRepo.UpsertSM2State(ctx, state)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resReviewdate := &UpdateReviewDateAsCompletedOutput{
+		ReviewDateID: input.ReviewDateID,
+		UserID:       input.UserID,
+		IsCompleted:  true,
+		IsFinished:   false,
+		RecallGrade:  recallGrade,
+		EditedAt:     targetEditedAt,
+		ReviewDates: []UpdateReviewDateOutput{
+			{
+				ReviewDateID:         nextReviewdate.ReviewdateID(),
+				UserID:               nextReviewdate.UserID(),
+				CategoryID:           nextReviewdate.CategoryID(),
+				BoxID:                nextReviewdate.BoxID(),
+				ItemID:               nextReviewdate.ItemID(),
+				StepNumber:           nextReviewdate.StepNumber(),
+				InitialScheduledDate: nextReviewdate.InitialScheduledDate().Format("2006-01-02"),
+				ScheduledDate:        nextReviewdate.ScheduledDate().Format("2006-01-02"),
+				IsCompleted:          nextReviewdate.IsCompleted(),
+				ScheduledAt:          nextReviewdate.ScheduledAt(),
+				RecallGrade:          nextReviewdate.RecallGrade(),
+			},
+		},
+	}
+
+	return resReviewdate, nil
 }
 
-// 復習物の復習日を完了済みに更新
-func (iu *ItemUsecase) UpdateReviewDateAsCompleted(ctx context.Context, input UpdateReviewDateAsCompletedInput) (*UpdateReviewDateAsCompletedOutput, error) {
+// 適応型の復習日の完了取り消し
+// 生成済みの次の復習日を削除し、取り消し対象より前の想起評価を順に反映し直して学習状態を復元する
+func (iu *ItemUsecase) inCompleteAdaptiveReviewDate(
+	ctx context.Context,
+	input UpdateReviewDateAsInCompletedInput,
+	targetItem *ItemDomain.Item,
+	targetPattern *PatternDomain.Pattern,
+	itemVersion *PatternDomain.PatternVersion,
+) (*UpdateReviewDateAsInCompletedOutput, error) {
 	targetReviewdates, err := iu.itemRepo.GetReviewDatesByItemID(ctx, input.ItemID, input.UserID)
 	if err != nil {
 		return nil, err
 	}
 
-	// targetReviewdatesの最後の復習日のStepNumberがinput.StepNumberと一致するかどうかを判定するフラグを作成
-	var isLastStepNumberMatch bool
-	// ここで検証
-	lastReviewdate := targetReviewdates[len(targetReviewdates)-1]
-	if lastReviewdate.StepNumber() == input.StepNumber {
-		isLastStepNumberMatch = true
-	} else {
-		isLastStepNumberMatch = false
+	// 後続の復習日が完了済みの場合は学習状態を巻き戻せないので、直近の完了のみ取り消せる
+	for _, rd := range targetReviewdates {
+		if rd.StepNumber() > input.StepNumber && rd.IsCompleted() {
+			return nil, ItemDomain.ErrAdaptiveReviewDateNotLatest
+		}
+	}
+
+	patternSteps, err := iu.itemPatternSteps(ctx, itemVersion, targetPattern.PatternID(), input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	firstIntervalDays := patternSteps[0].IntervalDays()
+
+	state, err := ItemDomain.NewSM2State(input.ItemID, input.UserID, firstIntervalDays)
+	if err != nil {
+		return nil, err
+	}
+	for _, rd := range targetReviewdates {
+		if rd.StepNumber() >= input.StepNumber || !rd.IsCompleted() {
+			continue
+		}
+		var recallGrade string
+		if rd.RecallGrade() != nil {
+			recallGrade = *rd.RecallGrade()
+		}
+		err = state.ApplyRecallGrade(recallGrade, firstIntervalDays)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	targetEditedAt, err := iu.itemRepo.GetEditedAtByItemID(ctx, input.ItemID, input.UserID)
@@ -830,38 +1624,42 @@ func (iu *ItemUsecase) UpdateReviewDateAsCompleted(ctx context.Context, input Up
 		return nil, err
 	}
 	resultEditedAt := targetEditedAt
-	// 最後の復習日が完了した場合、復習物を完了済みに更新
-	if isLastStepNumberMatch {
-		err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
-			err = iu.itemRepo.UpdateReviewDateAsCompleted(ctx, input.ReviewDateID, input.UserID)
-			if err != nil {
-				return nil
-			}
 
-			resultEditedAt = time.Now().UTC()
-			err = iu.itemRepo.UpdateItemAsFinished(ctx, input.ItemID, input.UserID, resultEditedAt)
-			if err != nil {
-				return err
-			}
-			return nil
-		})
+	err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		err = iu.itemRepo.UpdateReviewDateAsInCompleted(ctx, input.ReviewDateID, input.UserID)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		// 復習物そのものは未完了のまま
-	} else {
-		err = iu.itemRepo.UpdateReviewDateAsCompleted(ctx, input.ReviewDateID, input.UserID)
+		err = iu.itemRepo.DeleteInCompletedReviewDatesAfterStep(ctx, input.ItemID, input.UserID, input.StepNumber)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		err = iu.itemRepo.UpsertSM2State(ctx, state)
+		if err != nil {
+			return err
+		}
+
+		// 手動で完了にされていた場合は未完了に戻す
+		if targetItem.IsFinished() {
+			resultEditedAt = time.Now().UTC()
+			err = iu.itemRepo.UpdateItemAsUnFinished(ctx, input.ItemID, input.UserID, resultEditedAt)
+			if err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	resReviewdate := &UpdateReviewDateAsCompletedOutput{
+	resReviewdate := &UpdateReviewDateAsInCompletedOutput{
 		ReviewDateID: input.ReviewDateID,
 		UserID:       input.UserID,
-		IsCompleted:  true,
-		IsFinished:   isLastStepNumberMatch,
+		IsCompleted:  false,
+		IsFinished:   targetItem.IsFinished(),
 		EditedAt:     resultEditedAt,
 	}
 
@@ -875,6 +1673,42 @@ func (iu *ItemUsecase) UpdateReviewDateAsInCompleted(ctx context.Context, input
 		return nil, err
 	}
 
+	// 適応型の場合は、生成済みの次の復習日の削除と学習状態の巻き戻しも行う
+	// 繰り返しの間隔日数が設定されている場合、最後のステップ以降の復習日の取り消しでは生成済みの次の復習日を削除する
+	isMaintenanceReviewdate := false
+	if targetItem.PatternID() != nil {
+		targetPattern, err := iu.patternRepo.FindPatternByPatternID(ctx, *targetItem.PatternID(), input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		itemVersion, err := iu.findItemPatternVersion(ctx, targetItem)
+		if err != nil {
+			return nil, err
+		}
+		if itemSchedulingAlgorithm(itemVersion, targetPattern) == PatternDomain.SchedulingAlgorithmSM2Adaptive {
+			return iu.inCompleteAdaptiveReviewDate(ctx, input, targetItem, targetPattern, itemVersion)
+		}
+		if targetPattern.HasMaintenanceInterval() {
+			patternSteps, err := iu.itemPatternSteps(ctx, itemVersion, targetPattern.PatternID(), input.UserID)
+			if err != nil {
+				return nil, err
+			}
+			isMaintenanceReviewdate = input.StepNumber >= patternSteps[len(patternSteps)-1].StepNumber()
+		}
+	}
+	if isMaintenanceReviewdate {
+		targetReviewdates, err := iu.itemRepo.GetReviewDatesByItemID(ctx, input.ItemID, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		// 後続の復習日が完了済みの場合は次の復習日を生成し直せないので、直近の完了のみ取り消せる
+		for _, rd := range targetReviewdates {
+			if rd.StepNumber() > input.StepNumber && rd.IsCompleted() {
+				return nil, ItemDomain.ErrMaintenanceReviewDateNotLatest
+			}
+		}
+	}
+
 	var isItemFinished bool
 	if targetItem.IsFinished() {
 		isItemFinished = true
@@ -885,17 +1719,26 @@ func (iu *ItemUsecase) UpdateReviewDateAsInCompleted(ctx context.Context, input
 		return nil, err
 	}
 	resultEditedAt := targetEditedAt
-	if isItemFinished {
+	if isItemFinished || isMaintenanceReviewdate {
 		err = iu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
 			err = iu.itemRepo.UpdateReviewDateAsInCompleted(ctx, input.ReviewDateID, input.UserID)
 			if err != nil {
 				return err
 			}
 
-			resultEditedAt = time.Now().UTC()
-			err = iu.itemRepo.UpdateItemAsUnFinished(ctx, input.ItemID, input.UserID, resultEditedAt)
-			if err != nil {
-				return err
+			if isMaintenanceReviewdate {
+				err = iu.itemRepo.DeleteInCompletedReviewDatesAfterStep(ctx, input.ItemID, input.UserID, input.StepNumber)
+				if err != nil {
+					return err
+				}
+			}
+
+			if isItemFinished {
+				resultEditedAt = time.Now().UTC()
+				err = iu.itemRepo.UpdateItemAsUnFinished(ctx, input.ItemID, input.UserID, resultEditedAt)
+				if err != nil {
+					return err
+				}
 			}
 			return nil
 		})
@@ -933,8 +1776,10 @@ func (iu *ItemUsecase) UpdateItemAsUnFinishedForce(ctx context.Context, input Up
 	var firstInCompletedScheduledDate time.Time
 	var firstInCompletedInitialScheduledDate time.Time
 	var firstInCompletedStepNumber int
+	var firstInCompletedReviewDateID string
 	for _, ReviewDate := range ReviewDates {
 		if !ReviewDate.IsCompleted() {
+			firstInCompletedReviewDateID = ReviewDate.ReviewdateID()
 			firstInCompletedScheduledDate = ReviewDate.ScheduledDate()
 			firstInCompletedInitialScheduledDate = ReviewDate.InitialScheduledDate()
 			firstInCompletedStepNumber = ReviewDate.StepNumber()
@@ -962,24 +1807,58 @@ func (iu *ItemUsecase) UpdateItemAsUnFinishedForce(ctx context.Context, input Up
 		if err != nil {
 			return nil, err
 		}
-		reviewDateIDs := make([]string, len(ReviewDates))
-		for i, rd := range ReviewDates {
-			reviewDateIDs[i] = rd.ReviewdateID()
-		}
-
-		newReviewdates, err = iu.scheduler.FormatWithOverdueMarkedInCompletedWithIDs(
-			patternSteps,
-			reviewDateIDs,
-			input.UserID,
-			input.CategoryID,
-			input.BoxID,
-			input.ItemID,
-			FakeLearnedDate,
-			parsedToday,
-		)
+		targetPattern, err := iu.patternRepo.FindPatternByPatternID(ctx, input.PatternID, input.UserID)
 		if err != nil {
 			return nil, err
 		}
+
+		// 繰り返しの間隔日数で生成した復習日もパターンのステップに対応しないため、適応型と同様に扱う
+		isMaintenanceReviewdate := firstInCompletedStepNumber > patternSteps[len(patternSteps)-1].StepNumber()
+		if targetPattern.IsAdaptive() || isMaintenanceReviewdate {
+			// 適応型の未完了の復習日は直近の1件のみなので、それを今日に移動する
+			pendingReviewdate, err := ItemDomain.NewReviewdate(
+				firstInCompletedReviewDateID,
+				input.UserID,
+				input.CategoryID,
+				input.BoxID,
+				input.ItemID,
+				firstInCompletedStepNumber,
+				parsedToday,
+				parsedToday,
+				false,
+			)
+			if err != nil {
+				return nil, err
+			}
+			newReviewdates = []*ItemDomain.Reviewdate{pendingReviewdate}
+		} else {
+			scheduler, err := iu.scheduler.WithAlgorithm(targetPattern.SchedulingAlgorithm())
+			if err != nil {
+				return nil, err
+			}
+			scheduler, err = iu.withSchedulingRestrictions(ctx, scheduler, targetPattern, input.UserID)
+			if err != nil {
+				return nil, err
+			}
+			reviewDateIDs := make([]string, len(ReviewDates))
+			for i, rd := range ReviewDates {
+				reviewDateIDs[i] = rd.ReviewdateID()
+			}
+
+			newReviewdates, err = scheduler.FormatWithOverdueMarkedInCompletedWithIDs(
+				patternSteps,
+				reviewDateIDs,
+				input.UserID,
+				input.CategoryID,
+				input.BoxID,
+				input.ItemID,
+				FakeLearnedDate,
+				parsedToday,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// 操作対象の復習日以降の復習日のみ抽出
@@ -1039,6 +1918,8 @@ func (iu *ItemUsecase) UpdateItemAsUnFinishedForce(ctx context.Context, input Up
 			InitialScheduledDate: rs.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        rs.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          rs.IsCompleted(),
+			ScheduledAt:          rs.ScheduledAt(),
+			RecallGrade:          rs.RecallGrade(),
 		}
 	}
 
@@ -1107,6 +1988,8 @@ func (iu *ItemUsecase) GetAllUnFinishedItemsByBoxID(ctx context.Context, boxID s
 			InitialScheduledDate: rd.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        rd.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          rd.IsCompleted(),
+			ScheduledAt:          rd.ScheduledAt(),
+			RecallGrade:          rd.RecallGrade(),
 		}
 		idxs[rd.ItemID()]++
 	}
@@ -1184,6 +2067,8 @@ func (iu *ItemUsecase) GetAllUnFinishedUnclassifiedItemsByUserID(ctx context.Con
 			InitialScheduledDate: rd.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        rd.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          rd.IsCompleted(),
+			ScheduledAt:          rd.ScheduledAt(),
+			RecallGrade:          rd.RecallGrade(),
 		}
 		idxs[rd.ItemID()]++
 	}
@@ -1256,6 +2141,8 @@ func (iu *ItemUsecase) GetAllUnFinishedUnclassifiedItemsByCategoryID(ctx context
 			InitialScheduledDate: rd.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        rd.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          rd.IsCompleted(),
+			ScheduledAt:          rd.ScheduledAt(),
+			RecallGrade:          rd.RecallGrade(),
 		}
 		idxs[rd.ItemID()]++
 	}
@@ -1392,6 +2279,77 @@ func (iu *ItemUsecase) CountAllDailyReviewDates(ctx context.Context, userID stri
 	return count, nil
 }
 
+// 復習量の予測で指定できる最大日数
+const maxForecastDays = 366
+
+// fromから指定日数分の各日の未完了の復習日数を、ボックス毎・カテゴリー毎の未分類・ホーム画面の未分類に分けて取得
+// 復習日がない日も0件として含める
+func (iu *ItemUsecase) GetReviewForecast(ctx context.Context, userID string, from string, days int) (*ReviewForecastOutput, error) {
+	parsedFrom, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, err
+	}
+	if days < 1 || days > maxForecastDays {
+		return nil, ItemDomain.ErrInvalidForecastDays
+	}
+	parsedTo := parsedFrom.AddDate(0, 0, days-1)
+
+	boxCounts, err := iu.itemRepo.CountForecastDatesGroupedByBoxByUserID(ctx, userID, parsedFrom, parsedTo)
+	if err != nil {
+		return nil, err
+	}
+	unclassifiedCounts, err := iu.itemRepo.CountForecastDatesUnclassifiedGroupedByCategoryByUserID(ctx, userID, parsedFrom, parsedTo)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &ReviewForecastOutput{
+		From: parsedFrom.Format("2006-01-02"),
+		To:   parsedTo.Format("2006-01-02"),
+		Days: make([]ForecastDayOutput, days),
+	}
+	// 日付をキーにして、集計結果を各日に振り分ける
+	dayIndexByDate := make(map[string]int, days)
+	for i := 0; i < days; i++ {
+		date := parsedFrom.AddDate(0, 0, i).Format("2006-01-02")
+		dayIndexByDate[date] = i
+		res.Days[i] = ForecastDayOutput{
+			Date:                   date,
+			ByBox:                  []DailyCountGroupedByBoxOutput{},
+			UnclassifiedByCategory: []UnclassifiedDailyDatesCountGroupedByCategoryOutput{},
+		}
+	}
+	for _, count := range boxCounts {
+		i, ok := dayIndexByDate[count.ScheduledDate.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		res.Days[i].ByBox = append(res.Days[i].ByBox, DailyCountGroupedByBoxOutput{
+			CategoryID: count.CategoryID,
+			BoxID:      count.BoxID,
+			Count:      count.Count,
+		})
+		res.Days[i].TotalCount += count.Count
+	}
+	for _, count := range unclassifiedCounts {
+		i, ok := dayIndexByDate[count.ScheduledDate.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		if count.CategoryID == nil {
+			res.Days[i].UnclassifiedCount += count.Count
+		} else {
+			res.Days[i].UnclassifiedByCategory = append(res.Days[i].UnclassifiedByCategory, UnclassifiedDailyDatesCountGroupedByCategoryOutput{
+				CategoryID: *count.CategoryID,
+				Count:      count.Count,
+			})
+		}
+		res.Days[i].TotalCount += count.Count
+	}
+
+	return res, nil
+}
+
 // TODO: ボックスレベルの完了済みの過去日の復習日を今日に変更するユースケース実装
 // TODO: 完了した復習物（is_finishedがtrue）を取得するユースケース実装
 
@@ -1514,6 +2472,7 @@ func (iu *ItemUsecase) GetAllDailyReviewDates(ctx context.Context, userID string
 					ScheduledDate:        sched,
 					NextScheduledDate:    next,
 					IsCompleted:          d.IsCompleted,
+					IsOverdue:            d.IsOverdue,
 					ItemID:               d.ItemID,
 					ItemName:             d.Name,
 					Detail:               d.Detail,
@@ -1560,6 +2519,7 @@ func (iu *ItemUsecase) GetAllDailyReviewDates(ctx context.Context, userID string
 					ScheduledDate:        sched,
 					NextScheduledDate:    next,
 					IsCompleted:          d.IsCompleted,
+					IsOverdue:            d.IsOverdue,
 					ItemID:               d.ItemID,
 					ItemName:             d.Name,
 					Detail:               d.Detail,
@@ -1606,6 +2566,7 @@ func (iu *ItemUsecase) GetAllDailyReviewDates(ctx context.Context, userID string
 				ScheduledDate:        sched,
 				NextScheduledDate:    next,
 				IsCompleted:          d.IsCompleted,
+				IsOverdue:            d.IsOverdue,
 				ItemID:               d.ItemID,
 				ItemName:             d.Name,
 				Detail:               d.Detail,
@@ -1619,6 +2580,30 @@ func (iu *ItemUsecase) GetAllDailyReviewDates(ctx context.Context, userID string
 }
 
 // 完了済み復習物取得系
+func (iu *ItemUsecase) GetDueNowReviewDates(ctx context.Context, userID string) ([]*DueNowReviewDateOutput, error) {
+	dueNowDates, err := iu.itemRepo.GetDueNowReviewDates(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*DueNowReviewDateOutput, len(dueNowDates))
+	for i, d := range dueNowDates {
+		result[i] = &DueNowReviewDateOutput{
+			ReviewDateID:  d.ReviewdateID,
+			CategoryID:    d.CategoryID,
+			BoxID:         d.BoxID,
+			StepNumber:    d.StepNumber,
+			ScheduledDate: d.ScheduledDate.Format("2006-01-02"),
+			ScheduledAt:   d.ScheduledAt,
+			ItemID:        d.ItemID,
+			ItemName:      d.Name,
+			Detail:        d.Detail,
+			LearnedDate:   d.LearnedDate.Format("2006-01-02"),
+		}
+	}
+	return result, nil
+}
+
 func (iu *ItemUsecase) GetFinishedItemsByBoxID(ctx context.Context, boxID string, userID string) ([]*GetItemOutput, error) {
 	items, err := iu.itemRepo.GetFinishedItemsByBoxID(ctx, boxID, userID)
 	if err != nil {
@@ -1654,6 +2639,8 @@ func (iu *ItemUsecase) GetFinishedItemsByBoxID(ctx context.Context, boxID string
 			InitialScheduledDate: reviewdate.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        reviewdate.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          reviewdate.IsCompleted(),
+			ScheduledAt:          reviewdate.ScheduledAt(),
+			RecallGrade:          reviewdate.RecallGrade(),
 		})
 	}
 
@@ -1712,6 +2699,8 @@ func (iu *ItemUsecase) GetUnclassfiedFinishedItemsByCategoryID(ctx context.Conte
 			InitialScheduledDate: reviewdate.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        reviewdate.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          reviewdate.IsCompleted(),
+			ScheduledAt:          reviewdate.ScheduledAt(),
+			RecallGrade:          reviewdate.RecallGrade(),
 		})
 	}
 
@@ -1770,6 +2759,8 @@ func (iu *ItemUsecase) GetUnclassfiedFinishedItemsByUserID(ctx context.Context,
 			InitialScheduledDate: reviewdate.InitialScheduledDate().Format("2006-01-02"),
 			ScheduledDate:        reviewdate.ScheduledDate().Format("2006-01-02"),
 			IsCompleted:          reviewdate.IsCompleted(),
+			ScheduledAt:          reviewdate.ScheduledAt(),
+			RecallGrade:          reviewdate.RecallGrade(),
 		})
 	}
 
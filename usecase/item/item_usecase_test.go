@@ -37,6 +37,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 		patternID,
 		1,
 		1,
+		PatternDomain.IntervalUnitDay,
 	)
 	testPatternStep2, _ := PatternDomain.NewPatternStep(
 		uuid.NewString(),
@@ -44,6 +45,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 		patternID,
 		2,
 		3,
+		PatternDomain.IntervalUnitDay,
 	)
 	testPatternSteps := []*PatternDomain.PatternStep{
 		testPatternStep1,
@@ -81,6 +83,22 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 		testReviewdate2,
 	}
 
+	// 負荷分散で1日後ろにずらした復習日
+	testBalancedReviewdate, _ := ItemDomain.NewReviewdate(
+		testReviewdate1.ReviewdateID(),
+		userID,
+		&categoryID,
+		&boxID,
+		itemID,
+		1,
+		parsedToday.AddDate(0, 0, 1),
+		parsedToday.AddDate(0, 0, 1),
+		false,
+	)
+	testBalancedReviewdates := []*ItemDomain.Reviewdate{
+		testBalancedReviewdate,
+	}
+
 	tests := []struct {
 		name      string
 		input     CreateItemInput
@@ -144,6 +162,30 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 						GetAllPatternStepsByPatternID(gomock.Any(), patternID, userID).
 						Return(testPatternSteps, nil).
 						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newFixedPattern(patternID, userID), nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).
+						Return(mockScheduler, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(gomock.Any(), userID).
+						Return([]int{}, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetBlockedDatesByUserID(gomock.Any(), userID).
+						Return([]time.Time{}, nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithExcludedWeekdays(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
 					mockScheduler.EXPECT().
 						FormatWithOverdueMarkedCompleted(
 							testPatternSteps,
@@ -156,6 +198,10 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 						).
 						Return(testReviewdates2, true, nil).
 						Times(1),
+					mockItemRepo.EXPECT().
+						GetDailyReviewLimitByUserID(gomock.Any(), userID).
+						Return(0, nil).
+						Times(1),
 					mockTransactionManager.EXPECT().
 						RunInTransaction(gomock.Any(), gomock.Any()).
 						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
@@ -217,6 +263,30 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 						GetAllPatternStepsByPatternID(gomock.Any(), patternID, userID).
 						Return(testPatternSteps, nil).
 						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newFixedPattern(patternID, userID), nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).
+						Return(mockScheduler, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(gomock.Any(), userID).
+						Return([]int{}, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetBlockedDatesByUserID(gomock.Any(), userID).
+						Return([]time.Time{}, nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithExcludedWeekdays(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
 					mockScheduler.EXPECT().
 						FormatWithOverdueMarkedInCompleted(
 							testPatternSteps,
@@ -229,6 +299,10 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 						).
 						Return(testReviewdates1, nil).
 						Times(1),
+					mockItemRepo.EXPECT().
+						GetDailyReviewLimitByUserID(gomock.Any(), userID).
+						Return(0, nil).
+						Times(1),
 					mockTransactionManager.EXPECT().
 						RunInTransaction(gomock.Any(), gomock.Any()).
 						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
@@ -271,6 +345,232 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "PatternIDが設定されている場合の正常系（パターンで負荷分散が有効な場合は復習予定数が少ない日にずらす）",
+			input: CreateItemInput{
+				UserID:                   userID,
+				CategoryID:               &categoryID,
+				BoxID:                    &boxID,
+				PatternID:                &patternID,
+				Name:                     "Test Item",
+				Detail:                   "Test Detail",
+				LearnedDate:              "2024-01-01",
+				IsMarkOverdueAsCompleted: false,
+				Today:                    "2024-01-10",
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				dailyCounts := []*ItemDomain.DailyScheduledCount{
+					{ScheduledDate: parsedToday, Count: 5},
+				}
+				gomock.InOrder(
+					mockPatternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(gomock.Any(), patternID, userID).
+						Return(testPatternSteps, nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newLoadBalancedPattern(patternID, userID), nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).
+						Return(mockScheduler, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(gomock.Any(), userID).
+						Return([]int{}, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetBlockedDatesByUserID(gomock.Any(), userID).
+						Return([]time.Time{}, nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithExcludedWeekdays(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+					mockScheduler.EXPECT().
+						FormatWithOverdueMarkedInCompleted(
+							testPatternSteps,
+							userID,
+							&categoryID,
+							&boxID,
+							gomock.Any(),
+							parsedLearnedDate,
+							parsedToday,
+						).
+						Return(testReviewdates1, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetDailyReviewLimitByUserID(gomock.Any(), userID).
+						Return(0, nil).
+						Times(1),
+					// 学習日から復習日(1/10)までの間隔9日の1割なので、前後1日の復習予定数を取得する
+					mockItemRepo.EXPECT().
+						CountScheduledDatesGroupedByDateByUserID(gomock.Any(), userID, parsedToday.AddDate(0, 0, -1), parsedToday.AddDate(0, 0, 1)).
+						Return(dailyCounts, nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						LoadBalance(testReviewdates1, dailyCounts, parsedLearnedDate, parsedToday).
+						Return(testBalancedReviewdates, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockItemRepo.EXPECT().
+						CreateItem(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						CreateReviewdates(gomock.Any(), testBalancedReviewdates).
+						Return(int64(1), nil).
+						Times(1),
+				)
+			},
+			want: &CreateItemOutput{
+				ItemID:       itemID,
+				UserID:       userID,
+				CategoryID:   &categoryID,
+				BoxID:        &boxID,
+				PatternID:    &patternID,
+				Name:         "Test Item",
+				Detail:       "Test Detail",
+				LearnedDate:  "2024-01-01",
+				IsCompleted:  false,
+				RegisteredAt: registeredAt,
+				EditedAt:     registeredAt,
+				Reviewdates: []CreateReviewdateOutput{
+					{
+						DateID:               testBalancedReviewdates[0].ReviewdateID(),
+						UserID:               userID,
+						ItemID:               itemID,
+						StepNumber:           1,
+						InitialScheduledDate: "2024-01-11",
+						ScheduledDate:        "2024-01-11",
+						IsCompleted:          false,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "PatternIDが設定されている場合の正常系（1日の復習数の上限に達している日の復習日は次の日に繰り越す）",
+			input: CreateItemInput{
+				UserID:                   userID,
+				CategoryID:               &categoryID,
+				BoxID:                    &boxID,
+				PatternID:                &patternID,
+				Name:                     "Test Item",
+				Detail:                   "Test Detail",
+				LearnedDate:              "2024-01-01",
+				IsMarkOverdueAsCompleted: false,
+				Today:                    "2024-01-10",
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				dailyCounts := []*ItemDomain.DailyScheduledCount{
+					{ScheduledDate: parsedToday, Count: 3},
+				}
+				gomock.InOrder(
+					mockPatternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(gomock.Any(), patternID, userID).
+						Return(testPatternSteps, nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newFixedPattern(patternID, userID), nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).
+						Return(mockScheduler, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(gomock.Any(), userID).
+						Return([]int{}, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetBlockedDatesByUserID(gomock.Any(), userID).
+						Return([]time.Time{}, nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithExcludedWeekdays(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+					mockScheduler.EXPECT().
+						FormatWithOverdueMarkedInCompleted(
+							testPatternSteps,
+							userID,
+							&categoryID,
+							&boxID,
+							gomock.Any(),
+							parsedLearnedDate,
+							parsedToday,
+						).
+						Return(testReviewdates1, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetDailyReviewLimitByUserID(gomock.Any(), userID).
+						Return(3, nil).
+						Times(1),
+					// 負荷分散の範囲に加えて、繰り越し先を探す期間の復習予定数も取得する
+					mockItemRepo.EXPECT().
+						CountScheduledDatesGroupedByDateByUserID(gomock.Any(), userID, parsedToday.AddDate(0, 0, -1), parsedToday.AddDate(0, 0, 1+366)).
+						Return(dailyCounts, nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						DeferOverflow(testReviewdates1, dailyCounts, 3, parsedToday).
+						Return(testBalancedReviewdates, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockItemRepo.EXPECT().
+						CreateItem(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						CreateReviewdates(gomock.Any(), testBalancedReviewdates).
+						Return(int64(1), nil).
+						Times(1),
+				)
+			},
+			want: &CreateItemOutput{
+				ItemID:       itemID,
+				UserID:       userID,
+				CategoryID:   &categoryID,
+				BoxID:        &boxID,
+				PatternID:    &patternID,
+				Name:         "Test Item",
+				Detail:       "Test Detail",
+				LearnedDate:  "2024-01-01",
+				IsCompleted:  false,
+				RegisteredAt: registeredAt,
+				EditedAt:     registeredAt,
+				Reviewdates: []CreateReviewdateOutput{
+					{
+						DateID:               testBalancedReviewdates[0].ReviewdateID(),
+						UserID:               userID,
+						ItemID:               itemID,
+						StepNumber:           1,
+						InitialScheduledDate: "2024-01-11",
+						ScheduledDate:        "2024-01-11",
+						IsCompleted:          false,
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -489,7 +789,11 @@ func TestItemUsecase_UpdateReviewDateAsCompleted(t *testing.T) {
 	reviewDateID := uuid.NewString()
 	userID := uuid.NewString()
 	itemID := uuid.NewString()
+	patternID := uuid.NewString()
 	editedAt := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	parsedToday := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	gradeGood := ItemDomain.RecallGradeGood
+	gradeAgain := ItemDomain.RecallGradeAgain
 
 	testReviewdate1, _ := ItemDomain.NewReviewdate(
 		uuid.NewString(),
@@ -518,47 +822,519 @@ func TestItemUsecase_UpdateReviewDateAsCompleted(t *testing.T) {
 		testReviewdate2,
 	}
 
-	tests := []struct {
-		name      string
-		input     UpdateReviewDateAsCompletedInput
-		mockSetup func(*CategoryDomain.MockICategoryRepository, *BoxDomain.MockIBoxRepository, *ItemDomain.MockIItemRepository, *PatternDomain.MockIPatternRepository, *transaction.MockITransactionManager, *ItemDomain.MockIScheduler)
-		want      *UpdateReviewDateAsCompletedOutput
-		wantErr   bool
-	}{
-		{
-			name: "最後のステップの復習日完了（復習物も完了）",
-			input: UpdateReviewDateAsCompletedInput{
-				ReviewDateID: reviewDateID,
-				UserID:       userID,
-				ItemID:       itemID,
-				StepNumber:   2,
-			},
-			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
-				gomock.InOrder(
-					mockItemRepo.EXPECT().
-						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
-						Return(testReviewdates, nil).
-						Times(1),
+	testPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1, PatternDomain.IntervalUnitDay)
+	testPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 3, PatternDomain.IntervalUnitDay)
+	testPatternSteps := []*PatternDomain.PatternStep{
+		testPatternStep1,
+		testPatternStep2,
+	}
 
-					mockItemRepo.EXPECT().
-						GetEditedAtByItemID(gomock.Any(), itemID, userID).
-						Return(editedAt, nil).
-						Times(1),
+	testItem, _ := ItemDomain.NewItem(
+		itemID,
+		userID,
+		nil,
+		nil,
+		&patternID,
+		"Test Item",
+		"Test Detail",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		false,
+		time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		editedAt,
+	)
 
-					mockTransactionManager.EXPECT().
-						RunInTransaction(gomock.Any(), gomock.Any()).
+	// 適応型パターンの復習物（未完了の復習日は直近の1件のみ）
+	adaptiveReviewdate1, _ := ItemDomain.NewReviewdate(
+		uuid.NewString(),
+		userID,
+		nil,
+		nil,
+		itemID,
+		1,
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		false,
+	)
+	adaptivePatternStep, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1, PatternDomain.IntervalUnitDay)
+	// 初期状態にgoodを反映した状態（2回目の間隔は6日）
+	adaptiveState, _ := ItemDomain.ReconstructSM2State(itemID, userID, 2.5, 1, 6)
+	nextAdaptiveReviewdate, _ := ItemDomain.NewReviewdate(
+		uuid.NewString(),
+		userID,
+		nil,
+		nil,
+		itemID,
+		2,
+		time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+		false,
+	)
+
+	// 繰り返しの間隔日数(30日)で今日(1/3)を起点に生成された3回目の復習日
+	maintenanceReviewdate3, _ := ItemDomain.NewReviewdate(
+		uuid.NewString(),
+		userID,
+		nil,
+		nil,
+		itemID,
+		3,
+		time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC),
+		false,
+	)
+
+	// again評価で今日(1/3)を起点に再計算された2回目の復習日
+	rescheduledReviewdate2, _ := ItemDomain.NewReviewdate(
+		testReviewdate2.ReviewdateID(),
+		userID,
+		nil,
+		nil,
+		itemID,
+		2,
+		time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC),
+		false,
+	)
+
+	tests := []struct {
+		name      string
+		input     UpdateReviewDateAsCompletedInput
+		mockSetup func(*CategoryDomain.MockICategoryRepository, *BoxDomain.MockIBoxRepository, *ItemDomain.MockIItemRepository, *PatternDomain.MockIPatternRepository, *transaction.MockITransactionManager, *ItemDomain.MockIScheduler)
+		want      *UpdateReviewDateAsCompletedOutput
+		wantErr   bool
+	}{
+		{
+			name: "最後のステップの復習日完了（復習物も完了）",
+			input: UpdateReviewDateAsCompletedInput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   2,
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testItem, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return(testReviewdates, nil).
+						Times(1),
+
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newFixedPattern(patternID, userID), nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetEditedAtByItemID(gomock.Any(), itemID, userID).
+						Return(editedAt, nil).
+						Times(1),
+
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						UpdateReviewDateAsCompleted(gomock.Any(), reviewDateID, userID, nil).
+						Return(nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						UpdateItemAsFinished(gomock.Any(), itemID, userID, gomock.Any()).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &UpdateReviewDateAsCompletedOutput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				IsCompleted:  true,
+				IsFinished:   true,
+				EditedAt:     editedAt,
+				ReviewDates:  []UpdateReviewDateOutput{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "繰り返しの間隔日数があるパターンで最後のステップの復習日完了（復習物は完了にせず次の復習日を生成）",
+			input: UpdateReviewDateAsCompletedInput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   2,
+				Today:        "2024-01-03",
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testItem, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return(testReviewdates, nil).
+						Times(1),
+
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newMaintenancePattern(patternID, userID, 30), nil).
+						Times(1),
+
+					mockScheduler.EXPECT().
+						WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).
+						Return(mockScheduler, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(gomock.Any(), userID).
+						Return([]int{}, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetBlockedDatesByUserID(gomock.Any(), userID).
+						Return([]time.Time{}, nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithExcludedWeekdays(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+
+					mockScheduler.EXPECT().
+						NextMaintenanceReviewdate(testReviewdate2, 30, parsedToday, parsedToday).
+						Return(maintenanceReviewdate3, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetEditedAtByItemID(gomock.Any(), itemID, userID).
+						Return(editedAt, nil).
+						Times(1),
+
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						UpdateReviewDateAsCompleted(gomock.Any(), reviewDateID, userID, nil).
+						Return(nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						CreateReviewdates(gomock.Any(), []*ItemDomain.Reviewdate{maintenanceReviewdate3}).
+						Return(int64(1), nil).
+						Times(1),
+				)
+			},
+			want: &UpdateReviewDateAsCompletedOutput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				IsCompleted:  true,
+				IsFinished:   false,
+				EditedAt:     editedAt,
+				ReviewDates: []UpdateReviewDateOutput{
+					{
+						ReviewDateID:         maintenanceReviewdate3.ReviewdateID(),
+						UserID:               userID,
+						ItemID:               itemID,
+						StepNumber:           3,
+						InitialScheduledDate: "2024-02-02",
+						ScheduledDate:        "2024-02-02",
+						IsCompleted:          false,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "最後のステップではない復習日完了",
+			input: UpdateReviewDateAsCompletedInput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   1,
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testItem, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return(testReviewdates, nil).
+						Times(1),
+
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newFixedPattern(patternID, userID), nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetEditedAtByItemID(gomock.Any(), itemID, userID).
+						Return(editedAt, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						UpdateReviewDateAsCompleted(gomock.Any(), reviewDateID, userID, nil).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &UpdateReviewDateAsCompletedOutput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				IsCompleted:  true,
+				IsFinished:   false,
+				EditedAt:     editedAt,
+				ReviewDates:  []UpdateReviewDateOutput{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "想起評価goodで復習日完了（後続の復習日は変更しない）",
+			input: UpdateReviewDateAsCompletedInput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   1,
+				RecallGrade:  ItemDomain.RecallGradeGood,
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testItem, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return(testReviewdates, nil).
+						Times(1),
+
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newFixedPattern(patternID, userID), nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetEditedAtByItemID(gomock.Any(), itemID, userID).
+						Return(editedAt, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						UpdateReviewDateAsCompleted(gomock.Any(), reviewDateID, userID, &gradeGood).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &UpdateReviewDateAsCompletedOutput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				IsCompleted:  true,
+				IsFinished:   false,
+				RecallGrade:  &gradeGood,
+				EditedAt:     editedAt,
+				ReviewDates:  []UpdateReviewDateOutput{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "想起評価againで復習日完了（後続の復習日を今日から再計算）",
+			input: UpdateReviewDateAsCompletedInput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   1,
+				RecallGrade:  ItemDomain.RecallGradeAgain,
+				Today:        "2024-01-03",
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testItem, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return(testReviewdates, nil).
+						Times(1),
+
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newFixedPattern(patternID, userID), nil).
+						Times(1),
+
+					mockPatternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(gomock.Any(), patternID, userID).
+						Return(testPatternSteps, nil).
+						Times(1),
+
+					mockScheduler.EXPECT().
+						WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).
+						Return(mockScheduler, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(gomock.Any(), userID).
+						Return([]int{}, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetBlockedDatesByUserID(gomock.Any(), userID).
+						Return([]time.Time{}, nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithExcludedWeekdays(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+
+					mockScheduler.EXPECT().
+						RescheduleByRecallGrade(testPatternSteps, testReviewdates, 1, ItemDomain.RecallGradeAgain, parsedToday).
+						Return([]*ItemDomain.Reviewdate{rescheduledReviewdate2}, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetEditedAtByItemID(gomock.Any(), itemID, userID).
+						Return(editedAt, nil).
+						Times(1),
+
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						UpdateReviewDateAsCompleted(gomock.Any(), reviewDateID, userID, &gradeAgain).
+						Return(nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						UpdateReviewDates(gomock.Any(), []*ItemDomain.Reviewdate{rescheduledReviewdate2}, userID).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &UpdateReviewDateAsCompletedOutput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				IsCompleted:  true,
+				IsFinished:   false,
+				RecallGrade:  &gradeAgain,
+				EditedAt:     editedAt,
+				ReviewDates: []UpdateReviewDateOutput{
+					{
+						ReviewDateID:         testReviewdate2.ReviewdateID(),
+						UserID:               userID,
+						ItemID:               itemID,
+						StepNumber:           2,
+						InitialScheduledDate: "2024-01-04",
+						ScheduledDate:        "2024-01-04",
+						IsCompleted:          false,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "適応型パターンの復習日完了（次の復習日を1件生成）",
+			input: UpdateReviewDateAsCompletedInput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   1,
+				RecallGrade:  ItemDomain.RecallGradeGood,
+				Today:        "2024-01-03",
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testItem, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return([]*ItemDomain.Reviewdate{adaptiveReviewdate1}, nil).
+						Times(1),
+
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newAdaptivePattern(patternID, userID), nil).
+						Times(1),
+
+					mockPatternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(gomock.Any(), patternID, userID).
+						Return([]*PatternDomain.PatternStep{adaptivePatternStep}, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						FindSM2StateByItemID(gomock.Any(), itemID, userID).
+						Return(nil, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(gomock.Any(), userID).
+						Return([]int{}, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetBlockedDatesByUserID(gomock.Any(), userID).
+						Return([]time.Time{}, nil).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithExcludedWeekdays(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+					mockScheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(mockScheduler).
+						Times(1),
+
+					mockScheduler.EXPECT().
+						NextAdaptiveReviewdate(adaptiveReviewdate1, adaptiveState, parsedToday).
+						Return(nextAdaptiveReviewdate, nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						GetEditedAtByItemID(gomock.Any(), itemID, userID).
+						Return(editedAt, nil).
+						Times(1),
+
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
 						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
 						}).
 						Times(1),
 
 					mockItemRepo.EXPECT().
-						UpdateReviewDateAsCompleted(gomock.Any(), reviewDateID, userID).
+						UpdateReviewDateAsCompleted(gomock.Any(), reviewDateID, userID, &gradeGood).
 						Return(nil).
 						Times(1),
 
 					mockItemRepo.EXPECT().
-						UpdateItemAsFinished(gomock.Any(), itemID, userID, gomock.Any()).
+						CreateReviewdates(gomock.Any(), []*ItemDomain.Reviewdate{nextAdaptiveReviewdate}).
+						Return(int64(1), nil).
+						Times(1),
+
+					mockItemRepo.EXPECT().
+						UpsertSM2State(gomock.Any(), adaptiveState).
 						Return(nil).
 						Times(1),
 				)
@@ -567,13 +1343,25 @@ func TestItemUsecase_UpdateReviewDateAsCompleted(t *testing.T) {
 				ReviewDateID: reviewDateID,
 				UserID:       userID,
 				IsCompleted:  true,
-				IsFinished:   true,
+				IsFinished:   false,
+				RecallGrade:  &gradeGood,
 				EditedAt:     editedAt,
+				ReviewDates: []UpdateReviewDateOutput{
+					{
+						ReviewDateID:         nextAdaptiveReviewdate.ReviewdateID(),
+						UserID:               userID,
+						ItemID:               itemID,
+						StepNumber:           2,
+						InitialScheduledDate: "2024-01-09",
+						ScheduledDate:        "2024-01-09",
+						IsCompleted:          false,
+					},
+				},
 			},
 			wantErr: false,
 		},
 		{
-			name: "最後のステップではない復習日完了",
+			name: "適応型パターンで直近以外の復習日を完了しようとした場合",
 			input: UpdateReviewDateAsCompletedInput{
 				ReviewDateID: reviewDateID,
 				UserID:       userID,
@@ -583,29 +1371,37 @@ func TestItemUsecase_UpdateReviewDateAsCompleted(t *testing.T) {
 			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
 				gomock.InOrder(
 					mockItemRepo.EXPECT().
-						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
-						Return(testReviewdates, nil).
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testItem, nil).
 						Times(1),
 
 					mockItemRepo.EXPECT().
-						GetEditedAtByItemID(gomock.Any(), itemID, userID).
-						Return(editedAt, nil).
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return(testReviewdates, nil).
 						Times(1),
 
-					mockItemRepo.EXPECT().
-						UpdateReviewDateAsCompleted(gomock.Any(), reviewDateID, userID).
-						Return(nil).
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newAdaptivePattern(patternID, userID), nil).
 						Times(1),
 				)
 			},
-			want: &UpdateReviewDateAsCompletedOutput{
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "想起評価の値が不正な場合",
+			input: UpdateReviewDateAsCompletedInput{
 				ReviewDateID: reviewDateID,
 				UserID:       userID,
-				IsCompleted:  true,
-				IsFinished:   false,
-				EditedAt:     editedAt,
+				ItemID:       itemID,
+				StepNumber:   1,
+				RecallGrade:  "perfect",
 			},
-			wantErr: false,
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+			},
+			want:    nil,
+			wantErr: true,
 		},
 	}
 
@@ -689,6 +1485,78 @@ func TestItemUsecase_UpdateReviewDateAsInCompleted(t *testing.T) {
 		editedAt,
 	)
 
+	// 適応型パターンの復習物（1回目good、2回目againで完了済み）
+	patternID := uuid.NewString()
+	gradeGood := ItemDomain.RecallGradeGood
+	gradeAgain := ItemDomain.RecallGradeAgain
+	testAdaptiveItem, _ := ItemDomain.NewItem(
+		itemID,
+		userID,
+		nil,
+		nil,
+		&patternID,
+		"Test Item",
+		"Test Detail",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		false,
+		time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		editedAt,
+	)
+	adaptiveReviewdate1, _ := ItemDomain.ReconstructReviewdate(uuid.NewString(), userID, nil, nil, itemID, 1, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true, &gradeGood, nil)
+	adaptiveReviewdate2, _ := ItemDomain.ReconstructReviewdate(reviewDateID, userID, nil, nil, itemID, 2, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), true, &gradeAgain, nil)
+	adaptiveReviewdate3, _ := ItemDomain.ReconstructReviewdate(uuid.NewString(), userID, nil, nil, itemID, 3, time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC), false, nil, nil)
+	adaptiveReviewdates := []*ItemDomain.Reviewdate{
+		adaptiveReviewdate1,
+		adaptiveReviewdate2,
+		adaptiveReviewdate3,
+	}
+	adaptivePatternStep, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1, PatternDomain.IntervalUnitDay)
+	// 1回目のgoodのみを反映し直した状態
+	restoredState, _ := ItemDomain.ReconstructSM2State(itemID, userID, 2.5, 1, 6)
+
+	// 繰り返しの間隔日数があるパターンの復習物（最後のステップの2回目まで完了済みで、3回目が生成済み）
+	maintenanceReviewdate1, _ := ItemDomain.ReconstructReviewdate(uuid.NewString(), userID, nil, nil, itemID, 1, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true, nil, nil)
+	maintenanceReviewdate2, _ := ItemDomain.ReconstructReviewdate(reviewDateID, userID, nil, nil, itemID, 2, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), true, nil, nil)
+	maintenanceReviewdate3, _ := ItemDomain.ReconstructReviewdate(uuid.NewString(), userID, nil, nil, itemID, 3, time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC), false, nil, nil)
+	maintenanceReviewdates := []*ItemDomain.Reviewdate{
+		maintenanceReviewdate1,
+		maintenanceReviewdate2,
+		maintenanceReviewdate3,
+	}
+	maintenancePatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1, PatternDomain.IntervalUnitDay)
+	maintenancePatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 4, PatternDomain.IntervalUnitDay)
+	maintenancePatternSteps := []*PatternDomain.PatternStep{
+		maintenancePatternStep1,
+		maintenancePatternStep2,
+	}
+
+	// 3ステップの版で復習日を算出した後に、パターンのステップが2つに変更された復習物
+	patternVersionID := uuid.NewString()
+	testVersionedItem, _ := ItemDomain.ReconstructItem(
+		itemID,
+		userID,
+		nil,
+		nil,
+		&patternID,
+		&patternVersionID,
+		"Test Item",
+		"Test Detail",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		false,
+		time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		editedAt,
+	)
+	versionStep3, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 3, 7, PatternDomain.IntervalUnitDay)
+	itemPatternVersion, _ := PatternDomain.ReconstructPatternVersion(
+		patternVersionID,
+		userID,
+		patternID,
+		1,
+		PatternDomain.SchedulingAlgorithmFixed,
+		[]*PatternDomain.PatternStep{maintenancePatternStep1, maintenancePatternStep2, versionStep3},
+		time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+	)
+
 	tests := []struct {
 		name      string
 		input     UpdateReviewDateAsInCompletedInput
@@ -770,7 +1638,221 @@ func TestItemUsecase_UpdateReviewDateAsInCompleted(t *testing.T) {
 				IsFinished:   false,
 				EditedAt:     editedAt,
 			},
-			wantErr: false,
+			wantErr: false,
+		},
+		{
+			name: "適応型パターンの復習日未完了化（次の復習日の削除と学習状態の復元）",
+			input: UpdateReviewDateAsInCompletedInput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   2,
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testAdaptiveItem, nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newAdaptivePattern(patternID, userID), nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return(adaptiveReviewdates, nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(gomock.Any(), patternID, userID).
+						Return([]*PatternDomain.PatternStep{adaptivePatternStep}, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetEditedAtByItemID(gomock.Any(), itemID, userID).
+						Return(editedAt, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockItemRepo.EXPECT().
+						UpdateReviewDateAsInCompleted(gomock.Any(), reviewDateID, userID).
+						Return(nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						DeleteInCompletedReviewDatesAfterStep(gomock.Any(), itemID, userID, 2).
+						Return(nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						UpsertSM2State(gomock.Any(), restoredState).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &UpdateReviewDateAsInCompletedOutput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				IsCompleted:  false,
+				IsFinished:   false,
+				EditedAt:     editedAt,
+			},
+			wantErr: false,
+		},
+		{
+			name: "適応型パターンで後続の復習日が完了済みの場合",
+			input: UpdateReviewDateAsInCompletedInput{
+				ReviewDateID: adaptiveReviewdate1.ReviewdateID(),
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   1,
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testAdaptiveItem, nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newAdaptivePattern(patternID, userID), nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return(adaptiveReviewdates, nil).
+						Times(1),
+				)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "繰り返しの間隔日数があるパターンで最後のステップの復習日未完了化（生成済みの次の復習日を削除）",
+			input: UpdateReviewDateAsInCompletedInput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   2,
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testAdaptiveItem, nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newMaintenancePattern(patternID, userID, 30), nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(gomock.Any(), patternID, userID).
+						Return(maintenancePatternSteps, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return(maintenanceReviewdates, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetEditedAtByItemID(gomock.Any(), itemID, userID).
+						Return(editedAt, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockItemRepo.EXPECT().
+						UpdateReviewDateAsInCompleted(gomock.Any(), reviewDateID, userID).
+						Return(nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						DeleteInCompletedReviewDatesAfterStep(gomock.Any(), itemID, userID, 2).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &UpdateReviewDateAsInCompletedOutput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				IsCompleted:  false,
+				IsFinished:   false,
+				EditedAt:     editedAt,
+			},
+			wantErr: false,
+		},
+		{
+			name: "版を記録している復習物は版のステップで最後のステップかどうかを判定する",
+			input: UpdateReviewDateAsInCompletedInput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   2,
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testVersionedItem, nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newMaintenancePattern(patternID, userID, 30), nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternVersionByID(gomock.Any(), patternVersionID, userID).
+						Return(itemPatternVersion, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetEditedAtByItemID(gomock.Any(), itemID, userID).
+						Return(editedAt, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						UpdateReviewDateAsInCompleted(gomock.Any(), reviewDateID, userID).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &UpdateReviewDateAsInCompletedOutput{
+				ReviewDateID: reviewDateID,
+				UserID:       userID,
+				IsCompleted:  false,
+				IsFinished:   false,
+				EditedAt:     editedAt,
+			},
+			wantErr: false,
+		},
+		{
+			name: "繰り返しの間隔日数があるパターンで後続の復習日が完了済みの場合",
+			input: UpdateReviewDateAsInCompletedInput{
+				ReviewDateID: maintenanceReviewdate1.ReviewdateID(),
+				UserID:       userID,
+				ItemID:       itemID,
+				StepNumber:   2,
+			},
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				completedReviewdate3, _ := ItemDomain.ReconstructReviewdate(maintenanceReviewdate3.ReviewdateID(), userID, nil, nil, itemID, 3, time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC), true, nil, nil)
+				gomock.InOrder(
+					mockItemRepo.EXPECT().
+						GetItemByID(gomock.Any(), itemID, userID).
+						Return(testAdaptiveItem, nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						FindPatternByPatternID(gomock.Any(), patternID, userID).
+						Return(newMaintenancePattern(patternID, userID, 30), nil).
+						Times(1),
+					mockPatternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(gomock.Any(), patternID, userID).
+						Return(maintenancePatternSteps, nil).
+						Times(1),
+					mockItemRepo.EXPECT().
+						GetReviewDatesByItemID(gomock.Any(), itemID, userID).
+						Return([]*ItemDomain.Reviewdate{maintenanceReviewdate1, maintenanceReviewdate2, completedReviewdate3}, nil).
+						Times(1),
+				)
+			},
+			want:    nil,
+			wantErr: true,
 		},
 	}
 
@@ -864,6 +1946,7 @@ func TestItemUsecase_UpdateItemAsUnFinishedForce(t *testing.T) {
 		patternID,
 		1,
 		1,
+		PatternDomain.IntervalUnitDay,
 	)
 	testPatternStep2, _ := PatternDomain.NewPatternStep(
 		uuid.NewString(),
@@ -871,6 +1954,7 @@ func TestItemUsecase_UpdateItemAsUnFinishedForce(t *testing.T) {
 		patternID,
 		2,
 		3,
+		PatternDomain.IntervalUnitDay,
 	)
 	testPatternSteps := []*PatternDomain.PatternStep{
 		testPatternStep1,
@@ -925,6 +2009,12 @@ func TestItemUsecase_UpdateItemAsUnFinishedForce(t *testing.T) {
 				gomock.InOrder(
 					mockItemRepo.EXPECT().GetReviewDatesByItemID(ctx, itemID, userID).Return(testReviewDates, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(testPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedInCompletedWithIDs(
 						testPatternSteps,
 						[]string{testReviewDates[0].ReviewdateID(), testReviewDates[1].ReviewdateID()},
@@ -1103,6 +2193,7 @@ func TestItemUsecase_UpdateItem_PatternNilToNotNil(t *testing.T) {
 					patternID,
 					1,
 					1,
+					PatternDomain.IntervalUnitDay,
 				)
 				testPatternStep2, _ := PatternDomain.NewPatternStep(
 					uuid.NewString(),
@@ -1110,6 +2201,7 @@ func TestItemUsecase_UpdateItem_PatternNilToNotNil(t *testing.T) {
 					patternID,
 					2,
 					3,
+					PatternDomain.IntervalUnitDay,
 				)
 				testPatternSteps := []*PatternDomain.PatternStep{
 					testPatternStep1,
@@ -1159,10 +2251,17 @@ func TestItemUsecase_UpdateItem_PatternNilToNotNil(t *testing.T) {
 				gomock.InOrder(
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(testPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(false, nil).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedInCompleted(
 						testPatternSteps, userID, &categoryID, &boxID, itemID, learnedDate, gomock.Any(),
 					).Return(testNewReviewdates1, nil).Times(1),
+					mockItemRepo.EXPECT().GetDailyReviewLimitByUserID(ctx, userID).Return(0, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
@@ -1207,6 +2306,7 @@ func TestItemUsecase_UpdateItem_PatternNilToNotNil(t *testing.T) {
 					patternID,
 					1,
 					1,
+					PatternDomain.IntervalUnitDay,
 				)
 				testPatternStep2, _ := PatternDomain.NewPatternStep(
 					uuid.NewString(),
@@ -1214,6 +2314,7 @@ func TestItemUsecase_UpdateItem_PatternNilToNotNil(t *testing.T) {
 					patternID,
 					2,
 					3,
+					PatternDomain.IntervalUnitDay,
 				)
 				testPatternSteps := []*PatternDomain.PatternStep{
 					testPatternStep1,
@@ -1263,10 +2364,17 @@ func TestItemUsecase_UpdateItem_PatternNilToNotNil(t *testing.T) {
 				gomock.InOrder(
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(testPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(false, nil).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedCompleted(
 						testPatternSteps, userID, &categoryID, &boxID, itemID, learnedDate, gomock.Any(),
 					).Return(testNewReviewdates2, false, nil).Times(1),
+					mockItemRepo.EXPECT().GetDailyReviewLimitByUserID(ctx, userID).Return(0, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
@@ -1354,6 +2462,7 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_LengthDiff(t *testing.T) {
 					currentPatternID,
 					1,
 					1,
+					PatternDomain.IntervalUnitDay,
 				)
 				currentPatternStep2, _ := PatternDomain.NewPatternStep(
 					uuid.NewString(),
@@ -1361,6 +2470,7 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_LengthDiff(t *testing.T) {
 					currentPatternID,
 					2,
 					3,
+					PatternDomain.IntervalUnitDay,
 				)
 				currentPatternSteps := []*PatternDomain.PatternStep{
 					currentPatternStep1,
@@ -1373,6 +2483,7 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_LengthDiff(t *testing.T) {
 					newPatternID,
 					1,
 					1,
+					PatternDomain.IntervalUnitDay,
 				)
 				newPatternStep2, _ := PatternDomain.NewPatternStep(
 					uuid.NewString(),
@@ -1380,6 +2491,7 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_LengthDiff(t *testing.T) {
 					newPatternID,
 					2,
 					3,
+					PatternDomain.IntervalUnitDay,
 				)
 				newPatternStep3, _ := PatternDomain.NewPatternStep(
 					uuid.NewString(),
@@ -1387,6 +2499,7 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_LengthDiff(t *testing.T) {
 					newPatternID,
 					3,
 					7,
+					PatternDomain.IntervalUnitDay,
 				)
 				newPatternSteps := []*PatternDomain.PatternStep{
 					newPatternStep1,
@@ -1433,10 +2546,18 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_LengthDiff(t *testing.T) {
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, currentPatternID, userID).Return(currentPatternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, newPatternID, userID).Return(newPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, currentPatternID, userID).Return(newFixedPattern(currentPatternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, newPatternID, userID).Return(newFixedPattern(newPatternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(false, nil).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedInCompleted(
 						newPatternSteps, userID, &categoryID, &boxID, itemID, learnedDate, gomock.Any(),
 					).Return(testNewReviewdates, nil).Times(1),
+					mockItemRepo.EXPECT().GetDailyReviewLimitByUserID(ctx, userID).Return(0, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
@@ -1486,13 +2607,13 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_LengthDiff(t *testing.T) {
 					time.Now(),
 				)
 
-				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1)
-				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3)
+				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				currentPatternSteps := []*PatternDomain.PatternStep{currentPatternStep1, currentPatternStep2}
 
-				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1)
-				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3)
-				newPatternStep3, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 3, 7)
+				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3, PatternDomain.IntervalUnitDay)
+				newPatternStep3, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 3, 7, PatternDomain.IntervalUnitDay)
 				newPatternSteps := []*PatternDomain.PatternStep{newPatternStep1, newPatternStep2, newPatternStep3}
 
 				testNewReviewdate1, _ := ItemDomain.NewReviewdate(
@@ -1534,10 +2655,18 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_LengthDiff(t *testing.T) {
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, currentPatternID, userID).Return(currentPatternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, newPatternID, userID).Return(newPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, currentPatternID, userID).Return(newFixedPattern(currentPatternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, newPatternID, userID).Return(newFixedPattern(newPatternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(false, nil).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedCompleted(
 						newPatternSteps, userID, &categoryID, &boxID, itemID, learnedDate, gomock.Any(),
 					).Return(testNewReviewdates, false, nil).Times(1),
+					mockItemRepo.EXPECT().GetDailyReviewLimitByUserID(ctx, userID).Return(0, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
@@ -1587,19 +2716,26 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_LengthDiff(t *testing.T) {
 					time.Now(),
 				)
 
-				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1)
-				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3)
+				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				currentPatternSteps := []*PatternDomain.PatternStep{currentPatternStep1, currentPatternStep2}
 
-				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1)
-				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3)
-				newPatternStep3, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 3, 7)
+				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3, PatternDomain.IntervalUnitDay)
+				newPatternStep3, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 3, 7, PatternDomain.IntervalUnitDay)
 				newPatternSteps := []*PatternDomain.PatternStep{newPatternStep1, newPatternStep2, newPatternStep3}
 
 				gomock.InOrder(
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, currentPatternID, userID).Return(currentPatternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, newPatternID, userID).Return(newPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, currentPatternID, userID).Return(newFixedPattern(currentPatternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, newPatternID, userID).Return(newFixedPattern(newPatternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(true, nil).Times(1),
 				)
 
@@ -1686,12 +2822,12 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_IntervalDaysDiff(t *testin
 					time.Now(),
 				)
 
-				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1)
-				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3)
+				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				currentPatternSteps := []*PatternDomain.PatternStep{currentPatternStep1, currentPatternStep2}
 
-				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 2)
-				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 5)
+				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 2, PatternDomain.IntervalUnitDay)
+				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 5, PatternDomain.IntervalUnitDay)
 				newPatternSteps := []*PatternDomain.PatternStep{newPatternStep1, newPatternStep2}
 
 				reviewDateIDs := []string{uuid.NewString(), uuid.NewString()}
@@ -1724,11 +2860,19 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_IntervalDaysDiff(t *testin
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, currentPatternID, userID).Return(currentPatternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, newPatternID, userID).Return(newPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, currentPatternID, userID).Return(newFixedPattern(currentPatternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, newPatternID, userID).Return(newFixedPattern(newPatternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(false, nil).Times(1),
 					mockItemRepo.EXPECT().GetReviewDateIDsByItemID(ctx, itemID, userID).Return(reviewDateIDs, nil).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedInCompletedWithIDs(
 						newPatternSteps, reviewDateIDs, userID, &categoryID, &boxID, itemID, learnedDate, gomock.Any(),
 					).Return(testNewReviewdates, nil).Times(1),
+					mockItemRepo.EXPECT().GetDailyReviewLimitByUserID(ctx, userID).Return(0, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
@@ -1777,12 +2921,12 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_IntervalDaysDiff(t *testin
 					time.Now(),
 				)
 
-				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1)
-				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3)
+				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				currentPatternSteps := []*PatternDomain.PatternStep{currentPatternStep1, currentPatternStep2}
 
-				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 2)
-				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 5)
+				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 2, PatternDomain.IntervalUnitDay)
+				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 5, PatternDomain.IntervalUnitDay)
 				newPatternSteps := []*PatternDomain.PatternStep{newPatternStep1, newPatternStep2}
 
 				reviewDateIDs := []string{uuid.NewString(), uuid.NewString()}
@@ -1815,11 +2959,19 @@ func TestItemUsecase_UpdateItem_PatternNotNilToNotNil_IntervalDaysDiff(t *testin
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, currentPatternID, userID).Return(currentPatternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, newPatternID, userID).Return(newPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, currentPatternID, userID).Return(newFixedPattern(currentPatternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, newPatternID, userID).Return(newFixedPattern(newPatternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(false, nil).Times(1),
 					mockItemRepo.EXPECT().GetReviewDateIDsByItemID(ctx, itemID, userID).Return(reviewDateIDs, nil).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedCompletedWithIDs(
 						newPatternSteps, reviewDateIDs, userID, &categoryID, &boxID, itemID, learnedDate, gomock.Any(),
 					).Return(testNewReviewdates, false, nil).Times(1),
+					mockItemRepo.EXPECT().GetDailyReviewLimitByUserID(ctx, userID).Return(0, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
@@ -1912,8 +3064,8 @@ func TestItemUsecase_UpdateItem_LearnedDateChanged(t *testing.T) {
 					time.Now(),
 				)
 
-				patternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1)
-				patternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 3)
+				patternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1, PatternDomain.IntervalUnitDay)
+				patternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 3, PatternDomain.IntervalUnitDay)
 				patternSteps := []*PatternDomain.PatternStep{patternStep1, patternStep2}
 
 				reviewDateIDs := []string{uuid.NewString(), uuid.NewString()}
@@ -1959,11 +3111,19 @@ func TestItemUsecase_UpdateItem_LearnedDateChanged(t *testing.T) {
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(patternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(patternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(false, nil).Times(1),
 					mockItemRepo.EXPECT().GetReviewDateIDsByItemID(ctx, itemID, userID).Return(reviewDateIDs, nil).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedInCompletedWithIDs(
 						patternSteps, reviewDateIDs, userID, &categoryID, &boxID, itemID, gomock.Any(), gomock.Any(),
 					).Return(testNewReviewdates, nil).Times(1),
+					mockItemRepo.EXPECT().GetDailyReviewLimitByUserID(ctx, userID).Return(0, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
@@ -2001,8 +3161,8 @@ func TestItemUsecase_UpdateItem_LearnedDateChanged(t *testing.T) {
 					time.Now(),
 				)
 
-				patternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1)
-				patternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 3)
+				patternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1, PatternDomain.IntervalUnitDay)
+				patternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 3, PatternDomain.IntervalUnitDay)
 				patternSteps := []*PatternDomain.PatternStep{patternStep1, patternStep2}
 
 				input := UpdateItemInput{
@@ -2022,6 +3182,13 @@ func TestItemUsecase_UpdateItem_LearnedDateChanged(t *testing.T) {
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(patternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(patternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(true, nil).Times(1),
 				)
 				return ctx, input
@@ -2097,12 +3264,12 @@ func TestItemUsecase_UpdateItem_SamePatternStepsStructure_LearnedDateChanged(t *
 				)
 
 				// 同じpatternStepsStructure（stepNumberとintervalDaysが同じ）
-				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1)
-				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3)
+				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				currentPatternSteps := []*PatternDomain.PatternStep{currentPatternStep1, currentPatternStep2}
 
-				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1)
-				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3)
+				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				newPatternSteps := []*PatternDomain.PatternStep{newPatternStep1, newPatternStep2}
 
 				reviewDateIDs := []string{uuid.NewString(), uuid.NewString()}
@@ -2135,11 +3302,19 @@ func TestItemUsecase_UpdateItem_SamePatternStepsStructure_LearnedDateChanged(t *
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, currentPatternID, userID).Return(currentPatternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, newPatternID, userID).Return(newPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, currentPatternID, userID).Return(newFixedPattern(currentPatternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, newPatternID, userID).Return(newFixedPattern(newPatternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(false, nil).Times(1),
 					mockItemRepo.EXPECT().GetReviewDateIDsByItemID(ctx, itemID, userID).Return(reviewDateIDs, nil).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedInCompletedWithIDs(
 						newPatternSteps, reviewDateIDs, userID, &categoryID, &boxID, itemID, gomock.Any(), gomock.Any(),
 					).Return(testNewReviewdates, nil).Times(1),
+					mockItemRepo.EXPECT().GetDailyReviewLimitByUserID(ctx, userID).Return(0, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
@@ -2189,12 +3364,12 @@ func TestItemUsecase_UpdateItem_SamePatternStepsStructure_LearnedDateChanged(t *
 				)
 
 				// 同じpatternStepsStructure（stepNumberとintervalDaysが同じ）
-				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1)
-				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3)
+				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				currentPatternSteps := []*PatternDomain.PatternStep{currentPatternStep1, currentPatternStep2}
 
-				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1)
-				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3)
+				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				newPatternSteps := []*PatternDomain.PatternStep{newPatternStep1, newPatternStep2}
 
 				reviewDateIDs := []string{uuid.NewString(), uuid.NewString()}
@@ -2227,11 +3402,19 @@ func TestItemUsecase_UpdateItem_SamePatternStepsStructure_LearnedDateChanged(t *
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, currentPatternID, userID).Return(currentPatternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, newPatternID, userID).Return(newPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, currentPatternID, userID).Return(newFixedPattern(currentPatternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, newPatternID, userID).Return(newFixedPattern(newPatternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(false, nil).Times(1),
 					mockItemRepo.EXPECT().GetReviewDateIDsByItemID(ctx, itemID, userID).Return(reviewDateIDs, nil).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedCompletedWithIDs(
 						newPatternSteps, reviewDateIDs, userID, &categoryID, &boxID, itemID, gomock.Any(), gomock.Any(),
 					).Return(testNewReviewdates, false, nil).Times(1),
+					mockItemRepo.EXPECT().GetDailyReviewLimitByUserID(ctx, userID).Return(0, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
 							return fn(ctx)
@@ -2281,18 +3464,25 @@ func TestItemUsecase_UpdateItem_SamePatternStepsStructure_LearnedDateChanged(t *
 				)
 
 				// 同じpatternStepsStructure（stepNumberとintervalDaysが同じ）
-				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1)
-				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3)
+				currentPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				currentPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, currentPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				currentPatternSteps := []*PatternDomain.PatternStep{currentPatternStep1, currentPatternStep2}
 
-				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1)
-				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3)
+				newPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 1, 1, PatternDomain.IntervalUnitDay)
+				newPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, newPatternID, 2, 3, PatternDomain.IntervalUnitDay)
 				newPatternSteps := []*PatternDomain.PatternStep{newPatternStep1, newPatternStep2}
 
 				gomock.InOrder(
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, currentPatternID, userID).Return(currentPatternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, newPatternID, userID).Return(newPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, currentPatternID, userID).Return(newFixedPattern(currentPatternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, newPatternID, userID).Return(newFixedPattern(newPatternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().HasCompletedReviewDateByItemID(ctx, itemID, userID).Return(true, nil).Times(1),
 				)
 
@@ -2372,8 +3562,8 @@ func TestItemUsecase_UpdateItem_CategoryIDBoxIDUpdate(t *testing.T) {
 		time.Now(),
 	)
 
-	patternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1)
-	patternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 3)
+	patternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1, PatternDomain.IntervalUnitDay)
+	patternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 3, PatternDomain.IntervalUnitDay)
 	patternSteps := []*PatternDomain.PatternStep{patternStep1, patternStep2}
 
 	currentReviewdate1, _ := ItemDomain.NewReviewdate(
@@ -2425,6 +3615,13 @@ func TestItemUsecase_UpdateItem_CategoryIDBoxIDUpdate(t *testing.T) {
 					mockItemRepo.EXPECT().GetItemByID(ctx, itemID, userID).Return(currentItem, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(patternSteps, nil).Times(1),
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(patternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockItemRepo.EXPECT().GetReviewDatesByItemID(ctx, itemID, userID).Return(currentReviewdates, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
@@ -2487,8 +3684,8 @@ func TestItemUsecase_UpdateReviewDates(t *testing.T) {
 	initialScheduledDate := "2024-01-02"
 	requestScheduledDate := "2024-01-05"
 
-	testPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1)
-	testPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 3)
+	testPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1, PatternDomain.IntervalUnitDay)
+	testPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 3, PatternDomain.IntervalUnitDay)
 	testPatternSteps := []*PatternDomain.PatternStep{testPatternStep1, testPatternStep2}
 
 	testReviewDateIDs := []string{reviewDateID, uuid.NewString()}
@@ -2557,6 +3754,37 @@ func TestItemUsecase_UpdateReviewDates(t *testing.T) {
 	)
 	testOverdueCompletedReviewdates := []*ItemDomain.Reviewdate{testOverdueCompletedReviewdate1, testOverdueCompletedReviewdate2}
 
+	// 適応型パターン用のテストデータ
+	testAdaptivePatternStep, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 1, PatternDomain.IntervalUnitDay)
+	testAdaptiveReviewdate, _ := ItemDomain.NewReviewdate(
+		reviewDateID,
+		userID,
+		&categoryID,
+		&boxID,
+		itemID,
+		1,
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		false,
+	)
+	testNextAdaptiveReviewdate, _ := ItemDomain.NewReviewdate(
+		uuid.NewString(),
+		userID,
+		&categoryID,
+		&boxID,
+		itemID,
+		2,
+		time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC),
+		false,
+	)
+	testAdaptiveState, _ := ItemDomain.ReconstructSM2State(itemID, userID, 2.5, 1, 6)
+
+	// 分・時間単位のステップを含むパターン用のテストデータ
+	testIntradayPatternStep1, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 1, 10, PatternDomain.IntervalUnitMinute)
+	testIntradayPatternStep2, _ := PatternDomain.NewPatternStep(uuid.NewString(), userID, patternID, 2, 1, PatternDomain.IntervalUnitDay)
+	testIntradayPatternSteps := []*PatternDomain.PatternStep{testIntradayPatternStep1, testIntradayPatternStep2}
+
 	tests := []struct {
 		name      string
 		input     UpdateBackReviewDateInput
@@ -2582,7 +3810,14 @@ func TestItemUsecase_UpdateReviewDates(t *testing.T) {
 			setupMock: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
 				gomock.InOrder(
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(testPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
 					mockItemRepo.EXPECT().GetReviewDateIDsByItemID(ctx, itemID, userID).Return(testReviewDateIDs, nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().OffsetDays(testPatternSteps, gomock.Any()).Return([]int{1, 3}).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedInCompletedWithIDsForBackReviewDates(
 						testPatternSteps,
 						testReviewDateIDs,
@@ -2619,7 +3854,13 @@ func TestItemUsecase_UpdateReviewDates(t *testing.T) {
 			setupMock: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
 				gomock.InOrder(
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(testPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
 					mockItemRepo.EXPECT().GetReviewDateIDsByItemID(ctx, itemID, userID).Return(testReviewDateIDs, nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedCompletedWithIDs(
 						testPatternSteps,
 						testReviewDateIDs,
@@ -2656,7 +3897,13 @@ func TestItemUsecase_UpdateReviewDates(t *testing.T) {
 			setupMock: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
 				gomock.InOrder(
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(testPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
 					mockItemRepo.EXPECT().GetReviewDateIDsByItemID(ctx, itemID, userID).Return(testReviewDateIDs, nil).Times(1),
+					mockScheduler.EXPECT().WithAlgorithm(PatternDomain.SchedulingAlgorithmFixed).Return(mockScheduler, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
 					mockScheduler.EXPECT().FormatWithOverdueMarkedCompletedWithIDs(
 						testPatternSteps,
 						testReviewDateIDs,
@@ -2699,6 +3946,7 @@ func TestItemUsecase_UpdateReviewDates(t *testing.T) {
 			setupMock: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
 				gomock.InOrder(
 					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(testPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
 					mockItemRepo.EXPECT().GetEditedAtByItemID(ctx, itemID, userID).Return(editedAt, nil).Times(1),
 					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
 						func(ctx context.Context, fn func(context.Context) error) error {
@@ -2712,6 +3960,71 @@ func TestItemUsecase_UpdateReviewDates(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "正常系_適応型パターン",
+			input: UpdateBackReviewDateInput{
+				ReviewDateID:             reviewDateID,
+				UserID:                   userID,
+				CategoryID:               &categoryID,
+				BoxID:                    &boxID,
+				ItemID:                   itemID,
+				StepNumber:               1,
+				InitialScheduledDate:     initialScheduledDate,
+				RequestScheduledDate:     requestScheduledDate,
+				IsMarkOverdueAsCompleted: false,
+				Today:                    today,
+				LearnedDate:              learnedDate,
+				PatternID:                patternID,
+			},
+			setupMock: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return([]*PatternDomain.PatternStep{testAdaptivePatternStep}, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newAdaptivePattern(patternID, userID), nil).Times(1),
+					mockItemRepo.EXPECT().GetReviewDatesByItemID(ctx, itemID, userID).Return([]*ItemDomain.Reviewdate{testAdaptiveReviewdate}, nil).Times(1),
+					mockItemRepo.EXPECT().FindSM2StateByItemID(ctx, itemID, userID).Return(nil, nil).Times(1),
+					mockItemRepo.EXPECT().GetExcludedWeekdaysByUserID(ctx, userID).Return([]int{}, nil).Times(1),
+					mockItemRepo.EXPECT().GetBlockedDatesByUserID(ctx, userID).Return([]time.Time{}, nil).Times(1),
+					mockScheduler.EXPECT().WithExcludedWeekdays(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().WithBlockedDates(gomock.Any()).Return(mockScheduler).Times(1),
+					mockScheduler.EXPECT().NextAdaptiveReviewdate(gomock.Any(), testAdaptiveState, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)).Return(testNextAdaptiveReviewdate, nil).Times(1),
+					mockItemRepo.EXPECT().GetEditedAtByItemID(ctx, itemID, userID).Return(editedAt, nil).Times(1),
+					mockTransactionManager.EXPECT().RunInTransaction(ctx, gomock.Any()).DoAndReturn(
+						func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						},
+					).Times(1),
+					mockItemRepo.EXPECT().UpdateReviewDatesBack(ctx, gomock.Any(), userID).Return(nil).Times(1),
+					mockItemRepo.EXPECT().CreateReviewdates(ctx, []*ItemDomain.Reviewdate{testNextAdaptiveReviewdate}).Return(int64(1), nil).Times(1),
+					mockItemRepo.EXPECT().UpsertSM2State(ctx, testAdaptiveState).Return(nil).Times(1),
+					mockItemRepo.EXPECT().GetReviewDatesByItemID(ctx, itemID, userID).Return([]*ItemDomain.Reviewdate{testAdaptiveReviewdate, testNextAdaptiveReviewdate}, nil).Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系_分・時間単位のステップの復習日は変更できない",
+			input: UpdateBackReviewDateInput{
+				ReviewDateID:             reviewDateID,
+				UserID:                   userID,
+				CategoryID:               &categoryID,
+				BoxID:                    &boxID,
+				ItemID:                   itemID,
+				StepNumber:               1,
+				InitialScheduledDate:     learnedDate,
+				RequestScheduledDate:     requestScheduledDate,
+				IsMarkOverdueAsCompleted: false,
+				Today:                    today,
+				LearnedDate:              learnedDate,
+				PatternID:                patternID,
+			},
+			setupMock: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockPatternRepo.EXPECT().GetAllPatternStepsByPatternID(ctx, patternID, userID).Return(testIntradayPatternSteps, nil).Times(1),
+					mockPatternRepo.EXPECT().FindPatternByPatternID(ctx, patternID, userID).Return(newFixedPattern(patternID, userID), nil).Times(1),
+				)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -3326,6 +4639,157 @@ func TestItemUsecase_CountUnclassifiedItemsByUserID(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_GetReviewForecast(t *testing.T) {
+	// テストデータの準備
+	userID := uuid.NewString()
+	from := "2024-01-10"
+	parsedFrom := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	parsedTo := time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)
+	categoryID := uuid.NewString()
+	boxID := uuid.NewString()
+
+	boxCounts := []*ItemDomain.ForecastCountGroupedByBox{
+		{
+			ScheduledDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			CategoryID:    categoryID,
+			BoxID:         boxID,
+			Count:         3,
+		},
+		{
+			ScheduledDate: time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC),
+			CategoryID:    categoryID,
+			BoxID:         boxID,
+			Count:         2,
+		},
+	}
+	unclassifiedCounts := []*ItemDomain.UnclassifiedForecastCountGroupedByCategory{
+		{
+			ScheduledDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			CategoryID:    &categoryID,
+			Count:         1,
+		},
+		{
+			ScheduledDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			CategoryID:    nil,
+			Count:         4,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		userID         string
+		from           string
+		days           int
+		mockSetup      func(*CategoryDomain.MockICategoryRepository, *BoxDomain.MockIBoxRepository, *ItemDomain.MockIItemRepository, *PatternDomain.MockIPatternRepository, *transaction.MockITransactionManager, *ItemDomain.MockIScheduler)
+		wantTo         string
+		wantTotals     []int
+		wantUnclassify int
+		wantErr        bool
+		wantErrIs      error
+	}{
+		{
+			name:   "正常系",
+			userID: userID,
+			from:   from,
+			days:   7,
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+				gomock.InOrder(
+					mockItemRepo.EXPECT().CountForecastDatesGroupedByBoxByUserID(gomock.Any(), userID, parsedFrom, parsedTo).Return(boxCounts, nil).Times(1),
+					mockItemRepo.EXPECT().CountForecastDatesUnclassifiedGroupedByCategoryByUserID(gomock.Any(), userID, parsedFrom, parsedTo).Return(unclassifiedCounts, nil).Times(1),
+				)
+			},
+			wantTo:         "2024-01-16",
+			wantTotals:     []int{8, 0, 2, 0, 0, 0, 0},
+			wantUnclassify: 4,
+			wantErr:        false,
+		},
+		{
+			name:   "異常系: 予測日数が0日",
+			userID: userID,
+			from:   from,
+			days:   0,
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+			},
+			wantErr:   true,
+			wantErrIs: ItemDomain.ErrInvalidForecastDays,
+		},
+		{
+			name:   "異常系: 予測日数が上限を超える",
+			userID: userID,
+			from:   from,
+			days:   367,
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+			},
+			wantErr:   true,
+			wantErrIs: ItemDomain.ErrInvalidForecastDays,
+		},
+		{
+			name:   "異常系: 開始日の形式が不正",
+			userID: userID,
+			from:   "2024/01/10",
+			days:   7,
+			mockSetup: func(mockCategoryRepo *CategoryDomain.MockICategoryRepository, mockBoxRepo *BoxDomain.MockIBoxRepository, mockItemRepo *ItemDomain.MockIItemRepository, mockPatternRepo *PatternDomain.MockIPatternRepository, mockTransactionManager *transaction.MockITransactionManager, mockScheduler *ItemDomain.MockIScheduler) {
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCategoryRepo := CategoryDomain.NewMockICategoryRepository(ctrl)
+			mockBoxRepo := BoxDomain.NewMockIBoxRepository(ctrl)
+			mockItemRepo := ItemDomain.NewMockIItemRepository(ctrl)
+			mockPatternRepo := PatternDomain.NewMockIPatternRepository(ctrl)
+			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
+			mockScheduler := ItemDomain.NewMockIScheduler(ctrl)
+
+			usecase := NewItemUsecase(
+				mockCategoryRepo,
+				mockBoxRepo,
+				mockItemRepo,
+				mockPatternRepo,
+				mockTransactionManager,
+				mockScheduler,
+			)
+
+			tc.mockSetup(mockCategoryRepo, mockBoxRepo, mockItemRepo, mockPatternRepo, mockTransactionManager, mockScheduler)
+			got, err := usecase.GetReviewForecast(context.Background(), tc.userID, tc.from, tc.days)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetReviewForecast() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if tc.wantErr {
+				if tc.wantErrIs != nil && err != tc.wantErrIs {
+					t.Errorf("GetReviewForecast() error = %v, want %v", err, tc.wantErrIs)
+				}
+				return
+			}
+			if got.From != tc.from || got.To != tc.wantTo {
+				t.Errorf("GetReviewForecast() range = %v..%v, want %v..%v", got.From, got.To, tc.from, tc.wantTo)
+			}
+			if len(got.Days) != len(tc.wantTotals) {
+				t.Fatalf("GetReviewForecast() got len = %v, want %v", len(got.Days), len(tc.wantTotals))
+			}
+			for i, day := range got.Days {
+				if day.TotalCount != tc.wantTotals[i] {
+					t.Errorf("GetReviewForecast() day %s total = %v, want %v", day.Date, day.TotalCount, tc.wantTotals[i])
+				}
+			}
+			if got.Days[0].UnclassifiedCount != tc.wantUnclassify {
+				t.Errorf("GetReviewForecast() unclassified count = %v, want %v", got.Days[0].UnclassifiedCount, tc.wantUnclassify)
+			}
+			if len(got.Days[0].ByBox) != 1 || len(got.Days[0].UnclassifiedByCategory) != 1 {
+				t.Errorf("GetReviewForecast() first day breakdown = %+v", got.Days[0])
+			}
+		})
+	}
+}
+
 func TestItemUsecase_CountDailyDatesGroupedByBoxByUserID(t *testing.T) {
 	// テストデータの準備
 	userID := uuid.NewString()
@@ -3641,6 +5105,112 @@ func TestItemUsecase_GetAllDailyReviewDates(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_GetDueNowReviewDates(t *testing.T) {
+	userID := uuid.NewString()
+	categoryID := uuid.NewString()
+	boxID := uuid.NewString()
+	itemID := uuid.NewString()
+	reviewDateID := uuid.NewString()
+	scheduledAt := time.Date(2024, 1, 10, 9, 40, 0, 0, time.UTC)
+
+	testDueNowReviewDates := []*ItemDomain.DueNowReviewDate{
+		{
+			ReviewdateID:  reviewDateID,
+			CategoryID:    &categoryID,
+			BoxID:         &boxID,
+			StepNumber:    1,
+			ScheduledDate: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			ScheduledAt:   scheduledAt,
+			ItemID:        itemID,
+			Name:          "英単語",
+			Detail:        "詳細",
+			LearnedDate:   time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		userID    string
+		mockSetup func(*ItemDomain.MockIItemRepository)
+		want      []*DueNowReviewDateOutput
+		wantErr   bool
+	}{
+		{
+			name:   "正常系",
+			userID: userID,
+			mockSetup: func(mockItemRepo *ItemDomain.MockIItemRepository) {
+				mockItemRepo.EXPECT().
+					GetDueNowReviewDates(gomock.Any(), userID, gomock.Any()).
+					Return(testDueNowReviewDates, nil).
+					Times(1)
+			},
+			want: []*DueNowReviewDateOutput{
+				{
+					ReviewDateID:  reviewDateID,
+					CategoryID:    &categoryID,
+					BoxID:         &boxID,
+					StepNumber:    1,
+					ScheduledDate: "2024-01-10",
+					ScheduledAt:   scheduledAt,
+					ItemID:        itemID,
+					ItemName:      "英単語",
+					Detail:        "詳細",
+					LearnedDate:   "2024-01-10",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:   "正常系_復習日時を過ぎた復習日がない",
+			userID: userID,
+			mockSetup: func(mockItemRepo *ItemDomain.MockIItemRepository) {
+				mockItemRepo.EXPECT().
+					GetDueNowReviewDates(gomock.Any(), userID, gomock.Any()).
+					Return([]*ItemDomain.DueNowReviewDate{}, nil).
+					Times(1)
+			},
+			want:    []*DueNowReviewDateOutput{},
+			wantErr: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCategoryRepo := CategoryDomain.NewMockICategoryRepository(ctrl)
+			mockBoxRepo := BoxDomain.NewMockIBoxRepository(ctrl)
+			mockItemRepo := ItemDomain.NewMockIItemRepository(ctrl)
+			mockPatternRepo := PatternDomain.NewMockIPatternRepository(ctrl)
+			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
+			mockScheduler := ItemDomain.NewMockIScheduler(ctrl)
+
+			usecase := NewItemUsecase(
+				mockCategoryRepo,
+				mockBoxRepo,
+				mockItemRepo,
+				mockPatternRepo,
+				mockTransactionManager,
+				mockScheduler,
+			)
+
+			tc.mockSetup(mockItemRepo)
+
+			got, err := usecase.GetDueNowReviewDates(context.Background(), tc.userID)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetDueNowReviewDates() error = %v, wantErr %v", err, tc.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetDueNowReviewDates() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestItemUsecase_GetFinishedItemsByBoxID(t *testing.T) {
 	// テストデータの準備
 	userID := uuid.NewString()
@@ -3895,3 +5465,69 @@ func TestItemUsecase_GetUnclassfiedFinishedItemsByUserID(t *testing.T) {
 		})
 	}
 }
+
+// 固定ステップ方式のパターン（スケジューラの取得で使う）
+func newFixedPattern(patternID string, userID string) *PatternDomain.Pattern {
+	p, _ := PatternDomain.ReconstructPattern(
+		patternID,
+		userID,
+		"Test Pattern",
+		PatternDomain.TargetWeightNormal,
+		PatternDomain.SchedulingAlgorithmFixed,
+		false,
+		[]int{},
+		0,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+	return p
+}
+
+func newLoadBalancedPattern(patternID string, userID string) *PatternDomain.Pattern {
+	p, _ := PatternDomain.ReconstructPattern(
+		patternID,
+		userID,
+		"Test Pattern",
+		PatternDomain.TargetWeightNormal,
+		PatternDomain.SchedulingAlgorithmFixed,
+		true,
+		[]int{},
+		0,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+	return p
+}
+
+func newAdaptivePattern(patternID string, userID string) *PatternDomain.Pattern {
+	p, _ := PatternDomain.ReconstructPattern(
+		patternID,
+		userID,
+		"Test Pattern",
+		PatternDomain.TargetWeightNormal,
+		PatternDomain.SchedulingAlgorithmSM2Adaptive,
+		false,
+		[]int{},
+		0,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+	return p
+}
+
+// 最後のステップの後、maintenanceIntervalDays日ごとに復習日を生成し続けるパターン
+func newMaintenancePattern(patternID string, userID string, maintenanceIntervalDays int) *PatternDomain.Pattern {
+	p, _ := PatternDomain.ReconstructPattern(
+		patternID,
+		userID,
+		"Test Pattern",
+		PatternDomain.TargetWeightNormal,
+		PatternDomain.SchedulingAlgorithmFixed,
+		false,
+		[]int{},
+		maintenanceIntervalDays,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+	return p
+}
@@ -0,0 +1,11 @@
+package lock
+
+import (
+	"context"
+)
+
+type ILocker interface {
+	// nameのロックを取得できた場合だけfnを実行し、終了後にロックを解放する
+	// 他のプロセスがロックを保持していて取得できなかった場合は、fnを実行せずにfalseを返す
+	RunWithLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+}
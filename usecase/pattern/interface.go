@@ -7,4 +7,12 @@ type IPatternUsecase interface {
 	GetPatternsByUserID(ctx context.Context, userID string) ([]*GetPatternOutput, error)
 	UpdatePattern(ctx context.Context, pattern UpdatePatternInput) (*UpdatePatternOutput, error)
 	DeletePattern(ctx context.Context, patternID string, userID string) error
+	PreviewSchedule(ctx context.Context, in PreviewScheduleInput) (*PreviewScheduleOutput, error)
+	GetPatternVersions(ctx context.Context, patternID string, userID string) ([]*GetPatternVersionOutput, error)
+	GetPatternPresets(ctx context.Context, userID string) ([]*GetPatternPresetOutput, error)
+	CreatePatternFromPreset(ctx context.Context, userID string, presetKey string) (*CreatePatternOutput, error)
+	ExportPatterns(ctx context.Context, userID string) (*ExportPatternsOutput, error)
+	ImportPatterns(ctx context.Context, in ImportPatternsInput) (*ImportPatternsOutput, error)
+	GetPatternUsage(ctx context.Context, patternID string, userID string) (*GetPatternUsageOutput, error)
+	DeletePatternWithReassign(ctx context.Context, in DeletePatternWithReassignInput) (*DeletePatternWithReassignOutput, error)
 }
@@ -3,15 +3,25 @@ package pattern
 import "time"
 
 type CreatePatternStepInput struct {
-	StepNumber   int
+	StepNumber int
+	// IntervalUnitの単位での間隔
 	IntervalDays int
+	// minute、hour、day、week、monthのいずれか。空文字の場合は日単位
+	IntervalUnit string
 }
 
 type CreatePatternInput struct {
 	UserID       string
 	Name         string
 	TargetWeight string
-	Steps        []CreatePatternStepInput
+	// 空文字の場合は固定ステップ方式
+	SchedulingAlgorithm string
+	IsLoadBalanced      bool
+	// 0が日曜日、6が土曜日。nilの場合は除外しない
+	ExcludedWeekdays []int
+	// 0の場合は最後のステップの完了で復習物を完了にする
+	MaintenanceIntervalDays int
+	Steps                   []CreatePatternStepInput
 }
 
 type CreatePatternStepOutput struct {
@@ -20,16 +30,21 @@ type CreatePatternStepOutput struct {
 	PatternID     string
 	StepNumber    int
 	IntervalDays  int
+	IntervalUnit  string
 }
 
 type CreatePatternOutput struct {
-	ID           string
-	UserID       string
-	Name         string
-	TargetWeight string
-	RegisteredAt time.Time
-	EditedAt     time.Time
-	Steps        []CreatePatternStepOutput
+	ID                      string
+	UserID                  string
+	Name                    string
+	TargetWeight            string
+	SchedulingAlgorithm     string
+	IsLoadBalanced          bool
+	ExcludedWeekdays        []int
+	MaintenanceIntervalDays int
+	RegisteredAt            time.Time
+	EditedAt                time.Time
+	Steps                   []CreatePatternStepOutput
 }
 
 type GetPatternStepOutput struct {
@@ -37,23 +52,31 @@ type GetPatternStepOutput struct {
 	PatternID     string
 	StepNumber    int
 	IntervalDays  int
+	IntervalUnit  string
 }
 
 type GetPatternOutput struct {
-	PatternID    string
-	UserID       string
-	Name         string
-	TargetWeight string
-	RegisteredAt time.Time
-	EditedAt     time.Time
-	Steps        []GetPatternStepOutput
+	PatternID               string
+	UserID                  string
+	Name                    string
+	TargetWeight            string
+	SchedulingAlgorithm     string
+	IsLoadBalanced          bool
+	ExcludedWeekdays        []int
+	MaintenanceIntervalDays int
+	RegisteredAt            time.Time
+	EditedAt                time.Time
+	Steps                   []GetPatternStepOutput
 }
 
 type UpdatePatternStepInput struct {
-	StepID       string
-	PatternID    string
-	StepNumber   int
+	StepID     string
+	PatternID  string
+	StepNumber int
+	// IntervalUnitの単位での間隔
 	IntervalDays int
+	// minute、hour、day、week、monthのいずれか。空文字の場合は日単位
+	IntervalUnit string
 }
 
 type UpdatePatternInput struct {
@@ -61,7 +84,20 @@ type UpdatePatternInput struct {
 	UserID       string
 	Name         string
 	TargetWeight string
-	Steps        []UpdatePatternStepInput
+	// 空文字の場合は変更しない
+	SchedulingAlgorithm string
+	// nilの場合は変更しない
+	IsLoadBalanced *bool
+	// nilの場合は変更しない。空のスライスの場合は除外しない
+	ExcludedWeekdays []int
+	// nilの場合は変更しない。0の場合は繰り返さない
+	MaintenanceIntervalDays *int
+	Steps                   []UpdatePatternStepInput
+	// 復習物に紐づくパターンのステップ（またはスケジューリング方式）を変更する場合の既存の復習物の扱い。keepかreschedule
+	// 空文字の場合、復習物に紐づくパターンのステップは変更できない
+	StepChangeMode string
+	// rescheduleの場合に、組み直した復習日が今日より前にならないようにするための今日の日付
+	Today string
 }
 
 type UpdatePatternStepOutput struct {
@@ -70,14 +106,177 @@ type UpdatePatternStepOutput struct {
 	PatternID     string
 	StepNumber    int
 	IntervalDays  int
+	IntervalUnit  string
 }
 
 type UpdatePatternOutput struct {
-	PatternID    string
-	UserID       string
-	Name         string
-	TargetWeight string
-	RegisteredAt time.Time
-	EditedAt     time.Time
-	Steps        []UpdatePatternStepOutput
+	PatternID               string
+	UserID                  string
+	Name                    string
+	TargetWeight            string
+	SchedulingAlgorithm     string
+	IsLoadBalanced          bool
+	ExcludedWeekdays        []int
+	MaintenanceIntervalDays int
+	RegisteredAt            time.Time
+	EditedAt                time.Time
+	Steps                   []UpdatePatternStepOutput
+	// rescheduleで復習日を組み直した復習物の数と、組み直して生成した復習日の数
+	UpdatedItemCount       int
+	UpdatedReviewDateCount int
+}
+
+// PatternIDがnilの場合は、SchedulingAlgorithmとStepsで指定された未保存のパターンでプレビューする
+type PreviewScheduleInput struct {
+	PatternID *string
+	UserID    string
+	// 空文字の場合は固定ステップ方式
+	SchedulingAlgorithm string
+	// 未保存のパターンで除外する曜日。ユーザー設定の除外する曜日と合わせて適用する
+	ExcludedWeekdays []int
+	// 未保存のパターンの繰り返しの間隔日数
+	MaintenanceIntervalDays  int
+	Steps                    []CreatePatternStepInput
+	LearnedDate              string
+	Today                    string
+	IsMarkOverdueAsCompleted bool
+}
+
+type PreviewReviewDateOutput struct {
+	StepNumber    int
+	ScheduledDate string
+	IsCompleted   bool
+	// 分・時間単位のステップの復習日時。日単位以上のステップではnil
+	ScheduledAt *time.Time
+}
+
+// IsFinishedは、同じ条件で復習物を作成した場合に作成時点で完了扱いになるかどうか
+type PreviewScheduleOutput struct {
+	SchedulingAlgorithm string
+	IsFinished          bool
+	ReviewDates         []PreviewReviewDateOutput
+}
+
+type GetPatternVersionStepOutput struct {
+	StepNumber   int
+	IntervalDays int
+	IntervalUnit string
+}
+
+// IsCurrentは、パターンの現在のステップの版（新しく作成する復習物に割り当てる版）かどうか
+type GetPatternVersionOutput struct {
+	PatternVersionID    string
+	PatternID           string
+	VersionNumber       int
+	SchedulingAlgorithm string
+	IsCurrent           bool
+	CreatedAt           time.Time
+	Steps               []GetPatternVersionStepOutput
+}
+
+type GetPatternPresetStepOutput struct {
+	StepNumber   int
+	IntervalDays int
+	IntervalUnit string
+}
+
+// Nameはユーザーの言語の名前
+type GetPatternPresetOutput struct {
+	Key                 string
+	Name                string
+	TargetWeight        string
+	SchedulingAlgorithm string
+	Steps               []GetPatternPresetStepOutput
+}
+
+// パターンのエクスポート形式のバージョン。形式を変える場合は上げて、インポート時に古い形式を読み替える
+const PatternExportFormatVersion = 1
+
+// エクスポートとインポートで同じ形式を使う。IDや日時はアカウント毎に異なるので含めない
+type PatternExportStep struct {
+	StepNumber   int
+	IntervalDays int
+	IntervalUnit string
+}
+
+type PatternExport struct {
+	Name                    string
+	TargetWeight            string
+	SchedulingAlgorithm     string
+	IsLoadBalanced          bool
+	ExcludedWeekdays        []int
+	MaintenanceIntervalDays int
+	Steps                   []PatternExportStep
+}
+
+type ExportPatternsOutput struct {
+	FormatVersion int
+	Patterns      []PatternExport
+}
+
+type ImportPatternsInput struct {
+	UserID        string
+	FormatVersion int
+	Patterns      []PatternExport
+	// trueの場合、既存のパターン（または先にインポートしたパターン）と同じ名前のパターンは作成しない
+	SkipDuplicates bool
+}
+
+const (
+	ImportPatternStatusCreated string = "created"
+	ImportPatternStatusSkipped string = "skipped"
+	ImportPatternStatusFailed  string = "failed"
+)
+
+// Indexはインポートしたパターンの配列の添字。PatternIDは作成した場合のみ、Errorは失敗した場合のみ設定する
+type ImportPatternResultOutput struct {
+	Index     int
+	Name      string
+	Status    string
+	PatternID string
+	Error     string
+}
+
+type ImportPatternsOutput struct {
+	CreatedCount int
+	SkippedCount int
+	FailedCount  int
+	Results      []ImportPatternResultOutput
+}
+
+type PatternUsageBoxOutput struct {
+	BoxID      string
+	CategoryID string
+	Name       string
+}
+
+// CategoryIDとBoxIDは未分類の復習物の場合はnil
+type PatternUsageItemOutput struct {
+	ItemID     string
+	CategoryID *string
+	BoxID      *string
+	Name       string
+	IsFinished bool
+}
+
+type GetPatternUsageOutput struct {
+	Boxes []PatternUsageBoxOutput
+	Items []PatternUsageItemOutput
+}
+
+type DeletePatternWithReassignInput struct {
+	PatternID string
+	UserID    string
+	// 付け替え先のパターンID
+	ReassignTo string
+	// 未完了の復習日を組み直す基準日（YYYY-MM-DD）
+	Today string
+}
+
+// ReassignedItemCountは完了済みを含めて付け替えた復習物の数、RescheduledItemCountはそのうち復習日を組み直した未完了の復習物の数
+type DeletePatternWithReassignOutput struct {
+	ReassignedBoxCount         int64
+	ReassignedItemCount        int64
+	RescheduledItemCount       int
+	RescheduledReviewDateCount int
 }
@@ -2,11 +2,14 @@ package pattern
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	boxDomain "github.com/minminseo/recall-setter/domain/box"
 	itemDomain "github.com/minminseo/recall-setter/domain/item"
 	patternDomain "github.com/minminseo/recall-setter/domain/pattern"
+	userDomain "github.com/minminseo/recall-setter/domain/user"
 	"github.com/minminseo/recall-setter/usecase/transaction"
 )
 
@@ -15,17 +18,29 @@ type patternUsecase struct {
 	itemRepo    itemDomain.IItemRepository
 	// ここでtransactionManagerを使うのは、patternとpatternStepを同一トランザクションで永続化するため。
 	transactionManeger transaction.ITransactionManager
+	// 復習スケジュールのプレビューで、復習物作成時と同じ算出ロジックを使うため。
+	scheduler itemDomain.IScheduler
+	// プリセットの名前をユーザーの言語で返すため。
+	userRepo userDomain.UserRepository
+	// パターンの使用箇所の一覧と、パターン削除時にボックスを別のパターンに付け替えるため。
+	boxRepo boxDomain.IBoxRepository
 }
 
 func NewPatternUsecase(
 	patternRepo patternDomain.IPatternRepository,
 	itemRepo itemDomain.IItemRepository,
 	transactionManeger transaction.ITransactionManager,
+	scheduler itemDomain.IScheduler,
+	userRepo userDomain.UserRepository,
+	boxRepo boxDomain.IBoxRepository,
 ) IPatternUsecase {
 	return &patternUsecase{
 		patternRepo:        patternRepo,
 		itemRepo:           itemRepo,
 		transactionManeger: transactionManeger,
+		scheduler:          scheduler,
+		userRepo:           userRepo,
+		boxRepo:            boxRepo,
 	}
 }
 
@@ -35,11 +50,25 @@ func (pu *patternUsecase) CreatePattern(ctx context.Context, in CreatePatternInp
 	registeredAt := time.Now().UTC()
 	editedAt := registeredAt
 
+	schedulingAlgorithm := in.SchedulingAlgorithm
+	if schedulingAlgorithm == "" {
+		schedulingAlgorithm = patternDomain.SchedulingAlgorithmFixed
+	}
+
+	excludedWeekdays := in.ExcludedWeekdays
+	if excludedWeekdays == nil {
+		excludedWeekdays = []int{}
+	}
+
 	newPattern, err := patternDomain.NewPattern(
 		patternID,
 		in.UserID,
 		in.Name,
 		in.TargetWeight,
+		schedulingAlgorithm,
+		in.IsLoadBalanced,
+		excludedWeekdays,
+		in.MaintenanceIntervalDays,
 		registeredAt,
 		editedAt,
 	)
@@ -56,6 +85,7 @@ func (pu *patternUsecase) CreatePattern(ctx context.Context, in CreatePatternInp
 			patternID,
 			s.StepNumber,
 			s.IntervalDays,
+			intervalUnitOrDefault(s.IntervalUnit),
 		)
 		if err != nil {
 			return nil, err
@@ -67,6 +97,23 @@ func (pu *patternUsecase) CreatePattern(ctx context.Context, in CreatePatternInp
 	if err != nil {
 		return nil, err
 	}
+	err = patternDomain.ValidateStepsForSchedulingAlgorithm(schedulingAlgorithm, newSteps)
+	if err != nil {
+		return nil, err
+	}
+
+	firstVersion, err := patternDomain.NewPatternVersion(
+		uuid.NewString(),
+		in.UserID,
+		patternID,
+		1,
+		schedulingAlgorithm,
+		newSteps,
+		registeredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
 
 	// patternとstepは別テーブルなので同一トランザクションで永続化
 	err = pu.transactionManeger.RunInTransaction(ctx, func(ctx context.Context) error {
@@ -81,6 +128,12 @@ func (pu *patternUsecase) CreatePattern(ctx context.Context, in CreatePatternInp
 		if err != nil {
 			return err
 		}
+
+		// 作成時のステップを版1として残す
+		err = pu.patternRepo.CreatePatternVersion(ctx, firstVersion)
+		if err != nil {
+			return err
+		}
 		return nil
 	})
 	if err != nil {
@@ -88,12 +141,16 @@ func (pu *patternUsecase) CreatePattern(ctx context.Context, in CreatePatternInp
 	}
 
 	out := &CreatePatternOutput{
-		ID:           newPattern.PatternID(),
-		UserID:       newPattern.UserID(),
-		Name:         newPattern.Name(),
-		TargetWeight: newPattern.TargetWeight(),
-		RegisteredAt: newPattern.RegisteredAt(),
-		EditedAt:     newPattern.EditedAt(),
+		ID:                      newPattern.PatternID(),
+		UserID:                  newPattern.UserID(),
+		Name:                    newPattern.Name(),
+		TargetWeight:            newPattern.TargetWeight(),
+		SchedulingAlgorithm:     newPattern.SchedulingAlgorithm(),
+		IsLoadBalanced:          newPattern.IsLoadBalanced(),
+		ExcludedWeekdays:        newPattern.ExcludedWeekdays(),
+		MaintenanceIntervalDays: newPattern.MaintenanceIntervalDays(),
+		RegisteredAt:            newPattern.RegisteredAt(),
+		EditedAt:                newPattern.EditedAt(),
 	}
 	out.Steps = make([]CreatePatternStepOutput, len(newSteps))
 	for i, ps := range newSteps {
@@ -103,6 +160,7 @@ func (pu *patternUsecase) CreatePattern(ctx context.Context, in CreatePatternInp
 			PatternID:     ps.PatternID(),
 			StepNumber:    ps.StepNumber(),
 			IntervalDays:  ps.IntervalDays(),
+			IntervalUnit:  ps.IntervalUnit(),
 		}
 	}
 	return out, nil
@@ -127,6 +185,7 @@ func (pu *patternUsecase) GetPatternsByUserID(ctx context.Context, userID string
 			PatternID:     domainStep.PatternID(),
 			StepNumber:    domainStep.StepNumber(),
 			IntervalDays:  domainStep.IntervalDays(),
+			IntervalUnit:  domainStep.IntervalUnit(),
 		}
 		stepsByPattern[domainStep.PatternID()] = append(stepsByPattern[domainStep.PatternID()], stepOutput)
 	}
@@ -136,13 +195,17 @@ func (pu *patternUsecase) GetPatternsByUserID(ctx context.Context, userID string
 	result = make([]*GetPatternOutput, 0, len(allPatterns))
 	for _, domainPattern := range allPatterns {
 		patternOutput := &GetPatternOutput{
-			PatternID:    domainPattern.PatternID(),
-			UserID:       domainPattern.UserID(),
-			Name:         domainPattern.Name(),
-			TargetWeight: domainPattern.TargetWeight(),
-			RegisteredAt: domainPattern.RegisteredAt(),
-			EditedAt:     domainPattern.EditedAt(),
-			Steps:        stepsByPattern[domainPattern.PatternID()],
+			PatternID:               domainPattern.PatternID(),
+			UserID:                  domainPattern.UserID(),
+			Name:                    domainPattern.Name(),
+			TargetWeight:            domainPattern.TargetWeight(),
+			SchedulingAlgorithm:     domainPattern.SchedulingAlgorithm(),
+			IsLoadBalanced:          domainPattern.IsLoadBalanced(),
+			ExcludedWeekdays:        domainPattern.ExcludedWeekdays(),
+			MaintenanceIntervalDays: domainPattern.MaintenanceIntervalDays(),
+			RegisteredAt:            domainPattern.RegisteredAt(),
+			EditedAt:                domainPattern.EditedAt(),
+			Steps:                   stepsByPattern[domainPattern.PatternID()],
 		}
 		result = append(result, patternOutput)
 	}
@@ -151,6 +214,11 @@ func (pu *patternUsecase) GetPatternsByUserID(ctx context.Context, userID string
 }
 
 func (pu *patternUsecase) UpdatePattern(ctx context.Context, input UpdatePatternInput) (*UpdatePatternOutput, error) {
+	err := patternDomain.ValidateStepChangeMode(input.StepChangeMode)
+	if err != nil {
+		return nil, err
+	}
+
 	targetPattern, err := pu.patternRepo.FindPatternByPatternID(ctx, input.PatternID, input.UserID)
 	if err != nil {
 		return nil, err
@@ -160,16 +228,41 @@ func (pu *patternUsecase) UpdatePattern(ctx context.Context, input UpdatePattern
 		return nil, err
 	}
 
+	schedulingAlgorithm := input.SchedulingAlgorithm
+	if schedulingAlgorithm == "" {
+		schedulingAlgorithm = targetPattern.SchedulingAlgorithm()
+	}
+
+	isLoadBalanced := targetPattern.IsLoadBalanced()
+	if input.IsLoadBalanced != nil {
+		isLoadBalanced = *input.IsLoadBalanced
+	}
+
+	excludedWeekdays := targetPattern.ExcludedWeekdays()
+	if input.ExcludedWeekdays != nil {
+		excludedWeekdays = input.ExcludedWeekdays
+	}
+
+	maintenanceIntervalDays := targetPattern.MaintenanceIntervalDays()
+	if input.MaintenanceIntervalDays != nil {
+		maintenanceIntervalDays = *input.MaintenanceIntervalDays
+	}
+
 	// 変更部分の判定
 	// pattern
-	isPatternChanged := targetPattern.Name() != input.Name || targetPattern.TargetWeight() != input.TargetWeight
+	// 負荷分散の有無と除外する曜日、繰り返しの間隔日数はこれから生成する復習日にのみ影響するため、既存の復習日の有無に関わらず変更できる
+	isAlgorithmChanged := targetPattern.SchedulingAlgorithm() != schedulingAlgorithm
+	isLoadBalancedChanged := targetPattern.IsLoadBalanced() != isLoadBalanced
+	isExcludedWeekdaysChanged := !slices.Equal(targetPattern.ExcludedWeekdays(), excludedWeekdays)
+	isMaintenanceIntervalChanged := targetPattern.MaintenanceIntervalDays() != maintenanceIntervalDays
+	isPatternChanged := targetPattern.Name() != input.Name || targetPattern.TargetWeight() != input.TargetWeight || isAlgorithmChanged || isLoadBalancedChanged || isExcludedWeekdaysChanged || isMaintenanceIntervalChanged
 
 	// steps
+	// ステップ数が異なる場合は、要素毎の比較はしない（ステップ数を減らす場合にinput.Stepsの範囲外を参照するため）
 	isStepsChanged := len(targetPatternSteps) != len(input.Steps)
-	for i := range targetPatternSteps {
-		if targetPatternSteps[i].IntervalDays() != input.Steps[i].IntervalDays {
+	for i := 0; !isStepsChanged && i < len(targetPatternSteps); i++ {
+		if targetPatternSteps[i].IntervalDays() != input.Steps[i].IntervalDays || targetPatternSteps[i].IntervalUnit() != intervalUnitOrDefault(input.Steps[i].IntervalUnit) {
 			isStepsChanged = true
-			break
 		}
 	}
 
@@ -177,20 +270,27 @@ func (pu *patternUsecase) UpdatePattern(ctx context.Context, input UpdatePattern
 		return nil, patternDomain.ErrNoDiff
 	}
 
-	if isStepsChanged {
+	// スケジューリング方式の変更も既存の復習日に影響するため、ステップの変更と同様に扱う
+	// 既存の復習物は記録している版のステップを使い続けるため、扱いの指定がない場合はそのまま残す
+	isRescheduleRequired := false
+	if isStepsChanged || isAlgorithmChanged {
 		hasItemByPatternID := false
 		hasItemByPatternID, err = pu.itemRepo.IsPatternRelatedToItemByPatternID(ctx, input.PatternID, input.UserID)
 		if err != nil {
 			return nil, err
 		}
 		if hasItemByPatternID {
-			return nil, patternDomain.ErrPatternRelatedToItemUpdate
+			isRescheduleRequired = input.StepChangeMode == patternDomain.StepChangeModeReschedule
 		}
 	}
 
+	// 組み直す対象の復習日かどうかの判定には、変更前のステップ数と方式を使う（版を記録している復習物は版のステップ数と方式を使う）
+	lastStepNumberBeforeUpdate := len(targetPatternSteps)
+	wasAdaptive := targetPattern.IsAdaptive()
+
 	if isPatternChanged {
 		editedAt := time.Now().UTC()
-		err = targetPattern.UpdatePattern(input.Name, input.TargetWeight, editedAt)
+		err = targetPattern.UpdatePattern(input.Name, input.TargetWeight, schedulingAlgorithm, isLoadBalanced, excludedWeekdays, maintenanceIntervalDays, editedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -207,6 +307,7 @@ func (pu *patternUsecase) UpdatePattern(ctx context.Context, input UpdatePattern
 				input.PatternID,
 				s.StepNumber,
 				s.IntervalDays,
+				intervalUnitOrDefault(s.IntervalUnit),
 			)
 			if err != nil {
 				return nil, err
@@ -219,8 +320,67 @@ func (pu *patternUsecase) UpdatePattern(ctx context.Context, input UpdatePattern
 		}
 	}
 
+	// 方式かステップが変わる場合、変更後の組み合わせが有効か確認
+	resultSteps := targetPatternSteps
+	if isStepsChanged {
+		resultSteps = newSteps
+	}
+	if isStepsChanged || isAlgorithmChanged {
+		err = patternDomain.ValidateStepsForSchedulingAlgorithm(schedulingAlgorithm, resultSteps)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var parsedToday time.Time
+	if isRescheduleRequired {
+		// 適応型の方式は復習日を完了時に1件ずつ生成するため、事前に組み直せない
+		if schedulingAlgorithm == patternDomain.SchedulingAlgorithmSM2Adaptive {
+			return nil, patternDomain.ErrAdaptivePatternReschedule
+		}
+		parsedToday, err = time.Parse("2006-01-02", input.Today)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 変更後のステップと方式で新しい版を作る。変更前の版は、その版で復習日を算出した復習物のために残す
+	var newVersion *patternDomain.PatternVersion
+	if isStepsChanged || isAlgorithmChanged {
+		latestVersion, err := pu.patternRepo.FindLatestPatternVersionByPatternID(ctx, input.PatternID, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		newVersion, err = patternDomain.NewPatternVersion(
+			uuid.NewString(),
+			input.UserID,
+			input.PatternID,
+			latestVersion.VersionNumber()+1,
+			schedulingAlgorithm,
+			resultSteps,
+			time.Now().UTC(),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// patternとstepは別テーブルなので同一トランザクションで永続化
+	var rescheduledItems []*rescheduledItem
 	err = pu.transactionManeger.RunInTransaction(ctx, func(ctx context.Context) error {
+		// 既存の復習物の未完了の復習日を、変更後のステップで組み直す
+		// 組み直す間に復習日の完了や編集で組み直す前の状態が変わらないように、未完了の復習物とその復習日をロックしてから読み込む
+		if isRescheduleRequired {
+			err = pu.itemRepo.LockUnFinishedItemsByPatternID(ctx, input.PatternID, input.UserID)
+			if err != nil {
+				return err
+			}
+			rescheduledItems, err = pu.rescheduleItems(ctx, targetPattern, schedulingAlgorithm, excludedWeekdays, maintenanceIntervalDays, resultSteps, lastStepNumberBeforeUpdate, wasAdaptive, parsedToday)
+			if err != nil {
+				return err
+			}
+		}
+
 		// パターンに変更がある場合、パターンを更新
 		if isPatternChanged {
 			err = pu.patternRepo.UpdatePattern(ctx, targetPattern)
@@ -241,6 +401,38 @@ func (pu *patternUsecase) UpdatePattern(ctx context.Context, input UpdatePattern
 				return err
 			}
 		}
+
+		if newVersion != nil {
+			err = pu.patternRepo.CreatePatternVersion(ctx, newVersion)
+			if err != nil {
+				return err
+			}
+		}
+
+		// 組み直す復習物は、完了したステップより後の未完了の復習日を削除→組み直した復習日を一括挿入し、新しい版に移す
+		var newReviewdates []*itemDomain.Reviewdate
+		for _, ri := range rescheduledItems {
+			err = pu.itemRepo.DeleteInCompletedReviewDatesAfterStep(ctx, ri.itemID, input.UserID, ri.lastCompletedStepNumber)
+			if err != nil {
+				return err
+			}
+			err = pu.itemRepo.UpdateItemPatternVersionID(ctx, ri.itemID, input.UserID, newVersion.PatternVersionID())
+			if err != nil {
+				return err
+			}
+			if ri.isFinished {
+				err = pu.itemRepo.UpdateItemAsFinished(ctx, ri.itemID, input.UserID, time.Now().UTC())
+				if err != nil {
+					return err
+				}
+			}
+			newReviewdates = append(newReviewdates, ri.reviewdates...)
+		}
+		if len(newReviewdates) > 0 {
+			if _, err := pu.itemRepo.CreateReviewdates(ctx, newReviewdates); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -248,12 +440,20 @@ func (pu *patternUsecase) UpdatePattern(ctx context.Context, input UpdatePattern
 	}
 
 	resPattern := &UpdatePatternOutput{
-		PatternID:    targetPattern.PatternID(),
-		UserID:       targetPattern.UserID(),
-		Name:         targetPattern.Name(),
-		TargetWeight: targetPattern.TargetWeight(),
-		RegisteredAt: targetPattern.RegisteredAt(),
-		EditedAt:     targetPattern.EditedAt(),
+		PatternID:               targetPattern.PatternID(),
+		UserID:                  targetPattern.UserID(),
+		Name:                    targetPattern.Name(),
+		TargetWeight:            targetPattern.TargetWeight(),
+		SchedulingAlgorithm:     targetPattern.SchedulingAlgorithm(),
+		IsLoadBalanced:          targetPattern.IsLoadBalanced(),
+		ExcludedWeekdays:        targetPattern.ExcludedWeekdays(),
+		MaintenanceIntervalDays: targetPattern.MaintenanceIntervalDays(),
+		RegisteredAt:            targetPattern.RegisteredAt(),
+		EditedAt:                targetPattern.EditedAt(),
+		UpdatedItemCount:        len(rescheduledItems),
+	}
+	for _, ri := range rescheduledItems {
+		resPattern.UpdatedReviewDateCount += len(ri.reviewdates)
 	}
 	resPattern.Steps = make([]UpdatePatternStepOutput, len(newSteps))
 	for i, s := range newSteps {
@@ -263,6 +463,7 @@ func (pu *patternUsecase) UpdatePattern(ctx context.Context, input UpdatePattern
 			PatternID:     s.PatternID(),
 			StepNumber:    s.StepNumber(),
 			IntervalDays:  s.IntervalDays(),
+			IntervalUnit:  s.IntervalUnit(),
 		}
 	}
 
@@ -270,9 +471,142 @@ func (pu *patternUsecase) UpdatePattern(ctx context.Context, input UpdatePattern
 	return resPattern, nil
 }
 
+// ステップ変更時に組み直す復習物
+// lastCompletedStepNumberより後の未完了の復習日を削除し、reviewdatesを新たに作成する
+type rescheduledItem struct {
+	itemID                  string
+	lastCompletedStepNumber int
+	reviewdates             []*itemDomain.Reviewdate
+	// 完了したステップが変更後の全ステップに達している（繰り返しの間隔日数もない）場合は復習物を完了にする
+	isFinished bool
+}
+
+// パターンを使う未完了の復習物毎に、完了済みの復習日は残したまま、以降の未完了の復習日を変更後のステップで組み直す（トランザクション内で、復習物をロックしてから使う）
+// 変更前（版を記録している復習物はその版）のステップの範囲に未完了の復習日がない復習物（全ステップを完了して繰り返し中のものなど）は対象外とする
+func (pu *patternUsecase) rescheduleItems(
+	ctx context.Context,
+	targetPattern *patternDomain.Pattern,
+	schedulingAlgorithm string,
+	excludedWeekdays []int,
+	maintenanceIntervalDays int,
+	resultSteps []*patternDomain.PatternStep,
+	lastStepNumberBeforeUpdate int,
+	wasAdaptive bool,
+	parsedToday time.Time,
+) ([]*rescheduledItem, error) {
+	scheduler, err := pu.schedulerWithRestrictions(ctx, schedulingAlgorithm, excludedWeekdays, targetPattern.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := pu.itemRepo.GetAllUnFinishedItemsByPatternID(ctx, targetPattern.PatternID(), targetPattern.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	// 同じ版の復習物が多いため、版は1回だけ取得する
+	versions := make(map[string]*patternDomain.PatternVersion)
+
+	result := make([]*rescheduledItem, 0, len(items))
+	for _, item := range items {
+		reviewdates, err := pu.itemRepo.GetReviewDatesByItemID(ctx, item.ItemID(), item.UserID())
+		if err != nil {
+			return nil, err
+		}
+
+		// 古い版の復習物は、パターンの変更前のステップ数と方式ではなく、記録している版のステップ数と方式で復習日を算出している
+		itemLastStepNumber := lastStepNumberBeforeUpdate
+		itemWasAdaptive := wasAdaptive
+		if item.PatternVersionID() != nil {
+			version, ok := versions[*item.PatternVersionID()]
+			if !ok {
+				version, err = pu.patternRepo.FindPatternVersionByID(ctx, *item.PatternVersionID(), item.UserID())
+				if err != nil {
+					return nil, err
+				}
+				versions[*item.PatternVersionID()] = version
+			}
+			itemLastStepNumber = len(version.Steps())
+			itemWasAdaptive = version.IsAdaptive()
+		}
+
+		lastCompleted := itemDomain.LastCompletedReviewdate(reviewdates)
+		lastCompletedStepNumber := 0
+		if lastCompleted != nil {
+			lastCompletedStepNumber = lastCompleted.StepNumber()
+		}
+
+		// 適応型の方式では、ステップ数に関わらず未完了の復習日は全てステップの復習日
+		hasPendingStep := false
+		for _, rd := range reviewdates {
+			if !rd.IsCompleted() && rd.StepNumber() > lastCompletedStepNumber && (itemWasAdaptive || rd.StepNumber() <= itemLastStepNumber) {
+				hasPendingStep = true
+				break
+			}
+		}
+		if !hasPendingStep {
+			continue
+		}
+
+		newReviewdates, err := scheduler.RescheduleForUpdatedSteps(
+			resultSteps,
+			item.UserID(),
+			item.CategoryID(),
+			item.BoxID(),
+			item.ItemID(),
+			lastCompletedStepNumber,
+			item.LearnedDate(),
+			parsedToday,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		isFinished := false
+		if len(newReviewdates) == 0 {
+			// 変更後のステップを全て完了している場合は、復習物作成時と同じく繰り返しの間隔日数があれば次の復習日を追加し、なければ完了にする
+			if maintenanceIntervalDays > 0 && lastCompleted != nil {
+				nextReviewdate, err := scheduler.NextMaintenanceReviewdate(lastCompleted, maintenanceIntervalDays, lastCompleted.ScheduledDate(), parsedToday)
+				if err != nil {
+					return nil, err
+				}
+				newReviewdates = []*itemDomain.Reviewdate{nextReviewdate}
+			} else {
+				isFinished = true
+			}
+		}
+
+		result = append(result, &rescheduledItem{
+			itemID:                  item.ItemID(),
+			lastCompletedStepNumber: lastCompletedStepNumber,
+			reviewdates:             newReviewdates,
+			isFinished:              isFinished,
+		})
+	}
+	return result, nil
+}
+
+// 復習物作成時と同じく、パターンとユーザー設定の除外する曜日、ユーザーの復習日を置かない日付を避けるスケジューラを返す
+func (pu *patternUsecase) schedulerWithRestrictions(ctx context.Context, schedulingAlgorithm string, patternExcludedWeekdays []int, userID string) (itemDomain.IScheduler, error) {
+	scheduler, err := pu.scheduler.WithAlgorithm(schedulingAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	userExcludedWeekdays, err := pu.itemRepo.GetExcludedWeekdaysByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	blockedDates, err := pu.itemRepo.GetBlockedDatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.
+		WithExcludedWeekdays(itemDomain.NewExcludedWeekdays(patternExcludedWeekdays, userExcludedWeekdays)).
+		WithBlockedDates(itemDomain.NewBlockedDates(blockedDates)), nil
+}
+
 func (pu *patternUsecase) DeletePattern(ctx context.Context, patternID, userID string) error {
-	// パターンに紐づく復習物が存在するか確認。
-	// TODO: どの復習物に紐づいているのかを返す機能もあってもいいかも
+	// パターンに紐づく復習物が存在するか確認。どの復習物に紐づいているかはGetPatternUsageで取得する
 	var isItemRelated bool
 	isItemRelated, err := pu.itemRepo.IsPatternRelatedToItemByPatternID(ctx, patternID, userID)
 	if err != nil {
@@ -288,3 +622,538 @@ func (pu *patternUsecase) DeletePattern(ctx context.Context, patternID, userID s
 	}
 	return nil
 }
+
+// パターンを使うボックスと復習物（完了済みを含む）を返す
+func (pu *patternUsecase) GetPatternUsage(ctx context.Context, patternID string, userID string) (*GetPatternUsageOutput, error) {
+	// 他のユーザーのパターンや存在しないパターンの場合はここでエラーになる
+	_, err := pu.patternRepo.FindPatternByPatternID(ctx, patternID, userID)
+	if err != nil {
+		return nil, err
+	}
+	boxes, err := pu.boxRepo.GetAllByPatternID(ctx, patternID, userID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := pu.itemRepo.GetAllItemsByPatternID(ctx, patternID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &GetPatternUsageOutput{
+		Boxes: make([]PatternUsageBoxOutput, len(boxes)),
+		Items: make([]PatternUsageItemOutput, len(items)),
+	}
+	for i, b := range boxes {
+		out.Boxes[i] = PatternUsageBoxOutput{
+			BoxID:      b.ID(),
+			CategoryID: b.CategoryID(),
+			Name:       b.Name(),
+		}
+	}
+	for i, item := range items {
+		out.Items[i] = PatternUsageItemOutput{
+			ItemID:     item.ItemID(),
+			CategoryID: item.CategoryID(),
+			BoxID:      item.BoxID(),
+			Name:       item.Name(),
+			IsFinished: item.IsFinished(),
+		}
+	}
+	return out, nil
+}
+
+// パターンを使う全てのボックスと復習物を別のパターンに付け替えてから、パターンを削除する
+// 未完了の復習物は、完了済みの復習日は残したまま以降の未完了の復習日を付け替え先のステップで組み直す
+// 付け替え・組み直し・削除は同一トランザクションで行い、組み直す復習物はトランザクション内でロックしてから読み込む
+func (pu *patternUsecase) DeletePatternWithReassign(ctx context.Context, in DeletePatternWithReassignInput) (*DeletePatternWithReassignOutput, error) {
+	if in.ReassignTo == in.PatternID {
+		return nil, patternDomain.ErrReassignToSamePattern
+	}
+	_, err := pu.patternRepo.FindPatternByPatternID(ctx, in.PatternID, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+	newPattern, err := pu.patternRepo.FindPatternByPatternID(ctx, in.ReassignTo, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &DeletePatternWithReassignOutput{}
+	editedAt := time.Now().UTC()
+	err = pu.transactionManeger.RunInTransaction(ctx, func(ctx context.Context) error {
+		// 組み直す間に復習日の完了や編集で組み直す前の状態が変わらないように、付け替える未完了の復習物とその復習日をロックしてから読み込む
+		err := pu.itemRepo.LockUnFinishedItemsByPatternID(ctx, in.PatternID, in.UserID)
+		if err != nil {
+			return err
+		}
+		items, err := pu.itemRepo.GetAllUnFinishedItemsByPatternID(ctx, in.PatternID, in.UserID)
+		if err != nil {
+			return err
+		}
+		var reassignedItems []*rescheduledItem
+		if len(items) > 0 {
+			// 適応型の方式は復習日を完了時に1件ずつ生成するため、事前に組み直せない
+			if newPattern.IsAdaptive() {
+				return patternDomain.ErrAdaptivePatternReschedule
+			}
+			parsedToday, err := time.Parse("2006-01-02", in.Today)
+			if err != nil {
+				return err
+			}
+			reassignedItems, err = pu.replanItemsForReassign(ctx, items, newPattern, parsedToday)
+			if err != nil {
+				return err
+			}
+		}
+		out.RescheduledItemCount = len(reassignedItems)
+		for _, ri := range reassignedItems {
+			out.RescheduledReviewDateCount += len(ri.reviewdates)
+		}
+
+		out.ReassignedBoxCount, err = pu.boxRepo.UpdatePatternIDByPatternID(ctx, in.PatternID, in.UserID, in.ReassignTo, editedAt)
+		if err != nil {
+			return err
+		}
+		out.ReassignedItemCount, err = pu.itemRepo.UpdateItemsPatternIDByPatternID(ctx, in.PatternID, in.UserID, in.ReassignTo)
+		if err != nil {
+			return err
+		}
+
+		// 最後に完了したステップより後の未完了の復習日を削除→組み直した復習日を一括挿入
+		var newReviewdates []*itemDomain.Reviewdate
+		for _, ri := range reassignedItems {
+			err = pu.itemRepo.DeleteInCompletedReviewDatesAfterStep(ctx, ri.itemID, in.UserID, ri.lastCompletedStepNumber)
+			if err != nil {
+				return err
+			}
+			if ri.isFinished {
+				err = pu.itemRepo.UpdateItemAsFinished(ctx, ri.itemID, in.UserID, editedAt)
+				if err != nil {
+					return err
+				}
+			}
+			newReviewdates = append(newReviewdates, ri.reviewdates...)
+		}
+		if len(newReviewdates) > 0 {
+			if _, err := pu.itemRepo.CreateReviewdates(ctx, newReviewdates); err != nil {
+				return err
+			}
+		}
+
+		return pu.patternRepo.DeletePattern(ctx, in.PatternID, in.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// 付け替える未完了の復習物毎に、完了済みの復習日は残したまま、以降の未完了の復習日を付け替え先のパターンのステップで組み直す（トランザクション内で、復習物をロックしてから使う）
+// ボックスのパターン変更時と同じく、復習物を作成した時と同じ学習日から付け替え先の各ステップの復習日を算出する
+func (pu *patternUsecase) replanItemsForReassign(ctx context.Context, items []*itemDomain.Item, newPattern *patternDomain.Pattern, parsedToday time.Time) ([]*rescheduledItem, error) {
+	newSteps, err := pu.patternRepo.GetAllPatternStepsByPatternID(ctx, newPattern.PatternID(), newPattern.UserID())
+	if err != nil {
+		return nil, err
+	}
+	scheduler, err := pu.schedulerWithRestrictions(ctx, newPattern.SchedulingAlgorithm(), newPattern.ExcludedWeekdays(), newPattern.UserID())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*rescheduledItem, len(items))
+	for i, item := range items {
+		reviewdates, err := pu.itemRepo.GetReviewDatesByItemID(ctx, item.ItemID(), item.UserID())
+		if err != nil {
+			return nil, err
+		}
+
+		lastCompleted := itemDomain.LastCompletedReviewdate(reviewdates)
+		lastCompletedStepNumber := 0
+		if lastCompleted != nil {
+			lastCompletedStepNumber = lastCompleted.StepNumber()
+		}
+
+		newReviewdates, err := scheduler.RescheduleForUpdatedSteps(
+			newSteps,
+			item.UserID(),
+			item.CategoryID(),
+			item.BoxID(),
+			item.ItemID(),
+			lastCompletedStepNumber,
+			item.LearnedDate(),
+			parsedToday,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		isFinished := false
+		if len(newReviewdates) == 0 {
+			// 付け替え先のステップを全て完了している場合は、繰り返しの間隔日数があれば次の復習日を追加し、なければ完了にする
+			if newPattern.HasMaintenanceInterval() && lastCompleted != nil {
+				nextReviewdate, err := scheduler.NextMaintenanceReviewdate(lastCompleted, newPattern.MaintenanceIntervalDays(), lastCompleted.ScheduledDate(), parsedToday)
+				if err != nil {
+					return nil, err
+				}
+				newReviewdates = []*itemDomain.Reviewdate{nextReviewdate}
+			} else {
+				isFinished = true
+			}
+		}
+
+		result[i] = &rescheduledItem{
+			itemID:                  item.ItemID(),
+			lastCompletedStepNumber: lastCompletedStepNumber,
+			reviewdates:             newReviewdates,
+			isFinished:              isFinished,
+		}
+	}
+	return result, nil
+}
+
+// 復習スケジュールのプレビュー
+// 復習物作成時と同じスケジューラで復習日を算出するだけで、永続化は行わない
+func (pu *patternUsecase) PreviewSchedule(ctx context.Context, in PreviewScheduleInput) (*PreviewScheduleOutput, error) {
+	parsedLearnedDate, err := time.Parse("2006-01-02", in.LearnedDate)
+	if err != nil {
+		return nil, err
+	}
+	parsedToday, err := time.Parse("2006-01-02", in.Today)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedulingAlgorithm string
+	var patternExcludedWeekdays []int
+	var maintenanceIntervalDays int
+	// 未保存のパターンは負荷分散なしとして扱う
+	var isLoadBalanced bool
+	var targetPatternSteps []*patternDomain.PatternStep
+	if in.PatternID != nil {
+		targetPattern, err := pu.patternRepo.FindPatternByPatternID(ctx, *in.PatternID, in.UserID)
+		if err != nil {
+			return nil, err
+		}
+		schedulingAlgorithm = targetPattern.SchedulingAlgorithm()
+		patternExcludedWeekdays = targetPattern.ExcludedWeekdays()
+		maintenanceIntervalDays = targetPattern.MaintenanceIntervalDays()
+		isLoadBalanced = targetPattern.IsLoadBalanced()
+
+		targetPatternSteps, err = pu.patternRepo.GetAllPatternStepsByPatternID(ctx, *in.PatternID, in.UserID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		schedulingAlgorithm = in.SchedulingAlgorithm
+		if schedulingAlgorithm == "" {
+			schedulingAlgorithm = patternDomain.SchedulingAlgorithmFixed
+		}
+		err = patternDomain.ValidateExcludedWeekdays(in.ExcludedWeekdays)
+		if err != nil {
+			return nil, err
+		}
+		patternExcludedWeekdays = in.ExcludedWeekdays
+		err = patternDomain.ValidateMaintenanceIntervalDays(schedulingAlgorithm, in.MaintenanceIntervalDays)
+		if err != nil {
+			return nil, err
+		}
+		maintenanceIntervalDays = in.MaintenanceIntervalDays
+
+		// 保存しないのでIDは仮のもので良い
+		targetPatternSteps = make([]*patternDomain.PatternStep, len(in.Steps))
+		for i, s := range in.Steps {
+			patternStep, err := patternDomain.NewPatternStep(
+				uuid.NewString(),
+				in.UserID,
+				uuid.NewString(),
+				s.StepNumber,
+				s.IntervalDays,
+				intervalUnitOrDefault(s.IntervalUnit),
+			)
+			if err != nil {
+				return nil, err
+			}
+			targetPatternSteps[i] = patternStep
+		}
+
+		err = patternDomain.ValidateSteps(targetPatternSteps)
+		if err != nil {
+			return nil, err
+		}
+		err = patternDomain.ValidateStepsForSchedulingAlgorithm(schedulingAlgorithm, targetPatternSteps)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scheduler, err := pu.schedulerWithRestrictions(ctx, schedulingAlgorithm, patternExcludedWeekdays, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 復習物作成時と同じく、期日を過ぎた復習日を完了扱いにするかどうかで算出方法を切り替える
+	var previewReviewdates []*itemDomain.Reviewdate
+	var isFinished bool
+	previewItemID := uuid.NewString()
+	if in.IsMarkOverdueAsCompleted {
+		previewReviewdates, isFinished, err = scheduler.FormatWithOverdueMarkedCompleted(
+			targetPatternSteps,
+			in.UserID,
+			nil,
+			nil,
+			previewItemID,
+			parsedLearnedDate,
+			parsedToday,
+		)
+		if err != nil {
+			return nil, err
+		}
+		// 繰り返しの間隔日数が設定されている場合は完了にならず、最後の復習日の次の復習日が追加される
+		if isFinished && maintenanceIntervalDays > 0 {
+			lastReviewdate := previewReviewdates[len(previewReviewdates)-1]
+			nextReviewdate, err := scheduler.NextMaintenanceReviewdate(lastReviewdate, maintenanceIntervalDays, lastReviewdate.ScheduledDate(), parsedToday)
+			if err != nil {
+				return nil, err
+			}
+			previewReviewdates = append(previewReviewdates, nextReviewdate)
+			isFinished = false
+		}
+	} else {
+		previewReviewdates, err = scheduler.FormatWithOverdueMarkedInCompleted(
+			targetPatternSteps,
+			in.UserID,
+			nil,
+			nil,
+			previewItemID,
+			parsedLearnedDate,
+			parsedToday,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 復習物作成時と同じく、日毎の復習予定数に合わせて調整する（復習予定数は読み取るだけ）
+	previewReviewdates, err = itemDomain.AdjustReviewdatesToDailyLoad(ctx, pu.itemRepo, scheduler, isLoadBalanced, previewReviewdates, in.UserID, parsedLearnedDate, parsedToday)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &PreviewScheduleOutput{
+		SchedulingAlgorithm: schedulingAlgorithm,
+		IsFinished:          isFinished,
+	}
+	out.ReviewDates = make([]PreviewReviewDateOutput, len(previewReviewdates))
+	for i, rd := range previewReviewdates {
+		out.ReviewDates[i] = PreviewReviewDateOutput{
+			StepNumber:    rd.StepNumber(),
+			ScheduledDate: rd.ScheduledDate().Format("2006-01-02"),
+			IsCompleted:   rd.IsCompleted(),
+			ScheduledAt:   rd.ScheduledAt(),
+		}
+	}
+	return out, nil
+}
+
+// パターンの版の履歴を、版番号の昇順で返す
+func (pu *patternUsecase) GetPatternVersions(ctx context.Context, patternID string, userID string) ([]*GetPatternVersionOutput, error) {
+	// 他のユーザーのパターンや存在しないパターンの場合はここでエラーになる
+	_, err := pu.patternRepo.FindPatternByPatternID(ctx, patternID, userID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := pu.patternRepo.GetPatternVersionsByPatternID(ctx, patternID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*GetPatternVersionOutput, len(versions))
+	for i, v := range versions {
+		steps := make([]GetPatternVersionStepOutput, len(v.Steps()))
+		for j, s := range v.Steps() {
+			steps[j] = GetPatternVersionStepOutput{
+				StepNumber:   s.StepNumber(),
+				IntervalDays: s.IntervalDays(),
+				IntervalUnit: s.IntervalUnit(),
+			}
+		}
+		result[i] = &GetPatternVersionOutput{
+			PatternVersionID:    v.PatternVersionID(),
+			PatternID:           v.PatternID(),
+			VersionNumber:       v.VersionNumber(),
+			SchedulingAlgorithm: v.SchedulingAlgorithm(),
+			IsCurrent:           i == len(versions)-1,
+			CreatedAt:           v.CreatedAt(),
+			Steps:               steps,
+		}
+	}
+	return result, nil
+}
+
+// 組み込みのプリセット一覧を、名前をユーザーの言語にして返す
+func (pu *patternUsecase) GetPatternPresets(ctx context.Context, userID string) ([]*GetPatternPresetOutput, error) {
+	user, err := pu.userRepo.GetSettingByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	presets := patternDomain.GetPatternPresets()
+	result := make([]*GetPatternPresetOutput, len(presets))
+	for i, p := range presets {
+		steps := make([]GetPatternPresetStepOutput, len(p.Steps()))
+		for j, s := range p.Steps() {
+			steps[j] = GetPatternPresetStepOutput{
+				StepNumber:   j + 1,
+				IntervalDays: s.IntervalDays,
+				IntervalUnit: s.IntervalUnit,
+			}
+		}
+		result[i] = &GetPatternPresetOutput{
+			Key:                 p.Key(),
+			Name:                p.Name(user.Language()),
+			TargetWeight:        p.TargetWeight(),
+			SchedulingAlgorithm: p.SchedulingAlgorithm(),
+			Steps:               steps,
+		}
+	}
+	return result, nil
+}
+
+// プリセットを複製して、ユーザーの言語の名前でユーザーのパターンを作成する
+// 作成後は通常のパターンと同じく編集できる
+func (pu *patternUsecase) CreatePatternFromPreset(ctx context.Context, userID string, presetKey string) (*CreatePatternOutput, error) {
+	preset, err := patternDomain.FindPatternPreset(presetKey)
+	if err != nil {
+		return nil, err
+	}
+	user, err := pu.userRepo.GetSettingByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]CreatePatternStepInput, len(preset.Steps()))
+	for i, s := range preset.Steps() {
+		steps[i] = CreatePatternStepInput{
+			StepNumber:   i + 1,
+			IntervalDays: s.IntervalDays,
+			IntervalUnit: s.IntervalUnit,
+		}
+	}
+	return pu.CreatePattern(ctx, CreatePatternInput{
+		UserID:              userID,
+		Name:                preset.Name(user.Language()),
+		TargetWeight:        preset.TargetWeight(),
+		SchedulingAlgorithm: preset.SchedulingAlgorithm(),
+		Steps:               steps,
+	})
+}
+
+// 別のアカウントに取り込めるように、ユーザーの全パターンをエクスポート形式で返す
+func (pu *patternUsecase) ExportPatterns(ctx context.Context, userID string) (*ExportPatternsOutput, error) {
+	patterns, err := pu.GetPatternsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := make([]PatternExport, len(patterns))
+	for i, p := range patterns {
+		steps := make([]PatternExportStep, len(p.Steps))
+		for j, s := range p.Steps {
+			steps[j] = PatternExportStep{
+				StepNumber:   s.StepNumber,
+				IntervalDays: s.IntervalDays,
+				IntervalUnit: s.IntervalUnit,
+			}
+		}
+		exported[i] = PatternExport{
+			Name:                    p.Name,
+			TargetWeight:            p.TargetWeight,
+			SchedulingAlgorithm:     p.SchedulingAlgorithm,
+			IsLoadBalanced:          p.IsLoadBalanced,
+			ExcludedWeekdays:        p.ExcludedWeekdays,
+			MaintenanceIntervalDays: p.MaintenanceIntervalDays,
+			Steps:                   steps,
+		}
+	}
+	return &ExportPatternsOutput{
+		FormatVersion: PatternExportFormatVersion,
+		Patterns:      exported,
+	}, nil
+}
+
+// エクスポート形式のパターンをパターン毎に作成し、パターン毎の結果を返す
+// 重みやステップの検証はCreatePatternで行い、1件が不正でも他のパターンの作成は続ける
+func (pu *patternUsecase) ImportPatterns(ctx context.Context, in ImportPatternsInput) (*ImportPatternsOutput, error) {
+	if in.FormatVersion != PatternExportFormatVersion {
+		return nil, patternDomain.ErrUnsupportedPatternFormatVersion
+	}
+
+	existingNames := make(map[string]struct{})
+	if in.SkipDuplicates {
+		existingPatterns, err := pu.patternRepo.GetAllPatternsByUserID(ctx, in.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range existingPatterns {
+			existingNames[p.Name()] = struct{}{}
+		}
+	}
+
+	out := &ImportPatternsOutput{
+		Results: make([]ImportPatternResultOutput, len(in.Patterns)),
+	}
+	for i, p := range in.Patterns {
+		result := ImportPatternResultOutput{
+			Index: i,
+			Name:  p.Name,
+		}
+
+		if _, ok := existingNames[p.Name]; ok && in.SkipDuplicates {
+			result.Status = ImportPatternStatusSkipped
+			out.SkippedCount++
+			out.Results[i] = result
+			continue
+		}
+
+		steps := make([]CreatePatternStepInput, len(p.Steps))
+		for j, s := range p.Steps {
+			steps[j] = CreatePatternStepInput{
+				StepNumber:   s.StepNumber,
+				IntervalDays: s.IntervalDays,
+				IntervalUnit: s.IntervalUnit,
+			}
+		}
+		created, err := pu.CreatePattern(ctx, CreatePatternInput{
+			UserID:                  in.UserID,
+			Name:                    p.Name,
+			TargetWeight:            p.TargetWeight,
+			SchedulingAlgorithm:     p.SchedulingAlgorithm,
+			IsLoadBalanced:          p.IsLoadBalanced,
+			ExcludedWeekdays:        p.ExcludedWeekdays,
+			MaintenanceIntervalDays: p.MaintenanceIntervalDays,
+			Steps:                   steps,
+		})
+		if err != nil {
+			result.Status = ImportPatternStatusFailed
+			result.Error = err.Error()
+			out.FailedCount++
+			out.Results[i] = result
+			continue
+		}
+
+		existingNames[p.Name] = struct{}{}
+		result.Status = ImportPatternStatusCreated
+		result.PatternID = created.ID
+		out.CreatedCount++
+		out.Results[i] = result
+	}
+	return out, nil
+}
+
+// ステップの間隔の単位が空文字の場合は日単位とする
+func intervalUnitOrDefault(intervalUnit string) string {
+	if intervalUnit == "" {
+		return patternDomain.IntervalUnitDay
+	}
+	return intervalUnit
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/mock/gomock"
 
+	boxDomain "github.com/minminseo/recall-setter/domain/box"
 	itemDomain "github.com/minminseo/recall-setter/domain/item"
 	patternDomain "github.com/minminseo/recall-setter/domain/pattern"
+	userDomain "github.com/minminseo/recall-setter/domain/user"
 	"github.com/minminseo/recall-setter/usecase/transaction"
 )
 
@@ -49,17 +51,23 @@ func TestPatternUsecase_CreatePattern(t *testing.T) {
 						CreatePatternSteps(ctx, gomock.Any()).
 						Return(int64(1), nil).
 						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternVersion(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
 				)
 			},
 			want: &CreatePatternOutput{
-				ID:           "",
-				UserID:       "user-123",
-				Name:         "テストパターン",
-				TargetWeight: "light",
-				RegisteredAt: fixedTime,
-				EditedAt:     fixedTime,
+				ID:                  "",
+				UserID:              "user-123",
+				Name:                "テストパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            fixedTime,
 				Steps: []CreatePatternStepOutput{
-					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 1, IntervalDays: 1},
+					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
 				},
 			},
 			wantErr: false,
@@ -92,19 +100,124 @@ func TestPatternUsecase_CreatePattern(t *testing.T) {
 						CreatePatternSteps(ctx, gomock.Any()).
 						Return(int64(3), nil).
 						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternVersion(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
 				)
 			},
 			want: &CreatePatternOutput{
-				ID:           "",
+				ID:                  "",
+				UserID:              "user-123",
+				Name:                "複数ステップパターン",
+				TargetWeight:        "heavy",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            fixedTime,
+				Steps: []CreatePatternStepOutput{
+					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
+					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 2, IntervalDays: 3, IntervalUnit: "day"},
+					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 3, IntervalDays: 7, IntervalUnit: "day"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系_日・週・月の単位が混在するパターン作成成功",
+			input: CreatePatternInput{
 				UserID:       "user-123",
-				Name:         "複数ステップパターン",
-				TargetWeight: "heavy",
-				RegisteredAt: fixedTime,
-				EditedAt:     fixedTime,
+				Name:         "単位混在パターン",
+				TargetWeight: "normal",
+				Steps: []CreatePatternStepInput{
+					{StepNumber: 1, IntervalDays: 1},
+					{StepNumber: 2, IntervalDays: 1, IntervalUnit: "week"},
+					{StepNumber: 3, IntervalDays: 1, IntervalUnit: "month"},
+					{StepNumber: 4, IntervalDays: 3, IntervalUnit: "month"},
+				},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePattern(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternSteps(ctx, gomock.Any()).
+						Return(int64(4), nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternVersion(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &CreatePatternOutput{
+				ID:                  "",
+				UserID:              "user-123",
+				Name:                "単位混在パターン",
+				TargetWeight:        "normal",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            fixedTime,
+				Steps: []CreatePatternStepOutput{
+					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
+					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 2, IntervalDays: 1, IntervalUnit: "week"},
+					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 3, IntervalDays: 1, IntervalUnit: "month"},
+					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 4, IntervalDays: 3, IntervalUnit: "month"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系_適応型SM-2方式のパターン作成成功",
+			input: CreatePatternInput{
+				UserID:              "user-123",
+				Name:                "テストパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "sm2_adaptive",
+				Steps:               []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 1}},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePattern(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternSteps(ctx, gomock.Any()).
+						Return(int64(1), nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternVersion(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &CreatePatternOutput{
+				ID:                  "",
+				UserID:              "user-123",
+				Name:                "テストパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "sm2_adaptive",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            fixedTime,
 				Steps: []CreatePatternStepOutput{
-					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 1, IntervalDays: 1},
-					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 2, IntervalDays: 3},
-					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 3, IntervalDays: 7},
+					{PatternStepID: "", UserID: "user-123", PatternID: "", StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
 				},
 			},
 			wantErr: false,
@@ -116,6 +229,13 @@ func TestPatternUsecase_CreatePattern(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:  "異常系_SchedulingAlgorithmが無効な値",
+			input: CreatePatternInput{UserID: "user-123", Name: "テストパターン", TargetWeight: "light", SchedulingAlgorithm: "invalid", Steps: []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 1}}},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+			},
+			wantErr: true,
+		},
 		{
 			name:  "異常系_Nameが空文字列",
 			input: CreatePatternInput{UserID: "user-123", Name: "", TargetWeight: "light", Steps: []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 1}}},
@@ -130,6 +250,32 @@ func TestPatternUsecase_CreatePattern(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:  "異常系_適応型SM-2方式でステップが複数",
+			input: CreatePatternInput{UserID: "user-123", Name: "テストパターン", TargetWeight: "light", SchedulingAlgorithm: "sm2_adaptive", Steps: []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 1}, {StepNumber: 2, IntervalDays: 3}}},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+			},
+			wantErr: true,
+		},
+		{
+			name:  "異常系_適応型SM-2方式で月単位のステップ",
+			input: CreatePatternInput{UserID: "user-123", Name: "テストパターン", TargetWeight: "light", SchedulingAlgorithm: "sm2_adaptive", Steps: []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 1, IntervalUnit: "month"}}},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系_4週の次に1か月（復習日が重なりうる）",
+			input: CreatePatternInput{
+				UserID:       "user-123",
+				Name:         "テストパターン",
+				TargetWeight: "light",
+				Steps:        []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 4, IntervalUnit: "week"}, {StepNumber: 2, IntervalDays: 1, IntervalUnit: "month"}},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+			},
+			wantErr: true,
+		},
 		{
 			name: "異常系_StepNumberが重複",
 			input: CreatePatternInput{
@@ -194,6 +340,38 @@ func TestPatternUsecase_CreatePattern(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "異常系_CreatePatternVersionでエラー",
+			input: CreatePatternInput{
+				UserID:       "user-123",
+				Name:         "テストパターン",
+				TargetWeight: "light",
+				Steps:        []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 1}},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePattern(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternSteps(ctx, gomock.Any()).
+						Return(int64(1), nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternVersion(ctx, gomock.Any()).
+						Return(errors.New("版作成エラー")).
+						Times(1),
+				)
+			},
+			wantErr: true,
+		},
 		{
 			name: "異常系_トランザクション全体でエラー",
 			input: CreatePatternInput{
@@ -227,7 +405,7 @@ func TestPatternUsecase_CreatePattern(t *testing.T) {
 
 			tt.setup(patternRepo, itemRepo, txManager)
 
-			uc := NewPatternUsecase(patternRepo, itemRepo, txManager)
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
 			got, err := uc.CreatePattern(ctx, tt.input)
 
 			if tt.wantErr {
@@ -280,12 +458,16 @@ func TestPatternUsecase_GetPatternsByUserID(t *testing.T) {
 					"user-123",
 					"パターン1",
 					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					fixedTime,
 					fixedTime,
 				)
 				patterns := []*patternDomain.Pattern{pattern1}
-				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1)
-				step2, _ := patternDomain.ReconstructPatternStep("step-2", "user-123", "pattern-1", 2, 3)
+				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
+				step2, _ := patternDomain.ReconstructPatternStep("step-2", "user-123", "pattern-1", 2, 3, patternDomain.IntervalUnitDay)
 				steps := []*patternDomain.PatternStep{step1, step2}
 				gomock.InOrder(
 					patternRepo.EXPECT().
@@ -299,15 +481,17 @@ func TestPatternUsecase_GetPatternsByUserID(t *testing.T) {
 				)
 			},
 			want: []*GetPatternOutput{{
-				PatternID:    "pattern-1",
-				UserID:       "user-123",
-				Name:         "パターン1",
-				TargetWeight: "light",
-				RegisteredAt: fixedTime,
-				EditedAt:     fixedTime,
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "パターン1",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            fixedTime,
 				Steps: []GetPatternStepOutput{
-					{PatternStepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1},
-					{PatternStepID: "step-2", PatternID: "pattern-1", StepNumber: 2, IntervalDays: 3},
+					{PatternStepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
+					{PatternStepID: "step-2", PatternID: "pattern-1", StepNumber: 2, IntervalDays: 3, IntervalUnit: "day"},
 				},
 			}},
 		},
@@ -320,6 +504,10 @@ func TestPatternUsecase_GetPatternsByUserID(t *testing.T) {
 					"user-123",
 					"パターン1",
 					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					fixedTime,
 					fixedTime,
 				)
@@ -337,13 +525,15 @@ func TestPatternUsecase_GetPatternsByUserID(t *testing.T) {
 				)
 			},
 			want: []*GetPatternOutput{{
-				PatternID:    "pattern-1",
-				UserID:       "user-123",
-				Name:         "パターン1",
-				TargetWeight: "light",
-				RegisteredAt: fixedTime,
-				EditedAt:     fixedTime,
-				Steps:        nil,
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "パターン1",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            fixedTime,
+				Steps:               nil,
 			}},
 		},
 		{
@@ -383,6 +573,10 @@ func TestPatternUsecase_GetPatternsByUserID(t *testing.T) {
 					"user-123",
 					"パターン1",
 					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					fixedTime,
 					fixedTime,
 				)
@@ -415,7 +609,7 @@ func TestPatternUsecase_GetPatternsByUserID(t *testing.T) {
 
 			tt.setup(patternRepo, itemRepo, txManager)
 
-			uc := NewPatternUsecase(patternRepo, itemRepo, txManager)
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
 			got, err := uc.GetPatternsByUserID(ctx, tt.userID)
 
 			if tt.wantErr {
@@ -462,10 +656,14 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 					"user-123",
 					"元のパターン",
 					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					fixedTime,
 					fixedTime,
 				)
-				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1)
+				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
 				steps := []*patternDomain.PatternStep{step1}
 				gomock.InOrder(
 					patternRepo.EXPECT().
@@ -489,23 +687,26 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 				)
 			},
 			want: &UpdatePatternOutput{
-				PatternID:    "pattern-1",
-				UserID:       "user-123",
-				Name:         "更新されたパターン",
-				TargetWeight: "heavy",
-				RegisteredAt: fixedTime,
-				EditedAt:     editedTime,
-				Steps:        []UpdatePatternStepOutput{},
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "更新されたパターン",
+				TargetWeight:        "heavy",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            editedTime,
+				Steps:               []UpdatePatternStepOutput{},
 			},
 		},
 		{
-			name: "正常系_ステップのみ更新成功",
+			name: "正常系_復習物関連があっても負荷分散の有無は変更できる",
 			input: UpdatePatternInput{
-				PatternID:    "pattern-1",
-				UserID:       "user-123",
-				Name:         "元のパターン",
-				TargetWeight: "light",
-				Steps:        []UpdatePatternStepInput{{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 2}},
+				PatternID:      "pattern-1",
+				UserID:         "user-123",
+				Name:           "元のパターン",
+				TargetWeight:   "light",
+				IsLoadBalanced: func() *bool { b := true; return &b }(),
+				Steps:          []UpdatePatternStepInput{{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1}},
 			},
 			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
 				pattern, _ := patternDomain.ReconstructPattern(
@@ -513,11 +714,16 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 					"user-123",
 					"元のパターン",
 					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					fixedTime,
 					fixedTime,
 				)
-				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1)
+				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
 				steps := []*patternDomain.PatternStep{step1}
+				// 既存の復習日には影響しないため、IsPatternRelatedToItemByPatternIDは呼ばれない
 				gomock.InOrder(
 					patternRepo.EXPECT().
 						FindPatternByPatternID(ctx, "pattern-1", "user-123").
@@ -527,10 +733,6 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 						GetAllPatternStepsByPatternID(ctx, "pattern-1", "user-123").
 						Return(steps, nil).
 						Times(1),
-					itemRepo.EXPECT().
-						IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
-						Return(false, nil).
-						Times(1),
 					txManager.EXPECT().
 						RunInTransaction(ctx, gomock.Any()).
 						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
@@ -538,47 +740,92 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 						}).
 						Times(1),
 					patternRepo.EXPECT().
-						DeletePatternSteps(ctx, "pattern-1", "user-123").
+						UpdatePattern(ctx, gomock.Any()).
 						Return(nil).
 						Times(1),
-					patternRepo.EXPECT().
-						CreatePatternSteps(ctx, gomock.Any()).
-						Return(int64(1), nil).
-						Times(1),
 				)
 			},
 			want: &UpdatePatternOutput{
-				PatternID:    "pattern-1",
-				UserID:       "user-123",
-				Name:         "元のパターン",
-				TargetWeight: "light",
-				RegisteredAt: fixedTime,
-				EditedAt:     fixedTime,
-				Steps: []UpdatePatternStepOutput{
-					{PatternStepID: "", UserID: "user-123", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 2},
-				},
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "元のパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "fixed",
+				IsLoadBalanced:      true,
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            editedTime,
+				Steps:               []UpdatePatternStepOutput{},
 			},
-			wantErr: false,
 		},
 		{
-			name:  "異常系_パターンが存在しない",
-			input: UpdatePatternInput{PatternID: "pattern-1", UserID: "user-123", Name: "更新されたパターン", TargetWeight: "heavy", Steps: []UpdatePatternStepInput{}},
+			name: "正常系_復習物関連があっても除外する曜日は変更できる",
+			input: UpdatePatternInput{
+				PatternID:        "pattern-1",
+				UserID:           "user-123",
+				Name:             "元のパターン",
+				TargetWeight:     "light",
+				ExcludedWeekdays: []int{0, 6},
+				Steps:            []UpdatePatternStepInput{{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1}},
+			},
 			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
-				patternRepo.EXPECT().
-					FindPatternByPatternID(ctx, "pattern-1", "user-123").
-					Return(nil, patternDomain.ErrPatternNotFound).
-					Times(1)
+				pattern, _ := patternDomain.ReconstructPattern(
+					"pattern-1",
+					"user-123",
+					"元のパターン",
+					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
+					fixedTime,
+					fixedTime,
+				)
+				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
+				steps := []*patternDomain.PatternStep{step1}
+				// 既存の復習日には影響しないため、IsPatternRelatedToItemByPatternIDは呼ばれない
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, "pattern-1", "user-123").
+						Return(pattern, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(ctx, "pattern-1", "user-123").
+						Return(steps, nil).
+						Times(1),
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					patternRepo.EXPECT().
+						UpdatePattern(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want: &UpdatePatternOutput{
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "元のパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{0, 6},
+				RegisteredAt:        fixedTime,
+				EditedAt:            editedTime,
+				Steps:               []UpdatePatternStepOutput{},
 			},
-			wantErr: true,
 		},
 		{
-			name: "異常系_変更がない場合",
+			name: "正常系_復習物関連があっても繰り返しの間隔日数は変更できる",
 			input: UpdatePatternInput{
-				PatternID:    "pattern-1",
-				UserID:       "user-123",
-				Name:         "元のパターン",
-				TargetWeight: "light",
-				Steps:        []UpdatePatternStepInput{{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1}},
+				PatternID:               "pattern-1",
+				UserID:                  "user-123",
+				Name:                    "元のパターン",
+				TargetWeight:            "light",
+				MaintenanceIntervalDays: func() *int { d := 30; return &d }(),
+				Steps:                   []UpdatePatternStepInput{{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1}},
 			},
 			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
 				pattern, _ := patternDomain.ReconstructPattern(
@@ -586,11 +833,16 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 					"user-123",
 					"元のパターン",
 					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					fixedTime,
 					fixedTime,
 				)
-				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1)
+				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
 				steps := []*patternDomain.PatternStep{step1}
+				// 最後のステップ以降に生成する復習日にのみ影響するため、IsPatternRelatedToItemByPatternIDは呼ばれない
 				gomock.InOrder(
 					patternRepo.EXPECT().
 						FindPatternByPatternID(ctx, "pattern-1", "user-123").
@@ -600,12 +852,33 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 						GetAllPatternStepsByPatternID(ctx, "pattern-1", "user-123").
 						Return(steps, nil).
 						Times(1),
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					patternRepo.EXPECT().
+						UpdatePattern(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
 				)
 			},
-			wantErr: true,
+			want: &UpdatePatternOutput{
+				PatternID:               "pattern-1",
+				UserID:                  "user-123",
+				Name:                    "元のパターン",
+				TargetWeight:            "light",
+				SchedulingAlgorithm:     "fixed",
+				ExcludedWeekdays:        []int{},
+				MaintenanceIntervalDays: 30,
+				RegisteredAt:            fixedTime,
+				EditedAt:                editedTime,
+				Steps:                   []UpdatePatternStepOutput{},
+			},
 		},
 		{
-			name: "異常系_ステップ変更時に復習物関連がある",
+			name: "正常系_ステップのみ更新成功",
 			input: UpdatePatternInput{
 				PatternID:    "pattern-1",
 				UserID:       "user-123",
@@ -619,11 +892,16 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 					"user-123",
 					"元のパターン",
 					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
 					fixedTime,
 					fixedTime,
 				)
-				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1)
+				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
 				steps := []*patternDomain.PatternStep{step1}
+				latestVersion, _ := patternDomain.ReconstructPatternVersion("version-1", "user-123", "pattern-1", 1, "fixed", steps, fixedTime)
 				gomock.InOrder(
 					patternRepo.EXPECT().
 						FindPatternByPatternID(ctx, "pattern-1", "user-123").
@@ -635,10 +913,1764 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 						Times(1),
 					itemRepo.EXPECT().
 						IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
-						Return(true, nil).
+						Return(false, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						FindLatestPatternVersionByPatternID(ctx, "pattern-1", "user-123").
+						Return(latestVersion, nil).
+						Times(1),
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					patternRepo.EXPECT().
+						DeletePatternSteps(ctx, "pattern-1", "user-123").
+						Return(nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternSteps(ctx, gomock.Any()).
+						Return(int64(1), nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternVersion(ctx, gomock.Any()).
+						Return(nil).
 						Times(1),
 				)
 			},
+			want: &UpdatePatternOutput{
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "元のパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            fixedTime,
+				Steps: []UpdatePatternStepOutput{
+					{PatternStepID: "", UserID: "user-123", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 2, IntervalUnit: "day"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "異常系_パターンが存在しない",
+			input: UpdatePatternInput{PatternID: "pattern-1", UserID: "user-123", Name: "更新されたパターン", TargetWeight: "heavy", Steps: []UpdatePatternStepInput{}},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				patternRepo.EXPECT().
+					FindPatternByPatternID(ctx, "pattern-1", "user-123").
+					Return(nil, patternDomain.ErrPatternNotFound).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系_変更がない場合",
+			input: UpdatePatternInput{
+				PatternID:    "pattern-1",
+				UserID:       "user-123",
+				Name:         "元のパターン",
+				TargetWeight: "light",
+				Steps:        []UpdatePatternStepInput{{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1}},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				pattern, _ := patternDomain.ReconstructPattern(
+					"pattern-1",
+					"user-123",
+					"元のパターン",
+					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
+					fixedTime,
+					fixedTime,
+				)
+				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
+				steps := []*patternDomain.PatternStep{step1}
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, "pattern-1", "user-123").
+						Return(pattern, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(ctx, "pattern-1", "user-123").
+						Return(steps, nil).
+						Times(1),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "正常系_復習物関連があり既存の復習物の扱いの指定がない場合はkeepとしてステップを変更する",
+			input: UpdatePatternInput{
+				PatternID:    "pattern-1",
+				UserID:       "user-123",
+				Name:         "元のパターン",
+				TargetWeight: "light",
+				Steps:        []UpdatePatternStepInput{{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 2}},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				pattern, _ := patternDomain.ReconstructPattern(
+					"pattern-1",
+					"user-123",
+					"元のパターン",
+					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
+					fixedTime,
+					fixedTime,
+				)
+				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
+				steps := []*patternDomain.PatternStep{step1}
+				latestVersion, _ := patternDomain.ReconstructPatternVersion("version-1", "user-123", "pattern-1", 1, "fixed", steps, fixedTime)
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, "pattern-1", "user-123").
+						Return(pattern, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(ctx, "pattern-1", "user-123").
+						Return(steps, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
+						Return(true, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						FindLatestPatternVersionByPatternID(ctx, "pattern-1", "user-123").
+						Return(latestVersion, nil).
+						Times(1),
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					patternRepo.EXPECT().
+						DeletePatternSteps(ctx, "pattern-1", "user-123").
+						Return(nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternSteps(ctx, gomock.Any()).
+						Return(int64(1), nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternVersion(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, v *patternDomain.PatternVersion) error {
+							if v.VersionNumber() != 2 {
+								return errors.New("版番号が最新の版の次になっていません")
+							}
+							return nil
+						}).
+						Times(1),
+				)
+			},
+			want: &UpdatePatternOutput{
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "元のパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            fixedTime,
+				Steps: []UpdatePatternStepOutput{
+					{PatternStepID: "", UserID: "user-123", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 2, IntervalUnit: "day"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系_復習物関連があり既存の復習物の扱いの指定がない場合はkeepとしてスケジューリング方式を変更する",
+			input: UpdatePatternInput{
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "元のパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "sm2",
+				Steps:               []UpdatePatternStepInput{{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1}},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				pattern, _ := patternDomain.ReconstructPattern(
+					"pattern-1",
+					"user-123",
+					"元のパターン",
+					"light",
+					"fixed",
+					false,
+					[]int{},
+					0,
+					fixedTime,
+					fixedTime,
+				)
+				step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
+				steps := []*patternDomain.PatternStep{step1}
+				latestVersion, _ := patternDomain.ReconstructPatternVersion("version-1", "user-123", "pattern-1", 1, "fixed", steps, fixedTime)
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, "pattern-1", "user-123").
+						Return(pattern, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(ctx, "pattern-1", "user-123").
+						Return(steps, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
+						Return(true, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						FindLatestPatternVersionByPatternID(ctx, "pattern-1", "user-123").
+						Return(latestVersion, nil).
+						Times(1),
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					patternRepo.EXPECT().
+						UpdatePattern(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternVersion(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, v *patternDomain.PatternVersion) error {
+							if v.SchedulingAlgorithm() != "sm2" {
+								return errors.New("版の方式が変更後の方式になっていません")
+							}
+							return nil
+						}).
+						Times(1),
+				)
+			},
+			want: &UpdatePatternOutput{
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "元のパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: "sm2",
+				ExcludedWeekdays:    []int{},
+				RegisteredAt:        fixedTime,
+				EditedAt:            editedTime,
+				Steps:               []UpdatePatternStepOutput{},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			txManager := transaction.NewMockITransactionManager(ctrl)
+
+			tt.setup(patternRepo, itemRepo, txManager)
+
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
+			got, err := uc.UpdatePattern(ctx, tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("UpdatePattern() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("UpdatePattern() unexpected error = %v", err)
+				return
+			}
+
+			if got != nil {
+				for i := range got.Steps {
+					got.Steps[i].PatternStepID = ""
+				}
+				if got.EditedAt.After(tt.want.EditedAt) {
+					got.EditedAt = editedTime
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UpdatePattern() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatternUsecase_UpdatePatternWithStepChangeMode(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	learnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	newPattern := func() *patternDomain.Pattern {
+		pattern, _ := patternDomain.ReconstructPattern(
+			"pattern-1",
+			"user-123",
+			"元のパターン",
+			"light",
+			"fixed",
+			false,
+			[]int{},
+			0,
+			fixedTime,
+			fixedTime,
+		)
+		return pattern
+	}
+	step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
+	step2, _ := patternDomain.ReconstructPatternStep("step-2", "user-123", "pattern-1", 2, 3, patternDomain.IntervalUnitDay)
+	steps := []*patternDomain.PatternStep{step1, step2}
+	updatedSteps := []UpdatePatternStepInput{
+		{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1},
+		{StepID: "step-2", PatternID: "pattern-1", StepNumber: 2, IntervalDays: 7},
+		{StepNumber: 3, IntervalDays: 14},
+	}
+
+	patternID := "pattern-1"
+	item1, _ := itemDomain.ReconstructItem("item-1", "user-123", nil, nil, &patternID, nil, "復習物1", "", learnedDate, false, fixedTime, fixedTime)
+	completedReviewdate1, _ := itemDomain.NewReviewdate("rd-1", "user-123", nil, nil, "item-1", 1, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true)
+	inCompletedReviewdate2, _ := itemDomain.NewReviewdate("rd-2", "user-123", nil, nil, "item-1", 2, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), false)
+	completedReviewdate2, _ := itemDomain.NewReviewdate("rd-2", "user-123", nil, nil, "item-1", 2, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), true)
+	maintenanceReviewdate3, _ := itemDomain.NewReviewdate("rd-3", "user-123", nil, nil, "item-1", 3, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), false)
+	rescheduledReviewdate2, _ := itemDomain.NewReviewdate("rd-new-2", "user-123", nil, nil, "item-1", 2, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), false)
+	rescheduledReviewdate3, _ := itemDomain.NewReviewdate("rd-new-3", "user-123", nil, nil, "item-1", 3, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), false)
+	// ステップが1つだけだった古い版の復習物。ステップ2の復習日は全ステップを完了した後の繰り返しの復習日
+	oldVersionID := "version-old"
+	oldVersion, _ := patternDomain.ReconstructPatternVersion(oldVersionID, "user-123", "pattern-1", 1, "fixed", []*patternDomain.PatternStep{step1}, fixedTime)
+	itemOnOldVersion, _ := itemDomain.ReconstructItem("item-1", "user-123", nil, nil, &patternID, &oldVersionID, "復習物1", "", learnedDate, false, fixedTime, fixedTime)
+
+	// 復習物に紐づくパターンの取得からステップの差し替えまでの共通の呼び出し
+	expectUpdateSteps := func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository) []any {
+		return []any{
+			patternRepo.EXPECT().
+				FindPatternByPatternID(ctx, "pattern-1", "user-123").
+				Return(newPattern(), nil).
+				Times(1),
+			patternRepo.EXPECT().
+				GetAllPatternStepsByPatternID(ctx, "pattern-1", "user-123").
+				Return(steps, nil).
+				Times(1),
+			itemRepo.EXPECT().
+				IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
+				Return(true, nil).
+				Times(1),
+		}
+	}
+	expectScheduler := func(itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) []any {
+		return []any{
+			scheduler.EXPECT().
+				WithAlgorithm("fixed").
+				Return(scheduler, nil).
+				Times(1),
+			itemRepo.EXPECT().
+				GetExcludedWeekdaysByUserID(ctx, "user-123").
+				Return([]int{}, nil).
+				Times(1),
+			itemRepo.EXPECT().
+				GetBlockedDatesByUserID(ctx, "user-123").
+				Return([]time.Time{}, nil).
+				Times(1),
+			scheduler.EXPECT().
+				WithExcludedWeekdays(gomock.Any()).
+				Return(scheduler).
+				Times(1),
+			scheduler.EXPECT().
+				WithBlockedDates(gomock.Any()).
+				Return(scheduler).
+				Times(1),
+		}
+	}
+	errLock := errors.New("lock failed")
+	latestVersion, _ := patternDomain.ReconstructPatternVersion("version-1", "user-123", "pattern-1", 1, "fixed", steps, fixedTime)
+	// 新しい版の作成からトランザクションの開始までの共通の呼び出し
+	expectBeginTransaction := func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager) []any {
+		return []any{
+			patternRepo.EXPECT().
+				FindLatestPatternVersionByPatternID(ctx, "pattern-1", "user-123").
+				Return(latestVersion, nil).
+				Times(1),
+			txManager.EXPECT().
+				RunInTransaction(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).
+				Times(1),
+		}
+	}
+	// ステップと版の保存の共通の呼び出し
+	expectSaveSteps := func(patternRepo *patternDomain.MockIPatternRepository) []any {
+		return []any{
+			patternRepo.EXPECT().
+				DeletePatternSteps(ctx, "pattern-1", "user-123").
+				Return(nil).
+				Times(1),
+			patternRepo.EXPECT().
+				CreatePatternSteps(ctx, gomock.Any()).
+				Return(int64(3), nil).
+				Times(1),
+			patternRepo.EXPECT().
+				CreatePatternVersion(ctx, gomock.Any()).
+				Return(nil).
+				Times(1),
+		}
+	}
+	expectTransaction := func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager) []any {
+		return append(expectBeginTransaction(patternRepo, txManager), expectSaveSteps(patternRepo)...)
+	}
+	// 組み直す前に、トランザクション内で未完了の復習物をロックしてから復習物を読み込む
+	expectLockAndScheduler := func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) []any {
+		calls := expectBeginTransaction(patternRepo, txManager)
+		calls = append(calls,
+			itemRepo.EXPECT().
+				LockUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+				Return(nil).
+				Times(1),
+		)
+		return append(calls, expectScheduler(itemRepo, scheduler)...)
+	}
+
+	tests := []struct {
+		name                       string
+		input                      UpdatePatternInput
+		setup                      func(*patternDomain.MockIPatternRepository, *itemDomain.MockIItemRepository, *transaction.MockITransactionManager, *itemDomain.MockIScheduler)
+		wantUpdatedItemCount       int
+		wantUpdatedReviewDateCount int
+		wantErr                    error
+	}{
+		{
+			name: "正常系_keepの場合は既存の復習日を変更せずにステップを変更する",
+			input: UpdatePatternInput{
+				PatternID:      "pattern-1",
+				UserID:         "user-123",
+				Name:           "元のパターン",
+				TargetWeight:   "light",
+				Steps:          updatedSteps,
+				StepChangeMode: patternDomain.StepChangeModeKeep,
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectUpdateSteps(patternRepo, itemRepo)
+				calls = append(calls, expectTransaction(patternRepo, txManager)...)
+				gomock.InOrder(calls...)
+			},
+			wantUpdatedItemCount:       0,
+			wantUpdatedReviewDateCount: 0,
+		},
+		{
+			name: "正常系_rescheduleの場合は完了したステップより後の未完了の復習日を組み直す",
+			input: UpdatePatternInput{
+				PatternID:      "pattern-1",
+				UserID:         "user-123",
+				Name:           "元のパターン",
+				TargetWeight:   "light",
+				Steps:          updatedSteps,
+				StepChangeMode: patternDomain.StepChangeModeReschedule,
+				Today:          "2024-01-03",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectUpdateSteps(patternRepo, itemRepo)
+				calls = append(calls, expectLockAndScheduler(patternRepo, itemRepo, txManager, scheduler)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						GetAllUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*itemDomain.Item{item1}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetReviewDatesByItemID(ctx, "item-1", "user-123").
+						Return([]*itemDomain.Reviewdate{completedReviewdate1, inCompletedReviewdate2}, nil).
+						Times(1),
+					scheduler.EXPECT().
+						RescheduleForUpdatedSteps(gomock.Any(), "user-123", nil, nil, "item-1", 1, learnedDate, today).
+						Return([]*itemDomain.Reviewdate{rescheduledReviewdate2, rescheduledReviewdate3}, nil).
+						Times(1),
+				)
+				calls = append(calls, expectSaveSteps(patternRepo)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						DeleteInCompletedReviewDatesAfterStep(ctx, "item-1", "user-123", 1).
+						Return(nil).
+						Times(1),
+					itemRepo.EXPECT().
+						UpdateItemPatternVersionID(ctx, "item-1", "user-123", gomock.Any()).
+						Return(nil).
+						Times(1),
+					itemRepo.EXPECT().
+						CreateReviewdates(ctx, []*itemDomain.Reviewdate{rescheduledReviewdate2, rescheduledReviewdate3}).
+						Return(int64(2), nil).
+						Times(1),
+				)
+				gomock.InOrder(calls...)
+			},
+			wantUpdatedItemCount:       1,
+			wantUpdatedReviewDateCount: 2,
+		},
+		{
+			name: "正常系_rescheduleで変更後の全ステップを完了している場合は復習物を完了にする",
+			input: UpdatePatternInput{
+				PatternID:    "pattern-1",
+				UserID:       "user-123",
+				Name:         "元のパターン",
+				TargetWeight: "light",
+				Steps: []UpdatePatternStepInput{
+					{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 2},
+				},
+				StepChangeMode: patternDomain.StepChangeModeReschedule,
+				Today:          "2024-01-03",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectUpdateSteps(patternRepo, itemRepo)
+				calls = append(calls, expectLockAndScheduler(patternRepo, itemRepo, txManager, scheduler)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						GetAllUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*itemDomain.Item{item1}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetReviewDatesByItemID(ctx, "item-1", "user-123").
+						Return([]*itemDomain.Reviewdate{completedReviewdate1, inCompletedReviewdate2}, nil).
+						Times(1),
+					scheduler.EXPECT().
+						RescheduleForUpdatedSteps(gomock.Any(), "user-123", nil, nil, "item-1", 1, learnedDate, today).
+						Return([]*itemDomain.Reviewdate{}, nil).
+						Times(1),
+				)
+				calls = append(calls, expectSaveSteps(patternRepo)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						DeleteInCompletedReviewDatesAfterStep(ctx, "item-1", "user-123", 1).
+						Return(nil).
+						Times(1),
+					itemRepo.EXPECT().
+						UpdateItemPatternVersionID(ctx, "item-1", "user-123", gomock.Any()).
+						Return(nil).
+						Times(1),
+					itemRepo.EXPECT().
+						UpdateItemAsFinished(ctx, "item-1", "user-123", gomock.Any()).
+						Return(nil).
+						Times(1),
+				)
+				gomock.InOrder(calls...)
+			},
+			wantUpdatedItemCount:       1,
+			wantUpdatedReviewDateCount: 0,
+		},
+		{
+			name: "正常系_rescheduleで全ステップを完了して繰り返し中の復習物は組み直さない",
+			input: UpdatePatternInput{
+				PatternID:      "pattern-1",
+				UserID:         "user-123",
+				Name:           "元のパターン",
+				TargetWeight:   "light",
+				Steps:          updatedSteps,
+				StepChangeMode: patternDomain.StepChangeModeReschedule,
+				Today:          "2024-01-03",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectUpdateSteps(patternRepo, itemRepo)
+				calls = append(calls, expectLockAndScheduler(patternRepo, itemRepo, txManager, scheduler)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						GetAllUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*itemDomain.Item{item1}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetReviewDatesByItemID(ctx, "item-1", "user-123").
+						Return([]*itemDomain.Reviewdate{completedReviewdate1, completedReviewdate2, maintenanceReviewdate3}, nil).
+						Times(1),
+				)
+				calls = append(calls, expectSaveSteps(patternRepo)...)
+				gomock.InOrder(calls...)
+			},
+			wantUpdatedItemCount:       0,
+			wantUpdatedReviewDateCount: 0,
+		},
+		{
+			name: "正常系_rescheduleで古い版の全ステップを完了して繰り返し中の復習物は組み直さない",
+			input: UpdatePatternInput{
+				PatternID:      "pattern-1",
+				UserID:         "user-123",
+				Name:           "元のパターン",
+				TargetWeight:   "light",
+				Steps:          updatedSteps,
+				StepChangeMode: patternDomain.StepChangeModeReschedule,
+				Today:          "2024-01-03",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectUpdateSteps(patternRepo, itemRepo)
+				calls = append(calls, expectLockAndScheduler(patternRepo, itemRepo, txManager, scheduler)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						GetAllUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*itemDomain.Item{itemOnOldVersion}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetReviewDatesByItemID(ctx, "item-1", "user-123").
+						Return([]*itemDomain.Reviewdate{completedReviewdate1, inCompletedReviewdate2}, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						FindPatternVersionByID(ctx, oldVersionID, "user-123").
+						Return(oldVersion, nil).
+						Times(1),
+				)
+				calls = append(calls, expectSaveSteps(patternRepo)...)
+				gomock.InOrder(calls...)
+			},
+			wantUpdatedItemCount:       0,
+			wantUpdatedReviewDateCount: 0,
+		},
+		{
+			name: "異常系_rescheduleで復習物のロックに失敗した場合は復習物を読み込まずに組み直さない",
+			input: UpdatePatternInput{
+				PatternID:      "pattern-1",
+				UserID:         "user-123",
+				Name:           "元のパターン",
+				TargetWeight:   "light",
+				Steps:          updatedSteps,
+				StepChangeMode: patternDomain.StepChangeModeReschedule,
+				Today:          "2024-01-03",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectUpdateSteps(patternRepo, itemRepo)
+				calls = append(calls, expectBeginTransaction(patternRepo, txManager)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						LockUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return(errLock).
+						Times(1),
+				)
+				gomock.InOrder(calls...)
+			},
+			wantErr: errLock,
+		},
+		{
+			name: "異常系_既存の復習物の扱いが不正な値",
+			input: UpdatePatternInput{
+				PatternID:      "pattern-1",
+				UserID:         "user-123",
+				Name:           "元のパターン",
+				TargetWeight:   "light",
+				Steps:          updatedSteps,
+				StepChangeMode: "invalid",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+			},
+			wantErr: patternDomain.ErrInvalidStepChangeMode,
+		},
+		{
+			name: "異常系_rescheduleで適応型SM-2方式に変更する",
+			input: UpdatePatternInput{
+				PatternID:           "pattern-1",
+				UserID:              "user-123",
+				Name:                "元のパターン",
+				TargetWeight:        "light",
+				SchedulingAlgorithm: patternDomain.SchedulingAlgorithmSM2Adaptive,
+				Steps: []UpdatePatternStepInput{
+					{StepID: "step-1", PatternID: "pattern-1", StepNumber: 1, IntervalDays: 1},
+				},
+				StepChangeMode: patternDomain.StepChangeModeReschedule,
+				Today:          "2024-01-03",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				gomock.InOrder(expectUpdateSteps(patternRepo, itemRepo)...)
+			},
+			wantErr: patternDomain.ErrAdaptivePatternReschedule,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			txManager := transaction.NewMockITransactionManager(ctrl)
+			scheduler := itemDomain.NewMockIScheduler(ctrl)
+
+			tt.setup(patternRepo, itemRepo, txManager, scheduler)
+
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, scheduler, userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
+			got, err := uc.UpdatePattern(ctx, tt.input)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("UpdatePattern() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("UpdatePattern() unexpected error = %v", err)
+				return
+			}
+			if got.UpdatedItemCount != tt.wantUpdatedItemCount {
+				t.Errorf("UpdatePattern() UpdatedItemCount = %d, want %d", got.UpdatedItemCount, tt.wantUpdatedItemCount)
+			}
+			if got.UpdatedReviewDateCount != tt.wantUpdatedReviewDateCount {
+				t.Errorf("UpdatePattern() UpdatedReviewDateCount = %d, want %d", got.UpdatedReviewDateCount, tt.wantUpdatedReviewDateCount)
+			}
+			if len(got.Steps) != len(tt.input.Steps) {
+				t.Errorf("UpdatePattern() len(Steps) = %d, want %d", len(got.Steps), len(tt.input.Steps))
+			}
+		})
+	}
+}
+
+func TestPatternUsecase_DeletePattern(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		patternID string
+		userID    string
+		setup     func(*patternDomain.MockIPatternRepository, *itemDomain.MockIItemRepository, *transaction.MockITransactionManager)
+		wantErr   bool
+	}{
+		{
+			name:      "正常系_復習物関連なしでの削除成功",
+			patternID: "pattern-1",
+			userID:    "user-123",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					itemRepo.EXPECT().
+						IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
+						Return(false, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						DeletePattern(ctx, "pattern-1", "user-123").
+						Return(nil).
+						Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name:      "異常系_復習物関連がある場合",
+			patternID: "pattern-1",
+			userID:    "user-123",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				itemRepo.EXPECT().
+					IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
+					Return(true, nil).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "異常系_IsPatternRelatedToItemByPatternIDでエラー",
+			patternID: "pattern-1",
+			userID:    "user-123",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				itemRepo.EXPECT().
+					IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
+					Return(false, errors.New("データベースエラー")).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:      "異常系_DeletePatternでエラー",
+			patternID: "pattern-1",
+			userID:    "user-123",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					itemRepo.EXPECT().
+						IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
+						Return(false, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						DeletePattern(ctx, "pattern-1", "user-123").
+						Return(errors.New("削除エラー")).
+						Times(1),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			txManager := transaction.NewMockITransactionManager(ctrl)
+
+			tt.setup(patternRepo, itemRepo, txManager)
+
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
+			err := uc.DeletePattern(ctx, tt.patternID, tt.userID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("DeletePattern() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("DeletePattern() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestPatternUsecase_GetPatternUsage(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	learnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	pattern, _ := patternDomain.ReconstructPattern("pattern-1", "user-123", "元のパターン", "light", "fixed", false, []int{}, 0, fixedTime, fixedTime)
+	box1, _ := boxDomain.ReconstructBox("box-1", "user-123", "category-1", "pattern-1", "ボックス1", fixedTime, fixedTime)
+	patternID := "pattern-1"
+	categoryID := "category-1"
+	boxID := "box-1"
+	item1, _ := itemDomain.ReconstructItem("item-1", "user-123", &categoryID, &boxID, &patternID, nil, "復習物1", "", learnedDate, false, fixedTime, fixedTime)
+	item2, _ := itemDomain.ReconstructItem("item-2", "user-123", nil, nil, &patternID, nil, "復習物2", "", learnedDate, true, fixedTime, fixedTime)
+
+	tests := []struct {
+		name    string
+		setup   func(*patternDomain.MockIPatternRepository, *itemDomain.MockIItemRepository, *boxDomain.MockIBoxRepository)
+		want    *GetPatternUsageOutput
+		wantErr bool
+	}{
+		{
+			name: "正常系_パターンを使うボックスと完了済みを含む復習物を返す",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, boxRepo *boxDomain.MockIBoxRepository) {
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, "pattern-1", "user-123").
+						Return(pattern, nil).
+						Times(1),
+					boxRepo.EXPECT().
+						GetAllByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*boxDomain.Box{box1}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetAllItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*itemDomain.Item{item1, item2}, nil).
+						Times(1),
+				)
+			},
+			want: &GetPatternUsageOutput{
+				Boxes: []PatternUsageBoxOutput{
+					{BoxID: "box-1", CategoryID: "category-1", Name: "ボックス1"},
+				},
+				Items: []PatternUsageItemOutput{
+					{ItemID: "item-1", CategoryID: &categoryID, BoxID: &boxID, Name: "復習物1", IsFinished: false},
+					{ItemID: "item-2", CategoryID: nil, BoxID: nil, Name: "復習物2", IsFinished: true},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系_パターンが存在しない",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, boxRepo *boxDomain.MockIBoxRepository) {
+				patternRepo.EXPECT().
+					FindPatternByPatternID(ctx, "pattern-1", "user-123").
+					Return(nil, patternDomain.ErrPatternNotFound).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系_GetAllItemsByPatternIDでエラー",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, boxRepo *boxDomain.MockIBoxRepository) {
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, "pattern-1", "user-123").
+						Return(pattern, nil).
+						Times(1),
+					boxRepo.EXPECT().
+						GetAllByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*boxDomain.Box{box1}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetAllItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return(nil, errors.New("データベースエラー")).
+						Times(1),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			txManager := transaction.NewMockITransactionManager(ctrl)
+			boxRepo := boxDomain.NewMockIBoxRepository(ctrl)
+
+			tt.setup(patternRepo, itemRepo, boxRepo)
+
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userDomain.NewMockUserRepository(ctrl), boxRepo)
+			got, err := uc.GetPatternUsage(ctx, "pattern-1", "user-123")
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("GetPatternUsage() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("GetPatternUsage() unexpected error = %v", err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetPatternUsage() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatternUsecase_DeletePatternWithReassign(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	learnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	oldPattern, _ := patternDomain.ReconstructPattern("pattern-1", "user-123", "削除するパターン", "light", "fixed", false, []int{}, 0, fixedTime, fixedTime)
+	newPattern, _ := patternDomain.ReconstructPattern("pattern-2", "user-123", "付け替え先のパターン", "light", "fixed", false, []int{}, 0, fixedTime, fixedTime)
+	adaptivePattern, _ := patternDomain.ReconstructPattern("pattern-2", "user-123", "付け替え先のパターン", "light", patternDomain.SchedulingAlgorithmSM2Adaptive, false, []int{}, 0, fixedTime, fixedTime)
+	newStep1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-2", 1, 2, patternDomain.IntervalUnitDay)
+	newStep2, _ := patternDomain.ReconstructPatternStep("step-2", "user-123", "pattern-2", 2, 5, patternDomain.IntervalUnitDay)
+	newSteps := []*patternDomain.PatternStep{newStep1, newStep2}
+
+	patternID := "pattern-1"
+	item1, _ := itemDomain.ReconstructItem("item-1", "user-123", nil, nil, &patternID, nil, "復習物1", "", learnedDate, false, fixedTime, fixedTime)
+	completedReviewdate1, _ := itemDomain.NewReviewdate("rd-1", "user-123", nil, nil, "item-1", 1, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true)
+	inCompletedReviewdate2, _ := itemDomain.NewReviewdate("rd-2", "user-123", nil, nil, "item-1", 2, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), false)
+	rescheduledReviewdate2, _ := itemDomain.NewReviewdate("rd-new-2", "user-123", nil, nil, "item-1", 2, time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), false)
+
+	defaultInput := DeletePatternWithReassignInput{
+		PatternID:  "pattern-1",
+		UserID:     "user-123",
+		ReassignTo: "pattern-2",
+		Today:      "2024-01-03",
+	}
+	// 削除するパターンと付け替え先のパターンの取得
+	expectFindPatterns := func(patternRepo *patternDomain.MockIPatternRepository, target *patternDomain.Pattern) []any {
+		return []any{
+			patternRepo.EXPECT().
+				FindPatternByPatternID(ctx, "pattern-1", "user-123").
+				Return(oldPattern, nil).
+				Times(1),
+			patternRepo.EXPECT().
+				FindPatternByPatternID(ctx, "pattern-2", "user-123").
+				Return(target, nil).
+				Times(1),
+		}
+	}
+
+	// トランザクションを開始して、付け替える未完了の復習物をロックする。ロックしてから復習物を読み込む
+	expectLock := func(itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager, lockErr error) []any {
+		return []any{
+			txManager.EXPECT().
+				RunInTransaction(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).
+				Times(1),
+			itemRepo.EXPECT().
+				LockUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+				Return(lockErr).
+				Times(1),
+		}
+	}
+	errLock := errors.New("lock failed")
+
+	tests := []struct {
+		name    string
+		input   DeletePatternWithReassignInput
+		setup   func(*patternDomain.MockIPatternRepository, *itemDomain.MockIItemRepository, *boxDomain.MockIBoxRepository, *transaction.MockITransactionManager, *itemDomain.MockIScheduler)
+		want    *DeletePatternWithReassignOutput
+		wantErr error
+	}{
+		{
+			name:  "正常系_ボックスと復習物を付け替えて未完了の復習日を組み直してから削除する",
+			input: defaultInput,
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, boxRepo *boxDomain.MockIBoxRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectFindPatterns(patternRepo, newPattern)
+				calls = append(calls, expectLock(itemRepo, txManager, nil)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						GetAllUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*itemDomain.Item{item1}, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(ctx, "pattern-2", "user-123").
+						Return(newSteps, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithAlgorithm("fixed").
+						Return(scheduler, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(ctx, "user-123").
+						Return([]int{}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetBlockedDatesByUserID(ctx, "user-123").
+						Return([]time.Time{}, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithExcludedWeekdays(gomock.Any()).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(scheduler).
+						Times(1),
+					itemRepo.EXPECT().
+						GetReviewDatesByItemID(ctx, "item-1", "user-123").
+						Return([]*itemDomain.Reviewdate{completedReviewdate1, inCompletedReviewdate2}, nil).
+						Times(1),
+					scheduler.EXPECT().
+						RescheduleForUpdatedSteps(newSteps, "user-123", nil, nil, "item-1", 1, learnedDate, today).
+						Return([]*itemDomain.Reviewdate{rescheduledReviewdate2}, nil).
+						Times(1),
+					boxRepo.EXPECT().
+						UpdatePatternIDByPatternID(ctx, "pattern-1", "user-123", "pattern-2", gomock.Any()).
+						Return(int64(1), nil).
+						Times(1),
+					itemRepo.EXPECT().
+						UpdateItemsPatternIDByPatternID(ctx, "pattern-1", "user-123", "pattern-2").
+						Return(int64(2), nil).
+						Times(1),
+					itemRepo.EXPECT().
+						DeleteInCompletedReviewDatesAfterStep(ctx, "item-1", "user-123", 1).
+						Return(nil).
+						Times(1),
+					itemRepo.EXPECT().
+						CreateReviewdates(ctx, []*itemDomain.Reviewdate{rescheduledReviewdate2}).
+						Return(int64(1), nil).
+						Times(1),
+					patternRepo.EXPECT().
+						DeletePattern(ctx, "pattern-1", "user-123").
+						Return(nil).
+						Times(1),
+				)
+				gomock.InOrder(calls...)
+			},
+			want: &DeletePatternWithReassignOutput{
+				ReassignedBoxCount:         1,
+				ReassignedItemCount:        2,
+				RescheduledItemCount:       1,
+				RescheduledReviewDateCount: 1,
+			},
+		},
+		{
+			name:  "正常系_未完了の復習物がない場合は組み直さずに付け替えて削除する",
+			input: defaultInput,
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, boxRepo *boxDomain.MockIBoxRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectFindPatterns(patternRepo, newPattern)
+				calls = append(calls, expectLock(itemRepo, txManager, nil)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						GetAllUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*itemDomain.Item{}, nil).
+						Times(1),
+					boxRepo.EXPECT().
+						UpdatePatternIDByPatternID(ctx, "pattern-1", "user-123", "pattern-2", gomock.Any()).
+						Return(int64(0), nil).
+						Times(1),
+					itemRepo.EXPECT().
+						UpdateItemsPatternIDByPatternID(ctx, "pattern-1", "user-123", "pattern-2").
+						Return(int64(0), nil).
+						Times(1),
+					patternRepo.EXPECT().
+						DeletePattern(ctx, "pattern-1", "user-123").
+						Return(nil).
+						Times(1),
+				)
+				gomock.InOrder(calls...)
+			},
+			want: &DeletePatternWithReassignOutput{},
+		},
+		{
+			name:  "異常系_復習物のロックに失敗した場合は復習物を読み込まずに付け替えない",
+			input: defaultInput,
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, boxRepo *boxDomain.MockIBoxRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectFindPatterns(patternRepo, newPattern)
+				calls = append(calls, expectLock(itemRepo, txManager, errLock)...)
+				gomock.InOrder(calls...)
+			},
+			wantErr: errLock,
+		},
+		{
+			name: "異常系_付け替え先に削除するパターンを指定",
+			input: DeletePatternWithReassignInput{
+				PatternID:  "pattern-1",
+				UserID:     "user-123",
+				ReassignTo: "pattern-1",
+				Today:      "2024-01-03",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, boxRepo *boxDomain.MockIBoxRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+			},
+			wantErr: patternDomain.ErrReassignToSamePattern,
+		},
+		{
+			name:  "異常系_未完了の復習物がある状態で適応型SM-2方式のパターンに付け替える",
+			input: defaultInput,
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, boxRepo *boxDomain.MockIBoxRepository, txManager *transaction.MockITransactionManager, scheduler *itemDomain.MockIScheduler) {
+				calls := expectFindPatterns(patternRepo, adaptivePattern)
+				calls = append(calls, expectLock(itemRepo, txManager, nil)...)
+				calls = append(calls,
+					itemRepo.EXPECT().
+						GetAllUnFinishedItemsByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*itemDomain.Item{item1}, nil).
+						Times(1),
+				)
+				gomock.InOrder(calls...)
+			},
+			wantErr: patternDomain.ErrAdaptivePatternReschedule,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			boxRepo := boxDomain.NewMockIBoxRepository(ctrl)
+			txManager := transaction.NewMockITransactionManager(ctrl)
+			scheduler := itemDomain.NewMockIScheduler(ctrl)
+
+			tt.setup(patternRepo, itemRepo, boxRepo, txManager, scheduler)
+
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, scheduler, userDomain.NewMockUserRepository(ctrl), boxRepo)
+			got, err := uc.DeletePatternWithReassign(ctx, tt.input)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("DeletePatternWithReassign() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("DeletePatternWithReassign() unexpected error = %v", err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DeletePatternWithReassign() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatternUsecase_GetPatternVersions(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	editedTime := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	pattern, _ := patternDomain.ReconstructPattern("pattern-1", "user-123", "元のパターン", "light", "fixed", false, []int{}, 0, fixedTime, editedTime)
+	v1Step1, _ := patternDomain.ReconstructPatternStep("v1-step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
+	v1Step2, _ := patternDomain.ReconstructPatternStep("v1-step-2", "user-123", "pattern-1", 2, 3, patternDomain.IntervalUnitDay)
+	v2Step1, _ := patternDomain.ReconstructPatternStep("v2-step-1", "user-123", "pattern-1", 1, 2, patternDomain.IntervalUnitWeek)
+	version1, _ := patternDomain.ReconstructPatternVersion("version-1", "user-123", "pattern-1", 1, "fixed", []*patternDomain.PatternStep{v1Step1, v1Step2}, fixedTime)
+	version2, _ := patternDomain.ReconstructPatternVersion("version-2", "user-123", "pattern-1", 2, "sm2", []*patternDomain.PatternStep{v2Step1}, editedTime)
+
+	tests := []struct {
+		name    string
+		setup   func(*patternDomain.MockIPatternRepository)
+		want    []*GetPatternVersionOutput
+		wantErr bool
+	}{
+		{
+			name: "正常系_最新の版を現在の版として返す",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository) {
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, "pattern-1", "user-123").
+						Return(pattern, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetPatternVersionsByPatternID(ctx, "pattern-1", "user-123").
+						Return([]*patternDomain.PatternVersion{version1, version2}, nil).
+						Times(1),
+				)
+			},
+			want: []*GetPatternVersionOutput{
+				{
+					PatternVersionID:    "version-1",
+					PatternID:           "pattern-1",
+					VersionNumber:       1,
+					SchedulingAlgorithm: "fixed",
+					IsCurrent:           false,
+					CreatedAt:           fixedTime,
+					Steps: []GetPatternVersionStepOutput{
+						{StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
+						{StepNumber: 2, IntervalDays: 3, IntervalUnit: "day"},
+					},
+				},
+				{
+					PatternVersionID:    "version-2",
+					PatternID:           "pattern-1",
+					VersionNumber:       2,
+					SchedulingAlgorithm: "sm2",
+					IsCurrent:           true,
+					CreatedAt:           editedTime,
+					Steps: []GetPatternVersionStepOutput{
+						{StepNumber: 1, IntervalDays: 2, IntervalUnit: "week"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系_パターンが存在しない",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository) {
+				patternRepo.EXPECT().
+					FindPatternByPatternID(ctx, "pattern-1", "user-123").
+					Return(nil, patternDomain.ErrPatternNotFound).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系_GetPatternVersionsByPatternIDでエラー",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository) {
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, "pattern-1", "user-123").
+						Return(pattern, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetPatternVersionsByPatternID(ctx, "pattern-1", "user-123").
+						Return(nil, errors.New("データベースエラー")).
+						Times(1),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			txManager := transaction.NewMockITransactionManager(ctrl)
+
+			tt.setup(patternRepo)
+
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
+			got, err := uc.GetPatternVersions(ctx, "pattern-1", "user-123")
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("GetPatternVersions() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("GetPatternVersions() unexpected error = %v", err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("GetPatternVersions() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatternUsecase_PreviewSchedule(t *testing.T) {
+	ctx := context.Background()
+	patternID := "pattern-1"
+	learnedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	pattern1, _ := patternDomain.ReconstructPattern(
+		patternID,
+		"user-123",
+		"パターン1",
+		"normal",
+		"expanding",
+		false,
+		[]int{},
+		0,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+	step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", patternID, 1, 1, patternDomain.IntervalUnitDay)
+	step2, _ := patternDomain.ReconstructPatternStep("step-2", "user-123", patternID, 2, 3, patternDomain.IntervalUnitDay)
+	steps := []*patternDomain.PatternStep{step1, step2}
+	loadBalancedPattern, _ := patternDomain.ReconstructPattern(
+		patternID,
+		"user-123",
+		"パターン1",
+		"normal",
+		"fixed",
+		true,
+		[]int{},
+		0,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+	// 復習物作成時と同じく、復習予定数の多い日を避けて負荷分散した復習日
+	dailyCounts := []*itemDomain.DailyScheduledCount{
+		{ScheduledDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), Count: 10},
+	}
+	// 1日あたりの復習数の上限に達している日を避けて繰り越した復習日
+	deferredReviewdate1, _ := itemDomain.NewReviewdate("rd-1", "user-123", nil, nil, "item-1", 1, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), false)
+	loadBalancedReviewdate1, _ := itemDomain.NewReviewdate("rd-1", "user-123", nil, nil, "item-1", 1, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC), false)
+
+	reviewdate1, _ := itemDomain.NewReviewdate("rd-1", "user-123", nil, nil, "item-1", 1, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), true)
+	reviewdate2, _ := itemDomain.NewReviewdate("rd-2", "user-123", nil, nil, "item-1", 2, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), false)
+	inCompletedReviewdate1, _ := itemDomain.NewReviewdate("rd-1", "user-123", nil, nil, "item-1", 1, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), false)
+	completedReviewdate2, _ := itemDomain.NewReviewdate("rd-2", "user-123", nil, nil, "item-1", 2, time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), true)
+	maintenanceReviewdate3, _ := itemDomain.NewReviewdate("rd-3", "user-123", nil, nil, "item-1", 3, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), false)
+
+	tests := []struct {
+		name    string
+		input   PreviewScheduleInput
+		setup   func(*patternDomain.MockIPatternRepository, *itemDomain.MockIItemRepository, *itemDomain.MockIScheduler)
+		want    *PreviewScheduleOutput
+		wantErr bool
+	}{
+		{
+			name: "正常系_保存済みパターンでのプレビュー",
+			input: PreviewScheduleInput{
+				PatternID:   &patternID,
+				UserID:      "user-123",
+				LearnedDate: "2024-01-01",
+				Today:       "2024-01-05",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) {
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, patternID, "user-123").
+						Return(pattern1, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(ctx, patternID, "user-123").
+						Return(steps, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithAlgorithm("expanding").
+						Return(scheduler, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(ctx, "user-123").
+						Return([]int{}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetBlockedDatesByUserID(ctx, "user-123").
+						Return([]time.Time{}, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithExcludedWeekdays(itemDomain.NewExcludedWeekdays([]int{}, []int{})).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						FormatWithOverdueMarkedInCompleted(steps, "user-123", nil, nil, gomock.Any(), learnedDate, today).
+						Return([]*itemDomain.Reviewdate{inCompletedReviewdate1}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetDailyReviewLimitByUserID(ctx, "user-123").
+						Return(0, nil).
+						Times(1),
+				)
+			},
+			want: &PreviewScheduleOutput{
+				SchedulingAlgorithm: "expanding",
+				IsFinished:          false,
+				ReviewDates: []PreviewReviewDateOutput{
+					{StepNumber: 1, ScheduledDate: "2024-01-05", IsCompleted: false},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系_負荷分散するパターンでは復習物作成時と同じく負荷分散した復習日をプレビューする",
+			input: PreviewScheduleInput{
+				PatternID:   &patternID,
+				UserID:      "user-123",
+				LearnedDate: "2024-01-01",
+				Today:       "2024-01-05",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) {
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, patternID, "user-123").
+						Return(loadBalancedPattern, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(ctx, patternID, "user-123").
+						Return(steps, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithAlgorithm("fixed").
+						Return(scheduler, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(ctx, "user-123").
+						Return([]int{}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetBlockedDatesByUserID(ctx, "user-123").
+						Return([]time.Time{}, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithExcludedWeekdays(itemDomain.NewExcludedWeekdays([]int{}, []int{})).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						FormatWithOverdueMarkedInCompleted(steps, "user-123", nil, nil, gomock.Any(), learnedDate, today).
+						Return([]*itemDomain.Reviewdate{inCompletedReviewdate1}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetDailyReviewLimitByUserID(ctx, "user-123").
+						Return(0, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						CountScheduledDatesGroupedByDateByUserID(ctx, "user-123", gomock.Any(), gomock.Any()).
+						Return(dailyCounts, nil).
+						Times(1),
+					scheduler.EXPECT().
+						LoadBalance([]*itemDomain.Reviewdate{inCompletedReviewdate1}, dailyCounts, learnedDate, today).
+						Return([]*itemDomain.Reviewdate{loadBalancedReviewdate1}, nil).
+						Times(1),
+				)
+			},
+			want: &PreviewScheduleOutput{
+				SchedulingAlgorithm: "fixed",
+				IsFinished:          false,
+				ReviewDates: []PreviewReviewDateOutput{
+					{StepNumber: 1, ScheduledDate: "2024-01-06", IsCompleted: false},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系_1日の復習数の上限があるユーザーでは復習物作成時と同じく上限を超える復習日を繰り越してプレビューする",
+			input: PreviewScheduleInput{
+				PatternID:   &patternID,
+				UserID:      "user-123",
+				LearnedDate: "2024-01-01",
+				Today:       "2024-01-05",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) {
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						FindPatternByPatternID(ctx, patternID, "user-123").
+						Return(pattern1, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetAllPatternStepsByPatternID(ctx, patternID, "user-123").
+						Return(steps, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithAlgorithm("expanding").
+						Return(scheduler, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(ctx, "user-123").
+						Return([]int{}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetBlockedDatesByUserID(ctx, "user-123").
+						Return([]time.Time{}, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithExcludedWeekdays(itemDomain.NewExcludedWeekdays([]int{}, []int{})).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						FormatWithOverdueMarkedInCompleted(steps, "user-123", nil, nil, gomock.Any(), learnedDate, today).
+						Return([]*itemDomain.Reviewdate{inCompletedReviewdate1}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetDailyReviewLimitByUserID(ctx, "user-123").
+						Return(10, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						CountScheduledDatesGroupedByDateByUserID(ctx, "user-123", gomock.Any(), gomock.Any()).
+						Return(dailyCounts, nil).
+						Times(1),
+					scheduler.EXPECT().
+						DeferOverflow([]*itemDomain.Reviewdate{inCompletedReviewdate1}, dailyCounts, 10, today).
+						Return([]*itemDomain.Reviewdate{deferredReviewdate1}, nil).
+						Times(1),
+				)
+			},
+			want: &PreviewScheduleOutput{
+				SchedulingAlgorithm: "expanding",
+				IsFinished:          false,
+				ReviewDates: []PreviewReviewDateOutput{
+					{StepNumber: 1, ScheduledDate: "2024-01-06", IsCompleted: false},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系_ステップ指定で期日超過分を完了扱いにするプレビュー",
+			input: PreviewScheduleInput{
+				UserID:                   "user-123",
+				Steps:                    []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 1}, {StepNumber: 2, IntervalDays: 4}},
+				LearnedDate:              "2024-01-01",
+				Today:                    "2024-01-05",
+				IsMarkOverdueAsCompleted: true,
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) {
+				gomock.InOrder(
+					scheduler.EXPECT().
+						WithAlgorithm("fixed").
+						Return(scheduler, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(ctx, "user-123").
+						Return([]int{}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetBlockedDatesByUserID(ctx, "user-123").
+						Return([]time.Time{}, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithExcludedWeekdays(itemDomain.NewExcludedWeekdays([]int{}, []int{})).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						FormatWithOverdueMarkedCompleted(gomock.Any(), "user-123", nil, nil, gomock.Any(), learnedDate, today).
+						Return([]*itemDomain.Reviewdate{reviewdate1, reviewdate2}, false, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetDailyReviewLimitByUserID(ctx, "user-123").
+						Return(0, nil).
+						Times(1),
+				)
+			},
+			want: &PreviewScheduleOutput{
+				SchedulingAlgorithm: "fixed",
+				IsFinished:          false,
+				ReviewDates: []PreviewReviewDateOutput{
+					{StepNumber: 1, ScheduledDate: "2024-01-02", IsCompleted: true},
+					{StepNumber: 2, ScheduledDate: "2024-01-05", IsCompleted: false},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系_繰り返しの間隔日数を指定すると全て完了扱いでも次の復習日が追加される",
+			input: PreviewScheduleInput{
+				UserID:                   "user-123",
+				MaintenanceIntervalDays:  7,
+				Steps:                    []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 1}, {StepNumber: 2, IntervalDays: 3}},
+				LearnedDate:              "2024-01-01",
+				Today:                    "2024-01-05",
+				IsMarkOverdueAsCompleted: true,
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) {
+				gomock.InOrder(
+					scheduler.EXPECT().
+						WithAlgorithm("fixed").
+						Return(scheduler, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetExcludedWeekdaysByUserID(ctx, "user-123").
+						Return([]int{}, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetBlockedDatesByUserID(ctx, "user-123").
+						Return([]time.Time{}, nil).
+						Times(1),
+					scheduler.EXPECT().
+						WithExcludedWeekdays(itemDomain.NewExcludedWeekdays(nil, []int{})).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						WithBlockedDates(gomock.Any()).
+						Return(scheduler).
+						Times(1),
+					scheduler.EXPECT().
+						FormatWithOverdueMarkedCompleted(gomock.Any(), "user-123", nil, nil, gomock.Any(), learnedDate, today).
+						Return([]*itemDomain.Reviewdate{reviewdate1, completedReviewdate2}, true, nil).
+						Times(1),
+					scheduler.EXPECT().
+						NextMaintenanceReviewdate(completedReviewdate2, 7, completedReviewdate2.ScheduledDate(), today).
+						Return(maintenanceReviewdate3, nil).
+						Times(1),
+					itemRepo.EXPECT().
+						GetDailyReviewLimitByUserID(ctx, "user-123").
+						Return(0, nil).
+						Times(1),
+				)
+			},
+			want: &PreviewScheduleOutput{
+				SchedulingAlgorithm: "fixed",
+				IsFinished:          false,
+				ReviewDates: []PreviewReviewDateOutput{
+					{StepNumber: 1, ScheduledDate: "2024-01-02", IsCompleted: true},
+					{StepNumber: 2, ScheduledDate: "2024-01-04", IsCompleted: true},
+					{StepNumber: 3, ScheduledDate: "2024-01-11", IsCompleted: false},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系_ステップ指定で間隔が昇順でない",
+			input: PreviewScheduleInput{
+				UserID:      "user-123",
+				Steps:       []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 3}, {StepNumber: 2, IntervalDays: 1}},
+				LearnedDate: "2024-01-01",
+				Today:       "2024-01-05",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系_SchedulingAlgorithmが無効な値",
+			input: PreviewScheduleInput{
+				UserID:              "user-123",
+				SchedulingAlgorithm: "invalid",
+				Steps:               []CreatePatternStepInput{{StepNumber: 1, IntervalDays: 1}},
+				LearnedDate:         "2024-01-01",
+				Today:               "2024-01-05",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) {
+				scheduler.EXPECT().
+					WithAlgorithm("invalid").
+					Return(nil, itemDomain.ErrUnknownSchedulingAlgorithm).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系_学習日の形式が不正",
+			input: PreviewScheduleInput{
+				PatternID:   &patternID,
+				UserID:      "user-123",
+				LearnedDate: "2024/01/01",
+				Today:       "2024-01-05",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系_FindPatternByPatternIDでエラー",
+			input: PreviewScheduleInput{
+				PatternID:   &patternID,
+				UserID:      "user-123",
+				LearnedDate: "2024-01-01",
+				Today:       "2024-01-05",
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, scheduler *itemDomain.MockIScheduler) {
+				patternRepo.EXPECT().
+					FindPatternByPatternID(ctx, patternID, "user-123").
+					Return(nil, errors.New("データベースエラー")).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			txManager := transaction.NewMockITransactionManager(ctrl)
+			scheduler := itemDomain.NewMockIScheduler(ctrl)
+
+			tt.setup(patternRepo, itemRepo, scheduler)
+
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, scheduler, userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
+			got, err := uc.PreviewSchedule(ctx, tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("PreviewSchedule() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("PreviewSchedule() unexpected error = %v", err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("PreviewSchedule() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatternUsecase_GetPatternPresets(t *testing.T) {
+	ctx := context.Background()
+
+	enUser, _ := userDomain.ReconstructUserForSettings("user-123", "encrypted", "Asia/Tokyo", "dark", "en", 0, []int{}, "slide_all", nil)
+
+	tests := []struct {
+		name     string
+		setup    func(*userDomain.MockUserRepository)
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name: "正常系_ユーザーの言語の名前で返す",
+			setup: func(userRepo *userDomain.MockUserRepository) {
+				userRepo.EXPECT().
+					GetSettingByID(ctx, "user-123").
+					Return(enUser, nil).
+					Times(1)
+			},
+			wantName: "Leitner system",
+			wantErr:  false,
+		},
+		{
+			name: "異常系_GetSettingByIDでエラー",
+			setup: func(userRepo *userDomain.MockUserRepository) {
+				userRepo.EXPECT().
+					GetSettingByID(ctx, "user-123").
+					Return(nil, errors.New("データベースエラー")).
+					Times(1)
+			},
 			wantErr: true,
 		},
 	}
@@ -653,106 +2685,246 @@ func TestPatternUsecase_UpdatePattern(t *testing.T) {
 			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
 			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
 			txManager := transaction.NewMockITransactionManager(ctrl)
+			userRepo := userDomain.NewMockUserRepository(ctrl)
 
-			tt.setup(patternRepo, itemRepo, txManager)
+			tt.setup(userRepo)
 
-			uc := NewPatternUsecase(patternRepo, itemRepo, txManager)
-			got, err := uc.UpdatePattern(ctx, tt.input)
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userRepo, boxDomain.NewMockIBoxRepository(ctrl))
+			got, err := uc.GetPatternPresets(ctx, "user-123")
 
 			if tt.wantErr {
 				if err == nil {
-					t.Errorf("UpdatePattern() error = %v, wantErr %v", err, tt.wantErr)
+					t.Errorf("GetPatternPresets() error = %v, wantErr %v", err, tt.wantErr)
 				}
 				return
 			}
 			if err != nil {
-				t.Errorf("UpdatePattern() unexpected error = %v", err)
+				t.Errorf("GetPatternPresets() unexpected error = %v", err)
 				return
 			}
-
-			if got != nil {
-				for i := range got.Steps {
-					got.Steps[i].PatternStepID = ""
-				}
-				if got.EditedAt.After(tt.want.EditedAt) {
-					got.EditedAt = editedTime
-				}
+			if len(got) != len(patternDomain.GetPatternPresets()) {
+				t.Fatalf("GetPatternPresets() len = %d, want %d", len(got), len(patternDomain.GetPatternPresets()))
 			}
-			if diff := cmp.Diff(tt.want, got); diff != "" {
-				t.Errorf("UpdatePattern() diff (-want +got):\n%s", diff)
+			want := &GetPatternPresetOutput{
+				Key:                 patternDomain.PatternPresetKeyLeitner,
+				Name:                tt.wantName,
+				TargetWeight:        "unset",
+				SchedulingAlgorithm: "fixed",
+				Steps: []GetPatternPresetStepOutput{
+					{StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
+					{StepNumber: 2, IntervalDays: 3, IntervalUnit: "day"},
+					{StepNumber: 3, IntervalDays: 7, IntervalUnit: "day"},
+					{StepNumber: 4, IntervalDays: 14, IntervalUnit: "day"},
+					{StepNumber: 5, IntervalDays: 30, IntervalUnit: "day"},
+				},
+			}
+			if diff := cmp.Diff(want, got[0]); diff != "" {
+				t.Errorf("GetPatternPresets() diff (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestPatternUsecase_DeletePattern(t *testing.T) {
+func TestPatternUsecase_CreatePatternFromPreset(t *testing.T) {
 	ctx := context.Background()
 
+	jaUser, _ := userDomain.ReconstructUserForSettings("user-123", "encrypted", "Asia/Tokyo", "dark", "ja", 0, []int{}, "slide_all", nil)
+
 	tests := []struct {
 		name      string
-		patternID string
-		userID    string
-		setup     func(*patternDomain.MockIPatternRepository, *itemDomain.MockIItemRepository, *transaction.MockITransactionManager)
+		presetKey string
+		setup     func(*patternDomain.MockIPatternRepository, *transaction.MockITransactionManager, *userDomain.MockUserRepository)
+		want      *CreatePatternOutput
 		wantErr   bool
+		wantErrIs error
 	}{
 		{
-			name:      "正常系_復習物関連なしでの削除成功",
-			patternID: "pattern-1",
-			userID:    "user-123",
-			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+			name:      "正常系_プリセットを複製してパターンを作成",
+			presetKey: patternDomain.PatternPresetKeyExamCram,
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, userRepo *userDomain.MockUserRepository) {
 				gomock.InOrder(
-					itemRepo.EXPECT().
-						IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
-						Return(false, nil).
+					userRepo.EXPECT().
+						GetSettingByID(ctx, "user-123").
+						Return(jaUser, nil).
+						Times(1),
+					txManager.EXPECT().
+						RunInTransaction(ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+							return fn(ctx)
+						}).
 						Times(1),
 					patternRepo.EXPECT().
-						DeletePattern(ctx, "pattern-1", "user-123").
+						CreatePattern(ctx, gomock.Any()).
+						Return(nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternSteps(ctx, gomock.Any()).
+						Return(int64(5), nil).
+						Times(1),
+					patternRepo.EXPECT().
+						CreatePatternVersion(ctx, gomock.Any()).
 						Return(nil).
 						Times(1),
 				)
 			},
+			want: &CreatePatternOutput{
+				UserID:              "user-123",
+				Name:                "試験直前の詰め込み",
+				TargetWeight:        "heavy",
+				SchedulingAlgorithm: "fixed",
+				ExcludedWeekdays:    []int{},
+				Steps: []CreatePatternStepOutput{
+					{UserID: "user-123", StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
+					{UserID: "user-123", StepNumber: 2, IntervalDays: 2, IntervalUnit: "day"},
+					{UserID: "user-123", StepNumber: 3, IntervalDays: 3, IntervalUnit: "day"},
+					{UserID: "user-123", StepNumber: 4, IntervalDays: 5, IntervalUnit: "day"},
+					{UserID: "user-123", StepNumber: 5, IntervalDays: 7, IntervalUnit: "day"},
+				},
+			},
 			wantErr: false,
 		},
 		{
-			name:      "異常系_復習物関連がある場合",
-			patternID: "pattern-1",
-			userID:    "user-123",
-			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
-				itemRepo.EXPECT().
-					IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
-					Return(true, nil).
-					Times(1)
+			name:      "異常系_存在しないプリセット",
+			presetKey: "unknown",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, userRepo *userDomain.MockUserRepository) {
 			},
-			wantErr: true,
+			wantErr:   true,
+			wantErrIs: patternDomain.ErrPatternPresetNotFound,
 		},
 		{
-			name:      "異常系_IsPatternRelatedToItemByPatternIDでエラー",
-			patternID: "pattern-1",
-			userID:    "user-123",
-			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
-				itemRepo.EXPECT().
-					IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
-					Return(false, errors.New("データベースエラー")).
+			name:      "異常系_GetSettingByIDでエラー",
+			presetKey: patternDomain.PatternPresetKeyLeitner,
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, userRepo *userDomain.MockUserRepository) {
+				userRepo.EXPECT().
+					GetSettingByID(ctx, "user-123").
+					Return(nil, errors.New("データベースエラー")).
 					Times(1)
 			},
 			wantErr: true,
 		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			txManager := transaction.NewMockITransactionManager(ctrl)
+			userRepo := userDomain.NewMockUserRepository(ctrl)
+
+			tt.setup(patternRepo, txManager, userRepo)
+
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userRepo, boxDomain.NewMockIBoxRepository(ctrl))
+			got, err := uc.CreatePatternFromPreset(ctx, "user-123", tt.presetKey)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("CreatePatternFromPreset() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("CreatePatternFromPreset() error = %v, wantErrIs %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("CreatePatternFromPreset() unexpected error = %v", err)
+				return
+			}
+
+			// IDと日時は作成時に採番されるので比較しない
+			got.ID = ""
+			got.RegisteredAt = time.Time{}
+			got.EditedAt = time.Time{}
+			for i := range got.Steps {
+				got.Steps[i].PatternStepID = ""
+				got.Steps[i].PatternID = ""
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CreatePatternFromPreset() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatternUsecase_ExportPatterns(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	pattern1, _ := patternDomain.ReconstructPattern("pattern-1", "user-123", "パターン1", "light", "fixed", true, []int{0}, 30, fixedTime, fixedTime)
+	step1, _ := patternDomain.ReconstructPatternStep("step-1", "user-123", "pattern-1", 1, 1, patternDomain.IntervalUnitDay)
+	step2, _ := patternDomain.ReconstructPatternStep("step-2", "user-123", "pattern-1", 2, 1, patternDomain.IntervalUnitWeek)
+
+	tests := []struct {
+		name    string
+		setup   func(*patternDomain.MockIPatternRepository)
+		want    *ExportPatternsOutput
+		wantErr bool
+	}{
+		{
+			name: "正常系_IDと日時を含めずにエクスポート",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository) {
+				gomock.InOrder(
+					patternRepo.EXPECT().
+						GetAllPatternsByUserID(ctx, "user-123").
+						Return([]*patternDomain.Pattern{pattern1}, nil).
+						Times(1),
+					patternRepo.EXPECT().
+						GetAllPatternStepsByUserID(ctx, "user-123").
+						Return([]*patternDomain.PatternStep{step1, step2}, nil).
+						Times(1),
+				)
+			},
+			want: &ExportPatternsOutput{
+				FormatVersion: PatternExportFormatVersion,
+				Patterns: []PatternExport{
+					{
+						Name:                    "パターン1",
+						TargetWeight:            "light",
+						SchedulingAlgorithm:     "fixed",
+						IsLoadBalanced:          true,
+						ExcludedWeekdays:        []int{0},
+						MaintenanceIntervalDays: 30,
+						Steps: []PatternExportStep{
+							{StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
+							{StepNumber: 2, IntervalDays: 1, IntervalUnit: "week"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
-			name:      "異常系_DeletePatternでエラー",
-			patternID: "pattern-1",
-			userID:    "user-123",
-			setup: func(patternRepo *patternDomain.MockIPatternRepository, itemRepo *itemDomain.MockIItemRepository, txManager *transaction.MockITransactionManager) {
+			name: "正常系_パターンが存在しない場合は空の配列",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository) {
 				gomock.InOrder(
-					itemRepo.EXPECT().
-						IsPatternRelatedToItemByPatternID(ctx, "pattern-1", "user-123").
-						Return(false, nil).
+					patternRepo.EXPECT().
+						GetAllPatternsByUserID(ctx, "user-123").
+						Return([]*patternDomain.Pattern{}, nil).
 						Times(1),
 					patternRepo.EXPECT().
-						DeletePattern(ctx, "pattern-1", "user-123").
-						Return(errors.New("削除エラー")).
+						GetAllPatternStepsByUserID(ctx, "user-123").
+						Return([]*patternDomain.PatternStep{}, nil).
 						Times(1),
 				)
 			},
+			want: &ExportPatternsOutput{
+				FormatVersion: PatternExportFormatVersion,
+				Patterns:      []PatternExport{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系_GetAllPatternsByUserIDでエラー",
+			setup: func(patternRepo *patternDomain.MockIPatternRepository) {
+				patternRepo.EXPECT().
+					GetAllPatternsByUserID(ctx, "user-123").
+					Return(nil, errors.New("データベースエラー")).
+					Times(1)
+			},
 			wantErr: true,
 		},
 	}
@@ -768,19 +2940,186 @@ func TestPatternUsecase_DeletePattern(t *testing.T) {
 			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
 			txManager := transaction.NewMockITransactionManager(ctrl)
 
-			tt.setup(patternRepo, itemRepo, txManager)
+			tt.setup(patternRepo)
 
-			uc := NewPatternUsecase(patternRepo, itemRepo, txManager)
-			err := uc.DeletePattern(ctx, tt.patternID, tt.userID)
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
+			got, err := uc.ExportPatterns(ctx, "user-123")
 
 			if tt.wantErr {
 				if err == nil {
-					t.Errorf("DeletePattern() error = %v, wantErr %v", err, tt.wantErr)
+					t.Errorf("ExportPatterns() error = %v, wantErr %v", err, tt.wantErr)
 				}
 				return
 			}
 			if err != nil {
-				t.Errorf("DeletePattern() unexpected error = %v", err)
+				t.Errorf("ExportPatterns() unexpected error = %v", err)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ExportPatterns() diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPatternUsecase_ImportPatterns(t *testing.T) {
+	ctx := context.Background()
+	fixedTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	existingPattern, _ := patternDomain.ReconstructPattern("pattern-1", "user-123", "既存のパターン", "light", "fixed", false, []int{}, 0, fixedTime, fixedTime)
+	validSteps := []PatternExportStep{
+		{StepNumber: 1, IntervalDays: 1, IntervalUnit: "day"},
+		{StepNumber: 2, IntervalDays: 3, IntervalUnit: "day"},
+	}
+	// 作成する1件分のトランザクション内の呼び出し
+	expectCreate := func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager, times int) {
+		txManager.EXPECT().
+			RunInTransaction(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(times)
+		patternRepo.EXPECT().CreatePattern(ctx, gomock.Any()).Return(nil).Times(times)
+		patternRepo.EXPECT().CreatePatternSteps(ctx, gomock.Any()).Return(int64(2), nil).Times(times)
+		patternRepo.EXPECT().CreatePatternVersion(ctx, gomock.Any()).Return(nil).Times(times)
+	}
+
+	tests := []struct {
+		name      string
+		input     ImportPatternsInput
+		setup     func(*patternDomain.MockIPatternRepository, *transaction.MockITransactionManager)
+		want      *ImportPatternsOutput
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name: "正常系_重複をスキップし、不正なパターンは失敗として続ける",
+			input: ImportPatternsInput{
+				UserID:         "user-123",
+				FormatVersion:  1,
+				SkipDuplicates: true,
+				Patterns: []PatternExport{
+					{Name: "既存のパターン", TargetWeight: "light", Steps: validSteps},
+					{Name: "新しいパターン", TargetWeight: "normal", Steps: validSteps},
+					{Name: "重みが不正", TargetWeight: "invalid", Steps: validSteps},
+					{Name: "新しいパターン", TargetWeight: "heavy", Steps: validSteps},
+				},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager) {
+				patternRepo.EXPECT().
+					GetAllPatternsByUserID(ctx, "user-123").
+					Return([]*patternDomain.Pattern{existingPattern}, nil).
+					Times(1)
+				expectCreate(patternRepo, txManager, 1)
+			},
+			want: &ImportPatternsOutput{
+				CreatedCount: 1,
+				SkippedCount: 2,
+				FailedCount:  1,
+				Results: []ImportPatternResultOutput{
+					{Index: 0, Name: "既存のパターン", Status: ImportPatternStatusSkipped},
+					{Index: 1, Name: "新しいパターン", Status: ImportPatternStatusCreated},
+					{Index: 2, Name: "重みが不正", Status: ImportPatternStatusFailed, Error: "重みの値が不正です"},
+					{Index: 3, Name: "新しいパターン", Status: ImportPatternStatusSkipped},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系_重複をスキップしない場合は同じ名前でも作成",
+			input: ImportPatternsInput{
+				UserID:        "user-123",
+				FormatVersion: 1,
+				Patterns: []PatternExport{
+					{Name: "既存のパターン", TargetWeight: "light", Steps: validSteps},
+					{Name: "ステップが降順", TargetWeight: "light", Steps: []PatternExportStep{
+						{StepNumber: 1, IntervalDays: 3, IntervalUnit: "day"},
+						{StepNumber: 2, IntervalDays: 1, IntervalUnit: "day"},
+					}},
+				},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager) {
+				expectCreate(patternRepo, txManager, 1)
+			},
+			want: &ImportPatternsOutput{
+				CreatedCount: 1,
+				FailedCount:  1,
+				Results: []ImportPatternResultOutput{
+					{Index: 0, Name: "既存のパターン", Status: ImportPatternStatusCreated},
+					{Index: 1, Name: "ステップが降順", Status: ImportPatternStatusFailed, Error: "復習日間隔数は昇順で指定してください"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系_対応していない形式のバージョン",
+			input: ImportPatternsInput{
+				UserID:        "user-123",
+				FormatVersion: 2,
+				Patterns:      []PatternExport{{Name: "パターン", TargetWeight: "light", Steps: validSteps}},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager) {
+			},
+			wantErr:   true,
+			wantErrIs: patternDomain.ErrUnsupportedPatternFormatVersion,
+		},
+		{
+			name: "異常系_GetAllPatternsByUserIDでエラー",
+			input: ImportPatternsInput{
+				UserID:         "user-123",
+				FormatVersion:  1,
+				SkipDuplicates: true,
+				Patterns:       []PatternExport{{Name: "パターン", TargetWeight: "light", Steps: validSteps}},
+			},
+			setup: func(patternRepo *patternDomain.MockIPatternRepository, txManager *transaction.MockITransactionManager) {
+				patternRepo.EXPECT().
+					GetAllPatternsByUserID(ctx, "user-123").
+					Return(nil, errors.New("データベースエラー")).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			patternRepo := patternDomain.NewMockIPatternRepository(ctrl)
+			itemRepo := itemDomain.NewMockIItemRepository(ctrl)
+			txManager := transaction.NewMockITransactionManager(ctrl)
+
+			tt.setup(patternRepo, txManager)
+
+			uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
+			got, err := uc.ImportPatterns(ctx, tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ImportPatterns() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("ImportPatterns() error = %v, wantErrIs %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ImportPatterns() unexpected error = %v", err)
+				return
+			}
+
+			// 作成したパターンのIDは作成時に採番されるので比較しない
+			for i := range got.Results {
+				if got.Results[i].Status == ImportPatternStatusCreated && got.Results[i].PatternID == "" {
+					t.Errorf("ImportPatterns() Results[%d].PatternID is empty", i)
+				}
+				got.Results[i].PatternID = ""
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ImportPatterns() diff (-want +got):\n%s", diff)
 			}
 		})
 	}
@@ -795,7 +3134,7 @@ func TestNewPatternUsecase(t *testing.T) {
 	itemRepo := itemDomain.NewMockIItemRepository(ctrl)
 	txManager := transaction.NewMockITransactionManager(ctrl)
 
-	uc := NewPatternUsecase(patternRepo, itemRepo, txManager)
+	uc := NewPatternUsecase(patternRepo, itemRepo, txManager, itemDomain.NewMockIScheduler(ctrl), userDomain.NewMockUserRepository(ctrl), boxDomain.NewMockIBoxRepository(ctrl))
 	if uc == nil {
 		t.Error("NewPatternUsecase() returned nil")
 	}
@@ -11,6 +11,12 @@ type IUserUsecase interface {
 	VerifyEmail(ctx context.Context, input VerifyEmailInput) (*LoginUserOutput, error)
 	RequestPasswordReset(ctx context.Context, email string) error
 	ResetPassword(ctx context.Context, email, code, newPassword string) error
+	CreatePause(ctx context.Context, input CreatePauseInput) (*CreatePauseOutput, error)
+	CreateBlockedDates(ctx context.Context, input CreateBlockedDatesInput) (*CreateBlockedDatesOutput, error)
+	ImportBlockedDatesFromICS(ctx context.Context, input ImportBlockedDatesInput) (*CreateBlockedDatesOutput, error)
+	GetBlockedDates(ctx context.Context, userID string) ([]*BlockedDateOutput, error)
+	UpdateBlockedDate(ctx context.Context, input UpdateBlockedDateInput) (*BlockedDateOutput, error)
+	DeleteBlockedDate(ctx context.Context, id string, userID string) error
 }
 
 type iEmailSender interface {
@@ -34,6 +34,65 @@ func (m *MockIUserUsecase) EXPECT() *MockIUserUsecaseMockRecorder {
 	return m.recorder
 }
 
+// CreateBlockedDates mocks base method.
+func (m *MockIUserUsecase) CreateBlockedDates(ctx context.Context, input CreateBlockedDatesInput) (*CreateBlockedDatesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBlockedDates", ctx, input)
+	ret0, _ := ret[0].(*CreateBlockedDatesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBlockedDates indicates an expected call of CreateBlockedDates.
+func (mr *MockIUserUsecaseMockRecorder) CreateBlockedDates(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockedDates", reflect.TypeOf((*MockIUserUsecase)(nil).CreateBlockedDates), ctx, input)
+}
+
+// DeleteBlockedDate mocks base method.
+func (m *MockIUserUsecase) DeleteBlockedDate(ctx context.Context, id, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlockedDate", ctx, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlockedDate indicates an expected call of DeleteBlockedDate.
+func (mr *MockIUserUsecaseMockRecorder) DeleteBlockedDate(ctx, id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlockedDate", reflect.TypeOf((*MockIUserUsecase)(nil).DeleteBlockedDate), ctx, id, userID)
+}
+
+// CreatePause mocks base method.
+func (m *MockIUserUsecase) CreatePause(ctx context.Context, input CreatePauseInput) (*CreatePauseOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePause", ctx, input)
+	ret0, _ := ret[0].(*CreatePauseOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePause indicates an expected call of CreatePause.
+func (mr *MockIUserUsecaseMockRecorder) CreatePause(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePause", reflect.TypeOf((*MockIUserUsecase)(nil).CreatePause), ctx, input)
+}
+
+// GetBlockedDates mocks base method.
+func (m *MockIUserUsecase) GetBlockedDates(ctx context.Context, userID string) ([]*BlockedDateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockedDates", ctx, userID)
+	ret0, _ := ret[0].([]*BlockedDateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockedDates indicates an expected call of GetBlockedDates.
+func (mr *MockIUserUsecaseMockRecorder) GetBlockedDates(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockedDates", reflect.TypeOf((*MockIUserUsecase)(nil).GetBlockedDates), ctx, userID)
+}
+
 // GetUserSetting mocks base method.
 func (m *MockIUserUsecase) GetUserSetting(ctx context.Context, userID string) (*GetUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -49,6 +108,21 @@ func (mr *MockIUserUsecaseMockRecorder) GetUserSetting(ctx, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSetting", reflect.TypeOf((*MockIUserUsecase)(nil).GetUserSetting), ctx, userID)
 }
 
+// ImportBlockedDatesFromICS mocks base method.
+func (m *MockIUserUsecase) ImportBlockedDatesFromICS(ctx context.Context, input ImportBlockedDatesInput) (*CreateBlockedDatesOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBlockedDatesFromICS", ctx, input)
+	ret0, _ := ret[0].(*CreateBlockedDatesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBlockedDatesFromICS indicates an expected call of ImportBlockedDatesFromICS.
+func (mr *MockIUserUsecaseMockRecorder) ImportBlockedDatesFromICS(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBlockedDatesFromICS", reflect.TypeOf((*MockIUserUsecase)(nil).ImportBlockedDatesFromICS), ctx, input)
+}
+
 // LogIn mocks base method.
 func (m *MockIUserUsecase) LogIn(ctx context.Context, user LoginUserInput) (*LoginUserOutput, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +181,21 @@ func (mr *MockIUserUsecaseMockRecorder) SignUp(ctx, user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockIUserUsecase)(nil).SignUp), ctx, user)
 }
 
+// UpdateBlockedDate mocks base method.
+func (m *MockIUserUsecase) UpdateBlockedDate(ctx context.Context, input UpdateBlockedDateInput) (*BlockedDateOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBlockedDate", ctx, input)
+	ret0, _ := ret[0].(*BlockedDateOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBlockedDate indicates an expected call of UpdateBlockedDate.
+func (mr *MockIUserUsecaseMockRecorder) UpdateBlockedDate(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBlockedDate", reflect.TypeOf((*MockIUserUsecase)(nil).UpdateBlockedDate), ctx, input)
+}
+
 // UpdatePassword mocks base method.
 func (m *MockIUserUsecase) UpdatePassword(ctx context.Context, userID, password string) error {
 	m.ctrl.T.Helper()
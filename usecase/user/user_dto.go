@@ -1,5 +1,7 @@
 package user
 
+import "io"
+
 type CreateUserInput struct {
 	Email      string
 	Password   string
@@ -25,10 +27,13 @@ type LoginUserOutput struct {
 }
 
 type GetUserOutput struct {
-	Email      string
-	Timezone   string
-	ThemeColor string
-	Language   string
+	Email            string
+	Timezone         string
+	ThemeColor       string
+	Language         string
+	DailyReviewLimit int
+	ExcludedWeekdays []int
+	OverduePolicy    string
 }
 
 type UpdateUserInput struct {
@@ -37,16 +42,72 @@ type UpdateUserInput struct {
 	Timezone   string
 	ThemeColor string
 	Language   string
+	// 0の場合は上限なし
+	DailyReviewLimit int
+	// 0が日曜日、6が土曜日。nilの場合は除外しない
+	ExcludedWeekdays []int
+	// 空文字の場合はslide_all
+	OverduePolicy string
 }
 
 type UpdateUserOutput struct {
-	Email      string
-	Timezone   string
-	ThemeColor string
-	Language   string
+	Email            string
+	Timezone         string
+	ThemeColor       string
+	Language         string
+	DailyReviewLimit int
+	ExcludedWeekdays []int
+	OverduePolicy    string
 }
 
 type VerifyEmailInput struct {
 	Email string
 	Code  string
 }
+
+// 日付はYYYY-MM-DD形式（開始日と終了日を含む）
+type CreatePauseInput struct {
+	UserID    string
+	StartDate string
+	EndDate   string
+}
+
+type CreatePauseOutput struct {
+	ID        string
+	StartDate string
+	EndDate   string
+}
+
+type BlockedDateInput struct {
+	Date string
+	Name string
+}
+
+type CreateBlockedDatesInput struct {
+	UserID string
+	Dates  []BlockedDateInput
+}
+
+type ImportBlockedDatesInput struct {
+	UserID string
+	File   io.Reader
+}
+
+type CreateBlockedDatesOutput struct {
+	BlockedDates []*BlockedDateOutput
+	// 既に登録されている日付や今日より前の日付のため、登録しなかった件数
+	SkippedCount int
+}
+
+type UpdateBlockedDateInput struct {
+	ID     string
+	UserID string
+	Date   string
+	Name   string
+}
+
+type BlockedDateOutput struct {
+	ID   string
+	Date string
+	Name string
+}
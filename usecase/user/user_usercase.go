@@ -14,6 +14,8 @@ import (
 type userUsecase struct {
 	userRepo              userDomain.UserRepository
 	emailVerificationRepo userDomain.EmailVerificationRepository
+	pauseRepo             userDomain.PauseRepository
+	blockedDateRepo       userDomain.BlockedDateRepository
 	transactionManager    transaction.ITransactionManager
 	cryptoService         *userDomain.CryptoService
 	hasher                userDomain.IHasher
@@ -24,6 +26,8 @@ type userUsecase struct {
 func NewUserUsecase(
 	userRepo userDomain.UserRepository,
 	emailVerificationRepo userDomain.EmailVerificationRepository,
+	pauseRepo userDomain.PauseRepository,
+	blockedDateRepo userDomain.BlockedDateRepository,
 	transactionManager transaction.ITransactionManager,
 	cryptoService *userDomain.CryptoService,
 	hasher userDomain.IHasher,
@@ -34,6 +38,8 @@ func NewUserUsecase(
 	return &userUsecase{
 		userRepo:              userRepo,
 		emailVerificationRepo: emailVerificationRepo,
+		pauseRepo:             pauseRepo,
+		blockedDateRepo:       blockedDateRepo,
 		transactionManager:    transactionManager,
 		cryptoService:         cryptoService,
 		hasher:                hasher,
@@ -249,10 +255,13 @@ func (uu *userUsecase) GetUserSetting(ctx context.Context, userID string) (*GetU
 	}
 
 	resUser := &GetUserOutput{
-		Email:      email,
-		Timezone:   user.Timezone(),
-		ThemeColor: user.ThemeColor(),
-		Language:   user.Language(),
+		Email:            email,
+		Timezone:         user.Timezone(),
+		ThemeColor:       user.ThemeColor(),
+		Language:         user.Language(),
+		DailyReviewLimit: user.DailyReviewLimit(),
+		ExcludedWeekdays: user.ExcludedWeekdays(),
+		OverduePolicy:    user.OverduePolicy(),
 	}
 	return resUser, nil
 }
@@ -265,7 +274,17 @@ func (uu *userUsecase) UpdateSetting(ctx context.Context, user UpdateUserInput)
 
 	searchKey := uu.hasher.GenerateSearchKey(user.Email)
 
-	err = targetUser.UpdateSetting(user.Email, user.Timezone, user.ThemeColor, user.Language, uu.cryptoService, searchKey)
+	excludedWeekdays := user.ExcludedWeekdays
+	if excludedWeekdays == nil {
+		excludedWeekdays = []int{}
+	}
+
+	overduePolicy := user.OverduePolicy
+	if overduePolicy == "" {
+		overduePolicy = userDomain.OverduePolicySlideAll
+	}
+
+	err = targetUser.UpdateSetting(user.Email, user.Timezone, user.ThemeColor, user.Language, user.DailyReviewLimit, excludedWeekdays, overduePolicy, uu.cryptoService, searchKey)
 	if err != nil {
 		return nil, err
 	}
@@ -281,10 +300,13 @@ func (uu *userUsecase) UpdateSetting(ctx context.Context, user UpdateUserInput)
 	}
 
 	resUser := &UpdateUserOutput{
-		Email:      decryptedEmail,
-		Timezone:   targetUser.Timezone(),
-		ThemeColor: targetUser.ThemeColor(),
-		Language:   targetUser.Language(),
+		Email:            decryptedEmail,
+		Timezone:         targetUser.Timezone(),
+		ThemeColor:       targetUser.ThemeColor(),
+		Language:         targetUser.Language(),
+		DailyReviewLimit: targetUser.DailyReviewLimit(),
+		ExcludedWeekdays: targetUser.ExcludedWeekdays(),
+		OverduePolicy:    targetUser.OverduePolicy(),
 	}
 
 	return resUser, nil
@@ -392,3 +414,253 @@ func (uu *userUsecase) ResetPassword(ctx context.Context, email, code, newPasswo
 
 	return nil
 }
+
+// 休止期間の登録
+// 開始日が今日以降かどうかは、ユーザー設定のタイムゾーンでの今日を基準に判定する
+func (uu *userUsecase) CreatePause(ctx context.Context, input CreatePauseInput) (*CreatePauseOutput, error) {
+	startDate, err := time.Parse("2006-01-02", input.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := time.Parse("2006-01-02", input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedToday, err := uu.getParsedToday(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	pause, err := userDomain.NewPause(uuid.NewString(), input.UserID, startDate, endDate, parsedToday)
+	if err != nil {
+		return nil, err
+	}
+
+	isOverlapped, err := uu.pauseRepo.HasOverlappingPause(ctx, input.UserID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if isOverlapped {
+		return nil, userDomain.ErrPauseOverlapped
+	}
+
+	if err := uu.pauseRepo.Create(ctx, pause); err != nil {
+		return nil, err
+	}
+
+	return &CreatePauseOutput{
+		ID:        pause.ID(),
+		StartDate: pause.StartDate().Format("2006-01-02"),
+		EndDate:   pause.EndDate().Format("2006-01-02"),
+	}, nil
+}
+
+// 復習日を置かない日付の登録
+// 日付は今日以降で指定する。既に登録されている日付は登録しない
+func (uu *userUsecase) CreateBlockedDates(ctx context.Context, input CreateBlockedDatesInput) (*CreateBlockedDatesOutput, error) {
+	if len(input.Dates) == 0 {
+		return nil, userDomain.ErrBlockedDatesEmpty
+	}
+	if len(input.Dates) > userDomain.MaxBlockedDatesPerRequest {
+		return nil, userDomain.ErrTooManyBlockedDates
+	}
+
+	parsedToday, err := uu.getParsedToday(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*userDomain.BlockedDate, 0, len(input.Dates))
+	for _, d := range input.Dates {
+		date, err := time.Parse("2006-01-02", d.Date)
+		if err != nil {
+			return nil, err
+		}
+		blockedDate, err := userDomain.NewBlockedDate(uuid.NewString(), input.UserID, date, d.Name, parsedToday)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, blockedDate)
+	}
+
+	return uu.createBlockedDates(ctx, input.UserID, candidates, 0)
+}
+
+// iCalendarファイルから復習日を置かない日付を登録する
+// 祝日カレンダーなどは過去の日付も含むため、今日より前の日付と既に登録されている日付は登録せずに件数だけ返す
+func (uu *userUsecase) ImportBlockedDatesFromICS(ctx context.Context, input ImportBlockedDatesInput) (*CreateBlockedDatesOutput, error) {
+	icsDates, err := userDomain.ParseICS(input.File)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedToday, err := uu.getParsedToday(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*userDomain.BlockedDate, 0, len(icsDates))
+	skippedCount := 0
+	for _, d := range icsDates {
+		if d.Date.Before(parsedToday) {
+			skippedCount++
+			continue
+		}
+		// 名前が長すぎる予定は、日付だけ登録できるように名前を切り詰める
+		name := []rune(d.Name)
+		if len(name) > userDomain.MaxBlockedDateNameLength {
+			name = name[:userDomain.MaxBlockedDateNameLength]
+		}
+		blockedDate, err := userDomain.NewBlockedDate(uuid.NewString(), input.UserID, d.Date, string(name), parsedToday)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, blockedDate)
+	}
+	if len(candidates) > userDomain.MaxBlockedDatesPerRequest {
+		return nil, userDomain.ErrTooManyBlockedDates
+	}
+
+	return uu.createBlockedDates(ctx, input.UserID, candidates, skippedCount)
+}
+
+// 既に登録されている日付を除いて、復習日を置かない日付をまとめて登録する
+func (uu *userUsecase) createBlockedDates(ctx context.Context, userID string, candidates []*userDomain.BlockedDate, skippedCount int) (*CreateBlockedDatesOutput, error) {
+	output := &CreateBlockedDatesOutput{
+		BlockedDates: make([]*BlockedDateOutput, 0, len(candidates)),
+		SkippedCount: skippedCount,
+	}
+
+	var createdDates []time.Time
+	err := uu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		existing, err := uu.blockedDateRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		registered := make(map[string]struct{}, len(existing)+len(candidates))
+		for _, b := range existing {
+			registered[b.Date().Format("2006-01-02")] = struct{}{}
+		}
+
+		for _, b := range candidates {
+			key := b.Date().Format("2006-01-02")
+			if _, ok := registered[key]; ok {
+				output.SkippedCount++
+				continue
+			}
+			if err := uu.blockedDateRepo.Create(ctx, b); err != nil {
+				return err
+			}
+			registered[key] = struct{}{}
+			output.BlockedDates = append(output.BlockedDates, toBlockedDateOutput(b))
+			createdDates = append(createdDates, b.Date())
+		}
+		return uu.moveReviewDatesOffBlockedDates(ctx, userID, createdDates)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// 登録済みの復習日は復習日を置かない日付を登録しても残るため、登録と同じトランザクションで次の復習日を置ける日にずらす
+func (uu *userUsecase) moveReviewDatesOffBlockedDates(ctx context.Context, userID string, dates []time.Time) error {
+	if len(dates) == 0 {
+		return nil
+	}
+	rows, err := uu.blockedDateRepo.MoveReviewDatesOffBlockedDates(ctx, userID, dates)
+	if err != nil {
+		return err
+	}
+	if rows > 0 {
+		slog.Info("復習日を置かない日付にあった復習日をずらしました。", "user_id", userID, "件数", rows)
+	}
+	return nil
+}
+
+func (uu *userUsecase) GetBlockedDates(ctx context.Context, userID string) ([]*BlockedDateOutput, error) {
+	blockedDates, err := uu.blockedDateRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*BlockedDateOutput, len(blockedDates))
+	for i, b := range blockedDates {
+		res[i] = toBlockedDateOutput(b)
+	}
+	return res, nil
+}
+
+func (uu *userUsecase) UpdateBlockedDate(ctx context.Context, input UpdateBlockedDateInput) (*BlockedDateOutput, error) {
+	date, err := time.Parse("2006-01-02", input.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedToday, err := uu.getParsedToday(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockedDate *userDomain.BlockedDate
+	err = uu.transactionManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		blockedDate, err = uu.blockedDateRepo.GetByID(ctx, input.ID, input.UserID)
+		if err != nil {
+			return err
+		}
+
+		existing, err := uu.blockedDateRepo.GetByUserID(ctx, input.UserID)
+		if err != nil {
+			return err
+		}
+		for _, b := range existing {
+			if b.ID() != input.ID && b.Date().Equal(date) {
+				return userDomain.ErrBlockedDateDuplicated
+			}
+		}
+
+		if err := blockedDate.Update(date, input.Name, parsedToday); err != nil {
+			return err
+		}
+		if err := uu.blockedDateRepo.Update(ctx, blockedDate); err != nil {
+			return err
+		}
+		return uu.moveReviewDatesOffBlockedDates(ctx, input.UserID, []time.Time{blockedDate.Date()})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toBlockedDateOutput(blockedDate), nil
+}
+
+func (uu *userUsecase) DeleteBlockedDate(ctx context.Context, id string, userID string) error {
+	if _, err := uu.blockedDateRepo.GetByID(ctx, id, userID); err != nil {
+		return err
+	}
+	return uu.blockedDateRepo.Delete(ctx, id, userID)
+}
+
+// ユーザー設定のタイムゾーンでの今日の日付を返す
+func (uu *userUsecase) getParsedToday(ctx context.Context, userID string) (time.Time, error) {
+	user, err := uu.userRepo.GetSettingByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := time.LoadLocation(user.Timezone())
+	if err != nil {
+		return time.Time{}, err
+	}
+	now := time.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+func toBlockedDateOutput(b *userDomain.BlockedDate) *BlockedDateOutput {
+	return &BlockedDateOutput{
+		ID:   b.ID(),
+		Date: b.Date().Format("2006-01-02"),
+		Name: b.Name(),
+	}
+}
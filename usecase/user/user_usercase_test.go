@@ -3,9 +3,12 @@ package user
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
 
@@ -200,6 +203,8 @@ func TestUserUsecase_SignUp_StrictOrderAndCount(t *testing.T) {
 
 			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
 			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
 			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
 			mockHasher := userDomain.NewMockIHasher(ctrl)
 			mockEmailSender := NewMockiEmailSender(ctrl)
@@ -209,6 +214,8 @@ func TestUserUsecase_SignUp_StrictOrderAndCount(t *testing.T) {
 			usecase := NewUserUsecase(
 				mockUserRepo,
 				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
 				mockTransactionManager,
 				mockCryptoService,
 				mockHasher,
@@ -455,6 +462,8 @@ func TestUserUsecase_VerifyEmail(t *testing.T) {
 
 			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
 			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
 			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
 			mockHasher := userDomain.NewMockIHasher(ctrl)
 			mockEmailSender := NewMockiEmailSender(ctrl)
@@ -464,6 +473,8 @@ func TestUserUsecase_VerifyEmail(t *testing.T) {
 			usecase := NewUserUsecase(
 				mockUserRepo,
 				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
 				mockTransactionManager,
 				mockCryptoService,
 				mockHasher,
@@ -625,6 +636,8 @@ func TestUserUsecase_LogIn(t *testing.T) {
 
 			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
 			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
 			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
 			mockHasher := userDomain.NewMockIHasher(ctrl)
 			mockEmailSender := NewMockiEmailSender(ctrl)
@@ -634,6 +647,8 @@ func TestUserUsecase_LogIn(t *testing.T) {
 			usecase := NewUserUsecase(
 				mockUserRepo,
 				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
 				mockTransactionManager,
 				mockCryptoService,
 				mockHasher,
@@ -676,6 +691,9 @@ func TestUserUsecase_GetUserSetting(t *testing.T) {
 					"Asia/Tokyo",
 					"dark",
 					"ja",
+					0,
+					[]int{},
+					"slide_all",
 					nil,
 				)
 
@@ -718,6 +736,8 @@ func TestUserUsecase_GetUserSetting(t *testing.T) {
 
 			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
 			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
 			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
 			mockHasher := userDomain.NewMockIHasher(ctrl)
 			mockEmailSender := NewMockiEmailSender(ctrl)
@@ -727,6 +747,8 @@ func TestUserUsecase_GetUserSetting(t *testing.T) {
 			usecase := NewUserUsecase(
 				mockUserRepo,
 				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
 				mockTransactionManager,
 				mockCryptoService,
 				mockHasher,
@@ -777,6 +799,9 @@ func TestUserUsecase_UpdateSetting(t *testing.T) {
 					"Asia/Tokyo",
 					"dark",
 					"ja",
+					0,
+					[]int{},
+					"slide_all",
 					nil,
 				)
 
@@ -812,6 +837,168 @@ func TestUserUsecase_UpdateSetting(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "1日の復習数の上限が負の値",
+			dto: UpdateUserInput{
+				ID:               testID,
+				Email:            testEmail,
+				Timezone:         "Asia/Tokyo",
+				ThemeColor:       "light",
+				Language:         "en",
+				DailyReviewLimit: -1,
+			},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockEmailVerificationRepo *userDomain.MockEmailVerificationRepository, mockTransactionManager *transaction.MockITransactionManager, mockHasher *userDomain.MockIHasher, mockEmailSender *MockiEmailSender, mockTokenGenerator *MockiTokenGenerator, mockCryptoService *userDomain.CryptoService) {
+				encryptedEmail, _ := mockCryptoService.Encrypt("old@example.com")
+				user, _ := userDomain.ReconstructUserForSettings(
+					testID,
+					encryptedEmail,
+					"Asia/Tokyo",
+					"dark",
+					"ja",
+					0,
+					[]int{},
+					"slide_all",
+					nil,
+				)
+
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(user, nil).
+						Times(1),
+
+					mockHasher.EXPECT().
+						GenerateSearchKey(testEmail).
+						Return(testSearchKey).
+						Times(1),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "期限切れの復習日の扱いを変更する",
+			dto: UpdateUserInput{
+				ID:            testID,
+				Email:         testEmail,
+				Timezone:      "Asia/Tokyo",
+				ThemeColor:    "light",
+				Language:      "en",
+				OverduePolicy: userDomain.OverduePolicyKeepOverdue,
+			},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockEmailVerificationRepo *userDomain.MockEmailVerificationRepository, mockTransactionManager *transaction.MockITransactionManager, mockHasher *userDomain.MockIHasher, mockEmailSender *MockiEmailSender, mockTokenGenerator *MockiTokenGenerator, mockCryptoService *userDomain.CryptoService) {
+				encryptedEmail, _ := mockCryptoService.Encrypt("old@example.com")
+				user, _ := userDomain.ReconstructUserForSettings(
+					testID,
+					encryptedEmail,
+					"Asia/Tokyo",
+					"dark",
+					"ja",
+					0,
+					[]int{},
+					"slide_all",
+					nil,
+				)
+
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(user, nil).
+						Times(1),
+
+					mockHasher.EXPECT().
+						GenerateSearchKey(testEmail).
+						Return(testSearchKey).
+						Times(1),
+
+					mockUserRepo.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, u *userDomain.User) error {
+							if u.OverduePolicy() != userDomain.OverduePolicyKeepOverdue {
+								return errors.New("期限切れの復習日の扱いが更新されていません")
+							}
+							return nil
+						}).
+						Times(1),
+				)
+			},
+			wantErr: false,
+		},
+		{
+			name: "期限切れの復習日の扱いが不正",
+			dto: UpdateUserInput{
+				ID:            testID,
+				Email:         testEmail,
+				Timezone:      "Asia/Tokyo",
+				ThemeColor:    "light",
+				Language:      "en",
+				OverduePolicy: "invalid",
+			},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockEmailVerificationRepo *userDomain.MockEmailVerificationRepository, mockTransactionManager *transaction.MockITransactionManager, mockHasher *userDomain.MockIHasher, mockEmailSender *MockiEmailSender, mockTokenGenerator *MockiTokenGenerator, mockCryptoService *userDomain.CryptoService) {
+				encryptedEmail, _ := mockCryptoService.Encrypt("old@example.com")
+				user, _ := userDomain.ReconstructUserForSettings(
+					testID,
+					encryptedEmail,
+					"Asia/Tokyo",
+					"dark",
+					"ja",
+					0,
+					[]int{},
+					"slide_all",
+					nil,
+				)
+
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(user, nil).
+						Times(1),
+
+					mockHasher.EXPECT().
+						GenerateSearchKey(testEmail).
+						Return(testSearchKey).
+						Times(1),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "全ての曜日が除外されている",
+			dto: UpdateUserInput{
+				ID:               testID,
+				Email:            testEmail,
+				Timezone:         "Asia/Tokyo",
+				ThemeColor:       "light",
+				Language:         "en",
+				ExcludedWeekdays: []int{0, 1, 2, 3, 4, 5, 6},
+			},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockEmailVerificationRepo *userDomain.MockEmailVerificationRepository, mockTransactionManager *transaction.MockITransactionManager, mockHasher *userDomain.MockIHasher, mockEmailSender *MockiEmailSender, mockTokenGenerator *MockiTokenGenerator, mockCryptoService *userDomain.CryptoService) {
+				encryptedEmail, _ := mockCryptoService.Encrypt("old@example.com")
+				user, _ := userDomain.ReconstructUserForSettings(
+					testID,
+					encryptedEmail,
+					"Asia/Tokyo",
+					"dark",
+					"ja",
+					0,
+					[]int{},
+					"slide_all",
+					nil,
+				)
+
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(user, nil).
+						Times(1),
+
+					mockHasher.EXPECT().
+						GenerateSearchKey(testEmail).
+						Return(testSearchKey).
+						Times(1),
+				)
+			},
+			wantErr: true,
+		},
 		{
 			name: "更新処理失敗",
 			dto:  dto,
@@ -823,6 +1010,9 @@ func TestUserUsecase_UpdateSetting(t *testing.T) {
 					"Asia/Tokyo",
 					"dark",
 					"ja",
+					0,
+					[]int{},
+					"slide_all",
 					nil,
 				)
 
@@ -856,6 +1046,8 @@ func TestUserUsecase_UpdateSetting(t *testing.T) {
 
 			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
 			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
 			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
 			mockHasher := userDomain.NewMockIHasher(ctrl)
 			mockEmailSender := NewMockiEmailSender(ctrl)
@@ -865,6 +1057,8 @@ func TestUserUsecase_UpdateSetting(t *testing.T) {
 			usecase := NewUserUsecase(
 				mockUserRepo,
 				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
 				mockTransactionManager,
 				mockCryptoService,
 				mockHasher,
@@ -907,6 +1101,9 @@ func TestUserUsecase_UpdatePassword(t *testing.T) {
 					"Asia/Tokyo",
 					"dark",
 					"ja",
+					0,
+					[]int{},
+					"slide_all",
 					nil)
 				gomock.InOrder(
 					mockUserRepo.EXPECT().
@@ -947,6 +1144,9 @@ func TestUserUsecase_UpdatePassword(t *testing.T) {
 					"Asia/Tokyo",
 					"dark",
 					"ja",
+					0,
+					[]int{},
+					"slide_all",
 					nil)
 				gomock.InOrder(
 					mockUserRepo.EXPECT().
@@ -973,6 +1173,8 @@ func TestUserUsecase_UpdatePassword(t *testing.T) {
 
 			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
 			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
 			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
 			mockHasher := userDomain.NewMockIHasher(ctrl)
 			mockEmailSender := NewMockiEmailSender(ctrl)
@@ -982,6 +1184,8 @@ func TestUserUsecase_UpdatePassword(t *testing.T) {
 			usecase := NewUserUsecase(
 				mockUserRepo,
 				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
 				mockTransactionManager,
 				mockCryptoService,
 				mockHasher,
@@ -997,3 +1201,696 @@ func TestUserUsecase_UpdatePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestUserUsecase_CreatePause(t *testing.T) {
+	testID := "550e8400-e29b-41d4-a716-446655440001"
+	// 開始日が今日以降かの判定は実行時の日付に依存するため、今日から十分離れた日付を使う
+	startDate := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	endDate := time.Now().AddDate(0, 0, 13).Format("2006-01-02")
+	pastDate := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+
+	settingUser, _ := userDomain.ReconstructUserForSettings(
+		testID,
+		"encrypted_email",
+		"Asia/Tokyo",
+		"dark",
+		"ja",
+		0,
+		[]int{},
+		"slide_all",
+		nil,
+	)
+
+	tests := []struct {
+		name      string
+		input     CreatePauseInput
+		mockFunc  func(*userDomain.MockUserRepository, *userDomain.MockPauseRepository)
+		want      *CreatePauseOutput
+		wantErr   bool
+		wantErrIs error
+	}{
+		{
+			name:  "休止期間の登録成功",
+			input: CreatePauseInput{UserID: testID, StartDate: startDate, EndDate: endDate},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockPauseRepo *userDomain.MockPauseRepository) {
+				parsedStartDate, _ := time.Parse("2006-01-02", startDate)
+				parsedEndDate, _ := time.Parse("2006-01-02", endDate)
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockPauseRepo.EXPECT().
+						HasOverlappingPause(gomock.Any(), testID, parsedStartDate, parsedEndDate).
+						Return(false, nil).
+						Times(1),
+					mockPauseRepo.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(1),
+				)
+			},
+			want:    &CreatePauseOutput{StartDate: startDate, EndDate: endDate},
+			wantErr: false,
+		},
+		{
+			name:  "日付の形式が不正",
+			input: CreatePauseInput{UserID: testID, StartDate: "2024/01/01", EndDate: endDate},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockPauseRepo *userDomain.MockPauseRepository) {
+			},
+			wantErr: true,
+		},
+		{
+			name:  "開始日が今日より前",
+			input: CreatePauseInput{UserID: testID, StartDate: pastDate, EndDate: endDate},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockPauseRepo *userDomain.MockPauseRepository) {
+				mockUserRepo.EXPECT().
+					GetSettingByID(gomock.Any(), testID).
+					Return(settingUser, nil).
+					Times(1)
+			},
+			wantErr:   true,
+			wantErrIs: userDomain.ErrPauseStartDateBeforeToday,
+		},
+		{
+			name:  "既存の休止期間と重なる",
+			input: CreatePauseInput{UserID: testID, StartDate: startDate, EndDate: endDate},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockPauseRepo *userDomain.MockPauseRepository) {
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockPauseRepo.EXPECT().
+						HasOverlappingPause(gomock.Any(), testID, gomock.Any(), gomock.Any()).
+						Return(true, nil).
+						Times(1),
+				)
+			},
+			wantErr:   true,
+			wantErrIs: userDomain.ErrPauseOverlapped,
+		},
+		{
+			name:  "ユーザーが見つからない",
+			input: CreatePauseInput{UserID: testID, StartDate: startDate, EndDate: endDate},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockPauseRepo *userDomain.MockPauseRepository) {
+				mockUserRepo.EXPECT().
+					GetSettingByID(gomock.Any(), testID).
+					Return(nil, errors.New("not found")).
+					Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name:  "登録処理失敗",
+			input: CreatePauseInput{UserID: testID, StartDate: startDate, EndDate: endDate},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockPauseRepo *userDomain.MockPauseRepository) {
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockPauseRepo.EXPECT().
+						HasOverlappingPause(gomock.Any(), testID, gomock.Any(), gomock.Any()).
+						Return(false, nil).
+						Times(1),
+					mockPauseRepo.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(errors.New("create failed")).
+						Times(1),
+				)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
+			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
+			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
+			mockHasher := userDomain.NewMockIHasher(ctrl)
+			mockEmailSender := NewMockiEmailSender(ctrl)
+			mockTokenGenerator := NewMockiTokenGenerator(ctrl)
+			mockCryptoService, _ := userDomain.NewCryptoService("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+
+			usecase := NewUserUsecase(
+				mockUserRepo,
+				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
+				mockTransactionManager,
+				mockCryptoService,
+				mockHasher,
+				mockEmailSender,
+				mockTokenGenerator,
+			)
+
+			tt.mockFunc(mockUserRepo, mockPauseRepo)
+			result, err := usecase.CreatePause(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreatePause() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("CreatePause() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.ID == "" {
+				t.Error("CreatePause() result.ID should not be empty")
+			}
+			if result.StartDate != tt.want.StartDate || result.EndDate != tt.want.EndDate {
+				t.Errorf("CreatePause() = %v, %v, want %v, %v", result.StartDate, result.EndDate, tt.want.StartDate, tt.want.EndDate)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_CreateBlockedDates(t *testing.T) {
+	testID := "550e8400-e29b-41d4-a716-446655440001"
+	// 日付が今日以降かの判定は実行時の日付に依存するため、今日から十分離れた日付を使う
+	futureDate := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	otherFutureDate := time.Now().AddDate(0, 0, 8).Format("2006-01-02")
+	pastDate := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+	parsedFutureDate, _ := time.Parse("2006-01-02", futureDate)
+	parsedOtherFutureDate, _ := time.Parse("2006-01-02", otherFutureDate)
+
+	settingUser, _ := userDomain.ReconstructUserForSettings(
+		testID,
+		"encrypted_email",
+		"Asia/Tokyo",
+		"dark",
+		"ja",
+		0,
+		[]int{},
+		"slide_all",
+		nil,
+	)
+	existing, _ := userDomain.ReconstructBlockedDate("blocked-date-id", testID, parsedFutureDate, "祝日")
+	errMove := errors.New("move failed")
+
+	tests := []struct {
+		name             string
+		input            CreateBlockedDatesInput
+		mockFunc         func(*userDomain.MockUserRepository, *userDomain.MockBlockedDateRepository, *transaction.MockITransactionManager)
+		wantDates        []string
+		wantSkippedCount int
+		wantErr          bool
+		wantErrIs        error
+	}{
+		{
+			name: "登録済みの日付を除いて登録する",
+			input: CreateBlockedDatesInput{UserID: testID, Dates: []BlockedDateInput{
+				{Date: futureDate, Name: "祝日"},
+				{Date: otherFutureDate, Name: "旅行"},
+			}},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						GetByUserID(gomock.Any(), testID).
+						Return([]*userDomain.BlockedDate{existing}, nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(1),
+					// 登録済みだった日付の復習日は登録時にずらしているため、新しく登録した日付だけをずらす
+					mockBlockedDateRepo.EXPECT().
+						MoveReviewDatesOffBlockedDates(gomock.Any(), testID, []time.Time{parsedOtherFutureDate}).
+						Return(int64(0), nil).
+						Times(1),
+				)
+			},
+			wantDates:        []string{otherFutureDate},
+			wantSkippedCount: 1,
+			wantErr:          false,
+		},
+		{
+			name: "登録した日付にある既存の復習日を同じトランザクションでずらす",
+			input: CreateBlockedDatesInput{UserID: testID, Dates: []BlockedDateInput{
+				{Date: futureDate, Name: "祝日"},
+				{Date: otherFutureDate, Name: "旅行"},
+			}},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						GetByUserID(gomock.Any(), testID).
+						Return([]*userDomain.BlockedDate{}, nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(2),
+					mockBlockedDateRepo.EXPECT().
+						MoveReviewDatesOffBlockedDates(gomock.Any(), testID, []time.Time{parsedFutureDate, parsedOtherFutureDate}).
+						Return(int64(3), nil).
+						Times(1),
+				)
+			},
+			wantDates:        []string{futureDate, otherFutureDate},
+			wantSkippedCount: 0,
+			wantErr:          false,
+		},
+		{
+			name:  "既存の復習日をずらせない場合は登録しない",
+			input: CreateBlockedDatesInput{UserID: testID, Dates: []BlockedDateInput{{Date: otherFutureDate, Name: "旅行"}}},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						GetByUserID(gomock.Any(), testID).
+						Return([]*userDomain.BlockedDate{}, nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						MoveReviewDatesOffBlockedDates(gomock.Any(), testID, []time.Time{parsedOtherFutureDate}).
+						Return(int64(0), errMove).
+						Times(1),
+				)
+			},
+			wantErr:   true,
+			wantErrIs: errMove,
+		},
+		{
+			name:  "日付が指定されていない",
+			input: CreateBlockedDatesInput{UserID: testID},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+			},
+			wantErr:   true,
+			wantErrIs: userDomain.ErrBlockedDatesEmpty,
+		},
+		{
+			name:  "日付が今日より前",
+			input: CreateBlockedDatesInput{UserID: testID, Dates: []BlockedDateInput{{Date: pastDate}}},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+				mockUserRepo.EXPECT().
+					GetSettingByID(gomock.Any(), testID).
+					Return(settingUser, nil).
+					Times(1)
+			},
+			wantErr:   true,
+			wantErrIs: userDomain.ErrBlockedDateBeforeToday,
+		},
+		{
+			name:  "日付の形式が不正",
+			input: CreateBlockedDatesInput{UserID: testID, Dates: []BlockedDateInput{{Date: "2024/01/01"}}},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+				mockUserRepo.EXPECT().
+					GetSettingByID(gomock.Any(), testID).
+					Return(settingUser, nil).
+					Times(1)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
+			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
+			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
+			mockHasher := userDomain.NewMockIHasher(ctrl)
+			mockEmailSender := NewMockiEmailSender(ctrl)
+			mockTokenGenerator := NewMockiTokenGenerator(ctrl)
+			mockCryptoService, _ := userDomain.NewCryptoService("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+
+			usecase := NewUserUsecase(
+				mockUserRepo,
+				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
+				mockTransactionManager,
+				mockCryptoService,
+				mockHasher,
+				mockEmailSender,
+				mockTokenGenerator,
+			)
+
+			tt.mockFunc(mockUserRepo, mockBlockedDateRepo, mockTransactionManager)
+			result, err := usecase.CreateBlockedDates(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateBlockedDates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("CreateBlockedDates() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotDates := make([]string, len(result.BlockedDates))
+			for i, b := range result.BlockedDates {
+				gotDates[i] = b.Date
+			}
+			if diff := cmp.Diff(tt.wantDates, gotDates); diff != "" {
+				t.Errorf("CreateBlockedDates() dates mismatch (-want +got):\n%s", diff)
+			}
+			if result.SkippedCount != tt.wantSkippedCount {
+				t.Errorf("CreateBlockedDates() SkippedCount = %v, want %v", result.SkippedCount, tt.wantSkippedCount)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_ImportBlockedDatesFromICS(t *testing.T) {
+	testID := "550e8400-e29b-41d4-a716-446655440001"
+	future := time.Now().AddDate(0, 0, 7)
+	past := time.Now().AddDate(0, 0, -7)
+	parsedFuture, _ := time.Parse("2006-01-02", future.Format("2006-01-02"))
+
+	settingUser, _ := userDomain.ReconstructUserForSettings(
+		testID,
+		"encrypted_email",
+		"Asia/Tokyo",
+		"dark",
+		"ja",
+		0,
+		[]int{},
+		"slide_all",
+		nil,
+	)
+
+	validICS := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:" + past.Format("20060102") + "\r\n" +
+		"SUMMARY:過去の祝日\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:" + future.Format("20060102") + "\r\n" +
+		"SUMMARY:祝日\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	tests := []struct {
+		name             string
+		ics              string
+		mockFunc         func(*userDomain.MockUserRepository, *userDomain.MockBlockedDateRepository, *transaction.MockITransactionManager)
+		want             []*BlockedDateOutput
+		wantSkippedCount int
+		wantErrIs        error
+	}{
+		{
+			name: "今日より前の日付を除いて登録する",
+			ics:  validICS,
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						GetByUserID(gomock.Any(), testID).
+						Return([]*userDomain.BlockedDate{}, nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						Create(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						MoveReviewDatesOffBlockedDates(gomock.Any(), testID, []time.Time{parsedFuture}).
+						Return(int64(1), nil).
+						Times(1),
+				)
+			},
+			want:             []*BlockedDateOutput{{Date: future.Format("2006-01-02"), Name: "祝日"}},
+			wantSkippedCount: 1,
+		},
+		{
+			name: "iCalendarファイルの形式が正しくない",
+			ics:  "not an ics file",
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+			},
+			wantErrIs: userDomain.ErrInvalidICS,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
+			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
+			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
+			mockHasher := userDomain.NewMockIHasher(ctrl)
+			mockEmailSender := NewMockiEmailSender(ctrl)
+			mockTokenGenerator := NewMockiTokenGenerator(ctrl)
+			mockCryptoService, _ := userDomain.NewCryptoService("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+
+			usecase := NewUserUsecase(
+				mockUserRepo,
+				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
+				mockTransactionManager,
+				mockCryptoService,
+				mockHasher,
+				mockEmailSender,
+				mockTokenGenerator,
+			)
+
+			tt.mockFunc(mockUserRepo, mockBlockedDateRepo, mockTransactionManager)
+			result, err := usecase.ImportBlockedDatesFromICS(context.Background(), ImportBlockedDatesInput{
+				UserID: testID,
+				File:   strings.NewReader(tt.ics),
+			})
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("ImportBlockedDatesFromICS() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportBlockedDatesFromICS() unexpected error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, result.BlockedDates, cmpopts.IgnoreFields(BlockedDateOutput{}, "ID")); diff != "" {
+				t.Errorf("ImportBlockedDatesFromICS() mismatch (-want +got):\n%s", diff)
+			}
+			if result.SkippedCount != tt.wantSkippedCount {
+				t.Errorf("ImportBlockedDatesFromICS() SkippedCount = %v, want %v", result.SkippedCount, tt.wantSkippedCount)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_UpdateBlockedDate(t *testing.T) {
+	testID := "550e8400-e29b-41d4-a716-446655440001"
+	blockedDateID := "c60e8400-e29b-41d4-a716-446655440001"
+	futureDate := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	otherFutureDate := time.Now().AddDate(0, 0, 8).Format("2006-01-02")
+	parsedFutureDate, _ := time.Parse("2006-01-02", futureDate)
+	parsedOtherFutureDate, _ := time.Parse("2006-01-02", otherFutureDate)
+
+	settingUser, _ := userDomain.ReconstructUserForSettings(
+		testID,
+		"encrypted_email",
+		"Asia/Tokyo",
+		"dark",
+		"ja",
+		0,
+		[]int{},
+		"slide_all",
+		nil,
+	)
+
+	tests := []struct {
+		name      string
+		input     UpdateBlockedDateInput
+		mockFunc  func(*userDomain.MockUserRepository, *userDomain.MockBlockedDateRepository, *transaction.MockITransactionManager)
+		want      *BlockedDateOutput
+		wantErrIs error
+	}{
+		{
+			name:  "復習日を置かない日付の更新成功",
+			input: UpdateBlockedDateInput{ID: blockedDateID, UserID: testID, Date: otherFutureDate, Name: "旅行"},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+				target, _ := userDomain.ReconstructBlockedDate(blockedDateID, testID, parsedFutureDate, "")
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						GetByID(gomock.Any(), blockedDateID, testID).
+						Return(target, nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						GetByUserID(gomock.Any(), testID).
+						Return([]*userDomain.BlockedDate{target}, nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						Update(gomock.Any(), gomock.Any()).
+						Return(nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						MoveReviewDatesOffBlockedDates(gomock.Any(), testID, []time.Time{parsedOtherFutureDate}).
+						Return(int64(0), nil).
+						Times(1),
+				)
+			},
+			want: &BlockedDateOutput{ID: blockedDateID, Date: otherFutureDate, Name: "旅行"},
+		},
+		{
+			name:  "他の登録済みの日付と重なる",
+			input: UpdateBlockedDateInput{ID: blockedDateID, UserID: testID, Date: otherFutureDate},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+				target, _ := userDomain.ReconstructBlockedDate(blockedDateID, testID, parsedFutureDate, "")
+				other, _ := userDomain.ReconstructBlockedDate("other-id", testID, parsedOtherFutureDate, "")
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						GetByID(gomock.Any(), blockedDateID, testID).
+						Return(target, nil).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						GetByUserID(gomock.Any(), testID).
+						Return([]*userDomain.BlockedDate{target, other}, nil).
+						Times(1),
+				)
+			},
+			wantErrIs: userDomain.ErrBlockedDateDuplicated,
+		},
+		{
+			name:  "復習日を置かない日付が見つからない",
+			input: UpdateBlockedDateInput{ID: blockedDateID, UserID: testID, Date: otherFutureDate},
+			mockFunc: func(mockUserRepo *userDomain.MockUserRepository, mockBlockedDateRepo *userDomain.MockBlockedDateRepository, mockTransactionManager *transaction.MockITransactionManager) {
+				gomock.InOrder(
+					mockUserRepo.EXPECT().
+						GetSettingByID(gomock.Any(), testID).
+						Return(settingUser, nil).
+						Times(1),
+					mockTransactionManager.EXPECT().
+						RunInTransaction(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+							return fn(ctx)
+						}).
+						Times(1),
+					mockBlockedDateRepo.EXPECT().
+						GetByID(gomock.Any(), blockedDateID, testID).
+						Return(nil, userDomain.ErrBlockedDateNotFound).
+						Times(1),
+				)
+			},
+			wantErrIs: userDomain.ErrBlockedDateNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserRepo := userDomain.NewMockUserRepository(ctrl)
+			mockEmailVerificationRepo := userDomain.NewMockEmailVerificationRepository(ctrl)
+			mockPauseRepo := userDomain.NewMockPauseRepository(ctrl)
+			mockBlockedDateRepo := userDomain.NewMockBlockedDateRepository(ctrl)
+			mockTransactionManager := transaction.NewMockITransactionManager(ctrl)
+			mockHasher := userDomain.NewMockIHasher(ctrl)
+			mockEmailSender := NewMockiEmailSender(ctrl)
+			mockTokenGenerator := NewMockiTokenGenerator(ctrl)
+			mockCryptoService, _ := userDomain.NewCryptoService("0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+
+			usecase := NewUserUsecase(
+				mockUserRepo,
+				mockEmailVerificationRepo,
+				mockPauseRepo,
+				mockBlockedDateRepo,
+				mockTransactionManager,
+				mockCryptoService,
+				mockHasher,
+				mockEmailSender,
+				mockTokenGenerator,
+			)
+
+			tt.mockFunc(mockUserRepo, mockBlockedDateRepo, mockTransactionManager)
+			result, err := usecase.UpdateBlockedDate(context.Background(), tt.input)
+			if tt.wantErrIs != nil {
+				if !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("UpdateBlockedDate() error = %v, want %v", err, tt.wantErrIs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateBlockedDate() unexpected error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, result); diff != "" {
+				t.Errorf("UpdateBlockedDate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}